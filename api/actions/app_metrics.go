@@ -0,0 +1,149 @@
+package actions
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type (
+	Gauge struct {
+		Unit  string
+		Value float64
+	}
+
+	// InstanceMetrics are the usage gauges of an app instance, named after the
+	// gauges of the CF container metrics (cpu, memory, disk, memory_quota and
+	// disk_quota)
+	InstanceMetrics struct {
+		AppGUID     string
+		ProcessGUID string
+		ProcessType string
+		InstanceID  string
+		Timestamp   time.Time
+		Gauges      map[string]Gauge
+	}
+
+	AppMetrics struct {
+		appRepo     shared.CFAppRepository
+		processRepo shared.CFProcessRepository
+		metricsRepo MetricsRepository
+	}
+)
+
+// Labels returns the log-cache labels (or envelope tags) of the instance
+func (m InstanceMetrics) Labels() map[string]string {
+	return map[string]string{
+		"source_id":    m.AppGUID,
+		"instance_id":  m.InstanceID,
+		"process_id":   m.ProcessGUID,
+		"process_type": m.ProcessType,
+	}
+}
+
+func NewAppMetrics(appRepo shared.CFAppRepository, processRepo shared.CFProcessRepository, metricsRepo MetricsRepository) *AppMetrics {
+	return &AppMetrics{
+		appRepo:     appRepo,
+		processRepo: processRepo,
+		metricsRepo: metricsRepo,
+	}
+}
+
+// FetchMetrics returns the current usage of all the running instances of the
+// app. Instances without metrics (e.g. ones that are still starting) are
+// omitted.
+func (a *AppMetrics) FetchMetrics(ctx context.Context, authInfo authorization.Info, appGUID string) ([]InstanceMetrics, error) {
+	appRecord, err := a.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if appRecord.State == repositories.StoppedState {
+		return []InstanceMetrics{}, nil
+	}
+
+	processes, err := a.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
+		AppGUIDs:  []string{appGUID},
+		SpaceGUID: appRecord.SpaceGUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	processesByGUID := map[string]repositories.ProcessRecord{}
+	for _, process := range processes {
+		processesByGUID[process.GUID] = process
+	}
+
+	metrics, err := a.metricsRepo.GetMetrics(ctx, authInfo, appRecord.SpaceGUID, client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: appRecord.GUID,
+		korifiv1alpha1.VersionLabelKey:   appRecord.Revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := []InstanceMetrics{}
+	for _, m := range metrics {
+		process, ok := processesByGUID[m.Pod.Labels[LabelGUID]]
+		if !ok {
+			continue
+		}
+
+		index, err := extractIndex(m.Pod)
+		if err != nil {
+			return nil, err
+		}
+
+		metricsMap := aggregateContainerMetrics(m.Metrics.Containers)
+		if len(metricsMap) == 0 {
+			continue
+		}
+
+		gauges := map[string]Gauge{
+			"memory_quota": {Unit: "bytes", Value: float64(megabytesToBytes(process.MemoryMB))},
+			"disk_quota":   {Unit: "bytes", Value: float64(megabytesToBytes(process.DiskQuotaMB))},
+		}
+
+		if cpuQuantity, ok := metricsMap["cpu"]; ok {
+			// the CF cpu gauge is the percentage of a core being used
+			gauges["cpu"] = Gauge{Unit: "percentage", Value: float64(cpuQuantity.ScaledValue(resource.Nano)) / 1e7}
+		}
+
+		if memQuantity, ok := metricsMap["memory"]; ok {
+			gauges["memory"] = Gauge{Unit: "bytes", Value: float64(memQuantity.Value())}
+		}
+
+		if storageQuantity, ok := metricsMap["storage"]; ok {
+			gauges["disk"] = Gauge{Unit: "bytes", Value: float64(storageQuantity.Value())}
+		}
+
+		result = append(result, InstanceMetrics{
+			AppGUID:     appRecord.GUID,
+			ProcessGUID: process.GUID,
+			ProcessType: process.Type,
+			InstanceID:  strconv.Itoa(index),
+			Timestamp:   m.Metrics.Timestamp.Time,
+			Gauges:      gauges,
+		})
+	}
+
+	slices.SortFunc(result, func(m1, m2 InstanceMetrics) int {
+		return cmp.Or(
+			cmp.Compare(m1.ProcessType, m2.ProcessType),
+			cmp.Compare(len(m1.InstanceID), len(m2.InstanceID)),
+			cmp.Compare(m1.InstanceID, m2.InstanceID),
+		)
+	})
+
+	return result, nil
+}
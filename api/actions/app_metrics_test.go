@@ -0,0 +1,225 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	sfake "code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+var _ = Describe("AppMetrics", func() {
+	var (
+		processRepo *sfake.CFProcessRepository
+		metricsRepo *fake.MetricsRepository
+		appRepo     *sfake.CFAppRepository
+		authInfo    authorization.Info
+
+		appMetrics *AppMetrics
+
+		instanceMetrics []InstanceMetrics
+		fetchErr        error
+
+		podMetrics []repositories.PodMetrics
+	)
+
+	BeforeEach(func() {
+		processRepo = new(sfake.CFProcessRepository)
+		metricsRepo = new(fake.MetricsRepository)
+		appRepo = new(sfake.CFAppRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "the-app-guid",
+			SpaceGUID: "the-space-guid",
+			State:     "STARTED",
+			Revision:  "1",
+		}, nil)
+
+		processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+			{
+				GUID:        "web-guid",
+				Type:        "web",
+				MemoryMB:    1024,
+				DiskQuotaMB: 2048,
+			},
+			{
+				GUID:        "worker-guid",
+				Type:        "worker",
+				MemoryMB:    512,
+				DiskQuotaMB: 1024,
+			},
+		}, nil)
+
+		podMetrics = []repositories.PodMetrics{
+			{
+				Pod:     createPod("1", "1"),
+				Metrics: createPodMetrics("250m", "456", "890"),
+			},
+			{
+				Pod:     createPod("0", "1"),
+				Metrics: createPodMetrics("123m", "457", "891"),
+			},
+			{
+				Pod:     createPod("0", "1"),
+				Metrics: createPodMetrics("124m", "458", "892"),
+			},
+		}
+		podMetrics[0].Pod.Labels[LabelGUID] = "web-guid"
+		podMetrics[1].Pod.Labels[LabelGUID] = "web-guid"
+		podMetrics[2].Pod.Labels[LabelGUID] = "worker-guid"
+		metricsRepo.GetMetricsReturns(podMetrics, nil)
+
+		appMetrics = NewAppMetrics(appRepo, processRepo, metricsRepo)
+	})
+
+	JustBeforeEach(func() {
+		instanceMetrics, fetchErr = appMetrics.FetchMetrics(context.Background(), authInfo, "the-app-guid")
+	})
+
+	It("fetches the metrics of the app pods", func() {
+		Expect(fetchErr).NotTo(HaveOccurred())
+
+		Expect(appRepo.GetAppCallCount()).To(Equal(1))
+		_, actualAuthInfo, appGUID := appRepo.GetAppArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(appGUID).To(Equal("the-app-guid"))
+
+		Expect(processRepo.ListProcessesCallCount()).To(Equal(1))
+		_, actualAuthInfo, listMessage := processRepo.ListProcessesArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(listMessage).To(Equal(repositories.ListProcessesMessage{
+			AppGUIDs:  []string{"the-app-guid"},
+			SpaceGUID: "the-space-guid",
+		}))
+
+		Expect(metricsRepo.GetMetricsCallCount()).To(Equal(1))
+		_, actualAuthInfo, spaceGUID, labelMatcher := metricsRepo.GetMetricsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(spaceGUID).To(Equal("the-space-guid"))
+		Expect(labelMatcher).To(Equal(client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey: "the-app-guid",
+			korifiv1alpha1.VersionLabelKey:   "1",
+		}))
+	})
+
+	It("returns the instance gauges ordered by process type and index", func() {
+		Expect(instanceMetrics).To(HaveLen(3))
+
+		Expect(instanceMetrics[0].AppGUID).To(Equal("the-app-guid"))
+		Expect(instanceMetrics[0].ProcessGUID).To(Equal("web-guid"))
+		Expect(instanceMetrics[0].ProcessType).To(Equal("web"))
+		Expect(instanceMetrics[0].InstanceID).To(Equal("0"))
+		Expect(instanceMetrics[0].Timestamp).To(Equal(podMetrics[1].Metrics.Timestamp.Time))
+		Expect(instanceMetrics[0].Gauges).To(Equal(map[string]Gauge{
+			"cpu":          {Unit: "percentage", Value: 12.3},
+			"memory":       {Unit: "bytes", Value: 457},
+			"disk":         {Unit: "bytes", Value: 891},
+			"memory_quota": {Unit: "bytes", Value: 1024 * 1024 * 1024},
+			"disk_quota":   {Unit: "bytes", Value: 2048 * 1024 * 1024},
+		}))
+
+		Expect(instanceMetrics[1].ProcessType).To(Equal("web"))
+		Expect(instanceMetrics[1].InstanceID).To(Equal("1"))
+		Expect(instanceMetrics[1].Gauges).To(HaveKeyWithValue("cpu", Gauge{Unit: "percentage", Value: 25}))
+
+		Expect(instanceMetrics[2].ProcessGUID).To(Equal("worker-guid"))
+		Expect(instanceMetrics[2].ProcessType).To(Equal("worker"))
+		Expect(instanceMetrics[2].InstanceID).To(Equal("0"))
+		Expect(instanceMetrics[2].Gauges).To(HaveKeyWithValue("memory_quota", Gauge{Unit: "bytes", Value: 512 * 1024 * 1024}))
+	})
+
+	It("labels the instances with their app and process", func() {
+		Expect(instanceMetrics[2].Labels()).To(Equal(map[string]string{
+			"source_id":    "the-app-guid",
+			"instance_id":  "0",
+			"process_id":   "worker-guid",
+			"process_type": "worker",
+		}))
+	})
+
+	When("a pod has no metrics yet", func() {
+		BeforeEach(func() {
+			podMetrics[0].Metrics = metricsv1beta1.PodMetrics{}
+		})
+
+		It("omits the instance", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(instanceMetrics).To(HaveLen(2))
+		})
+	})
+
+	When("a pod does not belong to any of the app processes", func() {
+		BeforeEach(func() {
+			podMetrics[2].Pod.Labels[LabelGUID] = "another-process-guid"
+		})
+
+		It("omits the instance", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(instanceMetrics).To(HaveLen(2))
+		})
+	})
+
+	When("the app is stopped", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				State: repositories.StoppedState,
+			}, nil)
+		})
+
+		It("returns no metrics", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(instanceMetrics).To(BeEmpty())
+			Expect(metricsRepo.GetMetricsCallCount()).To(BeZero())
+		})
+	})
+
+	When("getting the app fails", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(fetchErr).To(MatchError("get-app-err"))
+		})
+	})
+
+	When("listing the processes fails", func() {
+		BeforeEach(func() {
+			processRepo.ListProcessesReturns(nil, errors.New("list-processes-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(fetchErr).To(MatchError("list-processes-err"))
+		})
+	})
+
+	When("getting the metrics fails", func() {
+		BeforeEach(func() {
+			metricsRepo.GetMetricsReturns(nil, errors.New("get-metrics-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(fetchErr).To(MatchError("get-metrics-err"))
+		})
+	})
+
+	When("the pod-index label is not set", func() {
+		BeforeEach(func() {
+			delete(podMetrics[0].Pod.Labels, korifiv1alpha1.PodIndexLabelKey)
+		})
+
+		It("returns an error", func() {
+			Expect(fetchErr).To(MatchError(ContainSubstring("label not found")))
+		})
+	})
+})
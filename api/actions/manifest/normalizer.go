@@ -83,7 +83,8 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 	}
 
 	if appInfo.Memory != nil || appInfo.DiskQuota != nil || appInfo.Instances != nil || appInfo.Command != nil ||
-		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil {
+		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil ||
+		appInfo.LogRateLimitPerSecond != nil {
 
 		webProc.Memory = procValIfSet(appInfo.Memory, webProc.Memory)
 		webProc.DiskQuota = procValIfSet(appInfo.DiskQuota, webProc.DiskQuota)
@@ -93,6 +94,7 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 		webProc.HealthCheckType = procValIfSet(appInfo.HealthCheckType, webProc.HealthCheckType)
 		webProc.HealthCheckInvocationTimeout = procValIfSet(appInfo.HealthCheckInvocationTimeout, webProc.HealthCheckInvocationTimeout)
 		webProc.Timeout = procValIfSet(appInfo.Timeout, webProc.Timeout)
+		webProc.LogRateLimitPerSecond = procValIfSet(appInfo.LogRateLimitPerSecond, webProc.LogRateLimitPerSecond)
 	}
 
 	return processes
@@ -19,6 +19,7 @@ type prcParams struct {
 	HealthCheckInvocationTimeout *int32
 	HealthCheckType              *string
 	Timeout                      *int32
+	LogRateLimitPerSecond        *string
 }
 
 type (
@@ -167,6 +168,7 @@ var _ = Describe("Normalizer", func() {
 				appInfo.HealthCheckType = app.HealthCheckType
 				appInfo.HealthCheckInvocationTimeout = app.HealthCheckInvocationTimeout
 				appInfo.Timeout = app.Timeout
+				appInfo.LogRateLimitPerSecond = app.LogRateLimitPerSecond
 
 				if (process != prcParams{}) {
 					appInfo.Processes = append(appInfo.Processes, payloads.ManifestApplicationProcess{
@@ -179,6 +181,7 @@ var _ = Describe("Normalizer", func() {
 						HealthCheckType:              process.HealthCheckType,
 						HealthCheckInvocationTimeout: process.HealthCheckInvocationTimeout,
 						Timeout:                      process.Timeout,
+						LogRateLimitPerSecond:        process.LogRateLimitPerSecond,
 					})
 				}
 
@@ -193,6 +196,7 @@ var _ = Describe("Normalizer", func() {
 				Expect(webProc.HealthCheckType).To(Equal(effective.HealthCheckType))
 				Expect(webProc.HealthCheckInvocationTimeout).To(Equal(effective.HealthCheckInvocationTimeout))
 				Expect(webProc.Timeout).To(Equal(effective.Timeout))
+				Expect(webProc.LogRateLimitPerSecond).To(Equal(effective.LogRateLimitPerSecond))
 			},
 
 			// without an explicit web process in the manifest
@@ -220,6 +224,9 @@ var _ = Describe("Normalizer", func() {
 			Entry("app-level timeout only",
 				appParams{Timeout: tools.PtrTo(int32(12))}, prcParams{},
 				expParams{Timeout: tools.PtrTo(int32(12))}),
+			Entry("app-level log rate limit only",
+				appParams{LogRateLimitPerSecond: tools.PtrTo("16K")}, prcParams{},
+				expParams{LogRateLimitPerSecond: tools.PtrTo("16K")}),
 			Entry("a combination of fields",
 				appParams{Memory: tools.PtrTo("512M"), DiskQuota: tools.PtrTo("2G")}, prcParams{},
 				expParams{Memory: tools.PtrTo("512M"), DiskQuota: tools.PtrTo("2G")}),
@@ -257,6 +264,10 @@ var _ = Describe("Normalizer", func() {
 				appParams{Timeout: tools.PtrTo(int32(32))},
 				prcParams{Instances: tools.PtrTo[int32](3)},
 				expParams{Timeout: tools.PtrTo(int32(32)), Instances: tools.PtrTo[int32](3)}),
+			Entry("empty proc with log rate limit",
+				appParams{LogRateLimitPerSecond: tools.PtrTo("16K")},
+				prcParams{Instances: tools.PtrTo[int32](3)},
+				expParams{LogRateLimitPerSecond: tools.PtrTo("16K"), Instances: tools.PtrTo[int32](3)}),
 
 			// with an existing web process with the given value set
 			Entry("value from proc memory used",
@@ -291,6 +302,10 @@ var _ = Describe("Normalizer", func() {
 				appParams{Timeout: tools.PtrTo(int32(25))},
 				prcParams{Timeout: tools.PtrTo(int32(2))},
 				expParams{Timeout: tools.PtrTo(int32(2))}),
+			Entry("value from proc log rate limit used",
+				appParams{LogRateLimitPerSecond: tools.PtrTo("16K")},
+				prcParams{LogRateLimitPerSecond: tools.PtrTo("1M")},
+				expParams{LogRateLimitPerSecond: tools.PtrTo("1M")}),
 		)
 	})
 
@@ -71,7 +71,7 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, err
 	}
 
-	existingAppRoutes, err := s.collectRoutes(ctx, authInfo, appRecord.GUID, spaceGUID)
+	existingAppRoutes, err := s.collectRoutes(ctx, authInfo, appRecord.GUID)
 	if err != nil {
 		return AppState{}, err
 	}
@@ -106,9 +106,9 @@ func (s StateCollector) collectProcesses(ctx context.Context, authInfo authoriza
 	return existingProcesses, nil
 }
 
-func (s StateCollector) collectRoutes(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) (map[string]repositories.RouteRecord, error) {
+func (s StateCollector) collectRoutes(ctx context.Context, authInfo authorization.Info, appGUID string) (map[string]repositories.RouteRecord, error) {
 	existingAppRoutes := map[string]repositories.RouteRecord{}
-	routes, err := s.routeRepo.ListRoutesForApp(ctx, authInfo, appGUID, spaceGUID)
+	routes, err := s.routeRepo.ListRoutesForApp(ctx, authInfo, appGUID)
 	if err != nil {
 		return nil, err
 	}
@@ -159,9 +159,8 @@ var _ = Describe("StateCollector", func() {
 
 		It("lists the app routes", func() {
 			Expect(routeRepo.ListRoutesForAppCallCount()).To(Equal(1))
-			_, _, appGUID, spaceGUID := routeRepo.ListRoutesForAppArgsForCall(0)
+			_, _, appGUID := routeRepo.ListRoutesForAppArgsForCall(0)
 			Expect(appGUID).To(Equal("app-guid"))
-			Expect(spaceGUID).To(Equal("space-guid"))
 		})
 
 		When("listing the routes fails", func() {
@@ -41,13 +41,12 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
-	ListRoutesForAppStub        func(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
+	ListRoutesForAppStub        func(context.Context, authorization.Info, string) ([]repositories.RouteRecord, error)
 	listRoutesForAppMutex       sync.RWMutex
 	listRoutesForAppArgsForCall []struct {
 		arg1 context.Context
 		arg2 authorization.Info
 		arg3 string
-		arg4 string
 	}
 	listRoutesForAppReturns struct {
 		result1 []repositories.RouteRecord
@@ -208,21 +207,20 @@ func (fake *CFRouteRepository) GetOrCreateRouteReturnsOnCall(i int, result1 repo
 	}{result1, result2}
 }
 
-func (fake *CFRouteRepository) ListRoutesForApp(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) ([]repositories.RouteRecord, error) {
+func (fake *CFRouteRepository) ListRoutesForApp(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]repositories.RouteRecord, error) {
 	fake.listRoutesForAppMutex.Lock()
 	ret, specificReturn := fake.listRoutesForAppReturnsOnCall[len(fake.listRoutesForAppArgsForCall)]
 	fake.listRoutesForAppArgsForCall = append(fake.listRoutesForAppArgsForCall, struct {
 		arg1 context.Context
 		arg2 authorization.Info
 		arg3 string
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
+	}{arg1, arg2, arg3})
 	stub := fake.ListRoutesForAppStub
 	fakeReturns := fake.listRoutesForAppReturns
-	fake.recordInvocation("ListRoutesForApp", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ListRoutesForApp", []interface{}{arg1, arg2, arg3})
 	fake.listRoutesForAppMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -236,17 +234,17 @@ func (fake *CFRouteRepository) ListRoutesForAppCallCount() int {
 	return len(fake.listRoutesForAppArgsForCall)
 }
 
-func (fake *CFRouteRepository) ListRoutesForAppCalls(stub func(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)) {
+func (fake *CFRouteRepository) ListRoutesForAppCalls(stub func(context.Context, authorization.Info, string) ([]repositories.RouteRecord, error)) {
 	fake.listRoutesForAppMutex.Lock()
 	defer fake.listRoutesForAppMutex.Unlock()
 	fake.ListRoutesForAppStub = stub
 }
 
-func (fake *CFRouteRepository) ListRoutesForAppArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+func (fake *CFRouteRepository) ListRoutesForAppArgsForCall(i int) (context.Context, authorization.Info, string) {
 	fake.listRoutesForAppMutex.RLock()
 	defer fake.listRoutesForAppMutex.RUnlock()
 	argsForCall := fake.listRoutesForAppArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *CFRouteRepository) ListRoutesForAppReturns(result1 []repositories.RouteRecord, result2 error) {
@@ -36,7 +36,7 @@ type CFDomainRepository interface {
 
 type CFRouteRepository interface {
 	GetOrCreateRoute(context.Context, authorization.Info, repositories.CreateRouteMessage) (repositories.RouteRecord, error)
-	ListRoutesForApp(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
+	ListRoutesForApp(context.Context, authorization.Info, string) ([]repositories.RouteRecord, error)
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationMessage) (repositories.RouteRecord, error)
 }
@@ -0,0 +1,142 @@
+package authorization
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;list;delete,namespace=ROOT_NAMESPACE
+
+const (
+	OneTimeCodeLabelKey = "korifi.cloudfoundry.org/one-time-code"
+	OneTimeCodeTTL      = 5 * time.Minute
+
+	oneTimeCodeIdentityNameKey = "identity-name"
+	oneTimeCodeIdentityKindKey = "identity-kind"
+)
+
+var ErrInvalidOneTimeCode = errors.New("invalid one-time code")
+
+// OneTimeCodes issues short-lived codes identifying the user they were issued
+// to. A code can only be redeemed once. They allow components that cannot
+// authenticate users with their Kubernetes credentials, such as the SSH proxy,
+// to find out who the user is. Codes are stored as secrets in the root
+// namespace, named after the hash of the code.
+type OneTimeCodes struct {
+	privilegedClient client.Client
+	namespace        string
+	ttl              time.Duration
+}
+
+func NewOneTimeCodes(privilegedClient client.Client, namespace string, ttl time.Duration) *OneTimeCodes {
+	return &OneTimeCodes{
+		privilegedClient: privilegedClient,
+		namespace:        namespace,
+		ttl:              ttl,
+	}
+}
+
+func (c *OneTimeCodes) Issue(ctx context.Context, identity Identity) (string, error) {
+	c.deleteExpiredCodes(ctx)
+
+	codeBytes := make([]byte, 16)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return "", fmt.Errorf("failed to generate one-time code: %w", err)
+	}
+	code := hex.EncodeToString(codeBytes)
+
+	err := c.privilegedClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.namespace,
+			Name:      oneTimeCodeSecretName(code),
+			Labels: map[string]string{
+				OneTimeCodeLabelKey: "true",
+			},
+		},
+		Data: map[string][]byte{
+			oneTimeCodeIdentityNameKey: []byte(identity.Name),
+			oneTimeCodeIdentityKindKey: []byte(identity.Kind),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store one-time code: %w", err)
+	}
+
+	return code, nil
+}
+
+// Redeem returns the identity the code was issued to and invalidates the code.
+// It returns ErrInvalidOneTimeCode if the code does not exist, has already been
+// redeemed or has expired.
+func (c *OneTimeCodes) Redeem(ctx context.Context, code string) (Identity, error) {
+	secret := &corev1.Secret{}
+	err := c.privilegedClient.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: oneTimeCodeSecretName(code)}, secret)
+	if k8serrors.IsNotFound(err) {
+		return Identity{}, ErrInvalidOneTimeCode
+	}
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to get one-time code: %w", err)
+	}
+
+	// deleting the secret makes sure that concurrent attempts to redeem the
+	// code cannot both succeed
+	err = c.privilegedClient.Delete(ctx, secret, client.Preconditions{UID: &secret.UID})
+	if k8serrors.IsNotFound(err) || k8serrors.IsConflict(err) {
+		return Identity{}, ErrInvalidOneTimeCode
+	}
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to delete one-time code: %w", err)
+	}
+
+	if c.isExpired(*secret) {
+		return Identity{}, ErrInvalidOneTimeCode
+	}
+
+	return Identity{
+		Name: string(secret.Data[oneTimeCodeIdentityNameKey]),
+		Kind: string(secret.Data[oneTimeCodeIdentityKindKey]),
+	}, nil
+}
+
+func (c *OneTimeCodes) isExpired(secret corev1.Secret) bool {
+	return time.Since(secret.CreationTimestamp.Time) > c.ttl
+}
+
+// deleteExpiredCodes removes the codes that have not been redeemed in time.
+// Failures are only logged as they do not affect the codes being issued.
+func (c *OneTimeCodes) deleteExpiredCodes(ctx context.Context) {
+	log := logr.FromContextOrDiscard(ctx).WithName("one-time-codes")
+
+	secrets := &corev1.SecretList{}
+	err := c.privilegedClient.List(ctx, secrets, client.InNamespace(c.namespace), client.HasLabels{OneTimeCodeLabelKey})
+	if err != nil {
+		log.Info("failed to list one-time codes", "reason", err)
+		return
+	}
+
+	for i := range secrets.Items {
+		if !c.isExpired(secrets.Items[i]) {
+			continue
+		}
+
+		if err = c.privilegedClient.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete expired one-time code", "reason", err)
+		}
+	}
+}
+
+func oneTimeCodeSecretName(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return "one-time-code-" + hex.EncodeToString(hash[:])
+}
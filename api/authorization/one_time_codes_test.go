@@ -0,0 +1,97 @@
+package authorization_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("OneTimeCodes", func() {
+	var (
+		ctx          context.Context
+		namespace    string
+		ttl          time.Duration
+		oneTimeCodes *authorization.OneTimeCodes
+		identity     authorization.Identity
+		code         string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = generateGUID("root")
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		ttl = time.Minute
+		identity = authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}
+	})
+
+	JustBeforeEach(func() {
+		oneTimeCodes = authorization.NewOneTimeCodes(k8sClient, namespace, ttl)
+
+		var err error
+		code, err = oneTimeCodes.Issue(ctx, identity)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("issues a code", func() {
+		Expect(code).NotTo(BeEmpty())
+	})
+
+	It("does not store the code in clear", func() {
+		secrets := &corev1.SecretList{}
+		Expect(k8sClient.List(ctx, secrets, client.InNamespace(namespace), client.HasLabels{authorization.OneTimeCodeLabelKey})).To(Succeed())
+		Expect(secrets.Items).To(HaveLen(1))
+		Expect(secrets.Items[0].Name).NotTo(ContainSubstring(code))
+	})
+
+	It("redeems the code for the identity it was issued to", func() {
+		redeemedIdentity, err := oneTimeCodes.Redeem(ctx, code)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(redeemedIdentity).To(Equal(identity))
+	})
+
+	It("does not redeem the code twice", func() {
+		_, err := oneTimeCodes.Redeem(ctx, code)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = oneTimeCodes.Redeem(ctx, code)
+		Expect(err).To(MatchError(authorization.ErrInvalidOneTimeCode))
+	})
+
+	It("does not redeem unknown codes", func() {
+		_, err := oneTimeCodes.Redeem(ctx, "not-a-code")
+		Expect(err).To(MatchError(authorization.ErrInvalidOneTimeCode))
+	})
+
+	When("the code has expired", func() {
+		BeforeEach(func() {
+			ttl = time.Millisecond
+		})
+
+		It("does not redeem the code", func() {
+			time.Sleep(time.Second)
+			_, err := oneTimeCodes.Redeem(ctx, code)
+			Expect(err).To(MatchError(authorization.ErrInvalidOneTimeCode))
+		})
+
+		It("deletes the expired code when issuing another one", func() {
+			time.Sleep(time.Second)
+			_, err := oneTimeCodes.Issue(ctx, identity)
+			Expect(err).NotTo(HaveOccurred())
+
+			secrets := &corev1.SecretList{}
+			Expect(k8sClient.List(ctx, secrets, client.InNamespace(namespace), client.HasLabels{authorization.OneTimeCodeLabelKey})).To(Succeed())
+			Expect(secrets.Items).To(HaveLen(1))
+		})
+	})
+})
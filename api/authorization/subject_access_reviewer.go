@@ -0,0 +1,57 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const authenticatedGroup = "system:authenticated"
+
+// SubjectAccessReviewer checks what an identity is allowed to do on behalf of
+// components that do not have the Kubernetes credentials of the user, e.g.
+// because the user has been authenticated with a one-time code
+type SubjectAccessReviewer struct {
+	privilegedClient client.Client
+}
+
+func NewSubjectAccessReviewer(privilegedClient client.Client) *SubjectAccessReviewer {
+	return &SubjectAccessReviewer{
+		privilegedClient: privilegedClient,
+	}
+}
+
+func (r *SubjectAccessReviewer) IsAllowed(ctx context.Context, identity Identity, attributes authzv1.ResourceAttributes) (bool, error) {
+	review := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:               identity.Name,
+			Groups:             identityGroups(identity),
+			ResourceAttributes: &attributes,
+		},
+	}
+	if err := r.privilegedClient.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to create subject access review: %w", err)
+	}
+
+	return review.Status.Allowed, nil
+}
+
+// identityGroups returns the groups Kubernetes puts the identity in, so that
+// permissions granted to them are taken into account
+func identityGroups(identity Identity) []string {
+	if identity.Kind != rbacv1.ServiceAccountKind {
+		return []string{authenticatedGroup}
+	}
+
+	groups := []string{serviceAccountsGroup, authenticatedGroup}
+	nameParts := strings.Split(strings.TrimPrefix(identity.Name, serviceAccountNamePrefix), ":")
+	if len(nameParts) == 2 {
+		groups = append(groups, serviceAccountsGroup+":"+nameParts[0])
+	}
+
+	return groups
+}
@@ -0,0 +1,121 @@
+package authorization_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("SubjectAccessReviewer", func() {
+	var (
+		ctx        context.Context
+		namespace  string
+		identity   authorization.Identity
+		reviewer   *authorization.SubjectAccessReviewer
+		allowed    bool
+		reviewErr  error
+		attributes authzv1.ResourceAttributes
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = generateGUID("space")
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ssh"},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"korifi.cloudfoundry.org"},
+				Resources: []string{"cfapps/ssh"},
+				Verbs:     []string{"create"},
+			}},
+		})).To(Succeed())
+
+		identity = authorization.Identity{Name: generateGUID("user"), Kind: rbacv1.UserKind}
+		attributes = authzv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        "create",
+			Group:       "korifi.cloudfoundry.org",
+			Resource:    "cfapps",
+			Subresource: "ssh",
+			Name:        "my-app",
+		}
+		reviewer = authorization.NewSubjectAccessReviewer(k8sClient)
+	})
+
+	JustBeforeEach(func() {
+		allowed, reviewErr = reviewer.IsAllowed(ctx, identity, attributes)
+	})
+
+	It("does not allow identities without permissions", func() {
+		Expect(reviewErr).NotTo(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+	})
+
+	When("the user has been granted the permission", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ssh"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "ssh"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: identity.Name}},
+			})).To(Succeed())
+		})
+
+		It("allows the identity", func() {
+			Expect(reviewErr).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+	})
+
+	When("the user has only been granted to exec into pods", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "exec"},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods/exec"},
+					Verbs:     []string{"create"},
+				}},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "exec"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "exec"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: identity.Name}},
+			})).To(Succeed())
+		})
+
+		It("does not allow the identity", func() {
+			Expect(reviewErr).NotTo(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+		})
+	})
+
+	When("the permission has been granted to the service accounts of a namespace", func() {
+		BeforeEach(func() {
+			identity = authorization.Identity{
+				Name: "system:serviceaccount:" + namespace + ":my-sa",
+				Kind: rbacv1.ServiceAccountKind,
+			}
+
+			Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ssh"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "ssh"},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:" + namespace}},
+			})).To(Succeed())
+		})
+
+		It("allows the service account", func() {
+			Expect(reviewErr).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+	})
+})
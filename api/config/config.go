@@ -1,20 +1,30 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
+	"golang.org/x/crypto/ssh"
 
 	"go.uber.org/zap/zapcore"
 	"k8s.io/client-go/rest"
 )
 
 const (
-	defaultExternalProtocol           = "https"
-	OrgRole                 RoleLevel = "org"
-	SpaceRole               RoleLevel = "space"
+	defaultExternalProtocol                 = "https"
+	defaultLogBufferSize                    = 1000
+	defaultResourceCacheMaxSizeMB           = 1024
+	defaultResourceCacheDirName             = "korifi-resource-cache"
+	OrgRole                       RoleLevel = "org"
+	SpaceRole                     RoleLevel = "space"
 )
 
 type (
@@ -41,6 +51,10 @@ type (
 		DefaultDomainName                        string                 `yaml:"defaultDomainName"`
 		UserCertificateExpirationWarningDuration string                 `yaml:"userCertificateExpirationWarningDuration"`
 		DefaultLifecycleConfig                   DefaultLifecycleConfig `yaml:"defaultLifecycleConfig"`
+		LogBufferSize                            int                    `yaml:"logBufferSize"`
+		ResourceCacheDir                         string                 `yaml:"resourceCacheDir"`
+		ResourceCacheMaxSizeMB                   int                    `yaml:"resourceCacheMaxSizeMB"`
+		RouterGroups                             []RouterGroup          `yaml:"routerGroups"`
 
 		RoleMappings map[string]Role `yaml:"roleMappings"`
 
@@ -48,16 +62,41 @@ type (
 		AuthProxyCACert string        `yaml:"authProxyCACert"`
 		LogLevel        zapcore.Level `yaml:"logLevel"`
 
+		SSHProxy SSHProxy `yaml:"sshProxy"`
+
 		Experimental Experimental `yaml:"experimental"`
 	}
 
+	SSHProxy struct {
+		Enabled     bool   `yaml:"enabled"`
+		Host        string `yaml:"host"`
+		HostKeyPath string `yaml:"hostKeyPath"`
+
+		// HostKeyFingerprint is computed from the host key when the config is loaded
+		HostKeyFingerprint string `yaml:"-"`
+	}
+
+	RouterGroup struct {
+		Name            string `yaml:"name"`
+		ReservablePorts string `yaml:"reservablePorts"`
+
+		// PortRanges are parsed from ReservablePorts when the config is loaded
+		PortRanges []PortRange `yaml:"-"`
+	}
+
+	PortRange struct {
+		Start int32
+		End   int32
+	}
+
 	Experimental struct {
 		ManagedServices ManagedServices `yaml:"managedServices"`
 		UAA             UAA             `yaml:"uaa"`
 	}
 
 	ManagedServices struct {
-		Enabled bool `yaml:"enabled"`
+		Enabled              bool `yaml:"enabled"`
+		TrustInsecureBrokers bool `yaml:"trustInsecureBrokers"`
 	}
 
 	UAA struct {
@@ -107,6 +146,20 @@ func LoadFromPath(path string) (*APIConfig, error) {
 		return nil, err
 	}
 
+	if config.SSHProxy.Enabled {
+		config.SSHProxy.HostKeyFingerprint, err = hostKeyFingerprint(config.SSHProxy.HostKeyPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, routerGroup := range config.RouterGroups {
+		config.RouterGroups[i].PortRanges, err = parsePortRanges(routerGroup.ReservablePorts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reservable ports for router group %q: %w", routerGroup.Name, err)
+		}
+	}
+
 	return &config, nil
 }
 
@@ -142,9 +195,62 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.SSHProxy.Enabled && (c.SSHProxy.Host == "" || c.SSHProxy.HostKeyPath == "") {
+		return errors.New("SSHProxy requires values for Host and HostKeyPath")
+	}
+
+	routerGroupNames := map[string]bool{}
+	for _, routerGroup := range c.RouterGroups {
+		if routerGroup.Name == "" {
+			return errors.New("RouterGroups must have a name")
+		}
+		if routerGroupNames[routerGroup.Name] {
+			return fmt.Errorf("RouterGroup %q is configured more than once", routerGroup.Name)
+		}
+		routerGroupNames[routerGroup.Name] = true
+	}
+
 	return nil
 }
 
+// parsePortRanges parses reservable ports in the CF routing API format, i.e.
+// a comma separated list of ports and port ranges such as "1024-1033,2000"
+func parsePortRanges(reservablePorts string) ([]PortRange, error) {
+	portRanges := []PortRange{}
+
+	for _, portRange := range strings.Split(reservablePorts, ",") {
+		start, end, isRange := strings.Cut(portRange, "-")
+		if !isRange {
+			end = start
+		}
+
+		startPort, err := parsePort(start)
+		if err != nil {
+			return nil, err
+		}
+		endPort, err := parsePort(end)
+		if err != nil {
+			return nil, err
+		}
+		if startPort > endPort {
+			return nil, fmt.Errorf("invalid port range %q: start port is greater than end port", strings.TrimSpace(portRange))
+		}
+
+		portRanges = append(portRanges, PortRange{Start: startPort, End: endPort})
+	}
+
+	return portRanges, nil
+}
+
+func parsePort(port string) (int32, error) {
+	parsedPort, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+	if err != nil || parsedPort < 1 || parsedPort > 65535 {
+		return 0, fmt.Errorf("invalid port %q: ports must be between 1 and 65535", strings.TrimSpace(port))
+	}
+
+	return int32(parsedPort), nil
+}
+
 func (c *APIConfig) GetUserCertificateDuration() time.Duration {
 	if c.UserCertificateExpirationWarningDuration == "" {
 		return time.Hour * 24 * 7
@@ -153,6 +259,27 @@ func (c *APIConfig) GetUserCertificateDuration() time.Duration {
 	return d
 }
 
+func (c *APIConfig) GetLogBufferSize() int {
+	if c.LogBufferSize <= 0 {
+		return defaultLogBufferSize
+	}
+	return c.LogBufferSize
+}
+
+func (c *APIConfig) GetResourceCacheDir() string {
+	if c.ResourceCacheDir == "" {
+		return filepath.Join(os.TempDir(), defaultResourceCacheDirName)
+	}
+	return c.ResourceCacheDir
+}
+
+func (c *APIConfig) GetResourceCacheMaxSizeMB() int {
+	if c.ResourceCacheMaxSizeMB <= 0 {
+		return defaultResourceCacheMaxSizeMB
+	}
+	return c.ResourceCacheMaxSizeMB
+}
+
 func (c *APIConfig) composeServerURL() (string, error) {
 	toReturn := defaultExternalProtocol + "://" + c.ExternalFQDN
 
@@ -163,6 +290,23 @@ func (c *APIConfig) composeServerURL() (string, error) {
 	return toReturn, nil
 }
 
+// hostKeyFingerprint returns the fingerprint of the SSH proxy host key in the
+// format the cf CLI expects, i.e. the base64 encoded SHA256 of the public key
+func hostKeyFingerprint(hostKeyPath string) (string, error) {
+	hostKeyBytes, err := os.ReadFile(hostKeyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ssh proxy host key: %w", err)
+	}
+
+	hostKey, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse ssh proxy host key: %w", err)
+	}
+
+	fingerprint := sha256.Sum256(hostKey.PublicKey().Marshal())
+	return base64.StdEncoding.EncodeToString(fingerprint[:]), nil
+}
+
 func (c *APIConfig) GenerateK8sClientConfig(k8sClientConfig *rest.Config) *rest.Config {
 	if c.AuthProxyHost != "" && c.AuthProxyCACert != "" {
 		k8sClientConfig.Host = c.AuthProxyHost
@@ -1,7 +1,12 @@
 package config_test
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
+	"path/filepath"
 
 	"go.uber.org/zap/zapcore"
 
@@ -48,7 +53,8 @@ var _ = Describe("Config", func() {
 			},
 			"experimental": map[string]any{
 				"managedServices": map[string]any{
-					"enabled": true,
+					"enabled":              true,
+					"trustInsecureBrokers": true,
 				},
 			},
 		}
@@ -94,6 +100,7 @@ var _ = Describe("Config", func() {
 		}))
 		Expect(cfg.ContainerRegistryType).To(BeEmpty())
 		Expect(cfg.Experimental.ManagedServices.Enabled).To(BeTrue())
+		Expect(cfg.Experimental.ManagedServices.TrustInsecureBrokers).To(BeTrue())
 	})
 
 	When("the FQDN is not specified", func() {
@@ -171,6 +178,54 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("the log buffer size is configured", func() {
+		BeforeEach(func() {
+			configMap["logBufferSize"] = 42
+		})
+
+		It("uses it", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.GetLogBufferSize()).To(Equal(42))
+		})
+
+		When("the log buffer size is not set", func() {
+			BeforeEach(func() {
+				delete(configMap, "logBufferSize")
+			})
+
+			It("uses the default", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+				Expect(cfg.GetLogBufferSize()).To(Equal(1000))
+			})
+		})
+	})
+
+	When("the resource cache is configured", func() {
+		BeforeEach(func() {
+			configMap["resourceCacheDir"] = "/var/cache/resources"
+			configMap["resourceCacheMaxSizeMB"] = 42
+		})
+
+		It("uses it", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.GetResourceCacheDir()).To(Equal("/var/cache/resources"))
+			Expect(cfg.GetResourceCacheMaxSizeMB()).To(Equal(42))
+		})
+
+		When("the resource cache is not set", func() {
+			BeforeEach(func() {
+				delete(configMap, "resourceCacheDir")
+				delete(configMap, "resourceCacheMaxSizeMB")
+			})
+
+			It("uses the defaults", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+				Expect(cfg.GetResourceCacheDir()).To(Equal(filepath.Join(os.TempDir(), "korifi-resource-cache")))
+				Expect(cfg.GetResourceCacheMaxSizeMB()).To(Equal(1024))
+			})
+		})
+	})
+
 	When("the UserCertificateExpirationWarningDuration is invalid", func() {
 		BeforeEach(func() {
 			configMap["userCertificateExpirationWarningDuration"] = "invalid-duration"
@@ -201,4 +256,132 @@ var _ = Describe("Config", func() {
 			Expect(cfg.ServerURL).To(Equal("https://api.foo:1234"))
 		})
 	})
+
+	When("the ssh proxy is enabled", func() {
+		var hostKeyDir string
+
+		BeforeEach(func() {
+			var err error
+			hostKeyDir, err = os.MkdirTemp("", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			hostKeyPath := filepath.Join(hostKeyDir, "host-key")
+			Expect(os.WriteFile(hostKeyPath, pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+			}), 0o600)).To(Succeed())
+
+			configMap["sshProxy"] = map[string]any{
+				"enabled":     true,
+				"host":        "ssh.foo:2222",
+				"hostKeyPath": hostKeyPath,
+			}
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(hostKeyDir)).To(Succeed())
+		})
+
+		It("computes the host key fingerprint", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.SSHProxy.Host).To(Equal("ssh.foo:2222"))
+			Expect(cfg.SSHProxy.HostKeyFingerprint).To(HaveLen(44))
+		})
+
+		When("the host is not specified", func() {
+			BeforeEach(func() {
+				delete(configMap["sshProxy"].(map[string]any), "host")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy requires values for Host and HostKeyPath"))
+			})
+		})
+
+		When("the host key is invalid", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(filepath.Join(hostKeyDir, "host-key"), []byte("not-a-key"), 0o600)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("failed to parse ssh proxy host key")))
+			})
+		})
+	})
+
+	When("router groups are configured", func() {
+		BeforeEach(func() {
+			configMap["routerGroups"] = []map[string]any{{
+				"name":            "default-tcp",
+				"reservablePorts": "1024-1033, 2000",
+			}}
+		})
+
+		It("parses the reservable ports", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.RouterGroups).To(ConsistOf(config.RouterGroup{
+				Name:            "default-tcp",
+				ReservablePorts: "1024-1033, 2000",
+				PortRanges: []config.PortRange{
+					{Start: 1024, End: 1033},
+					{Start: 2000, End: 2000},
+				},
+			}))
+		})
+
+		When("the router group has no name", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{{"reservablePorts": "1024"}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("RouterGroups must have a name"))
+			})
+		})
+
+		When("a router group is configured twice", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{
+					{"name": "default-tcp", "reservablePorts": "1024"},
+					{"name": "default-tcp", "reservablePorts": "1025"},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("configured more than once")))
+			})
+		})
+
+		When("the reservable ports are not numbers", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{{"name": "default-tcp", "reservablePorts": "1024-abc"}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(`invalid reservable ports for router group "default-tcp": invalid port "abc": ports must be between 1 and 65535`))
+			})
+		})
+
+		When("the reservable ports are out of range", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{{"name": "default-tcp", "reservablePorts": "70000"}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring(`invalid port "70000"`)))
+			})
+		})
+
+		When("a port range is reversed", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{{"name": "default-tcp", "reservablePorts": "2000-1024"}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("start port is greater than end port")))
+			})
+		})
+	})
 })
@@ -370,6 +370,21 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type FeatureDisabledError struct {
+	apiError
+}
+
+func NewFeatureDisabledError(feature string) FeatureDisabledError {
+	return FeatureDisabledError{
+		apiError: apiError{
+			title:      "CF-FeatureDisabled",
+			detail:     fmt.Sprintf("Feature Disabled: %s", feature),
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := validation.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
@@ -66,17 +66,18 @@ type PodRepository interface {
 }
 
 type App struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	dropletRepo      CFDropletRepository
-	processRepo      CFProcessRepository
-	processStats     ProcessStats
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	spaceRepo        CFSpaceRepository
-	packageRepo      CFPackageRepository
-	requestValidator RequestValidator
-	podRepo          PodRepository
+	serverURL          url.URL
+	appRepo            CFAppRepository
+	dropletRepo        CFDropletRepository
+	processRepo        CFProcessRepository
+	processStats       ProcessStats
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	spaceRepo          CFSpaceRepository
+	packageRepo        CFPackageRepository
+	requestValidator   RequestValidator
+	podRepo            PodRepository
+	featureFlagChecker FeatureFlagChecker
 }
 
 func NewApp(
@@ -91,19 +92,21 @@ func NewApp(
 	packageRepo CFPackageRepository,
 	requestValidator RequestValidator,
 	podRepo PodRepository,
+	featureFlagChecker FeatureFlagChecker,
 ) *App {
 	return &App{
-		serverURL:        serverURL,
-		appRepo:          appRepo,
-		dropletRepo:      dropletRepo,
-		processRepo:      processRepo,
-		processStats:     processStatsFetcher,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		spaceRepo:        spaceRepo,
-		packageRepo:      packageRepo,
-		requestValidator: requestValidator,
-		podRepo:          podRepo,
+		serverURL:          serverURL,
+		appRepo:            appRepo,
+		dropletRepo:        dropletRepo,
+		processRepo:        processRepo,
+		processStats:       processStatsFetcher,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		spaceRepo:          spaceRepo,
+		packageRepo:        packageRepo,
+		requestValidator:   requestValidator,
+		podRepo:            podRepo,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -138,6 +141,12 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if payload.Lifecycle != nil && payload.Lifecycle.Type == "docker" {
+		if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker apps are disabled", "App Name", payload.Name)
+		}
+	}
+
 	appRecord, err := h.appRepo.CreateApp(r.Context(), authInfo, payload.ToAppCreateMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create app", "App Name", payload.Name)
@@ -362,7 +371,7 @@ func (h *App) getRoutes(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 	}
 
-	routes, err := h.lookupAppRouteAndDomainList(r.Context(), authInfo, app.GUID, app.SpaceGUID)
+	routes, err := h.lookupAppRouteAndDomainList(r.Context(), authInfo, app.GUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch route or domains from Kubernetes")
 	}
@@ -470,8 +479,8 @@ func (h *App) delete(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(appGUID, presenter.AppDeleteOperation, h.serverURL)), nil
 }
 
-func (h *App) lookupAppRouteAndDomainList(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) ([]repositories.RouteRecord, error) {
-	routeRecords, err := h.routeRepo.ListRoutesForApp(ctx, authInfo, appGUID, spaceGUID)
+func (h *App) lookupAppRouteAndDomainList(ctx context.Context, authInfo authorization.Info, appGUID string) ([]repositories.RouteRecord, error) {
+	routeRecords, err := h.routeRepo.ListRoutesForApp(ctx, authInfo, appGUID)
 	if err != nil {
 		return []repositories.RouteRecord{}, err
 	}
@@ -662,21 +671,44 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
-	}), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	spaceSSH, err := h.featureFlagChecker.GetSpaceFeature(r.Context(), authInfo, app.SpaceGUID, repositories.SpaceFeatureSSH)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch space ssh feature", "SpaceGUID", app.SpaceGUID)
+	}
+
+	sshEnabled := presenter.AppSSHEnabled{Enabled: true}
+	if !spaceSSH.Enabled {
+		sshEnabled = presenter.AppSSHEnabled{Enabled: false, Reason: "Disabled for this space"}
+	} else if !app.EnableSSH {
+		sshEnabled = presenter.AppSSHEnabled{Enabled: false, Reason: "Disabled for this app"}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(sshEnabled), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-app-feature")
+	appGUID := routing.URLParam(r, "guid")
 	featureName := routing.URLParam(r, "name")
+
 	switch featureName {
 	case "ssh":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "ssh",
-			"description": "Enable SSHing into the app.",
-			"enabled":     false,
-		}), nil
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+		}
+
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHFeature(app)), nil
 	case "revisions":
 		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
 			"name":        "revisions",
@@ -688,6 +720,34 @@ func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
 	}
 }
 
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-app-feature")
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if featureName != "ssh" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "Unsupported app feature", "Name", featureName)
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	var payload payloads.AppFeatureUpdate
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err = h.appRepo.PatchApp(r.Context(), authInfo, payload.ToSSHMessage(appGUID, app.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHFeature(app)), nil
+}
+
 func (h *App) restartInstance(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.restart-instance")
@@ -760,6 +820,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 		{Method: "DELETE", Pattern: AppInstanceRestartPath, Handler: h.restartInstance},
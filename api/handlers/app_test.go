@@ -30,17 +30,18 @@ const (
 
 var _ = Describe("App", func() {
 	var (
-		appRepo          *fake.CFAppRepository
-		dropletRepo      *fake.CFDropletRepository
-		processRepo      *fake.CFProcessRepository
-		processStats     *fake.ProcessStats
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		spaceRepo        *fake.CFSpaceRepository
-		packageRepo      *fake.CFPackageRepository
-		podRepo          *fake.PodRepository
-		requestValidator *fake.RequestValidator
-		req              *http.Request
+		appRepo            *fake.CFAppRepository
+		dropletRepo        *fake.CFDropletRepository
+		processRepo        *fake.CFProcessRepository
+		processStats       *fake.ProcessStats
+		routeRepo          *fake.CFRouteRepository
+		domainRepo         *fake.CFDomainRepository
+		spaceRepo          *fake.CFSpaceRepository
+		packageRepo        *fake.CFPackageRepository
+		podRepo            *fake.PodRepository
+		requestValidator   *fake.RequestValidator
+		featureFlagChecker *fake.FeatureFlagChecker
+		req                *http.Request
 
 		appRecord repositories.AppRecord
 	)
@@ -56,6 +57,7 @@ var _ = Describe("App", func() {
 		packageRepo = new(fake.CFPackageRepository)
 		requestValidator = new(fake.RequestValidator)
 		podRepo = new(fake.PodRepository)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
 		apiHandler := NewApp(
 			*serverURL,
@@ -69,6 +71,7 @@ var _ = Describe("App", func() {
 			packageRepo,
 			requestValidator,
 			podRepo,
+			featureFlagChecker,
 		)
 
 		appRecord = repositories.AppRecord{
@@ -230,6 +233,28 @@ var _ = Describe("App", func() {
 						Data: repositories.LifecycleData{},
 					}))
 			})
+
+			It("checks the diego_docker feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal("diego_docker"))
+			})
+
+			When("the diego_docker feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("diego_docker"))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectFeatureDisabledError("diego_docker")
+					Expect(appRepo.CreateAppCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		It("does not check feature flags for buildpack apps", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
 		})
 
 		It("creates the `web` process", func() {
@@ -1170,7 +1195,7 @@ var _ = Describe("App", func() {
 			Expect(actualAuthInfo).To(Equal(authInfo))
 
 			Expect(routeRepo.ListRoutesForAppCallCount()).To(Equal(1))
-			_, actualAuthInfo, _, _ = routeRepo.ListRoutesForAppArgsForCall(0)
+			_, actualAuthInfo, _ = routeRepo.ListRoutesForAppArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 
 			Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
@@ -1777,35 +1802,108 @@ var _ = Describe("App", func() {
 
 	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
 		BeforeEach(func() {
+			appRecord.EnableSSH = true
+			appRepo.GetAppReturns(appRecord, nil)
+			featureFlagChecker.GetSpaceFeatureReturns(repositories.SpaceFeatureRecord{Name: "ssh", Enabled: true}, nil)
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
 		})
 
-		It("returns false", func() {
+		It("returns true", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.reason", BeEmpty()),
 			)))
 		})
+
+		It("checks the ssh feature of the app space", func() {
+			Expect(featureFlagChecker.GetSpaceFeatureCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, actualName := featureFlagChecker.GetSpaceFeatureArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(actualName).To(Equal("ssh"))
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.EnableSSH = false
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for this app")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the space", func() {
+			BeforeEach(func() {
+				featureFlagChecker.GetSpaceFeatureReturns(repositories.SpaceFeatureRecord{Name: "ssh", Enabled: false}, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for this space")),
+				)))
+			})
+		})
+
+		When("getting the app is forbidden", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("getting the space feature fails", func() {
+			BeforeEach(func() {
+				featureFlagChecker.GetSpaceFeatureReturns(repositories.SpaceFeatureRecord{}, errors.New("space-feature-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("GET /v3/apps/GUID/features", func() {
 		When("feature ssh is called", func() {
 			BeforeEach(func() {
+				appRecord.EnableSSH = true
+				appRepo.GetAppReturns(appRecord, nil)
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/ssh", nil)
 			})
 
-			It("returns ssh enabled false", func() {
+			It("returns whether ssh is enabled for the app", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("ssh")),
 					MatchJSONPath("$.description", Equal("Enable SSHing into the app.")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
+
+			When("getting the app is forbidden", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError("App")
+				})
+			})
 		})
+
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/revisions", nil)
@@ -1839,6 +1937,79 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("PATCH /v3/apps/GUID/features/ssh", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AppFeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+
+			appRecord.EnableSSH = false
+			appRepo.PatchAppReturns(appRecord, nil)
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-json-body"))
+		})
+
+		It("updates the app", func() {
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, msg := appRepo.PatchAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(msg).To(Equal(repositories.PatchAppMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				EnableSSH: tools.PtrTo(false),
+			}))
+		})
+
+		It("returns the ssh feature of the app", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is not ssh", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-json-body"))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the app is forbidden", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("patch-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/apps/:guid/processes/:process/instances/:instance", func() {
 		BeforeEach(func() {
 			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+	AuditEventPath  = "/v3/audit_events/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+type CFAuditEventRepository interface {
+	GetAuditEvent(context.Context, authorization.Info, string) (repositories.AuditEventRecord, error)
+	ListAuditEvents(context.Context, authorization.Info, repositories.ListAuditEventsMessage) ([]repositories.AuditEventRecord, error)
+}
+
+type AuditEvent struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	auditEventRepo   CFAuditEventRepository
+}
+
+func NewAuditEvent(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	auditEventRepo CFAuditEventRepository,
+) *AuditEvent {
+	return &AuditEvent{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		auditEventRepo:   auditEventRepo,
+	}
+}
+
+func (h *AuditEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.get")
+
+	auditEventGUID := routing.URLParam(r, "guid")
+
+	auditEvent, err := h.auditEventRepo.GetAuditEvent(r.Context(), authInfo, auditEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get audit event", "guid", auditEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAuditEvent(auditEvent, h.serverURL)), nil
+}
+
+func (h *AuditEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.list")
+
+	var payload payloads.AuditEventList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list audit events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAuditEvent, auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AuditEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AuditEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AuditEventPath, Handler: h.get},
+	}
+}
+
+// AuditedRoutes maps the mutating routes to the audit event the
+// middleware.AuditEvents middleware records when they succeed
+var AuditedRoutes = map[string]middleware.AuditedRoute{
+	middleware.AuditedRouteKey("POST", AppsPath):                           appEvent("audit.app.create", ""),
+	middleware.AuditedRouteKey("PATCH", AppPath):                           appEvent("audit.app.update", "guid"),
+	middleware.AuditedRouteKey("DELETE", AppPath):                          appEvent("audit.app.delete-request", "guid"),
+	middleware.AuditedRouteKey("POST", AppStartPath):                       appEvent("audit.app.start", "guid"),
+	middleware.AuditedRouteKey("POST", AppStopPath):                        appEvent("audit.app.stop", "guid"),
+	middleware.AuditedRouteKey("POST", AppRestartPath):                     appEvent("audit.app.restart", "guid"),
+	middleware.AuditedRouteKey("PATCH", AppCurrentDropletRelationshipPath): appEvent("audit.app.droplet.mapped", "guid"),
+	middleware.AuditedRouteKey("PATCH", AppEnvVarsPath):                    appEvent("audit.app.update", "guid"),
+	middleware.AuditedRouteKey("PATCH", AppFeaturePath):                    appEvent("audit.app.update", "guid"),
+	middleware.AuditedRouteKey("POST", AppProcessScalePath):                appEvent("audit.app.process.scale", "guid"),
+	middleware.AuditedRouteKey("DELETE", AppInstanceRestartPath):           appEvent("audit.app.process.terminate_instance", "guid"),
+	middleware.AuditedRouteKey("POST", TasksPath):                          appEvent("audit.app.task.create", "appGUID"),
+
+	middleware.AuditedRouteKey("PATCH", TaskPath):                    taskEvent("audit.app.task.update"),
+	middleware.AuditedRouteKey("POST", TaskCancelPath):               taskEvent("audit.app.task.cancel"),
+	middleware.AuditedRouteKey("PUT", TaskCancelPathDeprecated):      taskEvent("audit.app.task.cancel"),
+	middleware.AuditedRouteKey("PATCH", ProcessPath):                 processEvent("audit.app.process.update"),
+	middleware.AuditedRouteKey("POST", ProcessScalePath):             processEvent("audit.app.process.scale"),
+	middleware.AuditedRouteKey("DELETE", ProcessInstanceRestartPath): processEvent("audit.app.process.terminate_instance"),
+
+	middleware.AuditedRouteKey("POST", BuildsPath):             spacedEvent("audit.app.build.create", "build", "", repositories.BuildResourceType),
+	middleware.AuditedRouteKey("PATCH", BuildPath):             spacedEvent("audit.app.build.update", "build", "guid", repositories.BuildResourceType),
+	middleware.AuditedRouteKey("POST", DeploymentsPath):        spacedEvent("audit.app.deployment.create", "deployment", "", ""),
+	middleware.AuditedRouteKey("POST", DeploymentCancelPath):   spacedEvent("audit.app.deployment.cancel", "deployment", "guid", ""),
+	middleware.AuditedRouteKey("POST", DeploymentContinuePath): spacedEvent("audit.app.deployment.continue", "deployment", "guid", ""),
+	middleware.AuditedRouteKey("POST", DropletsPath):           spacedEvent("audit.app.droplet.create", "droplet", "", repositories.DropletResourceType),
+	middleware.AuditedRouteKey("PATCH", DropletPath):           spacedEvent("audit.app.droplet.update", "droplet", "guid", repositories.DropletResourceType),
+	middleware.AuditedRouteKey("POST", DropletUploadPath):      spacedEvent("audit.app.droplet.upload", "droplet", "guid", repositories.DropletResourceType),
+	middleware.AuditedRouteKey("POST", PackagesPath):           spacedEvent("audit.app.package.create", "package", "", repositories.PackageResourceType),
+	middleware.AuditedRouteKey("PATCH", PackagePath):           spacedEvent("audit.app.package.update", "package", "guid", repositories.PackageResourceType),
+	middleware.AuditedRouteKey("POST", PackageUploadPath):      spacedEvent("audit.app.package.upload", "package", "guid", repositories.PackageResourceType),
+
+	middleware.AuditedRouteKey("POST", RoutesPath):             routeEvent("audit.route.create", ""),
+	middleware.AuditedRouteKey("PATCH", RoutePath):             routeEvent("audit.route.update", "guid"),
+	middleware.AuditedRouteKey("DELETE", RoutePath):            routeEvent("audit.route.delete-request", "guid"),
+	middleware.AuditedRouteKey("POST", RouteDestinationsPath):  routeEvent("audit.app.map-route", "guid"),
+	middleware.AuditedRouteKey("DELETE", RouteDestinationPath): routeEvent("audit.app.unmap-route", "guid"),
+	middleware.AuditedRouteKey("POST", RouteSharedSpacesPath):  routeEvent("audit.route.share", "guid"),
+	middleware.AuditedRouteKey("DELETE", RouteSharedSpacePath): routeEvent("audit.route.unshare", "guid"),
+	middleware.AuditedRouteKey("PATCH", RouteSpacePath):        routeEvent("audit.route.transfer-owner", "guid"),
+
+	middleware.AuditedRouteKey("POST", ServiceInstancesPath):             spacedEvent("audit.service_instance.create", "service_instance", "", repositories.ServiceInstanceResourceType),
+	middleware.AuditedRouteKey("PATCH", ServiceInstancePath):             spacedEvent("audit.service_instance.update", "service_instance", "guid", repositories.ServiceInstanceResourceType),
+	middleware.AuditedRouteKey("DELETE", ServiceInstancePath):            spacedEvent("audit.service_instance.delete", "service_instance", "guid", repositories.ServiceInstanceResourceType),
+	middleware.AuditedRouteKey("POST", ServiceInstanceSharedSpacesPath):  spacedEvent("audit.service_instance.share", "service_instance", "guid", repositories.ServiceInstanceResourceType),
+	middleware.AuditedRouteKey("DELETE", ServiceInstanceSharedSpacePath): spacedEvent("audit.service_instance.unshare", "service_instance", "guid", repositories.ServiceInstanceResourceType),
+	middleware.AuditedRouteKey("POST", ServiceBindingsPath):              spacedEvent("audit.service_binding.create", "service_binding", "", repositories.ServiceBindingResourceType),
+	middleware.AuditedRouteKey("PATCH", ServiceBindingPath):              spacedEvent("audit.service_binding.update", "service_binding", "guid", repositories.ServiceBindingResourceType),
+	middleware.AuditedRouteKey("DELETE", ServiceBindingPath):             spacedEvent("audit.service_binding.delete", "service_binding", "guid", repositories.ServiceBindingResourceType),
+	middleware.AuditedRouteKey("POST", ServiceRouteBindingsPath):         spacedEvent("audit.service_route_binding.create", "service_route_binding", "", repositories.ServiceRouteBindingResourceType),
+	middleware.AuditedRouteKey("DELETE", ServiceRouteBindingPath):        spacedEvent("audit.service_route_binding.delete", "service_route_binding", "guid", repositories.ServiceRouteBindingResourceType),
+
+	middleware.AuditedRouteKey("POST", SpacesPath):             spaceEvent("audit.space.create", ""),
+	middleware.AuditedRouteKey("PATCH", SpacePath):             spaceEvent("audit.space.update", "guid"),
+	middleware.AuditedRouteKey("DELETE", SpacePath):            spaceEvent("audit.space.delete-request", "guid"),
+	middleware.AuditedRouteKey("POST", SpaceManifestApplyPath): spaceEvent("audit.space.apply_manifest", "spaceGUID"),
+	middleware.AuditedRouteKey("PATCH", SpaceFeaturePath):      spaceEvent("audit.space.update", "guid"),
+	middleware.AuditedRouteKey("POST", OrgsPath):               orgEvent("audit.organization.create", ""),
+	middleware.AuditedRouteKey("PATCH", OrgPath):               orgEvent("audit.organization.update", "guid"),
+	middleware.AuditedRouteKey("DELETE", OrgPath):              orgEvent("audit.organization.delete-request", "guid"),
+
+	middleware.AuditedRouteKey("POST", RolesPath):        globalEvent("audit.role.create", "role", ""),
+	middleware.AuditedRouteKey("DELETE", RolePath):       globalEvent("audit.role.delete", "role", "guid"),
+	middleware.AuditedRouteKey("POST", DomainsPath):      globalEvent("audit.domain.create", "domain", ""),
+	middleware.AuditedRouteKey("PATCH", DomainPath):      globalEvent("audit.domain.update", "domain", "guid"),
+	middleware.AuditedRouteKey("DELETE", DomainPath):     globalEvent("audit.domain.delete-request", "domain", "guid"),
+	middleware.AuditedRouteKey("PATCH", FeatureFlagPath): globalEvent("audit.feature_flag.update", "feature_flag", "name"),
+	middleware.AuditedRouteKey("PATCH", EnvVarGroupPath): globalEvent("audit.environment_variable_group.update", "environment_variable_group", "name"),
+
+	middleware.AuditedRouteKey("POST", ServiceBrokersPath):         globalEvent("audit.service_broker.create", "service_broker", ""),
+	middleware.AuditedRouteKey("PATCH", ServiceBrokerPath):         globalEvent("audit.service_broker.update", "service_broker", "guid"),
+	middleware.AuditedRouteKey("DELETE", ServiceBrokerPath):        globalEvent("audit.service_broker.delete", "service_broker", "guid"),
+	middleware.AuditedRouteKey("DELETE", ServiceOfferingPath):      globalEvent("audit.service.delete", "service", "guid"),
+	middleware.AuditedRouteKey("DELETE", ServicePlanPath):          globalEvent("audit.service_plan.delete", "service_plan", "guid"),
+	middleware.AuditedRouteKey("POST", ServicePlanVisivilityPath):  globalEvent("audit.service_plan_visibility.update", "service_plan", "guid"),
+	middleware.AuditedRouteKey("PATCH", ServicePlanVisivilityPath): globalEvent("audit.service_plan_visibility.update", "service_plan", "guid"),
+
+	middleware.AuditedRouteKey("POST", NetworkPoliciesPath):       globalEvent("audit.network_policy.create", "network_policy", ""),
+	middleware.AuditedRouteKey("POST", NetworkPoliciesDeletePath): globalEvent("audit.network_policy.delete", "network_policy", ""),
+
+	middleware.AuditedRouteKey("POST", SecurityGroupsPath):              globalEvent("audit.security_group.create", "security_group", ""),
+	middleware.AuditedRouteKey("PATCH", SecurityGroupPath):              globalEvent("audit.security_group.update", "security_group", "guid"),
+	middleware.AuditedRouteKey("DELETE", SecurityGroupPath):             globalEvent("audit.security_group.delete", "security_group", "guid"),
+	middleware.AuditedRouteKey("POST", SecurityGroupRunningSpacesPath):  globalEvent("audit.security_group.bind_running_spaces", "security_group", "guid"),
+	middleware.AuditedRouteKey("POST", SecurityGroupStagingSpacesPath):  globalEvent("audit.security_group.bind_staging_spaces", "security_group", "guid"),
+	middleware.AuditedRouteKey("DELETE", SecurityGroupRunningSpacePath): globalEvent("audit.security_group.unbind_running_space", "security_group", "guid"),
+	middleware.AuditedRouteKey("DELETE", SecurityGroupStagingSpacePath): globalEvent("audit.security_group.unbind_staging_space", "security_group", "guid"),
+
+	middleware.AuditedRouteKey("POST", AppUsageEventsPurgePath):     globalEvent("audit.app_usage_events.purge", "app_usage_event", ""),
+	middleware.AuditedRouteKey("POST", ServiceUsageEventsPurgePath): globalEvent("audit.service_usage_events.purge", "service_usage_event", ""),
+
+	middleware.AuditedRouteKey("POST", OrgQuotasPath):             globalEvent("audit.organization_quota.create", "organization_quota", ""),
+	middleware.AuditedRouteKey("PATCH", OrgQuotaPath):             globalEvent("audit.organization_quota.update", "organization_quota", "guid"),
+	middleware.AuditedRouteKey("POST", OrgQuotaOrganizationsPath): globalEvent("audit.organization_quota.apply", "organization_quota", "guid"),
+	middleware.AuditedRouteKey("POST", SpaceQuotasPath):           globalEvent("audit.space_quota.create", "space_quota", ""),
+	middleware.AuditedRouteKey("PATCH", SpaceQuotaPath):           globalEvent("audit.space_quota.update", "space_quota", "guid"),
+	middleware.AuditedRouteKey("POST", SpaceQuotaSpacesPath):      globalEvent("audit.space_quota.apply", "space_quota", "guid"),
+}
+
+func appEvent(eventType, targetGUIDParam string) middleware.AuditedRoute {
+	return spacedEvent(eventType, "app", targetGUIDParam, repositories.AppResourceType)
+}
+
+func taskEvent(eventType string) middleware.AuditedRoute {
+	return spacedEvent(eventType, "task", "taskGUID", repositories.TaskResourceType)
+}
+
+func processEvent(eventType string) middleware.AuditedRoute {
+	return spacedEvent(eventType, "process", "guid", repositories.ProcessResourceType)
+}
+
+func routeEvent(eventType, targetGUIDParam string) middleware.AuditedRoute {
+	return spacedEvent(eventType, "route", targetGUIDParam, repositories.RouteResourceType)
+}
+
+func spaceEvent(eventType, targetGUIDParam string) middleware.AuditedRoute {
+	return globalEvent(eventType, repositories.AuditEventTargetTypeSpace, targetGUIDParam)
+}
+
+func orgEvent(eventType, targetGUIDParam string) middleware.AuditedRoute {
+	return globalEvent(eventType, repositories.AuditEventTargetTypeOrganization, targetGUIDParam)
+}
+
+func spacedEvent(eventType, targetType, targetGUIDParam, resourceType string) middleware.AuditedRoute {
+	return middleware.AuditedRoute{
+		EventType:       eventType,
+		TargetType:      targetType,
+		TargetGUIDParam: targetGUIDParam,
+		ResourceType:    resourceType,
+	}
+}
+
+func globalEvent(eventType, targetType, targetGUIDParam string) middleware.AuditedRoute {
+	return spacedEvent(eventType, targetType, targetGUIDParam, "")
+}
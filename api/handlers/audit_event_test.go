@@ -0,0 +1,313 @@
+package handlers_test
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		apiHandler       *handlers.AuditEvent
+		auditEventRepo   *fake.CFAuditEventRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+		apiHandler = handlers.NewAuditEvent(
+			*serverURL,
+			requestValidator,
+			auditEventRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/audit_events/{guid}", func() {
+		BeforeEach(func() {
+			auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{
+				GUID:       "event-guid",
+				Type:       "audit.app.start",
+				TargetGUID: "app-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/audit_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the audit event", func() {
+			Expect(auditEventRepo.GetAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := auditEventRepo.GetAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.type", "audit.app.start"),
+				MatchJSONPath("$.target.guid", "app-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/audit_events/event-guid"),
+			)))
+		})
+
+		When("the audit event is not accessible", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AuditEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AuditEventResourceType)
+			})
+		})
+
+		When("getting the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/audit_events", func() {
+		BeforeEach(func() {
+			auditEventRepo.ListAuditEventsReturns([]repositories.AuditEventRecord{
+				{GUID: "event-1", CreatedAt: time.UnixMilli(1000).UTC()},
+				{GUID: "event-2", CreatedAt: time.UnixMilli(2000).UTC()},
+			}, nil)
+
+			payload := payloads.AuditEventList{
+				Types:       "audit.app.start,audit.app.stop",
+				TargetGUIDs: "app-guid",
+				SpaceGUIDs:  "space-guid",
+				OrderBy:     "-created_at",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/audit_events?types=audit.app.start,audit.app.stop", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the audit events", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListAuditEventsMessage{
+				Types:       []string{"audit.app.start", "audit.app.stop"},
+				TargetGUIDs: []string{"app-guid"},
+				SpaceGUIDs:  []string{"space-guid"},
+				OrderBy:     "-created_at",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/audit_events?types=audit.app.start,audit.app.stop"),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+				MatchJSONPath("$.resources[1].guid", "event-2"),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = nil
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("listing the audit events fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.ListAuditEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
+
+var _ = Describe("AuditedRoutes", func() {
+	var routeKeys []string
+
+	BeforeEach(func() {
+		routeKeys = registeredRouteKeys()
+		Expect(routeKeys).NotTo(BeEmpty())
+	})
+
+	It("audits every mutating route", func() {
+		// These routes do not change any resource
+		notAudited := []string{
+			middleware.AuditedRouteKey("POST", handlers.OAuthTokenPath),
+			middleware.AuditedRouteKey("POST", handlers.ResourceMatchesPath),
+			middleware.AuditedRouteKey("POST", handlers.SpaceManifestDiffPath),
+		}
+
+		unaudited := []string{}
+		for _, routeKey := range routeKeys {
+			if strings.HasPrefix(routeKey, "GET ") || slices.Contains(notAudited, routeKey) {
+				continue
+			}
+
+			if _, ok := handlers.AuditedRoutes[routeKey]; !ok {
+				unaudited = append(unaudited, routeKey)
+			}
+		}
+		Expect(unaudited).To(BeEmpty(), "these mutating routes are not audited")
+	})
+
+	It("only audits registered routes", func() {
+		unregistered := []string{}
+		for routeKey := range handlers.AuditedRoutes {
+			if !slices.Contains(routeKeys, routeKey) {
+				unregistered = append(unregistered, routeKey)
+			}
+		}
+		Expect(unregistered).To(BeEmpty(), "these audited routes are not registered")
+	})
+})
+
+// registeredRouteKeys collects the routing.Route literals declared by the
+// handlers, so that routes cannot be added without being considered here
+func registeredRouteKeys() []string {
+	GinkgoHelper()
+
+	fileNames, err := filepath.Glob("*.go")
+	Expect(err).NotTo(HaveOccurred())
+
+	fileSet := token.NewFileSet()
+	constants := map[string]ast.Expr{}
+	routes := []*ast.CompositeLit{}
+	for _, fileName := range fileNames {
+		if strings.HasSuffix(fileName, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, fileName, nil, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.CONST {
+				for _, spec := range genDecl.Specs {
+					valueSpec := spec.(*ast.ValueSpec)
+					for i, name := range valueSpec.Names {
+						if i < len(valueSpec.Values) {
+							constants[name.Name] = valueSpec.Values[i]
+						}
+					}
+				}
+			}
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			literal, ok := node.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+
+			if isRouteType(literal.Type) {
+				routes = append(routes, literal)
+			}
+			if array, ok := literal.Type.(*ast.ArrayType); ok && isRouteType(array.Elt) {
+				for _, elt := range literal.Elts {
+					if route, ok := elt.(*ast.CompositeLit); ok && route.Type == nil {
+						routes = append(routes, route)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	routeKeys := []string{}
+	for _, route := range routes {
+		var method, pattern string
+		for _, elt := range route.Elts {
+			field := elt.(*ast.KeyValueExpr)
+			switch field.Key.(*ast.Ident).Name {
+			case "Method":
+				method = stringValue(constants, field.Value)
+			case "Pattern":
+				pattern = stringValue(constants, field.Value)
+			}
+		}
+		Expect(method).NotTo(BeEmpty(), "unexpected route method at %s", fileSet.Position(route.Pos()))
+		Expect(pattern).NotTo(BeEmpty(), "unexpected route pattern at %s", fileSet.Position(route.Pos()))
+
+		routeKeys = append(routeKeys, middleware.AuditedRouteKey(method, pattern))
+	}
+
+	return routeKeys
+}
+
+func isRouteType(expr ast.Expr) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "routing" && selector.Sel.Name == "Route"
+}
+
+// stringValue evaluates string constant expressions such as
+// `RolesPath + "/{guid}"`, returning an empty string for anything else
+func stringValue(constants map[string]ast.Expr, expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return ""
+		}
+		value, err := strconv.Unquote(e.Value)
+		if err != nil {
+			return ""
+		}
+		return value
+	case *ast.Ident:
+		if value, ok := constants[e.Name]; ok {
+			return stringValue(constants, value)
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return stringValue(constants, e.X) + stringValue(constants, e.Y)
+		}
+	}
+
+	return ""
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -35,17 +36,20 @@ type Domain struct {
 	serverURL        url.URL
 	requestValidator RequestValidator
 	domainRepo       CFDomainRepository
+	routerGroupRepo  RouterGroupRepository
 }
 
 func NewDomain(
 	serverURL url.URL,
 	requestValidator RequestValidator,
 	domainRepo CFDomainRepository,
+	routerGroupRepo RouterGroupRepository,
 ) *Domain {
 	return &Domain{
 		serverURL:        serverURL,
 		requestValidator: requestValidator,
 		domainRepo:       domainRepo,
+		routerGroupRepo:  routerGroupRepo,
 	}
 }
 
@@ -64,6 +68,22 @@ func (h *Domain) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.RouterGroup != "" {
+		_, err = h.routerGroupRepo.GetRouterGroup(r.Context(), domainCreateMessage.RouterGroup)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(
+					err,
+					fmt.Sprintf("Router group with guid '%s' not found.", domainCreateMessage.RouterGroup),
+					apierrors.NotFoundError{},
+				),
+				"Failed to get router group",
+				"routerGroup", domainCreateMessage.RouterGroup,
+			)
+		}
+	}
+
 	domain, err := h.domainRepo.CreateDomain(r.Context(), authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
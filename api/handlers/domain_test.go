@@ -21,6 +21,7 @@ var _ = Describe("Domain", func() {
 	var (
 		apiHandler       *handlers.Domain
 		domainRepo       *fake.CFDomainRepository
+		routerGroupRepo  *fake.RouterGroupRepository
 		requestValidator *fake.RequestValidator
 		req              *http.Request
 	)
@@ -28,10 +29,12 @@ var _ = Describe("Domain", func() {
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		domainRepo = new(fake.CFDomainRepository)
+		routerGroupRepo = new(fake.RouterGroupRepository)
 		apiHandler = handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			routerGroupRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -108,11 +111,47 @@ var _ = Describe("Domain", func() {
 
 		When("the decoded payload is not valid", func() {
 			BeforeEach(func() {
-				payload.Internal = true
+				payload.Relationships = map[string]payloads.Relationship{"organization": {}}
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Error converting domain payload to repository message: internal domains are not supported")
+				expectUnprocessableEntityError("Error converting domain payload to repository message: private domains are not supported")
+			})
+		})
+
+		It("does not look up a router group", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(BeZero())
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				payload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
+			})
+
+			It("creates the domain with the router group", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, actualRouterGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(actualRouterGroupGUID).To(Equal("default-tcp"))
+
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.RouterGroup).To(Equal("default-tcp"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the router group does not exist", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Router group with guid 'default-tcp' not found.")
+				})
+
+				It("does not create the domain", func() {
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
 			})
 		})
 
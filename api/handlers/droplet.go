@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -17,7 +18,10 @@ import (
 )
 
 const (
-	DropletPath = "/v3/droplets/{guid}"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletsPath        = "/v3/droplets"
+	DropletUploadPath   = "/v3/droplets/{guid}/upload"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
@@ -25,23 +29,41 @@ type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) ([]repositories.DropletRecord, error)
 	UpdateDroplet(context.Context, authorization.Info, repositories.UpdateDropletMessage) (repositories.DropletRecord, error)
+	CreateDroplet(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	UpdateDropletSource(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name DropletImageRepository . DropletImageRepository
+type DropletImageRepository interface {
+	UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, tarReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error)
+	CopyDropletImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, dstRepoRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
 }
 
 type Droplet struct {
-	serverURL        url.URL
-	dropletRepo      CFDropletRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	dropletRepo         CFDropletRepository
+	appRepo             CFAppRepository
+	imageRepo           DropletImageRepository
+	requestValidator    RequestValidator
+	registrySecretNames []string
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo DropletImageRepository,
 	requestValidator RequestValidator,
+	registrySecretNames []string,
 ) *Droplet {
 	return &Droplet{
-		serverURL:        serverURL,
-		dropletRepo:      dropletRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		dropletRepo:         dropletRepo,
+		appRepo:             appRepo,
+		imageRepo:           imageRepo,
+		requestValidator:    requestValidator,
+		registrySecretNames: registrySecretNames,
 	}
 }
 
@@ -93,6 +115,225 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.create")
+
+	if r.URL.Query().Has("source_guid") {
+		return h.copy(r)
+	}
+
+	var payload payloads.DropletCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.getBuildpackApp(r.Context(), logger, authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+// copy creates a droplet for the app in the payload out of the source droplet.
+// The droplet image is copied to the droplet repository of the app, so that
+// the new droplet can be assigned to the app straight away.
+func (h *Droplet) copy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.copy")
+
+	var payload payloads.DropletCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceGUID := r.URL.Query().Get("source_guid")
+	sourceDroplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source droplet is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source droplet",
+			"Source Droplet GUID", sourceGUID,
+		)
+	}
+
+	if sourceDroplet.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source droplet must be in the STAGED state."),
+			"cannot copy a droplet that is not staged",
+			"Source Droplet GUID", sourceGUID,
+		)
+	}
+
+	if sourceDroplet.Lifecycle.Type != "buildpack" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source droplet lifecycle type must be buildpack."),
+			fmt.Sprintf("copying %s droplets is not supported", sourceDroplet.Lifecycle.Type),
+			"Source Droplet GUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.getBuildpackApp(r.Context(), logger, authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord, sourceDroplet))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	copiedImageRef, err := h.imageRepo.CopyDropletImage(r.Context(), authInfo, sourceDroplet.DropletImageRef, droplet.ImageRef, droplet.SpaceGUID, droplet.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling CopyDropletImage", "Source Droplet GUID", sourceGUID)
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletSource(r.Context(), authInfo, repositories.UpdateDropletSourceMessage{
+		GUID:                droplet.GUID,
+		SpaceGUID:           droplet.SpaceGUID,
+		ImageRef:            copiedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletSource")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) getBuildpackApp(ctx context.Context, logger logr.Logger, authInfo authorization.Info, appGUID string) (repositories.AppRecord, error) {
+	appRecord, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return repositories.AppRecord{}, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", appGUID,
+		)
+	}
+
+	if appRecord.Lifecycle.Type != "buildpack" {
+		return repositories.AppRecord{}, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplets can only be created for buildpack apps."),
+			fmt.Sprintf("creating droplets for %s apps is not supported", appRecord.Lifecycle.Type),
+			"App GUID", appGUID,
+		)
+	}
+
+	return appRecord, nil
+}
+
+func (h *Droplet) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.upload")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	err := r.ParseForm()
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	bitsFile, _, err := r.FormFile("bits")
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
+	}
+	defer bitsFile.Close()
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.State != repositories.DropletStateAwaitingUpload {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet must be in the AWAITING_UPLOAD state."),
+			"Error, cannot upload droplet bits when the state is not AWAITING_UPLOAD", "dropletGUID", dropletGUID,
+		)
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadDropletImage(r.Context(), authInfo, droplet.ImageRef, bitsFile, droplet.SpaceGUID, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UploadDropletImage")
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletSource(r.Context(), authInfo, repositories.UpdateDropletSourceMessage{
+		GUID:                dropletGUID,
+		SpaceGUID:           droplet.SpaceGUID,
+		ImageRef:            uploadedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletSource")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(dropletGUID, presenter.DropletUploadOperation, h.serverURL)).
+		WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.Lifecycle.Type != "buildpack" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot download droplets with '%s' lifecycle.", droplet.Lifecycle.Type)),
+			"downloading non buildpack droplets is not supported", "dropletGUID", dropletGUID,
+		)
+	}
+
+	if droplet.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Only staged droplets can be downloaded."),
+			"Error, cannot download droplet bits before they are staged", "dropletGUID", dropletGUID,
+		)
+	}
+
+	bitsReader, err := h.imageRepo.DownloadDropletImage(r.Context(), authInfo, droplet.DropletImageRef, droplet.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling DownloadDropletImage")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/gzip").
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tgz")).
+		WithStream(func(w http.ResponseWriter) error {
+			defer bitsReader.Close()
+
+			_, err := io.Copy(w, bitsReader)
+			return err
+		}), nil
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -101,5 +342,8 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "POST", Pattern: DropletsPath, Handler: h.create},
+		{Method: "POST", Pattern: DropletUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
@@ -1,7 +1,11 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
@@ -31,11 +35,15 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.DropletImageRepository
 		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.DropletImageRepository)
 		var err error
 		req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID, nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -45,7 +53,10 @@ var _ = Describe("Droplet", func() {
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			[]string{"registry-secret"},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -240,4 +251,456 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/droplets endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCreate{
+				Relationships: &payloads.DropletRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: appGUID},
+					},
+				},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			})
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs4"},
+				},
+			}, nil)
+
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:    dropletGUID,
+				State:   "AWAITING_UPLOAD",
+				AppGUID: appGUID,
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/droplets", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the droplet", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      appGUID,
+				SpaceGUID:    "space-guid",
+				Stack:        "cflinuxfs4",
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is a docker app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplets can only be created for buildpack apps.")
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{}, errors.New("create-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the source_guid query parameter is set", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCopy{
+					Relationships: &payloads.DropletRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{GUID: appGUID},
+						},
+					},
+				})
+
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  "source-droplet-guid",
+					State: "STAGED",
+					Lifecycle: repositories.Lifecycle{
+						Type: "buildpack",
+					},
+					Stack:           "cflinuxfs4",
+					ProcessTypes:    map[string]string{"web": "bundle exec rackup"},
+					DropletImageRef: "registry.repo/source-droplets@sha256:123",
+				}, nil)
+
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					SpaceGUID: "space-guid",
+					ImageRef:  "registry.repo/app-droplets",
+					State:     "AWAITING_UPLOAD",
+				}, nil)
+
+				imageRepo.CopyDropletImageReturns("registry.repo/app-droplets@sha256:123", nil)
+
+				dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: "PROCESSING_UPLOAD",
+				}, nil)
+
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "POST", "/v3/droplets?source_guid=source-droplet-guid", strings.NewReader("the-json-body"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates a droplet out of the source droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, _, actualSourceGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualSourceGUID).To(Equal("source-droplet-guid"))
+
+				Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+				_, _, message := dropletRepo.CreateDropletArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateDropletMessage{
+					AppGUID:      appGUID,
+					SpaceGUID:    "space-guid",
+					Stack:        "cflinuxfs4",
+					ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				}))
+			})
+
+			It("copies the droplet image", func() {
+				Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(1))
+				_, actualAuthInfo, srcRef, dstRef, actualSpaceGUID, actualTags := imageRepo.CopyDropletImageArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(srcRef).To(Equal("registry.repo/source-droplets@sha256:123"))
+				Expect(dstRef).To(Equal("registry.repo/app-droplets"))
+				Expect(actualSpaceGUID).To(Equal("space-guid"))
+				Expect(actualTags).To(ConsistOf(dropletGUID))
+
+				Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(1))
+				_, _, updateMessage := dropletRepo.UpdateDropletSourceArgsForCall(0)
+				Expect(updateMessage).To(Equal(repositories.UpdateDropletSourceMessage{
+					GUID:                dropletGUID,
+					SpaceGUID:           "space-guid",
+					ImageRef:            "registry.repo/app-droplets@sha256:123",
+					RegistrySecretNames: []string{"registry-secret"},
+				}))
+			})
+
+			It("returns the new droplet", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", dropletGUID),
+					MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+				)))
+			})
+
+			When("the source droplet does not exist", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewNotFoundError(nil, repositories.DropletResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Source droplet is invalid. Ensure it exists and you have access to it.")
+				})
+			})
+
+			When("the source droplet is not staged", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{
+						State:     "AWAITING_UPLOAD",
+						Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Source droplet must be in the STAGED state.")
+					Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+				})
+			})
+
+			When("the source droplet is a docker droplet", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{
+						State:     "STAGED",
+						Lifecycle: repositories.Lifecycle{Type: "docker"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Source droplet lifecycle type must be buildpack.")
+					Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+				})
+			})
+
+			When("copying the droplet image fails", func() {
+				BeforeEach(func() {
+					imageRepo.CopyDropletImageReturns("", errors.New("copy-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+					Expect(dropletRepo.UpdateDropletSourceCallCount()).To(BeZero())
+				})
+			})
+
+			When("updating the droplet source fails", func() {
+				BeforeEach(func() {
+					dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{}, errors.New("update-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets/:guid/upload endpoint", func() {
+		uploadRequest := func(writeForm func(*multipart.Writer)) *http.Request {
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			writeForm(writer)
+			Expect(writer.Close()).To(Succeed())
+
+			uploadReq, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("/v3/droplets/%s/upload", dropletGUID), &b)
+			Expect(err).NotTo(HaveOccurred())
+			uploadReq.Header.Add("Content-Type", writer.FormDataContentType())
+
+			return uploadReq
+		}
+
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				SpaceGUID: "space-guid",
+				State:     "AWAITING_UPLOAD",
+				ImageRef:  "registry.repo/app-droplets",
+			}, nil)
+
+			imageRepo.UploadDropletImageReturns("registry.repo/app-droplets@sha256:123", nil)
+
+			dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{
+				GUID:  dropletGUID,
+				State: "PROCESSING_UPLOAD",
+			}, nil)
+
+			req = uploadRequest(func(writer *multipart.Writer) {
+				part, err := writer.CreateFormFile("bits", "droplet.tgz")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader("the-droplet-contents"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("uploads the droplet image", func() {
+			Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, repoRef, bitsFile, actualSpaceGUID, actualTags := imageRepo.UploadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(repoRef).To(Equal("registry.repo/app-droplets"))
+			Expect(io.ReadAll(bitsFile)).To(BeEquivalentTo("the-droplet-contents"))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualTags).To(ConsistOf(dropletGUID))
+
+			Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(1))
+			_, _, updateMessage := dropletRepo.UpdateDropletSourceArgsForCall(0)
+			Expect(updateMessage).To(Equal(repositories.UpdateDropletSourceMessage{
+				GUID:                dropletGUID,
+				SpaceGUID:           "space-guid",
+				ImageRef:            "registry.repo/app-droplets@sha256:123",
+				RegistrySecretNames: []string{"registry-secret"},
+			}))
+		})
+
+		It("returns the droplet and a job to poll", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/droplet.upload~"+dropletGUID))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+			)))
+		})
+
+		When("no bits file is given", func() {
+			BeforeEach(func() {
+				req = uploadRequest(func(*multipart.Writer) {})
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Upload must include bits")
+				Expect(imageRepo.UploadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Droplet")
+				Expect(imageRepo.UploadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet is not awaiting upload", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{State: "STAGED"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplet must be in the AWAITING_UPLOAD state.")
+				Expect(imageRepo.UploadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("uploading the droplet image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadDropletImageReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+				Expect(dropletRepo.UpdateDropletSourceCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the droplet source fails", func() {
+			BeforeEach(func() {
+				dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:            dropletGUID,
+				SpaceGUID:       "space-guid",
+				State:           "STAGED",
+				Lifecycle:       repositories.Lifecycle{Type: "buildpack"},
+				DropletImageRef: "registry.repo/app-droplets@sha256:123",
+			}, nil)
+
+			imageRepo.DownloadDropletImageReturns(io.NopCloser(strings.NewReader("the-tgz-contents")), nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v3/droplets/%s/download", dropletGUID), nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("downloads the droplet image", func() {
+			Expect(imageRepo.DownloadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, actualSpaceGUID := imageRepo.DownloadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/app-droplets@sha256:123"))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+		})
+
+		It("streams the droplet as a tgz", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/gzip"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tgz")))
+			Expect(rr).To(HaveHTTPBody("the-tgz-contents"))
+		})
+
+		When("getting the droplet is forbidden", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Droplet")
+				Expect(imageRepo.DownloadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet is a docker droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					State:     "STAGED",
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot download droplets with 'docker' lifecycle.")
+			})
+		})
+
+		When("the droplet is not staged", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					State:     "AWAITING_UPLOAD",
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only staged droplets can be downloaded.")
+				Expect(imageRepo.DownloadDropletImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadDropletImageReturns(nil, errors.New("download-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
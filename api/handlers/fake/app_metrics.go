@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type AppMetrics struct {
+	FetchMetricsStub        func(context.Context, authorization.Info, string) ([]actions.InstanceMetrics, error)
+	fetchMetricsMutex       sync.RWMutex
+	fetchMetricsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	fetchMetricsReturns struct {
+		result1 []actions.InstanceMetrics
+		result2 error
+	}
+	fetchMetricsReturnsOnCall map[int]struct {
+		result1 []actions.InstanceMetrics
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *AppMetrics) FetchMetrics(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]actions.InstanceMetrics, error) {
+	fake.fetchMetricsMutex.Lock()
+	ret, specificReturn := fake.fetchMetricsReturnsOnCall[len(fake.fetchMetricsArgsForCall)]
+	fake.fetchMetricsArgsForCall = append(fake.fetchMetricsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.FetchMetricsStub
+	fakeReturns := fake.fetchMetricsReturns
+	fake.recordInvocation("FetchMetrics", []interface{}{arg1, arg2, arg3})
+	fake.fetchMetricsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AppMetrics) FetchMetricsCallCount() int {
+	fake.fetchMetricsMutex.RLock()
+	defer fake.fetchMetricsMutex.RUnlock()
+	return len(fake.fetchMetricsArgsForCall)
+}
+
+func (fake *AppMetrics) FetchMetricsCalls(stub func(context.Context, authorization.Info, string) ([]actions.InstanceMetrics, error)) {
+	fake.fetchMetricsMutex.Lock()
+	defer fake.fetchMetricsMutex.Unlock()
+	fake.FetchMetricsStub = stub
+}
+
+func (fake *AppMetrics) FetchMetricsArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.fetchMetricsMutex.RLock()
+	defer fake.fetchMetricsMutex.RUnlock()
+	argsForCall := fake.fetchMetricsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *AppMetrics) FetchMetricsReturns(result1 []actions.InstanceMetrics, result2 error) {
+	fake.fetchMetricsMutex.Lock()
+	defer fake.fetchMetricsMutex.Unlock()
+	fake.FetchMetricsStub = nil
+	fake.fetchMetricsReturns = struct {
+		result1 []actions.InstanceMetrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AppMetrics) FetchMetricsReturnsOnCall(i int, result1 []actions.InstanceMetrics, result2 error) {
+	fake.fetchMetricsMutex.Lock()
+	defer fake.fetchMetricsMutex.Unlock()
+	fake.FetchMetricsStub = nil
+	if fake.fetchMetricsReturnsOnCall == nil {
+		fake.fetchMetricsReturnsOnCall = make(map[int]struct {
+			result1 []actions.InstanceMetrics
+			result2 error
+		})
+	}
+	fake.fetchMetricsReturnsOnCall[i] = struct {
+		result1 []actions.InstanceMetrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AppMetrics) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.fetchMetricsMutex.RLock()
+	defer fake.fetchMetricsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *AppMetrics) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AppMetrics = new(AppMetrics)
@@ -11,6 +11,21 @@ import (
 )
 
 type CFDropletRepository struct {
+	CreateDropletStub        func(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	createDropletMutex       sync.RWMutex
+	createDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateDropletMessage
+	}
+	createDropletReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	createDropletReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
 	GetDropletStub        func(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	getDropletMutex       sync.RWMutex
 	getDropletArgsForCall []struct {
@@ -56,10 +71,91 @@ type CFDropletRepository struct {
 		result1 repositories.DropletRecord
 		result2 error
 	}
+	UpdateDropletSourceStub        func(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)
+	updateDropletSourceMutex       sync.RWMutex
+	updateDropletSourceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDropletSourceMessage
+	}
+	updateDropletSourceReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	updateDropletSourceReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFDropletRepository) CreateDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateDropletMessage) (repositories.DropletRecord, error) {
+	fake.createDropletMutex.Lock()
+	ret, specificReturn := fake.createDropletReturnsOnCall[len(fake.createDropletArgsForCall)]
+	fake.createDropletArgsForCall = append(fake.createDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateDropletMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateDropletStub
+	fakeReturns := fake.createDropletReturns
+	fake.recordInvocation("CreateDroplet", []interface{}{arg1, arg2, arg3})
+	fake.createDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) CreateDropletCallCount() int {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	return len(fake.createDropletArgsForCall)
+}
+
+func (fake *CFDropletRepository) CreateDropletCalls(stub func(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = stub
+}
+
+func (fake *CFDropletRepository) CreateDropletArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateDropletMessage) {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	argsForCall := fake.createDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) CreateDropletReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = nil
+	fake.createDropletReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) CreateDropletReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = nil
+	if fake.createDropletReturnsOnCall == nil {
+		fake.createDropletReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.createDropletReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDropletRepository) GetDroplet(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DropletRecord, error) {
 	fake.getDropletMutex.Lock()
 	ret, specificReturn := fake.getDropletReturnsOnCall[len(fake.getDropletArgsForCall)]
@@ -258,15 +354,85 @@ func (fake *CFDropletRepository) UpdateDropletReturnsOnCall(i int, result1 repos
 	}{result1, result2}
 }
 
+func (fake *CFDropletRepository) UpdateDropletSource(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error) {
+	fake.updateDropletSourceMutex.Lock()
+	ret, specificReturn := fake.updateDropletSourceReturnsOnCall[len(fake.updateDropletSourceArgsForCall)]
+	fake.updateDropletSourceArgsForCall = append(fake.updateDropletSourceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDropletSourceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateDropletSourceStub
+	fakeReturns := fake.updateDropletSourceReturns
+	fake.recordInvocation("UpdateDropletSource", []interface{}{arg1, arg2, arg3})
+	fake.updateDropletSourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceCallCount() int {
+	fake.updateDropletSourceMutex.RLock()
+	defer fake.updateDropletSourceMutex.RUnlock()
+	return len(fake.updateDropletSourceArgsForCall)
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceCalls(stub func(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)) {
+	fake.updateDropletSourceMutex.Lock()
+	defer fake.updateDropletSourceMutex.Unlock()
+	fake.UpdateDropletSourceStub = stub
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) {
+	fake.updateDropletSourceMutex.RLock()
+	defer fake.updateDropletSourceMutex.RUnlock()
+	argsForCall := fake.updateDropletSourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.updateDropletSourceMutex.Lock()
+	defer fake.updateDropletSourceMutex.Unlock()
+	fake.UpdateDropletSourceStub = nil
+	fake.updateDropletSourceReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.updateDropletSourceMutex.Lock()
+	defer fake.updateDropletSourceMutex.Unlock()
+	fake.UpdateDropletSourceStub = nil
+	if fake.updateDropletSourceReturnsOnCall == nil {
+		fake.updateDropletSourceReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.updateDropletSourceReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDropletRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
 	fake.getDropletMutex.RLock()
 	defer fake.getDropletMutex.RUnlock()
 	fake.listDropletsMutex.RLock()
 	defer fake.listDropletsMutex.RUnlock()
 	fake.updateDropletMutex.RLock()
 	defer fake.updateDropletMutex.RUnlock()
+	fake.updateDropletSourceMutex.RLock()
+	defer fake.updateDropletSourceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFEnvVarGroupRepository struct {
+	GetEnvVarGroupStub        func(context.Context, authorization.Info, string) (repositories.EnvVarGroupRecord, error)
+	getEnvVarGroupMutex       sync.RWMutex
+	getEnvVarGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getEnvVarGroupReturns struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	getEnvVarGroupReturnsOnCall map[int]struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	UpdateEnvVarGroupStub        func(context.Context, authorization.Info, repositories.UpdateEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error)
+	updateEnvVarGroupMutex       sync.RWMutex
+	updateEnvVarGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateEnvVarGroupMessage
+	}
+	updateEnvVarGroupReturns struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	updateEnvVarGroupReturnsOnCall map[int]struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroup(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.EnvVarGroupRecord, error) {
+	fake.getEnvVarGroupMutex.Lock()
+	ret, specificReturn := fake.getEnvVarGroupReturnsOnCall[len(fake.getEnvVarGroupArgsForCall)]
+	fake.getEnvVarGroupArgsForCall = append(fake.getEnvVarGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetEnvVarGroupStub
+	fakeReturns := fake.getEnvVarGroupReturns
+	fake.recordInvocation("GetEnvVarGroup", []interface{}{arg1, arg2, arg3})
+	fake.getEnvVarGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupCallCount() int {
+	fake.getEnvVarGroupMutex.RLock()
+	defer fake.getEnvVarGroupMutex.RUnlock()
+	return len(fake.getEnvVarGroupArgsForCall)
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupCalls(stub func(context.Context, authorization.Info, string) (repositories.EnvVarGroupRecord, error)) {
+	fake.getEnvVarGroupMutex.Lock()
+	defer fake.getEnvVarGroupMutex.Unlock()
+	fake.GetEnvVarGroupStub = stub
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getEnvVarGroupMutex.RLock()
+	defer fake.getEnvVarGroupMutex.RUnlock()
+	argsForCall := fake.getEnvVarGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupReturns(result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.getEnvVarGroupMutex.Lock()
+	defer fake.getEnvVarGroupMutex.Unlock()
+	fake.GetEnvVarGroupStub = nil
+	fake.getEnvVarGroupReturns = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupReturnsOnCall(i int, result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.getEnvVarGroupMutex.Lock()
+	defer fake.getEnvVarGroupMutex.Unlock()
+	fake.GetEnvVarGroupStub = nil
+	if fake.getEnvVarGroupReturnsOnCall == nil {
+		fake.getEnvVarGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.EnvVarGroupRecord
+			result2 error
+		})
+	}
+	fake.getEnvVarGroupReturnsOnCall[i] = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) UpdateEnvVarGroup(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error) {
+	fake.updateEnvVarGroupMutex.Lock()
+	ret, specificReturn := fake.updateEnvVarGroupReturnsOnCall[len(fake.updateEnvVarGroupArgsForCall)]
+	fake.updateEnvVarGroupArgsForCall = append(fake.updateEnvVarGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateEnvVarGroupMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateEnvVarGroupStub
+	fakeReturns := fake.updateEnvVarGroupReturns
+	fake.recordInvocation("UpdateEnvVarGroup", []interface{}{arg1, arg2, arg3})
+	fake.updateEnvVarGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFEnvVarGroupRepository) UpdateEnvVarGroupCallCount() int {
+	fake.updateEnvVarGroupMutex.RLock()
+	defer fake.updateEnvVarGroupMutex.RUnlock()
+	return len(fake.updateEnvVarGroupArgsForCall)
+}
+
+func (fake *CFEnvVarGroupRepository) UpdateEnvVarGroupCalls(stub func(context.Context, authorization.Info, repositories.UpdateEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error)) {
+	fake.updateEnvVarGroupMutex.Lock()
+	defer fake.updateEnvVarGroupMutex.Unlock()
+	fake.UpdateEnvVarGroupStub = stub
+}
+
+func (fake *CFEnvVarGroupRepository) UpdateEnvVarGroupArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateEnvVarGroupMessage) {
+	fake.updateEnvVarGroupMutex.RLock()
+	defer fake.updateEnvVarGroupMutex.RUnlock()
+	argsForCall := fake.updateEnvVarGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFEnvVarGroupRepository) UpdateEnvVarGroupReturns(result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.updateEnvVarGroupMutex.Lock()
+	defer fake.updateEnvVarGroupMutex.Unlock()
+	fake.UpdateEnvVarGroupStub = nil
+	fake.updateEnvVarGroupReturns = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) UpdateEnvVarGroupReturnsOnCall(i int, result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.updateEnvVarGroupMutex.Lock()
+	defer fake.updateEnvVarGroupMutex.Unlock()
+	fake.UpdateEnvVarGroupStub = nil
+	if fake.updateEnvVarGroupReturnsOnCall == nil {
+		fake.updateEnvVarGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.EnvVarGroupRecord
+			result2 error
+		})
+	}
+	fake.updateEnvVarGroupReturnsOnCall[i] = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getEnvVarGroupMutex.RLock()
+	defer fake.getEnvVarGroupMutex.RUnlock()
+	fake.updateEnvVarGroupMutex.RLock()
+	defer fake.updateEnvVarGroupMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFEnvVarGroupRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFEnvVarGroupRepository = new(CFEnvVarGroupRepository)
@@ -0,0 +1,538 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFFeatureFlagRepository struct {
+	GetFeatureFlagStub        func(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)
+	getFeatureFlagMutex       sync.RWMutex
+	getFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getFeatureFlagReturns struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	getFeatureFlagReturnsOnCall map[int]struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	GetSpaceFeatureStub        func(context.Context, authorization.Info, string, string) (repositories.SpaceFeatureRecord, error)
+	getSpaceFeatureMutex       sync.RWMutex
+	getSpaceFeatureArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	getSpaceFeatureReturns struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}
+	getSpaceFeatureReturnsOnCall map[int]struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}
+	ListFeatureFlagsStub        func(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)
+	listFeatureFlagsMutex       sync.RWMutex
+	listFeatureFlagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	listFeatureFlagsReturns struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}
+	listFeatureFlagsReturnsOnCall map[int]struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}
+	ListSpaceFeaturesStub        func(context.Context, authorization.Info, string) ([]repositories.SpaceFeatureRecord, error)
+	listSpaceFeaturesMutex       sync.RWMutex
+	listSpaceFeaturesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	listSpaceFeaturesReturns struct {
+		result1 []repositories.SpaceFeatureRecord
+		result2 error
+	}
+	listSpaceFeaturesReturnsOnCall map[int]struct {
+		result1 []repositories.SpaceFeatureRecord
+		result2 error
+	}
+	UpdateFeatureFlagStub        func(context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)
+	updateFeatureFlagMutex       sync.RWMutex
+	updateFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateFeatureFlagMessage
+	}
+	updateFeatureFlagReturns struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	updateFeatureFlagReturnsOnCall map[int]struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}
+	UpdateSpaceFeatureStub        func(context.Context, authorization.Info, repositories.UpdateSpaceFeatureMessage) (repositories.SpaceFeatureRecord, error)
+	updateSpaceFeatureMutex       sync.RWMutex
+	updateSpaceFeatureArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSpaceFeatureMessage
+	}
+	updateSpaceFeatureReturns struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}
+	updateSpaceFeatureReturnsOnCall map[int]struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.FeatureFlagRecord, error) {
+	fake.getFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.getFeatureFlagReturnsOnCall[len(fake.getFeatureFlagArgsForCall)]
+	fake.getFeatureFlagArgsForCall = append(fake.getFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetFeatureFlagStub
+	fakeReturns := fake.getFeatureFlagReturns
+	fake.recordInvocation("GetFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.getFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagCallCount() int {
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	return len(fake.getFeatureFlagArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagCalls(stub func(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	argsForCall := fake.getFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagReturns(result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = nil
+	fake.getFeatureFlagReturns = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) GetFeatureFlagReturnsOnCall(i int, result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.getFeatureFlagMutex.Lock()
+	defer fake.getFeatureFlagMutex.Unlock()
+	fake.GetFeatureFlagStub = nil
+	if fake.getFeatureFlagReturnsOnCall == nil {
+		fake.getFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.getFeatureFlagReturnsOnCall[i] = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) GetSpaceFeature(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (repositories.SpaceFeatureRecord, error) {
+	fake.getSpaceFeatureMutex.Lock()
+	ret, specificReturn := fake.getSpaceFeatureReturnsOnCall[len(fake.getSpaceFeatureArgsForCall)]
+	fake.getSpaceFeatureArgsForCall = append(fake.getSpaceFeatureArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetSpaceFeatureStub
+	fakeReturns := fake.getSpaceFeatureReturns
+	fake.recordInvocation("GetSpaceFeature", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getSpaceFeatureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) GetSpaceFeatureCallCount() int {
+	fake.getSpaceFeatureMutex.RLock()
+	defer fake.getSpaceFeatureMutex.RUnlock()
+	return len(fake.getSpaceFeatureArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) GetSpaceFeatureCalls(stub func(context.Context, authorization.Info, string, string) (repositories.SpaceFeatureRecord, error)) {
+	fake.getSpaceFeatureMutex.Lock()
+	defer fake.getSpaceFeatureMutex.Unlock()
+	fake.GetSpaceFeatureStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) GetSpaceFeatureArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.getSpaceFeatureMutex.RLock()
+	defer fake.getSpaceFeatureMutex.RUnlock()
+	argsForCall := fake.getSpaceFeatureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *CFFeatureFlagRepository) GetSpaceFeatureReturns(result1 repositories.SpaceFeatureRecord, result2 error) {
+	fake.getSpaceFeatureMutex.Lock()
+	defer fake.getSpaceFeatureMutex.Unlock()
+	fake.GetSpaceFeatureStub = nil
+	fake.getSpaceFeatureReturns = struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) GetSpaceFeatureReturnsOnCall(i int, result1 repositories.SpaceFeatureRecord, result2 error) {
+	fake.getSpaceFeatureMutex.Lock()
+	defer fake.getSpaceFeatureMutex.Unlock()
+	fake.GetSpaceFeatureStub = nil
+	if fake.getSpaceFeatureReturnsOnCall == nil {
+		fake.getSpaceFeatureReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceFeatureRecord
+			result2 error
+		})
+	}
+	fake.getSpaceFeatureReturnsOnCall[i] = struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlags(arg1 context.Context, arg2 authorization.Info) ([]repositories.FeatureFlagRecord, error) {
+	fake.listFeatureFlagsMutex.Lock()
+	ret, specificReturn := fake.listFeatureFlagsReturnsOnCall[len(fake.listFeatureFlagsArgsForCall)]
+	fake.listFeatureFlagsArgsForCall = append(fake.listFeatureFlagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.ListFeatureFlagsStub
+	fakeReturns := fake.listFeatureFlagsReturns
+	fake.recordInvocation("ListFeatureFlags", []interface{}{arg1, arg2})
+	fake.listFeatureFlagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsCallCount() int {
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	return len(fake.listFeatureFlagsArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsCalls(stub func(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	argsForCall := fake.listFeatureFlagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsReturns(result1 []repositories.FeatureFlagRecord, result2 error) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = nil
+	fake.listFeatureFlagsReturns = struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListFeatureFlagsReturnsOnCall(i int, result1 []repositories.FeatureFlagRecord, result2 error) {
+	fake.listFeatureFlagsMutex.Lock()
+	defer fake.listFeatureFlagsMutex.Unlock()
+	fake.ListFeatureFlagsStub = nil
+	if fake.listFeatureFlagsReturnsOnCall == nil {
+		fake.listFeatureFlagsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.listFeatureFlagsReturnsOnCall[i] = struct {
+		result1 []repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListSpaceFeatures(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]repositories.SpaceFeatureRecord, error) {
+	fake.listSpaceFeaturesMutex.Lock()
+	ret, specificReturn := fake.listSpaceFeaturesReturnsOnCall[len(fake.listSpaceFeaturesArgsForCall)]
+	fake.listSpaceFeaturesArgsForCall = append(fake.listSpaceFeaturesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListSpaceFeaturesStub
+	fakeReturns := fake.listSpaceFeaturesReturns
+	fake.recordInvocation("ListSpaceFeatures", []interface{}{arg1, arg2, arg3})
+	fake.listSpaceFeaturesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) ListSpaceFeaturesCallCount() int {
+	fake.listSpaceFeaturesMutex.RLock()
+	defer fake.listSpaceFeaturesMutex.RUnlock()
+	return len(fake.listSpaceFeaturesArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) ListSpaceFeaturesCalls(stub func(context.Context, authorization.Info, string) ([]repositories.SpaceFeatureRecord, error)) {
+	fake.listSpaceFeaturesMutex.Lock()
+	defer fake.listSpaceFeaturesMutex.Unlock()
+	fake.ListSpaceFeaturesStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) ListSpaceFeaturesArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.listSpaceFeaturesMutex.RLock()
+	defer fake.listSpaceFeaturesMutex.RUnlock()
+	argsForCall := fake.listSpaceFeaturesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) ListSpaceFeaturesReturns(result1 []repositories.SpaceFeatureRecord, result2 error) {
+	fake.listSpaceFeaturesMutex.Lock()
+	defer fake.listSpaceFeaturesMutex.Unlock()
+	fake.ListSpaceFeaturesStub = nil
+	fake.listSpaceFeaturesReturns = struct {
+		result1 []repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) ListSpaceFeaturesReturnsOnCall(i int, result1 []repositories.SpaceFeatureRecord, result2 error) {
+	fake.listSpaceFeaturesMutex.Lock()
+	defer fake.listSpaceFeaturesMutex.Unlock()
+	fake.ListSpaceFeaturesStub = nil
+	if fake.listSpaceFeaturesReturnsOnCall == nil {
+		fake.listSpaceFeaturesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SpaceFeatureRecord
+			result2 error
+		})
+	}
+	fake.listSpaceFeaturesReturnsOnCall[i] = struct {
+		result1 []repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error) {
+	fake.updateFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.updateFeatureFlagReturnsOnCall[len(fake.updateFeatureFlagArgsForCall)]
+	fake.updateFeatureFlagArgsForCall = append(fake.updateFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateFeatureFlagMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateFeatureFlagStub
+	fakeReturns := fake.updateFeatureFlagReturns
+	fake.recordInvocation("UpdateFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.updateFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagCallCount() int {
+	fake.updateFeatureFlagMutex.RLock()
+	defer fake.updateFeatureFlagMutex.RUnlock()
+	return len(fake.updateFeatureFlagArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagCalls(stub func(context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)) {
+	fake.updateFeatureFlagMutex.Lock()
+	defer fake.updateFeatureFlagMutex.Unlock()
+	fake.UpdateFeatureFlagStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) {
+	fake.updateFeatureFlagMutex.RLock()
+	defer fake.updateFeatureFlagMutex.RUnlock()
+	argsForCall := fake.updateFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagReturns(result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.updateFeatureFlagMutex.Lock()
+	defer fake.updateFeatureFlagMutex.Unlock()
+	fake.UpdateFeatureFlagStub = nil
+	fake.updateFeatureFlagReturns = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) UpdateFeatureFlagReturnsOnCall(i int, result1 repositories.FeatureFlagRecord, result2 error) {
+	fake.updateFeatureFlagMutex.Lock()
+	defer fake.updateFeatureFlagMutex.Unlock()
+	fake.UpdateFeatureFlagStub = nil
+	if fake.updateFeatureFlagReturnsOnCall == nil {
+		fake.updateFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 repositories.FeatureFlagRecord
+			result2 error
+		})
+	}
+	fake.updateFeatureFlagReturnsOnCall[i] = struct {
+		result1 repositories.FeatureFlagRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) UpdateSpaceFeature(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateSpaceFeatureMessage) (repositories.SpaceFeatureRecord, error) {
+	fake.updateSpaceFeatureMutex.Lock()
+	ret, specificReturn := fake.updateSpaceFeatureReturnsOnCall[len(fake.updateSpaceFeatureArgsForCall)]
+	fake.updateSpaceFeatureArgsForCall = append(fake.updateSpaceFeatureArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSpaceFeatureMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateSpaceFeatureStub
+	fakeReturns := fake.updateSpaceFeatureReturns
+	fake.recordInvocation("UpdateSpaceFeature", []interface{}{arg1, arg2, arg3})
+	fake.updateSpaceFeatureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFFeatureFlagRepository) UpdateSpaceFeatureCallCount() int {
+	fake.updateSpaceFeatureMutex.RLock()
+	defer fake.updateSpaceFeatureMutex.RUnlock()
+	return len(fake.updateSpaceFeatureArgsForCall)
+}
+
+func (fake *CFFeatureFlagRepository) UpdateSpaceFeatureCalls(stub func(context.Context, authorization.Info, repositories.UpdateSpaceFeatureMessage) (repositories.SpaceFeatureRecord, error)) {
+	fake.updateSpaceFeatureMutex.Lock()
+	defer fake.updateSpaceFeatureMutex.Unlock()
+	fake.UpdateSpaceFeatureStub = stub
+}
+
+func (fake *CFFeatureFlagRepository) UpdateSpaceFeatureArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateSpaceFeatureMessage) {
+	fake.updateSpaceFeatureMutex.RLock()
+	defer fake.updateSpaceFeatureMutex.RUnlock()
+	argsForCall := fake.updateSpaceFeatureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFFeatureFlagRepository) UpdateSpaceFeatureReturns(result1 repositories.SpaceFeatureRecord, result2 error) {
+	fake.updateSpaceFeatureMutex.Lock()
+	defer fake.updateSpaceFeatureMutex.Unlock()
+	fake.UpdateSpaceFeatureStub = nil
+	fake.updateSpaceFeatureReturns = struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) UpdateSpaceFeatureReturnsOnCall(i int, result1 repositories.SpaceFeatureRecord, result2 error) {
+	fake.updateSpaceFeatureMutex.Lock()
+	defer fake.updateSpaceFeatureMutex.Unlock()
+	fake.UpdateSpaceFeatureStub = nil
+	if fake.updateSpaceFeatureReturnsOnCall == nil {
+		fake.updateSpaceFeatureReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceFeatureRecord
+			result2 error
+		})
+	}
+	fake.updateSpaceFeatureReturnsOnCall[i] = struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFFeatureFlagRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getFeatureFlagMutex.RLock()
+	defer fake.getFeatureFlagMutex.RUnlock()
+	fake.getSpaceFeatureMutex.RLock()
+	defer fake.getSpaceFeatureMutex.RUnlock()
+	fake.listFeatureFlagsMutex.RLock()
+	defer fake.listFeatureFlagsMutex.RUnlock()
+	fake.listSpaceFeaturesMutex.RLock()
+	defer fake.listSpaceFeaturesMutex.RUnlock()
+	fake.updateFeatureFlagMutex.RLock()
+	defer fake.updateFeatureFlagMutex.RUnlock()
+	fake.updateSpaceFeatureMutex.RLock()
+	defer fake.updateSpaceFeatureMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFFeatureFlagRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFFeatureFlagRepository = new(CFFeatureFlagRepository)
@@ -0,0 +1,279 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFNetworkPolicyRepository struct {
+	CreateNetworkPoliciesStub        func(context.Context, authorization.Info, repositories.CreateNetworkPoliciesMessage) error
+	createNetworkPoliciesMutex       sync.RWMutex
+	createNetworkPoliciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateNetworkPoliciesMessage
+	}
+	createNetworkPoliciesReturns struct {
+		result1 error
+	}
+	createNetworkPoliciesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteNetworkPoliciesStub        func(context.Context, authorization.Info, repositories.DeleteNetworkPoliciesMessage) error
+	deleteNetworkPoliciesMutex       sync.RWMutex
+	deleteNetworkPoliciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteNetworkPoliciesMessage
+	}
+	deleteNetworkPoliciesReturns struct {
+		result1 error
+	}
+	deleteNetworkPoliciesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListNetworkPoliciesStub        func(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)
+	listNetworkPoliciesMutex       sync.RWMutex
+	listNetworkPoliciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListNetworkPoliciesMessage
+	}
+	listNetworkPoliciesReturns struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}
+	listNetworkPoliciesReturnsOnCall map[int]struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicies(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateNetworkPoliciesMessage) error {
+	fake.createNetworkPoliciesMutex.Lock()
+	ret, specificReturn := fake.createNetworkPoliciesReturnsOnCall[len(fake.createNetworkPoliciesArgsForCall)]
+	fake.createNetworkPoliciesArgsForCall = append(fake.createNetworkPoliciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateNetworkPoliciesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateNetworkPoliciesStub
+	fakeReturns := fake.createNetworkPoliciesReturns
+	fake.recordInvocation("CreateNetworkPolicies", []interface{}{arg1, arg2, arg3})
+	fake.createNetworkPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPoliciesCallCount() int {
+	fake.createNetworkPoliciesMutex.RLock()
+	defer fake.createNetworkPoliciesMutex.RUnlock()
+	return len(fake.createNetworkPoliciesArgsForCall)
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPoliciesCalls(stub func(context.Context, authorization.Info, repositories.CreateNetworkPoliciesMessage) error) {
+	fake.createNetworkPoliciesMutex.Lock()
+	defer fake.createNetworkPoliciesMutex.Unlock()
+	fake.CreateNetworkPoliciesStub = stub
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPoliciesArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateNetworkPoliciesMessage) {
+	fake.createNetworkPoliciesMutex.RLock()
+	defer fake.createNetworkPoliciesMutex.RUnlock()
+	argsForCall := fake.createNetworkPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPoliciesReturns(result1 error) {
+	fake.createNetworkPoliciesMutex.Lock()
+	defer fake.createNetworkPoliciesMutex.Unlock()
+	fake.CreateNetworkPoliciesStub = nil
+	fake.createNetworkPoliciesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPoliciesReturnsOnCall(i int, result1 error) {
+	fake.createNetworkPoliciesMutex.Lock()
+	defer fake.createNetworkPoliciesMutex.Unlock()
+	fake.CreateNetworkPoliciesStub = nil
+	if fake.createNetworkPoliciesReturnsOnCall == nil {
+		fake.createNetworkPoliciesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createNetworkPoliciesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicies(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteNetworkPoliciesMessage) error {
+	fake.deleteNetworkPoliciesMutex.Lock()
+	ret, specificReturn := fake.deleteNetworkPoliciesReturnsOnCall[len(fake.deleteNetworkPoliciesArgsForCall)]
+	fake.deleteNetworkPoliciesArgsForCall = append(fake.deleteNetworkPoliciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteNetworkPoliciesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteNetworkPoliciesStub
+	fakeReturns := fake.deleteNetworkPoliciesReturns
+	fake.recordInvocation("DeleteNetworkPolicies", []interface{}{arg1, arg2, arg3})
+	fake.deleteNetworkPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPoliciesCallCount() int {
+	fake.deleteNetworkPoliciesMutex.RLock()
+	defer fake.deleteNetworkPoliciesMutex.RUnlock()
+	return len(fake.deleteNetworkPoliciesArgsForCall)
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPoliciesCalls(stub func(context.Context, authorization.Info, repositories.DeleteNetworkPoliciesMessage) error) {
+	fake.deleteNetworkPoliciesMutex.Lock()
+	defer fake.deleteNetworkPoliciesMutex.Unlock()
+	fake.DeleteNetworkPoliciesStub = stub
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPoliciesArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteNetworkPoliciesMessage) {
+	fake.deleteNetworkPoliciesMutex.RLock()
+	defer fake.deleteNetworkPoliciesMutex.RUnlock()
+	argsForCall := fake.deleteNetworkPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPoliciesReturns(result1 error) {
+	fake.deleteNetworkPoliciesMutex.Lock()
+	defer fake.deleteNetworkPoliciesMutex.Unlock()
+	fake.DeleteNetworkPoliciesStub = nil
+	fake.deleteNetworkPoliciesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPoliciesReturnsOnCall(i int, result1 error) {
+	fake.deleteNetworkPoliciesMutex.Lock()
+	defer fake.deleteNetworkPoliciesMutex.Unlock()
+	fake.DeleteNetworkPoliciesStub = nil
+	if fake.deleteNetworkPoliciesReturnsOnCall == nil {
+		fake.deleteNetworkPoliciesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteNetworkPoliciesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPolicies(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	ret, specificReturn := fake.listNetworkPoliciesReturnsOnCall[len(fake.listNetworkPoliciesArgsForCall)]
+	fake.listNetworkPoliciesArgsForCall = append(fake.listNetworkPoliciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListNetworkPoliciesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListNetworkPoliciesStub
+	fakeReturns := fake.listNetworkPoliciesReturns
+	fake.recordInvocation("ListNetworkPolicies", []interface{}{arg1, arg2, arg3})
+	fake.listNetworkPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesCallCount() int {
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	return len(fake.listNetworkPoliciesArgsForCall)
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesCalls(stub func(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = stub
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesArgsForCall(i int) (context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) {
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	argsForCall := fake.listNetworkPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesReturns(result1 []repositories.NetworkPolicyRecord, result2 error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = nil
+	fake.listNetworkPoliciesReturns = struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesReturnsOnCall(i int, result1 []repositories.NetworkPolicyRecord, result2 error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = nil
+	if fake.listNetworkPoliciesReturnsOnCall == nil {
+		fake.listNetworkPoliciesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.NetworkPolicyRecord
+			result2 error
+		})
+	}
+	fake.listNetworkPoliciesReturnsOnCall[i] = struct {
+		result1 []repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFNetworkPolicyRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createNetworkPoliciesMutex.RLock()
+	defer fake.createNetworkPoliciesMutex.RUnlock()
+	fake.deleteNetworkPoliciesMutex.RLock()
+	defer fake.deleteNetworkPoliciesMutex.RUnlock()
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFNetworkPolicyRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFNetworkPolicyRepository = new(CFNetworkPolicyRepository)
@@ -0,0 +1,455 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFOrgQuotaRepository struct {
+	ApplyOrgQuotaStub        func(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	applyOrgQuotaMutex       sync.RWMutex
+	applyOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplyOrgQuotaMessage
+	}
+	applyOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	applyOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	CreateOrgQuotaStub        func(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	createOrgQuotaMutex       sync.RWMutex
+	createOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateOrgQuotaMessage
+	}
+	createOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	createOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	GetOrgQuotaStub        func(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	getOrgQuotaMutex       sync.RWMutex
+	getOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	getOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	ListOrgQuotasStub        func(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	listOrgQuotasMutex       sync.RWMutex
+	listOrgQuotasArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListOrgQuotasMessage
+	}
+	listOrgQuotasReturns struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}
+	listOrgQuotasReturnsOnCall map[int]struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}
+	UpdateOrgQuotaStub        func(context.Context, authorization.Info, repositories.UpdateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	updateOrgQuotaMutex       sync.RWMutex
+	updateOrgQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateOrgQuotaMessage
+	}
+	updateOrgQuotaReturns struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	updateOrgQuotaReturnsOnCall map[int]struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFOrgQuotaRepository) ApplyOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error) {
+	fake.applyOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.applyOrgQuotaReturnsOnCall[len(fake.applyOrgQuotaArgsForCall)]
+	fake.applyOrgQuotaArgsForCall = append(fake.applyOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplyOrgQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ApplyOrgQuotaStub
+	fakeReturns := fake.applyOrgQuotaReturns
+	fake.recordInvocation("ApplyOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.applyOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFOrgQuotaRepository) ApplyOrgQuotaCallCount() int {
+	fake.applyOrgQuotaMutex.RLock()
+	defer fake.applyOrgQuotaMutex.RUnlock()
+	return len(fake.applyOrgQuotaArgsForCall)
+}
+
+func (fake *CFOrgQuotaRepository) ApplyOrgQuotaCalls(stub func(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)) {
+	fake.applyOrgQuotaMutex.Lock()
+	defer fake.applyOrgQuotaMutex.Unlock()
+	fake.ApplyOrgQuotaStub = stub
+}
+
+func (fake *CFOrgQuotaRepository) ApplyOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) {
+	fake.applyOrgQuotaMutex.RLock()
+	defer fake.applyOrgQuotaMutex.RUnlock()
+	argsForCall := fake.applyOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFOrgQuotaRepository) ApplyOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.applyOrgQuotaMutex.Lock()
+	defer fake.applyOrgQuotaMutex.Unlock()
+	fake.ApplyOrgQuotaStub = nil
+	fake.applyOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) ApplyOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.applyOrgQuotaMutex.Lock()
+	defer fake.applyOrgQuotaMutex.Unlock()
+	fake.ApplyOrgQuotaStub = nil
+	if fake.applyOrgQuotaReturnsOnCall == nil {
+		fake.applyOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.applyOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) CreateOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error) {
+	fake.createOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.createOrgQuotaReturnsOnCall[len(fake.createOrgQuotaArgsForCall)]
+	fake.createOrgQuotaArgsForCall = append(fake.createOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateOrgQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateOrgQuotaStub
+	fakeReturns := fake.createOrgQuotaReturns
+	fake.recordInvocation("CreateOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.createOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFOrgQuotaRepository) CreateOrgQuotaCallCount() int {
+	fake.createOrgQuotaMutex.RLock()
+	defer fake.createOrgQuotaMutex.RUnlock()
+	return len(fake.createOrgQuotaArgsForCall)
+}
+
+func (fake *CFOrgQuotaRepository) CreateOrgQuotaCalls(stub func(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)) {
+	fake.createOrgQuotaMutex.Lock()
+	defer fake.createOrgQuotaMutex.Unlock()
+	fake.CreateOrgQuotaStub = stub
+}
+
+func (fake *CFOrgQuotaRepository) CreateOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) {
+	fake.createOrgQuotaMutex.RLock()
+	defer fake.createOrgQuotaMutex.RUnlock()
+	argsForCall := fake.createOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFOrgQuotaRepository) CreateOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.createOrgQuotaMutex.Lock()
+	defer fake.createOrgQuotaMutex.Unlock()
+	fake.CreateOrgQuotaStub = nil
+	fake.createOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) CreateOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.createOrgQuotaMutex.Lock()
+	defer fake.createOrgQuotaMutex.Unlock()
+	fake.CreateOrgQuotaStub = nil
+	if fake.createOrgQuotaReturnsOnCall == nil {
+		fake.createOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.createOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) GetOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.OrgQuotaRecord, error) {
+	fake.getOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.getOrgQuotaReturnsOnCall[len(fake.getOrgQuotaArgsForCall)]
+	fake.getOrgQuotaArgsForCall = append(fake.getOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetOrgQuotaStub
+	fakeReturns := fake.getOrgQuotaReturns
+	fake.recordInvocation("GetOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.getOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFOrgQuotaRepository) GetOrgQuotaCallCount() int {
+	fake.getOrgQuotaMutex.RLock()
+	defer fake.getOrgQuotaMutex.RUnlock()
+	return len(fake.getOrgQuotaArgsForCall)
+}
+
+func (fake *CFOrgQuotaRepository) GetOrgQuotaCalls(stub func(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)) {
+	fake.getOrgQuotaMutex.Lock()
+	defer fake.getOrgQuotaMutex.Unlock()
+	fake.GetOrgQuotaStub = stub
+}
+
+func (fake *CFOrgQuotaRepository) GetOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getOrgQuotaMutex.RLock()
+	defer fake.getOrgQuotaMutex.RUnlock()
+	argsForCall := fake.getOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFOrgQuotaRepository) GetOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.getOrgQuotaMutex.Lock()
+	defer fake.getOrgQuotaMutex.Unlock()
+	fake.GetOrgQuotaStub = nil
+	fake.getOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) GetOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.getOrgQuotaMutex.Lock()
+	defer fake.getOrgQuotaMutex.Unlock()
+	fake.GetOrgQuotaStub = nil
+	if fake.getOrgQuotaReturnsOnCall == nil {
+		fake.getOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.getOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) ListOrgQuotas(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error) {
+	fake.listOrgQuotasMutex.Lock()
+	ret, specificReturn := fake.listOrgQuotasReturnsOnCall[len(fake.listOrgQuotasArgsForCall)]
+	fake.listOrgQuotasArgsForCall = append(fake.listOrgQuotasArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListOrgQuotasMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListOrgQuotasStub
+	fakeReturns := fake.listOrgQuotasReturns
+	fake.recordInvocation("ListOrgQuotas", []interface{}{arg1, arg2, arg3})
+	fake.listOrgQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFOrgQuotaRepository) ListOrgQuotasCallCount() int {
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	return len(fake.listOrgQuotasArgsForCall)
+}
+
+func (fake *CFOrgQuotaRepository) ListOrgQuotasCalls(stub func(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = stub
+}
+
+func (fake *CFOrgQuotaRepository) ListOrgQuotasArgsForCall(i int) (context.Context, authorization.Info, repositories.ListOrgQuotasMessage) {
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	argsForCall := fake.listOrgQuotasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFOrgQuotaRepository) ListOrgQuotasReturns(result1 []repositories.OrgQuotaRecord, result2 error) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = nil
+	fake.listOrgQuotasReturns = struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) ListOrgQuotasReturnsOnCall(i int, result1 []repositories.OrgQuotaRecord, result2 error) {
+	fake.listOrgQuotasMutex.Lock()
+	defer fake.listOrgQuotasMutex.Unlock()
+	fake.ListOrgQuotasStub = nil
+	if fake.listOrgQuotasReturnsOnCall == nil {
+		fake.listOrgQuotasReturnsOnCall = make(map[int]struct {
+			result1 []repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.listOrgQuotasReturnsOnCall[i] = struct {
+		result1 []repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) UpdateOrgQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateOrgQuotaMessage) (repositories.OrgQuotaRecord, error) {
+	fake.updateOrgQuotaMutex.Lock()
+	ret, specificReturn := fake.updateOrgQuotaReturnsOnCall[len(fake.updateOrgQuotaArgsForCall)]
+	fake.updateOrgQuotaArgsForCall = append(fake.updateOrgQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateOrgQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateOrgQuotaStub
+	fakeReturns := fake.updateOrgQuotaReturns
+	fake.recordInvocation("UpdateOrgQuota", []interface{}{arg1, arg2, arg3})
+	fake.updateOrgQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFOrgQuotaRepository) UpdateOrgQuotaCallCount() int {
+	fake.updateOrgQuotaMutex.RLock()
+	defer fake.updateOrgQuotaMutex.RUnlock()
+	return len(fake.updateOrgQuotaArgsForCall)
+}
+
+func (fake *CFOrgQuotaRepository) UpdateOrgQuotaCalls(stub func(context.Context, authorization.Info, repositories.UpdateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)) {
+	fake.updateOrgQuotaMutex.Lock()
+	defer fake.updateOrgQuotaMutex.Unlock()
+	fake.UpdateOrgQuotaStub = stub
+}
+
+func (fake *CFOrgQuotaRepository) UpdateOrgQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateOrgQuotaMessage) {
+	fake.updateOrgQuotaMutex.RLock()
+	defer fake.updateOrgQuotaMutex.RUnlock()
+	argsForCall := fake.updateOrgQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFOrgQuotaRepository) UpdateOrgQuotaReturns(result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.updateOrgQuotaMutex.Lock()
+	defer fake.updateOrgQuotaMutex.Unlock()
+	fake.UpdateOrgQuotaStub = nil
+	fake.updateOrgQuotaReturns = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) UpdateOrgQuotaReturnsOnCall(i int, result1 repositories.OrgQuotaRecord, result2 error) {
+	fake.updateOrgQuotaMutex.Lock()
+	defer fake.updateOrgQuotaMutex.Unlock()
+	fake.UpdateOrgQuotaStub = nil
+	if fake.updateOrgQuotaReturnsOnCall == nil {
+		fake.updateOrgQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgQuotaRecord
+			result2 error
+		})
+	}
+	fake.updateOrgQuotaReturnsOnCall[i] = struct {
+		result1 repositories.OrgQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFOrgQuotaRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.applyOrgQuotaMutex.RLock()
+	defer fake.applyOrgQuotaMutex.RUnlock()
+	fake.createOrgQuotaMutex.RLock()
+	defer fake.createOrgQuotaMutex.RUnlock()
+	fake.getOrgQuotaMutex.RLock()
+	defer fake.getOrgQuotaMutex.RUnlock()
+	fake.listOrgQuotasMutex.RLock()
+	defer fake.listOrgQuotasMutex.RUnlock()
+	fake.updateOrgQuotaMutex.RLock()
+	defer fake.updateOrgQuotaMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFOrgQuotaRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFOrgQuotaRepository = new(CFOrgQuotaRepository)
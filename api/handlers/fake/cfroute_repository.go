@@ -84,13 +84,12 @@ type CFRouteRepository struct {
 		result1 []repositories.RouteRecord
 		result2 error
 	}
-	ListRoutesForAppStub        func(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
+	ListRoutesForAppStub        func(context.Context, authorization.Info, string) ([]repositories.RouteRecord, error)
 	listRoutesForAppMutex       sync.RWMutex
 	listRoutesForAppArgsForCall []struct {
 		arg1 context.Context
 		arg2 authorization.Info
 		arg3 string
-		arg4 string
 	}
 	listRoutesForAppReturns struct {
 		result1 []repositories.RouteRecord
@@ -130,6 +129,51 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	ShareRouteStub        func(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	shareRouteMutex       sync.RWMutex
+	shareRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareRouteMessage
+	}
+	shareRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	shareRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	TransferRouteStub        func(context.Context, authorization.Info, repositories.TransferRouteMessage) (repositories.RouteRecord, error)
+	transferRouteMutex       sync.RWMutex
+	transferRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.TransferRouteMessage
+	}
+	transferRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	transferRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	UnshareRouteStub        func(context.Context, authorization.Info, repositories.UnshareRouteMessage) (repositories.RouteRecord, error)
+	unshareRouteMutex       sync.RWMutex
+	unshareRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareRouteMessage
+	}
+	unshareRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	unshareRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -461,21 +505,20 @@ func (fake *CFRouteRepository) ListRoutesReturnsOnCall(i int, result1 []reposito
 	}{result1, result2}
 }
 
-func (fake *CFRouteRepository) ListRoutesForApp(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) ([]repositories.RouteRecord, error) {
+func (fake *CFRouteRepository) ListRoutesForApp(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]repositories.RouteRecord, error) {
 	fake.listRoutesForAppMutex.Lock()
 	ret, specificReturn := fake.listRoutesForAppReturnsOnCall[len(fake.listRoutesForAppArgsForCall)]
 	fake.listRoutesForAppArgsForCall = append(fake.listRoutesForAppArgsForCall, struct {
 		arg1 context.Context
 		arg2 authorization.Info
 		arg3 string
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
+	}{arg1, arg2, arg3})
 	stub := fake.ListRoutesForAppStub
 	fakeReturns := fake.listRoutesForAppReturns
-	fake.recordInvocation("ListRoutesForApp", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ListRoutesForApp", []interface{}{arg1, arg2, arg3})
 	fake.listRoutesForAppMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -489,17 +532,17 @@ func (fake *CFRouteRepository) ListRoutesForAppCallCount() int {
 	return len(fake.listRoutesForAppArgsForCall)
 }
 
-func (fake *CFRouteRepository) ListRoutesForAppCalls(stub func(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)) {
+func (fake *CFRouteRepository) ListRoutesForAppCalls(stub func(context.Context, authorization.Info, string) ([]repositories.RouteRecord, error)) {
 	fake.listRoutesForAppMutex.Lock()
 	defer fake.listRoutesForAppMutex.Unlock()
 	fake.ListRoutesForAppStub = stub
 }
 
-func (fake *CFRouteRepository) ListRoutesForAppArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+func (fake *CFRouteRepository) ListRoutesForAppArgsForCall(i int) (context.Context, authorization.Info, string) {
 	fake.listRoutesForAppMutex.RLock()
 	defer fake.listRoutesForAppMutex.RUnlock()
 	argsForCall := fake.listRoutesForAppArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *CFRouteRepository) ListRoutesForAppReturns(result1 []repositories.RouteRecord, result2 error) {
@@ -660,6 +703,204 @@ func (fake *CFRouteRepository) RemoveDestinationFromRouteReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) ShareRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareRouteMessage) (repositories.RouteRecord, error) {
+	fake.shareRouteMutex.Lock()
+	ret, specificReturn := fake.shareRouteReturnsOnCall[len(fake.shareRouteArgsForCall)]
+	fake.shareRouteArgsForCall = append(fake.shareRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareRouteStub
+	fakeReturns := fake.shareRouteReturns
+	fake.recordInvocation("ShareRoute", []interface{}{arg1, arg2, arg3})
+	fake.shareRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) ShareRouteCallCount() int {
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	return len(fake.shareRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) ShareRouteCalls(stub func(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = stub
+}
+
+func (fake *CFRouteRepository) ShareRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareRouteMessage) {
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	argsForCall := fake.shareRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) ShareRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = nil
+	fake.shareRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ShareRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = nil
+	if fake.shareRouteReturnsOnCall == nil {
+		fake.shareRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.shareRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) TransferRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.TransferRouteMessage) (repositories.RouteRecord, error) {
+	fake.transferRouteMutex.Lock()
+	ret, specificReturn := fake.transferRouteReturnsOnCall[len(fake.transferRouteArgsForCall)]
+	fake.transferRouteArgsForCall = append(fake.transferRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.TransferRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.TransferRouteStub
+	fakeReturns := fake.transferRouteReturns
+	fake.recordInvocation("TransferRoute", []interface{}{arg1, arg2, arg3})
+	fake.transferRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) TransferRouteCallCount() int {
+	fake.transferRouteMutex.RLock()
+	defer fake.transferRouteMutex.RUnlock()
+	return len(fake.transferRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) TransferRouteCalls(stub func(context.Context, authorization.Info, repositories.TransferRouteMessage) (repositories.RouteRecord, error)) {
+	fake.transferRouteMutex.Lock()
+	defer fake.transferRouteMutex.Unlock()
+	fake.TransferRouteStub = stub
+}
+
+func (fake *CFRouteRepository) TransferRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.TransferRouteMessage) {
+	fake.transferRouteMutex.RLock()
+	defer fake.transferRouteMutex.RUnlock()
+	argsForCall := fake.transferRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) TransferRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.transferRouteMutex.Lock()
+	defer fake.transferRouteMutex.Unlock()
+	fake.TransferRouteStub = nil
+	fake.transferRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) TransferRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.transferRouteMutex.Lock()
+	defer fake.transferRouteMutex.Unlock()
+	fake.TransferRouteStub = nil
+	if fake.transferRouteReturnsOnCall == nil {
+		fake.transferRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.transferRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) UnshareRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareRouteMessage) (repositories.RouteRecord, error) {
+	fake.unshareRouteMutex.Lock()
+	ret, specificReturn := fake.unshareRouteReturnsOnCall[len(fake.unshareRouteArgsForCall)]
+	fake.unshareRouteArgsForCall = append(fake.unshareRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareRouteStub
+	fakeReturns := fake.unshareRouteReturns
+	fake.recordInvocation("UnshareRoute", []interface{}{arg1, arg2, arg3})
+	fake.unshareRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) UnshareRouteCallCount() int {
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
+	return len(fake.unshareRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) UnshareRouteCalls(stub func(context.Context, authorization.Info, repositories.UnshareRouteMessage) (repositories.RouteRecord, error)) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = stub
+}
+
+func (fake *CFRouteRepository) UnshareRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareRouteMessage) {
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
+	argsForCall := fake.unshareRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) UnshareRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = nil
+	fake.unshareRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) UnshareRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = nil
+	if fake.unshareRouteReturnsOnCall == nil {
+		fake.unshareRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.unshareRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -679,6 +920,12 @@ func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	defer fake.patchRouteMetadataMutex.RUnlock()
 	fake.removeDestinationFromRouteMutex.RLock()
 	defer fake.removeDestinationFromRouteMutex.RUnlock()
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	fake.transferRouteMutex.RLock()
+	defer fake.transferRouteMutex.RUnlock()
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
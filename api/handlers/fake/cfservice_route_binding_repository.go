@@ -0,0 +1,284 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFServiceRouteBindingRepository struct {
+	DeleteServiceRouteBindingStub        func(context.Context, authorization.Info, string) error
+	deleteServiceRouteBindingMutex       sync.RWMutex
+	deleteServiceRouteBindingArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteServiceRouteBindingReturns struct {
+		result1 error
+	}
+	deleteServiceRouteBindingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetServiceRouteBindingStub        func(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)
+	getServiceRouteBindingMutex       sync.RWMutex
+	getServiceRouteBindingArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceRouteBindingReturns struct {
+		result1 repositories.ServiceRouteBindingRecord
+		result2 error
+	}
+	getServiceRouteBindingReturnsOnCall map[int]struct {
+		result1 repositories.ServiceRouteBindingRecord
+		result2 error
+	}
+	ListServiceRouteBindingsStub        func(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error)
+	listServiceRouteBindingsMutex       sync.RWMutex
+	listServiceRouteBindingsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceRouteBindingsMessage
+	}
+	listServiceRouteBindingsReturns struct {
+		result1 []repositories.ServiceRouteBindingRecord
+		result2 error
+	}
+	listServiceRouteBindingsReturnsOnCall map[int]struct {
+		result1 []repositories.ServiceRouteBindingRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFServiceRouteBindingRepository) DeleteServiceRouteBinding(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteServiceRouteBindingMutex.Lock()
+	ret, specificReturn := fake.deleteServiceRouteBindingReturnsOnCall[len(fake.deleteServiceRouteBindingArgsForCall)]
+	fake.deleteServiceRouteBindingArgsForCall = append(fake.deleteServiceRouteBindingArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteServiceRouteBindingStub
+	fakeReturns := fake.deleteServiceRouteBindingReturns
+	fake.recordInvocation("DeleteServiceRouteBinding", []interface{}{arg1, arg2, arg3})
+	fake.deleteServiceRouteBindingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFServiceRouteBindingRepository) DeleteServiceRouteBindingCallCount() int {
+	fake.deleteServiceRouteBindingMutex.RLock()
+	defer fake.deleteServiceRouteBindingMutex.RUnlock()
+	return len(fake.deleteServiceRouteBindingArgsForCall)
+}
+
+func (fake *CFServiceRouteBindingRepository) DeleteServiceRouteBindingCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteServiceRouteBindingMutex.Lock()
+	defer fake.deleteServiceRouteBindingMutex.Unlock()
+	fake.DeleteServiceRouteBindingStub = stub
+}
+
+func (fake *CFServiceRouteBindingRepository) DeleteServiceRouteBindingArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteServiceRouteBindingMutex.RLock()
+	defer fake.deleteServiceRouteBindingMutex.RUnlock()
+	argsForCall := fake.deleteServiceRouteBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceRouteBindingRepository) DeleteServiceRouteBindingReturns(result1 error) {
+	fake.deleteServiceRouteBindingMutex.Lock()
+	defer fake.deleteServiceRouteBindingMutex.Unlock()
+	fake.DeleteServiceRouteBindingStub = nil
+	fake.deleteServiceRouteBindingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceRouteBindingRepository) DeleteServiceRouteBindingReturnsOnCall(i int, result1 error) {
+	fake.deleteServiceRouteBindingMutex.Lock()
+	defer fake.deleteServiceRouteBindingMutex.Unlock()
+	fake.DeleteServiceRouteBindingStub = nil
+	if fake.deleteServiceRouteBindingReturnsOnCall == nil {
+		fake.deleteServiceRouteBindingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteServiceRouteBindingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceRouteBindingRepository) GetServiceRouteBinding(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServiceRouteBindingRecord, error) {
+	fake.getServiceRouteBindingMutex.Lock()
+	ret, specificReturn := fake.getServiceRouteBindingReturnsOnCall[len(fake.getServiceRouteBindingArgsForCall)]
+	fake.getServiceRouteBindingArgsForCall = append(fake.getServiceRouteBindingArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceRouteBindingStub
+	fakeReturns := fake.getServiceRouteBindingReturns
+	fake.recordInvocation("GetServiceRouteBinding", []interface{}{arg1, arg2, arg3})
+	fake.getServiceRouteBindingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceRouteBindingRepository) GetServiceRouteBindingCallCount() int {
+	fake.getServiceRouteBindingMutex.RLock()
+	defer fake.getServiceRouteBindingMutex.RUnlock()
+	return len(fake.getServiceRouteBindingArgsForCall)
+}
+
+func (fake *CFServiceRouteBindingRepository) GetServiceRouteBindingCalls(stub func(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)) {
+	fake.getServiceRouteBindingMutex.Lock()
+	defer fake.getServiceRouteBindingMutex.Unlock()
+	fake.GetServiceRouteBindingStub = stub
+}
+
+func (fake *CFServiceRouteBindingRepository) GetServiceRouteBindingArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceRouteBindingMutex.RLock()
+	defer fake.getServiceRouteBindingMutex.RUnlock()
+	argsForCall := fake.getServiceRouteBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceRouteBindingRepository) GetServiceRouteBindingReturns(result1 repositories.ServiceRouteBindingRecord, result2 error) {
+	fake.getServiceRouteBindingMutex.Lock()
+	defer fake.getServiceRouteBindingMutex.Unlock()
+	fake.GetServiceRouteBindingStub = nil
+	fake.getServiceRouteBindingReturns = struct {
+		result1 repositories.ServiceRouteBindingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceRouteBindingRepository) GetServiceRouteBindingReturnsOnCall(i int, result1 repositories.ServiceRouteBindingRecord, result2 error) {
+	fake.getServiceRouteBindingMutex.Lock()
+	defer fake.getServiceRouteBindingMutex.Unlock()
+	fake.GetServiceRouteBindingStub = nil
+	if fake.getServiceRouteBindingReturnsOnCall == nil {
+		fake.getServiceRouteBindingReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceRouteBindingRecord
+			result2 error
+		})
+	}
+	fake.getServiceRouteBindingReturnsOnCall[i] = struct {
+		result1 repositories.ServiceRouteBindingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceRouteBindingRepository) ListServiceRouteBindings(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error) {
+	fake.listServiceRouteBindingsMutex.Lock()
+	ret, specificReturn := fake.listServiceRouteBindingsReturnsOnCall[len(fake.listServiceRouteBindingsArgsForCall)]
+	fake.listServiceRouteBindingsArgsForCall = append(fake.listServiceRouteBindingsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceRouteBindingsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListServiceRouteBindingsStub
+	fakeReturns := fake.listServiceRouteBindingsReturns
+	fake.recordInvocation("ListServiceRouteBindings", []interface{}{arg1, arg2, arg3})
+	fake.listServiceRouteBindingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceRouteBindingRepository) ListServiceRouteBindingsCallCount() int {
+	fake.listServiceRouteBindingsMutex.RLock()
+	defer fake.listServiceRouteBindingsMutex.RUnlock()
+	return len(fake.listServiceRouteBindingsArgsForCall)
+}
+
+func (fake *CFServiceRouteBindingRepository) ListServiceRouteBindingsCalls(stub func(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error)) {
+	fake.listServiceRouteBindingsMutex.Lock()
+	defer fake.listServiceRouteBindingsMutex.Unlock()
+	fake.ListServiceRouteBindingsStub = stub
+}
+
+func (fake *CFServiceRouteBindingRepository) ListServiceRouteBindingsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) {
+	fake.listServiceRouteBindingsMutex.RLock()
+	defer fake.listServiceRouteBindingsMutex.RUnlock()
+	argsForCall := fake.listServiceRouteBindingsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceRouteBindingRepository) ListServiceRouteBindingsReturns(result1 []repositories.ServiceRouteBindingRecord, result2 error) {
+	fake.listServiceRouteBindingsMutex.Lock()
+	defer fake.listServiceRouteBindingsMutex.Unlock()
+	fake.ListServiceRouteBindingsStub = nil
+	fake.listServiceRouteBindingsReturns = struct {
+		result1 []repositories.ServiceRouteBindingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceRouteBindingRepository) ListServiceRouteBindingsReturnsOnCall(i int, result1 []repositories.ServiceRouteBindingRecord, result2 error) {
+	fake.listServiceRouteBindingsMutex.Lock()
+	defer fake.listServiceRouteBindingsMutex.Unlock()
+	fake.ListServiceRouteBindingsStub = nil
+	if fake.listServiceRouteBindingsReturnsOnCall == nil {
+		fake.listServiceRouteBindingsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ServiceRouteBindingRecord
+			result2 error
+		})
+	}
+	fake.listServiceRouteBindingsReturnsOnCall[i] = struct {
+		result1 []repositories.ServiceRouteBindingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceRouteBindingRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteServiceRouteBindingMutex.RLock()
+	defer fake.deleteServiceRouteBindingMutex.RUnlock()
+	fake.getServiceRouteBindingMutex.RLock()
+	defer fake.getServiceRouteBindingMutex.RUnlock()
+	fake.listServiceRouteBindingsMutex.RLock()
+	defer fake.listServiceRouteBindingsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFServiceRouteBindingRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFServiceRouteBindingRepository = new(CFServiceRouteBindingRepository)
@@ -0,0 +1,524 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFUsageEventRepository struct {
+	GetAppUsageEventStub        func(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)
+	getAppUsageEventMutex       sync.RWMutex
+	getAppUsageEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getAppUsageEventReturns struct {
+		result1 repositories.AppUsageEventRecord
+		result2 error
+	}
+	getAppUsageEventReturnsOnCall map[int]struct {
+		result1 repositories.AppUsageEventRecord
+		result2 error
+	}
+	GetServiceUsageEventStub        func(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	getServiceUsageEventMutex       sync.RWMutex
+	getServiceUsageEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceUsageEventReturns struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}
+	getServiceUsageEventReturnsOnCall map[int]struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}
+	ListAppUsageEventsStub        func(context.Context, authorization.Info, repositories.ListAppUsageEventsMessage) ([]repositories.AppUsageEventRecord, error)
+	listAppUsageEventsMutex       sync.RWMutex
+	listAppUsageEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListAppUsageEventsMessage
+	}
+	listAppUsageEventsReturns struct {
+		result1 []repositories.AppUsageEventRecord
+		result2 error
+	}
+	listAppUsageEventsReturnsOnCall map[int]struct {
+		result1 []repositories.AppUsageEventRecord
+		result2 error
+	}
+	ListServiceUsageEventsStub        func(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)
+	listServiceUsageEventsMutex       sync.RWMutex
+	listServiceUsageEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceUsageEventsMessage
+	}
+	listServiceUsageEventsReturns struct {
+		result1 []repositories.ServiceUsageEventRecord
+		result2 error
+	}
+	listServiceUsageEventsReturnsOnCall map[int]struct {
+		result1 []repositories.ServiceUsageEventRecord
+		result2 error
+	}
+	PurgeAndReseedAppUsageEventsStub        func(context.Context, authorization.Info) error
+	purgeAndReseedAppUsageEventsMutex       sync.RWMutex
+	purgeAndReseedAppUsageEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	purgeAndReseedAppUsageEventsReturns struct {
+		result1 error
+	}
+	purgeAndReseedAppUsageEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PurgeAndReseedServiceUsageEventsStub        func(context.Context, authorization.Info) error
+	purgeAndReseedServiceUsageEventsMutex       sync.RWMutex
+	purgeAndReseedServiceUsageEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	purgeAndReseedServiceUsageEventsReturns struct {
+		result1 error
+	}
+	purgeAndReseedServiceUsageEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFUsageEventRepository) GetAppUsageEvent(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.AppUsageEventRecord, error) {
+	fake.getAppUsageEventMutex.Lock()
+	ret, specificReturn := fake.getAppUsageEventReturnsOnCall[len(fake.getAppUsageEventArgsForCall)]
+	fake.getAppUsageEventArgsForCall = append(fake.getAppUsageEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAppUsageEventStub
+	fakeReturns := fake.getAppUsageEventReturns
+	fake.recordInvocation("GetAppUsageEvent", []interface{}{arg1, arg2, arg3})
+	fake.getAppUsageEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFUsageEventRepository) GetAppUsageEventCallCount() int {
+	fake.getAppUsageEventMutex.RLock()
+	defer fake.getAppUsageEventMutex.RUnlock()
+	return len(fake.getAppUsageEventArgsForCall)
+}
+
+func (fake *CFUsageEventRepository) GetAppUsageEventCalls(stub func(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)) {
+	fake.getAppUsageEventMutex.Lock()
+	defer fake.getAppUsageEventMutex.Unlock()
+	fake.GetAppUsageEventStub = stub
+}
+
+func (fake *CFUsageEventRepository) GetAppUsageEventArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getAppUsageEventMutex.RLock()
+	defer fake.getAppUsageEventMutex.RUnlock()
+	argsForCall := fake.getAppUsageEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFUsageEventRepository) GetAppUsageEventReturns(result1 repositories.AppUsageEventRecord, result2 error) {
+	fake.getAppUsageEventMutex.Lock()
+	defer fake.getAppUsageEventMutex.Unlock()
+	fake.GetAppUsageEventStub = nil
+	fake.getAppUsageEventReturns = struct {
+		result1 repositories.AppUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) GetAppUsageEventReturnsOnCall(i int, result1 repositories.AppUsageEventRecord, result2 error) {
+	fake.getAppUsageEventMutex.Lock()
+	defer fake.getAppUsageEventMutex.Unlock()
+	fake.GetAppUsageEventStub = nil
+	if fake.getAppUsageEventReturnsOnCall == nil {
+		fake.getAppUsageEventReturnsOnCall = make(map[int]struct {
+			result1 repositories.AppUsageEventRecord
+			result2 error
+		})
+	}
+	fake.getAppUsageEventReturnsOnCall[i] = struct {
+		result1 repositories.AppUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) GetServiceUsageEvent(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServiceUsageEventRecord, error) {
+	fake.getServiceUsageEventMutex.Lock()
+	ret, specificReturn := fake.getServiceUsageEventReturnsOnCall[len(fake.getServiceUsageEventArgsForCall)]
+	fake.getServiceUsageEventArgsForCall = append(fake.getServiceUsageEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceUsageEventStub
+	fakeReturns := fake.getServiceUsageEventReturns
+	fake.recordInvocation("GetServiceUsageEvent", []interface{}{arg1, arg2, arg3})
+	fake.getServiceUsageEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFUsageEventRepository) GetServiceUsageEventCallCount() int {
+	fake.getServiceUsageEventMutex.RLock()
+	defer fake.getServiceUsageEventMutex.RUnlock()
+	return len(fake.getServiceUsageEventArgsForCall)
+}
+
+func (fake *CFUsageEventRepository) GetServiceUsageEventCalls(stub func(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)) {
+	fake.getServiceUsageEventMutex.Lock()
+	defer fake.getServiceUsageEventMutex.Unlock()
+	fake.GetServiceUsageEventStub = stub
+}
+
+func (fake *CFUsageEventRepository) GetServiceUsageEventArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceUsageEventMutex.RLock()
+	defer fake.getServiceUsageEventMutex.RUnlock()
+	argsForCall := fake.getServiceUsageEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFUsageEventRepository) GetServiceUsageEventReturns(result1 repositories.ServiceUsageEventRecord, result2 error) {
+	fake.getServiceUsageEventMutex.Lock()
+	defer fake.getServiceUsageEventMutex.Unlock()
+	fake.GetServiceUsageEventStub = nil
+	fake.getServiceUsageEventReturns = struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) GetServiceUsageEventReturnsOnCall(i int, result1 repositories.ServiceUsageEventRecord, result2 error) {
+	fake.getServiceUsageEventMutex.Lock()
+	defer fake.getServiceUsageEventMutex.Unlock()
+	fake.GetServiceUsageEventStub = nil
+	if fake.getServiceUsageEventReturnsOnCall == nil {
+		fake.getServiceUsageEventReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceUsageEventRecord
+			result2 error
+		})
+	}
+	fake.getServiceUsageEventReturnsOnCall[i] = struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) ListAppUsageEvents(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListAppUsageEventsMessage) ([]repositories.AppUsageEventRecord, error) {
+	fake.listAppUsageEventsMutex.Lock()
+	ret, specificReturn := fake.listAppUsageEventsReturnsOnCall[len(fake.listAppUsageEventsArgsForCall)]
+	fake.listAppUsageEventsArgsForCall = append(fake.listAppUsageEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListAppUsageEventsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListAppUsageEventsStub
+	fakeReturns := fake.listAppUsageEventsReturns
+	fake.recordInvocation("ListAppUsageEvents", []interface{}{arg1, arg2, arg3})
+	fake.listAppUsageEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFUsageEventRepository) ListAppUsageEventsCallCount() int {
+	fake.listAppUsageEventsMutex.RLock()
+	defer fake.listAppUsageEventsMutex.RUnlock()
+	return len(fake.listAppUsageEventsArgsForCall)
+}
+
+func (fake *CFUsageEventRepository) ListAppUsageEventsCalls(stub func(context.Context, authorization.Info, repositories.ListAppUsageEventsMessage) ([]repositories.AppUsageEventRecord, error)) {
+	fake.listAppUsageEventsMutex.Lock()
+	defer fake.listAppUsageEventsMutex.Unlock()
+	fake.ListAppUsageEventsStub = stub
+}
+
+func (fake *CFUsageEventRepository) ListAppUsageEventsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListAppUsageEventsMessage) {
+	fake.listAppUsageEventsMutex.RLock()
+	defer fake.listAppUsageEventsMutex.RUnlock()
+	argsForCall := fake.listAppUsageEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFUsageEventRepository) ListAppUsageEventsReturns(result1 []repositories.AppUsageEventRecord, result2 error) {
+	fake.listAppUsageEventsMutex.Lock()
+	defer fake.listAppUsageEventsMutex.Unlock()
+	fake.ListAppUsageEventsStub = nil
+	fake.listAppUsageEventsReturns = struct {
+		result1 []repositories.AppUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) ListAppUsageEventsReturnsOnCall(i int, result1 []repositories.AppUsageEventRecord, result2 error) {
+	fake.listAppUsageEventsMutex.Lock()
+	defer fake.listAppUsageEventsMutex.Unlock()
+	fake.ListAppUsageEventsStub = nil
+	if fake.listAppUsageEventsReturnsOnCall == nil {
+		fake.listAppUsageEventsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.AppUsageEventRecord
+			result2 error
+		})
+	}
+	fake.listAppUsageEventsReturnsOnCall[i] = struct {
+		result1 []repositories.AppUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) ListServiceUsageEvents(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error) {
+	fake.listServiceUsageEventsMutex.Lock()
+	ret, specificReturn := fake.listServiceUsageEventsReturnsOnCall[len(fake.listServiceUsageEventsArgsForCall)]
+	fake.listServiceUsageEventsArgsForCall = append(fake.listServiceUsageEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceUsageEventsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListServiceUsageEventsStub
+	fakeReturns := fake.listServiceUsageEventsReturns
+	fake.recordInvocation("ListServiceUsageEvents", []interface{}{arg1, arg2, arg3})
+	fake.listServiceUsageEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFUsageEventRepository) ListServiceUsageEventsCallCount() int {
+	fake.listServiceUsageEventsMutex.RLock()
+	defer fake.listServiceUsageEventsMutex.RUnlock()
+	return len(fake.listServiceUsageEventsArgsForCall)
+}
+
+func (fake *CFUsageEventRepository) ListServiceUsageEventsCalls(stub func(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)) {
+	fake.listServiceUsageEventsMutex.Lock()
+	defer fake.listServiceUsageEventsMutex.Unlock()
+	fake.ListServiceUsageEventsStub = stub
+}
+
+func (fake *CFUsageEventRepository) ListServiceUsageEventsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) {
+	fake.listServiceUsageEventsMutex.RLock()
+	defer fake.listServiceUsageEventsMutex.RUnlock()
+	argsForCall := fake.listServiceUsageEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFUsageEventRepository) ListServiceUsageEventsReturns(result1 []repositories.ServiceUsageEventRecord, result2 error) {
+	fake.listServiceUsageEventsMutex.Lock()
+	defer fake.listServiceUsageEventsMutex.Unlock()
+	fake.ListServiceUsageEventsStub = nil
+	fake.listServiceUsageEventsReturns = struct {
+		result1 []repositories.ServiceUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) ListServiceUsageEventsReturnsOnCall(i int, result1 []repositories.ServiceUsageEventRecord, result2 error) {
+	fake.listServiceUsageEventsMutex.Lock()
+	defer fake.listServiceUsageEventsMutex.Unlock()
+	fake.ListServiceUsageEventsStub = nil
+	if fake.listServiceUsageEventsReturnsOnCall == nil {
+		fake.listServiceUsageEventsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ServiceUsageEventRecord
+			result2 error
+		})
+	}
+	fake.listServiceUsageEventsReturnsOnCall[i] = struct {
+		result1 []repositories.ServiceUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedAppUsageEvents(arg1 context.Context, arg2 authorization.Info) error {
+	fake.purgeAndReseedAppUsageEventsMutex.Lock()
+	ret, specificReturn := fake.purgeAndReseedAppUsageEventsReturnsOnCall[len(fake.purgeAndReseedAppUsageEventsArgsForCall)]
+	fake.purgeAndReseedAppUsageEventsArgsForCall = append(fake.purgeAndReseedAppUsageEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.PurgeAndReseedAppUsageEventsStub
+	fakeReturns := fake.purgeAndReseedAppUsageEventsReturns
+	fake.recordInvocation("PurgeAndReseedAppUsageEvents", []interface{}{arg1, arg2})
+	fake.purgeAndReseedAppUsageEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedAppUsageEventsCallCount() int {
+	fake.purgeAndReseedAppUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedAppUsageEventsMutex.RUnlock()
+	return len(fake.purgeAndReseedAppUsageEventsArgsForCall)
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedAppUsageEventsCalls(stub func(context.Context, authorization.Info) error) {
+	fake.purgeAndReseedAppUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedAppUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedAppUsageEventsStub = stub
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedAppUsageEventsArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.purgeAndReseedAppUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedAppUsageEventsMutex.RUnlock()
+	argsForCall := fake.purgeAndReseedAppUsageEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedAppUsageEventsReturns(result1 error) {
+	fake.purgeAndReseedAppUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedAppUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedAppUsageEventsStub = nil
+	fake.purgeAndReseedAppUsageEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedAppUsageEventsReturnsOnCall(i int, result1 error) {
+	fake.purgeAndReseedAppUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedAppUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedAppUsageEventsStub = nil
+	if fake.purgeAndReseedAppUsageEventsReturnsOnCall == nil {
+		fake.purgeAndReseedAppUsageEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeAndReseedAppUsageEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedServiceUsageEvents(arg1 context.Context, arg2 authorization.Info) error {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	ret, specificReturn := fake.purgeAndReseedServiceUsageEventsReturnsOnCall[len(fake.purgeAndReseedServiceUsageEventsArgsForCall)]
+	fake.purgeAndReseedServiceUsageEventsArgsForCall = append(fake.purgeAndReseedServiceUsageEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.PurgeAndReseedServiceUsageEventsStub
+	fakeReturns := fake.purgeAndReseedServiceUsageEventsReturns
+	fake.recordInvocation("PurgeAndReseedServiceUsageEvents", []interface{}{arg1, arg2})
+	fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedServiceUsageEventsCallCount() int {
+	fake.purgeAndReseedServiceUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.RUnlock()
+	return len(fake.purgeAndReseedServiceUsageEventsArgsForCall)
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedServiceUsageEventsCalls(stub func(context.Context, authorization.Info) error) {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedServiceUsageEventsStub = stub
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedServiceUsageEventsArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.purgeAndReseedServiceUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.RUnlock()
+	argsForCall := fake.purgeAndReseedServiceUsageEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedServiceUsageEventsReturns(result1 error) {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedServiceUsageEventsStub = nil
+	fake.purgeAndReseedServiceUsageEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFUsageEventRepository) PurgeAndReseedServiceUsageEventsReturnsOnCall(i int, result1 error) {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedServiceUsageEventsStub = nil
+	if fake.purgeAndReseedServiceUsageEventsReturnsOnCall == nil {
+		fake.purgeAndReseedServiceUsageEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeAndReseedServiceUsageEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFUsageEventRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAppUsageEventMutex.RLock()
+	defer fake.getAppUsageEventMutex.RUnlock()
+	fake.getServiceUsageEventMutex.RLock()
+	defer fake.getServiceUsageEventMutex.RUnlock()
+	fake.listAppUsageEventsMutex.RLock()
+	defer fake.listAppUsageEventsMutex.RUnlock()
+	fake.listServiceUsageEventsMutex.RLock()
+	defer fake.listServiceUsageEventsMutex.RUnlock()
+	fake.purgeAndReseedAppUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedAppUsageEventsMutex.RUnlock()
+	fake.purgeAndReseedServiceUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFUsageEventRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFUsageEventRepository = new(CFUsageEventRepository)
@@ -0,0 +1,303 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type DropletImageRepository struct {
+	CopyDropletImageStub        func(context.Context, authorization.Info, string, string, string, ...string) (string, error)
+	copyDropletImageMutex       sync.RWMutex
+	copyDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}
+	copyDropletImageReturns struct {
+		result1 string
+		result2 error
+	}
+	copyDropletImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DownloadDropletImageStub        func(context.Context, authorization.Info, string, string) (io.ReadCloser, error)
+	downloadDropletImageMutex       sync.RWMutex
+	downloadDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	downloadDropletImageReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadDropletImageReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	UploadDropletImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
+	uploadDropletImageMutex       sync.RWMutex
+	uploadDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 string
+		arg6 []string
+	}
+	uploadDropletImageReturns struct {
+		result1 string
+		result2 error
+	}
+	uploadDropletImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DropletImageRepository) CopyDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 string, arg6 ...string) (string, error) {
+	fake.copyDropletImageMutex.Lock()
+	ret, specificReturn := fake.copyDropletImageReturnsOnCall[len(fake.copyDropletImageArgsForCall)]
+	fake.copyDropletImageArgsForCall = append(fake.copyDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopyDropletImageStub
+	fakeReturns := fake.copyDropletImageReturns
+	fake.recordInvocation("CopyDropletImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copyDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DropletImageRepository) CopyDropletImageCallCount() int {
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	return len(fake.copyDropletImageArgsForCall)
+}
+
+func (fake *DropletImageRepository) CopyDropletImageCalls(stub func(context.Context, authorization.Info, string, string, string, ...string) (string, error)) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = stub
+}
+
+func (fake *DropletImageRepository) CopyDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, string, string, []string) {
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	argsForCall := fake.copyDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *DropletImageRepository) CopyDropletImageReturns(result1 string, result2 error) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = nil
+	fake.copyDropletImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageRepository) CopyDropletImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = nil
+	if fake.copyDropletImageReturnsOnCall == nil {
+		fake.copyDropletImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyDropletImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageRepository) DownloadDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (io.ReadCloser, error) {
+	fake.downloadDropletImageMutex.Lock()
+	ret, specificReturn := fake.downloadDropletImageReturnsOnCall[len(fake.downloadDropletImageArgsForCall)]
+	fake.downloadDropletImageArgsForCall = append(fake.downloadDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadDropletImageStub
+	fakeReturns := fake.downloadDropletImageReturns
+	fake.recordInvocation("DownloadDropletImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.downloadDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DropletImageRepository) DownloadDropletImageCallCount() int {
+	fake.downloadDropletImageMutex.RLock()
+	defer fake.downloadDropletImageMutex.RUnlock()
+	return len(fake.downloadDropletImageArgsForCall)
+}
+
+func (fake *DropletImageRepository) DownloadDropletImageCalls(stub func(context.Context, authorization.Info, string, string) (io.ReadCloser, error)) {
+	fake.downloadDropletImageMutex.Lock()
+	defer fake.downloadDropletImageMutex.Unlock()
+	fake.DownloadDropletImageStub = stub
+}
+
+func (fake *DropletImageRepository) DownloadDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.downloadDropletImageMutex.RLock()
+	defer fake.downloadDropletImageMutex.RUnlock()
+	argsForCall := fake.downloadDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *DropletImageRepository) DownloadDropletImageReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadDropletImageMutex.Lock()
+	defer fake.downloadDropletImageMutex.Unlock()
+	fake.DownloadDropletImageStub = nil
+	fake.downloadDropletImageReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageRepository) DownloadDropletImageReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadDropletImageMutex.Lock()
+	defer fake.downloadDropletImageMutex.Unlock()
+	fake.DownloadDropletImageStub = nil
+	if fake.downloadDropletImageReturnsOnCall == nil {
+		fake.downloadDropletImageReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadDropletImageReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageRepository) UploadDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
+	fake.uploadDropletImageMutex.Lock()
+	ret, specificReturn := fake.uploadDropletImageReturnsOnCall[len(fake.uploadDropletImageArgsForCall)]
+	fake.uploadDropletImageArgsForCall = append(fake.uploadDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.UploadDropletImageStub
+	fakeReturns := fake.uploadDropletImageReturns
+	fake.recordInvocation("UploadDropletImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.uploadDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DropletImageRepository) UploadDropletImageCallCount() int {
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	return len(fake.uploadDropletImageArgsForCall)
+}
+
+func (fake *DropletImageRepository) UploadDropletImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = stub
+}
+
+func (fake *DropletImageRepository) UploadDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, string, []string) {
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	argsForCall := fake.uploadDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *DropletImageRepository) UploadDropletImageReturns(result1 string, result2 error) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = nil
+	fake.uploadDropletImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageRepository) UploadDropletImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = nil
+	if fake.uploadDropletImageReturnsOnCall == nil {
+		fake.uploadDropletImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.uploadDropletImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DropletImageRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	fake.downloadDropletImageMutex.RLock()
+	defer fake.downloadDropletImageMutex.RUnlock()
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DropletImageRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.DropletImageRepository = new(DropletImageRepository)
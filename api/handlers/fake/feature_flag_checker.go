@@ -0,0 +1,203 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type FeatureFlagChecker struct {
+	CheckFeatureFlagStub        func(context.Context, authorization.Info, string) error
+	checkFeatureFlagMutex       sync.RWMutex
+	checkFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	checkFeatureFlagReturns struct {
+		result1 error
+	}
+	checkFeatureFlagReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSpaceFeatureStub        func(context.Context, authorization.Info, string, string) (repositories.SpaceFeatureRecord, error)
+	getSpaceFeatureMutex       sync.RWMutex
+	getSpaceFeatureArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	getSpaceFeatureReturns struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}
+	getSpaceFeatureReturnsOnCall map[int]struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.checkFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.checkFeatureFlagReturnsOnCall[len(fake.checkFeatureFlagArgsForCall)]
+	fake.checkFeatureFlagArgsForCall = append(fake.checkFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CheckFeatureFlagStub
+	fakeReturns := fake.checkFeatureFlagReturns
+	fake.recordInvocation("CheckFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.checkFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagCallCount() int {
+	fake.checkFeatureFlagMutex.RLock()
+	defer fake.checkFeatureFlagMutex.RUnlock()
+	return len(fake.checkFeatureFlagArgsForCall)
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.checkFeatureFlagMutex.Lock()
+	defer fake.checkFeatureFlagMutex.Unlock()
+	fake.CheckFeatureFlagStub = stub
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.checkFeatureFlagMutex.RLock()
+	defer fake.checkFeatureFlagMutex.RUnlock()
+	argsForCall := fake.checkFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagReturns(result1 error) {
+	fake.checkFeatureFlagMutex.Lock()
+	defer fake.checkFeatureFlagMutex.Unlock()
+	fake.CheckFeatureFlagStub = nil
+	fake.checkFeatureFlagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagReturnsOnCall(i int, result1 error) {
+	fake.checkFeatureFlagMutex.Lock()
+	defer fake.checkFeatureFlagMutex.Unlock()
+	fake.CheckFeatureFlagStub = nil
+	if fake.checkFeatureFlagReturnsOnCall == nil {
+		fake.checkFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkFeatureFlagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagChecker) GetSpaceFeature(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (repositories.SpaceFeatureRecord, error) {
+	fake.getSpaceFeatureMutex.Lock()
+	ret, specificReturn := fake.getSpaceFeatureReturnsOnCall[len(fake.getSpaceFeatureArgsForCall)]
+	fake.getSpaceFeatureArgsForCall = append(fake.getSpaceFeatureArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetSpaceFeatureStub
+	fakeReturns := fake.getSpaceFeatureReturns
+	fake.recordInvocation("GetSpaceFeature", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getSpaceFeatureMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FeatureFlagChecker) GetSpaceFeatureCallCount() int {
+	fake.getSpaceFeatureMutex.RLock()
+	defer fake.getSpaceFeatureMutex.RUnlock()
+	return len(fake.getSpaceFeatureArgsForCall)
+}
+
+func (fake *FeatureFlagChecker) GetSpaceFeatureCalls(stub func(context.Context, authorization.Info, string, string) (repositories.SpaceFeatureRecord, error)) {
+	fake.getSpaceFeatureMutex.Lock()
+	defer fake.getSpaceFeatureMutex.Unlock()
+	fake.GetSpaceFeatureStub = stub
+}
+
+func (fake *FeatureFlagChecker) GetSpaceFeatureArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.getSpaceFeatureMutex.RLock()
+	defer fake.getSpaceFeatureMutex.RUnlock()
+	argsForCall := fake.getSpaceFeatureArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FeatureFlagChecker) GetSpaceFeatureReturns(result1 repositories.SpaceFeatureRecord, result2 error) {
+	fake.getSpaceFeatureMutex.Lock()
+	defer fake.getSpaceFeatureMutex.Unlock()
+	fake.GetSpaceFeatureStub = nil
+	fake.getSpaceFeatureReturns = struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FeatureFlagChecker) GetSpaceFeatureReturnsOnCall(i int, result1 repositories.SpaceFeatureRecord, result2 error) {
+	fake.getSpaceFeatureMutex.Lock()
+	defer fake.getSpaceFeatureMutex.Unlock()
+	fake.GetSpaceFeatureStub = nil
+	if fake.getSpaceFeatureReturnsOnCall == nil {
+		fake.getSpaceFeatureReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceFeatureRecord
+			result2 error
+		})
+	}
+	fake.getSpaceFeatureReturnsOnCall[i] = struct {
+		result1 repositories.SpaceFeatureRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FeatureFlagChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkFeatureFlagMutex.RLock()
+	defer fake.checkFeatureFlagMutex.RUnlock()
+	fake.getSpaceFeatureMutex.RLock()
+	defer fake.getSpaceFeatureMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FeatureFlagChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.FeatureFlagChecker = new(FeatureFlagChecker)
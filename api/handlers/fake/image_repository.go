@@ -11,6 +11,40 @@ import (
 )
 
 type ImageRepository struct {
+	CopySourceImageStub        func(context.Context, authorization.Info, string, string, string, ...string) (string, error)
+	copySourceImageMutex       sync.RWMutex
+	copySourceImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}
+	copySourceImageReturns struct {
+		result1 string
+		result2 error
+	}
+	copySourceImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DownloadSourceImageStub        func(context.Context, authorization.Info, string, string) (io.ReadCloser, error)
+	downloadSourceImageMutex       sync.RWMutex
+	downloadSourceImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	downloadSourceImageReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadSourceImageReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	UploadSourceImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
 	uploadSourceImageMutex       sync.RWMutex
 	uploadSourceImageArgsForCall []struct {
@@ -33,6 +67,142 @@ type ImageRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImageRepository) CopySourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 string, arg6 ...string) (string, error) {
+	fake.copySourceImageMutex.Lock()
+	ret, specificReturn := fake.copySourceImageReturnsOnCall[len(fake.copySourceImageArgsForCall)]
+	fake.copySourceImageArgsForCall = append(fake.copySourceImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopySourceImageStub
+	fakeReturns := fake.copySourceImageReturns
+	fake.recordInvocation("CopySourceImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copySourceImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) CopySourceImageCallCount() int {
+	fake.copySourceImageMutex.RLock()
+	defer fake.copySourceImageMutex.RUnlock()
+	return len(fake.copySourceImageArgsForCall)
+}
+
+func (fake *ImageRepository) CopySourceImageCalls(stub func(context.Context, authorization.Info, string, string, string, ...string) (string, error)) {
+	fake.copySourceImageMutex.Lock()
+	defer fake.copySourceImageMutex.Unlock()
+	fake.CopySourceImageStub = stub
+}
+
+func (fake *ImageRepository) CopySourceImageArgsForCall(i int) (context.Context, authorization.Info, string, string, string, []string) {
+	fake.copySourceImageMutex.RLock()
+	defer fake.copySourceImageMutex.RUnlock()
+	argsForCall := fake.copySourceImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) CopySourceImageReturns(result1 string, result2 error) {
+	fake.copySourceImageMutex.Lock()
+	defer fake.copySourceImageMutex.Unlock()
+	fake.CopySourceImageStub = nil
+	fake.copySourceImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopySourceImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copySourceImageMutex.Lock()
+	defer fake.copySourceImageMutex.Unlock()
+	fake.CopySourceImageStub = nil
+	if fake.copySourceImageReturnsOnCall == nil {
+		fake.copySourceImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copySourceImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) DownloadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (io.ReadCloser, error) {
+	fake.downloadSourceImageMutex.Lock()
+	ret, specificReturn := fake.downloadSourceImageReturnsOnCall[len(fake.downloadSourceImageArgsForCall)]
+	fake.downloadSourceImageArgsForCall = append(fake.downloadSourceImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadSourceImageStub
+	fakeReturns := fake.downloadSourceImageReturns
+	fake.recordInvocation("DownloadSourceImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.downloadSourceImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) DownloadSourceImageCallCount() int {
+	fake.downloadSourceImageMutex.RLock()
+	defer fake.downloadSourceImageMutex.RUnlock()
+	return len(fake.downloadSourceImageArgsForCall)
+}
+
+func (fake *ImageRepository) DownloadSourceImageCalls(stub func(context.Context, authorization.Info, string, string) (io.ReadCloser, error)) {
+	fake.downloadSourceImageMutex.Lock()
+	defer fake.downloadSourceImageMutex.Unlock()
+	fake.DownloadSourceImageStub = stub
+}
+
+func (fake *ImageRepository) DownloadSourceImageArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.downloadSourceImageMutex.RLock()
+	defer fake.downloadSourceImageMutex.RUnlock()
+	argsForCall := fake.downloadSourceImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ImageRepository) DownloadSourceImageReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadSourceImageMutex.Lock()
+	defer fake.downloadSourceImageMutex.Unlock()
+	fake.DownloadSourceImageStub = nil
+	fake.downloadSourceImageReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) DownloadSourceImageReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadSourceImageMutex.Lock()
+	defer fake.downloadSourceImageMutex.Unlock()
+	fake.DownloadSourceImageStub = nil
+	if fake.downloadSourceImageReturnsOnCall == nil {
+		fake.downloadSourceImageReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadSourceImageReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
 	fake.uploadSourceImageMutex.Lock()
 	ret, specificReturn := fake.uploadSourceImageReturnsOnCall[len(fake.uploadSourceImageArgsForCall)]
@@ -105,6 +275,10 @@ func (fake *ImageRepository) UploadSourceImageReturnsOnCall(i int, result1 strin
 func (fake *ImageRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copySourceImageMutex.RLock()
+	defer fake.copySourceImageMutex.RUnlock()
+	fake.downloadSourceImageMutex.RLock()
+	defer fake.downloadSourceImageMutex.RUnlock()
 	fake.uploadSourceImageMutex.RLock()
 	defer fake.uploadSourceImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type LogStreamRepository struct {
+	StreamAppLogsStub        func(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)
+	streamAppLogsMutex       sync.RWMutex
+	streamAppLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.StreamLogsMessage
+	}
+	streamAppLogsReturns struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}
+	streamAppLogsReturnsOnCall map[int]struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *LogStreamRepository) StreamAppLogs(arg1 context.Context, arg2 authorization.Info, arg3 repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error) {
+	fake.streamAppLogsMutex.Lock()
+	ret, specificReturn := fake.streamAppLogsReturnsOnCall[len(fake.streamAppLogsArgsForCall)]
+	fake.streamAppLogsArgsForCall = append(fake.streamAppLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.StreamLogsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.StreamAppLogsStub
+	fakeReturns := fake.streamAppLogsReturns
+	fake.recordInvocation("StreamAppLogs", []interface{}{arg1, arg2, arg3})
+	fake.streamAppLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LogStreamRepository) StreamAppLogsCallCount() int {
+	fake.streamAppLogsMutex.RLock()
+	defer fake.streamAppLogsMutex.RUnlock()
+	return len(fake.streamAppLogsArgsForCall)
+}
+
+func (fake *LogStreamRepository) StreamAppLogsCalls(stub func(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)) {
+	fake.streamAppLogsMutex.Lock()
+	defer fake.streamAppLogsMutex.Unlock()
+	fake.StreamAppLogsStub = stub
+}
+
+func (fake *LogStreamRepository) StreamAppLogsArgsForCall(i int) (context.Context, authorization.Info, repositories.StreamLogsMessage) {
+	fake.streamAppLogsMutex.RLock()
+	defer fake.streamAppLogsMutex.RUnlock()
+	argsForCall := fake.streamAppLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *LogStreamRepository) StreamAppLogsReturns(result1 <-chan repositories.LogRecord, result2 error) {
+	fake.streamAppLogsMutex.Lock()
+	defer fake.streamAppLogsMutex.Unlock()
+	fake.StreamAppLogsStub = nil
+	fake.streamAppLogsReturns = struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LogStreamRepository) StreamAppLogsReturnsOnCall(i int, result1 <-chan repositories.LogRecord, result2 error) {
+	fake.streamAppLogsMutex.Lock()
+	defer fake.streamAppLogsMutex.Unlock()
+	fake.StreamAppLogsStub = nil
+	if fake.streamAppLogsReturnsOnCall == nil {
+		fake.streamAppLogsReturnsOnCall = make(map[int]struct {
+			result1 <-chan repositories.LogRecord
+			result2 error
+		})
+	}
+	fake.streamAppLogsReturnsOnCall[i] = struct {
+		result1 <-chan repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LogStreamRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.streamAppLogsMutex.RLock()
+	defer fake.streamAppLogsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *LogStreamRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.LogStreamRepository = new(LogStreamRepository)
@@ -0,0 +1,282 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ResourceCache struct {
+	AddResourcesStub        func(io.ReaderAt, int64) error
+	addResourcesMutex       sync.RWMutex
+	addResourcesArgsForCall []struct {
+		arg1 io.ReaderAt
+		arg2 int64
+	}
+	addResourcesReturns struct {
+		result1 error
+	}
+	addResourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AssemblePackageStub        func(io.ReaderAt, int64, []repositories.ResourceRecord) (io.ReadCloser, error)
+	assemblePackageMutex       sync.RWMutex
+	assemblePackageArgsForCall []struct {
+		arg1 io.ReaderAt
+		arg2 int64
+		arg3 []repositories.ResourceRecord
+	}
+	assemblePackageReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	assemblePackageReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	MatchResourcesStub        func([]repositories.ResourceRecord) []repositories.ResourceRecord
+	matchResourcesMutex       sync.RWMutex
+	matchResourcesArgsForCall []struct {
+		arg1 []repositories.ResourceRecord
+	}
+	matchResourcesReturns struct {
+		result1 []repositories.ResourceRecord
+	}
+	matchResourcesReturnsOnCall map[int]struct {
+		result1 []repositories.ResourceRecord
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ResourceCache) AddResources(arg1 io.ReaderAt, arg2 int64) error {
+	fake.addResourcesMutex.Lock()
+	ret, specificReturn := fake.addResourcesReturnsOnCall[len(fake.addResourcesArgsForCall)]
+	fake.addResourcesArgsForCall = append(fake.addResourcesArgsForCall, struct {
+		arg1 io.ReaderAt
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.AddResourcesStub
+	fakeReturns := fake.addResourcesReturns
+	fake.recordInvocation("AddResources", []interface{}{arg1, arg2})
+	fake.addResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ResourceCache) AddResourcesCallCount() int {
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	return len(fake.addResourcesArgsForCall)
+}
+
+func (fake *ResourceCache) AddResourcesCalls(stub func(io.ReaderAt, int64) error) {
+	fake.addResourcesMutex.Lock()
+	defer fake.addResourcesMutex.Unlock()
+	fake.AddResourcesStub = stub
+}
+
+func (fake *ResourceCache) AddResourcesArgsForCall(i int) (io.ReaderAt, int64) {
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	argsForCall := fake.addResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ResourceCache) AddResourcesReturns(result1 error) {
+	fake.addResourcesMutex.Lock()
+	defer fake.addResourcesMutex.Unlock()
+	fake.AddResourcesStub = nil
+	fake.addResourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ResourceCache) AddResourcesReturnsOnCall(i int, result1 error) {
+	fake.addResourcesMutex.Lock()
+	defer fake.addResourcesMutex.Unlock()
+	fake.AddResourcesStub = nil
+	if fake.addResourcesReturnsOnCall == nil {
+		fake.addResourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addResourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ResourceCache) AssemblePackage(arg1 io.ReaderAt, arg2 int64, arg3 []repositories.ResourceRecord) (io.ReadCloser, error) {
+	var arg3Copy []repositories.ResourceRecord
+	if arg3 != nil {
+		arg3Copy = make([]repositories.ResourceRecord, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.assemblePackageMutex.Lock()
+	ret, specificReturn := fake.assemblePackageReturnsOnCall[len(fake.assemblePackageArgsForCall)]
+	fake.assemblePackageArgsForCall = append(fake.assemblePackageArgsForCall, struct {
+		arg1 io.ReaderAt
+		arg2 int64
+		arg3 []repositories.ResourceRecord
+	}{arg1, arg2, arg3Copy})
+	stub := fake.AssemblePackageStub
+	fakeReturns := fake.assemblePackageReturns
+	fake.recordInvocation("AssemblePackage", []interface{}{arg1, arg2, arg3Copy})
+	fake.assemblePackageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResourceCache) AssemblePackageCallCount() int {
+	fake.assemblePackageMutex.RLock()
+	defer fake.assemblePackageMutex.RUnlock()
+	return len(fake.assemblePackageArgsForCall)
+}
+
+func (fake *ResourceCache) AssemblePackageCalls(stub func(io.ReaderAt, int64, []repositories.ResourceRecord) (io.ReadCloser, error)) {
+	fake.assemblePackageMutex.Lock()
+	defer fake.assemblePackageMutex.Unlock()
+	fake.AssemblePackageStub = stub
+}
+
+func (fake *ResourceCache) AssemblePackageArgsForCall(i int) (io.ReaderAt, int64, []repositories.ResourceRecord) {
+	fake.assemblePackageMutex.RLock()
+	defer fake.assemblePackageMutex.RUnlock()
+	argsForCall := fake.assemblePackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ResourceCache) AssemblePackageReturns(result1 io.ReadCloser, result2 error) {
+	fake.assemblePackageMutex.Lock()
+	defer fake.assemblePackageMutex.Unlock()
+	fake.AssemblePackageStub = nil
+	fake.assemblePackageReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCache) AssemblePackageReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.assemblePackageMutex.Lock()
+	defer fake.assemblePackageMutex.Unlock()
+	fake.AssemblePackageStub = nil
+	if fake.assemblePackageReturnsOnCall == nil {
+		fake.assemblePackageReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.assemblePackageReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCache) MatchResources(arg1 []repositories.ResourceRecord) []repositories.ResourceRecord {
+	var arg1Copy []repositories.ResourceRecord
+	if arg1 != nil {
+		arg1Copy = make([]repositories.ResourceRecord, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.matchResourcesMutex.Lock()
+	ret, specificReturn := fake.matchResourcesReturnsOnCall[len(fake.matchResourcesArgsForCall)]
+	fake.matchResourcesArgsForCall = append(fake.matchResourcesArgsForCall, struct {
+		arg1 []repositories.ResourceRecord
+	}{arg1Copy})
+	stub := fake.MatchResourcesStub
+	fakeReturns := fake.matchResourcesReturns
+	fake.recordInvocation("MatchResources", []interface{}{arg1Copy})
+	fake.matchResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ResourceCache) MatchResourcesCallCount() int {
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	return len(fake.matchResourcesArgsForCall)
+}
+
+func (fake *ResourceCache) MatchResourcesCalls(stub func([]repositories.ResourceRecord) []repositories.ResourceRecord) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = stub
+}
+
+func (fake *ResourceCache) MatchResourcesArgsForCall(i int) []repositories.ResourceRecord {
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	argsForCall := fake.matchResourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ResourceCache) MatchResourcesReturns(result1 []repositories.ResourceRecord) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = nil
+	fake.matchResourcesReturns = struct {
+		result1 []repositories.ResourceRecord
+	}{result1}
+}
+
+func (fake *ResourceCache) MatchResourcesReturnsOnCall(i int, result1 []repositories.ResourceRecord) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = nil
+	if fake.matchResourcesReturnsOnCall == nil {
+		fake.matchResourcesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ResourceRecord
+		})
+	}
+	fake.matchResourcesReturnsOnCall[i] = struct {
+		result1 []repositories.ResourceRecord
+	}{result1}
+}
+
+func (fake *ResourceCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addResourcesMutex.RLock()
+	defer fake.addResourcesMutex.RUnlock()
+	fake.assemblePackageMutex.RLock()
+	defer fake.assemblePackageMutex.RUnlock()
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ResourceCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.ResourceCache = new(ResourceCache)
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	FeatureFlagsPath  = "/v3/feature_flags"
+	FeatureFlagPath   = "/v3/feature_flags/{name}"
+	SpaceFeaturesPath = "/v3/spaces/{guid}/features"
+	SpaceFeaturePath  = "/v3/spaces/{guid}/features/{name}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFFeatureFlagRepository . CFFeatureFlagRepository
+type CFFeatureFlagRepository interface {
+	ListFeatureFlags(context.Context, authorization.Info) ([]repositories.FeatureFlagRecord, error)
+	GetFeatureFlag(context.Context, authorization.Info, string) (repositories.FeatureFlagRecord, error)
+	UpdateFeatureFlag(context.Context, authorization.Info, repositories.UpdateFeatureFlagMessage) (repositories.FeatureFlagRecord, error)
+	ListSpaceFeatures(context.Context, authorization.Info, string) ([]repositories.SpaceFeatureRecord, error)
+	GetSpaceFeature(context.Context, authorization.Info, string, string) (repositories.SpaceFeatureRecord, error)
+	UpdateSpaceFeature(context.Context, authorization.Info, repositories.UpdateSpaceFeatureMessage) (repositories.SpaceFeatureRecord, error)
+}
+
+// FeatureFlagChecker is used by handlers to refuse requests for features
+// that have been disabled by an admin or for the space
+//
+//counterfeiter:generate -o fake -fake-name FeatureFlagChecker . FeatureFlagChecker
+type FeatureFlagChecker interface {
+	CheckFeatureFlag(context.Context, authorization.Info, string) error
+	GetSpaceFeature(context.Context, authorization.Info, string, string) (repositories.SpaceFeatureRecord, error)
+}
+
+type FeatureFlag struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	featureFlagRepo  CFFeatureFlagRepository
+	spaceRepo        CFSpaceRepository
+}
+
+func NewFeatureFlag(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	featureFlagRepo CFFeatureFlagRepository,
+	spaceRepo CFSpaceRepository,
+) *FeatureFlag {
+	return &FeatureFlag{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		featureFlagRepo:  featureFlagRepo,
+		spaceRepo:        spaceRepo,
+	}
+}
+
+func (h *FeatureFlag) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.list")
+
+	featureFlags, err := h.featureFlagRepo.ListFeatureFlags(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list feature flags")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForFeatureFlag, featureFlags, h.serverURL, *r.URL)), nil
+}
+
+func (h *FeatureFlag) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.get")
+
+	name := routing.URLParam(r, "name")
+
+	featureFlag, err := h.featureFlagRepo.GetFeatureFlag(r.Context(), authInfo, name)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get feature flag", "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForFeatureFlag(featureFlag, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.feature-flag.update")
+
+	name := routing.URLParam(r, "name")
+
+	var payload payloads.FeatureFlagUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	featureFlag, err := h.featureFlagRepo.UpdateFeatureFlag(r.Context(), authInfo, payload.ToMessage(name))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update feature flag", "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForFeatureFlag(featureFlag, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) listSpaceFeatures(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-feature.list")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
+	}
+
+	spaceFeatures, err := h.featureFlagRepo.ListSpaceFeatures(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list space features", "guid", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceFeatures(spaceFeatures, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) getSpaceFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-feature.get")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	name := routing.URLParam(r, "name")
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
+	}
+
+	spaceFeature, err := h.featureFlagRepo.GetSpaceFeature(r.Context(), authInfo, spaceGUID, name)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get space feature", "guid", spaceGUID, "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceFeature(spaceFeature, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) updateSpaceFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-feature.update")
+
+	spaceGUID := routing.URLParam(r, "guid")
+	name := routing.URLParam(r, "name")
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
+	}
+
+	var payload payloads.SpaceFeatureUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceFeature, err := h.featureFlagRepo.UpdateSpaceFeature(r.Context(), authInfo, payload.ToMessage(spaceGUID, name))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update space feature", "guid", spaceGUID, "name", name)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceFeature(spaceFeature, h.serverURL)), nil
+}
+
+func (h *FeatureFlag) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *FeatureFlag) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: FeatureFlagsPath, Handler: h.list},
+		{Method: "GET", Pattern: FeatureFlagPath, Handler: h.get},
+		{Method: "PATCH", Pattern: FeatureFlagPath, Handler: h.update},
+		{Method: "GET", Pattern: SpaceFeaturesPath, Handler: h.listSpaceFeatures},
+		{Method: "GET", Pattern: SpaceFeaturePath, Handler: h.getSpaceFeature},
+		{Method: "PATCH", Pattern: SpaceFeaturePath, Handler: h.updateSpaceFeature},
+	}
+}
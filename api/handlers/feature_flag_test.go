@@ -0,0 +1,316 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlag", func() {
+	var (
+		apiHandler       *handlers.FeatureFlag
+		featureFlagRepo  *fake.CFFeatureFlagRepository
+		spaceRepo        *fake.CFSpaceRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		apiHandler = handlers.NewFeatureFlag(
+			*serverURL,
+			requestValidator,
+			featureFlagRepo,
+			spaceRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/feature_flags", func() {
+		BeforeEach(func() {
+			featureFlagRepo.ListFeatureFlagsReturns([]repositories.FeatureFlagRecord{
+				{Name: "diego_docker", Enabled: true},
+				{Name: "task_creation", Enabled: false, CustomErrorMessage: "no tasks"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/feature_flags", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the feature flags", func() {
+			Expect(featureFlagRepo.ListFeatureFlagsCallCount()).To(Equal(1))
+			_, actualAuthInfo := featureFlagRepo.ListFeatureFlagsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/feature_flags"),
+				MatchJSONPath("$.resources[0].name", "diego_docker"),
+				MatchJSONPath("$.resources[0].enabled", true),
+				MatchJSONPath("$.resources[1].name", "task_creation"),
+				MatchJSONPath("$.resources[1].custom_error_message", "no tasks"),
+			)))
+		})
+
+		When("listing the feature flags fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.ListFeatureFlagsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/feature_flags/{name}", func() {
+		BeforeEach(func() {
+			featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:    "diego_docker",
+				Enabled: true,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/feature_flags/diego_docker", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("diego_docker"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "diego_docker"),
+				MatchJSONPath("$.enabled", true),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/feature_flags/diego_docker"),
+			)))
+		})
+
+		When("the feature flag does not exist", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Feature Flag")
+			})
+		})
+	})
+
+	Describe("PATCH /v3/feature_flags/{name}", func() {
+		BeforeEach(func() {
+			featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:               "diego_docker",
+				Enabled:            false,
+				CustomErrorMessage: "no docker",
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeatureFlagUpdate{
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no docker"),
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/feature_flags/diego_docker", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the feature flag", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(featureFlagRepo.UpdateFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := featureFlagRepo.UpdateFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateFeatureFlagMessage{
+				Name:               "diego_docker",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no docker"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "diego_docker"),
+				MatchJSONPath("$.enabled", false),
+				MatchJSONPath("$.custom_error_message", "no docker"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = nil
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(featureFlagRepo.UpdateFeatureFlagCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewForbiddenError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("GET /v3/spaces/{guid}/features", func() {
+		BeforeEach(func() {
+			featureFlagRepo.ListSpaceFeaturesReturns([]repositories.SpaceFeatureRecord{
+				{Name: "ssh", Description: "Enable SSHing into apps in the space.", Enabled: true},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/features", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the space features", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+			Expect(featureFlagRepo.ListSpaceFeaturesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := featureFlagRepo.ListSpaceFeaturesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.resources[0].name", "ssh"),
+				MatchJSONPath("$.resources[0].enabled", true),
+			)))
+		})
+
+		When("the space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Space")
+				Expect(featureFlagRepo.ListSpaceFeaturesCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("GET /v3/spaces/{guid}/features/{name}", func() {
+		BeforeEach(func() {
+			featureFlagRepo.GetSpaceFeatureReturns(repositories.SpaceFeatureRecord{
+				Name:        "ssh",
+				Description: "Enable SSHing into apps in the space.",
+				Enabled:     false,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/features/ssh", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space feature", func() {
+			Expect(featureFlagRepo.GetSpaceFeatureCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, actualName := featureFlagRepo.GetSpaceFeatureArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualName).To(Equal("ssh"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", false),
+			)))
+		})
+
+		When("getting the space feature fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetSpaceFeatureReturns(repositories.SpaceFeatureRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/spaces/{guid}/features/{name}", func() {
+		BeforeEach(func() {
+			featureFlagRepo.UpdateSpaceFeatureReturns(repositories.SpaceFeatureRecord{
+				Name:    "ssh",
+				Enabled: false,
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceFeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/spaces/space-guid/features/ssh", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the space feature", func() {
+			Expect(featureFlagRepo.UpdateSpaceFeatureCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := featureFlagRepo.UpdateSpaceFeatureArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateSpaceFeatureMessage{
+				SpaceGUID: "space-guid",
+				Name:      "ssh",
+				Enabled:   false,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.enabled", false)))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Space")
+				Expect(featureFlagRepo.UpdateSpaceFeatureCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				featureFlagRepo.UpdateSpaceFeatureReturns(repositories.SpaceFeatureRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceFeatureResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
@@ -88,6 +88,12 @@ func expectUnprocessableEntityError(detail string) {
 	expectErrorResponse(http.StatusUnprocessableEntity, "CF-UnprocessableEntity", detail, 10008)
 }
 
+func expectFeatureDisabledError(feature string) {
+	GinkgoHelper()
+
+	expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: "+feature, 330002)
+}
+
 func expectBlobstoreUnavailableError() {
 	GinkgoHelper()
 
@@ -28,10 +28,15 @@ const (
 	ServiceBrokerCreateJobType          = "service_broker.create"
 	ServiceBrokerUpdateJobType          = "service_broker.update"
 	ServiceBrokerDeleteJobType          = "service_broker.delete"
+	SecurityGroupDeleteJobType          = "security_group.delete"
 	ManagedServiceInstanceDeleteJobType = "managed_service_instance.delete"
 	ManagedServiceInstanceCreateJobType = "managed_service_instance.create"
+	ManagedServiceInstanceUpdateJobType = "managed_service_instance.update"
 	ManagedServiceBindingCreateJobType  = "managed_service_binding.create"
 	ManagedServiceBindingDeleteJobType  = "managed_service_binding.delete"
+	ServiceRouteBindingCreateJobType    = "service_route_bindings.create"
+	ServiceRouteBindingDeleteJobType    = "service_route_bindings.delete"
+	DropletUploadJobType                = "droplet.upload"
 	JobTimeoutDuration                  = 120.0
 )
 
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"regexp"
+	"slices"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
@@ -17,9 +20,11 @@ import (
 )
 
 const (
-	LogCacheInfoPath = "/api/v1/info"
-	LogCacheReadPath = "/api/v1/read/{guid}"
-	logCacheVersion  = "2.11.4+cf-k8s"
+	LogCacheInfoPath        = "/api/v1/info"
+	LogCacheReadPath        = "/api/v1/read/{guid}"
+	LogCachePromQLPath      = "/api/v1/promql"
+	LogCachePromQLRangePath = "/api/v1/promql_range"
+	logCacheVersion         = "2.11.4+cf-k8s"
 )
 
 //counterfeiter:generate -o fake -fake-name LogRepository . LogRepository
@@ -27,26 +32,31 @@ type LogRepository interface {
 	GetAppLogs(context.Context, authorization.Info, repositories.GetLogsMessage) ([]repositories.LogRecord, error)
 }
 
+//counterfeiter:generate -o fake -fake-name AppMetrics . AppMetrics
+type AppMetrics interface {
+	FetchMetrics(context.Context, authorization.Info, string) ([]actions.InstanceMetrics, error)
+}
+
 // LogCache implements the minimal set of log-cache API endpoints/features necessary
 // to support the "cf push" workfloh.handlerWrapper.
 type LogCache struct {
 	requestValidator RequestValidator
 	appRepo          CFAppRepository
-	buildRepo        CFBuildRepository
 	logRepo          LogRepository
+	appMetrics       AppMetrics
 }
 
 func NewLogCache(
 	requestValidator RequestValidator,
 	appRepo CFAppRepository,
-	buildRepository CFBuildRepository,
 	logRepo LogRepository,
+	appMetrics AppMetrics,
 ) *LogCache {
 	return &LogCache{
 		requestValidator: requestValidator,
 		appRepo:          appRepo,
-		buildRepo:        buildRepository,
 		logRepo:          logRepo,
+		appMetrics:       appMetrics,
 	}
 }
 
@@ -68,42 +78,132 @@ func (h *LogCache) read(r *http.Request) (*routing.Response, error) {
 
 	appGUID := routing.URLParam(r, "guid")
 
-	logs, err := h.getAppLogs(r.Context(), logger, authInfo, appGUID, payload)
+	logs := []repositories.LogRecord{}
+	if payload.ReadsEnvelopeType("LOG") {
+		var err error
+		logs, err = h.getAppLogs(r.Context(), logger, authInfo, appGUID, payload)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app logs", "app", appGUID)
+		}
+	}
+
+	instanceMetrics := []actions.InstanceMetrics{}
+	if payload.ReadsEnvelopeType("GAUGE") {
+		var err error
+		instanceMetrics, err = h.getAppGauges(r.Context(), authInfo, appGUID, payload)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app metrics", "app", appGUID)
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForLogCacheRead(logs, instanceMetrics)), nil
+}
+
+func (h *LogCache) promQL(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.promql")
+
+	payload := payloads.LogCachePromQL{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	instanceMetrics, err := h.getSelectedMetrics(r.Context(), authInfo, payload.Selector)
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app logs", "app", appGUID)
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app metrics", "app", payload.Selector.SourceID())
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForLogs(logs)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPromQLVector(payload.Selector.Metric, instanceMetrics)), nil
 }
 
-func (h *LogCache) getAppLogs(ctx context.Context, logger logr.Logger, authInfo authorization.Info, appGUID string, payload payloads.LogRead) ([]repositories.LogRecord, error) {
-	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+func (h *LogCache) promQLRange(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.promql-range")
+
+	payload := payloads.LogCachePromQLRange{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	instanceMetrics, err := h.getSelectedMetrics(r.Context(), authInfo, payload.Selector)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get app: %w", err)
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app metrics", "app", payload.Selector.SourceID())
 	}
 
-	build, err := h.buildRepo.GetLatestBuildByAppGUID(ctx, authInfo, app.SpaceGUID, app.GUID)
+	instanceMetrics = slices.DeleteFunc(instanceMetrics, func(m actions.InstanceMetrics) bool {
+		return m.Timestamp.Before(*payload.Start) || m.Timestamp.After(*payload.End)
+	})
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPromQLMatrix(payload.Selector.Metric, instanceMetrics)), nil
+}
+
+func (h *LogCache) getAppLogs(ctx context.Context, logger logr.Logger, authInfo authorization.Info, appGUID string, payload payloads.LogRead) ([]repositories.LogRecord, error) {
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
 	if err != nil {
-		if !errors.As(err, new(apierrors.NotFoundError)) {
-			return nil, fmt.Errorf("failed to get latest app build: %w", err)
-		}
-		return []repositories.LogRecord{}, nil
+		return nil, fmt.Errorf("failed to get app: %w", err)
 	}
 
 	logs, err := h.logRepo.GetAppLogs(ctx, authInfo, repositories.GetLogsMessage{
 		App:        app,
-		Build:      build,
 		StartTime:  payload.StartTime,
 		Limit:      payload.Limit,
 		Descending: payload.Descending,
 	})
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "failed to get app logs", "app", appGUID, "build", build.GUID)
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get app logs", "app", appGUID)
 	}
 
 	return logs, nil
 }
 
+// getAppGauges returns the metrics of the app instances that fall within the
+// requested time range, only keeping the gauges whose name matches the name
+// filter
+func (h *LogCache) getAppGauges(ctx context.Context, authInfo authorization.Info, appGUID string, payload payloads.LogRead) ([]actions.InstanceMetrics, error) {
+	instanceMetrics, err := h.appMetrics.FetchMetrics(ctx, authInfo, appGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, err := regexp.Compile(payload.NameFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []actions.InstanceMetrics{}
+	for _, m := range instanceMetrics {
+		timestamp := m.Timestamp.UnixNano()
+		if payload.StartTime != nil && timestamp < *payload.StartTime {
+			continue
+		}
+		if payload.EndTime != nil && timestamp >= *payload.EndTime {
+			continue
+		}
+
+		maps.DeleteFunc(m.Gauges, func(name string, _ actions.Gauge) bool {
+			return !nameFilter.MatchString(name)
+		})
+		if len(m.Gauges) == 0 {
+			continue
+		}
+
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+func (h *LogCache) getSelectedMetrics(ctx context.Context, authInfo authorization.Info, selector payloads.PromQLSelector) ([]actions.InstanceMetrics, error) {
+	instanceMetrics, err := h.appMetrics.FetchMetrics(ctx, authInfo, selector.SourceID())
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(instanceMetrics, func(m actions.InstanceMetrics) bool {
+		return !selector.Matches(m.Labels())
+	}), nil
+}
+
 func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheInfoPath, Handler: h.info},
@@ -113,5 +213,7 @@ func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 func (h *LogCache) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheReadPath, Handler: h.read},
+		{Method: "GET", Pattern: LogCachePromQLPath, Handler: h.promQL},
+		{Method: "GET", Pattern: LogCachePromQLRangePath, Handler: h.promQLRange},
 	}
 }
@@ -4,7 +4,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"time"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -21,8 +23,8 @@ import (
 var _ = Describe("LogCache", func() {
 	var (
 		appRepo          *fake.CFAppRepository
-		buildRepo        *fake.CFBuildRepository
 		logRepo          *fake.LogRepository
+		appMetrics       *fake.AppMetrics
 		req              *http.Request
 		requestValidator *fake.RequestValidator
 	)
@@ -30,23 +32,19 @@ var _ = Describe("LogCache", func() {
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		appRepo = new(fake.CFAppRepository)
-		buildRepo = new(fake.CFBuildRepository)
 		logRepo = new(fake.LogRepository)
+		appMetrics = new(fake.AppMetrics)
 
 		appRepo.GetAppReturns(repositories.AppRecord{
 			GUID:      "app-guid",
 			SpaceGUID: "app-space-guid",
 		}, nil)
 
-		buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{
-			GUID: "build-guid",
-		}, nil)
-
 		apiHandler := NewLogCache(
 			requestValidator,
 			appRepo,
-			buildRepo,
 			logRepo,
+			appMetrics,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -134,34 +132,7 @@ var _ = Describe("LogCache", func() {
 			})
 		})
 
-		It("gets the latest build for the specified app", func() {
-			Expect(buildRepo.GetLatestBuildByAppGUIDCallCount()).To(Equal(1))
-			_, _, actualSpaceGUID, actualAppGUID := buildRepo.GetLatestBuildByAppGUIDArgsForCall(0)
-			Expect(actualSpaceGUID).To(Equal("app-space-guid"))
-			Expect(actualAppGUID).To(Equal("app-guid"))
-		})
-
-		When("the build is not accessible", func() {
-			BeforeEach(func() {
-				buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildResourceType))
-			})
-
-			It("returns an error", func() {
-				expectNotFoundError("Build")
-			})
-		})
-
-		When("there is an error fetching the build", func() {
-			BeforeEach(func() {
-				buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{}, errors.New("unknown!"))
-			})
-
-			It("returns an error", func() {
-				expectUnknownError()
-			})
-		})
-
-		It("gets the build logs", func() {
+		It("gets the app logs", func() {
 			Expect(logRepo.GetAppLogsCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualMessage := logRepo.GetAppLogsArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
@@ -169,9 +140,6 @@ var _ = Describe("LogCache", func() {
 				"App": MatchFields(IgnoreExtras, Fields{
 					"GUID": Equal("app-guid"),
 				}),
-				"Build": MatchFields(IgnoreExtras, Fields{
-					"GUID": Equal("build-guid"),
-				}),
 				"StartTime":  PointTo(BeEquivalentTo(12345)),
 				"Limit":      PointTo(BeEquivalentTo(1000)),
 				"Descending": BeTrue(),
@@ -197,5 +165,245 @@ var _ = Describe("LogCache", func() {
 				MatchJSONPath("$.envelopes.batch[2].log.payload", Equal(base64.StdEncoding.EncodeToString([]byte("log2")))),
 			)))
 		})
+
+		It("does not fetch the app metrics", func() {
+			Expect(appMetrics.FetchMetricsCallCount()).To(BeZero())
+		})
+
+		When("gauge envelopes are requested", func() {
+			BeforeEach(func() {
+				payload.EnvelopeTypes = []string{"GAUGE"}
+				payload.StartTime = tools.PtrTo[int64](1000)
+				payload.EndTime = tools.PtrTo[int64](3000)
+				payload.NameFilter = "cpu|memory$"
+
+				appMetrics.FetchMetricsReturns([]actions.InstanceMetrics{
+					{
+						AppGUID:    "app-guid",
+						InstanceID: "0",
+						Timestamp:  time.Unix(0, 1000),
+						Gauges: map[string]actions.Gauge{
+							"cpu":          {Unit: "percentage", Value: 12},
+							"memory":       {Unit: "bytes", Value: 1024},
+							"memory_quota": {Unit: "bytes", Value: 2048},
+						},
+					},
+					{
+						AppGUID:    "app-guid",
+						InstanceID: "1",
+						Timestamp:  time.Unix(0, 3000),
+						Gauges: map[string]actions.Gauge{
+							"cpu": {Unit: "percentage", Value: 13},
+						},
+					},
+				}, nil)
+			})
+
+			It("fetches the app metrics", func() {
+				Expect(appMetrics.FetchMetricsCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualAppGUID := appMetrics.FetchMetricsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualAppGUID).To(Equal("app-guid"))
+			})
+
+			It("does not fetch the app logs", func() {
+				Expect(logRepo.GetAppLogsCallCount()).To(BeZero())
+			})
+
+			It("returns the gauges within the time range that match the name filter", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.envelopes.batch", HaveLen(1)),
+					MatchJSONPath("$.envelopes.batch[0].source_id", "app-guid"),
+					MatchJSONPath("$.envelopes.batch[0].instance_id", "0"),
+					MatchJSONPath("$.envelopes.batch[0].gauge.metrics", SatisfyAll(
+						HaveLen(2),
+						HaveKey("cpu"),
+						HaveKey("memory"),
+					)),
+				)))
+			})
+
+			When("fetching the app metrics fails", func() {
+				BeforeEach(func() {
+					appMetrics.FetchMetricsReturns(nil, errors.New("fetch-metrics-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("the app is not accessible", func() {
+				BeforeEach(func() {
+					appMetrics.FetchMetricsReturns(nil, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns an error", func() {
+					expectNotFoundError("App")
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/promql", func() {
+		var payload *payloads.LogCachePromQL
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/api/v1/promql", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload = &payloads.LogCachePromQL{
+				Query: `memory{source_id="app-guid",process_type="web"}`,
+				Selector: payloads.PromQLSelector{
+					Metric: "memory",
+					Labels: map[string]string{"source_id": "app-guid", "process_type": "web"},
+				},
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			appMetrics.FetchMetricsReturns([]actions.InstanceMetrics{
+				{
+					AppGUID:     "app-guid",
+					ProcessType: "web",
+					InstanceID:  "0",
+					Timestamp:   time.Unix(1700000000, 0),
+					Gauges:      map[string]actions.Gauge{"memory": {Unit: "bytes", Value: 1024}},
+				},
+				{
+					AppGUID:     "app-guid",
+					ProcessType: "worker",
+					InstanceID:  "0",
+					Timestamp:   time.Unix(1700000000, 0),
+					Gauges:      map[string]actions.Gauge{"memory": {Unit: "bytes", Value: 2048}},
+				},
+			}, nil)
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			_, actualPayload := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualPayload).To(Equal(payload))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		It("fetches the metrics of the source app", func() {
+			Expect(appMetrics.FetchMetricsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appMetrics.FetchMetricsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		It("returns the selected instances as an instant vector", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.status", "success"),
+				MatchJSONPath("$.data.resultType", "vector"),
+				MatchJSONPath("$.data.result", HaveLen(1)),
+				MatchJSONPath("$.data.result[0].metric.process_type", "web"),
+				MatchJSONPath("$.data.result[0].value[1]", "1024"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appMetrics.FetchMetricsReturns(nil, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("fetching the app metrics fails", func() {
+			BeforeEach(func() {
+				appMetrics.FetchMetricsReturns(nil, errors.New("fetch-metrics-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /api/v1/promql_range", func() {
+		var payload *payloads.LogCachePromQLRange
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/api/v1/promql_range", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload = &payloads.LogCachePromQLRange{
+				Query: `cpu{source_id="app-guid"}`,
+				Selector: payloads.PromQLSelector{
+					Metric: "cpu",
+					Labels: map[string]string{"source_id": "app-guid"},
+				},
+				Start: tools.PtrTo(time.Unix(1700000000, 0)),
+				End:   tools.PtrTo(time.Unix(1700000060, 0)),
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			appMetrics.FetchMetricsReturns([]actions.InstanceMetrics{
+				{
+					AppGUID:    "app-guid",
+					InstanceID: "0",
+					Timestamp:  time.Unix(1700000030, 0),
+					Gauges:     map[string]actions.Gauge{"cpu": {Unit: "percentage", Value: 12.5}},
+				},
+				{
+					AppGUID:    "app-guid",
+					InstanceID: "1",
+					Timestamp:  time.Unix(1700000090, 0),
+					Gauges:     map[string]actions.Gauge{"cpu": {Unit: "percentage", Value: 25}},
+				},
+			}, nil)
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			_, actualPayload := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualPayload).To(Equal(payload))
+		})
+
+		It("fetches the metrics of the source app", func() {
+			Expect(appMetrics.FetchMetricsCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appMetrics.FetchMetricsArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		It("returns the samples within the range as a matrix", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.status", "success"),
+				MatchJSONPath("$.data.resultType", "matrix"),
+				MatchJSONPath("$.data.result", HaveLen(1)),
+				MatchJSONPath("$.data.result[0].metric.instance_id", "0"),
+				MatchJSONPath("$.data.result[0].values[0][0]", BeEquivalentTo(1700000030)),
+				MatchJSONPath("$.data.result[0].values[0][1]", "12.5"),
+			)))
+		})
+
+		When("fetching the app metrics fails", func() {
+			BeforeEach(func() {
+				appMetrics.FetchMetricsReturns(nil, errors.New("fetch-metrics-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
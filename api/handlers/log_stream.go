@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	LogStreamReadPath = "/v2/read"
+
+	logStreamMaxBatchSize      = 100
+	logStreamHeartbeatInterval = 5 * time.Second
+)
+
+//counterfeiter:generate -o fake -fake-name LogStreamRepository . LogStreamRepository
+type LogStreamRepository interface {
+	StreamAppLogs(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)
+}
+
+// LogStream implements the read endpoint of the loggregator RLP gateway, which
+// is used by "cf logs" to tail app logs as server-sent events
+type LogStream struct {
+	requestValidator RequestValidator
+	appRepo          CFAppRepository
+	logRepo          LogStreamRepository
+}
+
+func NewLogStream(
+	requestValidator RequestValidator,
+	appRepo CFAppRepository,
+	logRepo LogStreamRepository,
+) *LogStream {
+	return &LogStream{
+		requestValidator: requestValidator,
+		appRepo:          appRepo,
+		logRepo:          logRepo,
+	}
+}
+
+func (h *LogStream) read(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-stream.read")
+
+	payload := payloads.LogStreamRead{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, payload.SourceID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "app", payload.SourceID)
+	}
+
+	logs, err := h.logRepo.StreamAppLogs(r.Context(), authInfo, repositories.StreamLogsMessage{App: app})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to stream app logs", "app", payload.SourceID)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "text/event-stream").
+		WithHeader("Cache-Control", "no-cache").
+		WithStream(func(w http.ResponseWriter) error {
+			err := writeLogStream(w, app.GUID, logs)
+			if r.Context().Err() != nil {
+				// the client has gone away
+				return nil
+			}
+			return err
+		}), nil
+}
+
+// writeLogStream sends the log records as batches of envelopes until the
+// records channel is closed. Heartbeats are sent while there are no logs, so
+// that the connection is not closed by proxies as idle.
+func writeLogStream(w http.ResponseWriter, sourceID string, logs <-chan repositories.LogRecord) error {
+	responseController := http.NewResponseController(w)
+
+	// the stream is meant to outlive the server write timeout
+	if err := responseController.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("failed to clear the write deadline: %w", err)
+	}
+
+	heartbeat := time.NewTicker(logStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case record, ok := <-logs:
+			if !ok {
+				return nil
+			}
+
+			if err := writeLogBatch(w, sourceID, receiveLogBatch(record, logs)); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprintf(w, "event: heartbeat\ndata: %d\n\n", time.Now().Unix()); err != nil {
+				return fmt.Errorf("failed to write heartbeat: %w", err)
+			}
+		}
+
+		if err := responseController.Flush(); err != nil {
+			return fmt.Errorf("failed to flush log stream: %w", err)
+		}
+	}
+}
+
+// receiveLogBatch collects the records that are already available, so that
+// bursts of logs are sent as a single event
+func receiveLogBatch(first repositories.LogRecord, logs <-chan repositories.LogRecord) []repositories.LogRecord {
+	batch := []repositories.LogRecord{first}
+	for len(batch) < logStreamMaxBatchSize {
+		select {
+		case record, ok := <-logs:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, record)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+func writeLogBatch(w io.Writer, sourceID string, batch []repositories.LogRecord) error {
+	data, err := json.Marshal(presenter.ForLogStream(sourceID, batch))
+	if err != nil {
+		return fmt.Errorf("failed to encode log batch: %w", err)
+	}
+
+	if _, err = fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+		return fmt.Errorf("failed to write log batch: %w", err)
+	}
+
+	return nil
+}
+
+func (h *LogStream) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *LogStream) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: LogStreamReadPath, Handler: h.read},
+	}
+}
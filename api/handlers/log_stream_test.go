@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogStream", func() {
+	var (
+		appRepo          *fake.CFAppRepository
+		logRepo          *fake.LogStreamRepository
+		req              *http.Request
+		requestValidator *fake.RequestValidator
+		payload          *payloads.LogStreamRead
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		appRepo = new(fake.CFAppRepository)
+		logRepo = new(fake.LogStreamRepository)
+
+		payload = &payloads.LogStreamRead{
+			SourceID: "app-guid",
+			Log:      true,
+		}
+		requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			SpaceGUID: "app-space-guid",
+		}, nil)
+
+		logs := make(chan repositories.LogRecord, 3)
+		logs <- repositories.LogRecord{Timestamp: 0, Message: "log0", InstanceID: "0", Tags: map[string]string{"source_type": "APP/PROC/WEB"}}
+		logs <- repositories.LogRecord{Timestamp: 1, Message: "log1", InstanceID: "1", Tags: map[string]string{"source_type": "APP/PROC/WEB"}}
+		logs <- repositories.LogRecord{Timestamp: 2, Message: "log2", InstanceID: "0", Tags: map[string]string{"source_type": "STG"}}
+		close(logs)
+		logRepo.StreamAppLogsReturns(logs, nil)
+
+		apiHandler := NewLogStream(
+			requestValidator,
+			appRepo,
+			logRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		var err error
+		req, err = http.NewRequestWithContext(ctx, "GET", "/v2/read?source_id=app-guid&log", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	It("validates the payload", func() {
+		Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+		_, actualPayload := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+		Expect(actualPayload).To(Equal(payload))
+	})
+
+	When("the payload is invalid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError("invalid-payload")
+		})
+	})
+
+	It("gets the app", func() {
+		Expect(appRepo.GetAppCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualAppGUID).To(Equal("app-guid"))
+	})
+
+	When("the app is not accessible", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+		})
+
+		It("returns an error", func() {
+			expectNotFoundError("App")
+		})
+	})
+
+	When("there is an error fetching the app", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("unknown!"))
+		})
+
+		It("returns an error", func() {
+			expectUnknownError()
+		})
+	})
+
+	It("streams the app logs", func() {
+		Expect(logRepo.StreamAppLogsCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualMessage := logRepo.StreamAppLogsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualMessage.App.GUID).To(Equal("app-guid"))
+	})
+
+	When("streaming the logs fails", func() {
+		BeforeEach(func() {
+			logRepo.StreamAppLogsReturns(nil, errors.New("stream-logs-error"))
+		})
+
+		It("returns an error", func() {
+			expectUnknownError()
+		})
+	})
+
+	It("returns the logs as server-sent events", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+		Expect(rr.Flushed).To(BeTrue())
+
+		event, found := strings.CutPrefix(rr.Body.String(), "data: ")
+		Expect(found).To(BeTrue())
+		event, found = strings.CutSuffix(event, "\n\n")
+		Expect(found).To(BeTrue())
+
+		Expect(event).To(SatisfyAll(
+			MatchJSONPath("$.batch[0].source_id", "app-guid"),
+			MatchJSONPath("$.batch[0].instance_id", "0"),
+			MatchJSONPath("$.batch[0].tags.source_type", "APP/PROC/WEB"),
+			MatchJSONPath("$.batch[0].log.payload", base64.StdEncoding.EncodeToString([]byte("log0"))),
+			MatchJSONPath("$.batch[1].instance_id", "1"),
+			MatchJSONPath("$.batch[1].log.payload", base64.StdEncoding.EncodeToString([]byte("log1"))),
+			MatchJSONPath("$.batch[2].tags.source_type", "STG"),
+			MatchJSONPath("$.batch[2].log.payload", base64.StdEncoding.EncodeToString([]byte("log2"))),
+		))
+	})
+})
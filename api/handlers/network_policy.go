@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	NetworkPoliciesPath       = "/v3/network_policies"
+	NetworkPoliciesDeletePath = "/v3/network_policies/delete"
+)
+
+//counterfeiter:generate -o fake -fake-name CFNetworkPolicyRepository . CFNetworkPolicyRepository
+type CFNetworkPolicyRepository interface {
+	CreateNetworkPolicies(context.Context, authorization.Info, repositories.CreateNetworkPoliciesMessage) error
+	ListNetworkPolicies(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) ([]repositories.NetworkPolicyRecord, error)
+	DeleteNetworkPolicies(context.Context, authorization.Info, repositories.DeleteNetworkPoliciesMessage) error
+}
+
+type NetworkPolicy struct {
+	serverURL         url.URL
+	requestValidator  RequestValidator
+	networkPolicyRepo CFNetworkPolicyRepository
+}
+
+func NewNetworkPolicy(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	networkPolicyRepo CFNetworkPolicyRepository,
+) *NetworkPolicy {
+	return &NetworkPolicy{
+		serverURL:         serverURL,
+		requestValidator:  requestValidator,
+		networkPolicyRepo: networkPolicyRepo,
+	}
+}
+
+func (h *NetworkPolicy) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.create")
+
+	var payload payloads.NetworkPolicies
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.networkPolicyRepo.CreateNetworkPolicies(r.Context(), authInfo, payload.ToCreateMessage()); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create network policies")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *NetworkPolicy) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.list")
+
+	var payload payloads.NetworkPolicyList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	networkPolicies, err := h.networkPolicyRepo.ListNetworkPolicies(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list network policies")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForNetworkPolicies(networkPolicies)), nil
+}
+
+func (h *NetworkPolicy) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.delete")
+
+	var payload payloads.NetworkPolicies
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.networkPolicyRepo.DeleteNetworkPolicies(r.Context(), authInfo, payload.ToDeleteMessage()); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete network policies")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *NetworkPolicy) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *NetworkPolicy) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: NetworkPoliciesPath, Handler: h.list},
+		{Method: "POST", Pattern: NetworkPoliciesPath, Handler: h.create},
+		{Method: "POST", Pattern: NetworkPoliciesDeletePath, Handler: h.delete},
+	}
+}
@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy", func() {
+	var (
+		apiHandler        *handlers.NetworkPolicy
+		networkPolicyRepo *fake.CFNetworkPolicyRepository
+		requestValidator  *fake.RequestValidator
+		req               *http.Request
+		policiesPayload   *payloads.NetworkPolicies
+		expectedRecords   []repositories.NetworkPolicyRecord
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		networkPolicyRepo = new(fake.CFNetworkPolicyRepository)
+		apiHandler = handlers.NewNetworkPolicy(
+			*serverURL,
+			requestValidator,
+			networkPolicyRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		policiesPayload = &payloads.NetworkPolicies{
+			Policies: []payloads.NetworkPolicy{{
+				Source: payloads.NetworkPolicySource{ID: "app-1"},
+				Destination: payloads.NetworkPolicyDestination{
+					ID:       "app-2",
+					Protocol: "tcp",
+					Ports:    payloads.NetworkPolicyPorts{Start: 8080, End: 8080},
+				},
+			}},
+		}
+		expectedRecords = []repositories.NetworkPolicyRecord{{
+			SourceAppGUID:      "app-1",
+			DestinationAppGUID: "app-2",
+			Protocol:           "tcp",
+			StartPort:          8080,
+			EndPort:            8080,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/network_policies", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(policiesPayload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/network_policies", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the network policies", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(networkPolicyRepo.CreateNetworkPoliciesCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := networkPolicyRepo.CreateNetworkPoliciesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateNetworkPoliciesMessage{Policies: expectedRecords}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = nil
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the network policies fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.CreateNetworkPoliciesReturns(errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/network_policies", func() {
+		BeforeEach(func() {
+			networkPolicyRepo.ListNetworkPoliciesReturns(expectedRecords, nil)
+
+			payload := payloads.NetworkPolicyList{IDs: "app-1,app-3"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/network_policies?id=app-1,app-3", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the network policies", func() {
+			Expect(networkPolicyRepo.ListNetworkPoliciesCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := networkPolicyRepo.ListNetworkPoliciesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUIDs).To(ConsistOf("app-1", "app-3"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.total_policies", BeEquivalentTo(1)),
+				MatchJSONPath("$.policies[0].source.id", "app-1"),
+				MatchJSONPath("$.policies[0].destination.id", "app-2"),
+				MatchJSONPath("$.policies[0].destination.ports.start", BeEquivalentTo(8080)),
+			)))
+		})
+
+		When("listing the network policies fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.ListNetworkPoliciesReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/network_policies/delete", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(policiesPayload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/network_policies/delete", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the network policies", func() {
+			Expect(networkPolicyRepo.DeleteNetworkPoliciesCallCount()).To(Equal(1))
+			_, actualAuthInfo, deleteMessage := networkPolicyRepo.DeleteNetworkPoliciesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deleteMessage).To(Equal(repositories.DeleteNetworkPoliciesMessage{Policies: expectedRecords}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("deleting the network policies fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.DeleteNetworkPoliciesReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
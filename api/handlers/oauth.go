@@ -1,26 +1,41 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/routing"
 
+	"github.com/go-logr/logr"
 	"github.com/golang-jwt/jwt"
 )
 
 const (
-	OAuthTokenPath = "/oauth/token"
+	OAuthTokenPath     = "/oauth/token"
+	OAuthAuthorizePath = "/oauth/authorize"
 )
 
+//counterfeiter:generate -o fake -fake-name OneTimeCodeIssuer . OneTimeCodeIssuer
+
+type OneTimeCodeIssuer interface {
+	Issue(context.Context, authorization.Identity) (string, error)
+}
+
 type OAuth struct {
-	apiBaseURL url.URL
+	apiBaseURL       url.URL
+	identityProvider IdentityProvider
+	codeIssuer       OneTimeCodeIssuer
 }
 
-func NewOAuth(apiBaseURL url.URL) *OAuth {
+func NewOAuth(apiBaseURL url.URL, identityProvider IdentityProvider, codeIssuer OneTimeCodeIssuer) *OAuth {
 	return &OAuth{
-		apiBaseURL: apiBaseURL,
+		apiBaseURL:       apiBaseURL,
+		identityProvider: identityProvider,
+		codeIssuer:       codeIssuer,
 	}
 }
 
@@ -41,6 +56,30 @@ func (h *OAuth) token(r *http.Request) (*routing.Response, error) {
 	}), nil
 }
 
+// authorize issues a one-time code for the authenticated user, the way the UAA
+// does for the authorization code grant. The cf CLI uses it to get the
+// password for `cf ssh` and reads the code from the redirect location.
+func (h *OAuth) authorize(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.oauth.authorize")
+
+	identity, err := h.identityProvider.GetIdentity(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get identity")
+	}
+
+	code, err := h.codeIssuer.Issue(r.Context(), identity)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to issue one-time code")
+	}
+
+	redirectURL := h.apiBaseURL
+	redirectURL.Path = "/login"
+	redirectURL.RawQuery = url.Values{"code": {code}}.Encode()
+
+	return routing.NewResponse(http.StatusFound).WithHeader("Location", redirectURL.String()), nil
+}
+
 func (h *OAuth) UnauthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "POST", Pattern: OAuthTokenPath, Handler: h.token},
@@ -48,5 +87,7 @@ func (h *OAuth) UnauthenticatedRoutes() []routing.Route {
 }
 
 func (h *OAuth) AuthenticatedRoutes() []routing.Route {
-	return nil
+	return []routing.Route{
+		{Method: "GET", Pattern: OAuthAuthorizePath, Handler: h.authorize},
+	}
 }
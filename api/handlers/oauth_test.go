@@ -2,32 +2,46 @@ package handlers_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
 
 	"github.com/SermoDigital/jose/jws"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 var _ = Describe("OAuth", func() {
-	var apiHandler *handlers.OAuth
+	var (
+		apiHandler       *handlers.OAuth
+		identityProvider *fake.IdentityProvider
+		codeIssuer       *fake.OneTimeCodeIssuer
+		req              *http.Request
+	)
 
 	BeforeEach(func() {
-		apiHandler = handlers.NewOAuth(*serverURL)
+		identityProvider = new(fake.IdentityProvider)
+		codeIssuer = new(fake.OneTimeCodeIssuer)
+		apiHandler = handlers.NewOAuth(*serverURL, identityProvider, codeIssuer)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequest(http.MethodPost, "/oauth/token", nil)
-		Expect(err).NotTo(HaveOccurred())
-
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
 	Describe("POST /oauth/token", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodPost, "/oauth/token", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("returns 201 with appropriate success JSON", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -43,4 +57,51 @@ var _ = Describe("OAuth", func() {
 			Expect(expiration.Unix()).To(BeNumerically(">", time.Now().Add(time.Minute*59).Unix()))
 		})
 	})
+
+	Describe("GET /oauth/authorize", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: rbacv1.UserKind}, nil)
+			codeIssuer.IssueReturns("the-code", nil)
+			ctx = authorization.NewContext(ctx, &authorization.Info{Token: "the-token"})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/oauth/authorize?response_type=code&client_id=ssh-proxy", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("issues a one-time code for the user", func() {
+			Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+			_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+
+			Expect(codeIssuer.IssueCallCount()).To(Equal(1))
+			_, actualIdentity := codeIssuer.IssueArgsForCall(0)
+			Expect(actualIdentity).To(Equal(authorization.Identity{Name: "the-user", Kind: rbacv1.UserKind}))
+		})
+
+		It("redirects with the code", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/login?code=the-code"))
+		})
+
+		When("getting the identity fails", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("issuing the code fails", func() {
+			BeforeEach(func() {
+				codeIssuer.IssueReturns("", errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,154 @@
+// nolint:dupl
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	OrgQuotasPath             = "/v3/organization_quotas"
+	OrgQuotaPath              = "/v3/organization_quotas/{guid}"
+	OrgQuotaOrganizationsPath = "/v3/organization_quotas/{guid}/relationships/organizations"
+)
+
+//counterfeiter:generate -o fake -fake-name CFOrgQuotaRepository . CFOrgQuotaRepository
+type CFOrgQuotaRepository interface {
+	CreateOrgQuota(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	GetOrgQuota(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	UpdateOrgQuota(context.Context, authorization.Info, repositories.UpdateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	ApplyOrgQuota(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+}
+
+type OrgQuota struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	orgQuotaRepo     CFOrgQuotaRepository
+}
+
+func NewOrgQuota(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	orgQuotaRepo CFOrgQuotaRepository,
+) *OrgQuota {
+	return &OrgQuota{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		orgQuotaRepo:     orgQuotaRepo,
+	}
+}
+
+func (h *OrgQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.create")
+
+	payload := payloads.NewOrgQuotaCreate()
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	orgQuota, err := h.orgQuotaRepo.CreateOrgQuota(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create org quota")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	orgQuota, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get org quota", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.list")
+
+	var payload payloads.OrgQuotaList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	orgQuotas, err := h.orgQuotaRepo.ListOrgQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list org quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrgQuota, orgQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *OrgQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	orgQuota, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get org quota", "guid", guid)
+	}
+
+	payload := payloads.OrgQuotaUpdate{OrgQuota: *orgQuota.OrgQuota.DeepCopy()}
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	orgQuota, err = h.orgQuotaRepo.UpdateOrgQuota(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update org quota", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) applyToOrganizations(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.apply")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.OrgQuotaApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	orgQuota, err := h.orgQuotaRepo.ApplyOrgQuota(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to apply org quota", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaOrganizations(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *OrgQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: OrgQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: OrgQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: OrgQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: OrgQuotaPath, Handler: h.update},
+		{Method: "POST", Pattern: OrgQuotaOrganizationsPath, Handler: h.applyToOrganizations},
+	}
+}
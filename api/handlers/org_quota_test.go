@@ -0,0 +1,294 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuota", func() {
+	var (
+		apiHandler       *handlers.OrgQuota
+		orgQuotaRepo     *fake.CFOrgQuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		orgQuotaRepo = new(fake.CFOrgQuotaRepository)
+		apiHandler = handlers.NewOrgQuota(
+			*serverURL,
+			requestValidator,
+			orgQuotaRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{
+			GUID: "quota-guid",
+			OrgQuota: quotas.OrgQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+			},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/organization_quotas", func() {
+		var payload *payloads.OrgQuotaCreate
+
+		BeforeEach(func() {
+			payload = &payloads.OrgQuotaCreate{
+				OrgQuota: quotas.OrgQuota{
+					Name: "my-quota",
+					Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				Relationships: &payloads.OrgQuotaRelationships{
+					Organizations: payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+					},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID:          "quota-guid",
+				OrgQuota:      payload.OrgQuota,
+				Organizations: []string{"org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/organization_quotas", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the org quota", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateOrgQuotaMessage{
+				OrgQuota:      payload.OrgQuota,
+				Organizations: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.apps.total_memory_in_mb", BeEquivalentTo(1024)),
+				MatchJSONPath("$.relationships.organizations.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = nil
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the org quota", func() {
+			Expect(orgQuotaRepo.GetOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the org quota is not found", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{
+				{GUID: "quota-1"},
+				{GUID: "quota-2"},
+			}, nil)
+
+			payload := payloads.OrgQuotaList{Names: "n1,n2"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas?names=n1,n2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the org quotas", func() {
+			Expect(orgQuotaRepo.ListOrgQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("n1", "n2"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("quota-1", "quota-2")),
+			)))
+		})
+
+		When("listing the org quotas fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ListOrgQuotasReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = func(_ *http.Request, decoded any) error {
+				payload, ok := decoded.(*payloads.OrgQuotaUpdate)
+				Expect(ok).To(BeTrue())
+				Expect(payload.Name).To(Equal("my-quota"))
+				payload.Apps.TotalInstances = tools.PtrTo[int64](5)
+				return nil
+			}
+
+			orgQuotaRepo.UpdateOrgQuotaReturns(repositories.OrgQuotaRecord{GUID: "quota-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/organization_quotas/quota-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the org quota on top of its current values", func() {
+			Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.UpdateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateOrgQuotaMessage{
+				GUID: "quota-guid",
+				OrgQuota: quotas.OrgQuota{
+					Name: "my-quota",
+					Apps: quotas.AppQuotas{
+						TotalMemoryInMB: tools.PtrTo[int64](1024),
+						TotalInstances:  tools.PtrTo[int64](5),
+					},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "quota-guid")))
+		})
+
+		When("the org quota is not found", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.UpdateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/organization_quotas/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaApply{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "org-2"}},
+				},
+			})
+
+			orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID:          "quota-guid",
+				Organizations: []string{"org-1", "org-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/organization_quotas/quota-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the quota to the organizations", func() {
+			Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ApplyOrgQuotaMessage{
+				GUID:          "quota-guid",
+				Organizations: []string{"org-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("org-1", "org-2"))))
+		})
+
+		When("applying the quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "no such org"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("no such org")
+			})
+		})
+	})
+})
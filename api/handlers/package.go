@@ -21,6 +21,7 @@ const (
 	PackagePath         = "/v3/packages/{guid}"
 	PackagesPath        = "/v3/packages"
 	PackageUploadPath   = "/v3/packages/{guid}/upload"
+	PackageDownloadPath = "/v3/packages/{guid}/download"
 	PackageDropletsPath = "/v3/packages/{guid}/droplets"
 )
 
@@ -38,6 +39,8 @@ type CFPackageRepository interface {
 
 type ImageRepository interface {
 	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error)
+	CopySourceImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, dstRepoRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
 }
 
 type Package struct {
@@ -46,8 +49,10 @@ type Package struct {
 	appRepo             CFAppRepository
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
+	resourceCache       ResourceCache
 	requestValidator    RequestValidator
 	registrySecretNames []string
+	featureFlagChecker  FeatureFlagChecker
 }
 
 func NewPackage(
@@ -56,8 +61,10 @@ func NewPackage(
 	appRepo CFAppRepository,
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
+	resourceCache ResourceCache,
 	requestValidator RequestValidator,
 	registrySecretNames []string,
+	featureFlagChecker FeatureFlagChecker,
 ) *Package {
 	return &Package{
 		serverURL:           serverURL,
@@ -65,8 +72,10 @@ func NewPackage(
 		appRepo:             appRepo,
 		dropletRepo:         dropletRepo,
 		imageRepo:           imageRepo,
+		resourceCache:       resourceCache,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
+		featureFlagChecker:  featureFlagChecker,
 	}
 }
 
@@ -106,6 +115,10 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.create")
 
+	if r.URL.Query().Has("source_guid") {
+		return h.copy(r)
+	}
+
 	var payload payloads.PackageCreate
 	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
@@ -126,6 +139,12 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if payload.Type == "docker" {
+		if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker packages are disabled", "App GUID", appRecord.GUID)
+		}
+	}
+
 	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
@@ -134,6 +153,91 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+// copy creates a package for the app in the payload out of the content of the
+// source package. The image of bits packages is copied to the repository of the
+// new package, so that it is ready to be staged straight away.
+func (h Package) copy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.copy")
+
+	var payload payloads.PackageCopy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sourceGUID := r.URL.Query().Get("source_guid")
+	sourceRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source package is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source package",
+			"Source Package GUID", sourceGUID,
+		)
+	}
+
+	if sourceRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Source package must be in the READY state."),
+			"cannot copy a package that is not ready",
+			"Source Package GUID", sourceGUID,
+		)
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	if sourceRecord.Type == "docker" {
+		if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker packages are disabled", "App GUID", appRecord.GUID)
+		}
+	}
+
+	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord, sourceRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
+	}
+
+	if record.Type != "bits" {
+		return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+	}
+
+	copiedImageRef, err := h.imageRepo.CopySourceImage(r.Context(), authInfo, sourceRecord.SourceImageRef, record.ImageRef, record.SpaceGUID, record.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling CopySourceImage", "Source Package GUID", sourceGUID)
+	}
+
+	record, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, repositories.UpdatePackageSourceMessage{
+		GUID:                record.GUID,
+		SpaceGUID:           record.SpaceGUID,
+		ImageRef:            copiedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+}
+
 func (h Package) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.update")
@@ -162,11 +266,22 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
 	}
 
-	bitsFile, _, err := r.FormFile("bits")
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
+	var bitsSize int64
+	bitsFile, bitsHeader, err := r.FormFile("bits")
+	if err == nil {
+		defer bitsFile.Close()
+		bitsSize = bitsHeader.Size
+	}
+
+	payload := new(payloads.PackageUpload)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request form values")
+	}
+	resources := payload.ToMessage()
+
+	if bitsFile == nil && len(resources) == 0 {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Upload must include either resources or bits"), "Error, neither bits nor resources were uploaded")
 	}
-	defer bitsFile.Close()
 
 	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
@@ -185,11 +300,27 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewPackageBitsAlreadyUploadedError(err), "Error, cannot call package upload state was not AWAITING_UPLOAD", "packageGUID", packageGUID)
 	}
 
-	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, bitsFile, packageRecord.SpaceGUID, packageGUID)
+	var packageZip io.Reader = bitsFile
+	if len(resources) > 0 {
+		assembledPackage, assembleErr := h.resourceCache.AssemblePackage(bitsFile, bitsSize, resources)
+		if assembleErr != nil {
+			return nil, apierrors.LogAndReturn(logger, assembleErr, "Error assembling package from the resource cache")
+		}
+		defer assembledPackage.Close()
+		packageZip = assembledPackage
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, packageZip, packageRecord.SpaceGUID, packageGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling uploadSourceImage")
 	}
 
+	if bitsFile != nil {
+		if err = h.resourceCache.AddResources(bitsFile, bitsSize); err != nil {
+			logger.Info("failed to add the package files to the resource cache", "reason", err)
+		}
+	}
+
 	packageRecord, err = h.packageRepo.UpdatePackageSource(r.Context(), authInfo, repositories.UpdatePackageSourceMessage{
 		GUID:                packageGUID,
 		SpaceGUID:           packageRecord.SpaceGUID,
@@ -203,6 +334,48 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
 }
 
+func (h Package) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.download")
+
+	packageGUID := routing.URLParam(r, "guid")
+	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
+	}
+
+	if packageRecord.Type != "bits" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package type must be bits."),
+			fmt.Sprintf("downloading bits of %s packages is not supported", packageRecord.Type),
+		)
+	}
+
+	if packageRecord.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Package has no bits to download."),
+			"Error, cannot download package bits before they are uploaded", "packageGUID", packageGUID,
+		)
+	}
+
+	bitsReader, err := h.imageRepo.DownloadSourceImage(r.Context(), authInfo, packageRecord.SourceImageRef, packageRecord.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling DownloadSourceImage")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/zip").
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")).
+		WithStream(func(w http.ResponseWriter) error {
+			defer bitsReader.Close()
+
+			_, err := io.Copy(w, bitsReader)
+			return err
+		}), nil
+}
+
 func (h Package) listDroplets(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.list-droplets")
@@ -238,6 +411,7 @@ func (h *Package) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: PackagesPath, Handler: h.list},
 		{Method: "POST", Pattern: PackagesPath, Handler: h.create},
 		{Method: "POST", Pattern: PackageUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: PackageDownloadPath, Handler: h.download},
 		{Method: "GET", Pattern: PackageDropletsPath, Handler: h.listDroplets},
 	}
 }
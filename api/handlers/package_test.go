@@ -29,7 +29,9 @@ var _ = Describe("Package", func() {
 		appRepo                     *fake.CFAppRepository
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
+		resourceCache               *fake.ResourceCache
 		requestValidator            *fake.RequestValidator
+		featureFlagChecker          *fake.FeatureFlagChecker
 		packageImagePullSecretNames []string
 
 		packageGUID string
@@ -44,7 +46,9 @@ var _ = Describe("Package", func() {
 		appRepo = new(fake.CFAppRepository)
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
+		resourceCache = new(fake.ResourceCache)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
 		packageGUID = generateGUID("package")
@@ -59,8 +63,10 @@ var _ = Describe("Package", func() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCache,
 			requestValidator,
 			packageImagePullSecretNames,
+			featureFlagChecker,
 		)
 
 		routerBuilder.LoadRoutes(apiHandler)
@@ -362,6 +368,48 @@ var _ = Describe("Package", func() {
 			})
 		}
 
+		It("does not check feature flags for bits packages", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
+		})
+
+		When("the package type is docker", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCreate{
+					Type: "docker",
+					Relationships: &payloads.PackageRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+					Data: &payloads.PackageData{
+						Image: "some/image",
+					},
+				})
+			})
+
+			It("checks the diego_docker feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal("diego_docker"))
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+			})
+
+			When("the diego_docker feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("diego_docker"))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectFeatureDisabledError("diego_docker")
+				})
+
+				itDoesntCreateAPackage()
+			})
+		})
+
 		When("the app doesn't exist", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(errors.New("NotFound"), repositories.AppResourceType))
@@ -419,6 +467,244 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the POST /v3/packages?source_guid=:guid endpoint", func() {
+		var sourcePackageGUID string
+
+		BeforeEach(func() {
+			sourcePackageGUID = generateGUID("source-package")
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCopy{
+				Relationships: &payloads.PackageRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: appGUID,
+						},
+					},
+				},
+			})
+
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				GUID:           sourcePackageGUID,
+				Type:           "bits",
+				State:          "READY",
+				ImageRef:       "registry.repo/source-app-packages",
+				SourceImageRef: "registry.repo/source-app-packages@sha256:123",
+			}, nil)
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				SpaceGUID: spaceGUID,
+				GUID:      appGUID,
+			}, nil)
+
+			packageRepo.CreatePackageReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "AWAITING_UPLOAD",
+				ImageRef:  "registry.repo/target-app-packages",
+			}, nil)
+
+			imageRepo.CopySourceImageReturns("registry.repo/target-app-packages@sha256:123", nil)
+
+			packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				GUID:      packageGUID,
+				State:     "READY",
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "POST", "/v3/packages?source_guid="+sourcePackageGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("gets the source package and the target app", func() {
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualPackageGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualPackageGUID).To(Equal(sourcePackageGUID))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+		})
+
+		It("creates a package for the target app", func() {
+			Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualCreate).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+			}))
+		})
+
+		It("copies the source image to the new package repository", func() {
+			Expect(imageRepo.CopySourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, srcRef, dstRef, actualSpaceGUID, actualTags := imageRepo.CopySourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(srcRef).To(Equal("registry.repo/source-app-packages@sha256:123"))
+			Expect(dstRef).To(Equal("registry.repo/target-app-packages"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(actualTags).To(ConsistOf(packageGUID))
+		})
+
+		It("sets the copied image as the new package source", func() {
+			Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := packageRepo.UpdatePackageSourceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdatePackageSourceMessage{
+				GUID:                packageGUID,
+				SpaceGUID:           spaceGUID,
+				ImageRef:            "registry.repo/target-app-packages@sha256:123",
+				RegistrySecretNames: packageImagePullSecretNames,
+			}))
+		})
+
+		It("returns the ready package", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", packageGUID),
+				MatchJSONPath("$.state", "READY"),
+			)))
+		})
+
+		When("the source package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:     sourcePackageGUID,
+					Type:     "docker",
+					State:    "READY",
+					ImageRef: "some/image",
+				}, nil)
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{
+					Type:  "docker",
+					GUID:  packageGUID,
+					State: "READY",
+				}, nil)
+			})
+
+			It("creates a package with the same image", func() {
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+				Expect(actualCreate.Type).To(Equal("docker"))
+				Expect(actualCreate.Data).To(Equal(&repositories.PackageData{Image: "some/image"}))
+			})
+
+			It("does not copy any image", func() {
+				Expect(imageRepo.CopySourceImageCallCount()).To(BeZero())
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(BeZero())
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			It("checks the diego_docker feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, _, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualFlag).To(Equal("diego_docker"))
+			})
+
+			When("the diego_docker feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("diego_docker"))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectFeatureDisabledError("diego_docker")
+				})
+			})
+		})
+
+		When("the request JSON is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "test-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("test-error")
+			})
+		})
+
+		When("the source package is not accessible", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the source package has no bits", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					Type:  "bits",
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package must be in the READY state.")
+				Expect(packageRepo.CreatePackageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the target app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+				Expect(packageRepo.CreatePackageCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the package fails", func() {
+			BeforeEach(func() {
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{}, errors.New("create-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.CopySourceImageReturns("", errors.New("copy-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the package source fails", func() {
+			BeforeEach(func() {
+				packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{}, errors.New("update-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the PATCH /v3/packages/:guid endpoint", func() {
 		BeforeEach(func() {
 			packageGUID = generateGUID("package")
@@ -509,6 +795,89 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the GET /v3/packages/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				Type:           "bits",
+				SpaceGUID:      spaceGUID,
+				GUID:           packageGUID,
+				State:          "READY",
+				SourceImageRef: "registry.repo/foo@sha256:123",
+			}, nil)
+
+			imageRepo.DownloadSourceImageReturns(io.NopCloser(strings.NewReader("the-zip-contents")), nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/v3/packages/%s/download", packageGUID), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("downloads the package source image", func() {
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualPackageGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualPackageGUID).To(Equal(packageGUID))
+
+			Expect(imageRepo.DownloadSourceImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualImageRef, actualSpaceGUID := imageRepo.DownloadSourceImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualImageRef).To(Equal("registry.repo/foo@sha256:123"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+		})
+
+		It("streams the bits as a zip", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", fmt.Sprintf("attachment; filename=%q", packageGUID+".zip")))
+			Expect(rr).To(HaveHTTPBody("the-zip-contents"))
+		})
+
+		When("getting the package is forbidden", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Package")
+				Expect(imageRepo.DownloadSourceImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("the package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{Type: "docker", State: "READY"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package type must be bits.")
+			})
+		})
+
+		When("the package bits have not been uploaded", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{Type: "bits", State: "AWAITING_UPLOAD"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package has no bits to download.")
+				Expect(imageRepo.DownloadSourceImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadSourceImageReturns(nil, errors.New("download-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the POST /v3/packages/upload endpoint", func() {
 		var (
 			imageRefWithDigest string
@@ -652,7 +1021,120 @@ var _ = Describe("Package", func() {
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Upload must include bits")
+				expectUnprocessableEntityError("Upload must include either resources or bits")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
+		It("adds the uploaded files to the resource cache", func() {
+			Expect(resourceCache.AddResourcesCallCount()).To(Equal(1))
+			packageZip, size := resourceCache.AddResourcesArgsForCall(0)
+			Expect(size).To(BeEquivalentTo(len("the-src-file-contents")))
+			content := make([]byte, size)
+			_, err := packageZip.ReadAt(content, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("the-src-file-contents"))
+		})
+
+		It("does not assemble the package from the resource cache", func() {
+			Expect(resourceCache.AssemblePackageCallCount()).To(BeZero())
+		})
+
+		When("adding the uploaded files to the resource cache fails", func() {
+			BeforeEach(func() {
+				resourceCache.AddResourcesReturns(errors.New("cache-err"))
+			})
+
+			It("uploads the package anyway", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+			})
+		})
+
+		When("resources are given", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageUpload{
+					Resources: []payloads.ResourceMatch{{
+						Checksum:    payloads.ResourceChecksum{Value: "the-sha"},
+						SizeInBytes: 42,
+						Path:        "path/to/file",
+						Mode:        "644",
+					}},
+				})
+				resourceCache.AssemblePackageReturns(io.NopCloser(strings.NewReader("the-assembled-package")), nil)
+			})
+
+			It("assembles the package from the bits and the cached resources", func() {
+				Expect(resourceCache.AssemblePackageCallCount()).To(Equal(1))
+				bits, bitsSize, resources := resourceCache.AssemblePackageArgsForCall(0)
+				Expect(bits).NotTo(BeNil())
+				Expect(bitsSize).To(BeEquivalentTo(len("the-src-file-contents")))
+				Expect(resources).To(Equal([]repositories.ResourceRecord{{
+					SHA1:        "the-sha",
+					SizeInBytes: 42,
+					Path:        "path/to/file",
+					Mode:        "644",
+				}}))
+			})
+
+			It("uploads the assembled package", func() {
+				Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+				_, _, _, srcFile, _, _ := imageRepo.UploadSourceImageArgsForCall(0)
+				actualSrcContents, err := io.ReadAll(srcFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(actualSrcContents)).To(Equal("the-assembled-package"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			It("adds the uploaded files to the resource cache", func() {
+				Expect(resourceCache.AddResourcesCallCount()).To(Equal(1))
+			})
+
+			When("no bits file is given", func() {
+				BeforeEach(func() {
+					var b bytes.Buffer
+					writer := multipart.NewWriter(&b)
+					Expect(writer.Close()).To(Succeed())
+					body = &b
+					formDataHeader = writer.FormDataContentType()
+				})
+
+				It("assembles the package from the cached resources only", func() {
+					Expect(resourceCache.AssemblePackageCallCount()).To(Equal(1))
+					bits, bitsSize, _ := resourceCache.AssemblePackageArgsForCall(0)
+					Expect(bits).To(BeNil())
+					Expect(bitsSize).To(BeZero())
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				It("does not add anything to the resource cache", func() {
+					Expect(resourceCache.AddResourcesCallCount()).To(BeZero())
+				})
+			})
+
+			When("assembling the package fails", func() {
+				BeforeEach(func() {
+					resourceCache.AssemblePackageReturns(nil, apierrors.NewUnprocessableEntityError(nil, "resource not cached"))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("resource not cached")
+				})
+				itDoesntUploadSourceImage()
+				itDoesntUpdateAnyPackages()
+			})
+		})
+
+		When("the form values are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid resources"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid resources")
 			})
 			itDoesntUploadSourceImage()
 			itDoesntUpdateAnyPackages()
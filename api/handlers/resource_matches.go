@@ -1,25 +1,53 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	ResourceMatchesPath = "/v3/resource_matches"
 )
 
-type ResourceMatches struct{}
+//counterfeiter:generate -o fake -fake-name ResourceCache . ResourceCache
+
+type ResourceCache interface {
+	MatchResources(resources []repositories.ResourceRecord) []repositories.ResourceRecord
+	AddResources(packageZip io.ReaderAt, size int64) error
+	AssemblePackage(bits io.ReaderAt, bitsSize int64, resources []repositories.ResourceRecord) (io.ReadCloser, error)
+}
+
+type ResourceMatches struct {
+	resourceCache    ResourceCache
+	requestValidator RequestValidator
+}
 
-func NewResourceMatches() *ResourceMatches {
-	return &ResourceMatches{}
+func NewResourceMatches(resourceCache ResourceCache, requestValidator RequestValidator) *ResourceMatches {
+	return &ResourceMatches{
+		resourceCache:    resourceCache,
+		requestValidator: requestValidator,
+	}
 }
 
 func (h *ResourceMatches) create(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusCreated).WithBody(map[string]interface{}{
-		"resources": []interface{}{},
-	}), nil
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.resource-matches.create")
+
+	var payload payloads.ResourceMatches
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	matches := h.resourceCache.MatchResources(payload.ToMessage())
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForResourceMatches(matches)), nil
 }
 
 func (h *ResourceMatches) UnauthenticatedRoutes() []routing.Route {
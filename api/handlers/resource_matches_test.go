@@ -4,17 +4,28 @@ import (
 	"net/http"
 	"strings"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("ResourceMatches", func() {
-	var req *http.Request
+	var (
+		req              *http.Request
+		resourceCache    *fake.ResourceCache
+		requestValidator *fake.RequestValidator
+	)
 
 	BeforeEach(func() {
-		apiHandler := NewResourceMatches()
+		resourceCache = new(fake.ResourceCache)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewResourceMatches(resourceCache, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -22,19 +33,76 @@ var _ = Describe("ResourceMatches", func() {
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
-	Describe("Get Resource Match Endpoint", func() {
+	Describe("POST /v3/resource_matches", func() {
 		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ResourceMatches{
+				Resources: []payloads.ResourceMatch{
+					{Checksum: payloads.ResourceChecksum{Value: "sha-1"}, SizeInBytes: 1, Path: "file-1", Mode: "644"},
+					{Checksum: payloads.ResourceChecksum{Value: "sha-2"}, SizeInBytes: 2, Path: "file-2", Mode: "755"},
+				},
+			})
+
+			resourceCache.MatchResourcesReturns([]repositories.ResourceRecord{
+				{SHA1: "sha-2", SizeInBytes: 2, Path: "file-2", Mode: "755"},
+			})
+
 			var err error
-			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("{}"))
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("the-json-body"))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("returns an empty list", func() {
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("matches the resources against the resource cache", func() {
+			Expect(resourceCache.MatchResourcesCallCount()).To(Equal(1))
+			Expect(resourceCache.MatchResourcesArgsForCall(0)).To(Equal([]repositories.ResourceRecord{
+				{SHA1: "sha-1", SizeInBytes: 1, Path: "file-1", Mode: "644"},
+				{SHA1: "sha-2", SizeInBytes: 2, Path: "file-2", Mode: "755"},
+			}))
+		})
+
+		It("returns the matching resources", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"resources": []
-			  }`)))
+				"resources": [
+					{
+						"checksum": {"value": "sha-2"},
+						"size_in_bytes": 2,
+						"path": "file-2",
+						"mode": "755"
+					}
+				]
+			}`)))
+		})
+
+		When("no resources match", func() {
+			BeforeEach(func() {
+				resourceCache.MatchResourcesReturns([]repositories.ResourceRecord{})
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"resources": []}`)))
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+
+			It("does not match any resources", func() {
+				Expect(resourceCache.MatchResourcesCallCount()).To(BeZero())
+			})
 		})
 	})
 })
@@ -0,0 +1,219 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		apiHandler       *handlers.Revision
+		revisionRepo     *fake.CFRevisionRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		revisionRepo = new(fake.CFRevisionRepository)
+		apiHandler = handlers.NewRevision(
+			*serverURL,
+			requestValidator,
+			revisionRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+			{GUID: "revision-1", AppGUID: "app-guid", Version: 1},
+			{GUID: "revision-2", AppGUID: "app-guid", Version: 2, Deployed: true},
+		}, nil)
+		revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+			GUID:        "revision-1",
+			AppGUID:     "app-guid",
+			Version:     1,
+			DropletGUID: "droplet-guid",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RevisionList{
+				Versions: "1,2",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/revisions?versions=1,2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the app revisions", func() {
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:  "app-guid",
+				Versions: []string{"1", "2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/app-guid/revisions?versions=1,2"),
+				MatchJSONPath("$.resources[0].guid", "revision-1"),
+				MatchJSONPath("$.resources[1].guid", "revision-2"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(nil, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("listing the revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions/deployed", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-2", AppGUID: "app-guid", Version: 2, Deployed: true},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/revisions/deployed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the deployed revisions", func() {
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, _, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:  "app-guid",
+				Deployed: true,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "revision-2"),
+			)))
+		})
+	})
+
+	Describe("GET /v3/revisions/{guid}", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/revisions/revision-1", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the revision", func() {
+			Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "revision-1"),
+				MatchJSONPath("$.version", BeEquivalentTo(1)),
+				MatchJSONPath("$.droplet.guid", "droplet-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/revisions/revision-1"),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Revision")
+			})
+		})
+	})
+
+	Describe("GET /v3/revisions/{guid}/environment_variables", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionEnvVarsReturns(repositories.RevisionEnvVarsRecord{
+				RevisionGUID:         "revision-1",
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/revisions/revision-1/environment_variables", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the revision environment variables", func() {
+			Expect(revisionRepo.GetRevisionEnvVarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionEnvVarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.var.FOO", "bar"),
+				MatchJSONPath("$.links.revision.href", "https://api.example.org/v3/revisions/revision-1"),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Revision")
+				Expect(revisionRepo.GetRevisionEnvVarsCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user cannot read the environment variables", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionEnvVarsReturns(repositories.RevisionEnvVarsRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
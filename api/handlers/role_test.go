@@ -26,16 +26,18 @@ const (
 
 var _ = Describe("Role", func() {
 	var (
-		apiHandler       *handlers.Role
-		roleRepo         *fake.CFRoleRepository
-		requestValidator *fake.RequestValidator
+		apiHandler         *handlers.Role
+		roleRepo           *fake.CFRoleRepository
+		requestValidator   *fake.RequestValidator
+		featureFlagChecker *fake.FeatureFlagChecker
 	)
 
 	BeforeEach(func() {
 		roleRepo = new(fake.CFRoleRepository)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
-		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator)
+		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator, featureFlagChecker)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -101,6 +103,28 @@ var _ = Describe("Role", func() {
 				_, _, roleMessage := roleRepo.CreateRoleArgsForCall(0)
 				Expect(roleMessage.User).To(Equal("my-user"))
 			})
+
+			It("does not check the set_roles_by_username feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
+			})
+		})
+
+		It("checks the set_roles_by_username feature flag", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal("set_roles_by_username"))
+		})
+
+		When("the set_roles_by_username feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("set_roles_by_username"))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectFeatureDisabledError("set_roles_by_username")
+				Expect(roleRepo.CreateRoleCallCount()).To(BeZero())
+			})
 		})
 
 		When("the role is an organisation role", func() {
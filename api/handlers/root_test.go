@@ -18,7 +18,7 @@ var _ = Describe("Root", func() {
 	)
 
 	BeforeEach(func() {
-		apiHandler = handlers.NewRoot(*serverURL, config.UAA{})
+		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, config.SSHProxy{})
 	})
 
 	JustBeforeEach(func() {
@@ -49,7 +49,7 @@ var _ = Describe("Root", func() {
 				apiHandler = handlers.NewRoot(*serverURL, config.UAA{
 					Enabled: true,
 					URL:     "https://my.uaa",
-				})
+				}, config.SSHProxy{})
 			})
 
 			It("returns the uaa config", func() {
@@ -62,5 +62,25 @@ var _ = Describe("Root", func() {
 				)))
 			})
 		})
+
+		When("the ssh proxy is enabled", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, config.SSHProxy{
+					Enabled:            true,
+					Host:               "ssh.example.org:2222",
+					HostKeyFingerprint: "the-fingerprint",
+				})
+			})
+
+			It("returns the app_ssh link", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.links.app_ssh.href", "ssh.example.org:2222"),
+					MatchJSONPath("$.links.app_ssh.meta.host_key_fingerprint", "the-fingerprint"),
+					MatchJSONPath("$.links.app_ssh.meta.oauth_client", "ssh-proxy"),
+				)))
+			})
+		})
 	})
 })
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -11,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -20,6 +23,9 @@ const (
 	RoutesPath            = "/v3/routes"
 	RouteDestinationsPath = "/v3/routes/{guid}/destinations"
 	RouteDestinationPath  = "/v3/routes/{guid}/destinations/{destination_guid}"
+	RouteSharedSpacesPath = "/v3/routes/{guid}/relationships/shared_spaces"
+	RouteSharedSpacePath  = "/v3/routes/{guid}/relationships/shared_spaces/{space_guid}"
+	RouteSpacePath        = "/v3/routes/{guid}/relationships/space"
 )
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
@@ -27,21 +33,26 @@ const (
 type CFRouteRepository interface {
 	GetRoute(context.Context, authorization.Info, string) (repositories.RouteRecord, error)
 	ListRoutes(context.Context, authorization.Info, repositories.ListRoutesMessage) ([]repositories.RouteRecord, error)
-	ListRoutesForApp(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
+	ListRoutesForApp(context.Context, authorization.Info, string) ([]repositories.RouteRecord, error)
 	CreateRoute(context.Context, authorization.Info, repositories.CreateRouteMessage) (repositories.RouteRecord, error)
 	DeleteRoute(context.Context, authorization.Info, repositories.DeleteRouteMessage) error
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationMessage) (repositories.RouteRecord, error)
 	PatchRouteMetadata(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)
+	ShareRoute(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	UnshareRoute(context.Context, authorization.Info, repositories.UnshareRouteMessage) (repositories.RouteRecord, error)
+	TransferRoute(context.Context, authorization.Info, repositories.TransferRouteMessage) (repositories.RouteRecord, error)
 }
 
 type Route struct {
-	serverURL        url.URL
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	appRepo          CFAppRepository
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	appRepo            CFAppRepository
+	spaceRepo          CFSpaceRepository
+	routerGroupRepo    RouterGroupRepository
+	featureFlagChecker FeatureFlagChecker
+	requestValidator   RequestValidator
 }
 
 func NewRoute(
@@ -50,15 +61,19 @@ func NewRoute(
 	domainRepo CFDomainRepository,
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
+	routerGroupRepo RouterGroupRepository,
+	featureFlagChecker FeatureFlagChecker,
 	requestValidator RequestValidator,
 ) *Route {
 	return &Route{
-		serverURL:        serverURL,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		appRepo:          appRepo,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		routerGroupRepo:    routerGroupRepo,
+		featureFlagChecker: featureFlagChecker,
+		requestValidator:   requestValidator,
 	}
 }
 
@@ -147,6 +162,13 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 	}
 
 	createRouteMessage := payload.ToMessage(domain.Namespace, domain.Name)
+	if domain.RouterGroup != "" {
+		createRouteMessage, err = h.toTCPRouteMessage(r.Context(), createRouteMessage, domain)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Invalid TCP route", "routerGroup", domain.RouterGroup)
+		}
+	}
+
 	responseRouteRecord, err := h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create route", "Route Host", payload.Host)
@@ -157,6 +179,36 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
 }
 
+// toTCPRouteMessage checks that the requested port is reserved by the router
+// group of the domain. When no port is requested, the route is assigned any
+// available port of the router group.
+func (h *Route) toTCPRouteMessage(ctx context.Context, message repositories.CreateRouteMessage, domain repositories.DomainRecord) (repositories.CreateRouteMessage, error) {
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(ctx, domain.RouterGroup)
+	if err != nil {
+		return repositories.CreateRouteMessage{}, apierrors.AsUnprocessableEntity(
+			err,
+			fmt.Sprintf("Router group with guid '%s' not found.", domain.RouterGroup),
+			apierrors.NotFoundError{},
+		)
+	}
+
+	message.Protocol = string(korifiv1alpha1.ProtocolTCP)
+
+	if message.Port == nil {
+		message.AvailablePorts = routerGroup.Ports()
+		return message, nil
+	}
+
+	if !routerGroup.ReservesPort(*message.Port) {
+		return repositories.CreateRouteMessage{}, apierrors.NewUnprocessableEntityError(
+			nil,
+			fmt.Sprintf("Port %d is not available in the router group '%s'. Reservable ports: %s.", *message.Port, routerGroup.Name, routerGroup.ReservablePorts),
+		)
+	}
+
+	return message, nil
+}
+
 func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.insert-destinations")
@@ -174,6 +226,11 @@ func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	}
 
 	destinationListCreateMessage := destinationCreatePayload.ToMessage(routeRecord)
+	if len(routeRecord.SharedSpaces) > 0 {
+		if err = h.resolveDestinationSpaces(r.Context(), authInfo, routeRecord, destinationListCreateMessage.NewDestinations); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Invalid destinations", "Route GUID", routeRecord.GUID)
+		}
+	}
 
 	responseRouteRecord, err := h.routeRepo.AddDestinationsToRoute(r.Context(), authInfo, destinationListCreateMessage)
 	if err != nil {
@@ -183,6 +240,34 @@ func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteDestinations(responseRouteRecord, h.serverURL)), nil
 }
 
+// resolveDestinationSpaces sets the space of the destination apps of a shared
+// route, which must be either the space of the route or one of its shared
+// spaces
+func (h *Route) resolveDestinationSpaces(ctx context.Context, authInfo authorization.Info, routeRecord repositories.RouteRecord, destinations []repositories.DesiredDestination) error {
+	for i, destination := range destinations {
+		app, err := h.appRepo.GetApp(ctx, authInfo, destination.AppGUID)
+		if err != nil {
+			return apierrors.AsUnprocessableEntity(
+				err,
+				fmt.Sprintf("App with guid '%s' not found.", destination.AppGUID),
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			)
+		}
+
+		if app.SpaceGUID != routeRecord.SpaceGUID && !slices.Contains(routeRecord.SharedSpaces, app.SpaceGUID) {
+			return apierrors.NewUnprocessableEntityError(
+				nil,
+				"Routes destinations must be in either the route's space or the route's shared spaces",
+			)
+		}
+
+		destinations[i].SpaceGUID = app.SpaceGUID
+	}
+
+	return nil
+}
+
 func (h *Route) deleteDestination(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.delete-destination")
@@ -294,6 +379,132 @@ func (h *Route) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoute(route, h.serverURL)), nil
 }
 
+func (h *Route) listSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.list-shared-spaces")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(route, h.serverURL)), nil
+}
+
+func (h *Route) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.share")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.RouteShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagRouteSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route sharing is disabled", "RouteGUID", routeGUID)
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	for _, spaceGUID := range payload.GUIDs() {
+		if spaceGUID == route.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share route '%s' with space '%s'. Routes cannot be shared into the space where they were created.", routeGUID, spaceGUID)),
+				"Cannot share route with its own space", "RouteGUID", routeGUID,
+			)
+		}
+
+		if err = h.ensureSpaceExists(r.Context(), authInfo, spaceGUID); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch shared space from Kubernetes", "spaceGUID", spaceGUID)
+		}
+	}
+
+	route, err = h.routeRepo.ShareRoute(r.Context(), authInfo, payload.ToMessage(route))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share route", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(route, h.serverURL)), nil
+}
+
+func (h *Route) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.unshare")
+
+	routeGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	_, err = h.routeRepo.UnshareRoute(r.Context(), authInfo, repositories.UnshareRouteMessage{
+		RouteGUID:       route.GUID,
+		SpaceGUID:       route.SpaceGUID,
+		SharedSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare route", "RouteGUID", routeGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Route) transfer(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.transfer")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.RouteTransfer
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagRouteSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route sharing is disabled", "RouteGUID", routeGUID)
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	if err = h.ensureSpaceExists(r.Context(), authInfo, payload.Data.GUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch target space from Kubernetes", "spaceGUID", payload.Data.GUID)
+	}
+
+	_, err = h.routeRepo.TransferRoute(r.Context(), authInfo, payload.ToMessage(route))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to transfer route", "RouteGUID", routeGUID, "spaceGUID", payload.Data.GUID)
+	}
+
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *Route) ensureSpaceExists(ctx context.Context, authInfo authorization.Info, spaceGUID string) error {
+	_, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(
+			err,
+			"Invalid space. Ensure that the space exists and you have access to it.",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	return nil
+}
+
 func (h *Route) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -308,5 +519,9 @@ func (h *Route) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: RouteDestinationsPath, Handler: h.insertDestinations},
 		{Method: "DELETE", Pattern: RouteDestinationPath, Handler: h.deleteDestination},
 		{Method: "PATCH", Pattern: RoutePath, Handler: h.update},
+		{Method: "GET", Pattern: RouteSharedSpacesPath, Handler: h.listSharedSpaces},
+		{Method: "POST", Pattern: RouteSharedSpacesPath, Handler: h.share},
+		{Method: "DELETE", Pattern: RouteSharedSpacePath, Handler: h.unshare},
+		{Method: "PATCH", Pattern: RouteSpacePath, Handler: h.transfer},
 	}
 }
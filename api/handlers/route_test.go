@@ -1,11 +1,14 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -21,11 +24,13 @@ import (
 
 var _ = Describe("Route", func() {
 	var (
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		appRepo          *fake.CFAppRepository
-		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
+		routeRepo          *fake.CFRouteRepository
+		domainRepo         *fake.CFDomainRepository
+		appRepo            *fake.CFAppRepository
+		spaceRepo          *fake.CFSpaceRepository
+		routerGroupRepo    *fake.RouterGroupRepository
+		featureFlagChecker *fake.FeatureFlagChecker
+		requestValidator   *fake.RequestValidator
 
 		requestMethod string
 		requestPath   string
@@ -64,6 +69,15 @@ var _ = Describe("Route", func() {
 			Name: "test-space-guid",
 		}, nil)
 
+		routerGroupRepo = new(fake.RouterGroupRepository)
+		routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+			GUID:            "default-tcp",
+			Name:            "default-tcp",
+			ReservablePorts: "1024-1025",
+			PortRanges:      []config.PortRange{{Start: 1024, End: 1025}},
+		}, nil)
+
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewRoute(
@@ -72,6 +86,8 @@ var _ = Describe("Route", func() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
+			featureFlagChecker,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -250,6 +266,8 @@ var _ = Describe("Route", func() {
 	})
 
 	Describe("the POST /v3/routes endpoint", func() {
+		var payload payloads.RouteCreate
+
 		BeforeEach(func() {
 			requestMethod = http.MethodPost
 			requestPath = "/v3/routes"
@@ -267,7 +285,7 @@ var _ = Describe("Route", func() {
 
 			requestBody = "the-json-body"
 
-			payload := payloads.RouteCreate{
+			payload = payloads.RouteCreate{
 				Host: "test-route-host",
 				Path: "/test-route-path",
 				Relationships: &payloads.RouteRelationships{
@@ -319,6 +337,80 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		It("creates an http route", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(BeZero())
+
+			_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+			Expect(createRouteMessage.Protocol).To(BeEmpty())
+			Expect(createRouteMessage.Port).To(BeNil())
+			Expect(createRouteMessage.AvailablePorts).To(BeEmpty())
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:        "test-domain-guid",
+					Name:        "tcp.example.org",
+					RouterGroup: "default-tcp",
+				}, nil)
+
+				payload.Host = ""
+				payload.Path = ""
+				payload.Port = tools.PtrTo[int32](1025)
+			})
+
+			It("creates a tcp route on the requested port", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, actualRouterGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(actualRouterGroupGUID).To(Equal("default-tcp"))
+
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+				Expect(createRouteMessage.Protocol).To(Equal("tcp"))
+				Expect(createRouteMessage.Port).To(PointTo(BeEquivalentTo(1025)))
+				Expect(createRouteMessage.AvailablePorts).To(BeEmpty())
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("no port is requested", func() {
+				BeforeEach(func() {
+					payload.Port = nil
+				})
+
+				It("lets the repository pick any port of the router group", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+					_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+					Expect(createRouteMessage.Port).To(BeNil())
+					Expect(createRouteMessage.AvailablePorts).To(ConsistOf(BeEquivalentTo(1024), BeEquivalentTo(1025)))
+				})
+			})
+
+			When("the port is not reserved by the router group", func() {
+				BeforeEach(func() {
+					payload.Port = tools.PtrTo[int32](2000)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Port 2000 is not available in the router group 'default-tcp'. Reservable ports: 1024-1025.")
+				})
+
+				It("does not create the route", func() {
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+				})
+			})
+
+			When("the router group does not exist", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Router group with guid 'default-tcp' not found.")
+				})
+			})
+		})
+
 		When("the request body is invalid JSON", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -626,6 +718,69 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		It("does not look up the destination apps", func() {
+			Expect(appRepo.GetAppCallCount()).To(BeZero())
+		})
+
+		When("the route is shared", func() {
+			BeforeEach(func() {
+				routeRecord.SharedSpaces = []string{"shared-space-guid"}
+				routeRepo.GetRouteReturns(routeRecord, nil)
+
+				appRepo.GetAppStub = func(_ context.Context, _ authorization.Info, appGUID string) (repositories.AppRecord, error) {
+					if appGUID == "app-2-guid" {
+						return repositories.AppRecord{GUID: appGUID, SpaceGUID: "shared-space-guid"}, nil
+					}
+					return repositories.AppRecord{GUID: appGUID, SpaceGUID: "test-space-guid"}, nil
+				}
+			})
+
+			It("sets the space of the destination apps", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(2))
+				_, actualAuthInfo, _ := appRepo.GetAppArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				_, _, message := routeRepo.AddDestinationsToRouteArgsForCall(0)
+				Expect(message.NewDestinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"AppGUID":   Equal("app-1-guid"),
+						"SpaceGUID": Equal("test-space-guid"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"AppGUID":   Equal("app-2-guid"),
+						"SpaceGUID": Equal("shared-space-guid"),
+					}),
+				))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			When("a destination app is in a space the route is not shared with", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-1-guid", SpaceGUID: "another-space-guid"}, nil)
+					appRepo.GetAppStub = nil
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(BeZero())
+					expectUnprocessableEntityError("Routes destinations must be in either the route's space or the route's shared spaces")
+				})
+			})
+
+			When("a destination app does not exist", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+					appRepo.GetAppStub = nil
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(routeRepo.AddDestinationsToRouteCallCount()).To(BeZero())
+					expectUnprocessableEntityError("App with guid 'app-1-guid' not found.")
+				})
+			})
+		})
+
 		When("the route doesn't exist", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
@@ -790,4 +945,286 @@ var _ = Describe("Route", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			routeRecord.SharedSpaces = []string{"shared-space-guid"}
+			routeRepo.GetRouteReturns(routeRecord, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces"
+		})
+
+		It("returns the shared spaces of the route", func() {
+			Expect(routeRepo.GetRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRouteGUID := routeRepo.GetRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRouteGUID).To(Equal("test-route-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(1)),
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the user lacks permission to fetch the route", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns not found", func() {
+				expectNotFoundError("Route")
+			})
+		})
+	})
+
+	Describe("the POST /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			routeRepo.ShareRouteReturns(repositories.RouteRecord{
+				GUID:         "test-route-guid",
+				SharedSpaces: []string{"space-1-guid", "space-2-guid"},
+			}, nil)
+
+			requestMethod = http.MethodPost
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces"
+			requestBody = "the-json-body"
+
+			payload := payloads.RouteShare{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-1-guid"}, {GUID: "space-2-guid"}},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+		})
+
+		It("shares the route with the spaces", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagRouteSharing))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(1)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-2-guid"))
+
+			Expect(routeRepo.ShareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.ShareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareRouteMessage{
+				RouteGUID:        "test-route-guid",
+				SpaceGUID:        "test-space-guid",
+				SharedSpaceGUIDs: []string{"space-1-guid", "space-2-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(2)),
+				MatchJSONPath("$.data[0].guid", "space-1-guid"),
+				MatchJSONPath("$.data[1].guid", "space-2-guid"),
+			)))
+		})
+
+		When("route sharing is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(repositories.FeatureFlagRouteSharing))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+				expectFeatureDisabledError(repositories.FeatureFlagRouteSharing)
+			})
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns not found", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("sharing the route with its own space", func() {
+			BeforeEach(func() {
+				payload := payloads.RouteShare{
+					ToManyRelationship: payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "test-space-guid"}},
+					},
+				}
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Unable to share route 'test-route-guid' with space 'test-space-guid'. Routes cannot be shared into the space where they were created.")
+			})
+		})
+
+		When("sharing the route errors", func() {
+			BeforeEach(func() {
+				routeRepo.ShareRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(BeZero())
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/routes/:guid/relationships/shared_spaces/:space_guid endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces/shared-space-guid"
+		})
+
+		It("unshares the route with the space", func() {
+			Expect(routeRepo.UnshareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.UnshareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareRouteMessage{
+				RouteGUID:       "test-route-guid",
+				SpaceGUID:       "test-space-guid",
+				SharedSpaceGUID: "shared-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns not found", func() {
+				Expect(routeRepo.UnshareRouteCallCount()).To(BeZero())
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("unsharing the route errors", func() {
+			BeforeEach(func() {
+				routeRepo.UnshareRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/routes/:guid/relationships/space endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/routes/test-route-guid/relationships/space"
+			requestBody = "the-json-body"
+
+			payload := payloads.RouteTransfer{
+				Relationship: payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "target-space-guid"},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+		})
+
+		It("transfers the route to the space", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, _, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagRouteSharing))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("target-space-guid"))
+
+			Expect(routeRepo.TransferRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.TransferRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.TransferRouteMessage{
+				RouteGUID:       "test-route-guid",
+				SpaceGUID:       "test-space-guid",
+				TargetSpaceGUID: "target-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("route sharing is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(repositories.FeatureFlagRouteSharing))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(routeRepo.TransferRouteCallCount()).To(BeZero())
+				expectFeatureDisabledError(repositories.FeatureFlagRouteSharing)
+			})
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns not found", func() {
+				Expect(routeRepo.TransferRouteCallCount()).To(BeZero())
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("the target space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(routeRepo.TransferRouteCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("transferring the route errors", func() {
+			BeforeEach(func() {
+				routeRepo.TransferRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	RouterGroupsPath = "/routing/v1/router_groups"
+	RouterGroupPath  = "/routing/v1/router_groups/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name RouterGroupRepository . RouterGroupRepository
+
+type RouterGroupRepository interface {
+	ListRouterGroups(context.Context, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)
+	GetRouterGroup(context.Context, string) (repositories.RouterGroupRecord, error)
+}
+
+// RouterGroup serves the router groups endpoints of the CF routing API, which
+// the cf CLI uses to look up the router group of TCP domains
+type RouterGroup struct {
+	routerGroupRepo  RouterGroupRepository
+	requestValidator RequestValidator
+}
+
+func NewRouterGroup(
+	routerGroupRepo RouterGroupRepository,
+	requestValidator RequestValidator,
+) *RouterGroup {
+	return &RouterGroup{
+		routerGroupRepo:  routerGroupRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *RouterGroup) list(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.list")
+
+	payload := new(payloads.RouterGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	routerGroups, err := h.routerGroupRepo.ListRouterGroups(r.Context(), payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list router groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouterGroups(routerGroups)), nil
+}
+
+func (h *RouterGroup) get(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.get")
+
+	routerGroupGUID := routing.URLParam(r, "guid")
+
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(r.Context(), routerGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to get router group", "guid", routerGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouterGroup(routerGroup)), nil
+}
+
+func (h *RouterGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *RouterGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RouterGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: RouterGroupPath, Handler: h.get},
+	}
+}
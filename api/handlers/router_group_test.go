@@ -0,0 +1,136 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroup", func() {
+	var (
+		req              *http.Request
+		routerGroupRepo  *fake.RouterGroupRepository
+		requestValidator *fake.RequestValidator
+		routerGroup      repositories.RouterGroupRecord
+	)
+
+	BeforeEach(func() {
+		routerGroupRepo = new(fake.RouterGroupRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		routerGroup = repositories.RouterGroupRecord{
+			GUID:            "default-tcp",
+			Name:            "default-tcp",
+			Type:            "tcp",
+			ReservablePorts: "1024-1033",
+		}
+
+		apiHandler := NewRouterGroup(routerGroupRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /routing/v1/router_groups", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouterGroupList{
+				Name: "default-tcp",
+			})
+			routerGroupRepo.ListRouterGroupsReturns([]repositories.RouterGroupRecord{routerGroup}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups?name=default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the router groups", func() {
+			Expect(routerGroupRepo.ListRouterGroupsCallCount()).To(Equal(1))
+			_, message := routerGroupRepo.ListRouterGroupsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListRouterGroupsMessage{Names: []string{"default-tcp"}}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$", HaveLen(1)),
+				MatchJSONPath("$[0].guid", "default-tcp"),
+				MatchJSONPath("$[0].name", "default-tcp"),
+				MatchJSONPath("$[0].type", "tcp"),
+				MatchJSONPath("$[0].reservable_ports", "1024-1033"),
+			)))
+		})
+
+		When("there are no router groups", func() {
+			BeforeEach(func() {
+				routerGroupRepo.ListRouterGroupsReturns([]repositories.RouterGroupRecord{}, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON("[]")))
+			})
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(errors.New("foo"), "invalid"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid")
+			})
+		})
+
+		When("listing the router groups fails", func() {
+			BeforeEach(func() {
+				routerGroupRepo.ListRouterGroupsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /routing/v1/router_groups/{guid}", func() {
+		BeforeEach(func() {
+			routerGroupRepo.GetRouterGroupReturns(routerGroup, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups/default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the router group", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+			_, guid := routerGroupRepo.GetRouterGroupArgsForCall(0)
+			Expect(guid).To(Equal("default-tcp"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "default-tcp"),
+				MatchJSONPath("$.reservable_ports", "1024-1033"),
+			)))
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RouterGroupResourceType)
+			})
+		})
+	})
+})
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"github.com/go-logr/logr"
+)
+
+const (
+	SecurityGroupsPath             = "/v3/security_groups"
+	SecurityGroupPath              = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesPath = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupStagingSpacesPath = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupRunningSpacePath  = "/v3/security_groups/{guid}/relationships/running_spaces/{space_guid}"
+	SecurityGroupStagingSpacePath  = "/v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSecurityGroupRepository . CFSecurityGroupRepository
+type CFSecurityGroupRepository interface {
+	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupMessage) ([]repositories.SecurityGroupRecord, error)
+	UpdateSecurityGroup(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
+}
+
+type SecurityGroup struct {
+	serverURL         url.URL
+	requestValidator  RequestValidator
+	securityGroupRepo CFSecurityGroupRepository
+}
+
+func NewSecurityGroup(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	securityGroupRepo CFSecurityGroupRepository,
+) *SecurityGroup {
+	return &SecurityGroup{
+		serverURL:         serverURL,
+		requestValidator:  requestValidator,
+		securityGroupRepo: securityGroupRepo,
+	}
+}
+
+func (h *SecurityGroup) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.create")
+
+	var payload payloads.SecurityGroupCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	securityGroup, err := h.securityGroupRepo.CreateSecurityGroup(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create security group")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.list")
+
+	var payload payloads.SecurityGroupList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list security groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSecurityGroup, securityGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *SecurityGroup) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", guid)
+	}
+
+	payload := payloads.SecurityGroupUpdate{
+		Name:            securityGroup.Name,
+		GloballyEnabled: securityGroup.GloballyEnabled,
+	}
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	securityGroup, err = h.securityGroupRepo.UpdateSecurityGroup(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update security group", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", guid)
+	}
+
+	err = h.securityGroupRepo.DeleteSecurityGroup(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete security group", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(guid, presenter.SecurityGroupDeleteOperation, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) bindRunningSpaces(r *http.Request) (*routing.Response, error) {
+	securityGroup, err := h.bind(r, securitygroups.WorkloadRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupRunningSpaces(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) bindStagingSpaces(r *http.Request) (*routing.Response, error) {
+	securityGroup, err := h.bind(r, securitygroups.WorkloadStaging)
+	if err != nil {
+		return nil, err
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupStagingSpaces(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) bind(r *http.Request, workload string) (repositories.SecurityGroupRecord, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.bind")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.SecurityGroupBind
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, guid)
+	if err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", guid)
+	}
+
+	securityGroup, err := h.securityGroupRepo.BindSecurityGroup(r.Context(), authInfo, payload.ToMessage(guid, workload))
+	if err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, err, "failed to bind security group", "guid", guid, "workload", workload)
+	}
+
+	return securityGroup, nil
+}
+
+func (h *SecurityGroup) unbindRunningSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, securitygroups.WorkloadRunning)
+}
+
+func (h *SecurityGroup) unbindStagingSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, securitygroups.WorkloadStaging)
+}
+
+func (h *SecurityGroup) unbind(r *http.Request, workload string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.unbind")
+
+	guid := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", guid)
+	}
+
+	err = h.securityGroupRepo.UnbindSecurityGroup(r.Context(), authInfo, repositories.UnbindSecurityGroupMessage{
+		GUID:     guid,
+		Workload: workload,
+		Space:    spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unbind security group", "guid", guid, "workload", workload, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SecurityGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SecurityGroupsPath, Handler: h.create},
+		{Method: "GET", Pattern: SecurityGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: SecurityGroupPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SecurityGroupPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SecurityGroupPath, Handler: h.delete},
+		{Method: "POST", Pattern: SecurityGroupRunningSpacesPath, Handler: h.bindRunningSpaces},
+		{Method: "POST", Pattern: SecurityGroupStagingSpacesPath, Handler: h.bindStagingSpaces},
+		{Method: "DELETE", Pattern: SecurityGroupRunningSpacePath, Handler: h.unbindRunningSpace},
+		{Method: "DELETE", Pattern: SecurityGroupStagingSpacePath, Handler: h.unbindStagingSpace},
+	}
+}
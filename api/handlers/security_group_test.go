@@ -0,0 +1,418 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroup", func() {
+	var (
+		apiHandler        *handlers.SecurityGroup
+		securityGroupRepo *fake.CFSecurityGroupRepository
+		requestValidator  *fake.RequestValidator
+		req               *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		securityGroupRepo = new(fake.CFSecurityGroupRepository)
+		apiHandler = handlers.NewSecurityGroup(
+			*serverURL,
+			requestValidator,
+			securityGroupRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{
+			GUID:            "group-guid",
+			Name:            "my-group",
+			GloballyEnabled: securitygroups.Workloads{Running: true},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/security_groups", func() {
+		var payload *payloads.SecurityGroupCreate
+
+		BeforeEach(func() {
+			payload = &payloads.SecurityGroupCreate{
+				Name: "my-group",
+				Rules: []securitygroups.Rule{
+					{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "443"},
+				},
+				Relationships: &payloads.SecurityGroupRelationships{
+					RunningSpaces: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+					},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "group-guid",
+				Name:          "my-group",
+				Rules:         payload.Rules,
+				RunningSpaces: []string{"space-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the security group", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := securityGroupRepo.CreateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name:          "my-group",
+				Rules:         payload.Rules,
+				RunningSpaces: []string{"space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "group-guid"),
+				MatchJSONPath("$.rules[0].ports", "443"),
+				MatchJSONPath("$.relationships.running_spaces.data[0].guid", "space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/group-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = nil
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/security_groups/group-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the security group", func() {
+			Expect(securityGroupRepo.GetSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.GetSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("group-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "group-guid"),
+				MatchJSONPath("$.name", "my-group"),
+				MatchJSONPath("$.globally_enabled.running", BeTrue()),
+			)))
+		})
+
+		When("the security group is not found", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups", func() {
+		BeforeEach(func() {
+			securityGroupRepo.ListSecurityGroupsReturns([]repositories.SecurityGroupRecord{
+				{GUID: "group-1"},
+				{GUID: "group-2"},
+			}, nil)
+
+			payload := payloads.SecurityGroupList{RunningSpaceGUIDs: "s1,s2"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/security_groups?running_space_guids=s1,s2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the security groups", func() {
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.RunningSpaceGUIDs).To(ConsistOf("s1", "s2"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("group-1", "group-2")),
+			)))
+		})
+
+		When("listing the security groups fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.ListSecurityGroupsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = func(_ *http.Request, decoded any) error {
+				payload, ok := decoded.(*payloads.SecurityGroupUpdate)
+				Expect(ok).To(BeTrue())
+				Expect(payload.Name).To(Equal("my-group"))
+				payload.GloballyEnabled.Staging = true
+				return nil
+			}
+
+			securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{GUID: "group-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/security_groups/group-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the security group on top of its current values", func() {
+			Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.UpdateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:            "group-guid",
+				Name:            "my-group",
+				GloballyEnabled: securitygroups.Workloads{Running: true, Staging: true},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "group-guid")))
+		})
+
+		When("the security group is not found", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/group-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the security group", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("group-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/security_group.delete~group-guid"))
+		})
+
+		When("the security group is not found", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.DeleteSecurityGroupReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/:guid/relationships/running_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-2"}},
+				},
+			})
+
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "group-guid",
+				RunningSpaces: []string{"space-1", "space-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups/group-guid/relationships/running_spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the security group to the running workloads of the spaces", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:     "group-guid",
+				Workload: securitygroups.WorkloadRunning,
+				Spaces:   []string{"space-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/group-guid/relationships/running_spaces"),
+			)))
+		})
+
+		When("binding the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewUnprocessableEntityError(nil, "no such space"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("no such space")
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/:guid/relationships/staging_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-1"}},
+				},
+			})
+
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "group-guid",
+				StagingSpaces: []string{"space-1"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups/group-guid/relationships/staging_spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the security group to the staging workloads of the spaces", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(message.Workload).To(Equal(securitygroups.WorkloadStaging))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("space-1"))))
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid/relationships/staging_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/group-guid/relationships/staging_spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unbinds the security group from the staging workloads of the space", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:     "group-guid",
+				Workload: securitygroups.WorkloadStaging,
+				Space:    "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the security group is not found", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("unbinding the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UnbindSecurityGroupReturns(errors.New("unbind-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid/relationships/running_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/group-guid/relationships/running_spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unbinds the security group from the running workloads of the space", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(message.Workload).To(Equal(securitygroups.WorkloadRunning))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+	})
+})
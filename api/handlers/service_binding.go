@@ -4,11 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/go-logr/logr"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/middleware"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -17,8 +19,12 @@ import (
 )
 
 const (
-	ServiceBindingsPath = "/v3/service_credential_bindings"
-	ServiceBindingPath  = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingsPath          = "/v3/service_credential_bindings"
+	ServiceBindingPath           = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingDetailsPath    = "/v3/service_credential_bindings/{guid}/details"
+	ServiceBindingParametersPath = "/v3/service_credential_bindings/{guid}/parameters"
+
+	serviceKeyAuditTargetType = "service_key"
 )
 
 type ServiceBinding struct {
@@ -35,6 +41,8 @@ type CFServiceBindingRepository interface {
 	DeleteServiceBinding(context.Context, authorization.Info, string) error
 	ListServiceBindings(context.Context, authorization.Info, repositories.ListServiceBindingsMessage) ([]repositories.ServiceBindingRecord, error)
 	GetServiceBinding(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
+	GetServiceBindingDetails(context.Context, authorization.Info, string) (repositories.ServiceBindingDetailsRecord, error)
+	GetServiceBindingParameters(context.Context, authorization.Info, string) (map[string]any, error)
 	UpdateServiceBinding(context.Context, authorization.Info, repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 }
 
@@ -57,6 +65,10 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.IsKey() {
+		return h.createKey(r, authInfo, logger, payload)
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.AppResourceType)
@@ -67,7 +79,7 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceInstanceResourceType)
 	}
 
-	if app.SpaceGUID != serviceInstance.SpaceGUID {
+	if app.SpaceGUID != serviceInstance.SpaceGUID && !slices.Contains(serviceInstance.SharedSpaces, app.SpaceGUID) {
 		return nil, apierrors.LogAndReturn(
 			logger,
 			apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
@@ -78,7 +90,32 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 
 	ctx := logr.NewContext(r.Context(), logger.WithValues("app", app.GUID, "service-instance", serviceInstance.GUID))
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(app.SpaceGUID))
+	message := payload.ToMessage(app.SpaceGUID)
+	message.ServiceInstanceSpaceGUID = serviceInstance.SpaceGUID
+
+	return h.createBinding(ctx, authInfo, message, serviceInstance)
+}
+
+func (h *ServiceBinding) createKey(r *http.Request, authInfo authorization.Info, logger logr.Logger, payload payloads.ServiceBindingCreate) (*routing.Response, error) {
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceInstanceResourceType)
+	}
+
+	middleware.OverrideAuditEvent(r.Context(), "audit.service_key.create", serviceKeyAuditTargetType)
+
+	ctx := logr.NewContext(r.Context(), logger.WithValues("service-instance", serviceInstance.GUID))
+
+	return h.createBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID), serviceInstance)
+}
+
+func (h *ServiceBinding) createBinding(
+	ctx context.Context,
+	authInfo authorization.Info,
+	message repositories.CreateServiceBindingMessage,
+	serviceInstance repositories.ServiceInstanceRecord,
+) (*routing.Response, error) {
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, message)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logr.FromContextOrDiscard(ctx), err, "failed to create ServiceBinding")
 	}
@@ -116,6 +153,10 @@ func (h *ServiceBinding) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service binding", "guid", serviceBindingGUID)
 	}
 
+	if serviceBinding.Type == repositories.ServiceBindingTypeKey {
+		middleware.OverrideAuditEvent(r.Context(), "audit.service_key.delete", serviceKeyAuditTargetType)
+	}
+
 	if serviceInstance.Type == korifiv1alpha1.ManagedType {
 		return routing.NewResponse(http.StatusAccepted).
 			WithHeader("Location", presenter.JobURLForRedirects(serviceBinding.GUID, presenter.ManagedServiceBindingDeleteOperation, h.serverURL)), nil
@@ -143,7 +184,9 @@ func (h *ServiceBinding) list(r *http.Request) (*routing.Response, error) {
 		listAppsMessage := repositories.ListAppsMessage{}
 
 		for _, serviceBinding := range serviceBindingList {
-			listAppsMessage.Guids = append(listAppsMessage.Guids, serviceBinding.AppGUID)
+			if serviceBinding.AppGUID != "" {
+				listAppsMessage.Guids = append(listAppsMessage.Guids, serviceBinding.AppGUID)
+			}
 		}
 
 		appRecords, err = h.appRepo.ListApps(r.Context(), authInfo, listAppsMessage)
@@ -166,12 +209,16 @@ func (h *ServiceBinding) update(r *http.Request) (*routing.Response, error) { //
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	_, err := h.serviceBindingRepo.GetServiceBinding(r.Context(), authInfo, serviceBindingGUID)
+	serviceBinding, err := h.serviceBindingRepo.GetServiceBinding(r.Context(), authInfo, serviceBindingGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting service binding in repository")
 	}
 
-	serviceBinding, err := h.serviceBindingRepo.UpdateServiceBinding(r.Context(), authInfo, payload.ToMessage(serviceBindingGUID))
+	if serviceBinding.Type == repositories.ServiceBindingTypeKey {
+		middleware.OverrideAuditEvent(r.Context(), "audit.service_key.update", serviceKeyAuditTargetType)
+	}
+
+	serviceBinding, err = h.serviceBindingRepo.UpdateServiceBinding(r.Context(), authInfo, payload.ToMessage(serviceBindingGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error updating service binding in repository")
 	}
@@ -192,6 +239,38 @@ func (h *ServiceBinding) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
+func (h *ServiceBinding) getDetails(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.get-details")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+
+	serviceBindingDetails, err := h.serviceBindingRepo.GetServiceBindingDetails(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting service binding details in repository")
+	}
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBindingDetails(serviceBindingDetails)), nil
+}
+
+func (h *ServiceBinding) getParameters(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.get-parameters")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+
+	_, err := h.serviceBindingRepo.GetServiceBinding(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting service binding in repository")
+	}
+
+	parameters, err := h.serviceBindingRepo.GetServiceBindingParameters(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error getting service binding parameters in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBindingParameters(parameters)), nil
+}
+
 func (h *ServiceBinding) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -203,5 +282,7 @@ func (h *ServiceBinding) AuthenticatedRoutes() []routing.Route {
 		{Method: "DELETE", Pattern: ServiceBindingPath, Handler: h.delete},
 		{Method: "PATCH", Pattern: ServiceBindingPath, Handler: h.update},
 		{Method: "GET", Pattern: ServiceBindingPath, Handler: h.get},
+		{Method: "GET", Pattern: ServiceBindingDetailsPath, Handler: h.getDetails},
+		{Method: "GET", Pattern: ServiceBindingParametersPath, Handler: h.getParameters},
 	}
 }
@@ -8,6 +8,8 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	middlewarefake "code.cloudfoundry.org/korifi/api/middleware/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -28,6 +30,7 @@ var _ = Describe("ServiceBinding", func() {
 		appRepo             *fake.CFAppRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		requestValidator    *fake.RequestValidator
+		auditEventRecorder  *middlewarefake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
@@ -60,6 +63,14 @@ var _ = Describe("ServiceBinding", func() {
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
+
+		auditEventRecorder = new(middlewarefake.AuditEventRecorder)
+		routerBuilder.UseAuthMiddleware(middleware.AuditEvents(
+			auditEventRecorder,
+			new(middlewarefake.IdentityProvider),
+			new(middlewarefake.NamespaceRetriever),
+			AuditedRoutes,
+		))
 	})
 
 	JustBeforeEach(func() {
@@ -179,6 +190,26 @@ var _ = Describe("ServiceBinding", func() {
 			})
 		})
 
+		When("the ServiceInstance is shared with the space of the App", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: spaceGUID}, nil)
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:         "service-instance-guid",
+					SpaceGUID:    "another-space-guid",
+					SharedSpaces: []string{spaceGUID},
+					Type:         korifiv1alpha1.UserProvidedType,
+				}, nil)
+			})
+
+			It("creates a binding to the service instance in its space", func() {
+				Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+				_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+				Expect(createServiceBindingMessage.SpaceGUID).To(Equal(spaceGUID))
+				Expect(createServiceBindingMessage.ServiceInstanceSpaceGUID).To(Equal("another-space-guid"))
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+		})
+
 		When("binding to a user provided service instance", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -208,6 +239,13 @@ var _ = Describe("ServiceBinding", func() {
 				)))
 			})
 
+			It("records a service binding audit event", func() {
+				Expect(auditEventRecorder.RecordAuditEventCallCount()).To(Equal(1))
+				_, message := auditEventRecorder.RecordAuditEventArgsForCall(0)
+				Expect(message.Type).To(Equal("audit.service_binding.create"))
+				Expect(message.TargetType).To(Equal("service_binding"))
+			})
+
 			When("creating the ServiceBinding errors", func() {
 				BeforeEach(func() {
 					serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{}, errors.New("boom"))
@@ -254,6 +292,181 @@ var _ = Describe("ServiceBinding", func() {
 				})
 			})
 		})
+
+		When("creating a service key", func() {
+			BeforeEach(func() {
+				payload.Type = "key"
+				payload.Name = tools.PtrTo("my-key")
+				payload.Relationships.App = nil
+
+				serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
+					GUID: "service-binding-guid",
+					Type: "key",
+				}, nil)
+			})
+
+			It("does not get the app", func() {
+				Expect(appRepo.GetAppCallCount()).To(BeZero())
+			})
+
+			It("creates a key binding in the service instance space", func() {
+				Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+				_, actualAuthInfo, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(createServiceBindingMessage).To(Equal(repositories.CreateServiceBindingMessage{
+					Name:                tools.PtrTo("my-key"),
+					Type:                "key",
+					ServiceInstanceGUID: "service-instance-guid",
+					SpaceGUID:           "space-guid",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", "service-binding-guid"),
+					MatchJSONPath("$.type", "key"),
+				)))
+			})
+
+			It("records a service key audit event", func() {
+				Expect(auditEventRecorder.RecordAuditEventCallCount()).To(Equal(1))
+				_, message := auditEventRecorder.RecordAuditEventArgsForCall(0)
+				Expect(message.Type).To(Equal("audit.service_key.create"))
+				Expect(message.TargetType).To(Equal("service_key"))
+				Expect(message.TargetGUID).To(Equal("service-binding-guid"))
+			})
+
+			When("getting the service instance is forbidden", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError(repositories.ServiceInstanceResourceType)
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+				})
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      korifiv1alpha1.ManagedType,
+					}, nil)
+				})
+
+				It("returns a create job", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+					Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+						ContainSubstring("/v3/jobs/managed_service_binding.create~service-binding-guid")))
+				})
+			})
+		})
+	})
+
+	Describe("GET /v3/service_credential_bindings/{guid}/details", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_credential_bindings/service-binding-guid/details"
+			requestBody = ""
+
+			serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{
+				Credentials: map[string]any{
+					"username": "user",
+				},
+			}, nil)
+		})
+
+		It("gets the service binding details", func() {
+			Expect(serviceBindingRepo.GetServiceBindingDetailsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBindingRepo.GetServiceBindingDetailsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-binding-guid"))
+		})
+
+		It("returns the credentials", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"credentials": {
+					"username": "user"
+				}
+			}`)))
+		})
+
+		When("the repo returns an error", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{}, errors.New("get-details-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{}, apierrors.NewForbiddenError(nil, "CFServiceBinding"))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError("CFServiceBinding")
+			})
+		})
+	})
+
+	Describe("GET /v3/service_credential_bindings/{guid}/parameters", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_credential_bindings/service-binding-guid/parameters"
+			requestBody = ""
+
+			serviceBindingRepo.GetServiceBindingParametersReturns(map[string]any{"p1": "v1"}, nil)
+		})
+
+		It("gets the service binding parameters", func() {
+			Expect(serviceBindingRepo.GetServiceBindingParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBindingRepo.GetServiceBindingParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-binding-guid"))
+		})
+
+		It("returns the parameters", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"p1": "v1"}`)))
+		})
+
+		When("the user cannot read the binding", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				Expect(serviceBindingRepo.GetServiceBindingParametersCallCount()).To(BeZero())
+				expectNotFoundError(repositories.ServiceBindingResourceType)
+			})
+		})
+
+		When("the service does not support fetching parameters", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingParametersReturns(nil, apierrors.NewInvalidRequestError(nil, "This service does not support fetching service binding parameters."))
+			})
+
+			It("returns 400 Bad Request", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "This service does not support fetching service binding parameters.", 10004)
+			})
+		})
+
+		When("the repo returns an error", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingParametersReturns(nil, errors.New("get-parameters-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("GET /v3/service_credential_bindings/{guid}", func() {
@@ -431,6 +644,31 @@ var _ = Describe("ServiceBinding", func() {
 			Expect(guid).To(Equal("service-binding-guid"))
 		})
 
+		It("records a service binding audit event", func() {
+			Expect(auditEventRecorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRecorder.RecordAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.service_binding.delete"))
+			Expect(message.TargetType).To(Equal("service_binding"))
+		})
+
+		When("the service binding is a service key", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+					GUID:                "service-binding-guid",
+					Type:                "key",
+					ServiceInstanceGUID: "service-instance-guid",
+				}, nil)
+			})
+
+			It("records a service key audit event", func() {
+				Expect(auditEventRecorder.RecordAuditEventCallCount()).To(Equal(1))
+				_, message := auditEventRecorder.RecordAuditEventArgsForCall(0)
+				Expect(message.Type).To(Equal("audit.service_key.delete"))
+				Expect(message.TargetType).To(Equal("service_key"))
+				Expect(message.TargetGUID).To(Equal("service-binding-guid"))
+			})
+		})
+
 		When("the service instance is managed", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -505,6 +743,28 @@ var _ = Describe("ServiceBinding", func() {
 			}))
 		})
 
+		It("records a service binding audit event", func() {
+			Expect(auditEventRecorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := auditEventRecorder.RecordAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.service_binding.update"))
+		})
+
+		When("the service binding is a service key", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+					GUID: "service-binding-guid",
+					Type: "key",
+				}, nil)
+			})
+
+			It("records a service key audit event", func() {
+				Expect(auditEventRecorder.RecordAuditEventCallCount()).To(Equal(1))
+				_, message := auditEventRecorder.RecordAuditEventArgsForCall(0)
+				Expect(message.Type).To(Equal("audit.service_key.update"))
+				Expect(message.TargetType).To(Equal("service_key"))
+			})
+		})
+
 		When("the payload cannot be decoded", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
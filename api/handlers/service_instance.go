@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -21,8 +22,13 @@ import (
 )
 
 const (
-	ServiceInstancesPath = "/v3/service_instances"
-	ServiceInstancePath  = "/v3/service_instances/{guid}"
+	ServiceInstancesPath                        = "/v3/service_instances"
+	ServiceInstancePath                         = "/v3/service_instances/{guid}"
+	ServiceInstanceCredentialsPath              = "/v3/service_instances/{guid}/credentials"
+	ServiceInstanceParametersPath               = "/v3/service_instances/{guid}/parameters"
+	ServiceInstanceSharedSpacesPath             = "/v3/service_instances/{guid}/relationships/shared_spaces"
+	ServiceInstanceSharedSpacePath              = "/v3/service_instances/{guid}/relationships/shared_spaces/{space_guid}"
+	ServiceInstanceSharedSpacesUsageSummaryPath = "/v3/service_instances/{guid}/relationships/shared_spaces/usage_summary"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceInstanceRepository . CFServiceInstanceRepository
@@ -32,7 +38,12 @@ type CFServiceInstanceRepository interface {
 	PatchServiceInstance(context.Context, authorization.Info, repositories.PatchServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
+	GetServiceInstanceCredentials(context.Context, authorization.Info, string) (map[string]any, error)
+	GetServiceInstanceParameters(context.Context, authorization.Info, string) (map[string]any, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	ShareServiceInstance(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	UnshareServiceInstance(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
+	GetSharedSpacesUsageSummary(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageRecord, error)
 }
 
 type ServiceInstance struct {
@@ -40,6 +51,7 @@ type ServiceInstance struct {
 	serviceInstanceRepo CFServiceInstanceRepository
 	spaceRepo           CFSpaceRepository
 	requestValidator    RequestValidator
+	featureFlagChecker  FeatureFlagChecker
 	includeResolver     *include.IncludeResolver[
 		[]repositories.ServiceInstanceRecord,
 		repositories.ServiceInstanceRecord,
@@ -51,6 +63,7 @@ func NewServiceInstance(
 	serviceInstanceRepo CFServiceInstanceRepository,
 	spaceRepo CFSpaceRepository,
 	requestValidator RequestValidator,
+	featureFlagChecker FeatureFlagChecker,
 	relationshipRepo include.ResourceRelationshipRepository,
 ) *ServiceInstance {
 	return &ServiceInstance{
@@ -58,6 +71,7 @@ func NewServiceInstance(
 		serviceInstanceRepo: serviceInstanceRepo,
 		spaceRepo:           spaceRepo,
 		requestValidator:    requestValidator,
+		featureFlagChecker:  featureFlagChecker,
 		includeResolver:     include.NewIncludeResolver[[]repositories.ServiceInstanceRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
@@ -135,15 +149,89 @@ func (h *ServiceInstance) patch(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
 	}
 
+	if payload.UpdatesManagedFields() && serviceInstance.Type != korifiv1alpha1.ManagedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Parameters and service plan can only be updated for managed service instances"),
+			"cannot update parameters or plan of user-provided service instance",
+		)
+	}
+
 	patchMessage := payload.ToServiceInstancePatchMessage(serviceInstance.SpaceGUID, serviceInstance.GUID)
 	serviceInstance, err = h.serviceInstanceRepo.PatchServiceInstance(r.Context(), authInfo, patchMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to patch service instance")
 	}
 
+	if payload.UpdatesManagedFields() {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceInstance.GUID, presenter.ManagedServiceInstanceUpdateOperation, h.serverURL)), nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(serviceInstance, h.serverURL)), nil
 }
 
+func (h *ServiceInstance) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) getCredentials(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-credentials")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
+	}
+
+	if serviceInstance.Type != korifiv1alpha1.UserProvidedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType),
+			"credentials can only be read for user-provided service instances",
+		)
+	}
+
+	// only space developers are allowed to read the credentials secret
+	credentials, err := h.serviceInstanceRepo.GetServiceInstanceCredentials(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get service instance credentials")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceCredentials(credentials)), nil
+}
+
+func (h *ServiceInstance) getParameters(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-parameters")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	_, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
+	}
+
+	parameters, err := h.serviceInstanceRepo.GetServiceInstanceParameters(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get service instance parameters")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceParameters(parameters)), nil
+}
+
 func (h *ServiceInstance) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.list")
@@ -190,6 +278,119 @@ func (h *ServiceInstance) delete(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
+func (h *ServiceInstance) listSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.list-shared-spaces")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.share")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ServiceInstanceShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagServiceInstanceSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "service instance sharing is disabled", "guid", serviceInstanceGUID)
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	for _, spaceGUID := range payload.GUIDs() {
+		if spaceGUID == serviceInstance.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share service instance '%s' with space '%s'. Service instances cannot be shared into the space where they were created.", serviceInstance.Name, spaceGUID)),
+				"cannot share service instance with its own space", "guid", serviceInstanceGUID,
+			)
+		}
+
+		if err = h.ensureSpaceExists(r.Context(), authInfo, spaceGUID); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to fetch shared space from Kubernetes", "spaceGUID", spaceGUID)
+		}
+	}
+
+	serviceInstance, err = h.serviceInstanceRepo.ShareServiceInstance(r.Context(), authInfo, payload.ToMessage(serviceInstance))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to share service instance", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.unshare")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	err = h.serviceInstanceRepo.UnshareServiceInstance(r.Context(), authInfo, repositories.UnshareServiceInstanceMessage{
+		GUID:            serviceInstance.GUID,
+		SpaceGUID:       serviceInstance.SpaceGUID,
+		SharedSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unshare service instance", "guid", serviceInstanceGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *ServiceInstance) sharedSpacesUsageSummary(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.shared-spaces-usage-summary")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	usageSummary, err := h.serviceInstanceRepo.GetSharedSpacesUsageSummary(r.Context(), authInfo, serviceInstance.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get shared spaces usage summary", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceUsageSummary(serviceInstance.GUID, usageSummary, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) ensureSpaceExists(ctx context.Context, authInfo authorization.Info, spaceGUID string) error {
+	_, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(
+			err,
+			"Invalid space. Ensure that the space exists and you have access to it.",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	return nil
+}
+
 func (h *ServiceInstance) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -199,6 +400,13 @@ func (h *ServiceInstance) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: ServiceInstancesPath, Handler: h.create},
 		{Method: "PATCH", Pattern: ServiceInstancePath, Handler: h.patch},
 		{Method: "GET", Pattern: ServiceInstancesPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceInstancePath, Handler: h.get},
+		{Method: "GET", Pattern: ServiceInstanceCredentialsPath, Handler: h.getCredentials},
+		{Method: "GET", Pattern: ServiceInstanceParametersPath, Handler: h.getParameters},
 		{Method: "DELETE", Pattern: ServiceInstancePath, Handler: h.delete},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.listSharedSpaces},
+		{Method: "POST", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.share},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesUsageSummaryPath, Handler: h.sharedSpacesUsageSummary},
+		{Method: "DELETE", Pattern: ServiceInstanceSharedSpacePath, Handler: h.unshare},
 	}
 }
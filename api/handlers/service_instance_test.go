@@ -20,6 +20,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ServiceInstance", func() {
@@ -30,6 +31,7 @@ var _ = Describe("ServiceInstance", func() {
 		servicePlanRepo     *fake.CFServicePlanRepository
 		serviceBrokerRepo   *fake.CFServiceBrokerRepository
 		requestValidator    *fake.RequestValidator
+		featureFlagChecker  *fake.FeatureFlagChecker
 
 		reqMethod string
 		reqPath   string
@@ -49,12 +51,14 @@ var _ = Describe("ServiceInstance", func() {
 		servicePlanRepo = new(fake.CFServicePlanRepository)
 
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
 		apiHandler := NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
 			requestValidator,
+			featureFlagChecker,
 			relationships.NewResourseRelationshipsRepo(
 				serviceOfferingRepo,
 				serviceBrokerRepo,
@@ -409,6 +413,168 @@ var _ = Describe("ServiceInstance", func() {
 		})
 	})
 
+	Describe("GET /v3/service_instances/:guid", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid"
+		})
+
+		It("returns the service instance", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-instance-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid"),
+			)))
+		})
+
+		When("getting the service instance is forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/credentials", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceCredentialsReturns(map[string]any{"username": "user"}, nil)
+
+			reqPath += "/service-instance-guid/credentials"
+		})
+
+		It("returns the credentials of the service instance", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCredentialsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceCredentialsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"username": "user"}`)))
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.ManagedType,
+				}, nil)
+			})
+
+			It("returns 404 Not Found", func() {
+				Expect(serviceInstanceRepo.GetServiceInstanceCredentialsCallCount()).To(BeZero())
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("the user cannot read the service instance", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("the user is not allowed to read the credentials", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceCredentialsReturns(
+					nil,
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 403 Forbidden", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/parameters", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID: "service-instance-guid",
+				Type: korifiv1alpha1.ManagedType,
+			}, nil)
+			serviceInstanceRepo.GetServiceInstanceParametersReturns(map[string]any{"p1": "v1"}, nil)
+
+			reqPath += "/service-instance-guid/parameters"
+		})
+
+		It("returns the parameters of the service instance", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"p1": "v1"}`)))
+		})
+
+		When("the user cannot read the service instance", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				Expect(serviceInstanceRepo.GetServiceInstanceParametersCallCount()).To(BeZero())
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("the service does not support fetching parameters", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(
+					nil,
+					apierrors.NewInvalidRequestError(nil, "This service does not support fetching service instance parameters."),
+				)
+			})
+
+			It("returns 400 Bad Request", func() {
+				expectErrorResponse(http.StatusBadRequest, "CF-InvalidRequest", "This service does not support fetching service instance parameters.", 10004)
+			})
+		})
+
+		When("fetching the parameters fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("PATCH /v3/service_instances/:guid", func() {
 		BeforeEach(func() {
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
@@ -509,6 +675,47 @@ var _ = Describe("ServiceInstance", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the plan and parameters are updated", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					Parameters: &map[string]any{"param": "value"},
+					Relationships: &payloads.ServiceInstancePatchRelationships{
+						ServicePlan: &payloads.Relationship{
+							Data: &payloads.RelationshipData{GUID: "new-plan-guid"},
+						},
+					},
+				})
+			})
+
+			It("returns 422 Unprocessable Entity for user-provided service instances", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Parameters and service plan can only be updated for managed service instances")
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      korifiv1alpha1.ManagedType,
+					}, nil)
+				})
+
+				It("patches the plan and the parameters of the service instance", func() {
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+					_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+					Expect(patchMessage.PlanGUID).To(PointTo(Equal("new-plan-guid")))
+					Expect(patchMessage.Parameters).To(PointTo(Equal(map[string]any{"param": "value"})))
+				})
+
+				It("returns HTTP 202 Accepted response", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+					Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+						ContainSubstring("/v3/jobs/managed_service_instance.update~service-instance-guid")))
+				})
+			})
+		})
 	})
 
 	Describe("DELETE /v3/service_instances/:guid", func() {
@@ -616,4 +823,256 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:         "service-instance-guid",
+				SpaceGUID:    "space-guid",
+				SharedSpaces: []string{"shared-space-guid"},
+			}, nil)
+
+			reqMethod = http.MethodGet
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+		})
+
+		It("returns the shared spaces of the service instance", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(1)),
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the user lacks permission to get the service instance", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns not found", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+	})
+
+	Describe("POST /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      "service-instance-guid",
+				Name:      "service-instance-name",
+				SpaceGUID: "space-guid",
+			}, nil)
+			serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:         "service-instance-guid",
+				SharedSpaces: []string{"space-1-guid", "space-2-guid"},
+			}, nil)
+
+			reqMethod = http.MethodPost
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-1-guid"}, {GUID: "space-2-guid"}},
+				},
+			})
+		})
+
+		It("shares the service instance with the spaces", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagServiceInstanceSharing))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(1)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-2-guid"))
+
+			Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.ShareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareServiceInstanceMessage{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"space-1-guid", "space-2-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(2)),
+				MatchJSONPath("$.data[0].guid", "space-1-guid"),
+				MatchJSONPath("$.data[1].guid", "space-2-guid"),
+			)))
+		})
+
+		When("service instance sharing is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(repositories.FeatureFlagServiceInstanceSharing))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectFeatureDisabledError(repositories.FeatureFlagServiceInstanceSharing)
+			})
+		})
+
+		When("the service instance does not exist", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns not found", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("sharing the service instance with its own space", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+					ToManyRelationship: payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+					},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Unable to share service instance 'service-instance-name' with space 'space-guid'. Service instances cannot be shared into the space where they were created.")
+			})
+		})
+
+		When("sharing the service instance errors", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_instances/:guid/relationships/shared_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			reqMethod = http.MethodDelete
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces/shared-space-guid"
+		})
+
+		It("unshares the service instance from the space", func() {
+			Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.UnshareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareServiceInstanceMessage{
+				GUID:            "service-instance-guid",
+				SpaceGUID:       "space-guid",
+				SharedSpaceGUID: "shared-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the service instance does not exist", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns not found", func() {
+				Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(BeZero())
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+
+		When("unsharing the service instance errors", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.UnshareServiceInstanceReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces/usage_summary", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetSharedSpacesUsageSummaryReturns([]repositories.SharedSpaceUsageRecord{
+				{SpaceGUID: "shared-space-guid", BoundAppCount: 3},
+			}, nil)
+
+			reqMethod = http.MethodGet
+			reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"
+		})
+
+		It("returns the usage summary of the shared spaces", func() {
+			Expect(serviceInstanceRepo.GetSharedSpacesUsageSummaryCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetSharedSpacesUsageSummaryArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.usage_summary", HaveLen(1)),
+				MatchJSONPath("$.usage_summary[0].space.guid", "shared-space-guid"),
+				MatchJSONPath("$.usage_summary[0].bound_app_count", BeEquivalentTo(3)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"),
+			)))
+		})
+
+		When("the service instance does not exist", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns not found", func() {
+				Expect(serviceInstanceRepo.GetSharedSpacesUsageSummaryCallCount()).To(BeZero())
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+
+		When("getting the usage summary errors", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetSharedSpacesUsageSummaryReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -1,31 +1,121 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 
+	"github.com/go-logr/logr"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 )
 
 const (
 	ServiceRouteBindingsPath = "/v3/service_route_bindings"
+	ServiceRouteBindingPath  = "/v3/service_route_bindings/{guid}"
+
+	RouteServicesDisabledMessage = "Support for route services is disabled"
 )
 
+//counterfeiter:generate -o fake -fake-name CFServiceRouteBindingRepository . CFServiceRouteBindingRepository
+type CFServiceRouteBindingRepository interface {
+	GetServiceRouteBinding(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)
+	ListServiceRouteBindings(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error)
+	DeleteServiceRouteBinding(context.Context, authorization.Info, string) error
+}
+
 type ServiceRouteBinding struct {
-	serverURL url.URL
+	serverURL               url.URL
+	serviceRouteBindingRepo CFServiceRouteBindingRepository
+	requestValidator        RequestValidator
 }
 
 func NewServiceRouteBinding(
 	serverURL url.URL,
+	serviceRouteBindingRepo CFServiceRouteBindingRepository,
+	requestValidator RequestValidator,
 ) *ServiceRouteBinding {
 	return &ServiceRouteBinding{
-		serverURL: serverURL,
+		serverURL:               serverURL,
+		serviceRouteBindingRepo: serviceRouteBindingRepo,
+		requestValidator:        requestValidator,
+	}
+}
+
+// create refuses route bindings: Korifi routes traffic through the Gateway
+// API, which cannot sign and verify the X-CF-Proxy-Signature header route
+// services rely on to reject requests that did not come from the router
+func (h *ServiceRouteBinding) create(r *http.Request) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.create")
+
+	var payload payloads.ServiceRouteBindingCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	return nil, apierrors.LogAndReturn(
+		logger,
+		apierrors.NewUnprocessableEntityError(nil, RouteServicesDisabledMessage),
+		"route services are not supported",
+		"route", payload.Relationships.Route.Data.GUID,
+		"service-instance", payload.Relationships.ServiceInstance.Data.GUID,
+	)
+}
+
+func (h *ServiceRouteBinding) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.get")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType)
 	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBinding(serviceRouteBinding, h.serverURL)), nil
 }
 
 func (h *ServiceRouteBinding) list(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingsList(h.serverURL, *r.URL)), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.list")
+
+	listFilter := new(payloads.ServiceRouteBindingList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	serviceRouteBindings, err := h.serviceRouteBindingRepo.ListServiceRouteBindings(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingList(serviceRouteBindings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceRouteBinding) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.delete")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	err = h.serviceRouteBindingRepo.DeleteServiceRouteBinding(r.Context(), authInfo, serviceRouteBinding.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete "+repositories.ServiceRouteBindingResourceType, "guid", serviceRouteBindingGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(serviceRouteBinding.GUID, presenter.ServiceRouteBindingDeleteOperation, h.serverURL)), nil
 }
 
 func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
@@ -34,6 +124,9 @@ func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
 
 func (h *ServiceRouteBinding) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
+		{Method: "POST", Pattern: ServiceRouteBindingsPath, Handler: h.create},
 		{Method: "GET", Pattern: ServiceRouteBindingsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceRouteBindingPath, Handler: h.get},
+		{Method: "DELETE", Pattern: ServiceRouteBindingPath, Handler: h.delete},
 	}
 }
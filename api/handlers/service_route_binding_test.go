@@ -1,9 +1,15 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
-	"code.cloudfoundry.org/korifi/api/handlers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,25 +17,200 @@ import (
 )
 
 var _ = Describe("ServiceRouteBinding", func() {
-	Describe("the GET /v3/service_route_binding endpoint", func() {
+	var (
+		requestMethod string
+		requestPath   string
+		requestBody   string
+
+		serviceRouteBindingRepo *fake.CFServiceRouteBindingRepository
+		requestValidator        *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingRepo = new(fake.CFServiceRouteBindingRepository)
+		serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+			GUID:                "service-route-binding-guid",
+			RouteGUID:           "route-guid",
+			ServiceInstanceGUID: "service-instance-guid",
+			RouteServiceURL:     "https://route-service.example.com",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewServiceRouteBinding(
+			*serverURL,
+			serviceRouteBindingRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/service_route_bindings", func() {
 		BeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_route_bindings"
+			requestBody = "the-json-body"
 
-			apiHandler := handlers.NewServiceRouteBinding(
-				*serverURL)
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceRouteBindingCreate{
+				Relationships: &payloads.ServiceRouteBindingRelationships{
+					Route: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "route-guid"},
+					},
+					ServiceInstance: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "service-instance-guid"},
+					},
+				},
+			})
+		})
 
-			routerBuilder.LoadRoutes(apiHandler)
-			routerBuilder.Build().ServeHTTP(rr, req)
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
 
-		It("returns an empty list", func() {
+		It("returns an unprocessable entity error as route services are not supported", func() {
+			expectUnprocessableEntityError("Support for route services is disabled")
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_route_bindings"
+			requestBody = ""
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceRouteBindingList{
+				RouteGUIDs: "route-guid",
+			})
+
+			serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{
+				{GUID: "service-route-binding-guid", RouteGUID: "route-guid", ServiceInstanceGUID: "service-instance-guid"},
+			}, nil)
+		})
+
+		It("lists the route bindings", func() {
+			Expect(serviceRouteBindingRepo.ListServiceRouteBindingsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceRouteBindingRepo.ListServiceRouteBindingsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.RouteGUIDs).To(ConsistOf("route-guid"))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
 				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_route_bindings"),
+				MatchJSONPath("$.resources[0].guid", "service-route-binding-guid"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the route bindings fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+			requestBody = ""
+		})
+
+		It("returns the route binding", func() {
+			Expect(serviceRouteBindingRepo.GetServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.GetServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-route-binding-guid"),
+				MatchJSONPath("$.route_service_url", "https://route-service.example.com"),
+				MatchJSONPath("$.relationships.route.data.guid", "route-guid"),
 			)))
 		})
+
+		When("the route binding is forbidden", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+			requestBody = ""
+		})
+
+		It("deletes the route binding", func() {
+			Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.DeleteServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+				ContainSubstring("/v3/jobs/service_route_bindings.delete~service-route-binding-guid")))
+		})
+
+		When("the route binding does not exist", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+				Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the route binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.DeleteServiceRouteBindingReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
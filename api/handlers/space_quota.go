@@ -0,0 +1,154 @@
+// nolint:dupl
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	SpaceQuotasPath      = "/v3/space_quotas"
+	SpaceQuotaPath       = "/v3/space_quotas/{guid}"
+	SpaceQuotaSpacesPath = "/v3/space_quotas/{guid}/relationships/spaces"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSpaceQuotaRepository . CFSpaceQuotaRepository
+type CFSpaceQuotaRepository interface {
+	CreateSpaceQuota(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	GetSpaceQuota(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	UpdateSpaceQuota(context.Context, authorization.Info, repositories.UpdateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	ApplySpaceQuota(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+}
+
+type SpaceQuota struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	spaceQuotaRepo   CFSpaceQuotaRepository
+}
+
+func NewSpaceQuota(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	spaceQuotaRepo CFSpaceQuotaRepository,
+) *SpaceQuota {
+	return &SpaceQuota{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		spaceQuotaRepo:   spaceQuotaRepo,
+	}
+}
+
+func (h *SpaceQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.create")
+
+	payload := payloads.NewSpaceQuotaCreate()
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.CreateSpaceQuota(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create space quota")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.list")
+
+	var payload payloads.SpaceQuotaList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	spaceQuotas, err := h.spaceQuotaRepo.ListSpaceQuotas(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list space quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSpaceQuota, spaceQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *SpaceQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", guid)
+	}
+
+	payload := payloads.SpaceQuotaUpdate{SpaceQuota: *spaceQuota.SpaceQuota.DeepCopy()}
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceQuota, err = h.spaceQuotaRepo.UpdateSpaceQuota(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update space quota", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) applyToSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.apply")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceQuotaApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.ApplySpaceQuota(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to apply space quota", "guid", guid)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaSpaces(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SpaceQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SpaceQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: SpaceQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: SpaceQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SpaceQuotaPath, Handler: h.update},
+		{Method: "POST", Pattern: SpaceQuotaSpacesPath, Handler: h.applyToSpaces},
+	}
+}
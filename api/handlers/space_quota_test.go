@@ -0,0 +1,300 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuota", func() {
+	var (
+		apiHandler       *handlers.SpaceQuota
+		spaceQuotaRepo   *fake.CFSpaceQuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		spaceQuotaRepo = new(fake.CFSpaceQuotaRepository)
+		apiHandler = handlers.NewSpaceQuota(
+			*serverURL,
+			requestValidator,
+			spaceQuotaRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+			GUID: "quota-guid",
+			SpaceQuota: quotas.SpaceQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+			},
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/space_quotas", func() {
+		var payload *payloads.SpaceQuotaCreate
+
+		BeforeEach(func() {
+			payload = &payloads.SpaceQuotaCreate{
+				SpaceQuota: quotas.SpaceQuota{
+					Name: "my-quota",
+					Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				Relationships: &payloads.SpaceQuotaRelationships{
+					Organization: payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "org-guid"},
+					},
+					Spaces: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+					},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:       "quota-guid",
+				SpaceQuota: payload.SpaceQuota,
+				OrgGUID:    "org-guid",
+				Spaces:     []string{"space-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/space_quotas", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the space quota", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSpaceQuotaMessage{
+				SpaceQuota: payload.SpaceQuota,
+				OrgGUID:    "org-guid",
+				Spaces:     []string{"space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.apps.total_memory_in_mb", BeEquivalentTo(1024)),
+				MatchJSONPath("$.relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.relationships.spaces.data[0].guid", "space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = nil
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space quota", func() {
+			Expect(spaceQuotaRepo.GetSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := spaceQuotaRepo.GetSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the space quota is not found", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{
+				{GUID: "quota-1"},
+				{GUID: "quota-2"},
+			}, nil)
+
+			payload := payloads.SpaceQuotaList{Names: "n1,n2"}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas?names=n1,n2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the space quotas", func() {
+			Expect(spaceQuotaRepo.ListSpaceQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("n1", "n2"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[*].guid", ConsistOf("quota-1", "quota-2")),
+			)))
+		})
+
+		When("listing the space quotas fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.ListSpaceQuotasReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = func(_ *http.Request, decoded any) error {
+				payload, ok := decoded.(*payloads.SpaceQuotaUpdate)
+				Expect(ok).To(BeTrue())
+				Expect(payload.Name).To(Equal("my-quota"))
+				payload.Apps.TotalInstances = tools.PtrTo[int64](5)
+				return nil
+			}
+
+			spaceQuotaRepo.UpdateSpaceQuotaReturns(repositories.SpaceQuotaRecord{GUID: "quota-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/space_quotas/quota-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the space quota on top of its current values", func() {
+			Expect(spaceQuotaRepo.UpdateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.UpdateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateSpaceQuotaMessage{
+				GUID: "quota-guid",
+				SpaceQuota: quotas.SpaceQuota{
+					Name: "my-quota",
+					Apps: quotas.AppQuotas{
+						TotalMemoryInMB: tools.PtrTo[int64](1024),
+						TotalInstances:  tools.PtrTo[int64](5),
+					},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "quota-guid")))
+		})
+
+		When("the space quota is not found", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+				Expect(spaceQuotaRepo.UpdateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.UpdateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/space_quotas/:guid/relationships/spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaApply{
+				ToManyRelationship: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-2"}},
+				},
+			})
+
+			spaceQuotaRepo.ApplySpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:   "quota-guid",
+				Spaces: []string{"space-1", "space-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/space_quotas/quota-guid/relationships/spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the quota to the spaces", func() {
+			Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ApplySpaceQuotaMessage{
+				GUID:   "quota-guid",
+				Spaces: []string{"space-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2"))))
+		})
+
+		When("applying the quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.ApplySpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "no such space"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("no such space")
+			})
+		})
+	})
+})
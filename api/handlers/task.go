@@ -33,10 +33,11 @@ type CFTaskRepository interface {
 }
 
 type Task struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	taskRepo         CFTaskRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	appRepo            CFAppRepository
+	taskRepo           CFTaskRepository
+	requestValidator   RequestValidator
+	featureFlagChecker FeatureFlagChecker
 }
 
 func NewTask(
@@ -44,12 +45,14 @@ func NewTask(
 	appRepo CFAppRepository,
 	taskRepo CFTaskRepository,
 	requestValidator RequestValidator,
+	featureFlagChecker FeatureFlagChecker,
 ) *Task {
 	return &Task{
-		serverURL:        serverURL,
-		taskRepo:         taskRepo,
-		appRepo:          appRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		taskRepo:           taskRepo,
+		appRepo:            appRepo,
+		requestValidator:   requestValidator,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -95,6 +98,10 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
 	}
 
+	if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagTaskCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "task creation is disabled", "appGUID", appGUID)
+	}
+
 	if !appRecord.IsStaged {
 		return nil, apierrors.LogAndReturn(
 			logger,
@@ -22,11 +22,12 @@ import (
 
 var _ = Describe("Task", func() {
 	var (
-		requestMethod    string
-		requestPath      string
-		appRepo          *fake.CFAppRepository
-		taskRepo         *fake.CFTaskRepository
-		requestValidator *fake.RequestValidator
+		requestMethod      string
+		requestPath        string
+		appRepo            *fake.CFAppRepository
+		taskRepo           *fake.CFTaskRepository
+		requestValidator   *fake.RequestValidator
+		featureFlagChecker *fake.FeatureFlagChecker
 	)
 
 	BeforeEach(func() {
@@ -48,8 +49,9 @@ var _ = Describe("Task", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
-		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator)
+		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator, featureFlagChecker)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -99,6 +101,24 @@ var _ = Describe("Task", func() {
 			)))
 		})
 
+		It("checks the task_creation feature flag", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal("task_creation"))
+		})
+
+		When("the task_creation feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError("task_creation"))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectFeatureDisabledError("task_creation")
+				Expect(taskRepo.CreateTaskCallCount()).To(BeZero())
+			})
+		})
+
 		When("the app does not exist", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
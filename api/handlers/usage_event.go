@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppUsageEventsPath          = "/v3/app_usage_events"
+	AppUsageEventPath           = "/v3/app_usage_events/{guid}"
+	AppUsageEventsPurgePath     = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+	ServiceUsageEventsPath      = "/v3/service_usage_events"
+	ServiceUsageEventPath       = "/v3/service_usage_events/{guid}"
+	ServiceUsageEventsPurgePath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFUsageEventRepository . CFUsageEventRepository
+type CFUsageEventRepository interface {
+	GetAppUsageEvent(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)
+	ListAppUsageEvents(context.Context, authorization.Info, repositories.ListAppUsageEventsMessage) ([]repositories.AppUsageEventRecord, error)
+	PurgeAndReseedAppUsageEvents(context.Context, authorization.Info) error
+	GetServiceUsageEvent(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	ListServiceUsageEvents(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)
+	PurgeAndReseedServiceUsageEvents(context.Context, authorization.Info) error
+}
+
+type UsageEvent struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	usageEventRepo   CFUsageEventRepository
+}
+
+func NewUsageEvent(
+	serverURL url.URL,
+	requestValidator RequestValidator,
+	usageEventRepo CFUsageEventRepository,
+) *UsageEvent {
+	return &UsageEvent{
+		serverURL:        serverURL,
+		requestValidator: requestValidator,
+		usageEventRepo:   usageEventRepo,
+	}
+}
+
+func (h *UsageEvent) getAppUsageEvent(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.get")
+
+	eventGUID := routing.URLParam(r, "guid")
+
+	event, err := h.usageEventRepo.GetAppUsageEvent(r.Context(), authInfo, eventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get app usage event", "guid", eventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppUsageEvent(event, h.serverURL)), nil
+}
+
+func (h *UsageEvent) listAppUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.list")
+
+	var payload payloads.AppUsageEventList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	events, err := h.usageEventRepo.ListAppUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAppUsageEvent, events, h.serverURL, *r.URL)), nil
+}
+
+func (h *UsageEvent) purgeAppUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.purge")
+
+	if err := h.usageEventRepo.PurgeAndReseedAppUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to purge and reseed app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *UsageEvent) getServiceUsageEvent(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.get")
+
+	eventGUID := routing.URLParam(r, "guid")
+
+	event, err := h.usageEventRepo.GetServiceUsageEvent(r.Context(), authInfo, eventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get service usage event", "guid", eventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceUsageEvent(event, h.serverURL)), nil
+}
+
+func (h *UsageEvent) listServiceUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.list")
+
+	var payload payloads.ServiceUsageEventList
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	events, err := h.usageEventRepo.ListServiceUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceUsageEvent, events, h.serverURL, *r.URL)), nil
+}
+
+func (h *UsageEvent) purgeServiceUsageEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-usage-event.purge")
+
+	if err := h.usageEventRepo.PurgeAndReseedServiceUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to purge and reseed service usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *UsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *UsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppUsageEventsPath, Handler: h.listAppUsageEvents},
+		{Method: "GET", Pattern: AppUsageEventPath, Handler: h.getAppUsageEvent},
+		{Method: "POST", Pattern: AppUsageEventsPurgePath, Handler: h.purgeAppUsageEvents},
+		{Method: "GET", Pattern: ServiceUsageEventsPath, Handler: h.listServiceUsageEvents},
+		{Method: "GET", Pattern: ServiceUsageEventPath, Handler: h.getServiceUsageEvent},
+		{Method: "POST", Pattern: ServiceUsageEventsPurgePath, Handler: h.purgeServiceUsageEvents},
+	}
+}
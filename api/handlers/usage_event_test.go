@@ -0,0 +1,281 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UsageEvent", func() {
+	var (
+		apiHandler       *handlers.UsageEvent
+		usageEventRepo   *fake.CFUsageEventRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		usageEventRepo = new(fake.CFUsageEventRepository)
+		apiHandler = handlers.NewUsageEvent(
+			*serverURL,
+			requestValidator,
+			usageEventRepo,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/app_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+				GUID:    "event-guid",
+				State:   "STARTED",
+				AppGUID: "app-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/app_usage_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the app usage event", func() {
+			Expect(usageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state.current", "STARTED"),
+				MatchJSONPath("$.app.guid", "app-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/app_usage_events/event-guid"),
+			)))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("getting the app usage event fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/app_usage_events", func() {
+		BeforeEach(func() {
+			usageEventRepo.ListAppUsageEventsReturns([]repositories.AppUsageEventRecord{
+				{GUID: "event-1"},
+				{GUID: "event-2"},
+			}, nil)
+
+			payload := payloads.AppUsageEventList{
+				AfterGUID: "event-0",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/app_usage_events?after_guid=event-0", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the app usage events", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(usageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := usageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListAppUsageEventsMessage{AfterGUID: "event-0"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/app_usage_events?after_guid=event-0"),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+				MatchJSONPath("$.resources[1].guid", "event-2"),
+			)))
+		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = nil
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("listing the app usage events fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListAppUsageEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/app_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/app_usage_events/actions/destructively_purge_all_and_reseed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("purges and reseeds the app usage events", func() {
+			Expect(usageEventRepo.PurgeAndReseedAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := usageEventRepo.PurgeAndReseedAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("purging fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.PurgeAndReseedAppUsageEventsReturns(errors.New("purge-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				State:               "CREATED",
+				ServiceInstanceGUID: "instance-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_usage_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service usage event", func() {
+			Expect(usageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state", "CREATED"),
+				MatchJSONPath("$.service_instance.guid", "instance-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_usage_events/event-guid"),
+			)))
+		})
+
+		When("getting the service usage event fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events", func() {
+		BeforeEach(func() {
+			usageEventRepo.ListServiceUsageEventsReturns([]repositories.ServiceUsageEventRecord{
+				{GUID: "event-1"},
+			}, nil)
+
+			payload := payloads.ServiceUsageEventList{
+				ServiceInstanceTypes: "managed_service_instance",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_usage_events?service_instance_types=managed_service_instance", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service usage events", func() {
+			Expect(usageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := usageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListServiceUsageEventsMessage{
+				ServiceInstanceTypes: []string{"managed_service_instance"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+			)))
+		})
+
+		When("listing the service usage events fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListServiceUsageEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/service_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_usage_events/actions/destructively_purge_all_and_reseed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("purges and reseeds the service usage events", func() {
+			Expect(usageEventRepo.PurgeAndReseedServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := usageEventRepo.PurgeAndReseedServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				usageEventRepo.PurgeAndReseedServiceUsageEventsReturns(apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
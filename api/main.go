@@ -24,6 +24,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	"code.cloudfoundry.org/korifi/api/routing"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -31,6 +33,7 @@ import (
 	"code.cloudfoundry.org/korifi/version"
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
+	"github.com/go-logr/logr"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -47,7 +50,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 )
 
-var conditionTimeout = time.Second * 120
+var (
+	conditionTimeout            = time.Second * 120
+	logStreamPodPollInterval    = time.Second * 2
+	logCollectorPodPollInterval = time.Second * 2
+)
 
 func init() {
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme.Scheme))
@@ -154,10 +161,13 @@ func main() {
 		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 		repositories.NewAppSorter(),
+		cfg.RootNamespace,
 	)
 	dropletRepo := repositories.NewDropletRepo(
 		userClientFactory,
 		namespaceRetriever,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
 	)
 	routeRepo := repositories.NewRouteRepo(
 		namespaceRetriever,
@@ -168,19 +178,35 @@ func main() {
 		namespaceRetriever,
 		cfg.RootNamespace,
 	)
+	routerGroupRepo := repositories.NewRouterGroupRepo(cfg.RouterGroups)
 	deploymentRepo := repositories.NewDeploymentRepo(
 		userClientFactory,
 		namespaceRetriever,
 		repositories.NewDeploymentSorter(),
 	)
+	revisionRepo := repositories.NewRevisionRepo(userClientFactory, namespaceRetriever)
 	buildRepo := repositories.NewBuildRepo(
 		namespaceRetriever,
 		userClientFactory,
 	)
+	resourceCache, err := repositories.NewResourceCache(cfg.GetResourceCacheDir(), int64(cfg.GetResourceCacheMaxSizeMB())*1024*1024)
+	if err != nil {
+		panic(fmt.Sprintf("could not create resource cache: %v", err))
+	}
+	logBuffer := repositories.NewLogBuffer(cfg.GetLogBufferSize())
+	go repositories.NewLogCollector(
+		privilegedClient,
+		privilegedClientset,
+		repositories.DefaultLogStreamer,
+		logBuffer,
+		logCollectorPodPollInterval,
+	).Start(logr.NewContext(context.Background(), ctrl.Log))
 	logRepo := repositories.NewLogRepo(
 		userClientFactoryUnfiltered,
 		authorization.NewUnprivilegedClientsetFactory(k8sClientConfig),
 		repositories.DefaultLogStreamer,
+		logBuffer,
+		logStreamPodPollInterval,
 	)
 	runnerInfoRepo := repositories.NewRunnerInfoRepository(
 		userClientFactoryUnfiltered,
@@ -195,17 +221,27 @@ func main() {
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackageList](conditionTimeout),
 		repositories.NewPackageSorter(),
 	)
+	brokerClientFactory := osbapi.NewClientFactory(privilegedClient, cfg.Experimental.ManagedServices.TrustInsecureBrokers)
+	serviceAssets := osbapi.NewAssets(privilegedClient, cfg.RootNamespace)
 	serviceInstanceRepo := repositories.NewServiceInstanceRepo(
 		namespaceRetriever,
 		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstanceList](conditionTimeout),
 		repositories.NewServiceInstanceSorter(),
 		cfg.RootNamespace,
+		brokerClientFactory,
+		serviceAssets,
 	)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(
 		namespaceRetriever,
 		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](conditionTimeout),
+		brokerClientFactory,
+		serviceAssets,
+	)
+	serviceRouteBindingRepo := repositories.NewServiceRouteBindingRepo(
+		namespaceRetriever,
+		userClientFactory,
 	)
 	stackRepo := repositories.NewStackRepository(cfg.BuilderName,
 		userClientFactoryUnfiltered,
@@ -242,6 +278,28 @@ func main() {
 	serviceBrokerRepo := repositories.NewServiceBrokerRepo(userClientFactory, cfg.RootNamespace)
 	serviceOfferingRepo := repositories.NewServiceOfferingRepo(userClientFactory, cfg.RootNamespace, serviceBrokerRepo, nsPermissions)
 	servicePlanRepo := repositories.NewServicePlanRepo(userClientFactory, cfg.RootNamespace, orgRepo)
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(userClientFactory, cfg.RootNamespace)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(userClientFactory, cfg.RootNamespace)
+	securityGroupRepo := repositories.NewSecurityGroupRepo(userClientFactory, namespaceRetriever, cfg.RootNamespace)
+	networkPolicyRepo := repositories.NewNetworkPolicyRepo(userClientFactory, namespaceRetriever)
+	auditEventRepo := repositories.NewAuditEventRepo(
+		privilegedClient,
+		userClientFactoryUnfiltered,
+		namespaceRetriever,
+		nsPermissions,
+		cfg.RootNamespace,
+	)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(
+		privilegedClient,
+		userClientFactoryUnfiltered,
+		cfg.RootNamespace,
+	)
+	envVarGroupRepo := repositories.NewEnvVarGroupRepo(userClientFactoryUnfiltered, cfg.RootNamespace)
+	usageEventRepo := repositories.NewUsageEventRepo(
+		userClientFactory,
+		usage.NewRecorder(privilegedClient, cfg.RootNamespace),
+		cfg.RootNamespace,
+	)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
@@ -278,6 +336,12 @@ func main() {
 			cfg.RootNamespace,
 			cache.NewExpiring(),
 		),
+		middleware.AuditEvents(
+			auditEventRepo,
+			cachingIdentityProvider,
+			namespaceRetriever,
+			handlers.AuditedRoutes,
+		),
 	)
 
 	relationshipsRepo := relationships.NewResourseRelationshipsRepo(
@@ -288,12 +352,15 @@ func main() {
 
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA),
+		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, cfg.SSHProxy),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
 		),
-		handlers.NewResourceMatches(),
+		handlers.NewResourceMatches(
+			resourceCache,
+			requestValidator,
+		),
 		handlers.NewApp(
 			*serverURL,
 			appRepo,
@@ -306,6 +373,7 @@ func main() {
 			packageRepo,
 			requestValidator,
 			podRepo,
+			featureFlagRepo,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -313,10 +381,18 @@ func main() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
+			featureFlagRepo,
+			requestValidator,
+		),
+		handlers.NewRouterGroup(
+			routerGroupRepo,
 			requestValidator,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
+			serviceRouteBindingRepo,
+			requestValidator,
 		),
 		handlers.NewPackage(
 			*serverURL,
@@ -324,8 +400,10 @@ func main() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCache,
 			requestValidator,
 			cfg.PackageRegistrySecretNames,
+			featureFlagRepo,
 		),
 		handlers.NewBuild(
 			*serverURL,
@@ -337,7 +415,10 @@ func main() {
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewProcess(
 			*serverURL,
@@ -350,6 +431,7 @@ func main() {
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			routerGroupRepo,
 		),
 		handlers.NewDeployment(
 			*serverURL,
@@ -358,6 +440,11 @@ func main() {
 			runnerInfoRepo,
 			cfg.RunnerName,
 		),
+		handlers.NewRevision(
+			*serverURL,
+			requestValidator,
+			revisionRepo,
+		),
 		handlers.NewStack(
 			*serverURL,
 			stackRepo,
@@ -372,21 +459,31 @@ func main() {
 				handlers.DomainDeleteJobType:                 domainRepo,
 				handlers.RoleDeleteJobType:                   roleRepo,
 				handlers.ServiceBrokerDeleteJobType:          serviceBrokerRepo,
+				handlers.SecurityGroupDeleteJobType:          securityGroupRepo,
 				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingDeleteJobType:  serviceBindingRepo,
+				handlers.ServiceRouteBindingDeleteJobType:    serviceRouteBindingRepo,
 			},
 			map[string]handlers.StateRepository{
 				handlers.ServiceBrokerCreateJobType:          serviceBrokerRepo,
 				handlers.ServiceBrokerUpdateJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceCreateJobType: serviceInstanceRepo,
+				handlers.ManagedServiceInstanceUpdateJobType: serviceInstanceRepo,
 				handlers.ManagedServiceBindingCreateJobType:  serviceBindingRepo,
+				handlers.ServiceRouteBindingCreateJobType:    serviceRouteBindingRepo,
+				handlers.DropletUploadJobType:                dropletRepo,
 			},
 			500*time.Millisecond,
 		),
 		handlers.NewLogCache(
 			requestValidator,
 			appRepo,
-			buildRepo,
+			logRepo,
+			actions.NewAppMetrics(appRepo, processRepo, metricsRepo),
+		),
+		handlers.NewLogStream(
+			requestValidator,
+			appRepo,
 			logRepo,
 		),
 		handlers.NewOrg(
@@ -412,6 +509,7 @@ func main() {
 			*serverURL,
 			roleRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
 		handlers.NewUser(*serverURL),
@@ -425,6 +523,7 @@ func main() {
 			serviceInstanceRepo,
 			spaceRepo,
 			requestValidator,
+			featureFlagRepo,
 			relationshipsRepo,
 		),
 		handlers.NewServiceBinding(
@@ -439,9 +538,12 @@ func main() {
 			appRepo,
 			taskRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewOAuth(
 			*serverURL,
+			cachingIdentityProvider,
+			authorization.NewOneTimeCodes(privilegedClient, cfg.RootNamespace, authorization.OneTimeCodeTTL),
 		),
 		handlers.NewServiceBroker(
 			*serverURL,
@@ -461,6 +563,47 @@ func main() {
 			servicePlanRepo,
 			relationshipsRepo,
 		),
+		handlers.NewOrgQuota(
+			*serverURL,
+			requestValidator,
+			orgQuotaRepo,
+		),
+		handlers.NewSpaceQuota(
+			*serverURL,
+			requestValidator,
+			spaceQuotaRepo,
+		),
+		handlers.NewSecurityGroup(
+			*serverURL,
+			requestValidator,
+			securityGroupRepo,
+		),
+		handlers.NewNetworkPolicy(
+			*serverURL,
+			requestValidator,
+			networkPolicyRepo,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			requestValidator,
+			auditEventRepo,
+		),
+		handlers.NewUsageEvent(
+			*serverURL,
+			requestValidator,
+			usageEventRepo,
+		),
+		handlers.NewFeatureFlag(
+			*serverURL,
+			requestValidator,
+			featureFlagRepo,
+			spaceRepo,
+		),
+		handlers.NewEnvVarGroup(
+			*serverURL,
+			requestValidator,
+			envVarGroupRepo,
+		),
 	}
 	for _, handler := range apiHandlers {
 		routerBuilder.LoadRoutes(handler)
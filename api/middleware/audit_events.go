@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/go-chi/chi"
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+//counterfeiter:generate -o fake -fake-name AuditEventRecorder . AuditEventRecorder
+
+type AuditEventRecorder interface {
+	RecordAuditEvent(context.Context, repositories.RecordAuditEventMessage) error
+}
+
+//counterfeiter:generate -o fake -fake-name NamespaceRetriever . NamespaceRetriever
+
+type NamespaceRetriever interface {
+	NamespaceFor(ctx context.Context, resourceGUID, resourceType string) (string, error)
+}
+
+// AuditedRoute describes the audit event recorded when a route succeeds
+type AuditedRoute struct {
+	// The CF event type, e.g. audit.app.start
+	EventType string
+	// The CF target type, e.g. app
+	TargetType string
+	// The URL parameter holding the target GUID. When empty, the GUID is
+	// read from the response body (e.g. for create requests)
+	TargetGUIDParam string
+	// The repositories resource type of the target, used to look up the
+	// space of space scoped targets
+	ResourceType string
+}
+
+// AuditedRouteKey builds the key of an audited route, e.g. "POST /v3/apps"
+func AuditedRouteKey(method, pattern string) string {
+	return method + " " + pattern
+}
+
+type auditEventOverrideKey struct{}
+
+type auditEventOverride struct {
+	eventType  string
+	targetType string
+}
+
+// OverrideAuditEvent makes the audit middleware record the given event type
+// and target type instead of the ones of the audited route. Handlers use it
+// when a single route serves several kinds of resources, e.g. service
+// credential bindings that are either app bindings or service keys. It is a
+// no-op when the route is not audited.
+func OverrideAuditEvent(ctx context.Context, eventType, targetType string) {
+	override, ok := ctx.Value(auditEventOverrideKey{}).(*auditEventOverride)
+	if !ok {
+		return
+	}
+
+	override.eventType = eventType
+	override.targetType = targetType
+}
+
+type auditEvents struct {
+	recorder           AuditEventRecorder
+	identityProvider   IdentityProvider
+	namespaceRetriever NamespaceRetriever
+	auditedRoutes      map[string]AuditedRoute
+}
+
+// AuditEvents records an audit event for every successful request to one of
+// the audited routes. It must run after the authentication middleware as the
+// actor of the event is the authenticated subject. Failing to record an event
+// does not fail the request.
+func AuditEvents(
+	recorder AuditEventRecorder,
+	identityProvider IdentityProvider,
+	namespaceRetriever NamespaceRetriever,
+	auditedRoutes map[string]AuditedRoute,
+) func(http.Handler) http.Handler {
+	return (&auditEvents{
+		recorder:           recorder,
+		identityProvider:   identityProvider,
+		namespaceRetriever: namespaceRetriever,
+		auditedRoutes:      auditedRoutes,
+	}).middleware
+}
+
+func (m *auditEvents) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auditedRoute, ok := m.auditedRoutes[AuditedRouteKey(r.Method, chi.RouteContext(r.Context()).RoutePattern())]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		logger := logr.FromContextOrDiscard(r.Context()).WithName("audit-events")
+
+		// Resolve the space before serving the request, as the target might
+		// not exist anymore afterwards (e.g. on delete)
+		targetGUID := ""
+		spaceGUID := ""
+		if auditedRoute.TargetGUIDParam != "" {
+			targetGUID = chi.URLParam(r, auditedRoute.TargetGUIDParam)
+			spaceGUID = m.targetSpace(r.Context(), auditedRoute, targetGUID)
+		}
+
+		override := &auditEventOverride{}
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), auditEventOverrideKey{}, override)))
+
+		if recorder.status < 200 || recorder.status >= 300 {
+			return
+		}
+
+		if override.eventType != "" {
+			auditedRoute.EventType = override.eventType
+			auditedRoute.TargetType = override.targetType
+		}
+
+		body := parseAuditedResponseBody(recorder.body.Bytes())
+		if targetGUID == "" {
+			targetGUID = body.GUID
+			spaceGUID = m.targetSpace(r.Context(), auditedRoute, targetGUID)
+		}
+		if spaceGUID == "" {
+			spaceGUID = body.Relationships.Space.Data.GUID
+		}
+		if spaceGUID == "" && body.Relationships.App.Data.GUID != "" {
+			spaceGUID = m.namespaceFor(r.Context(), body.Relationships.App.Data.GUID, repositories.AppResourceType)
+		}
+
+		orgGUID := body.Relationships.Organization.Data.GUID
+		if auditedRoute.TargetType == repositories.AuditEventTargetTypeOrganization {
+			orgGUID = targetGUID
+		}
+
+		message := repositories.RecordAuditEventMessage{
+			Type:             auditedRoute.EventType,
+			TargetGUID:       targetGUID,
+			TargetType:       auditedRoute.TargetType,
+			TargetName:       body.Name,
+			SpaceGUID:        spaceGUID,
+			OrganizationGUID: orgGUID,
+		}
+
+		authInfo, _ := authorization.InfoFromContext(r.Context())
+		identity, err := m.identityProvider.GetIdentity(r.Context(), authInfo)
+		if err != nil {
+			logger.Info("failed to get identity of the audit event actor", "reason", err)
+			return
+		}
+		message.ActorGUID = identity.Name
+		message.ActorName = identity.Name
+		message.ActorType = toActorType(identity.Kind)
+
+		if err := m.recorder.RecordAuditEvent(r.Context(), message); err != nil {
+			logger.Info("failed to record audit event", "type", message.Type, "target", message.TargetGUID, "reason", err)
+		}
+	})
+}
+
+func (m *auditEvents) targetSpace(ctx context.Context, auditedRoute AuditedRoute, targetGUID string) string {
+	if targetGUID == "" {
+		return ""
+	}
+
+	if auditedRoute.TargetType == repositories.AuditEventTargetTypeSpace {
+		return targetGUID
+	}
+
+	if auditedRoute.ResourceType == "" {
+		return ""
+	}
+
+	return m.namespaceFor(ctx, targetGUID, auditedRoute.ResourceType)
+}
+
+func (m *auditEvents) namespaceFor(ctx context.Context, guid, resourceType string) string {
+	namespace, err := m.namespaceRetriever.NamespaceFor(ctx, guid, resourceType)
+	if err != nil {
+		return ""
+	}
+
+	return namespace
+}
+
+func toActorType(identityKind string) string {
+	if identityKind == rbacv1.ServiceAccountKind {
+		return "service_account"
+	}
+
+	return "user"
+}
+
+type relationshipData struct {
+	Data struct {
+		GUID string `json:"guid"`
+	} `json:"data"`
+}
+
+type auditedResponseBody struct {
+	GUID          string `json:"guid"`
+	Name          string `json:"name"`
+	Relationships struct {
+		App          relationshipData `json:"app"`
+		Space        relationshipData `json:"space"`
+		Organization relationshipData `json:"organization"`
+	} `json:"relationships"`
+}
+
+func parseAuditedResponseBody(body []byte) auditedResponseBody {
+	parsed := auditedResponseBody{}
+	// Responses without a (JSON) body, e.g. on delete, are fine
+	_ = json.Unmarshal(body, &parsed)
+	return parsed
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
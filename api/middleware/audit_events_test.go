@@ -0,0 +1,287 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/middleware"
+	"code.cloudfoundry.org/korifi/api/middleware/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/go-chi/chi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("AuditEvents", func() {
+	var (
+		recorder           *fake.AuditEventRecorder
+		identityProvider   *fake.IdentityProvider
+		namespaceRetriever *fake.NamespaceRetriever
+		router             *chi.Mux
+		handlerStatus      int
+		handlerBody        string
+		overrideEventType  string
+		authInfo           authorization.Info
+		method             string
+		path               string
+	)
+
+	BeforeEach(func() {
+		authInfo = authorization.Info{Token: "a-token"}
+
+		recorder = new(fake.AuditEventRecorder)
+		identityProvider = new(fake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}, nil)
+		namespaceRetriever = new(fake.NamespaceRetriever)
+		namespaceRetriever.NamespaceForStub = func(_ context.Context, guid, resourceType string) (string, error) {
+			return resourceType + "-" + guid + "-space", nil
+		}
+
+		handlerStatus = http.StatusOK
+		handlerBody = `{"guid": "body-guid", "name": "body-name"}`
+		overrideEventType = ""
+
+		auditEvents := middleware.AuditEvents(recorder, identityProvider, namespaceRetriever, map[string]middleware.AuditedRoute{
+			middleware.AuditedRouteKey("POST", "/v3/apps"): {
+				EventType:    "audit.app.create",
+				TargetType:   "app",
+				ResourceType: repositories.AppResourceType,
+			},
+			middleware.AuditedRouteKey("POST", "/v3/apps/{guid}/actions/start"): {
+				EventType:       "audit.app.start",
+				TargetType:      "app",
+				TargetGUIDParam: "guid",
+				ResourceType:    repositories.AppResourceType,
+			},
+			middleware.AuditedRouteKey("POST", "/v3/deployments"): {
+				EventType:  "audit.app.deployment.create",
+				TargetType: "deployment",
+			},
+			middleware.AuditedRouteKey("PATCH", "/v3/spaces/{guid}"): {
+				EventType:       "audit.space.update",
+				TargetType:      repositories.AuditEventTargetTypeSpace,
+				TargetGUIDParam: "guid",
+			},
+			middleware.AuditedRouteKey("DELETE", "/v3/organizations/{guid}"): {
+				EventType:       "audit.organization.delete-request",
+				TargetType:      repositories.AuditEventTargetTypeOrganization,
+				TargetGUIDParam: "guid",
+			},
+		})
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if overrideEventType != "" {
+				middleware.OverrideAuditEvent(r.Context(), overrideEventType, "overridden-target-type")
+			}
+			w.WriteHeader(handlerStatus)
+			_, _ = w.Write([]byte(handlerBody))
+		}
+		// Middlewares of a group run after routing, as the authenticated routes
+		// do, so that the route pattern is known
+		router = chi.NewRouter()
+		router.Group(func(r chi.Router) {
+			r.Use(auditEvents)
+			r.Post("/v3/apps", handler)
+			r.Get("/v3/apps", handler)
+			r.Post("/v3/apps/{guid}/actions/start", handler)
+			r.Post("/v3/deployments", handler)
+			r.Patch("/v3/spaces/{guid}", handler)
+			r.Delete("/v3/organizations/{guid}", handler)
+		})
+
+		method = "POST"
+		path = "/v3/apps/app-guid/actions/start"
+	})
+
+	JustBeforeEach(func() {
+		ctx := authorization.NewContext(context.Background(), &authInfo)
+		request, err := http.NewRequestWithContext(ctx, method, "http://localhost"+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, request)
+	})
+
+	It("delegates to the next handler", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		Expect(rr).To(HaveHTTPBody(MatchJSON(handlerBody)))
+	})
+
+	It("records an audit event on behalf of the authenticated user", func() {
+		Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+		_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+
+		Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+		_, message := recorder.RecordAuditEventArgsForCall(0)
+		Expect(message).To(Equal(repositories.RecordAuditEventMessage{
+			Type:       "audit.app.start",
+			ActorGUID:  "alice",
+			ActorType:  "user",
+			ActorName:  "alice",
+			TargetGUID: "app-guid",
+			TargetType: "app",
+			TargetName: "body-name",
+			SpaceGUID:  "App-app-guid-space",
+		}))
+	})
+
+	When("the actor is a service account", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{Name: "system:serviceaccount:ns:robot", Kind: rbacv1.ServiceAccountKind}, nil)
+		})
+
+		It("records the service account as actor", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.ActorGUID).To(Equal("system:serviceaccount:ns:robot"))
+			Expect(message.ActorType).To(Equal("service_account"))
+		})
+	})
+
+	When("the target guid is not in the URL", func() {
+		BeforeEach(func() {
+			path = "/v3/apps"
+		})
+
+		It("reads the target guid from the response body", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.app.create"))
+			Expect(message.TargetGUID).To(Equal("body-guid"))
+			Expect(message.SpaceGUID).To(Equal("App-body-guid-space"))
+		})
+	})
+
+	When("the target space is only known through the app relationship", func() {
+		BeforeEach(func() {
+			path = "/v3/deployments"
+			handlerBody = `{"guid": "deployment-guid", "relationships": {"app": {"data": {"guid": "app-guid"}}}}`
+		})
+
+		It("records the space of the app", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.TargetGUID).To(Equal("deployment-guid"))
+			Expect(message.SpaceGUID).To(Equal("App-app-guid-space"))
+		})
+	})
+
+	When("the target is a space", func() {
+		BeforeEach(func() {
+			method = "PATCH"
+			path = "/v3/spaces/space-guid"
+		})
+
+		It("records the space itself", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.TargetGUID).To(Equal("space-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(namespaceRetriever.NamespaceForCallCount()).To(BeZero())
+		})
+	})
+
+	When("the target is an organization", func() {
+		BeforeEach(func() {
+			method = "DELETE"
+			path = "/v3/organizations/org-guid"
+			handlerBody = ""
+		})
+
+		It("records the organization", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.TargetGUID).To(Equal("org-guid"))
+			Expect(message.OrganizationGUID).To(Equal("org-guid"))
+			Expect(message.SpaceGUID).To(BeEmpty())
+		})
+	})
+
+	When("the handler overrides the audit event", func() {
+		BeforeEach(func() {
+			overrideEventType = "audit.overridden.start"
+		})
+
+		It("records the overridden event type and target type", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.Type).To(Equal("audit.overridden.start"))
+			Expect(message.TargetType).To(Equal("overridden-target-type"))
+			Expect(message.TargetGUID).To(Equal("app-guid"))
+			Expect(message.SpaceGUID).To(Equal("App-app-guid-space"))
+		})
+
+		When("the route is not audited", func() {
+			BeforeEach(func() {
+				method = "GET"
+				path = "/v3/apps"
+			})
+
+			It("does not record an audit event", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(recorder.RecordAuditEventCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	When("the route is not audited", func() {
+		BeforeEach(func() {
+			method = "GET"
+			path = "/v3/apps"
+		})
+
+		It("does not record an audit event", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(recorder.RecordAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("the request fails", func() {
+		BeforeEach(func() {
+			handlerStatus = http.StatusUnprocessableEntity
+		})
+
+		It("does not record an audit event", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+			Expect(recorder.RecordAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("resolving the target space fails", func() {
+		BeforeEach(func() {
+			namespaceRetriever.NamespaceForStub = nil
+			namespaceRetriever.NamespaceForReturns("", errors.New("ns-err"))
+		})
+
+		It("records the event without a space", func() {
+			Expect(recorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, message := recorder.RecordAuditEventArgsForCall(0)
+			Expect(message.SpaceGUID).To(BeEmpty())
+		})
+	})
+
+	When("getting the actor identity fails", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("id-err"))
+		})
+
+		It("does not fail the request", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(recorder.RecordAuditEventCallCount()).To(BeZero())
+		})
+	})
+
+	When("recording the audit event fails", func() {
+		BeforeEach(func() {
+			recorder.RecordAuditEventReturns(errors.New("record-err"))
+		})
+
+		It("does not fail the request", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(handlerBody)))
+		})
+	})
+})
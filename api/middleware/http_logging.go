@@ -28,6 +28,11 @@ func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.status = statusCode
 }
 
+// Unwrap allows http.ResponseController to flush streamed responses
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
 func HTTPLogging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t1 := time.Now()
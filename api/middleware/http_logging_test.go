@@ -53,4 +53,17 @@ var _ = Describe("HttpLoggingMiddleware", func() {
 		Expect(resLog).To(HaveKeyWithValue("status", float64(http.StatusTeapot)))
 		Expect(resLog).To(HaveKeyWithValue("size", float64(13)))
 	})
+
+	It("allows the response to be flushed", func() {
+		res := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/path", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		middleware.HTTPLogging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "hello")
+			Expect(http.NewResponseController(w).Flush()).To(Succeed())
+		})).ServeHTTP(res, req)
+
+		Expect(res.Flushed).To(BeTrue())
+	})
 })
@@ -504,4 +504,38 @@ var _ = Describe("App payload validation", func() {
 			})
 		})
 	})
+	Describe("AppFeatureUpdate", func() {
+		var (
+			body           map[string]any
+			decodedPayload *payloads.AppFeatureUpdate
+		)
+
+		BeforeEach(func() {
+			body = map[string]any{"enabled": false}
+			decodedPayload = new(payloads.AppFeatureUpdate)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(body), decodedPayload)
+		})
+
+		It("converts the payload to an ssh patch message", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.ToSSHMessage("app-guid", "space-guid")).To(Equal(repositories.PatchAppMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				EnableSSH: tools.PtrTo(false),
+			}))
+		})
+
+		When("enabled is missing", func() {
+			BeforeEach(func() {
+				body = map[string]any{}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "enabled is required")
+			})
+		})
+	})
 })
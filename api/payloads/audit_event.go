@@ -0,0 +1,111 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AuditEventList struct {
+	GUIDs             string
+	Types             string
+	TargetGUIDs       string
+	SpaceGUIDs        string
+	OrganizationGUIDs string
+	CreatedAts        repositories.TimestampFilter
+	OrderBy           string
+}
+
+func (l *AuditEventList) SupportedKeys() []string {
+	return []string{
+		"guids",
+		"types",
+		"target_guids",
+		"space_guids",
+		"organization_guids",
+		"created_ats",
+		"created_ats[lt]",
+		"created_ats[lte]",
+		"created_ats[gt]",
+		"created_ats[gte]",
+		"order_by",
+	}
+}
+
+func (l *AuditEventList) IgnoredKeys() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		regexp.MustCompile("page"),
+		regexp.MustCompile("per_page"),
+	}
+}
+
+func (l *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	var err error
+
+	l.GUIDs = values.Get("guids")
+	l.Types = values.Get("types")
+	l.TargetGUIDs = values.Get("target_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.OrderBy = values.Get("order_by")
+
+	for _, value := range parse.ArrayParam(values.Get("created_ats")) {
+		var timestamp *time.Time
+		if timestamp, err = parseTimestamp("created_ats", value); err != nil {
+			return err
+		}
+		l.CreatedAts.Values = append(l.CreatedAts.Values, *timestamp)
+	}
+
+	if l.CreatedAts.LessThan, err = parseTimestamp("created_ats[lt]", values.Get("created_ats[lt]")); err != nil {
+		return err
+	}
+	if l.CreatedAts.LessThanOrEqual, err = parseTimestamp("created_ats[lte]", values.Get("created_ats[lte]")); err != nil {
+		return err
+	}
+	if l.CreatedAts.GreaterThan, err = parseTimestamp("created_ats[gt]", values.Get("created_ats[gt]")); err != nil {
+		return err
+	}
+	if l.CreatedAts.GreaterThanOrEqual, err = parseTimestamp("created_ats[gte]", values.Get("created_ats[gte]")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l AuditEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at")),
+	)
+}
+
+func (l *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Types:             parse.ArrayParam(l.Types),
+		TargetGUIDs:       parse.ArrayParam(l.TargetGUIDs),
+		SpaceGUIDs:        parse.ArrayParam(l.SpaceGUIDs),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		CreatedAts:        l.CreatedAts,
+		OrderBy:           l.OrderBy,
+	}
+}
+
+func parseTimestamp(key, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s timestamp %q: %w", key, value, err)
+	}
+
+	return &timestamp, nil
+}
@@ -0,0 +1,91 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("AuditEventList", func() {
+	var (
+		firstJan  time.Time
+		secondJan time.Time
+	)
+
+	BeforeEach(func() {
+		firstJan = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		secondJan = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	})
+
+	DescribeTable("valid query",
+		func(query string, expectedAuditEventListFn func() payloads.AuditEventList) {
+			actualAuditEventList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAuditEventList).To(Equal(expectedAuditEventListFn()))
+		},
+		Entry("guids", "guids=g1,g2", func() payloads.AuditEventList { return payloads.AuditEventList{GUIDs: "g1,g2"} }),
+		Entry("types", "types=audit.app.start,audit.app.stop", func() payloads.AuditEventList {
+			return payloads.AuditEventList{Types: "audit.app.start,audit.app.stop"}
+		}),
+		Entry("target_guids", "target_guids=t1,t2", func() payloads.AuditEventList { return payloads.AuditEventList{TargetGUIDs: "t1,t2"} }),
+		Entry("space_guids", "space_guids=s1,s2", func() payloads.AuditEventList { return payloads.AuditEventList{SpaceGUIDs: "s1,s2"} }),
+		Entry("organization_guids", "organization_guids=o1,o2", func() payloads.AuditEventList {
+			return payloads.AuditEventList{OrganizationGUIDs: "o1,o2"}
+		}),
+		Entry("created_ats", "created_ats=2024-01-01T00:00:00Z,2024-01-02T00:00:00Z", func() payloads.AuditEventList {
+			return payloads.AuditEventList{CreatedAts: repositories.TimestampFilter{Values: []time.Time{firstJan, secondJan}}}
+		}),
+		Entry("created_ats[lt]", "created_ats[lt]=2024-01-01T00:00:00Z", func() payloads.AuditEventList {
+			return payloads.AuditEventList{CreatedAts: repositories.TimestampFilter{LessThan: tools.PtrTo(firstJan)}}
+		}),
+		Entry("created_ats[lte]", "created_ats[lte]=2024-01-01T00:00:00Z", func() payloads.AuditEventList {
+			return payloads.AuditEventList{CreatedAts: repositories.TimestampFilter{LessThanOrEqual: tools.PtrTo(firstJan)}}
+		}),
+		Entry("created_ats[gt]", "created_ats[gt]=2024-01-01T00:00:00Z", func() payloads.AuditEventList {
+			return payloads.AuditEventList{CreatedAts: repositories.TimestampFilter{GreaterThan: tools.PtrTo(firstJan)}}
+		}),
+		Entry("created_ats[gte]", "created_ats[gte]=2024-01-01T00:00:00Z", func() payloads.AuditEventList {
+			return payloads.AuditEventList{CreatedAts: repositories.TimestampFilter{GreaterThanOrEqual: tools.PtrTo(firstJan)}}
+		}),
+		Entry("order_by created_at", "order_by=created_at", func() payloads.AuditEventList { return payloads.AuditEventList{OrderBy: "created_at"} }),
+		Entry("order_by -created_at", "order_by=-created_at", func() payloads.AuditEventList { return payloads.AuditEventList{OrderBy: "-created_at"} }),
+		Entry("pagination", "page=1&per_page=50", func() payloads.AuditEventList { return payloads.AuditEventList{} }),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, errMatcher types.GomegaMatcher) {
+			_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+			Expect(decodeErr).To(errMatcher)
+		},
+		Entry("invalid created_ats", "created_ats=yesterday", MatchError(ContainSubstring("invalid created_ats timestamp"))),
+		Entry("invalid created_ats[gt]", "created_ats[gt]=2024-01-01", MatchError(ContainSubstring("invalid created_ats[gt] timestamp"))),
+		Entry("invalid order_by", "order_by=type", MatchError(ContainSubstring("value must be one of"))),
+		Entry("unsupported key", "foo=bar", HaveOccurred()),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.AuditEventList{
+				Types:       "audit.app.start,audit.app.stop",
+				TargetGUIDs: "t1",
+				SpaceGUIDs:  "s1,s2",
+				CreatedAts:  repositories.TimestampFilter{GreaterThan: tools.PtrTo(firstJan)},
+				OrderBy:     "-created_at",
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+				Types:       []string{"audit.app.start", "audit.app.stop"},
+				TargetGUIDs: []string{"t1"},
+				SpaceGUIDs:  []string{"s1", "s2"},
+				CreatedAts:  repositories.TimestampFilter{GreaterThan: tools.PtrTo(firstJan)},
+				OrderBy:     "-created_at",
+			}))
+		})
+	})
+})
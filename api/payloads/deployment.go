@@ -17,21 +17,89 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      RevisionGUID             `json:"revision"`
+	Strategy      string                   `json:"strategy"`
+	Options       *DeploymentOptions       `json:"options"`
 	Relationships *DeploymentRelationships `json:"relationships"`
 }
 
+type DeploymentOptions struct {
+	Canary *DeploymentCanaryOptions `json:"canary"`
+}
+
+type DeploymentCanaryOptions struct {
+	Steps []DeploymentCanaryStep `json:"steps"`
+}
+
+type DeploymentCanaryStep struct {
+	Instances int32  `json:"instances"`
+	Weight    *int32 `json:"weight"`
+}
+
+func (o DeploymentOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.Canary),
+	)
+}
+
+func (o DeploymentCanaryOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.Steps),
+	)
+}
+
+func (s DeploymentCanaryStep) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Instances, jellidation.Required, jellidation.Min(int32(1))),
+		jellidation.Field(&s.Weight, jellidation.Min(int32(0)), jellidation.Max(int32(100))),
+	)
+}
+
 func (c DeploymentCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Revision, jellidation.By(func(value any) error {
+			if c.Revision.Guid != "" && c.Droplet.Guid != "" {
+				return jellidation.NewError("invalid_revision", "cannot be set together with droplet")
+			}
+			return nil
+		})),
+		jellidation.Field(&c.Strategy, validation.OneOf(
+			string(repositories.DeploymentStrategyRolling),
+			string(repositories.DeploymentStrategyCanary),
+		)),
+		jellidation.Field(&c.Options, jellidation.By(func(value any) error {
+			if c.Options != nil && c.Options.Canary != nil && c.Strategy != string(repositories.DeploymentStrategyCanary) {
+				return jellidation.NewError("invalid_options", "canary options are only supported by the canary strategy")
+			}
+			return nil
+		})),
 		jellidation.Field(&c.Relationships, jellidation.NotNil))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
-		AppGUID:     c.Relationships.App.Data.GUID,
-		DropletGUID: c.Droplet.Guid,
+	message := repositories.CreateDeploymentMessage{
+		AppGUID:      c.Relationships.App.Data.GUID,
+		DropletGUID:  c.Droplet.Guid,
+		RevisionGUID: c.Revision.Guid,
+		Strategy:     repositories.DeploymentStrategy(c.Strategy),
+	}
+
+	if c.Options != nil && c.Options.Canary != nil {
+		message.CanarySteps = slices.Collect(it.Map(slices.Values(c.Options.Canary.Steps), func(step DeploymentCanaryStep) repositories.CanaryStep {
+			return repositories.CanaryStep{
+				Instances: step.Instances,
+				Weight:    step.Weight,
+			}
+		}))
 	}
+
+	return message
 }
 
 type DeploymentRelationships struct {
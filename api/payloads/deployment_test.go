@@ -3,6 +3,7 @@ package payloads_test
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -86,6 +87,90 @@ var _ = Describe("DeploymentCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
 			})
 		})
+
+		When("a revision is specified instead of a droplet", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+		})
+
+		When("both a revision and a droplet are specified", func() {
+			BeforeEach(func() {
+				createDeployment.Revision = payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "revision cannot be set together with droplet")
+			})
+		})
+
+		When("the strategy is canary", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+				createDeployment.Options = &payloads.DeploymentOptions{
+					Canary: &payloads.DeploymentCanaryOptions{
+						Steps: []payloads.DeploymentCanaryStep{
+							{Instances: 1, Weight: tools.PtrTo[int32](10)},
+							{Instances: 3},
+						},
+					},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+
+			When("a step has no instances", func() {
+				BeforeEach(func() {
+					createDeployment.Options.Canary.Steps[1].Instances = 0
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "instances cannot be blank")
+				})
+			})
+
+			When("a step weight is greater than 100", func() {
+				BeforeEach(func() {
+					createDeployment.Options.Canary.Steps[0].Weight = tools.PtrTo[int32](101)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "weight must be no greater than 100")
+				})
+			})
+		})
+
+		When("the strategy is invalid", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "blue-green"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "strategy value must be one of")
+			})
+		})
+
+		When("canary options are set for the rolling strategy", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "rolling"
+				createDeployment.Options = &payloads.DeploymentOptions{
+					Canary: &payloads.DeploymentCanaryOptions{},
+				}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "options canary options are only supported by the canary strategy")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -101,6 +186,46 @@ var _ = Describe("DeploymentCreate", func() {
 				DropletGUID: "the-droplet",
 			}))
 		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("sets the revision guid", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      "the-app",
+					RevisionGUID: "the-revision",
+				}))
+			})
+		})
+
+		When("canary options are specified", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+				createDeployment.Options = &payloads.DeploymentOptions{
+					Canary: &payloads.DeploymentCanaryOptions{
+						Steps: []payloads.DeploymentCanaryStep{
+							{Instances: 1, Weight: tools.PtrTo[int32](10)},
+							{Instances: 3},
+						},
+					},
+				}
+			})
+
+			It("sets the strategy and the canary steps", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:     "the-app",
+					DropletGUID: "the-droplet",
+					Strategy:    repositories.DeploymentStrategyCanary,
+					CanarySteps: []repositories.CanaryStep{
+						{Instances: 1, Weight: tools.PtrTo[int32](10)},
+						{Instances: 3},
+					},
+				}))
+			})
+		})
 	})
 })
 
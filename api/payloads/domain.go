@@ -13,6 +13,7 @@ import (
 type DomainCreate struct {
 	Name          string                  `json:"name"`
 	Internal      bool                    `json:"internal"`
+	RouterGroup   *RelationshipData       `json:"router_group"`
 	Metadata      Metadata                `json:"metadata"`
 	Relationships map[string]Relationship `json:"relationships"`
 }
@@ -20,27 +21,30 @@ type DomainCreate struct {
 func (c DomainCreate) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Name, payload_validation.StrictlyRequired),
+		validation.Field(&c.RouterGroup, validation.When(c.Internal, validation.Nil.Error("cannot be set for internal domains"))),
 		validation.Field(&c.Metadata),
 		validation.Field(&c.Relationships),
 	)
 }
 
 func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
-	if c.Internal {
-		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
-	}
-
 	if len(c.Relationships) > 0 {
 		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
 	}
 
-	return repositories.CreateDomainMessage{
-		Name: c.Name,
+	message := repositories.CreateDomainMessage{
+		Name:     c.Name,
+		Internal: c.Internal,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
 		},
-	}, nil
+	}
+	if c.RouterGroup != nil {
+		message.RouterGroup = c.RouterGroup.GUID
+	}
+
+	return message, nil
 }
 
 type DomainUpdate struct {
@@ -79,6 +79,27 @@ var _ = Describe("DomainCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "data is required")
 			})
 		})
+
+		When("the router group has no guid", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.RelationshipData{}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+			})
+		})
+
+		When("an internal domain has a router group", func() {
+			BeforeEach(func() {
+				createPayload.Internal = true
+				createPayload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "router_group cannot be set for internal domains")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -118,8 +139,20 @@ var _ = Describe("DomainCreate", func() {
 				createPayload.Internal = true
 			})
 
-			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("internal domains are not supported")))
+			It("returns an internal domain create message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.Internal).To(BeTrue())
+			})
+		})
+
+		When("the payload has a router group", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.RelationshipData{GUID: "default-tcp"}
+			})
+
+			It("sets the router group on the message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.RouterGroup).To(Equal("default-tcp"))
 			})
 		})
 
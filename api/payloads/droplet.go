@@ -5,6 +5,58 @@ import (
 	"github.com/jellydator/validation"
 )
 
+// DropletCreate is the payload of POST /v3/droplets, which creates an empty
+// droplet for bits built outside the cluster
+type DropletCreate struct {
+	Relationships *DropletRelationships `json:"relationships"`
+	ProcessTypes  map[string]string     `json:"process_types"`
+}
+
+func (c DropletCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+	)
+}
+
+func (c DropletCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Stack:        appRecord.Lifecycle.Data.Stack,
+		ProcessTypes: c.ProcessTypes,
+	}
+}
+
+// DropletCopy is the payload of POST /v3/droplets?source_guid=<guid>, which
+// copies the source droplet to the app in the relationships
+type DropletCopy struct {
+	Relationships *DropletRelationships `json:"relationships"`
+}
+
+func (c DropletCopy) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+	)
+}
+
+func (c DropletCopy) ToMessage(appRecord repositories.AppRecord, sourceRecord repositories.DropletRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Stack:        sourceRecord.Stack,
+		ProcessTypes: sourceRecord.ProcessTypes,
+	}
+}
+
+type DropletRelationships struct {
+	App *Relationship `json:"app"`
+}
+
+func (r DropletRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.App, validation.NotNil))
+}
+
 type DropletUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
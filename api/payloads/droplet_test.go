@@ -2,6 +2,7 @@ package payloads_test
 
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
@@ -9,6 +10,160 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+var _ = Describe("DropletCreate", func() {
+	var createPayload payloads.DropletCreate
+
+	BeforeEach(func() {
+		createPayload = payloads.DropletCreate{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "app-guid",
+					},
+				},
+			},
+			ProcessTypes: map[string]string{
+				"web": "bundle exec rackup",
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			dropletCreate *payloads.DropletCreate
+			validatorErr  error
+		)
+
+		BeforeEach(func() {
+			dropletCreate = new(payloads.DropletCreate)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), dropletCreate)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(dropletCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		When("relationships is not set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships is required")
+			})
+		})
+
+		When("the app relationship is not set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.App = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "app is required")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("creates a message for a droplet of the app", func() {
+			Expect(createPayload.ToMessage(repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{
+					Data: repositories.LifecycleData{Stack: "cflinuxfs4"},
+				},
+			})).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				Stack:     "cflinuxfs4",
+				ProcessTypes: map[string]string{
+					"web": "bundle exec rackup",
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("DropletCopy", func() {
+	var copyPayload payloads.DropletCopy
+
+	BeforeEach(func() {
+		copyPayload = payloads.DropletCopy{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "app-guid",
+					},
+				},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			dropletCopy  *payloads.DropletCopy
+			validatorErr error
+		)
+
+		BeforeEach(func() {
+			dropletCopy = new(payloads.DropletCopy)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), dropletCopy)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(dropletCopy).To(gstruct.PointTo(Equal(copyPayload)))
+		})
+
+		When("relationships is not set", func() {
+			BeforeEach(func() {
+				copyPayload.Relationships = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships is required")
+			})
+		})
+
+		When("the app relationship is not set", func() {
+			BeforeEach(func() {
+				copyPayload.Relationships.App = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "app is required")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("creates a message for a droplet with the source process types and stack", func() {
+			Expect(copyPayload.ToMessage(
+				repositories.AppRecord{
+					GUID:      "app-guid",
+					SpaceGUID: "space-guid",
+				},
+				repositories.DropletRecord{
+					Stack:        "cflinuxfs4",
+					ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				},
+			)).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Stack:        "cflinuxfs4",
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			}))
+		})
+	})
+})
+
 var _ = Describe("DropletUpdate", func() {
 	Describe("Decode", func() {
 		var (
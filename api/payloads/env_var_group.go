@@ -0,0 +1,49 @@
+package payloads
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type EnvVarGroupUpdate struct {
+	Var map[string]interface{} `json:"var"`
+}
+
+func (p EnvVarGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Var,
+			validation.StrictlyRequired,
+			jellidation.Map().Keys(
+				validation.NotStartWith("VCAP_"),
+				validation.NotStartWith("VMC_"),
+				validation.NotEqual("PORT"),
+			).AllowExtraKeys(),
+		))
+}
+
+func (p EnvVarGroupUpdate) ToMessage(name string) repositories.UpdateEnvVarGroupMessage {
+	message := repositories.UpdateEnvVarGroupMessage{
+		Name: name,
+		Var:  map[string]*string{},
+	}
+
+	for k, v := range p.Var {
+		switch v := v.(type) {
+		case nil:
+			message.Var[k] = nil
+		case bool:
+			stringVar := fmt.Sprintf("%t", v)
+			message.Var[k] = &stringVar
+		case float64:
+			stringVar := fmt.Sprintf("%v", v)
+			message.Var[k] = &stringVar
+		case string:
+			message.Var[k] = &v
+		}
+	}
+
+	return message
+}
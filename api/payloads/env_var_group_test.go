@@ -0,0 +1,75 @@
+package payloads_test
+
+import (
+	"bytes"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvVarGroupUpdate", func() {
+	var (
+		body           string
+		decodedPayload *payloads.EnvVarGroupUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		body = `{"var": {"HTTP_PROXY": "http://proxy", "DEBUG": true, "RETRIES": 3, "OLD": null}}`
+	})
+
+	JustBeforeEach(func() {
+		decodedPayload = new(payloads.EnvVarGroupUpdate)
+		req, err := http.NewRequest("", "", bytes.NewReader([]byte(body)))
+		Expect(err).NotTo(HaveOccurred())
+		validatorErr = validator.DecodeAndValidateJSONPayload(req, decodedPayload)
+	})
+
+	It("converts the payload to a message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("running")).To(Equal(repositories.UpdateEnvVarGroupMessage{
+			Name: "running",
+			Var: map[string]*string{
+				"HTTP_PROXY": tools.PtrTo("http://proxy"),
+				"DEBUG":      tools.PtrTo("true"),
+				"RETRIES":    tools.PtrTo("3"),
+				"OLD":        nil,
+			},
+		}))
+	})
+
+	When("var is missing", func() {
+		BeforeEach(func() {
+			body = `{}`
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "var cannot be blank")
+		})
+	})
+
+	When("a variable name starts with VCAP_", func() {
+		BeforeEach(func() {
+			body = `{"var": {"VCAP_FOO": "bar"}}`
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VCAP_ is not allowed")
+		})
+	})
+
+	When("a variable is PORT", func() {
+		BeforeEach(func() {
+			body = `{"var": {"PORT": "8080"}}`
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "value PORT is not allowed")
+		})
+	})
+})
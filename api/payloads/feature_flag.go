@@ -0,0 +1,37 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type FeatureFlagUpdate struct {
+	Enabled            *bool   `json:"enabled"`
+	CustomErrorMessage *string `json:"custom_error_message"`
+}
+
+func (p FeatureFlagUpdate) ToMessage(name string) repositories.UpdateFeatureFlagMessage {
+	return repositories.UpdateFeatureFlagMessage{
+		Name:               name,
+		Enabled:            p.Enabled,
+		CustomErrorMessage: p.CustomErrorMessage,
+	}
+}
+
+type SpaceFeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p SpaceFeatureUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
+
+func (p SpaceFeatureUpdate) ToMessage(spaceGUID, name string) repositories.UpdateSpaceFeatureMessage {
+	return repositories.UpdateSpaceFeatureMessage{
+		SpaceGUID: spaceGUID,
+		Name:      name,
+		Enabled:   *p.Enabled,
+	}
+}
@@ -0,0 +1,100 @@
+package payloads_test
+
+import (
+	"bytes"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlagUpdate", func() {
+	var (
+		body           string
+		decodedPayload *payloads.FeatureFlagUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		body = `{"enabled": false, "custom_error_message": "not today"}`
+	})
+
+	JustBeforeEach(func() {
+		decodedPayload = new(payloads.FeatureFlagUpdate)
+		req, err := http.NewRequest("", "", bytes.NewReader([]byte(body)))
+		Expect(err).NotTo(HaveOccurred())
+		validatorErr = validator.DecodeAndValidateJSONPayload(req, decodedPayload)
+	})
+
+	It("converts the payload to a message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("diego_docker")).To(Equal(repositories.UpdateFeatureFlagMessage{
+			Name:               "diego_docker",
+			Enabled:            tools.PtrTo(false),
+			CustomErrorMessage: tools.PtrTo("not today"),
+		}))
+	})
+
+	When("only the custom error message is set", func() {
+		BeforeEach(func() {
+			body = `{"custom_error_message": "not today"}`
+		})
+
+		It("leaves enabled unset", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.Enabled).To(BeNil())
+		})
+	})
+
+	When("the payload contains unknown fields", func() {
+		BeforeEach(func() {
+			body = `{"foo": "bar"}`
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("SpaceFeatureUpdate", func() {
+	var (
+		body           string
+		decodedPayload *payloads.SpaceFeatureUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		body = `{"enabled": false}`
+	})
+
+	JustBeforeEach(func() {
+		decodedPayload = new(payloads.SpaceFeatureUpdate)
+		req, err := http.NewRequest("", "", bytes.NewReader([]byte(body)))
+		Expect(err).NotTo(HaveOccurred())
+		validatorErr = validator.DecodeAndValidateJSONPayload(req, decodedPayload)
+	})
+
+	It("converts the payload to a message", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("space-guid", "ssh")).To(Equal(repositories.UpdateSpaceFeatureMessage{
+			SpaceGUID: "space-guid",
+			Name:      "ssh",
+			Enabled:   false,
+		}))
+	})
+
+	When("enabled is missing", func() {
+		BeforeEach(func() {
+			body = `{}`
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+})
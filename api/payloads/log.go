@@ -1,16 +1,25 @@
 package payloads
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"net/url"
+	"regexp"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	jellidation "github.com/jellydator/validation"
 )
 
+// LogRead is the query of the log-cache read endpoint. The end time and the
+// name filter only apply to gauge envelopes.
 type LogRead struct {
 	StartTime     *int64
+	EndTime       *int64
 	EnvelopeTypes []string
+	NameFilter    string
 	Limit         *int64
 	Descending    bool
 }
@@ -18,13 +27,14 @@ type LogRead struct {
 func (l LogRead) Validate() error {
 	return jellidation.ValidateStruct(&l,
 		jellidation.Field(&l.EnvelopeTypes,
-			jellidation.Each(validation.OneOf("LOG")),
+			jellidation.Each(validation.OneOf("LOG", "GAUGE")),
 		),
+		jellidation.Field(&l.NameFilter, jellidation.By(validRegexp)),
 	)
 }
 
 func (l *LogRead) SupportedKeys() []string {
-	return []string{"start_time", "end_time", "envelope_types", "limit", "descending"}
+	return []string{"start_time", "end_time", "envelope_types", "name_filter", "limit", "descending"}
 }
 
 func (l *LogRead) DecodeFromURLValues(values url.Values) error {
@@ -32,7 +42,11 @@ func (l *LogRead) DecodeFromURLValues(values url.Values) error {
 	if l.StartTime, err = getIntPtr(values, "start_time"); err != nil {
 		return err
 	}
+	if l.EndTime, err = getIntPtr(values, "end_time"); err != nil {
+		return err
+	}
 	l.EnvelopeTypes = values["envelope_types"]
+	l.NameFilter = values.Get("name_filter")
 	if l.Limit, err = getIntPtr(values, "limit"); err != nil {
 		return err
 	}
@@ -42,6 +56,169 @@ func (l *LogRead) DecodeFromURLValues(values url.Values) error {
 	return nil
 }
 
+// ReadsEnvelopeType returns whether envelopes of the given type are requested.
+// Only log envelopes are read by default.
+func (l LogRead) ReadsEnvelopeType(envelopeType string) bool {
+	if len(l.EnvelopeTypes) == 0 {
+		return envelopeType == "LOG"
+	}
+
+	for _, t := range l.EnvelopeTypes {
+		if t == envelopeType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validRegexp(value any) error {
+	if _, err := regexp.Compile(value.(string)); err != nil {
+		return errors.New("must be a valid regular expression")
+	}
+
+	return nil
+}
+
+var (
+	promQLSelectorRegexp = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(?:\{(.*)\})?\s*$`)
+	promQLMatchersRegexp = regexp.MustCompile(`^\s*(?:[a-zA-Z_][a-zA-Z0-9_]*\s*=\s*"[^"]*"\s*(?:,\s*[a-zA-Z_][a-zA-Z0-9_]*\s*=\s*"[^"]*"\s*)*,?\s*)?$`)
+	promQLMatcherRegexp  = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*"([^"]*)"`)
+)
+
+// PromQLSelector is an instant vector selector with equality label matchers,
+// e.g. `memory{source_id="app-guid",instance_id="0"}`
+type PromQLSelector struct {
+	Metric string
+	Labels map[string]string
+}
+
+func (s PromQLSelector) SourceID() string {
+	return s.Labels["source_id"]
+}
+
+// Matches returns whether all the label matchers match the labels. As in
+// PromQL, missing labels match empty values.
+func (s PromQLSelector) Matches(labels map[string]string) bool {
+	for name, value := range s.Labels {
+		if labels[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parsePromQLSelector(query string) (PromQLSelector, error) {
+	selectorMatch := promQLSelectorRegexp.FindStringSubmatch(query)
+	if selectorMatch == nil || !promQLMatchersRegexp.MatchString(selectorMatch[2]) {
+		return PromQLSelector{}, errors.New("only instant vector selectors with equality label matchers are supported")
+	}
+
+	selector := PromQLSelector{
+		Metric: selectorMatch[1],
+		Labels: map[string]string{},
+	}
+	for _, matcher := range promQLMatcherRegexp.FindAllStringSubmatch(selectorMatch[2], -1) {
+		selector.Labels[matcher[1]] = matcher[2]
+	}
+
+	if selector.SourceID() == "" {
+		return PromQLSelector{}, errors.New("the selector must match a source_id label")
+	}
+
+	return selector, nil
+}
+
+func validPromQLSelector(value any) error {
+	_, err := parsePromQLSelector(value.(string))
+	return err
+}
+
+// LogCachePromQL is an instant query of the log-cache PromQL endpoint. Only
+// the current usage of app instances is available, so it is returned whatever
+// the requested time.
+type LogCachePromQL struct {
+	Query    string
+	Selector PromQLSelector
+}
+
+func (l LogCachePromQL) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Query, jellidation.Required, jellidation.By(validPromQLSelector)),
+	)
+}
+
+func (l *LogCachePromQL) SupportedKeys() []string {
+	return []string{"query", "time"}
+}
+
+func (l *LogCachePromQL) DecodeFromURLValues(values url.Values) error {
+	l.Query = values.Get("query")
+	l.Selector, _ = parsePromQLSelector(l.Query)
+	return nil
+}
+
+// LogCachePromQLRange is a range query of the log-cache PromQL endpoint. As
+// only the current usage of app instances is available, the result contains
+// at most one sample per instance.
+type LogCachePromQLRange struct {
+	Query    string
+	Selector PromQLSelector
+	Start    *time.Time
+	End      *time.Time
+}
+
+func (l LogCachePromQLRange) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Query, jellidation.Required, jellidation.By(validPromQLSelector)),
+		jellidation.Field(&l.Start, jellidation.Required),
+		jellidation.Field(&l.End, jellidation.Required),
+	)
+}
+
+func (l *LogCachePromQLRange) SupportedKeys() []string {
+	return []string{"query", "start", "end", "step"}
+}
+
+func (l *LogCachePromQLRange) DecodeFromURLValues(values url.Values) error {
+	var err error
+	l.Query = values.Get("query")
+	l.Selector, _ = parsePromQLSelector(l.Query)
+	if l.Start, err = getTimePtr(values, "start"); err != nil {
+		return err
+	}
+	if l.End, err = getTimePtr(values, "end"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// LogStreamRead is the query of the RLP gateway read endpoint. Only log
+// envelopes are supported, the other envelope type selectors are accepted for
+// compatibility but ignored.
+type LogStreamRead struct {
+	SourceID string
+	Log      bool
+}
+
+func (l LogStreamRead) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.SourceID, jellidation.Required),
+		jellidation.Field(&l.Log, jellidation.Required.Error("only log envelopes are supported")),
+	)
+}
+
+func (l *LogStreamRead) SupportedKeys() []string {
+	return []string{"source_id", "shard_id", "deterministic_name", "log", "counter", "gauge", "timer", "event"}
+}
+
+func (l *LogStreamRead) DecodeFromURLValues(values url.Values) error {
+	l.SourceID = values.Get("source_id")
+	l.Log = values.Has("log")
+	return nil
+}
+
 func getIntPtr(values url.Values, key string) (*int64, error) {
 	if !values.Has(key) {
 		return nil, nil
@@ -51,6 +228,28 @@ func getIntPtr(values url.Values, key string) (*int64, error) {
 	return &result, err
 }
 
+// getTimePtr parses PromQL API times, which are either unix timestamps with
+// optional decimal places or RFC3339 timestamps
+func getTimePtr(values url.Values, key string) (*time.Time, error) {
+	if !values.Has(key) {
+		return nil, nil
+	}
+
+	value := values.Get(key)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		wholeSeconds, fraction := math.Modf(seconds)
+		result := time.Unix(int64(wholeSeconds), int64(fraction*1e9)).UTC()
+		return &result, nil
+	}
+
+	result, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a unix or RFC3339 timestamp", key)
+	}
+
+	return &result, nil
+}
+
 func getBool(values url.Values, key string) (bool, error) {
 	if !values.Has(key) {
 		return false, nil
@@ -1,6 +1,8 @@
 package payloads_test
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -26,6 +28,11 @@ var _ = Describe("LogRead", func() {
 			Entry("empty descending", "descending=", payloads.LogRead{}),
 
 			Entry("envelope type LOG", "envelope_types=LOG", payloads.LogRead{EnvelopeTypes: []string{"LOG"}}),
+			Entry("envelope type GAUGE", "envelope_types=GAUGE", payloads.LogRead{EnvelopeTypes: []string{"GAUGE"}}),
+			Entry("gauge filters", "end_time=789&name_filter=memory|cpu", payloads.LogRead{
+				EndTime:    tools.PtrTo[int64](789),
+				NameFilter: "memory|cpu",
+			}),
 		)
 
 		DescribeTable("invalid query",
@@ -37,6 +44,125 @@ var _ = Describe("LogRead", func() {
 			Entry("invalid limit", "limit=foo", "invalid syntax"),
 			Entry("invalid descending", "descending=foo", "invalid syntax"),
 			Entry("invalid envelope type", "envelope_types=foo", "value must be one of"),
+			Entry("invalid end_time", "end_time=foo", "invalid syntax"),
+			Entry("invalid name_filter", "name_filter=(", "must be a valid regular expression"),
 		)
 	})
+
+	DescribeTable("ReadsEnvelopeType",
+		func(query string, envelopeType string, expected bool) {
+			logRead, decodeErr := decodeQuery[payloads.LogRead](query)
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(logRead.ReadsEnvelopeType(envelopeType)).To(Equal(expected))
+		},
+		Entry("logs by default", "", "LOG", true),
+		Entry("no gauges by default", "", "GAUGE", false),
+		Entry("requested type", "envelope_types=GAUGE", "GAUGE", true),
+		Entry("not requested type", "envelope_types=GAUGE", "LOG", false),
+		Entry("several types", "envelope_types=GAUGE&envelope_types=LOG", "LOG", true),
+	)
+})
+
+var _ = Describe("LogStreamRead", func() {
+	DescribeTable("valid query",
+		func(query string, expectedLogStreamRead payloads.LogStreamRead) {
+			actualLogStreamRead, decodeErr := decodeQuery[payloads.LogStreamRead](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualLogStreamRead).To(Equal(expectedLogStreamRead))
+		},
+		Entry("log envelopes", "source_id=app-guid&log", payloads.LogStreamRead{SourceID: "app-guid", Log: true}),
+		Entry("other selectors", "source_id=app-guid&shard_id=shard&deterministic_name=name&log&counter&gauge&timer&event", payloads.LogStreamRead{SourceID: "app-guid", Log: true}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.LogStreamRead](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing source_id", "log", "cannot be blank"),
+		Entry("missing log selector", "source_id=app-guid&counter", "only log envelopes are supported"),
+	)
+})
+
+var _ = Describe("LogCachePromQL", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSelector payloads.PromQLSelector) {
+			actualPromQL, decodeErr := decodeQuery[payloads.LogCachePromQL](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(actualPromQL.Selector).To(Equal(expectedSelector))
+		},
+		Entry("source_id matcher", `query=memory{source_id="app-guid"}`, payloads.PromQLSelector{
+			Metric: "memory",
+			Labels: map[string]string{"source_id": "app-guid"},
+		}),
+		Entry("several matchers", `query= cpu { source_id = "app-guid", instance_id="1", } &time=123`, payloads.PromQLSelector{
+			Metric: "cpu",
+			Labels: map[string]string{"source_id": "app-guid", "instance_id": "1"},
+		}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.LogCachePromQL](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing query", "", "cannot be blank"),
+		Entry("missing source_id", `query=memory{instance_id="1"}`, "must match a source_id"),
+		Entry("no matchers", `query=memory`, "must match a source_id"),
+		Entry("regexp matchers", `query=memory{source_id=~"app-.*"}`, "only instant vector selectors"),
+		Entry("aggregations", `query=avg(memory{source_id="app-guid"})`, "only instant vector selectors"),
+	)
+
+	Describe("PromQLSelector", func() {
+		var selector payloads.PromQLSelector
+
+		BeforeEach(func() {
+			selector = payloads.PromQLSelector{
+				Metric: "memory",
+				Labels: map[string]string{"source_id": "app-guid", "process_type": ""},
+			}
+		})
+
+		It("returns the source id", func() {
+			Expect(selector.SourceID()).To(Equal("app-guid"))
+		})
+
+		It("matches labels matching all matchers", func() {
+			Expect(selector.Matches(map[string]string{"source_id": "app-guid", "instance_id": "0"})).To(BeTrue())
+		})
+
+		It("does not match labels that do not match all matchers", func() {
+			Expect(selector.Matches(map[string]string{"source_id": "app-guid", "process_type": "web"})).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("LogCachePromQLRange", func() {
+	DescribeTable("valid query",
+		func(query string, expectedStart, expectedEnd time.Time) {
+			actualPromQLRange, decodeErr := decodeQuery[payloads.LogCachePromQLRange](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(actualPromQLRange.Selector.Metric).To(Equal("cpu"))
+			Expect(*actualPromQLRange.Start).To(BeTemporally("==", expectedStart))
+			Expect(*actualPromQLRange.End).To(BeTemporally("==", expectedEnd))
+		},
+		Entry("unix timestamps", `query=cpu{source_id="app-guid"}&start=100&end=200.5&step=10s`, time.Unix(100, 0), time.Unix(200, 500000000)),
+		Entry("RFC3339 timestamps", `query=cpu{source_id="app-guid"}&start=2024-01-01T00:00:00Z&end=2024-01-01T00:01:00Z`,
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.LogCachePromQLRange](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing query", "start=1&end=2", "cannot be blank"),
+		Entry("invalid query", `query=sum(cpu)&start=1&end=2`, "only instant vector selectors"),
+		Entry("missing start", `query=cpu{source_id="app-guid"}&end=2`, "cannot be blank"),
+		Entry("missing end", `query=cpu{source_id="app-guid"}&start=1`, "cannot be blank"),
+		Entry("invalid start", `query=cpu{source_id="app-guid"}&start=yesterday&end=2`, "start must be a unix or RFC3339 timestamp"),
+	)
 })
@@ -37,6 +37,7 @@ type ManifestApplication struct {
 	HealthCheckHTTPEndpoint      *string                      `yaml:"health-check-http-endpoint"`
 	HealthCheckInvocationTimeout *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
 	HealthCheckType              *string                      `json:"health-check-type" yaml:"health-check-type"`
+	LogRateLimitPerSecond        *string                      `json:"log-rate-limit-per-second" yaml:"log-rate-limit-per-second"`
 	Timeout                      *int32                       `json:"timeout" yaml:"timeout"`
 	Processes                    []ManifestApplicationProcess `json:"processes" yaml:"processes"`
 	Routes                       []ManifestRoute              `json:"routes" yaml:"routes"`
@@ -63,6 +64,7 @@ type ManifestApplicationProcess struct {
 	HealthCheckInvocationTimeout *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
 	HealthCheckType              *string `json:"health-check-type" yaml:"health-check-type"`
 	Instances                    *int32  `json:"instances" yaml:"instances"`
+	LogRateLimitPerSecond        *string `json:"log-rate-limit-per-second" yaml:"log-rate-limit-per-second"`
 	Memory                       *string `json:"memory" yaml:"memory"`
 	Timeout                      *int32  `json:"timeout" yaml:"timeout"`
 }
@@ -179,6 +181,10 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 		msg.DiskQuotaMB = parseMegabytes(*p.DiskQuota)
 	}
 
+	if p.LogRateLimitPerSecond != nil {
+		msg.LogRateLimitInBytesPerSecond = tools.PtrTo(parseLogRateLimit(*p.LogRateLimitPerSecond))
+	}
+
 	return msg
 }
 
@@ -204,6 +210,9 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	if p.Memory != nil {
 		message.MemoryMB = tools.PtrTo(parseMegabytes(*p.Memory))
 	}
+	if p.LogRateLimitPerSecond != nil {
+		message.LogRateLimitInBytesPerSecond = tools.PtrTo(parseLogRateLimit(*p.LogRateLimitPerSecond))
+	}
 	return message
 }
 
@@ -221,6 +230,7 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.Instances, validation.Min(0)),
 		validation.Field(&a.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.HealthCheckType, validation.In("none", "process", "port", "http")),
+		validation.Field(&a.LogRateLimitPerSecond, validation.By(validateLogRateLimit)),
 		validation.Field(&a.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
@@ -239,6 +249,7 @@ func (p ManifestApplicationProcess) Validate() error {
 		validation.Field(&p.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&p.HealthCheckType, validation.In("none", "process", "port", "http")),
 		validation.Field(&p.Instances, validation.Min(0)),
+		validation.Field(&p.LogRateLimitPerSecond, validation.By(validateLogRateLimit)),
 		validation.Field(&p.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&p.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 	)
@@ -280,6 +291,33 @@ func validateAmountWithUnit(value any) error {
 	return nil
 }
 
+var logRateLimitAmount = regexp.MustCompile(`^(?:-1|\d+(?:\.\d+)?(?:B|K|KB|M|m|MB|mb|G|g|GB|gb|T|t|TB|tb))$`)
+
+func validateLogRateLimit(value any) error {
+	v, isNil := validation.Indirect(value)
+	if isNil {
+		return nil
+	}
+
+	if !logRateLimitAmount.MatchString(v.(string)) {
+		return errors.New("must be -1 or use a supported unit (B, K, KB, M, m, MB, mb, G, g, GB, gb, T, t, TB or tb)")
+	}
+
+	return nil
+}
+
+// parseLogRateLimit returns the log rate limit in bytes per second, -1 meaning
+// unlimited
+func parseLogRateLimit(s string) int64 {
+	if s == "-1" {
+		return -1
+	}
+
+	// error intentionally ignored as the manifest is validated beforehand
+	bytes, _ := bytefmt.ToBytes(s)
+	return int64(bytes) // #nosec G115
+}
+
 func parseMegabytes(s string) int64 {
 	// error intentinally ignored as the manifesst is validated beforehand
 	mb, _ := bytefmt.ToMegabytes(s)
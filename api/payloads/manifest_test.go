@@ -224,6 +224,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("the log rate limit doesn't supply a unit", func() {
+				BeforeEach(func() {
+					testManifest.LogRateLimitPerSecond = tools.PtrTo("1024")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "log-rate-limit-per-second must be -1 or use a supported unit (B, K, KB, M, m, MB, mb, G, g, GB, gb, T, t, TB or tb)")
+				})
+			})
+
+			When("the log rate limit is unlimited", func() {
+				BeforeEach(func() {
+					testManifest.LogRateLimitPerSecond = tools.PtrTo("-1")
+				})
+
+				It("does not return a validation error", func() {
+					Expect(validateErr).NotTo(HaveOccurred())
+				})
+			})
+
 			When("random-route and default-route flags are both set", func() {
 				BeforeEach(func() {
 					testManifest.DefaultRoute = true
@@ -557,6 +577,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("the log rate limit doesn't supply a unit", func() {
+				BeforeEach(func() {
+					testManifestProcess.LogRateLimitPerSecond = tools.PtrTo("1024")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "log-rate-limit-per-second must be -1 or use a supported unit (B, K, KB, M, m, MB, mb, G, g, GB, gb, T, t, TB or tb)")
+				})
+			})
+
+			When("the log rate limit is a floating point number", func() {
+				BeforeEach(func() {
+					testManifestProcess.LogRateLimitPerSecond = tools.PtrTo("1.5K")
+				})
+
+				It("does not return a validation error", func() {
+					Expect(validateErr).NotTo(HaveOccurred())
+				})
+			})
+
 			When("Timeout is not positive", func() {
 				BeforeEach(func() {
 					testManifestProcess.Timeout = tools.PtrTo(int32(0))
@@ -583,6 +623,7 @@ var _ = Describe("Manifest payload", func() {
 						HealthCheckType:              tools.PtrTo("http"),
 						Instances:                    tools.PtrTo[int32](3),
 						Memory:                       tools.PtrTo("1G"),
+						LogRateLimitPerSecond:        tools.PtrTo("16K"),
 						Timeout:                      tools.PtrTo(int32(60)),
 					}
 				})
@@ -604,8 +645,9 @@ var _ = Describe("Manifest payload", func() {
 								InvocationTimeoutSeconds: 90,
 							},
 						},
-						DesiredInstances: tools.PtrTo[int32](3),
-						MemoryMB:         1024,
+						DesiredInstances:             tools.PtrTo[int32](3),
+						MemoryMB:                     1024,
+						LogRateLimitInBytesPerSecond: tools.PtrTo[int64](16384),
 					}))
 				})
 
@@ -732,6 +774,30 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("LogRateLimitPerSecond is specified", func() {
+				BeforeEach(func() {
+					processInfo.LogRateLimitPerSecond = tools.PtrTo("1M")
+				})
+
+				It("returns a message with LogRateLimitInBytesPerSecond set to the parsed value", func() {
+					Expect(
+						processInfo.ToProcessPatchMessage(processGUID, spaceGUID).LogRateLimitInBytesPerSecond,
+					).To(PointTo(BeEquivalentTo(1024 * 1024)))
+				})
+			})
+
+			When("LogRateLimitPerSecond is unlimited", func() {
+				BeforeEach(func() {
+					processInfo.LogRateLimitPerSecond = tools.PtrTo("-1")
+				})
+
+				It("returns a message with LogRateLimitInBytesPerSecond set to -1", func() {
+					Expect(
+						processInfo.ToProcessPatchMessage(processGUID, spaceGUID).LogRateLimitInBytesPerSecond,
+					).To(PointTo(BeEquivalentTo(-1)))
+				})
+			})
+
 			When("Instances is specified", func() {
 				BeforeEach(func() {
 					processInfo.Instances = tools.PtrTo[int32](3)
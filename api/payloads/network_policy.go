@@ -0,0 +1,120 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	jellidation "github.com/jellydator/validation"
+)
+
+// NetworkPolicies follows the shape of the policy-server API used by the
+// cf CLI network policy commands
+type NetworkPolicies struct {
+	Policies []NetworkPolicy `json:"policies"`
+}
+
+func (p NetworkPolicies) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Policies, jellidation.Required),
+	)
+}
+
+func (p NetworkPolicies) ToCreateMessage() repositories.CreateNetworkPoliciesMessage {
+	return repositories.CreateNetworkPoliciesMessage{
+		Policies: p.toRecords(),
+	}
+}
+
+func (p NetworkPolicies) ToDeleteMessage() repositories.DeleteNetworkPoliciesMessage {
+	return repositories.DeleteNetworkPoliciesMessage{
+		Policies: p.toRecords(),
+	}
+}
+
+func (p NetworkPolicies) toRecords() []repositories.NetworkPolicyRecord {
+	records := []repositories.NetworkPolicyRecord{}
+	for _, policy := range p.Policies {
+		records = append(records, repositories.NetworkPolicyRecord{
+			SourceAppGUID:      policy.Source.ID,
+			DestinationAppGUID: policy.Destination.ID,
+			Protocol:           policy.Destination.Protocol,
+			StartPort:          policy.Destination.Ports.Start,
+			EndPort:            policy.Destination.Ports.End,
+		})
+	}
+
+	return records
+}
+
+type NetworkPolicy struct {
+	Source      NetworkPolicySource      `json:"source"`
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+func (p NetworkPolicy) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Source),
+		jellidation.Field(&p.Destination),
+	)
+}
+
+type NetworkPolicySource struct {
+	ID string `json:"id"`
+}
+
+func (s NetworkPolicySource) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.ID, validation.StrictlyRequired),
+	)
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id"`
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+func (d NetworkPolicyDestination) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.ID, validation.StrictlyRequired),
+		jellidation.Field(&d.Protocol, jellidation.Required, validation.OneOf(
+			korifiv1alpha1.NetworkPolicyProtocolTCP,
+			korifiv1alpha1.NetworkPolicyProtocolUDP,
+		)),
+		jellidation.Field(&d.Ports),
+	)
+}
+
+type NetworkPolicyPorts struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+func (p NetworkPolicyPorts) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Start, jellidation.Required, jellidation.Min(int32(1)), jellidation.Max(int32(65535))),
+		jellidation.Field(&p.End, jellidation.Required, jellidation.Min(p.Start).Error("must be greater than or equal to the start port"), jellidation.Max(int32(65535))),
+	)
+}
+
+type NetworkPolicyList struct {
+	IDs string
+}
+
+func (l *NetworkPolicyList) SupportedKeys() []string {
+	return []string{"id"}
+}
+
+func (l *NetworkPolicyList) DecodeFromURLValues(values url.Values) error {
+	l.IDs = values.Get("id")
+	return nil
+}
+
+func (l *NetworkPolicyList) ToMessage() repositories.ListNetworkPoliciesMessage {
+	return repositories.ListNetworkPoliciesMessage{
+		AppGUIDs: parse.ArrayParam(l.IDs),
+	}
+}
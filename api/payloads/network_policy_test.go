@@ -0,0 +1,150 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicies", func() {
+	var (
+		policiesPayload payloads.NetworkPolicies
+		decodedPayload  *payloads.NetworkPolicies
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.NetworkPolicies)
+		policiesPayload = payloads.NetworkPolicies{
+			Policies: []payloads.NetworkPolicy{{
+				Source: payloads.NetworkPolicySource{ID: "app-1"},
+				Destination: payloads.NetworkPolicyDestination{
+					ID:       "app-2",
+					Protocol: "tcp",
+					Ports:    payloads.NetworkPolicyPorts{Start: 8080, End: 8090},
+				},
+			}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(policiesPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(policiesPayload)))
+	})
+
+	When("there are no policies", func() {
+		BeforeEach(func() {
+			policiesPayload.Policies = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "policies cannot be blank")
+		})
+	})
+
+	When("the source id is empty", func() {
+		BeforeEach(func() {
+			policiesPayload.Policies[0].Source.ID = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "id cannot be blank")
+		})
+	})
+
+	When("the destination id is empty", func() {
+		BeforeEach(func() {
+			policiesPayload.Policies[0].Destination.ID = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "id cannot be blank")
+		})
+	})
+
+	When("the protocol is invalid", func() {
+		BeforeEach(func() {
+			policiesPayload.Policies[0].Destination.Protocol = "icmp"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "protocol value must be one of")
+		})
+	})
+
+	When("the start port is out of range", func() {
+		BeforeEach(func() {
+			policiesPayload.Policies[0].Destination.Ports.Start = 70000
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "start must be no greater than 65535")
+		})
+	})
+
+	When("the end port is lower than the start port", func() {
+		BeforeEach(func() {
+			policiesPayload.Policies[0].Destination.Ports.End = 8000
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "end must be greater than or equal to the start port")
+		})
+	})
+
+	Describe("ToCreateMessage", func() {
+		It("converts the payload to a message", func() {
+			Expect(policiesPayload.ToCreateMessage()).To(Equal(repositories.CreateNetworkPoliciesMessage{
+				Policies: []repositories.NetworkPolicyRecord{{
+					SourceAppGUID:      "app-1",
+					DestinationAppGUID: "app-2",
+					Protocol:           "tcp",
+					StartPort:          8080,
+					EndPort:            8090,
+				}},
+			}))
+		})
+	})
+
+	Describe("ToDeleteMessage", func() {
+		It("converts the payload to a message", func() {
+			Expect(policiesPayload.ToDeleteMessage()).To(Equal(repositories.DeleteNetworkPoliciesMessage{
+				Policies: []repositories.NetworkPolicyRecord{{
+					SourceAppGUID:      "app-1",
+					DestinationAppGUID: "app-2",
+					Protocol:           "tcp",
+					StartPort:          8080,
+					EndPort:            8090,
+				}},
+			}))
+		})
+	})
+})
+
+var _ = Describe("NetworkPolicyList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedNetworkPolicyList payloads.NetworkPolicyList) {
+			actualNetworkPolicyList, decodeErr := decodeQuery[payloads.NetworkPolicyList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualNetworkPolicyList).To(Equal(expectedNetworkPolicyList))
+		},
+		Entry("id", "id=a1,a2", payloads.NetworkPolicyList{IDs: "a1,a2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.NetworkPolicyList{IDs: "a1,a2"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListNetworkPoliciesMessage{
+				AppGUIDs: []string{"a1", "a2"},
+			}))
+		})
+	})
+})
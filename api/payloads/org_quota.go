@@ -0,0 +1,118 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	jellidation "github.com/jellydator/validation"
+)
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+func (r OrgQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organizations),
+	)
+}
+
+type OrgQuotaCreate struct {
+	quotas.OrgQuota
+	Relationships *OrgQuotaRelationships `json:"relationships"`
+}
+
+// NewOrgQuotaCreate returns a payload populated with the CF defaults for the
+// quota fields that are not nullable
+func NewOrgQuotaCreate() OrgQuotaCreate {
+	return OrgQuotaCreate{
+		OrgQuota: quotas.OrgQuota{
+			Services: quotas.ServiceQuotas{PaidServicesAllowed: true},
+		},
+	}
+}
+
+func (c OrgQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Apps, validAppQuotas),
+		jellidation.Field(&c.Services, validServiceQuotas),
+		jellidation.Field(&c.Routes, validRouteQuotas),
+		jellidation.Field(&c.Domains, validDomainQuotas),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	message := repositories.CreateOrgQuotaMessage{
+		OrgQuota: c.OrgQuota,
+	}
+
+	if c.Relationships != nil {
+		message.Organizations = c.Relationships.Organizations.GUIDs()
+	}
+
+	return message
+}
+
+// OrgQuotaUpdate is decoded on top of the current quota so that only the
+// fields present in the request are changed
+type OrgQuotaUpdate struct {
+	quotas.OrgQuota
+}
+
+func (c OrgQuotaUpdate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Apps, validAppQuotas),
+		jellidation.Field(&c.Services, validServiceQuotas),
+		jellidation.Field(&c.Routes, validRouteQuotas),
+		jellidation.Field(&c.Domains, validDomainQuotas),
+	)
+}
+
+func (c OrgQuotaUpdate) ToMessage(guid string) repositories.UpdateOrgQuotaMessage {
+	return repositories.UpdateOrgQuotaMessage{
+		GUID:     guid,
+		OrgQuota: c.OrgQuota,
+	}
+}
+
+type OrgQuotaApply struct {
+	ToManyRelationship
+}
+
+func (a OrgQuotaApply) ToMessage(guid string) repositories.ApplyOrgQuotaMessage {
+	return repositories.ApplyOrgQuotaMessage{
+		GUID:          guid,
+		Organizations: a.GUIDs(),
+	}
+}
+
+type OrgQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "page", "per_page"}
+}
+
+func (l *OrgQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
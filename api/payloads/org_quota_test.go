@@ -0,0 +1,270 @@
+package payloads_test
+
+import (
+	"bytes"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuotaCreate", func() {
+	var (
+		createPayload  payloads.OrgQuotaCreate
+		decodedPayload *payloads.OrgQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaCreate)
+		createPayload = payloads.OrgQuotaCreate{
+			OrgQuota: quotas.OrgQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{
+					TotalMemoryInMB: tools.PtrTo[int64](1024),
+					PerAppTasks:     tools.PtrTo[int64](5),
+				},
+				Services: quotas.ServiceQuotas{
+					PaidServicesAllowed:   true,
+					TotalServiceInstances: tools.PtrTo[int64](10),
+				},
+				Routes: quotas.RouteQuotas{
+					TotalRoutes: tools.PtrTo[int64](8),
+				},
+			},
+			Relationships: &payloads.OrgQuotaRelationships{
+				Organizations: payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Apps.TotalMemoryInMB = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "apps.total_memory_in_mb must be no less than 0")
+		})
+	})
+
+	When("a log rate limit is set", func() {
+		BeforeEach(func() {
+			createPayload.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](1024)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.Apps.LogRateLimitInBytesPerSecond).To(gstruct.PointTo(BeEquivalentTo(1024)))
+		})
+	})
+
+	When("the log rate limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "apps.log_rate_limit_in_bytes_per_second must be no less than 0")
+		})
+	})
+
+	When("an organization relationship has no guid", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Organizations.Data = []payloads.RelationshipData{{}}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	Describe("NewOrgQuotaCreate", func() {
+		It("allows paid services by default", func() {
+			req, err := http.NewRequest("", "", bytes.NewReader([]byte(`{"name":"my-quota"}`)))
+			Expect(err).NotTo(HaveOccurred())
+
+			payload := payloads.NewOrgQuotaCreate()
+			Expect(validator.DecodeAndValidateJSONPayload(req, &payload)).To(Succeed())
+			Expect(payload.Services.PaidServicesAllowed).To(BeTrue())
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateOrgQuotaMessage{
+				OrgQuota:      createPayload.OrgQuota,
+				Organizations: []string{"org-guid"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaUpdate", func() {
+	var (
+		payload      payloads.OrgQuotaUpdate
+		requestBody  string
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.OrgQuotaUpdate{
+			OrgQuota: quotas.OrgQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{
+					TotalMemoryInMB: tools.PtrTo[int64](1024),
+					TotalInstances:  tools.PtrTo[int64](10),
+				},
+			},
+		}
+		requestBody = `{"apps": {"total_memory_in_mb": 2048, "total_instances": null}}`
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("", "", bytes.NewReader([]byte(requestBody)))
+		Expect(err).NotTo(HaveOccurred())
+		validatorErr = validator.DecodeAndValidateJSONPayload(req, &payload)
+	})
+
+	It("only changes the fields present in the request", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(payload.ToMessage("quota-guid")).To(Equal(repositories.UpdateOrgQuotaMessage{
+			GUID: "quota-guid",
+			OrgQuota: quotas.OrgQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{
+					TotalMemoryInMB: tools.PtrTo[int64](2048),
+				},
+			},
+		}))
+	})
+
+	When("the name is set to empty", func() {
+		BeforeEach(func() {
+			requestBody = `{"name": ""}`
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the log rate limit is set", func() {
+		BeforeEach(func() {
+			requestBody = `{"apps": {"log_rate_limit_in_bytes_per_second": 1024}}`
+		})
+
+		It("updates the log rate limit", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(payload.Apps.LogRateLimitInBytesPerSecond).To(gstruct.PointTo(BeEquivalentTo(1024)))
+		})
+	})
+
+	When("the log rate limit is set to null", func() {
+		BeforeEach(func() {
+			requestBody = `{"apps": {"log_rate_limit_in_bytes_per_second": null}}`
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaApply", func() {
+	var (
+		payload      payloads.OrgQuotaApply
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.OrgQuotaApply{
+			ToManyRelationship: payloads.ToManyRelationship{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		decoded := new(payloads.OrgQuotaApply)
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decoded)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(payload.ToMessage("quota-guid")).To(Equal(repositories.ApplyOrgQuotaMessage{
+			GUID:          "quota-guid",
+			Organizations: []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("data is missing", func() {
+		BeforeEach(func() {
+			payload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedOrgQuotaList payloads.OrgQuotaList) {
+			actualOrgQuotaList, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualOrgQuotaList).To(Equal(expectedOrgQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.OrgQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.OrgQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.OrgQuotaList{OrganizationGUIDs: "o1,o2"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.OrgQuotaList{GUIDs: "g1,g2", Names: "n1", OrganizationGUIDs: "o1"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:             []string{"g1", "g2"},
+				Names:             []string{"n1"},
+				OrganizationGUIDs: []string{"o1"},
+			}))
+		})
+	})
+})
@@ -48,6 +48,34 @@ func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 	return message
 }
 
+// PackageCopy is the payload of POST /v3/packages?source_guid=<guid>, which
+// copies the source package to the app in the relationships
+type PackageCopy struct {
+	Relationships *PackageRelationships `json:"relationships"`
+}
+
+func (c PackageCopy) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c PackageCopy) ToMessage(appRecord repositories.AppRecord, sourceRecord repositories.PackageRecord) repositories.CreatePackageMessage {
+	message := repositories.CreatePackageMessage{
+		Type:      sourceRecord.Type,
+		AppGUID:   appRecord.GUID,
+		SpaceGUID: appRecord.SpaceGUID,
+	}
+
+	if sourceRecord.Type == "docker" {
+		message.Data = &repositories.PackageData{
+			Image: sourceRecord.ImageRef,
+		}
+	}
+
+	return message
+}
+
 type PackageData struct {
 	Image    string  `json:"image"`
 	Username *string `json:"username"`
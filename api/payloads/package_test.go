@@ -236,6 +236,111 @@ var _ = Describe("PackageCreate", func() {
 	})
 })
 
+var _ = Describe("PackageCopy", func() {
+	var copyPayload payloads.PackageCopy
+
+	BeforeEach(func() {
+		copyPayload = payloads.PackageCopy{
+			Relationships: &payloads.PackageRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "some-guid",
+					},
+				},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			packageCopy  *payloads.PackageCopy
+			validatorErr error
+		)
+
+		BeforeEach(func() {
+			packageCopy = new(payloads.PackageCopy)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), packageCopy)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(packageCopy).To(gstruct.PointTo(Equal(copyPayload)))
+		})
+
+		When("relationships is not set", func() {
+			BeforeEach(func() {
+				copyPayload.Relationships = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships is required")
+			})
+		})
+
+		When("the app relationship is not set", func() {
+			BeforeEach(func() {
+				copyPayload.Relationships.App = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "app is required")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		var (
+			sourceRecord repositories.PackageRecord
+			copyMessage  repositories.CreatePackageMessage
+		)
+
+		BeforeEach(func() {
+			sourceRecord = repositories.PackageRecord{
+				Type:     "bits",
+				ImageRef: "my/repo",
+			}
+		})
+
+		JustBeforeEach(func() {
+			copyMessage = copyPayload.ToMessage(repositories.AppRecord{
+				GUID:      "guid",
+				SpaceGUID: "space-guid",
+			}, sourceRecord)
+		})
+
+		It("creates a message for a package of the source type", func() {
+			Expect(copyMessage).To(Equal(repositories.CreatePackageMessage{
+				Type:      "bits",
+				AppGUID:   "guid",
+				SpaceGUID: "space-guid",
+			}))
+		})
+
+		When("the source package type is docker", func() {
+			BeforeEach(func() {
+				sourceRecord = repositories.PackageRecord{
+					Type:     "docker",
+					ImageRef: "some/image",
+				}
+			})
+
+			It("copies the image", func() {
+				Expect(copyMessage).To(Equal(repositories.CreatePackageMessage{
+					Type:      "docker",
+					AppGUID:   "guid",
+					SpaceGUID: "space-guid",
+					Data: &repositories.PackageData{
+						Image: "some/image",
+					},
+				}))
+			})
+		})
+	})
+})
+
 var _ = Describe("PackageUpdate", func() {
 	var payload payloads.PackageUpdate
 
@@ -9,9 +9,10 @@ import (
 )
 
 type ProcessScale struct {
-	Instances *int32 `json:"instances"`
-	MemoryMB  *int64 `json:"memory_in_mb"`
-	DiskMB    *int64 `json:"disk_in_mb"`
+	Instances                    *int32 `json:"instances"`
+	MemoryMB                     *int64 `json:"memory_in_mb"`
+	DiskMB                       *int64 `json:"disk_in_mb"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
 }
 
 func (p ProcessScale) Validate() error {
@@ -19,6 +20,7 @@ func (p ProcessScale) Validate() error {
 		validation.Field(&p.Instances, validation.Min(0).Error("must be 0 or greater")),
 		validation.Field(&p.MemoryMB, validation.Min(1).Error("must be greater than 0")),
 		validation.Field(&p.DiskMB, validation.Min(1).Error("must be greater than 0")),
+		validation.Field(&p.LogRateLimitInBytesPerSecond, validation.Min(-1).Error("must be -1 or greater")),
 	)
 }
 
@@ -41,9 +43,10 @@ type Data struct {
 
 func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 	return repositories.ProcessScaleValues{
-		Instances: p.Instances,
-		MemoryMB:  p.MemoryMB,
-		DiskMB:    p.DiskMB,
+		Instances:                    p.Instances,
+		MemoryMB:                     p.MemoryMB,
+		DiskMB:                       p.DiskMB,
+		LogRateLimitInBytesPerSecond: p.LogRateLimitInBytesPerSecond,
 	}
 }
 
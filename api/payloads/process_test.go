@@ -37,9 +37,10 @@ var _ = Describe("Process payload validation", func() {
 
 		BeforeEach(func() {
 			payload = payloads.ProcessScale{
-				Instances: tools.PtrTo[int32](1),
-				MemoryMB:  tools.PtrTo[int64](2),
-				DiskMB:    tools.PtrTo[int64](3),
+				Instances:                    tools.PtrTo[int32](1),
+				MemoryMB:                     tools.PtrTo[int64](2),
+				DiskMB:                       tools.PtrTo[int64](3),
+				LogRateLimitInBytesPerSecond: tools.PtrTo[int64](4),
 			}
 
 			decodedPayload = new(payloads.ProcessScale)
@@ -83,5 +84,25 @@ var _ = Describe("Process payload validation", func() {
 				expectUnprocessableEntityError(validatorErr, "disk_in_mb must be greater than 0")
 			})
 		})
+
+		When("the log rate limit is unlimited", func() {
+			BeforeEach(func() {
+				payload.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](-1)
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the log rate limit is less than -1", func() {
+			BeforeEach(func() {
+				payload.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](-2)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "log_rate_limit_in_bytes_per_second must be -1 or greater")
+			})
+		})
 	})
 })
@@ -0,0 +1,42 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/model/quotas"
+	jellidation "github.com/jellydator/validation"
+)
+
+var (
+	validAppQuotas = jellidation.By(func(value any) error {
+		apps := value.(quotas.AppQuotas)
+		return jellidation.ValidateStruct(&apps,
+			jellidation.Field(&apps.TotalMemoryInMB, jellidation.Min(int64(0))),
+			jellidation.Field(&apps.PerProcessMemoryInMB, jellidation.Min(int64(0))),
+			jellidation.Field(&apps.LogRateLimitInBytesPerSecond, jellidation.Min(int64(0))),
+			jellidation.Field(&apps.TotalInstances, jellidation.Min(int64(0))),
+			jellidation.Field(&apps.PerAppTasks, jellidation.Min(int64(0))),
+		)
+	})
+
+	validServiceQuotas = jellidation.By(func(value any) error {
+		services := value.(quotas.ServiceQuotas)
+		return jellidation.ValidateStruct(&services,
+			jellidation.Field(&services.TotalServiceInstances, jellidation.Min(int64(0))),
+			jellidation.Field(&services.TotalServiceKeys, jellidation.Min(int64(0))),
+		)
+	})
+
+	validRouteQuotas = jellidation.By(func(value any) error {
+		routes := value.(quotas.RouteQuotas)
+		return jellidation.ValidateStruct(&routes,
+			jellidation.Field(&routes.TotalRoutes, jellidation.Min(int64(0))),
+			jellidation.Field(&routes.TotalReservedPorts, jellidation.Min(int64(0))),
+		)
+	})
+
+	validDomainQuotas = jellidation.By(func(value any) error {
+		domains := value.(quotas.DomainQuotas)
+		return jellidation.ValidateStruct(&domains,
+			jellidation.Field(&domains.TotalDomains, jellidation.Min(int64(0))),
+		)
+	})
+)
@@ -0,0 +1,109 @@
+package payloads
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	jellidation "github.com/jellydator/validation"
+)
+
+var (
+	sha1Regexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	modeRegexp = regexp.MustCompile(`^[0-7]{1,6}$`)
+)
+
+// ResourceMatches is the payload of POST /v3/resource_matches, listing the
+// files of an app that the client would like to skip uploading
+type ResourceMatches struct {
+	Resources []ResourceMatch `json:"resources"`
+}
+
+func (m ResourceMatches) Validate() error {
+	return jellidation.ValidateStruct(&m,
+		jellidation.Field(&m.Resources),
+	)
+}
+
+func (m ResourceMatches) ToMessage() []repositories.ResourceRecord {
+	return toResourceRecords(m.Resources)
+}
+
+type ResourceMatch struct {
+	Checksum    ResourceChecksum `json:"checksum"`
+	SizeInBytes int64            `json:"size_in_bytes"`
+	Path        string           `json:"path"`
+	Mode        string           `json:"mode"`
+}
+
+func (r ResourceMatch) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Checksum),
+		jellidation.Field(&r.SizeInBytes, jellidation.Min(int64(0))),
+		jellidation.Field(&r.Mode, jellidation.Match(modeRegexp).Error("must be an octal file mode")),
+	)
+}
+
+type ResourceChecksum struct {
+	Value string `json:"value"`
+}
+
+func (c ResourceChecksum) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Value, jellidation.Required, jellidation.Match(sha1Regexp).Error("must be a SHA1 checksum")),
+	)
+}
+
+// PackageUpload holds the form values of POST /v3/packages/:guid/upload
+// besides the bits. Resources lists the files of the package that were not
+// uploaded because they matched the resource cache.
+type PackageUpload struct {
+	Resources []ResourceMatch
+}
+
+func (p *PackageUpload) SupportedKeys() []string {
+	return []string{"resources"}
+}
+
+func (p *PackageUpload) DecodeFromURLValues(values url.Values) error {
+	resources := values.Get("resources")
+	if resources == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(resources), &p.Resources); err != nil {
+		return fmt.Errorf("failed to decode resources: %w", err)
+	}
+
+	return nil
+}
+
+func (p PackageUpload) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Resources, jellidation.Each(jellidation.By(func(value any) error {
+			resource, _ := value.(ResourceMatch)
+			return jellidation.Validate(resource.Path, jellidation.Required.Error("path cannot be blank"))
+		}))),
+	)
+}
+
+func (p PackageUpload) ToMessage() []repositories.ResourceRecord {
+	return toResourceRecords(p.Resources)
+}
+
+func toResourceRecords(resources []ResourceMatch) []repositories.ResourceRecord {
+	records := []repositories.ResourceRecord{}
+	for _, resource := range resources {
+		records = append(records, repositories.ResourceRecord{
+			SHA1:        resource.Checksum.Value,
+			SizeInBytes: resource.SizeInBytes,
+			Path:        resource.Path,
+			Mode:        resource.Mode,
+		})
+	}
+
+	return records
+}
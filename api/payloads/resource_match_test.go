@@ -0,0 +1,165 @@
+package payloads_test
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+const resourceSHA1 = "0123456789abcdef0123456789abcdef01234567"
+
+var _ = Describe("ResourceMatches", func() {
+	var (
+		payload        payloads.ResourceMatches
+		decodedPayload *payloads.ResourceMatches
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.ResourceMatches{
+			Resources: []payloads.ResourceMatch{{
+				Checksum:    payloads.ResourceChecksum{Value: resourceSHA1},
+				SizeInBytes: 42,
+				Path:        "path/to/file",
+				Mode:        "644",
+			}},
+		}
+		decodedPayload = new(payloads.ResourceMatches)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	When("the checksum is missing", func() {
+		BeforeEach(func() {
+			payload.Resources[0].Checksum.Value = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value cannot be blank")
+		})
+	})
+
+	When("the checksum is not a SHA1", func() {
+		BeforeEach(func() {
+			payload.Resources[0].Checksum.Value = "../../etc/passwd"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be a SHA1 checksum")
+		})
+	})
+
+	When("the size is negative", func() {
+		BeforeEach(func() {
+			payload.Resources[0].SizeInBytes = -1
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "size_in_bytes must be no less than 0")
+		})
+	})
+
+	When("the mode is not octal", func() {
+		BeforeEach(func() {
+			payload.Resources[0].Mode = "rw-r--r--"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be an octal file mode")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the resources to records", func() {
+			Expect(payload.ToMessage()).To(Equal([]repositories.ResourceRecord{{
+				SHA1:        resourceSHA1,
+				SizeInBytes: 42,
+				Path:        "path/to/file",
+				Mode:        "644",
+			}}))
+		})
+	})
+})
+
+var _ = Describe("PackageUpload", func() {
+	var (
+		values       url.Values
+		payload      *payloads.PackageUpload
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		values = url.Values{
+			"resources": []string{`[{"checksum": {"value": "` + resourceSHA1 + `"}, "size_in_bytes": 42, "path": "path/to/file", "mode": "0644"}]`},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		payload, err = decodeQuery[payloads.PackageUpload](values.Encode())
+		validatorErr = err
+	})
+
+	It("decodes the resources", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(payload.ToMessage()).To(Equal([]repositories.ResourceRecord{{
+			SHA1:        resourceSHA1,
+			SizeInBytes: 42,
+			Path:        "path/to/file",
+			Mode:        "0644",
+		}}))
+	})
+
+	When("there are no resources", func() {
+		BeforeEach(func() {
+			values = url.Values{}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(payload.ToMessage()).To(BeEmpty())
+		})
+	})
+
+	When("the resources are not valid JSON", func() {
+		BeforeEach(func() {
+			values.Set("resources", "not-json")
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("failed to decode resources")))
+		})
+	})
+
+	When("a resource has no path", func() {
+		BeforeEach(func() {
+			values.Set("resources", `[{"checksum": {"value": "`+resourceSHA1+`"}, "size_in_bytes": 42}]`)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "path cannot be blank")
+		})
+	})
+
+	When("a resource has an invalid checksum", func() {
+		BeforeEach(func() {
+			values.Set("resources", `[{"checksum": {"value": "foo"}, "size_in_bytes": 42, "path": "a"}]`)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be a SHA1 checksum")
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type RevisionList struct {
+	Versions string
+}
+
+func (r *RevisionList) ToMessage(appGUID string) repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		AppGUID:  appGUID,
+		Versions: parse.ArrayParam(r.Versions),
+	}
+}
+
+func (r *RevisionList) SupportedKeys() []string {
+	return []string{"versions", "per_page", "page"}
+}
+
+func (r *RevisionList) DecodeFromURLValues(values url.Values) error {
+	r.Versions = values.Get("versions")
+	return nil
+}
@@ -2,6 +2,7 @@ package payloads
 
 import (
 	"net/url"
+	"strconv"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
@@ -13,13 +14,14 @@ import (
 type RouteCreate struct {
 	Host          string              `json:"host"`
 	Path          string              `json:"path"`
+	Port          *int32              `json:"port"`
 	Relationships *RouteRelationships `json:"relationships"`
 	Metadata      Metadata            `json:"metadata"`
 }
 
 func (p RouteCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Host, jellidation.Required),
+		jellidation.Field(&p.Port, jellidation.Min(int32(1)), jellidation.Max(int32(65535))),
 		jellidation.Field(&p.Relationships, jellidation.NotNil),
 		jellidation.Field(&p.Metadata),
 	)
@@ -29,6 +31,7 @@ func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.
 	return repositories.CreateRouteMessage{
 		Host:            p.Host,
 		Path:            p.Path,
+		Port:            p.Port,
 		SpaceGUID:       p.Relationships.Space.Data.GUID,
 		DomainGUID:      p.Relationships.Domain.Data.GUID,
 		DomainNamespace: domainNamespace,
@@ -56,6 +59,7 @@ type RouteList struct {
 	DomainGUIDs string
 	Hosts       string
 	Paths       string
+	Ports       []int32
 }
 
 func (p RouteList) ToMessage() repositories.ListRoutesMessage {
@@ -65,11 +69,12 @@ func (p RouteList) ToMessage() repositories.ListRoutesMessage {
 		DomainGUIDs: parse.ArrayParam(p.DomainGUIDs),
 		Hosts:       parse.ArrayParam(p.Hosts),
 		Paths:       parse.ArrayParam(p.Paths),
+		Ports:       p.Ports,
 	}
 }
 
 func (p RouteList) SupportedKeys() []string {
-	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "per_page", "page"}
+	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "ports", "per_page", "page"}
 }
 
 func (p *RouteList) DecodeFromURLValues(values url.Values) error {
@@ -78,6 +83,16 @@ func (p *RouteList) DecodeFromURLValues(values url.Values) error {
 	p.DomainGUIDs = values.Get("domain_guids")
 	p.Hosts = values.Get("hosts")
 	p.Paths = values.Get("paths")
+
+	p.Ports = nil
+	for _, portStr := range parse.ArrayParam(values.Get("ports")) {
+		port, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return err
+		}
+		p.Ports = append(p.Ports, int32(port))
+	}
+
 	return nil
 }
 
@@ -121,7 +136,7 @@ type RouteDestination struct {
 func (r RouteDestination) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.App),
-		jellidation.Field(&r.Protocol, validation.OneOf("http1")),
+		jellidation.Field(&r.Protocol, validation.OneOf("http1", "tcp")),
 	)
 }
 
@@ -169,3 +184,27 @@ func (dc RouteDestinationCreate) ToMessage(routeRecord repositories.RouteRecord)
 		NewDestinations:      addDestinations,
 	}
 }
+
+type RouteShare struct {
+	ToManyRelationship
+}
+
+func (s RouteShare) ToMessage(routeRecord repositories.RouteRecord) repositories.ShareRouteMessage {
+	return repositories.ShareRouteMessage{
+		RouteGUID:        routeRecord.GUID,
+		SpaceGUID:        routeRecord.SpaceGUID,
+		SharedSpaceGUIDs: s.GUIDs(),
+	}
+}
+
+type RouteTransfer struct {
+	Relationship
+}
+
+func (t RouteTransfer) ToMessage(routeRecord repositories.RouteRecord) repositories.TransferRouteMessage {
+	return repositories.TransferRouteMessage{
+		RouteGUID:       routeRecord.GUID,
+		SpaceGUID:       routeRecord.SpaceGUID,
+		TargetSpaceGUID: t.Data.GUID,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -21,7 +22,7 @@ var _ = Describe("RouteList", func() {
 
 		BeforeEach(func() {
 			routeList = payloads.RouteList{}
-			params = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path"
+			params = "app_guids=app_guid&space_guids=space_guid&domain_guids=domain_guid&hosts=host&paths=path&ports=1024,1025"
 		})
 
 		JustBeforeEach(func() {
@@ -38,9 +39,20 @@ var _ = Describe("RouteList", func() {
 				DomainGUIDs: "domain_guid",
 				Hosts:       "host",
 				Paths:       "path",
+				Ports:       []int32{1024, 1025},
 			}))
 		})
 
+		When("a port is not a number", func() {
+			BeforeEach(func() {
+				params = "ports=abc"
+			})
+
+			It("fails", func() {
+				Expect(decodeErr).To(HaveOccurred())
+			})
+		})
+
 		When("it contains an invalid key", func() {
 			BeforeEach(func() {
 				params = "foo=bar"
@@ -95,14 +107,26 @@ var _ = Describe("RouteCreate", func() {
 		Expect(routeCreate).To(gstruct.PointTo(Equal(createPayload)))
 	})
 
-	When("host is blank", func() {
+	When("host is blank and a port is set", func() {
 		BeforeEach(func() {
 			createPayload.Host = ""
+			createPayload.Port = tools.PtrTo[int32](1024)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(routeCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+	})
+
+	When("the port is out of range", func() {
+		BeforeEach(func() {
+			createPayload.Port = tools.PtrTo[int32](70000)
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("host cannot be blank"))
+			Expect(apiError.Detail()).To(ContainSubstring("port must be no greater than 65535"))
 		})
 	})
 
@@ -254,14 +278,108 @@ var _ = Describe("Add destination", func() {
 		})
 	})
 
-	When("protocol is not http1", func() {
+	When("protocol is not http1 or tcp", func() {
 		BeforeEach(func() {
 			addPayload.Destinations[1].Protocol = tools.PtrTo("http")
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1"))
+			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1, tcp"))
 		})
 	})
 })
+
+var _ = Describe("RouteShare", func() {
+	var (
+		sharePayload payloads.RouteShare
+		routeShare   *payloads.RouteShare
+		validatorErr error
+		apiError     errors.ApiError
+	)
+
+	BeforeEach(func() {
+		routeShare = new(payloads.RouteShare)
+		sharePayload = payloads.RouteShare{
+			ToManyRelationship: payloads.ToManyRelationship{
+				Data: []payloads.RelationshipData{{GUID: "space-1-guid"}, {GUID: "space-2-guid"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), routeShare)
+		apiError, _ = validatorErr.(errors.ApiError)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(routeShare).To(gstruct.PointTo(Equal(sharePayload)))
+	})
+
+	When("a space guid is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data[1].GUID = ""
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("guid cannot be blank"))
+		})
+	})
+
+	It("converts to a message", func() {
+		Expect(sharePayload.ToMessage(repositories.RouteRecord{GUID: "route-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.ShareRouteMessage{
+			RouteGUID:        "route-guid",
+			SpaceGUID:        "space-guid",
+			SharedSpaceGUIDs: []string{"space-1-guid", "space-2-guid"},
+		}))
+	})
+})
+
+var _ = Describe("RouteTransfer", func() {
+	var (
+		transferPayload payloads.RouteTransfer
+		routeTransfer   *payloads.RouteTransfer
+		validatorErr    error
+		apiError        errors.ApiError
+	)
+
+	BeforeEach(func() {
+		routeTransfer = new(payloads.RouteTransfer)
+		transferPayload = payloads.RouteTransfer{
+			Relationship: payloads.Relationship{
+				Data: &payloads.RelationshipData{GUID: "target-space-guid"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(transferPayload), routeTransfer)
+		apiError, _ = validatorErr.(errors.ApiError)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(routeTransfer).To(gstruct.PointTo(Equal(transferPayload)))
+	})
+
+	When("the target space is missing", func() {
+		BeforeEach(func() {
+			transferPayload.Data = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("data is required"))
+		})
+	})
+
+	It("converts to a message", func() {
+		Expect(transferPayload.ToMessage(repositories.RouteRecord{GUID: "route-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.TransferRouteMessage{
+			RouteGUID:       "route-guid",
+			SpaceGUID:       "space-guid",
+			TargetSpaceGUID: "target-space-guid",
+		}))
+	})
+})
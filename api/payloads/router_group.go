@@ -0,0 +1,31 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// RouterGroupList is the query of the routing API router groups endpoint,
+// which filters by a single name
+type RouterGroupList struct {
+	Name string
+}
+
+func (l RouterGroupList) ToMessage() repositories.ListRouterGroupsMessage {
+	message := repositories.ListRouterGroupsMessage{}
+	if l.Name != "" {
+		message.Names = []string{l.Name}
+	}
+
+	return message
+}
+
+func (l *RouterGroupList) SupportedKeys() []string {
+	return []string{"name"}
+}
+
+func (l *RouterGroupList) DecodeFromURLValues(values url.Values) error {
+	l.Name = values.Get("name")
+	return nil
+}
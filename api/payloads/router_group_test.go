@@ -0,0 +1,35 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroupList", func() {
+	Describe("Validation", func() {
+		It("decodes the name", func() {
+			routerGroupList, decodeErr := decodeQuery[payloads.RouterGroupList]("name=default-tcp")
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*routerGroupList).To(Equal(payloads.RouterGroupList{Name: "default-tcp"}))
+		})
+
+		It("rejects unsupported keys", func() {
+			_, decodeErr := decodeQuery[payloads.RouterGroupList]("names=default-tcp")
+			Expect(decodeErr).To(MatchError(ContainSubstring("unsupported query parameter: names")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("filters by the name", func() {
+			Expect(payloads.RouterGroupList{Name: "default-tcp"}.ToMessage()).To(Equal(repositories.ListRouterGroupsMessage{
+				Names: []string{"default-tcp"},
+			}))
+		})
+
+		It("does not filter when there is no name", func() {
+			Expect(payloads.RouterGroupList{}.ToMessage()).To(Equal(repositories.ListRouterGroupsMessage{}))
+		})
+	})
+})
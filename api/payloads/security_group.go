@@ -0,0 +1,181 @@
+package payloads
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	jellidation "github.com/jellydator/validation"
+)
+
+// validSecurityGroupRule rejects icmp rules as security groups are enforced
+// by network policies, which cannot filter icmp traffic
+var validSecurityGroupRule = jellidation.By(func(value any) error {
+	rule := value.(securitygroups.Rule)
+	hasPorts := slices.Contains([]string{securitygroups.ProtocolTCP, securitygroups.ProtocolUDP}, rule.Protocol)
+
+	return jellidation.ValidateStruct(&rule,
+		jellidation.Field(&rule.Protocol,
+			jellidation.Required,
+			jellidation.NotIn(securitygroups.ProtocolICMP).Error("icmp is not supported, only tcp, udp and all rules can be enforced"),
+			validation.OneOf(
+				securitygroups.ProtocolTCP,
+				securitygroups.ProtocolUDP,
+				securitygroups.ProtocolAll,
+			),
+		),
+		jellidation.Field(&rule.Destination, jellidation.Required, jellidation.By(func(value any) error {
+			_, err := securitygroups.ParseDestination(value.(string))
+			return err
+		})),
+		jellidation.Field(&rule.Ports,
+			jellidation.When(hasPorts, jellidation.Required, jellidation.By(func(value any) error {
+				_, err := securitygroups.ParsePorts(value.(string))
+				return err
+			})).Else(jellidation.Empty.Error("are only allowed for the tcp and udp protocols")),
+		),
+		jellidation.Field(&rule.Type, jellidation.Nil.Error("is only allowed for icmp rules, which are not supported")),
+		jellidation.Field(&rule.Code, jellidation.Nil.Error("is only allowed for icmp rules, which are not supported")),
+		jellidation.Field(&rule.Log,
+			jellidation.When(rule.Protocol != securitygroups.ProtocolTCP, jellidation.Empty.Error("is only allowed for the tcp protocol")),
+		),
+	)
+})
+
+type SecurityGroupRelationships struct {
+	RunningSpaces *ToManyRelationship `json:"running_spaces"`
+	StagingSpaces *ToManyRelationship `json:"staging_spaces"`
+}
+
+func (r SecurityGroupRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.RunningSpaces),
+		jellidation.Field(&r.StagingSpaces),
+	)
+}
+
+type SecurityGroupCreate struct {
+	Name            string                      `json:"name"`
+	GloballyEnabled securitygroups.Workloads    `json:"globally_enabled"`
+	Rules           []securitygroups.Rule       `json:"rules"`
+	Relationships   *SecurityGroupRelationships `json:"relationships"`
+}
+
+func (c SecurityGroupCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Rules, jellidation.Each(validSecurityGroupRule)),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
+	message := repositories.CreateSecurityGroupMessage{
+		Name:            c.Name,
+		GloballyEnabled: c.GloballyEnabled,
+		Rules:           c.Rules,
+	}
+
+	if c.Relationships != nil {
+		if c.Relationships.RunningSpaces != nil {
+			message.RunningSpaces = c.Relationships.RunningSpaces.GUIDs()
+		}
+		if c.Relationships.StagingSpaces != nil {
+			message.StagingSpaces = c.Relationships.StagingSpaces.GUIDs()
+		}
+	}
+
+	return message
+}
+
+// SecurityGroupUpdate is decoded on top of the current name and globally
+// enabled workloads of the security group so that only the fields present in
+// the request are changed. The rules are replaced as a whole if present.
+type SecurityGroupUpdate struct {
+	Name            string                   `json:"name"`
+	GloballyEnabled securitygroups.Workloads `json:"globally_enabled"`
+	Rules           []securitygroups.Rule    `json:"rules"`
+}
+
+func (u SecurityGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, validation.StrictlyRequired),
+		jellidation.Field(&u.Rules, jellidation.Each(validSecurityGroupRule)),
+	)
+}
+
+func (u SecurityGroupUpdate) ToMessage(guid string) repositories.UpdateSecurityGroupMessage {
+	return repositories.UpdateSecurityGroupMessage{
+		GUID:            guid,
+		Name:            u.Name,
+		GloballyEnabled: u.GloballyEnabled,
+		Rules:           u.Rules,
+	}
+}
+
+type SecurityGroupBind struct {
+	ToManyRelationship
+}
+
+func (b SecurityGroupBind) ToMessage(guid, workload string) repositories.BindSecurityGroupMessage {
+	return repositories.BindSecurityGroupMessage{
+		GUID:     guid,
+		Workload: workload,
+		Spaces:   b.GUIDs(),
+	}
+}
+
+type SecurityGroupList struct {
+	GUIDs                  string
+	Names                  string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      string
+	StagingSpaceGUIDs      string
+}
+
+func (l *SecurityGroupList) SupportedKeys() []string {
+	return []string{
+		"guids",
+		"names",
+		"globally_enabled_running",
+		"globally_enabled_staging",
+		"running_space_guids",
+		"staging_space_guids",
+		"page",
+		"per_page",
+	}
+}
+
+func (l *SecurityGroupList) DecodeFromURLValues(values url.Values) error {
+	var err error
+
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.RunningSpaceGUIDs = values.Get("running_space_guids")
+	l.StagingSpaceGUIDs = values.Get("staging_space_guids")
+
+	if l.GloballyEnabledRunning, err = parseBool(values.Get("globally_enabled_running")); err != nil {
+		return err
+	}
+
+	if l.GloballyEnabledStaging, err = parseBool(values.Get("globally_enabled_staging")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupMessage {
+	return repositories.ListSecurityGroupMessage{
+		GUIDs:                  parse.ArrayParam(l.GUIDs),
+		Names:                  parse.ArrayParam(l.Names),
+		GloballyEnabledRunning: l.GloballyEnabledRunning,
+		GloballyEnabledStaging: l.GloballyEnabledStaging,
+		RunningSpaceGUIDs:      parse.ArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      parse.ArrayParam(l.StagingSpaceGUIDs),
+	}
+}
@@ -0,0 +1,264 @@
+package payloads_test
+
+import (
+	"bytes"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroupCreate", func() {
+	var (
+		createPayload  payloads.SecurityGroupCreate
+		decodedPayload *payloads.SecurityGroupCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SecurityGroupCreate)
+		createPayload = payloads.SecurityGroupCreate{
+			Name:            "my-group",
+			GloballyEnabled: securitygroups.Workloads{Running: true},
+			Rules: []securitygroups.Rule{
+				{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "443,80", Log: true},
+				{Protocol: "udp", Destination: "10.0.0.1-10.0.0.5", Ports: "53"},
+				{Protocol: "all", Destination: "192.168.0.1", Description: "everything"},
+			},
+			Relationships: &payloads.SecurityGroupRelationships{
+				RunningSpaces: &payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-1"}},
+				},
+				StagingSpaces: &payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-2"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the protocol is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Rules[2].Protocol = "sctp"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[2].protocol value must be one of")
+		})
+	})
+
+	When("the destination is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Destination = "10.0.0.0/64"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[0].destination invalid destination")
+		})
+	})
+
+	When("the ports are missing for a tcp rule", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Ports = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[0].ports cannot be blank")
+		})
+	})
+
+	When("the ports are invalid", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Ports = "80-70000"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[0].ports invalid port")
+		})
+	})
+
+	When("ports are set on an all rule", func() {
+		BeforeEach(func() {
+			createPayload.Rules[2].Ports = "80"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[2].ports are only allowed for the tcp and udp protocols")
+		})
+	})
+
+	When("the rule is an icmp rule", func() {
+		BeforeEach(func() {
+			createPayload.Rules[1] = securitygroups.Rule{
+				Protocol:    "icmp",
+				Destination: "10.0.0.1",
+				Type:        tools.PtrTo[int32](8),
+				Code:        tools.PtrTo[int32](0),
+			}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[1].protocol icmp is not supported, only tcp, udp and all rules can be enforced")
+		})
+	})
+
+	When("the icmp code is set", func() {
+		BeforeEach(func() {
+			createPayload.Rules[2].Code = tools.PtrTo[int32](1)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[2].code is only allowed for icmp rules, which are not supported")
+		})
+	})
+
+	When("log is set on a non tcp rule", func() {
+		BeforeEach(func() {
+			createPayload.Rules[2].Log = true
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[2].log is only allowed for the tcp protocol")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name:            "my-group",
+				GloballyEnabled: securitygroups.Workloads{Running: true},
+				Rules:           createPayload.Rules,
+				RunningSpaces:   []string{"space-1"},
+				StagingSpaces:   []string{"space-2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupUpdate", func() {
+	var (
+		payload      payloads.SecurityGroupUpdate
+		body         string
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.SecurityGroupUpdate{
+			Name:            "my-group",
+			GloballyEnabled: securitygroups.Workloads{Running: true},
+		}
+		body = `{"globally_enabled": {"staging": true}}`
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("", "", bytes.NewReader([]byte(body)))
+		Expect(err).NotTo(HaveOccurred())
+		validatorErr = validator.DecodeAndValidateJSONPayload(req, &payload)
+	})
+
+	It("only changes the fields present in the request", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(payload.ToMessage("group-guid")).To(Equal(repositories.UpdateSecurityGroupMessage{
+			GUID:            "group-guid",
+			Name:            "my-group",
+			GloballyEnabled: securitygroups.Workloads{Running: true, Staging: true},
+		}))
+	})
+
+	When("the rules are set", func() {
+		BeforeEach(func() {
+			body = `{"rules": [{"protocol": "udp", "destination": "10.0.0.1", "ports": "53"}]}`
+		})
+
+		It("replaces the rules", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(payload.ToMessage("group-guid").Rules).To(Equal([]securitygroups.Rule{
+				{Protocol: "udp", Destination: "10.0.0.1", Ports: "53"},
+			}))
+		})
+	})
+
+	When("a rule is invalid", func() {
+		BeforeEach(func() {
+			body = `{"rules": [{"protocol": "udp", "destination": "10.0.0.1"}]}`
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "rules[0].ports cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupBind", func() {
+	It("converts the payload to a message", func() {
+		payload := payloads.SecurityGroupBind{
+			ToManyRelationship: payloads.ToManyRelationship{
+				Data: []payloads.RelationshipData{{GUID: "space-1"}},
+			},
+		}
+		Expect(payload.ToMessage("group-guid", securitygroups.WorkloadStaging)).To(Equal(repositories.BindSecurityGroupMessage{
+			GUID:     "group-guid",
+			Workload: securitygroups.WorkloadStaging,
+			Spaces:   []string{"space-1"},
+		}))
+	})
+})
+
+var _ = Describe("SecurityGroupList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSecurityGroupList payloads.SecurityGroupList) {
+			actualSecurityGroupList, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSecurityGroupList).To(Equal(expectedSecurityGroupList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.SecurityGroupList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.SecurityGroupList{Names: "n1,n2"}),
+		Entry("globally_enabled_running", "globally_enabled_running=true", payloads.SecurityGroupList{GloballyEnabledRunning: tools.PtrTo(true)}),
+		Entry("globally_enabled_staging", "globally_enabled_staging=false", payloads.SecurityGroupList{GloballyEnabledStaging: tools.PtrTo(false)}),
+		Entry("running_space_guids", "running_space_guids=s1,s2", payloads.SecurityGroupList{RunningSpaceGUIDs: "s1,s2"}),
+		Entry("staging_space_guids", "staging_space_guids=s1,s2", payloads.SecurityGroupList{StagingSpaceGUIDs: "s1,s2"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string) {
+			_, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+			Expect(decodeErr).To(HaveOccurred())
+		},
+		Entry("invalid globally_enabled_running", "globally_enabled_running=maybe"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.SecurityGroupList{RunningSpaceGUIDs: "s1,s2", GloballyEnabledStaging: tools.PtrTo(true)}
+			Expect(list.ToMessage()).To(Equal(repositories.ListSecurityGroupMessage{
+				RunningSpaceGUIDs:      []string{"s1", "s2"},
+				GloballyEnabledStaging: tools.PtrTo(true),
+			}))
+		})
+	})
+})
@@ -16,18 +16,44 @@ type ServiceBindingCreate struct {
 }
 
 func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceBindingMessage {
-	return repositories.CreateServiceBindingMessage{
+	message := repositories.CreateServiceBindingMessage{
 		Name:                p.Name,
+		Type:                p.Type,
 		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
-		AppGUID:             p.Relationships.App.Data.GUID,
 		SpaceGUID:           spaceGUID,
 	}
+
+	if p.Relationships.App != nil {
+		message.AppGUID = p.Relationships.App.Data.GUID
+	}
+
+	return message
+}
+
+func (p ServiceBindingCreate) IsKey() bool {
+	return p.Type == repositories.ServiceBindingTypeKey
 }
 
 func (p ServiceBindingCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Type, validation.OneOf("app")),
-		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Type, validation.OneOf(repositories.ServiceBindingTypeApp, repositories.ServiceBindingTypeKey)),
+		jellidation.Field(&p.Name, jellidation.When(p.IsKey(), jellidation.Required)),
+		jellidation.Field(&p.Relationships, jellidation.NotNil, jellidation.By(func(value any) error {
+			relationships, ok := value.(*ServiceBindingRelationships)
+			if !ok || relationships == nil {
+				return nil
+			}
+
+			if p.IsKey() {
+				return jellidation.ValidateStruct(relationships,
+					jellidation.Field(&relationships.App, jellidation.Nil.Error("must be blank for service keys")),
+				)
+			}
+
+			return jellidation.ValidateStruct(relationships,
+				jellidation.Field(&relationships.App, jellidation.NotNil),
+			)
+		})),
 	)
 }
 
@@ -38,7 +64,7 @@ type ServiceBindingRelationships struct {
 
 func (r ServiceBindingRelationships) Validate() error {
 	return jellidation.ValidateStruct(&r,
-		jellidation.Field(&r.App, jellidation.NotNil),
+		jellidation.Field(&r.App),
 		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
 	)
 }
@@ -49,6 +75,7 @@ type ServiceBindingList struct {
 	Include              string
 	LabelSelector        string
 	PlanGUIDs            string
+	Type                 string
 }
 
 func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage {
@@ -57,6 +84,7 @@ func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage
 		AppGUIDs:             parse.ArrayParam(l.AppGUIDs),
 		LabelSelector:        l.LabelSelector,
 		PlanGUIDs:            parse.ArrayParam(l.PlanGUIDs),
+		Type:                 l.Type,
 	}
 }
 
@@ -70,6 +98,7 @@ func (l *ServiceBindingList) DecodeFromURLValues(values url.Values) error {
 	l.Include = values.Get("include")
 	l.LabelSelector = values.Get("label_selector")
 	l.PlanGUIDs = values.Get("service_plan_guids")
+	l.Type = values.Get("type")
 	return nil
 }
 
@@ -23,6 +23,7 @@ var _ = Describe("ServiceBindingList", func() {
 		Entry("include", "include=include", payloads.ServiceBindingList{Include: "include"}),
 		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceBindingList{LabelSelector: "foo"}),
 		Entry("service_plan_guids=plan-guid", "service_plan_guids=plan-guid", payloads.ServiceBindingList{PlanGUIDs: "plan-guid"}),
+		Entry("type=key", "type=key", payloads.ServiceBindingList{Type: "key"}),
 	)
 
 	Describe("ToMessage", func() {
@@ -38,6 +39,7 @@ var _ = Describe("ServiceBindingList", func() {
 				Include:              "include",
 				LabelSelector:        "foo=bar",
 				PlanGUIDs:            "p1,p2",
+				Type:                 "key",
 			}
 		})
 
@@ -51,6 +53,7 @@ var _ = Describe("ServiceBindingList", func() {
 				ServiceInstanceGUIDs: []string{"s1", "s2"},
 				LabelSelector:        "foo=bar",
 				PlanGUIDs:            []string{"p1", "p2"},
+				Type:                 "key",
 			}))
 		})
 	})
@@ -93,14 +96,53 @@ var _ = Describe("ServiceBindingCreate", func() {
 		Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
 	})
 
-	When(`the type is "key"`, func() {
+	When("the type is invalid", func() {
 		BeforeEach(func() {
-			createPayload.Type = "key"
+			createPayload.Type = "foo"
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("type value must be one of: app"))
+			Expect(apiError.Detail()).To(ContainSubstring("type value must be one of: app, key"))
+		})
+	})
+
+	When(`the type is "key"`, func() {
+		BeforeEach(func() {
+			createPayload.Type = "key"
+			createPayload.Name = tools.PtrTo("my-key")
+			createPayload.Relationships.App = nil
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				createPayload.Name = nil
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("name cannot be blank"))
+			})
+		})
+
+		When("the app relationship is set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.App = &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "app-guid",
+					},
+				}
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("relationships.app must be blank for service keys"))
+			})
 		})
 	})
 
@@ -103,30 +103,57 @@ func (r ServiceInstanceRelationships) ValidateManagedRelationships() error {
 }
 
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name          *string                            `json:"name,omitempty"`
+	Tags          *[]string                          `json:"tags,omitempty"`
+	Credentials   *map[string]any                    `json:"credentials,omitempty"`
+	Parameters    *map[string]any                    `json:"parameters,omitempty"`
+	Relationships *ServiceInstancePatchRelationships `json:"relationships,omitempty"`
+	Metadata      MetadataPatch                      `json:"metadata"`
+}
+
+type ServiceInstancePatchRelationships struct {
+	ServicePlan *Relationship `json:"service_plan"`
+}
+
+func (r ServiceInstancePatchRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServicePlan, jellidation.NotNil),
+	)
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Relationships),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
+// UpdatesManagedFields returns true if the patch changes the plan or the
+// parameters of the service instance, which only managed service instances
+// have
+func (p ServiceInstancePatch) UpdatesManagedFields() bool {
+	return p.Parameters != nil || p.Relationships != nil
+}
+
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
-	return repositories.PatchServiceInstanceMessage{
+	message := repositories.PatchServiceInstanceMessage{
 		SpaceGUID:   spaceGUID,
 		GUID:        appGUID,
 		Name:        p.Name,
 		Credentials: p.Credentials,
 		Tags:        p.Tags,
+		Parameters:  p.Parameters,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
 		},
 	}
+
+	if p.Relationships != nil {
+		message.PlanGUID = &p.Relationships.ServicePlan.Data.GUID
+	}
+
+	return message
 }
 
 func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
@@ -152,6 +179,10 @@ func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
 		patch.Credentials = &map[string]any{}
 	}
 
+	if v, ok := patchMap["parameters"]; ok && v == nil {
+		patch.Parameters = &map[string]any{}
+	}
+
 	*p = ServiceInstancePatch(patch)
 
 	return nil
@@ -274,3 +305,15 @@ func (d *ServiceInstanceDelete) DecodeFromURLValues(values url.Values) error {
 
 	return nil
 }
+
+type ServiceInstanceShare struct {
+	ToManyRelationship
+}
+
+func (s ServiceInstanceShare) ToMessage(serviceInstanceRecord repositories.ServiceInstanceRecord) repositories.ShareServiceInstanceMessage {
+	return repositories.ShareServiceInstanceMessage{
+		GUID:             serviceInstanceRecord.GUID,
+		SpaceGUID:        serviceInstanceRecord.SpaceGUID,
+		SharedSpaceGUIDs: s.GUIDs(),
+	}
+}
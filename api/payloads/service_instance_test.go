@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -448,9 +449,54 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("the plan and parameters are set", func() {
+		BeforeEach(func() {
+			patchPayload.Parameters = &map[string]any{"param": "value"}
+			patchPayload.Relationships = &payloads.ServiceInstancePatchRelationships{
+				ServicePlan: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "plan-guid"},
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstancePatch).To(PointTo(Equal(patchPayload)))
+			Expect(serviceInstancePatch.UpdatesManagedFields()).To(BeTrue())
+		})
+
+		It("converts to repo message correctly", func() {
+			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+			Expect(msg.PlanGUID).To(PointTo(Equal("plan-guid")))
+			Expect(msg.Parameters).To(PointTo(Equal(map[string]any{"param": "value"})))
+		})
+
+		When("the service plan relationship has no data", func() {
+			BeforeEach(func() {
+				patchPayload.Relationships.ServicePlan.Data = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships.service_plan.data is required")
+			})
+		})
+
+		When("the service plan relationship is missing", func() {
+			BeforeEach(func() {
+				patchPayload.Relationships.ServicePlan = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "service_plan is required")
+			})
+		})
+	})
+
 	Context("ToServiceInstancePatchMessage", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+			Expect(msg.PlanGUID).To(BeNil())
+			Expect(msg.Parameters).To(BeNil())
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
 			Expect(msg.GUID).To(Equal("app-guid"))
 			Expect(msg.Name).To(PointTo(Equal("service-instance-name")))
@@ -490,3 +536,50 @@ var _ = Describe("ServiceInstanceDelete", func() {
 		Entry("invalid value for purge", "purge=foo", "invalid syntax"),
 	)
 })
+
+var _ = Describe("ServiceInstanceShare", func() {
+	var (
+		sharePayload         payloads.ServiceInstanceShare
+		serviceInstanceShare *payloads.ServiceInstanceShare
+		validatorErr         error
+		apiError             errors.ApiError
+	)
+
+	BeforeEach(func() {
+		serviceInstanceShare = new(payloads.ServiceInstanceShare)
+		sharePayload = payloads.ServiceInstanceShare{
+			ToManyRelationship: payloads.ToManyRelationship{
+				Data: []payloads.RelationshipData{{GUID: "space-1-guid"}, {GUID: "space-2-guid"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), serviceInstanceShare)
+		apiError, _ = validatorErr.(errors.ApiError)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceInstanceShare).To(PointTo(Equal(sharePayload)))
+	})
+
+	When("a space guid is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data[1].GUID = ""
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("guid cannot be blank"))
+		})
+	})
+
+	It("converts to a message", func() {
+		Expect(sharePayload.ToMessage(repositories.ServiceInstanceRecord{GUID: "instance-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.ShareServiceInstanceMessage{
+			GUID:             "instance-guid",
+			SpaceGUID:        "space-guid",
+			SharedSpaceGUIDs: []string{"space-1-guid", "space-2-guid"},
+		}))
+	})
+})
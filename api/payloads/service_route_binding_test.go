@@ -0,0 +1,118 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServiceRouteBindingList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceRouteBindingList payloads.ServiceRouteBindingList) {
+			actualServiceRouteBindingList, decodeErr := decodeQuery[payloads.ServiceRouteBindingList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceRouteBindingList).To(Equal(expectedServiceRouteBindingList))
+		},
+		Entry("route_guids", "route_guids=route_guid", payloads.ServiceRouteBindingList{RouteGUIDs: "route_guid"}),
+		Entry("service_instance_guids", "service_instance_guids=si_guid", payloads.ServiceRouteBindingList{ServiceInstanceGUIDs: "si_guid"}),
+		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceRouteBindingList{LabelSelector: "foo"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServiceRouteBindingList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	Describe("ToMessage", func() {
+		It("returns a list service route bindings message", func() {
+			payload := payloads.ServiceRouteBindingList{
+				RouteGUIDs:           "r1,r2",
+				ServiceInstanceGUIDs: "s1,s2",
+				LabelSelector:        "foo=bar",
+			}
+
+			Expect(payload.ToMessage()).To(Equal(repositories.ListServiceRouteBindingsMessage{
+				RouteGUIDs:           []string{"r1", "r2"},
+				ServiceInstanceGUIDs: []string{"s1", "s2"},
+				LabelSelector:        "foo=bar",
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceRouteBindingCreate", func() {
+	var (
+		createPayload             payloads.ServiceRouteBindingCreate
+		serviceRouteBindingCreate *payloads.ServiceRouteBindingCreate
+		validatorErr              error
+		apiError                  errors.ApiError
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingCreate = new(payloads.ServiceRouteBindingCreate)
+		createPayload = payloads.ServiceRouteBindingCreate{
+			Relationships: &payloads.ServiceRouteBindingRelationships{
+				Route: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "route-guid",
+					},
+				},
+				ServiceInstance: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "service-instance-guid",
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), serviceRouteBindingCreate)
+		apiError, _ = validatorErr.(errors.ApiError)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceRouteBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("relationships is required"))
+		})
+	})
+
+	When("the route relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Route = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("relationships.route is required"))
+		})
+	})
+
+	When("the service instance relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.ServiceInstance = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("relationships.service_instance is required"))
+		})
+	})
+})
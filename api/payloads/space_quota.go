@@ -0,0 +1,122 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship        `json:"organization"`
+	Spaces       *ToManyRelationship `json:"spaces"`
+}
+
+func (r SpaceQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organization),
+		jellidation.Field(&r.Spaces),
+	)
+}
+
+type SpaceQuotaCreate struct {
+	quotas.SpaceQuota
+	Relationships *SpaceQuotaRelationships `json:"relationships"`
+}
+
+// NewSpaceQuotaCreate returns a payload populated with the CF defaults for
+// the quota fields that are not nullable
+func NewSpaceQuotaCreate() SpaceQuotaCreate {
+	return SpaceQuotaCreate{
+		SpaceQuota: quotas.SpaceQuota{
+			Services: quotas.ServiceQuotas{PaidServicesAllowed: true},
+		},
+	}
+}
+
+func (c SpaceQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Apps, validAppQuotas),
+		jellidation.Field(&c.Services, validServiceQuotas),
+		jellidation.Field(&c.Routes, validRouteQuotas),
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	message := repositories.CreateSpaceQuotaMessage{
+		SpaceQuota: c.SpaceQuota,
+		OrgGUID:    c.Relationships.Organization.Data.GUID,
+	}
+
+	if c.Relationships.Spaces != nil {
+		message.Spaces = c.Relationships.Spaces.GUIDs()
+	}
+
+	return message
+}
+
+// SpaceQuotaUpdate is decoded on top of the current quota so that only the
+// fields present in the request are changed
+type SpaceQuotaUpdate struct {
+	quotas.SpaceQuota
+}
+
+func (c SpaceQuotaUpdate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Apps, validAppQuotas),
+		jellidation.Field(&c.Services, validServiceQuotas),
+		jellidation.Field(&c.Routes, validRouteQuotas),
+	)
+}
+
+func (c SpaceQuotaUpdate) ToMessage(guid string) repositories.UpdateSpaceQuotaMessage {
+	return repositories.UpdateSpaceQuotaMessage{
+		GUID:       guid,
+		SpaceQuota: c.SpaceQuota,
+	}
+}
+
+type SpaceQuotaApply struct {
+	ToManyRelationship
+}
+
+func (a SpaceQuotaApply) ToMessage(guid string) repositories.ApplySpaceQuotaMessage {
+	return repositories.ApplySpaceQuotaMessage{
+		GUID:   guid,
+		Spaces: a.GUIDs(),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	SpaceGUIDs        string
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids", "page", "per_page"}
+}
+
+func (l *SpaceQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	return nil
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs:        parse.ArrayParam(l.SpaceGUIDs),
+	}
+}
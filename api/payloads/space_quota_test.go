@@ -0,0 +1,202 @@
+package payloads_test
+
+import (
+	"bytes"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/onsi/gomega/gstruct"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuotaCreate", func() {
+	var (
+		createPayload  payloads.SpaceQuotaCreate
+		decodedPayload *payloads.SpaceQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceQuotaCreate)
+		createPayload = payloads.SpaceQuotaCreate{
+			SpaceQuota: quotas.SpaceQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{
+					PerProcessMemoryInMB: tools.PtrTo[int64](512),
+				},
+				Services: quotas.ServiceQuotas{
+					PaidServicesAllowed: true,
+				},
+			},
+			Relationships: &payloads.SpaceQuotaRelationships{
+				Organization: payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "org-guid"},
+				},
+				Spaces: &payloads.ToManyRelationship{
+					Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Routes.TotalRoutes = tools.PtrTo[int64](-5)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "routes.total_routes must be no less than 0")
+		})
+	})
+
+	When("a log rate limit is set", func() {
+		BeforeEach(func() {
+			createPayload.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](1024)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.Apps.LogRateLimitInBytesPerSecond).To(gstruct.PointTo(BeEquivalentTo(1024)))
+		})
+	})
+
+	When("the log rate limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "apps.log_rate_limit_in_bytes_per_second must be no less than 0")
+		})
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the organization relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Organization.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateSpaceQuotaMessage{
+				SpaceQuota: createPayload.SpaceQuota,
+				OrgGUID:    "org-guid",
+				Spaces:     []string{"space-guid"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaUpdate", func() {
+	var (
+		payload      payloads.SpaceQuotaUpdate
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.SpaceQuotaUpdate{
+			SpaceQuota: quotas.SpaceQuota{
+				Name: "my-quota",
+				Routes: quotas.RouteQuotas{
+					TotalRoutes: tools.PtrTo[int64](10),
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("", "", bytes.NewReader([]byte(`{"name": "new-name", "services": {"paid_services_allowed": true}}`)))
+		Expect(err).NotTo(HaveOccurred())
+		validatorErr = validator.DecodeAndValidateJSONPayload(req, &payload)
+	})
+
+	It("only changes the fields present in the request", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(payload.ToMessage("quota-guid")).To(Equal(repositories.UpdateSpaceQuotaMessage{
+			GUID: "quota-guid",
+			SpaceQuota: quotas.SpaceQuota{
+				Name:     "new-name",
+				Services: quotas.ServiceQuotas{PaidServicesAllowed: true},
+				Routes: quotas.RouteQuotas{
+					TotalRoutes: tools.PtrTo[int64](10),
+				},
+			},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaApply", func() {
+	It("converts the payload to a message", func() {
+		payload := payloads.SpaceQuotaApply{
+			ToManyRelationship: payloads.ToManyRelationship{
+				Data: []payloads.RelationshipData{{GUID: "space-1"}},
+			},
+		}
+		Expect(payload.ToMessage("quota-guid")).To(Equal(repositories.ApplySpaceQuotaMessage{
+			GUID:   "quota-guid",
+			Spaces: []string{"space-1"},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSpaceQuotaList payloads.SpaceQuotaList) {
+			actualSpaceQuotaList, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSpaceQuotaList).To(Equal(expectedSpaceQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.SpaceQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.SpaceQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.SpaceQuotaList{OrganizationGUIDs: "o1,o2"}),
+		Entry("space_guids", "space_guids=s1,s2", payloads.SpaceQuotaList{SpaceGUIDs: "s1,s2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.SpaceQuotaList{SpaceGUIDs: "s1,s2"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListSpaceQuotasMessage{
+				SpaceGUIDs: []string{"s1", "s2"},
+			}))
+		})
+	})
+})
@@ -10,23 +10,26 @@ import (
 )
 
 type TaskCreate struct {
-	Command  string   `json:"command"`
-	Metadata Metadata `json:"metadata"`
+	Command                      string   `json:"command"`
+	LogRateLimitInBytesPerSecond *int64   `json:"log_rate_limit_in_bytes_per_second"`
+	Metadata                     Metadata `json:"metadata"`
 }
 
 func (c TaskCreate) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Command, validation.Required),
+		validation.Field(&c.LogRateLimitInBytesPerSecond, validation.Min(-1).Error("must be -1 or greater")),
 		validation.Field(&c.Metadata),
 	)
 }
 
 func (p TaskCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateTaskMessage {
 	return repositories.CreateTaskMessage{
-		Command:   p.Command,
-		SpaceGUID: appRecord.SpaceGUID,
-		AppGUID:   appRecord.GUID,
-		Metadata:  repositories.Metadata(p.Metadata),
+		Command:                      p.Command,
+		SpaceGUID:                    appRecord.SpaceGUID,
+		AppGUID:                      appRecord.GUID,
+		LogRateLimitInBytesPerSecond: p.LogRateLimitInBytesPerSecond,
+		Metadata:                     repositories.Metadata(p.Metadata),
 	}
 }
 
@@ -0,0 +1,94 @@
+package payloads
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AppUsageEventList struct {
+	GUIDs     string
+	AfterGUID string
+}
+
+func (l *AppUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid"}
+}
+
+func (l *AppUsageEventList) IgnoredKeys() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		regexp.MustCompile("page"),
+		regexp.MustCompile("per_page"),
+		regexp.MustCompile("order_by"),
+	}
+}
+
+func (l *AppUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	return nil
+}
+
+func (l *AppUsageEventList) ToMessage() repositories.ListAppUsageEventsMessage {
+	return repositories.ListAppUsageEventsMessage{
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+		AfterGUID: l.AfterGUID,
+	}
+}
+
+type ServiceUsageEventList struct {
+	GUIDs                string
+	AfterGUID            string
+	ServiceInstanceTypes string
+	ServiceOfferingGUIDs string
+}
+
+func (l *ServiceUsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "service_instance_types", "service_offering_guids"}
+}
+
+func (l *ServiceUsageEventList) IgnoredKeys() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		regexp.MustCompile("page"),
+		regexp.MustCompile("per_page"),
+		regexp.MustCompile("order_by"),
+	}
+}
+
+func (l *ServiceUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	l.ServiceInstanceTypes = values.Get("service_instance_types")
+	l.ServiceOfferingGUIDs = values.Get("service_offering_guids")
+	return nil
+}
+
+func (l ServiceUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.ServiceInstanceTypes, jellidation.By(func(value any) error {
+			serviceInstanceTypes, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("%T is not supported, string is expected", value)
+			}
+
+			return jellidation.Each(validation.OneOf(
+				"managed_service_instance",
+				"user_provided_service_instance",
+			)).Validate(parse.ArrayParam(serviceInstanceTypes))
+		})),
+	)
+}
+
+func (l *ServiceUsageEventList) ToMessage() repositories.ListServiceUsageEventsMessage {
+	return repositories.ListServiceUsageEventsMessage{
+		GUIDs:                parse.ArrayParam(l.GUIDs),
+		AfterGUID:            l.AfterGUID,
+		ServiceInstanceTypes: parse.ArrayParam(l.ServiceInstanceTypes),
+		ServiceOfferingGUIDs: parse.ArrayParam(l.ServiceOfferingGUIDs),
+	}
+}
@@ -0,0 +1,84 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("AppUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppUsageEventList payloads.AppUsageEventList) {
+			actualAppUsageEventList, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppUsageEventList).To(Equal(expectedAppUsageEventList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.AppUsageEventList{GUIDs: "g1,g2"}),
+		Entry("after_guid", "after_guid=g1", payloads.AppUsageEventList{AfterGUID: "g1"}),
+		Entry("pagination and ordering", "page=1&per_page=50&order_by=created_at", payloads.AppUsageEventList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, errMatcher types.GomegaMatcher) {
+			_, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+			Expect(decodeErr).To(errMatcher)
+		},
+		Entry("unsupported key", "foo=bar", HaveOccurred()),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.AppUsageEventList{GUIDs: "g1,g2", AfterGUID: "g0"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListAppUsageEventsMessage{
+				GUIDs:     []string{"g1", "g2"},
+				AfterGUID: "g0",
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceUsageEventList payloads.ServiceUsageEventList) {
+			actualServiceUsageEventList, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceUsageEventList).To(Equal(expectedServiceUsageEventList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.ServiceUsageEventList{GUIDs: "g1,g2"}),
+		Entry("after_guid", "after_guid=g1", payloads.ServiceUsageEventList{AfterGUID: "g1"}),
+		Entry("service_instance_types", "service_instance_types=managed_service_instance,user_provided_service_instance",
+			payloads.ServiceUsageEventList{ServiceInstanceTypes: "managed_service_instance,user_provided_service_instance"}),
+		Entry("service_offering_guids", "service_offering_guids=o1,o2", payloads.ServiceUsageEventList{ServiceOfferingGUIDs: "o1,o2"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, errMatcher types.GomegaMatcher) {
+			_, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+			Expect(decodeErr).To(errMatcher)
+		},
+		Entry("invalid service_instance_types", "service_instance_types=managed", MatchError(ContainSubstring("value must be one of"))),
+		Entry("unsupported key", "foo=bar", HaveOccurred()),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a message", func() {
+			list := payloads.ServiceUsageEventList{
+				GUIDs:                "g1",
+				AfterGUID:            "g0",
+				ServiceInstanceTypes: "managed_service_instance",
+				ServiceOfferingGUIDs: "o1,o2",
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListServiceUsageEventsMessage{
+				GUIDs:                []string{"g1"},
+				AfterGUID:            "g0",
+				ServiceInstanceTypes: []string{"managed_service_instance"},
+				ServiceOfferingGUIDs: []string{"o1", "o2"},
+			}))
+		})
+	})
+})
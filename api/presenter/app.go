@@ -166,8 +166,8 @@ type AppEnvResponse struct {
 func ForAppEnv(envVarRecord repositories.AppEnvRecord) AppEnvResponse {
 	return AppEnvResponse{
 		EnvironmentVariables: envVarRecord.EnvironmentVariables,
-		StagingEnvJSON:       map[string]string{},
-		RunningEnvJSON:       map[string]string{},
+		StagingEnvJSON:       emptyMapIfNil(envVarRecord.StagingEnv),
+		RunningEnvJSON:       emptyMapIfNil(envVarRecord.RunningEnv),
 		SystemEnvJSON:        emptyMapToAnyIfEmpty(envVarRecord.SystemEnv),
 		ApplicationEnvJSON:   emptyMapToAnyIfEmpty(envVarRecord.AppEnv),
 	}
@@ -185,3 +185,17 @@ type AppSSHEnabled struct {
 	Enabled bool   `json:"enabled"`
 	Reason  string `json:"reason"`
 }
+
+type AppFeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForAppSSHFeature(app repositories.AppRecord) AppFeatureResponse {
+	return AppFeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into the app.",
+		Enabled:     app.EnableSSH,
+	}
+}
@@ -259,6 +259,20 @@ var _ = Describe("App", func() {
 			}`))
 		})
 
+		When("environment variable groups are set", func() {
+			BeforeEach(func() {
+				record.StagingEnv = map[string]string{"NPM_REGISTRY": "http://registry"}
+				record.RunningEnv = map[string]string{"HTTP_PROXY": "http://proxy"}
+			})
+
+			It("returns them", func() {
+				Expect(output).To(SatisfyAll(
+					MatchJSONPath("$.staging_env_json.NPM_REGISTRY", "http://registry"),
+					MatchJSONPath("$.running_env_json.HTTP_PROXY", "http://proxy"),
+				))
+			})
+		})
+
 		When("system env is nil", func() {
 			BeforeEach(func() {
 				record.SystemEnv = nil
@@ -0,0 +1,80 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const (
+	auditEventsBase = "/v3/audit_events"
+)
+
+type AuditEventResponse struct {
+	GUID         string                   `json:"guid"`
+	CreatedAt    string                   `json:"created_at"`
+	UpdatedAt    string                   `json:"updated_at"`
+	Type         string                   `json:"type"`
+	Actor        AuditEventActorResponse  `json:"actor"`
+	Target       AuditEventTargetResponse `json:"target"`
+	Data         map[string]any           `json:"data"`
+	Space        *AuditEventGUIDResponse  `json:"space"`
+	Organization *AuditEventGUIDResponse  `json:"organization"`
+	Links        AuditEventLinks          `json:"links"`
+}
+
+type AuditEventActorResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventTargetResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventGUIDResponse struct {
+	GUID string `json:"guid"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(auditEvent repositories.AuditEventRecord, baseURL url.URL, includes ...model.IncludedResource) AuditEventResponse {
+	return AuditEventResponse{
+		GUID:      auditEvent.GUID,
+		CreatedAt: formatTimestamp(&auditEvent.CreatedAt),
+		UpdatedAt: formatTimestamp(auditEvent.UpdatedAt),
+		Type:      auditEvent.Type,
+		Actor: AuditEventActorResponse{
+			GUID: auditEvent.ActorGUID,
+			Type: auditEvent.ActorType,
+			Name: auditEvent.ActorName,
+		},
+		Target: AuditEventTargetResponse{
+			GUID: auditEvent.TargetGUID,
+			Type: auditEvent.TargetType,
+			Name: auditEvent.TargetName,
+		},
+		Data:         map[string]any{},
+		Space:        forAuditEventGUID(auditEvent.SpaceGUID),
+		Organization: forAuditEventGUID(auditEvent.OrganizationGUID),
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, auditEvent.GUID).build(),
+			},
+		},
+	}
+}
+
+func forAuditEventGUID(guid string) *AuditEventGUIDResponse {
+	if guid == "" {
+		return nil
+	}
+
+	return &AuditEventGUIDResponse{GUID: guid}
+}
@@ -0,0 +1,112 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Event", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AuditEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AuditEventRecord{
+			GUID:             "event-guid",
+			Type:             "audit.app.start",
+			ActorGUID:        "alice",
+			ActorType:        "user",
+			ActorName:        "alice",
+			TargetGUID:       "app-guid",
+			TargetType:       "app",
+			TargetName:       "my-app",
+			SpaceGUID:        "space-guid",
+			OrganizationGUID: "org-guid",
+			CreatedAt:        time.UnixMilli(1000).UTC(),
+			UpdatedAt:        tools.PtrTo(time.UnixMilli(2000).UTC()),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForAuditEvent(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"type": "audit.app.start",
+			"actor": {
+				"guid": "alice",
+				"type": "user",
+				"name": "alice"
+			},
+			"target": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": "my-app"
+			},
+			"data": {},
+			"space": {
+				"guid": "space-guid"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/audit_events/event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event is not related to a space or organization", func() {
+		BeforeEach(func() {
+			record.SpaceGUID = ""
+			record.OrganizationGUID = ""
+		})
+
+		It("returns null space and organization", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"type": "audit.app.start",
+				"actor": {
+					"guid": "alice",
+					"type": "user",
+					"name": "alice"
+				},
+				"target": {
+					"guid": "app-guid",
+					"type": "app",
+					"name": "my-app"
+				},
+				"data": {},
+				"space": null,
+				"organization": null,
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/audit_events/event-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -12,21 +12,47 @@ const (
 )
 
 type DeploymentStatus struct {
-	Value  string `json:"value"`
-	Reason string `json:"reason"`
+	Value  string                  `json:"value"`
+	Reason string                  `json:"reason"`
+	Canary *DeploymentCanaryStatus `json:"canary,omitempty"`
+}
+
+type DeploymentCanaryStatus struct {
+	Steps DeploymentCanaryStepsStatus `json:"steps"`
+}
+
+type DeploymentCanaryStepsStatus struct {
+	Current int `json:"current"`
+	Total   int `json:"total"`
+}
+
+type DeploymentOptions struct {
+	Canary *DeploymentCanaryOptions `json:"canary,omitempty"`
+}
+
+type DeploymentCanaryOptions struct {
+	Steps []DeploymentCanaryStep `json:"steps"`
+}
+
+type DeploymentCanaryStep struct {
+	Instances int32  `json:"instances"`
+	Weight    *int32 `json:"weight"`
 }
 
 type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 type DeploymentResponse struct {
-	GUID          string                             `json:"guid"`
-	Status        DeploymentStatus                   `json:"status"`
-	Droplet       DropletGUID                        `json:"droplet"`
-	Relationships map[string]model.ToOneRelationship `json:"relationships"`
-	Links         DeploymentLinks                    `json:"links"`
-	CreatedAt     string                             `json:"created_at"`
-	UpdatedAt     string                             `json:"updated_at"`
+	GUID            string                             `json:"guid"`
+	Status          DeploymentStatus                   `json:"status"`
+	Strategy        string                             `json:"strategy"`
+	Options         *DeploymentOptions                 `json:"options,omitempty"`
+	Droplet         DropletGUID                        `json:"droplet"`
+	PreviousDroplet DropletGUID                        `json:"previous_droplet"`
+	Relationships   map[string]model.ToOneRelationship `json:"relationships"`
+	Links           DeploymentLinks                    `json:"links"`
+	CreatedAt       string                             `json:"created_at"`
+	UpdatedAt       string                             `json:"updated_at"`
 }
 
 type DeploymentLinks struct {
@@ -35,15 +61,19 @@ type DeploymentLinks struct {
 }
 
 func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url.URL, includes ...model.IncludedResource) DeploymentResponse {
-	return DeploymentResponse{
+	response := DeploymentResponse{
 		GUID: responseDeployment.GUID,
 		Status: DeploymentStatus{
 			Value:  string(responseDeployment.Status.Value),
 			Reason: string(responseDeployment.Status.Reason),
 		},
+		Strategy: string(responseDeployment.Strategy),
 		Droplet: DropletGUID{
 			Guid: responseDeployment.DropletGUID,
 		},
+		PreviousDroplet: DropletGUID{
+			Guid: responseDeployment.PreviousDropletGUID,
+		},
 		Relationships: ForRelationships(responseDeployment.Relationships()),
 		CreatedAt:     formatTimestamp(&responseDeployment.CreatedAt),
 		UpdatedAt:     formatTimestamp(responseDeployment.UpdatedAt),
@@ -56,4 +86,26 @@ func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url
 			},
 		},
 	}
+
+	if len(responseDeployment.CanarySteps) > 0 {
+		steps := []DeploymentCanaryStep{}
+		for _, step := range responseDeployment.CanarySteps {
+			steps = append(steps, DeploymentCanaryStep{
+				Instances: step.Instances,
+				Weight:    step.Weight,
+			})
+		}
+
+		response.Options = &DeploymentOptions{
+			Canary: &DeploymentCanaryOptions{Steps: steps},
+		}
+		response.Status.Canary = &DeploymentCanaryStatus{
+			Steps: DeploymentCanaryStepsStatus{
+				Current: responseDeployment.CanaryCurrentStep + 1,
+				Total:   len(responseDeployment.CanarySteps),
+			},
+		}
+	}
+
+	return response
 }
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -25,10 +26,12 @@ var _ = Describe("Deployments", func() {
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
 		record = repositories.DeploymentRecord{
-			GUID:        "app-guid",
-			DropletGUID: "droplet-guid",
-			CreatedAt:   time.UnixMilli(1000),
-			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+			GUID:                "app-guid",
+			DropletGUID:         "droplet-guid",
+			PreviousDropletGUID: "previous-droplet-guid",
+			Strategy:            repositories.DeploymentStrategyRolling,
+			CreatedAt:           time.UnixMilli(1000),
+			UpdatedAt:           tools.PtrTo(time.UnixMilli(2000)),
 			Status: repositories.DeploymentStatus{
 				Value:  "deployment-status-value",
 				Reason: "deployment-status-reason",
@@ -50,9 +53,13 @@ var _ = Describe("Deployments", func() {
 				"value": "deployment-status-value",
 				"reason": "deployment-status-reason"
 			},
+			"strategy": "rolling",
 			"droplet": {
 				"guid": "droplet-guid"
 			},
+			"previous_droplet": {
+				"guid": "previous-droplet-guid"
+			},
 			"relationships": {
 				"app": {
 					"data": {
@@ -72,4 +79,27 @@ var _ = Describe("Deployments", func() {
 			}
 		}`))
 	})
+
+	When("the deployment is a canary deployment", func() {
+		BeforeEach(func() {
+			record.Strategy = repositories.DeploymentStrategyCanary
+			record.CanarySteps = []repositories.CanaryStep{
+				{Instances: 1, Weight: tools.PtrTo[int32](10)},
+				{Instances: 2},
+			}
+			record.CanaryCurrentStep = 1
+		})
+
+		It("presents the canary options and status", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.options.canary.steps[0].instances", BeEquivalentTo(1)),
+				MatchJSONPath("$.options.canary.steps[0].weight", BeEquivalentTo(10)),
+				MatchJSONPath("$.options.canary.steps[1].instances", BeEquivalentTo(2)),
+				MatchJSONPath("$.options.canary.steps[1].weight", BeNil()),
+				MatchJSONPath("$.status.canary.steps.current", BeEquivalentTo(2)),
+				MatchJSONPath("$.status.canary.steps.total", BeEquivalentTo(2)),
+			))
+		})
+	})
 })
@@ -12,11 +12,11 @@ const (
 )
 
 type DomainResponse struct {
-	Name               string   `json:"name"`
-	GUID               string   `json:"guid"`
-	Internal           bool     `json:"internal"`
-	RouterGroup        *string  `json:"router_group"`
-	SupportedProtocols []string `json:"supported_protocols"`
+	Name               string             `json:"name"`
+	GUID               string             `json:"guid"`
+	Internal           bool               `json:"internal"`
+	RouterGroup        *DomainRouterGroup `json:"router_group"`
+	SupportedProtocols []string           `json:"supported_protocols"`
 
 	CreatedAt     string              `json:"created_at"`
 	UpdatedAt     string              `json:"updated_at"`
@@ -25,6 +25,10 @@ type DomainResponse struct {
 	Links         DomainLinks         `json:"links"`
 }
 
+type DomainRouterGroup struct {
+	GUID string `json:"guid"`
+}
+
 type DomainLinks struct {
 	Self              Link  `json:"self"`
 	RouteReservations Link  `json:"route_reservations"`
@@ -45,10 +49,10 @@ type SharedOrganizations struct {
 }
 
 func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includes ...model.IncludedResource) DomainResponse {
-	return DomainResponse{
+	response := DomainResponse{
 		Name:               responseDomain.Name,
 		GUID:               responseDomain.GUID,
-		Internal:           false,
+		Internal:           responseDomain.Internal,
 		RouterGroup:        nil,
 		SupportedProtocols: []string{"http"},
 		CreatedAt:          formatTimestamp(&responseDomain.CreatedAt),
@@ -76,4 +80,14 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 			RouterGroup: nil,
 		},
 	}
+
+	if responseDomain.RouterGroup != "" {
+		response.RouterGroup = &DomainRouterGroup{GUID: responseDomain.RouterGroup}
+		response.SupportedProtocols = []string{"tcp"}
+		response.Links.RouterGroup = &Link{
+			HRef: buildURL(baseURL).appendPath(routerGroupsBase, responseDomain.RouterGroup).build(),
+		}
+	}
+
+	return response
 }
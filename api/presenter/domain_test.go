@@ -82,6 +82,30 @@ var _ = Describe("Domains", func() {
 		}`))
 	})
 
+	When("the domain is internal", func() {
+		BeforeEach(func() {
+			record.Internal = true
+		})
+
+		It("presents the domain as internal", func() {
+			Expect(output).To(MatchJSONPath("$.internal", BeTrue()))
+		})
+	})
+
+	When("the domain has a router group", func() {
+		BeforeEach(func() {
+			record.RouterGroup = "default-tcp"
+		})
+
+		It("presents the router group and the tcp protocol", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.router_group.guid", "default-tcp"),
+				MatchJSONPath("$.supported_protocols", ConsistOf("tcp")),
+				MatchJSONPath("$.links.router_group.href", "https://api.example.org/routing/v1/router_groups/default-tcp"),
+			))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
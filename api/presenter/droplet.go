@@ -82,5 +82,13 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL, inclu
 	if dropletRecord.Lifecycle.Type == "docker" {
 		toReturn.Image = &dropletRecord.Image
 	}
+	if dropletRecord.PackageGUID == "" {
+		toReturn.Links["package"] = nil
+	}
+	if dropletRecord.Lifecycle.Type == "buildpack" && dropletRecord.State == repositories.DropletStateStaged {
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
+	}
 	return toReturn
 }
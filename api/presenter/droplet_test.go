@@ -93,7 +93,9 @@ var _ = Describe("Droplet", func() {
 					"href": "https://api.example.org/v3/apps/the-app-guid/relationships/current_droplet",
 					"method": "PATCH"
 				},
-				"download": null
+				"download": {
+					"href": "https://api.example.org/v3/droplets/the-droplet-guid/download"
+				}
 			},
 			"metadata": {
 				"labels": {
@@ -170,6 +172,21 @@ var _ = Describe("Droplet", func() {
 		})
 	})
 
+	When("the droplet has not been staged from a package", func() {
+		BeforeEach(func() {
+			record.PackageGUID = ""
+			record.State = "AWAITING_UPLOAD"
+		})
+
+		It("does not link to a package", func() {
+			Expect(output).To(MatchJSONPath("$.links.package", BeNil()))
+		})
+
+		It("does not link to the download", func() {
+			Expect(output).To(MatchJSONPath("$.links.download", BeNil()))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const envVarGroupsBase = "/v3/environment_variable_groups"
+
+type EnvVarGroupResponse struct {
+	Name      string            `json:"name"`
+	Var       map[string]string `json:"var"`
+	UpdatedAt *string           `json:"updated_at"`
+	Links     EnvVarGroupLinks  `json:"links"`
+}
+
+type EnvVarGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForEnvVarGroup(envVarGroup repositories.EnvVarGroupRecord, baseURL url.URL) EnvVarGroupResponse {
+	return EnvVarGroupResponse{
+		Name:      envVarGroup.Name,
+		Var:       emptyMapIfNil(envVarGroup.Var),
+		UpdatedAt: nilIfEmpty(formatTimestamp(envVarGroup.UpdatedAt)),
+		Links: EnvVarGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(envVarGroupsBase, envVarGroup.Name).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const featureFlagsBase = "/v3/feature_flags"
+
+type FeatureFlagResponse struct {
+	Name               string           `json:"name"`
+	Enabled            bool             `json:"enabled"`
+	UpdatedAt          *string          `json:"updated_at"`
+	CustomErrorMessage *string          `json:"custom_error_message"`
+	Links              FeatureFlagLinks `json:"links"`
+}
+
+type FeatureFlagLinks struct {
+	Self Link `json:"self"`
+}
+
+type SpaceFeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForFeatureFlag(featureFlag repositories.FeatureFlagRecord, baseURL url.URL, includes ...model.IncludedResource) FeatureFlagResponse {
+	return FeatureFlagResponse{
+		Name:               featureFlag.Name,
+		Enabled:            featureFlag.Enabled,
+		UpdatedAt:          nilIfEmpty(formatTimestamp(featureFlag.UpdatedAt)),
+		CustomErrorMessage: nilIfEmpty(featureFlag.CustomErrorMessage),
+		Links: FeatureFlagLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(featureFlagsBase, featureFlag.Name).build(),
+			},
+		},
+	}
+}
+
+func ForSpaceFeature(spaceFeature repositories.SpaceFeatureRecord, _ url.URL, includes ...model.IncludedResource) SpaceFeatureResponse {
+	return SpaceFeatureResponse{
+		Name:        spaceFeature.Name,
+		Description: spaceFeature.Description,
+		Enabled:     spaceFeature.Enabled,
+	}
+}
+
+type SpaceFeaturesResponse struct {
+	Resources []SpaceFeatureResponse `json:"resources"`
+}
+
+func ForSpaceFeatures(spaceFeatures []repositories.SpaceFeatureRecord, baseURL url.URL) SpaceFeaturesResponse {
+	response := SpaceFeaturesResponse{Resources: []SpaceFeatureResponse{}}
+	for _, spaceFeature := range spaceFeatures {
+		response.Resources = append(response.Resources, ForSpaceFeature(spaceFeature, baseURL))
+	}
+	return response
+}
@@ -0,0 +1,106 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature Flags", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForFeatureFlag", func() {
+		var record repositories.FeatureFlagRecord
+
+		BeforeEach(func() {
+			record = repositories.FeatureFlagRecord{
+				Name:               "diego_docker",
+				Enabled:            false,
+				CustomErrorMessage: "no docker here",
+				UpdatedAt:          tools.PtrTo(time.UnixMilli(2000).UTC()),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForFeatureFlag(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "diego_docker",
+				"enabled": false,
+				"updated_at": "1970-01-01T00:00:02Z",
+				"custom_error_message": "no docker here",
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/feature_flags/diego_docker"
+					}
+				}
+			}`))
+		})
+
+		When("the feature flag has never been set", func() {
+			BeforeEach(func() {
+				record = repositories.FeatureFlagRecord{
+					Name:    "task_creation",
+					Enabled: true,
+				}
+			})
+
+			It("presents updated_at and custom_error_message as null", func() {
+				Expect(output).To(MatchJSON(`{
+					"name": "task_creation",
+					"enabled": true,
+					"updated_at": null,
+					"custom_error_message": null,
+					"links": {
+						"self": {
+							"href": "https://api.example.org/v3/feature_flags/task_creation"
+						}
+					}
+				}`))
+			})
+		})
+	})
+
+	Describe("ForSpaceFeatures", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSpaceFeatures([]repositories.SpaceFeatureRecord{{
+				Name:        "ssh",
+				Description: "Enable SSHing into apps in the space.",
+				Enabled:     true,
+			}}, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"resources": [
+					{
+						"name": "ssh",
+						"description": "Enable SSHing into apps in the space.",
+						"enabled": true
+					}
+				]
+			}`))
+		})
+	})
+})
@@ -26,11 +26,16 @@ const (
 	ServiceBrokerCreateOperation = "service_broker.create"
 	ServiceBrokerDeleteOperation = "service_broker.delete"
 	ServiceBrokerUpdateOperation = "service_broker.update"
+	SecurityGroupDeleteOperation = "security_group.delete"
+	DropletUploadOperation       = "droplet.upload"
 
 	ManagedServiceInstanceCreateOperation = "managed_service_instance.create"
 	ManagedServiceInstanceDeleteOperation = "managed_service_instance.delete"
+	ManagedServiceInstanceUpdateOperation = "managed_service_instance.update"
 	ManagedServiceBindingCreateOperation  = "managed_service_binding.create"
 	ManagedServiceBindingDeleteOperation  = "managed_service_binding.delete"
+	ServiceRouteBindingCreateOperation    = "service_route_bindings.create"
+	ServiceRouteBindingDeleteOperation    = "service_route_bindings.delete"
 )
 
 var (
@@ -1,7 +1,12 @@
 package presenter
 
 import (
+	"encoding/json"
+	"strconv"
+	"time"
+
 	"code.cloudfoundry.org/go-loggregator/v8/rpc/loggregator_v2"
+	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
@@ -14,9 +19,12 @@ type LogCacheReadResponseEnvelopes struct {
 }
 
 type LogCacheReadResponseBatch struct {
-	Timestamp int64                   `json:"timestamp"`
-	Log       LogCacheReadResponseLog `json:"log"`
-	Tags      map[string]string       `json:"tags,omitempty"`
+	Timestamp  int64                      `json:"timestamp"`
+	SourceID   string                     `json:"source_id,omitempty"`
+	InstanceID string                     `json:"instance_id,omitempty"`
+	Log        *LogCacheReadResponseLog   `json:"log,omitempty"`
+	Gauge      *LogCacheReadResponseGauge `json:"gauge,omitempty"`
+	Tags       map[string]string          `json:"tags,omitempty"`
 }
 
 type LogCacheReadResponseLog struct {
@@ -24,12 +32,23 @@ type LogCacheReadResponseLog struct {
 	Type    loggregator_v2.Log_Type `json:"type"`
 }
 
-func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse {
-	envelopes := make([]LogCacheReadResponseBatch, 0, len(logRecords))
+type LogCacheReadResponseGauge struct {
+	Metrics map[string]LogCacheReadResponseGaugeValue `json:"metrics"`
+}
+
+type LogCacheReadResponseGaugeValue struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// ForLogCacheRead presents the log records as log envelopes, followed by the
+// instance metrics as gauge envelopes
+func ForLogCacheRead(logRecords []repositories.LogRecord, instanceMetrics []actions.InstanceMetrics) LogCacheReadResponse {
+	envelopes := make([]LogCacheReadResponseBatch, 0, len(logRecords)+len(instanceMetrics))
 	for _, logRecord := range logRecords {
 		batch := LogCacheReadResponseBatch{
 			Timestamp: logRecord.Timestamp,
-			Log: LogCacheReadResponseLog{
+			Log: &LogCacheReadResponseLog{
 				Payload: []byte(logRecord.Message),
 				Type:    loggregator_v2.Log_OUT,
 			},
@@ -39,9 +58,127 @@ func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse {
 		envelopes = append(envelopes, batch)
 	}
 
+	for _, metrics := range instanceMetrics {
+		gauge := &LogCacheReadResponseGauge{
+			Metrics: map[string]LogCacheReadResponseGaugeValue{},
+		}
+		for name, value := range metrics.Gauges {
+			gauge.Metrics[name] = LogCacheReadResponseGaugeValue{Unit: value.Unit, Value: value.Value}
+		}
+
+		envelopes = append(envelopes, LogCacheReadResponseBatch{
+			Timestamp:  metrics.Timestamp.UnixNano(),
+			SourceID:   metrics.AppGUID,
+			InstanceID: metrics.InstanceID,
+			Gauge:      gauge,
+			Tags: map[string]string{
+				"process_id":   metrics.ProcessGUID,
+				"process_type": metrics.ProcessType,
+			},
+		})
+	}
+
 	return LogCacheReadResponse{
 		Envelopes: LogCacheReadResponseEnvelopes{
 			Batch: envelopes,
 		},
 	}
 }
+
+type LogStreamBatch struct {
+	Batch []LogStreamEnvelope `json:"batch"`
+}
+
+type LogStreamEnvelope struct {
+	Timestamp  int64                   `json:"timestamp"`
+	SourceID   string                  `json:"source_id"`
+	InstanceID string                  `json:"instance_id"`
+	Tags       map[string]string       `json:"tags,omitempty"`
+	Log        LogCacheReadResponseLog `json:"log"`
+}
+
+func ForLogStream(sourceID string, logRecords []repositories.LogRecord) LogStreamBatch {
+	envelopes := make([]LogStreamEnvelope, 0, len(logRecords))
+	for _, logRecord := range logRecords {
+		envelopes = append(envelopes, LogStreamEnvelope{
+			Timestamp:  logRecord.Timestamp,
+			SourceID:   sourceID,
+			InstanceID: logRecord.InstanceID,
+			Tags:       logRecord.Tags,
+			Log: LogCacheReadResponseLog{
+				Payload: []byte(logRecord.Message),
+				Type:    loggregator_v2.Log_OUT,
+			},
+		})
+	}
+
+	return LogStreamBatch{
+		Batch: envelopes,
+	}
+}
+
+type PromQLResponse struct {
+	Status string     `json:"status"`
+	Data   PromQLData `json:"data"`
+}
+
+type PromQLData struct {
+	ResultType string         `json:"resultType"`
+	Result     []PromQLResult `json:"result"`
+}
+
+type PromQLResult struct {
+	Metric map[string]string `json:"metric"`
+	Value  *PromQLSample     `json:"value,omitempty"`
+	Values []PromQLSample    `json:"values,omitempty"`
+}
+
+// PromQLSample is rendered as a [<unix seconds>, "<value>"] pair
+type PromQLSample struct {
+	Time  time.Time
+	Value float64
+}
+
+func (s PromQLSample) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{
+		float64(s.Time.UnixMilli()) / 1000,
+		strconv.FormatFloat(s.Value, 'f', -1, 64),
+	})
+}
+
+// ForPromQLVector presents the named gauge of each instance as an instant
+// vector
+func ForPromQLVector(metricName string, instanceMetrics []actions.InstanceMetrics) PromQLResponse {
+	return forPromQL("vector", metricName, instanceMetrics, func(result *PromQLResult, sample PromQLSample) {
+		result.Value = &sample
+	})
+}
+
+// ForPromQLMatrix presents the named gauge of each instance as a range vector
+func ForPromQLMatrix(metricName string, instanceMetrics []actions.InstanceMetrics) PromQLResponse {
+	return forPromQL("matrix", metricName, instanceMetrics, func(result *PromQLResult, sample PromQLSample) {
+		result.Values = []PromQLSample{sample}
+	})
+}
+
+func forPromQL(resultType string, metricName string, instanceMetrics []actions.InstanceMetrics, setSample func(*PromQLResult, PromQLSample)) PromQLResponse {
+	results := []PromQLResult{}
+	for _, metrics := range instanceMetrics {
+		gauge, ok := metrics.Gauges[metricName]
+		if !ok {
+			continue
+		}
+
+		result := PromQLResult{Metric: metrics.Labels()}
+		setSample(&result, PromQLSample{Time: metrics.Timestamp, Value: gauge.Value})
+		results = append(results, result)
+	}
+
+	return PromQLResponse{
+		Status: "success",
+		Data: PromQLData{
+			ResultType: resultType,
+			Result:     results,
+		},
+	}
+}
@@ -2,7 +2,9 @@ package presenter_test
 
 import (
 	"encoding/json"
+	"time"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 
@@ -12,8 +14,9 @@ import (
 
 var _ = Describe("LogCache", func() {
 	var (
-		output  []byte
-		records []repositories.LogRecord
+		output          []byte
+		records         []repositories.LogRecord
+		instanceMetrics []actions.InstanceMetrics
 	)
 
 	BeforeEach(func() {
@@ -30,10 +33,11 @@ var _ = Describe("LogCache", func() {
 				Timestamp: 456,
 			},
 		}
+		instanceMetrics = nil
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForLogs(records)
+		response := presenter.ForLogCacheRead(records, instanceMetrics)
 		var err error
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
@@ -64,4 +68,182 @@ var _ = Describe("LogCache", func() {
 			}
 		}`))
 	})
+
+	When("there are instance metrics", func() {
+		BeforeEach(func() {
+			records = nil
+			instanceMetrics = []actions.InstanceMetrics{{
+				AppGUID:     "app-guid",
+				ProcessGUID: "process-guid",
+				ProcessType: "web",
+				InstanceID:  "1",
+				Timestamp:   time.Unix(0, 789),
+				Gauges: map[string]actions.Gauge{
+					"cpu":    {Unit: "percentage", Value: 12.5},
+					"memory": {Unit: "bytes", Value: 1024},
+				},
+			}}
+		})
+
+		It("presents them as gauge envelopes", func() {
+			Expect(output).To(MatchJSON(`{
+				"envelopes": {
+					"batch": [
+						{
+							"timestamp": 789,
+							"source_id": "app-guid",
+							"instance_id": "1",
+							"gauge": {
+								"metrics": {
+									"cpu": {"unit": "percentage", "value": 12.5},
+									"memory": {"unit": "bytes", "value": 1024}
+								}
+							},
+							"tags": {
+								"process_id": "process-guid",
+								"process_type": "web"
+							}
+						}
+					]
+				}
+			}`))
+		})
+	})
+})
+
+var _ = Describe("PromQL", func() {
+	var (
+		output          []byte
+		instanceMetrics []actions.InstanceMetrics
+	)
+
+	BeforeEach(func() {
+		instanceMetrics = []actions.InstanceMetrics{
+			{
+				AppGUID:     "app-guid",
+				ProcessGUID: "process-guid",
+				ProcessType: "web",
+				InstanceID:  "0",
+				Timestamp:   time.UnixMilli(1700000000123),
+				Gauges: map[string]actions.Gauge{
+					"memory": {Unit: "bytes", Value: 1048576},
+				},
+			},
+			{
+				AppGUID:     "app-guid",
+				ProcessGUID: "process-guid",
+				ProcessType: "web",
+				InstanceID:  "1",
+				Timestamp:   time.UnixMilli(1700000000456),
+				Gauges:      map[string]actions.Gauge{},
+			},
+		}
+	})
+
+	marshal := func(response presenter.PromQLResponse) []byte {
+		GinkgoHelper()
+
+		result, err := json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	Describe("vector", func() {
+		JustBeforeEach(func() {
+			output = marshal(presenter.ForPromQLVector("memory", instanceMetrics))
+		})
+
+		It("presents the instances that have the metric as an instant vector", func() {
+			Expect(output).To(MatchJSON(`{
+				"status": "success",
+				"data": {
+					"resultType": "vector",
+					"result": [
+						{
+							"metric": {
+								"source_id": "app-guid",
+								"instance_id": "0",
+								"process_id": "process-guid",
+								"process_type": "web"
+							},
+							"value": [1700000000.123, "1048576"]
+						}
+					]
+				}
+			}`))
+		})
+	})
+
+	Describe("matrix", func() {
+		JustBeforeEach(func() {
+			output = marshal(presenter.ForPromQLMatrix("memory", instanceMetrics))
+		})
+
+		It("presents the instances that have the metric as a range vector", func() {
+			Expect(output).To(MatchJSON(`{
+				"status": "success",
+				"data": {
+					"resultType": "matrix",
+					"result": [
+						{
+							"metric": {
+								"source_id": "app-guid",
+								"instance_id": "0",
+								"process_id": "process-guid",
+								"process_type": "web"
+							},
+							"values": [[1700000000.123, "1048576"]]
+						}
+					]
+				}
+			}`))
+		})
+	})
+
+	When("no instance has the metric", func() {
+		JustBeforeEach(func() {
+			output = marshal(presenter.ForPromQLVector("http", instanceMetrics))
+		})
+
+		It("returns an empty result", func() {
+			Expect(output).To(MatchJSON(`{"status": "success", "data": {"resultType": "vector", "result": []}}`))
+		})
+	})
+})
+
+var _ = Describe("LogStream", func() {
+	var output []byte
+
+	JustBeforeEach(func() {
+		response := presenter.ForLogStream("app-guid", []repositories.LogRecord{{
+			Message:    "message-1",
+			Timestamp:  123,
+			InstanceID: "1",
+			Tags: map[string]string{
+				"source_type": "APP/PROC/WEB",
+			},
+		}})
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected envelopes json", func() {
+		Expect(output).To(MatchJSON(`{
+			"batch": [
+				{
+					"timestamp": 123,
+					"source_id": "app-guid",
+					"instance_id": "1",
+					"tags": {
+						"source_type": "APP/PROC/WEB"
+					},
+					"log": {
+						"payload": "bWVzc2FnZS0x",
+						"type": 0
+					}
+				}
+			]
+		}`))
+	})
 })
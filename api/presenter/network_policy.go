@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type NetworkPoliciesResponse struct {
+	TotalPolicies int                     `json:"total_policies"`
+	Policies      []NetworkPolicyResponse `json:"policies"`
+}
+
+type NetworkPolicyResponse struct {
+	Source      NetworkPolicySourceResponse      `json:"source"`
+	Destination NetworkPolicyDestinationResponse `json:"destination"`
+}
+
+type NetworkPolicySourceResponse struct {
+	ID string `json:"id"`
+}
+
+type NetworkPolicyDestinationResponse struct {
+	ID       string                     `json:"id"`
+	Protocol string                     `json:"protocol"`
+	Ports    NetworkPolicyPortsResponse `json:"ports"`
+}
+
+type NetworkPolicyPortsResponse struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+func ForNetworkPolicies(records []repositories.NetworkPolicyRecord) NetworkPoliciesResponse {
+	policies := make([]NetworkPolicyResponse, 0, len(records))
+	for _, record := range records {
+		policies = append(policies, NetworkPolicyResponse{
+			Source: NetworkPolicySourceResponse{
+				ID: record.SourceAppGUID,
+			},
+			Destination: NetworkPolicyDestinationResponse{
+				ID:       record.DestinationAppGUID,
+				Protocol: record.Protocol,
+				Ports: NetworkPolicyPortsResponse{
+					Start: record.StartPort,
+					End:   record.EndPort,
+				},
+			},
+		})
+	}
+
+	return NetworkPoliciesResponse{
+		TotalPolicies: len(policies),
+		Policies:      policies,
+	}
+}
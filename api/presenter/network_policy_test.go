@@ -0,0 +1,66 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicies", func() {
+	var (
+		output  []byte
+		records []repositories.NetworkPolicyRecord
+	)
+
+	BeforeEach(func() {
+		records = []repositories.NetworkPolicyRecord{{
+			SourceAppGUID:      "app-1",
+			DestinationAppGUID: "app-2",
+			Protocol:           "tcp",
+			StartPort:          8080,
+			EndPort:            8090,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForNetworkPolicies(records)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the policy-server json", func() {
+		Expect(output).To(MatchJSON(`{
+			"total_policies": 1,
+			"policies": [
+				{
+					"source": {
+						"id": "app-1"
+					},
+					"destination": {
+						"id": "app-2",
+						"protocol": "tcp",
+						"ports": {
+							"start": 8080,
+							"end": 8090
+						}
+					}
+				}
+			]
+		}`))
+	})
+
+	When("there are no policies", func() {
+		BeforeEach(func() {
+			records = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSON(`{"total_policies": 0, "policies": []}`))
+		})
+	})
+})
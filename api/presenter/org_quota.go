@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/quotas"
+)
+
+const (
+	orgQuotasBase = "/v3/organization_quotas"
+)
+
+type OrgQuotaResponse struct {
+	GUID      string `json:"guid"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	quotas.OrgQuota
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         OrgQuotaLinks         `json:"links"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations model.ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForOrgQuota(orgQuota repositories.OrgQuotaRecord, baseURL url.URL, includes ...model.IncludedResource) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      orgQuota.GUID,
+		CreatedAt: formatTimestamp(&orgQuota.CreatedAt),
+		UpdatedAt: formatTimestamp(orgQuota.UpdatedAt),
+		OrgQuota:  orgQuota.OrgQuota,
+		Relationships: OrgQuotaRelationships{
+			Organizations: forToManyRelationship(orgQuota.Organizations),
+		},
+		Links: OrgQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, orgQuota.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForOrgQuotaOrganizations(orgQuota repositories.OrgQuotaRecord, _ url.URL) model.ToManyRelationship {
+	return forToManyRelationship(orgQuota.Organizations)
+}
+
+func forToManyRelationship(guids []string) model.ToManyRelationship {
+	relationship := model.ToManyRelationship{Data: []model.Relationship{}}
+	for _, guid := range guids {
+		relationship.Data = append(relationship.Data, model.Relationship{GUID: guid})
+	}
+	return relationship
+}
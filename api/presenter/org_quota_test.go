@@ -0,0 +1,114 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Org Quota", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.OrgQuotaRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.OrgQuotaRecord{
+			OrgQuota: quotas.OrgQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{
+					TotalMemoryInMB:      tools.PtrTo[int64](2048),
+					PerProcessMemoryInMB: tools.PtrTo[int64](512),
+					PerAppTasks:          tools.PtrTo[int64](3),
+				},
+				Services: quotas.ServiceQuotas{
+					PaidServicesAllowed:   true,
+					TotalServiceInstances: tools.PtrTo[int64](10),
+				},
+				Routes: quotas.RouteQuotas{
+					TotalRoutes: tools.PtrTo[int64](20),
+				},
+			},
+			GUID:          "quota-guid",
+			Organizations: []string{"org-1", "org-2"},
+			CreatedAt:     time.UnixMilli(1000).UTC(),
+			UpdatedAt:     tools.PtrTo(time.UnixMilli(2000).UTC()),
+		}
+	})
+
+	Describe("ForOrgQuota", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForOrgQuota(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": 2048,
+					"per_process_memory_in_mb": 512,
+					"log_rate_limit_in_bytes_per_second": null,
+					"total_instances": null,
+					"per_app_tasks": 3
+				},
+				"services": {
+					"paid_services_allowed": true,
+					"total_service_instances": 10,
+					"total_service_keys": null
+				},
+				"routes": {
+					"total_routes": 20,
+					"total_reserved_ports": null
+				},
+				"domains": {
+					"total_domains": null
+				},
+				"relationships": {
+					"organizations": {
+						"data": [{"guid": "org-1"}, {"guid": "org-2"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organization_quotas/quota-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the quota is not applied to any organization", func() {
+			BeforeEach(func() {
+				record.Organizations = nil
+			})
+
+			It("returns an empty organizations list", func() {
+				Expect(output).To(MatchJSONPath("$.relationships.organizations.data", BeEmpty()))
+			})
+		})
+	})
+
+	Describe("ForOrgQuotaOrganizations", func() {
+		It("returns the organizations relationship", func() {
+			output, err := json.Marshal(presenter.ForOrgQuotaOrganizations(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{"data": [{"guid": "org-1"}, {"guid": "org-2"}]}`))
+		})
+	})
+})
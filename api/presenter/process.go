@@ -21,6 +21,7 @@ type ProcessResponse struct {
 	Instances     int32                              `json:"instances"`
 	MemoryMB      int64                              `json:"memory_in_mb"`
 	DiskQuotaMB   int64                              `json:"disk_in_mb"`
+	LogRateLimit  int64                              `json:"log_rate_limit_in_bytes_per_second"`
 	HealthCheck   ProcessResponseHealthCheck         `json:"health_check"`
 	Relationships map[string]model.ToOneRelationship `json:"relationships"`
 	Metadata      Metadata                           `json:"metadata"`
@@ -100,12 +101,13 @@ type ProcessResponseProcessHealthCheckData struct {
 
 func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) ProcessResponse {
 	return ProcessResponse{
-		GUID:        responseProcess.GUID,
-		Type:        responseProcess.Type,
-		Command:     responseProcess.Command,
-		Instances:   responseProcess.DesiredInstances,
-		MemoryMB:    responseProcess.MemoryMB,
-		DiskQuotaMB: responseProcess.DiskQuotaMB,
+		GUID:         responseProcess.GUID,
+		Type:         responseProcess.Type,
+		Command:      responseProcess.Command,
+		Instances:    responseProcess.DesiredInstances,
+		MemoryMB:     responseProcess.MemoryMB,
+		DiskQuotaMB:  responseProcess.DiskQuotaMB,
+		LogRateLimit: responseProcess.LogRateLimitInBytesPerSecond,
 		HealthCheck: ProcessResponseHealthCheck{
 			Type: string(responseProcess.HealthCheck.Type),
 			Data: ProcessResponseHealthCheckData{
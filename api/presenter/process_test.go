@@ -29,14 +29,15 @@ var _ = Describe("Process", func() {
 
 		BeforeEach(func() {
 			record = repositories.ProcessRecord{
-				GUID:             "process-guid",
-				SpaceGUID:        "space-guid",
-				AppGUID:          "app-guid",
-				Type:             "web",
-				Command:          "rackup",
-				DesiredInstances: 5,
-				MemoryMB:         256,
-				DiskQuotaMB:      1024,
+				GUID:                         "process-guid",
+				SpaceGUID:                    "space-guid",
+				AppGUID:                      "app-guid",
+				Type:                         "web",
+				Command:                      "rackup",
+				DesiredInstances:             5,
+				MemoryMB:                     256,
+				DiskQuotaMB:                  1024,
+				LogRateLimitInBytesPerSecond: 16384,
 				HealthCheck: repositories.HealthCheck{
 					Type: "port",
 				},
@@ -66,6 +67,7 @@ var _ = Describe("Process", func() {
 				"instances": 5,
 				"memory_in_mb": 256,
 				"disk_in_mb": 1024,
+				"log_rate_limit_in_bytes_per_second": 16384,
 				"health_check": {
 					"type": "port",
 					"data": {
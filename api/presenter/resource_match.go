@@ -0,0 +1,32 @@
+package presenter
+
+import "code.cloudfoundry.org/korifi/api/repositories"
+
+type ResourceMatchesResponse struct {
+	Resources []ResourceMatchResponse `json:"resources"`
+}
+
+type ResourceMatchResponse struct {
+	Checksum    ChecksumValue `json:"checksum"`
+	SizeInBytes int64         `json:"size_in_bytes"`
+	Path        string        `json:"path"`
+	Mode        string        `json:"mode"`
+}
+
+type ChecksumValue struct {
+	Value string `json:"value"`
+}
+
+func ForResourceMatches(records []repositories.ResourceRecord) ResourceMatchesResponse {
+	resources := []ResourceMatchResponse{}
+	for _, record := range records {
+		resources = append(resources, ResourceMatchResponse{
+			Checksum:    ChecksumValue{Value: record.SHA1},
+			SizeInBytes: record.SizeInBytes,
+			Path:        record.Path,
+			Mode:        record.Mode,
+		})
+	}
+
+	return ResourceMatchesResponse{Resources: resources}
+}
@@ -0,0 +1,58 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resource Matches", func() {
+	var (
+		records []repositories.ResourceRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		records = []repositories.ResourceRecord{{
+			SHA1:        "0123456789abcdef0123456789abcdef01234567",
+			SizeInBytes: 42,
+			Path:        "path/to/file",
+			Mode:        "644",
+		}}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForResourceMatches(records))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"resources": [
+				{
+					"checksum": {
+						"value": "0123456789abcdef0123456789abcdef01234567"
+					},
+					"size_in_bytes": 42,
+					"path": "path/to/file",
+					"mode": "644"
+				}
+			]
+		}`))
+	})
+
+	When("there are no matches", func() {
+		BeforeEach(func() {
+			records = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSON(`{"resources": []}`))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+)
+
+const revisionsBase = "/v3/revisions"
+
+type RevisionResponse struct {
+	GUID          string                             `json:"guid"`
+	Version       int                                `json:"version"`
+	Droplet       RevisionDroplet                    `json:"droplet"`
+	Processes     map[string]RevisionProcess         `json:"processes"`
+	Sidecars      []any                              `json:"sidecars"`
+	Description   string                             `json:"description"`
+	Deployable    bool                               `json:"deployable"`
+	Relationships map[string]model.ToOneRelationship `json:"relationships"`
+	CreatedAt     string                             `json:"created_at"`
+	UpdatedAt     string                             `json:"updated_at"`
+	Metadata      Metadata                           `json:"metadata"`
+	Links         RevisionLinks                      `json:"links"`
+}
+
+type RevisionDroplet struct {
+	GUID string `json:"guid"`
+}
+
+type RevisionProcess struct {
+	Command *string `json:"command"`
+}
+
+type RevisionLinks struct {
+	Self                 Link `json:"self"`
+	App                  Link `json:"app"`
+	EnvironmentVariables Link `json:"environment_variables"`
+}
+
+type RevisionEnvVarsResponse struct {
+	Var   map[string]string    `json:"var"`
+	Links RevisionEnvVarsLinks `json:"links"`
+}
+
+type RevisionEnvVarsLinks struct {
+	Self     Link `json:"self"`
+	Revision Link `json:"revision"`
+}
+
+func ForRevision(revision repositories.RevisionRecord, baseURL url.URL, includes ...model.IncludedResource) RevisionResponse {
+	processes := map[string]RevisionProcess{}
+	for processType, command := range revision.Processes {
+		processes[processType] = RevisionProcess{Command: nilIfEmpty(command)}
+	}
+
+	return RevisionResponse{
+		GUID:          revision.GUID,
+		Version:       revision.Version,
+		Droplet:       RevisionDroplet{GUID: revision.DropletGUID},
+		Processes:     processes,
+		Sidecars:      []any{},
+		Description:   revision.Description,
+		Deployable:    revision.Deployable,
+		Relationships: ForRelationships(revision.Relationships()),
+		CreatedAt:     formatTimestamp(&revision.CreatedAt),
+		UpdatedAt:     formatTimestamp(revision.UpdatedAt),
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(revision.Labels),
+			Annotations: emptyMapIfNil(revision.Annotations),
+		},
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, revision.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, revision.AppGUID).build(),
+			},
+			EnvironmentVariables: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, revision.GUID, "environment_variables").build(),
+			},
+		},
+	}
+}
+
+func ForRevisionEnvVars(record repositories.RevisionEnvVarsRecord, baseURL url.URL) RevisionEnvVarsResponse {
+	return RevisionEnvVarsResponse{
+		Var: emptyMapIfNil(record.EnvironmentVariables),
+		Links: RevisionEnvVarsLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID, "environment_variables").build(),
+			},
+			Revision: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID).build(),
+			},
+		},
+	}
+}
@@ -12,7 +12,9 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
 }
 
 type RootResponse struct {
@@ -20,9 +22,13 @@ type RootResponse struct {
 	CFOnK8s bool                `json:"cf_on_k8s"`
 }
 
-const V3APIVersion = "3.117.0+cf-k8s"
+const (
+	V3APIVersion = "3.117.0+cf-k8s"
 
-func ForRoot(baseURL url.URL, uaaConfig config.UAA) RootResponse {
+	SSHProxyOAuthClient = "ssh-proxy"
+)
+
+func ForRoot(baseURL url.URL, uaaConfig config.UAA, sshProxyConfig config.SSHProxy) RootResponse {
 	rootResponse := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -49,15 +55,23 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA) RootResponse {
 			},
 			"uaa":     nil,
 			"credhub": nil,
-			"routing": nil,
+			"routing": {
+				Link: Link{
+					HRef: buildURL(baseURL).appendPath("routing").build(),
+				},
+			},
 			"logging": nil,
 			"log_cache": {
 				Link: Link{
 					HRef: buildURL(baseURL).build(),
 				},
 			},
-			"log_stream": nil,
-			"app_ssh":    nil,
+			"log_stream": {
+				Link: Link{
+					HRef: buildURL(baseURL).build(),
+				},
+			},
+			"app_ssh": nil,
 		},
 		CFOnK8s: true,
 	}
@@ -76,6 +90,18 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA) RootResponse {
 		}
 	}
 
+	if sshProxyConfig.Enabled {
+		rootResponse.Links["app_ssh"] = &APILink{
+			Link: Link{
+				HRef: sshProxyConfig.Host,
+			},
+			Meta: APILinkMeta{
+				HostKeyFingerprint: sshProxyConfig.HostKeyFingerprint,
+				OAuthClient:        SSHProxyOAuthClient,
+			},
+		}
+	}
+
 	return rootResponse
 }
 
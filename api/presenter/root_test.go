@@ -24,14 +24,18 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/", func() {
-		var uaaConfig config.UAA
+		var (
+			uaaConfig      config.UAA
+			sshProxyConfig config.SSHProxy
+		)
 
 		BeforeEach(func() {
 			uaaConfig = config.UAA{}
+			sshProxyConfig = config.SSHProxy{}
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL, uaaConfig)
+			response := presenter.ForRoot(*baseURL, uaaConfig, sshProxyConfig)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -56,7 +60,12 @@ var _ = Describe("Root endpoints", func() {
 									"version": ""
 							}
 					},
-					"log_stream": null,
+					"log_stream": {
+							"href": "https://api.example.org",
+							"meta": {
+									"version": ""
+							}
+					},
 					"logging": null,
 					"login": {
 							"href": "https://api.example.org",
@@ -66,7 +75,12 @@ var _ = Describe("Root endpoints", func() {
 					},
 					"network_policy_v0": null,
 					"network_policy_v1": null,
-					"routing": null,
+					"routing": {
+							"href": "https://api.example.org/routing",
+							"meta": {
+									"version": ""
+							}
+					},
 					"self": {
 							"href": "https://api.example.org",
 							"meta": {
@@ -106,7 +120,12 @@ var _ = Describe("Root endpoints", func() {
 									"version": ""
 							}
 					},
-					"log_stream": null,
+					"log_stream": {
+							"href": "https://api.example.org",
+							"meta": {
+									"version": ""
+							}
+					},
 					"logging": null,
 					"login": {
 							"href": "https://my.uaa",
@@ -116,7 +135,12 @@ var _ = Describe("Root endpoints", func() {
 					},
 					"network_policy_v0": null,
 					"network_policy_v1": null,
-					"routing": null,
+					"routing": {
+							"href": "https://api.example.org/routing",
+							"meta": {
+									"version": ""
+							}
+					},
 					"self": {
 							"href": "https://api.example.org",
 							"meta": {
@@ -134,6 +158,29 @@ var _ = Describe("Root endpoints", func() {
 			}`))
 			})
 		})
+
+		When("the ssh proxy is enabled", func() {
+			BeforeEach(func() {
+				sshProxyConfig = config.SSHProxy{
+					Enabled:            true,
+					Host:               "ssh.example.org:2222",
+					HostKeyFingerprint: "the-fingerprint",
+				}
+			})
+
+			It("produces the app_ssh link", func() {
+				var response map[string]any
+				Expect(json.Unmarshal(output, &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", Equal(map[string]any{
+					"href": "ssh.example.org:2222",
+					"meta": map[string]any{
+						"version":              "",
+						"host_key_fingerprint": "the-fingerprint",
+						"oauth_client":         "ssh-proxy",
+					},
+				}))))
+			})
+		})
 	})
 
 	Context("/v3", func() {
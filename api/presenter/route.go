@@ -15,7 +15,7 @@ const (
 type RouteResponse struct {
 	GUID         string             `json:"guid"`
 	Protocol     string             `json:"protocol"`
-	Port         *int               `json:"port"`
+	Port         *int32             `json:"port"`
 	Host         string             `json:"host"`
 	Path         string             `json:"path"`
 	URL          string             `json:"url"`
@@ -33,6 +33,11 @@ type RouteDestinationsResponse struct {
 	Links        routeDestinationsLinks `json:"links"`
 }
 
+type RouteSharedSpacesResponse struct {
+	model.ToManyRelationship
+	Links routeSharedSpacesLinks `json:"links"`
+}
+
 type routeDestination struct {
 	GUID     string              `json:"guid"`
 	App      routeDestinationApp `json:"app"`
@@ -62,6 +67,10 @@ type routeDestinationsLinks struct {
 	Route Link `json:"route"`
 }
 
+type routeSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
 func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...model.IncludedResource) RouteResponse {
 	destinations := make([]routeDestination, 0, len(route.Destinations))
 	for _, destinationRecord := range route.Destinations {
@@ -70,6 +79,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...model
 	return RouteResponse{
 		GUID:          route.GUID,
 		Protocol:      route.Protocol,
+		Port:          route.Port,
 		Host:          route.Host,
 		Path:          route.Path,
 		URL:           routeURL(route),
@@ -131,7 +141,22 @@ func ForRouteDestinations(route repositories.RouteRecord, baseURL url.URL) Route
 	}
 }
 
+func ForRouteSharedSpaces(route repositories.RouteRecord, baseURL url.URL) RouteSharedSpacesResponse {
+	return RouteSharedSpacesResponse{
+		ToManyRelationship: forToManyRelationship(route.SharedSpaces),
+		Links: routeSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, route.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
+
 func routeURL(route repositories.RouteRecord) string {
+	if route.Port != nil {
+		return fmt.Sprintf("%s:%d", route.Domain.Name, *route.Port)
+	}
+
 	if route.Host != "" {
 		return fmt.Sprintf("%s.%s%s", route.Host, route.Domain.Name, route.Path)
 	} else {
@@ -143,6 +143,23 @@ var _ = Describe("Route", func() {
 				Expect(output).To(MatchJSONPath("$.url", "example.org/some_path"))
 			})
 		})
+
+		When("the route is a tcp route", func() {
+			BeforeEach(func() {
+				record.Host = ""
+				record.Path = ""
+				record.Protocol = "tcp"
+				record.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("presents the port in the url", func() {
+				Expect(output).To(SatisfyAll(
+					MatchJSONPath("$.protocol", "tcp"),
+					MatchJSONPath("$.port", BeEquivalentTo(1024)),
+					MatchJSONPath("$.url", "example.org:1024"),
+				))
+			})
+		})
 	})
 
 	Describe("destinations", func() {
@@ -192,4 +209,41 @@ var _ = Describe("Route", func() {
 			}`))
 		})
 	})
+
+	Describe("shared spaces", func() {
+		BeforeEach(func() {
+			record.SharedSpaces = []string{"space-1-guid", "space-2-guid"}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForRouteSharedSpaces(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "space-1-guid" },
+					{ "guid": "space-2-guid" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces"
+					}
+				}
+			}`))
+		})
+
+		When("the route is not shared", func() {
+			BeforeEach(func() {
+				record.SharedSpaces = nil
+			})
+
+			It("returns an empty list", func() {
+				Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+			})
+		})
+	})
 })
@@ -0,0 +1,36 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	routerGroupsBase = "/routing/v1/router_groups"
+)
+
+// RouterGroupResponse follows the CF routing API format, which unlike the V3
+// API presents resources without links or pagination
+type RouterGroupResponse struct {
+	GUID            string `json:"guid"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	ReservablePorts string `json:"reservable_ports"`
+}
+
+func ForRouterGroup(record repositories.RouterGroupRecord) RouterGroupResponse {
+	return RouterGroupResponse{
+		GUID:            record.GUID,
+		Name:            record.Name,
+		Type:            record.Type,
+		ReservablePorts: record.ReservablePorts,
+	}
+}
+
+func ForRouterGroups(records []repositories.RouterGroupRecord) []RouterGroupResponse {
+	response := []RouterGroupResponse{}
+	for _, record := range records {
+		response = append(response, ForRouterGroup(record))
+	}
+
+	return response
+}
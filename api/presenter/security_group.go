@@ -0,0 +1,82 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+)
+
+const (
+	securityGroupsBase = "/v3/security_groups"
+)
+
+type SecurityGroupResponse struct {
+	GUID            string                     `json:"guid"`
+	CreatedAt       string                     `json:"created_at"`
+	UpdatedAt       string                     `json:"updated_at"`
+	Name            string                     `json:"name"`
+	GloballyEnabled securitygroups.Workloads   `json:"globally_enabled"`
+	Rules           []securitygroups.Rule      `json:"rules"`
+	Relationships   SecurityGroupRelationships `json:"relationships"`
+	Links           SecurityGroupLinks         `json:"links"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces model.ToManyRelationship `json:"running_spaces"`
+	StagingSpaces model.ToManyRelationship `json:"staging_spaces"`
+}
+
+type SecurityGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForSecurityGroup(securityGroup repositories.SecurityGroupRecord, baseURL url.URL, includes ...model.IncludedResource) SecurityGroupResponse {
+	rules := securityGroup.Rules
+	if rules == nil {
+		rules = []securitygroups.Rule{}
+	}
+
+	return SecurityGroupResponse{
+		GUID:            securityGroup.GUID,
+		CreatedAt:       formatTimestamp(&securityGroup.CreatedAt),
+		UpdatedAt:       formatTimestamp(securityGroup.UpdatedAt),
+		Name:            securityGroup.Name,
+		GloballyEnabled: securityGroup.GloballyEnabled,
+		Rules:           rules,
+		Relationships: SecurityGroupRelationships{
+			RunningSpaces: forToManyRelationship(securityGroup.RunningSpaces),
+			StagingSpaces: forToManyRelationship(securityGroup.StagingSpaces),
+		},
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupsBase, securityGroup.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForSecurityGroupRunningSpaces(securityGroup repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupSpacesResponse {
+	return forSecurityGroupSpaces(securityGroup.RunningSpaces, securityGroup.GUID, "running_spaces", baseURL)
+}
+
+func ForSecurityGroupStagingSpaces(securityGroup repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupSpacesResponse {
+	return forSecurityGroupSpaces(securityGroup.StagingSpaces, securityGroup.GUID, "staging_spaces", baseURL)
+}
+
+type SecurityGroupSpacesResponse struct {
+	model.ToManyRelationship
+	Links SecurityGroupLinks `json:"links"`
+}
+
+func forSecurityGroupSpaces(spaces []string, guid, relationship string, baseURL url.URL) SecurityGroupSpacesResponse {
+	return SecurityGroupSpacesResponse{
+		ToManyRelationship: forToManyRelationship(spaces),
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupsBase, guid, "relationships", relationship).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Security Group", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.SecurityGroupRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SecurityGroupRecord{
+			GUID:            "group-guid",
+			Name:            "my-group",
+			GloballyEnabled: securitygroups.Workloads{Running: true},
+			Rules: []securitygroups.Rule{
+				{Protocol: "tcp", Destination: "10.0.0.0/24", Ports: "443"},
+				{Protocol: "icmp", Destination: "10.0.0.1", Type: tools.PtrTo[int32](8), Code: tools.PtrTo[int32](0), Description: "ping"},
+			},
+			RunningSpaces: []string{"space-1"},
+			CreatedAt:     time.UnixMilli(1000).UTC(),
+			UpdatedAt:     tools.PtrTo(time.UnixMilli(2000).UTC()),
+		}
+	})
+
+	Describe("ForSecurityGroup", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSecurityGroup(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "group-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-group",
+				"globally_enabled": {
+					"running": true,
+					"staging": false
+				},
+				"rules": [
+					{
+						"protocol": "tcp",
+						"destination": "10.0.0.0/24",
+						"ports": "443"
+					},
+					{
+						"protocol": "icmp",
+						"destination": "10.0.0.1",
+						"type": 8,
+						"code": 0,
+						"description": "ping"
+					}
+				],
+				"relationships": {
+					"running_spaces": {
+						"data": [{"guid": "space-1"}]
+					},
+					"staging_spaces": {
+						"data": []
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/group-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the security group has no rules", func() {
+			BeforeEach(func() {
+				record.Rules = nil
+			})
+
+			It("returns an empty list of rules", func() {
+				Expect(output).To(MatchJSONPath("$.rules", BeEmpty()))
+			})
+		})
+	})
+
+	Describe("ForSecurityGroupRunningSpaces", func() {
+		It("returns the expected JSON", func() {
+			output, err := json.Marshal(presenter.ForSecurityGroupRunningSpaces(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/group-guid/relationships/running_spaces"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSecurityGroupStagingSpaces", func() {
+		It("returns the expected JSON", func() {
+			output, err := json.Marshal(presenter.ForSecurityGroupStagingSpaces(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"data": [],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/group-guid/relationships/staging_spaces"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -35,13 +35,24 @@ type ServiceBindingLastOperationResponse struct {
 }
 
 type ServiceBindingLinks struct {
-	App             Link `json:"app"`
-	ServiceInstance Link `json:"service_instance"`
-	Self            Link `json:"self"`
-	Details         Link `json:"details"`
+	App             *Link `json:"app,omitempty"`
+	ServiceInstance Link  `json:"service_instance"`
+	Self            Link  `json:"self"`
+	Details         Link  `json:"details"`
+}
+
+type ServiceBindingDetailsResponse struct {
+	Credentials map[string]any `json:"credentials"`
 }
 
 func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL, includes ...model.IncludedResource) ServiceBindingResponse {
+	var appLink *Link
+	if record.Type != repositories.ServiceBindingTypeKey {
+		appLink = &Link{
+			HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+		}
+	}
+
 	return ServiceBindingResponse{
 		GUID:      record.GUID,
 		Type:      record.Type,
@@ -57,9 +68,7 @@ func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL
 		},
 		Relationships: ForRelationships(record.Relationships()),
 		Links: ServiceBindingLinks{
-			App: Link{
-				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
-			},
+			App: appLink,
 			ServiceInstance: Link{
 				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
 			},
@@ -87,3 +96,13 @@ func ForServiceBindingList(serviceBindingRecords []repositories.ServiceBindingRe
 
 	return ForList(ForServiceBinding, serviceBindingRecords, baseURL, requestURL, includedApps...)
 }
+
+func ForServiceBindingDetails(record repositories.ServiceBindingDetailsRecord) ServiceBindingDetailsResponse {
+	return ServiceBindingDetailsResponse{
+		Credentials: record.Credentials,
+	}
+}
+
+func ForServiceBindingParameters(parameters map[string]any) map[string]any {
+	return emptyMapIfNil(parameters)
+}
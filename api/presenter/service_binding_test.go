@@ -127,6 +127,56 @@ var _ = Describe("Service Binding", func() {
 				Expect(output).To(MatchJSONPath("$.metadata.annotations", Not(BeNil())))
 			})
 		})
+
+		When("the binding is a service key", func() {
+			BeforeEach(func() {
+				record.Type = "key"
+				record.AppGUID = ""
+			})
+
+			It("omits the app relationship and link", func() {
+				Expect(output).To(MatchJSONPath("$.type", "key"))
+				Expect(output).To(MatchJSONPath("$.relationships.service_instance.data.guid", "service-instance-guid"))
+
+				var response map[string]any
+				Expect(json.Unmarshal(output, &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("relationships", Not(HaveKey("app"))))
+				Expect(response).To(HaveKeyWithValue("links", Not(HaveKey("app"))))
+			})
+		})
+	})
+
+	Describe("ForServiceBindingParameters", func() {
+		It("returns the parameters", func() {
+			Expect(presenter.ForServiceBindingParameters(map[string]any{"p1": "v1"})).To(Equal(map[string]any{"p1": "v1"}))
+		})
+
+		It("returns an empty object when there are no parameters", func() {
+			Expect(presenter.ForServiceBindingParameters(nil)).To(Equal(map[string]any{}))
+		})
+	})
+
+	Describe("ForServiceBindingDetails", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForServiceBindingDetails(repositories.ServiceBindingDetailsRecord{
+				Credentials: map[string]any{
+					"username": "user",
+					"password": "pass",
+				},
+			})
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"credentials": {
+					"username": "user",
+					"password": "pass"
+				}
+			}`))
+		})
 	})
 
 	Describe("ForServiceBindingList", func() {
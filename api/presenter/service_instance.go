@@ -37,14 +37,22 @@ type lastOperation struct {
 }
 
 type ServiceInstanceLinks struct {
-	Self                      Link `json:"self"`
-	Space                     Link `json:"space"`
-	Credentials               Link `json:"credentials"`
-	ServiceCredentialBindings Link `json:"service_credential_bindings"`
-	ServiceRouteBindings      Link `json:"service_route_bindings"`
+	Self                      Link  `json:"self"`
+	Space                     Link  `json:"space"`
+	Credentials               Link  `json:"credentials"`
+	Parameters                *Link `json:"parameters,omitempty"`
+	ServiceCredentialBindings Link  `json:"service_credential_bindings"`
+	ServiceRouteBindings      Link  `json:"service_route_bindings"`
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL, includes ...model.IncludedResource) ServiceInstanceResponse {
+	var parametersLink *Link
+	if serviceInstanceRecord.Type == "managed" {
+		parametersLink = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "parameters").build(),
+		}
+	}
+
 	return ServiceInstanceResponse{
 		Name: serviceInstanceRecord.Name,
 		GUID: serviceInstanceRecord.GUID,
@@ -74,6 +82,7 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 			Credentials: Link{
 				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "credentials").build(),
 			},
+			Parameters: parametersLink,
 			ServiceCredentialBindings: Link{
 				HRef: buildURL(baseURL).appendPath(serviceCredentialBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
 			},
@@ -83,3 +92,72 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 		},
 	}
 }
+
+func ForServiceInstanceCredentials(credentials map[string]any) map[string]any {
+	return emptyMapIfNil(credentials)
+}
+
+func ForServiceInstanceParameters(parameters map[string]any) map[string]any {
+	return emptyMapIfNil(parameters)
+}
+
+type ServiceInstanceSharedSpacesResponse struct {
+	model.ToManyRelationship
+	Links serviceInstanceSharedSpacesLinks `json:"links"`
+}
+
+type serviceInstanceSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForServiceInstanceSharedSpaces(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceSharedSpacesResponse {
+	return ServiceInstanceSharedSpacesResponse{
+		ToManyRelationship: forToManyRelationship(serviceInstanceRecord.SharedSpaces),
+		Links: serviceInstanceSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
+
+type ServiceInstanceUsageSummaryResponse struct {
+	UsageSummary []sharedSpaceUsage               `json:"usage_summary"`
+	Links        serviceInstanceUsageSummaryLinks `json:"links"`
+}
+
+type sharedSpaceUsage struct {
+	Space         model.Relationship `json:"space"`
+	BoundAppCount int                `json:"bound_app_count"`
+}
+
+type serviceInstanceUsageSummaryLinks struct {
+	Self            Link `json:"self"`
+	SharedSpaces    Link `json:"shared_spaces"`
+	ServiceInstance Link `json:"service_instance"`
+}
+
+func ForServiceInstanceUsageSummary(serviceInstanceGUID string, usageRecords []repositories.SharedSpaceUsageRecord, baseURL url.URL) ServiceInstanceUsageSummaryResponse {
+	usageSummary := make([]sharedSpaceUsage, 0, len(usageRecords))
+	for _, usageRecord := range usageRecords {
+		usageSummary = append(usageSummary, sharedSpaceUsage{
+			Space:         model.Relationship{GUID: usageRecord.SpaceGUID},
+			BoundAppCount: usageRecord.BoundAppCount,
+		})
+	}
+
+	return ServiceInstanceUsageSummaryResponse{
+		UsageSummary: usageSummary,
+		Links: serviceInstanceUsageSummaryLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID, "relationships", "shared_spaces", "usage_summary").build(),
+			},
+			SharedSpaces: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID, "relationships", "shared_spaces").build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID).build(),
+			},
+		},
+	}
+}
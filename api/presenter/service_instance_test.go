@@ -110,6 +110,16 @@ var _ = Describe("Service Instance", func() {
 		}`))
 	})
 
+	When("the service instance is managed", func() {
+		BeforeEach(func() {
+			record.Type = "managed"
+		})
+
+		It("links to the parameters", func() {
+			Expect(output).To(MatchJSONPath("$.links.parameters.href", "https://api.example.org/v3/service_instances/service-instance-guid/parameters"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -130,3 +140,137 @@ var _ = Describe("Service Instance", func() {
 		})
 	})
 })
+
+var _ = Describe("Service Instance Credentials", func() {
+	var credentials map[string]any
+
+	BeforeEach(func() {
+		credentials = map[string]any{"username": "user"}
+	})
+
+	It("returns the credentials", func() {
+		Expect(presenter.ForServiceInstanceCredentials(credentials)).To(Equal(map[string]any{"username": "user"}))
+	})
+
+	When("there are no credentials", func() {
+		BeforeEach(func() {
+			credentials = nil
+		})
+
+		It("returns an empty object", func() {
+			Expect(presenter.ForServiceInstanceCredentials(credentials)).To(BeEmpty())
+			Expect(presenter.ForServiceInstanceCredentials(credentials)).NotTo(BeNil())
+		})
+	})
+})
+
+var _ = Describe("Service Instance Parameters", func() {
+	It("returns an empty object when there are no parameters", func() {
+		Expect(presenter.ForServiceInstanceParameters(nil)).To(Equal(map[string]any{}))
+	})
+})
+
+var _ = Describe("Service Instance Shared Spaces", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceInstanceRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceInstanceRecord{
+			GUID:         "service-instance-guid",
+			SpaceGUID:    "space-guid",
+			SharedSpaces: []string{"space-1-guid", "space-2-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceInstanceSharedSpaces(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"data": [
+				{ "guid": "space-1-guid" },
+				{ "guid": "space-2-guid" }
+			],
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+				}
+			}
+		}`))
+	})
+
+	When("the service instance is not shared", func() {
+		BeforeEach(func() {
+			record.SharedSpaces = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+		})
+	})
+})
+
+var _ = Describe("Service Instance Usage Summary", func() {
+	var (
+		baseURL      *url.URL
+		output       []byte
+		usageRecords []repositories.SharedSpaceUsageRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		usageRecords = []repositories.SharedSpaceUsageRecord{
+			{SpaceGUID: "space-1-guid", BoundAppCount: 2},
+			{SpaceGUID: "space-2-guid", BoundAppCount: 0},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceInstanceUsageSummary("service-instance-guid", usageRecords, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"usage_summary": [
+				{ "space": { "guid": "space-1-guid" }, "bound_app_count": 2 },
+				{ "space": { "guid": "space-2-guid" }, "bound_app_count": 0 }
+			],
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"
+				},
+				"shared_spaces": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+				},
+				"service_instance": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the service instance is not shared", func() {
+		BeforeEach(func() {
+			usageRecords = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSONPath("$.usage_summary", BeEmpty()))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Route Binding", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceRouteBindingRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceRouteBindingRecord{
+			GUID:                "binding-guid",
+			RouteGUID:           "route-guid",
+			ServiceInstanceGUID: "service-instance-guid",
+			SpaceGUID:           "space-guid",
+			RouteServiceURL:     "https://route-service.example.com",
+			Labels: map[string]string{
+				"label-key": "label-val",
+			},
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+			LastOperation: repositories.ServiceBindingLastOperation{
+				Type:      "create",
+				State:     "succeeded",
+				CreatedAt: time.UnixMilli(1000),
+				UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceRouteBinding(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "binding-guid",
+			"route_service_url": "https://route-service.example.com",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"last_operation": {
+				"type": "create",
+				"state": "succeeded",
+				"description": null,
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z"
+			},
+			"relationships": {
+				"route": {
+					"data": {
+						"guid": "route-guid"
+					}
+				},
+				"service_instance": {
+					"data": {
+						"guid": "service-instance-guid"
+					}
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_route_bindings/binding-guid"
+				},
+				"service_instance": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid"
+				},
+				"route": {
+					"href": "https://api.example.org/v3/routes/route-guid"
+				}
+			},
+			"metadata": {
+				"labels": {
+					"label-key": "label-val"
+				},
+				"annotations": {}
+			}
+		}`))
+	})
+
+	When("the broker has not returned the route service URL yet", func() {
+		BeforeEach(func() {
+			record.RouteServiceURL = ""
+		})
+
+		It("returns a null route service URL", func() {
+			Expect(output).To(MatchJSONPath("$.route_service_url", BeNil()))
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/quotas"
+)
+
+const (
+	spaceQuotasBase = "/v3/space_quotas"
+)
+
+type SpaceQuotaResponse struct {
+	GUID      string `json:"guid"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	quotas.SpaceQuota
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         SpaceQuotaLinks         `json:"links"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization model.ToOneRelationship  `json:"organization"`
+	Spaces       model.ToManyRelationship `json:"spaces"`
+}
+
+type SpaceQuotaLinks struct {
+	Self         Link `json:"self"`
+	Organization Link `json:"organization"`
+}
+
+func ForSpaceQuota(spaceQuota repositories.SpaceQuotaRecord, baseURL url.URL, includes ...model.IncludedResource) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:       spaceQuota.GUID,
+		CreatedAt:  formatTimestamp(&spaceQuota.CreatedAt),
+		UpdatedAt:  formatTimestamp(spaceQuota.UpdatedAt),
+		SpaceQuota: spaceQuota.SpaceQuota,
+		Relationships: SpaceQuotaRelationships{
+			Organization: model.ToOneRelationship{
+				Data: model.Relationship{GUID: spaceQuota.OrgGUID},
+			},
+			Spaces: forToManyRelationship(spaceQuota.Spaces),
+		},
+		Links: SpaceQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, spaceQuota.GUID).build(),
+			},
+			Organization: Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, spaceQuota.OrgGUID).build(),
+			},
+		},
+	}
+}
+
+func ForSpaceQuotaSpaces(spaceQuota repositories.SpaceQuotaRecord, _ url.URL) model.ToManyRelationship {
+	return forToManyRelationship(spaceQuota.Spaces)
+}
@@ -0,0 +1,98 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Space Quota", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.SpaceQuotaRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SpaceQuotaRecord{
+			SpaceQuota: quotas.SpaceQuota{
+				Name: "my-quota",
+				Apps: quotas.AppQuotas{
+					TotalInstances: tools.PtrTo[int64](5),
+				},
+			},
+			GUID:      "quota-guid",
+			OrgGUID:   "org-guid",
+			Spaces:    []string{"space-guid"},
+			CreatedAt: time.UnixMilli(1000).UTC(),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000).UTC()),
+		}
+	})
+
+	Describe("ForSpaceQuota", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSpaceQuota(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": null,
+					"per_process_memory_in_mb": null,
+					"log_rate_limit_in_bytes_per_second": null,
+					"total_instances": 5,
+					"per_app_tasks": null
+				},
+				"services": {
+					"paid_services_allowed": false,
+					"total_service_instances": null,
+					"total_service_keys": null
+				},
+				"routes": {
+					"total_routes": null,
+					"total_reserved_ports": null
+				},
+				"relationships": {
+					"organization": {
+						"data": {"guid": "org-guid"}
+					},
+					"spaces": {
+						"data": [{"guid": "space-guid"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/quota-guid"
+					},
+					"organization": {
+						"href": "https://api.example.org/v3/organizations/org-guid"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceQuotaSpaces", func() {
+		It("returns the spaces relationship", func() {
+			output, err := json.Marshal(presenter.ForSpaceQuotaSpaces(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{"data": [{"guid": "space-guid"}]}`))
+		})
+	})
+})
@@ -25,6 +25,7 @@ type TaskResponse struct {
 	UpdatedAt     string                             `json:"updated_at"`
 	MemoryMB      int64                              `json:"memory_in_mb"`
 	DiskMB        int64                              `json:"disk_in_mb"`
+	LogRateLimit  int64                              `json:"log_rate_limit_in_bytes_per_second"`
 	State         string                             `json:"state"`
 	Result        TaskResult                         `json:"result"`
 }
@@ -48,17 +49,18 @@ func ForTask(responseTask repositories.TaskRecord, baseURL url.URL, includes ...
 	}
 
 	return TaskResponse{
-		Name:        responseTask.Name,
-		GUID:        responseTask.GUID,
-		Command:     responseTask.Command,
-		SequenceID:  responseTask.SequenceID,
-		DropletGUID: responseTask.DropletGUID,
-		CreatedAt:   formatTimestamp(&responseTask.CreatedAt),
-		UpdatedAt:   formatTimestamp(responseTask.UpdatedAt),
-		MemoryMB:    responseTask.MemoryMB,
-		DiskMB:      responseTask.DiskMB,
-		State:       responseTask.State,
-		Result:      result,
+		Name:         responseTask.Name,
+		GUID:         responseTask.GUID,
+		Command:      responseTask.Command,
+		SequenceID:   responseTask.SequenceID,
+		DropletGUID:  responseTask.DropletGUID,
+		CreatedAt:    formatTimestamp(&responseTask.CreatedAt),
+		UpdatedAt:    formatTimestamp(responseTask.UpdatedAt),
+		MemoryMB:     responseTask.MemoryMB,
+		DiskMB:       responseTask.DiskMB,
+		LogRateLimit: responseTask.LogRateLimitInBytesPerSecond,
+		State:        responseTask.State,
+		Result:       result,
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(responseTask.Labels),
 			Annotations: emptyMapIfNil(responseTask.Annotations),
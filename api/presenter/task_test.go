@@ -26,21 +26,22 @@ var _ = Describe("Task", func() {
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
 		record = repositories.TaskRecord{
-			Name:          "task-name",
-			GUID:          "task-guid",
-			SpaceGUID:     "space-guid",
-			Command:       "sleep 10000",
-			AppGUID:       "app-guid",
-			DropletGUID:   "droplet-guid",
-			Labels:        map[string]string{"l": "l1"},
-			Annotations:   map[string]string{"a": "a1"},
-			SequenceID:    4,
-			CreatedAt:     time.UnixMilli(1000),
-			UpdatedAt:     tools.PtrTo(time.UnixMilli(2000)),
-			MemoryMB:      100,
-			DiskMB:        200,
-			State:         "ok",
-			FailureReason: "nope",
+			Name:                         "task-name",
+			GUID:                         "task-guid",
+			SpaceGUID:                    "space-guid",
+			Command:                      "sleep 10000",
+			AppGUID:                      "app-guid",
+			DropletGUID:                  "droplet-guid",
+			Labels:                       map[string]string{"l": "l1"},
+			Annotations:                  map[string]string{"a": "a1"},
+			SequenceID:                   4,
+			CreatedAt:                    time.UnixMilli(1000),
+			UpdatedAt:                    tools.PtrTo(time.UnixMilli(2000)),
+			MemoryMB:                     100,
+			DiskMB:                       200,
+			LogRateLimitInBytesPerSecond: -1,
+			State:                        "ok",
+			FailureReason:                "nope",
 		}
 	})
 
@@ -61,6 +62,7 @@ var _ = Describe("Task", func() {
 			"updated_at": "1970-01-01T00:00:02Z",
 			"memory_in_mb": 100,
 			"disk_in_mb": 200,
+			"log_rate_limit_in_bytes_per_second": -1,
 			"droplet_guid": "droplet-guid",
 			"state": "ok",
 			"metadata": {
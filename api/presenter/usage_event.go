@@ -0,0 +1,154 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	appUsageEventsBase     = "/v3/app_usage_events"
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type AppUsageEventResponse struct {
+	GUID                  string                  `json:"guid"`
+	CreatedAt             string                  `json:"created_at"`
+	UpdatedAt             string                  `json:"updated_at"`
+	State                 UsageEventStringChange  `json:"state"`
+	App                   UsageEventResource      `json:"app"`
+	Process               UsageEventProcess       `json:"process"`
+	Space                 UsageEventResource      `json:"space"`
+	Organization          UsageEventGUID          `json:"organization"`
+	Buildpack             UsageEventResource      `json:"buildpack"`
+	Task                  UsageEventResource      `json:"task"`
+	MemoryInMBPerInstance UsageEventIntegerChange `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventIntegerChange `json:"instance_count"`
+	Links                 UsageEventLinks         `json:"links"`
+}
+
+type ServiceUsageEventResponse struct {
+	GUID            string                  `json:"guid"`
+	CreatedAt       string                  `json:"created_at"`
+	UpdatedAt       string                  `json:"updated_at"`
+	State           string                  `json:"state"`
+	ServiceInstance UsageEventTypedResource `json:"service_instance"`
+	ServicePlan     UsageEventResource      `json:"service_plan"`
+	ServiceOffering UsageEventResource      `json:"service_offering"`
+	ServiceBroker   UsageEventResource      `json:"service_broker"`
+	Space           UsageEventResource      `json:"space"`
+	Organization    UsageEventGUID          `json:"organization"`
+	Links           UsageEventLinks         `json:"links"`
+}
+
+type UsageEventStringChange struct {
+	Current  string  `json:"current"`
+	Previous *string `json:"previous"`
+}
+
+type UsageEventIntegerChange struct {
+	Current  int64  `json:"current"`
+	Previous *int64 `json:"previous"`
+}
+
+type UsageEventGUID struct {
+	GUID *string `json:"guid"`
+}
+
+type UsageEventResource struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+}
+
+type UsageEventTypedResource struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type UsageEventProcess struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+type UsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(event repositories.AppUsageEventRecord, baseURL url.URL, includes ...model.IncludedResource) AppUsageEventResponse {
+	response := AppUsageEventResponse{
+		GUID:      event.GUID,
+		CreatedAt: formatTimestamp(&event.CreatedAt),
+		UpdatedAt: formatTimestamp(event.UpdatedAt),
+		State: UsageEventStringChange{
+			Current: event.State,
+		},
+		App:     forUsageEventResource(event.AppGUID, event.AppName),
+		Process: UsageEventProcess{GUID: event.ProcessGUID, Type: event.ProcessType},
+		Space:   forUsageEventResource(event.SpaceGUID, event.SpaceName),
+		Organization: UsageEventGUID{
+			GUID: nilIfEmpty(event.OrganizationGUID),
+		},
+		MemoryInMBPerInstance: UsageEventIntegerChange{
+			Current: event.MemoryInMBPerInstance,
+		},
+		InstanceCount: UsageEventIntegerChange{
+			Current: int64(event.InstanceCount),
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, event.GUID).build(),
+			},
+		},
+	}
+
+	if event.PreviousState != "" {
+		response.State.Previous = &event.PreviousState
+		response.MemoryInMBPerInstance.Previous = &event.PreviousMemoryInMBPerInstance
+		response.InstanceCount.Previous = tools.PtrTo(int64(event.PreviousInstanceCount))
+	}
+
+	return response
+}
+
+func ForServiceUsageEvent(event repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...model.IncludedResource) ServiceUsageEventResponse {
+	return ServiceUsageEventResponse{
+		GUID:      event.GUID,
+		CreatedAt: formatTimestamp(&event.CreatedAt),
+		UpdatedAt: formatTimestamp(event.UpdatedAt),
+		State:     event.State,
+		ServiceInstance: UsageEventTypedResource{
+			GUID: event.ServiceInstanceGUID,
+			Name: event.ServiceInstanceName,
+			Type: event.ServiceInstanceType,
+		},
+		ServicePlan:     forUsageEventResource(event.ServicePlanGUID, event.ServicePlanName),
+		ServiceOffering: forUsageEventResource(event.ServiceOfferingGUID, event.ServiceOfferingName),
+		ServiceBroker:   forUsageEventResource(event.ServiceBrokerGUID, event.ServiceBrokerName),
+		Space:           forUsageEventResource(event.SpaceGUID, event.SpaceName),
+		Organization: UsageEventGUID{
+			GUID: nilIfEmpty(event.OrganizationGUID),
+		},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, event.GUID).build(),
+			},
+		},
+	}
+}
+
+func forUsageEventResource(guid, name string) UsageEventResource {
+	return UsageEventResource{
+		GUID: nilIfEmpty(guid),
+		Name: nilIfEmpty(name),
+	}
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,200 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Usage Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppUsageEvent", func() {
+		var record repositories.AppUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.AppUsageEventRecord{
+				GUID:                          "event-guid",
+				State:                         "STARTED",
+				PreviousState:                 "STARTED",
+				AppGUID:                       "app-guid",
+				AppName:                       "my-app",
+				ProcessGUID:                   "process-guid",
+				ProcessType:                   "web",
+				SpaceGUID:                     "space-guid",
+				SpaceName:                     "my-space",
+				OrganizationGUID:              "org-guid",
+				InstanceCount:                 3,
+				PreviousInstanceCount:         2,
+				MemoryInMBPerInstance:         256,
+				PreviousMemoryInMBPerInstance: 128,
+				CreatedAt:                     time.UnixMilli(1000).UTC(),
+				UpdatedAt:                     tools.PtrTo(time.UnixMilli(2000).UTC()),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForAppUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"state": {
+					"current": "STARTED",
+					"previous": "STARTED"
+				},
+				"app": {
+					"guid": "app-guid",
+					"name": "my-app"
+				},
+				"process": {
+					"guid": "process-guid",
+					"type": "web"
+				},
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"buildpack": {
+					"guid": null,
+					"name": null
+				},
+				"task": {
+					"guid": null,
+					"name": null
+				},
+				"memory_in_mb_per_instance": {
+					"current": 256,
+					"previous": 128
+				},
+				"instance_count": {
+					"current": 3,
+					"previous": 2
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/app_usage_events/event-guid"
+					}
+				}
+			}`))
+		})
+
+		When("there is no previous state", func() {
+			BeforeEach(func() {
+				record.PreviousState = ""
+			})
+
+			It("presents the previous values as null", func() {
+				Expect(output).To(MatchJSONPath("$.state.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.instance_count.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.memory_in_mb_per_instance.previous", BeNil()))
+			})
+		})
+	})
+
+	Describe("ForServiceUsageEvent", func() {
+		var record repositories.ServiceUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				State:               "CREATED",
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceName: "my-instance",
+				ServiceInstanceType: "managed_service_instance",
+				ServicePlanGUID:     "plan-guid",
+				ServicePlanName:     "small",
+				ServiceOfferingGUID: "offering-guid",
+				ServiceOfferingName: "db",
+				ServiceBrokerGUID:   "broker-guid",
+				ServiceBrokerName:   "my-broker",
+				SpaceGUID:           "space-guid",
+				SpaceName:           "my-space",
+				OrganizationGUID:    "org-guid",
+				CreatedAt:           time.UnixMilli(1000).UTC(),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "",
+				"state": "CREATED",
+				"service_instance": {
+					"guid": "instance-guid",
+					"name": "my-instance",
+					"type": "managed_service_instance"
+				},
+				"service_plan": {
+					"guid": "plan-guid",
+					"name": "small"
+				},
+				"service_offering": {
+					"guid": "offering-guid",
+					"name": "db"
+				},
+				"service_broker": {
+					"guid": "broker-guid",
+					"name": "my-broker"
+				},
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_usage_events/event-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				record.ServiceInstanceType = "user_provided_service_instance"
+				record.ServicePlanGUID = ""
+				record.ServicePlanName = ""
+			})
+
+			It("presents the plan as null", func() {
+				Expect(output).To(MatchJSONPath("$.service_instance.type", "user_provided_service_instance"))
+				Expect(output).To(MatchJSONPath("$.service_plan.guid", BeNil()))
+				Expect(output).To(MatchJSONPath("$.service_plan.name", BeNil()))
+			})
+		})
+	})
+})
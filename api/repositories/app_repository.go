@@ -45,6 +45,7 @@ type AppRepo struct {
 	userClientFactory  authorization.UserClientFactory
 	appAwaiter         Awaiter[*korifiv1alpha1.CFApp]
 	sorter             AppSorter
+	rootNamespace      string
 }
 
 //counterfeiter:generate -o fake -fake-name AppSorter . AppSorter
@@ -95,12 +96,14 @@ func NewAppRepo(
 	userClientFactory authorization.UserClientFactory,
 	appAwaiter Awaiter[*korifiv1alpha1.CFApp],
 	sorter AppSorter,
+	rootNamespace string,
 ) *AppRepo {
 	return &AppRepo{
 		namespaceRetriever: namespaceRetriever,
 		userClientFactory:  userClientFactory,
 		appAwaiter:         appAwaiter,
 		sorter:             sorter,
+		rootNamespace:      rootNamespace,
 	}
 }
 
@@ -119,6 +122,7 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	EnableSSH             bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -167,6 +171,8 @@ type AppEnvRecord struct {
 	AppGUID              string
 	SpaceGUID            string
 	EnvironmentVariables map[string]string
+	StagingEnv           map[string]string
+	RunningEnv           map[string]string
 	SystemEnv            map[string]interface{}
 	AppEnv               map[string]interface{}
 }
@@ -191,6 +197,7 @@ type PatchAppMessage struct {
 	Name                 string
 	Lifecycle            *LifecyclePatch
 	EnvironmentVariables map[string]string
+	EnableSSH            *bool
 	MetadataPatch
 }
 
@@ -528,10 +535,22 @@ func (f *AppRepo) GetAppEnv(ctx context.Context, authInfo authorization.Info, ap
 		return AppEnvRecord{}, err
 	}
 
+	stagingEnvMap, err := getEnvVarGroupEnv(ctx, userClient, f.rootNamespace, korifiv1alpha1.StagingEnvVarGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
+	runningEnvMap, err := getEnvVarGroupEnv(ctx, userClient, f.rootNamespace, korifiv1alpha1.RunningEnvVarGroupName)
+	if err != nil {
+		return AppEnvRecord{}, err
+	}
+
 	appEnvRecord := AppEnvRecord{
 		AppGUID:              appGUID,
 		SpaceGUID:            app.SpaceGUID,
 		EnvironmentVariables: appEnvVarMap,
+		StagingEnv:           stagingEnvMap,
+		RunningEnv:           runningEnvMap,
 		SystemEnv:            systemEnvMap,
 		AppEnv:               appEnvMap,
 	}
@@ -646,6 +665,10 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 		}
 	}
 
+	if m.EnableSSH != nil {
+		app.Spec.EnableSSH = m.EnableSSH
+	}
+
 	m.MetadataPatch.Apply(app)
 }
 
@@ -671,6 +694,7 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady),
+		EnableSSH:             cfApp.SSHEnabled(),
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
@@ -63,7 +63,7 @@ var _ = Describe("AppRepository", func() {
 		userClientFactory = userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
 			return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
 		})
-		appRepo = repositories.NewAppRepo(namespaceRetriever, userClientFactory, appAwaiter, sorter)
+		appRepo = repositories.NewAppRepo(namespaceRetriever, userClientFactory, appAwaiter, sorter, rootNamespace)
 
 		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
@@ -716,6 +716,25 @@ var _ = Describe("AppRepository", func() {
 						Expect(cfApp.Spec.Lifecycle.Data.Stack).To(Equal(originalCFApp.Spec.Lifecycle.Data.Stack))
 					})
 				})
+
+				When("enable ssh is not specified", func() {
+					It("leaves ssh enabled", func() {
+						Expect(patchedAppRecord.EnableSSH).To(BeTrue())
+						Expect(cfApp.Spec.EnableSSH).To(BeNil())
+					})
+				})
+			})
+
+			When("ssh is disabled", func() {
+				BeforeEach(func() {
+					appPatchMessage.EnableSSH = tools.PtrTo(false)
+				})
+
+				It("disables ssh for the app", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(patchedAppRecord.EnableSSH).To(BeFalse())
+					Expect(cfApp.Spec.EnableSSH).To(PointTo(BeFalse()))
+				})
 			})
 		})
 
@@ -1126,6 +1145,27 @@ var _ = Describe("AppRepository", func() {
 				Expect(appEnvRecord.EnvironmentVariables).To(Equal(envVars))
 				Expect(appEnvRecord.SystemEnv).To(BeEmpty())
 				Expect(appEnvRecord.AppEnv).To(BeEmpty())
+				Expect(appEnvRecord.StagingEnv).To(BeEmpty())
+				Expect(appEnvRecord.RunningEnv).To(BeEmpty())
+			})
+
+			When("the environment variable groups are set", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: korifiv1alpha1.StagingEnvVarGroupName},
+						Spec:       korifiv1alpha1.CFEnvVarGroupSpec{Env: map[string]string{"STAGING": "yes"}},
+					})).To(Succeed())
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: korifiv1alpha1.RunningEnvVarGroupName},
+						Spec:       korifiv1alpha1.CFEnvVarGroupSpec{Env: map[string]string{"RUNNING": "yes"}},
+					})).To(Succeed())
+				})
+
+				It("returns the environment variable groups", func() {
+					Expect(getAppEnvErr).NotTo(HaveOccurred())
+					Expect(appEnvRecord.StagingEnv).To(Equal(map[string]string{"STAGING": "yes"}))
+					Expect(appEnvRecord.RunningEnv).To(Equal(map[string]string{"RUNNING": "yes"}))
+				})
 			})
 
 			When("the app has a service-binding secret", func() {
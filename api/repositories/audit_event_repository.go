@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+
+const (
+	AuditEventResourceType = "Audit Event"
+
+	AuditEventTargetTypeSpace        = "space"
+	AuditEventTargetTypeOrganization = "organization"
+)
+
+type AuditEventRecord struct {
+	GUID             string
+	Type             string
+	ActorGUID        string
+	ActorType        string
+	ActorName        string
+	TargetGUID       string
+	TargetType       string
+	TargetName       string
+	SpaceGUID        string
+	OrganizationGUID string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+type RecordAuditEventMessage struct {
+	Type             string
+	ActorGUID        string
+	ActorType        string
+	ActorName        string
+	TargetGUID       string
+	TargetType       string
+	TargetName       string
+	SpaceGUID        string
+	OrganizationGUID string
+}
+
+// TimestampFilter implements the CF timestamp filters, i.e. a list of exact
+// timestamps and/or the lt, lte, gt and gte relational operators
+type TimestampFilter struct {
+	Values             []time.Time
+	LessThan           *time.Time
+	LessThanOrEqual    *time.Time
+	GreaterThan        *time.Time
+	GreaterThanOrEqual *time.Time
+}
+
+func (f TimestampFilter) Matches(t time.Time) bool {
+	return (len(f.Values) == 0 || slices.ContainsFunc(f.Values, t.Equal)) &&
+		(f.LessThan == nil || t.Before(*f.LessThan)) &&
+		(f.LessThanOrEqual == nil || !t.After(*f.LessThanOrEqual)) &&
+		(f.GreaterThan == nil || t.After(*f.GreaterThan)) &&
+		(f.GreaterThanOrEqual == nil || !t.Before(*f.GreaterThanOrEqual))
+}
+
+type ListAuditEventsMessage struct {
+	GUIDs             []string
+	Types             []string
+	TargetGUIDs       []string
+	SpaceGUIDs        []string
+	OrganizationGUIDs []string
+	CreatedAts        TimestampFilter
+	OrderBy           string
+}
+
+func (m *ListAuditEventsMessage) matches(e korifiv1alpha1.CFAuditEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, e.Name) &&
+		tools.EmptyOrContains(m.Types, e.Spec.Type) &&
+		tools.EmptyOrContains(m.TargetGUIDs, e.Spec.Target.GUID) &&
+		tools.EmptyOrContains(m.SpaceGUIDs, e.Spec.SpaceGUID) &&
+		tools.EmptyOrContains(m.OrganizationGUIDs, e.Spec.OrganizationGUID) &&
+		m.CreatedAts.Matches(e.CreationTimestamp.Time)
+}
+
+type AuditEventRepo struct {
+	privilegedClient     client.Client
+	userClientFactory    authorization.UserClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
+}
+
+func NewAuditEventRepo(
+	privilegedClient client.Client,
+	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	rootNamespace string,
+) *AuditEventRepo {
+	return &AuditEventRepo{
+		privilegedClient:     privilegedClient,
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+// RecordAuditEvent stores the event on behalf of the actor. Events are written
+// with the privileged client so that users cannot forge or tamper with them.
+// Events about resources in a space are stored in the space namespace, events
+// about a space in the namespace of its organization and all the other events
+// in the root namespace, so that they outlive the deletion of their target
+// and are visible to the users with a role in the enclosing namespace.
+func (r *AuditEventRepo) RecordAuditEvent(ctx context.Context, message RecordAuditEventMessage) error {
+	if message.SpaceGUID != "" && message.OrganizationGUID == "" {
+		orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, message.SpaceGUID, SpaceResourceType)
+		if err != nil && !errors.As(err, &apierrors.NotFoundError{}) {
+			return err
+		}
+		message.OrganizationGUID = orgGUID
+	}
+
+	namespace := r.rootNamespace
+	switch {
+	case message.SpaceGUID != "" && message.TargetType != AuditEventTargetTypeSpace:
+		namespace = message.SpaceGUID
+	case message.OrganizationGUID != "" && message.TargetType != AuditEventTargetTypeOrganization:
+		namespace = message.OrganizationGUID
+	}
+
+	cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: message.Type,
+			Actor: korifiv1alpha1.AuditEventActor{
+				GUID: message.ActorGUID,
+				Type: message.ActorType,
+				Name: message.ActorName,
+			},
+			Target: korifiv1alpha1.AuditEventTarget{
+				GUID: message.TargetGUID,
+				Type: message.TargetType,
+				Name: message.TargetName,
+			},
+			SpaceGUID:        message.SpaceGUID,
+			OrganizationGUID: message.OrganizationGUID,
+		},
+	}
+
+	if err := r.privilegedClient.Create(ctx, cfAuditEvent); err != nil {
+		return fmt.Errorf("failed to create audit event: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return nil
+}
+
+func (r *AuditEventRepo) GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (AuditEventRecord, error) {
+	auditEvents, err := r.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return AuditEventRecord{}, err
+	}
+
+	if len(auditEvents) == 0 {
+		return AuditEventRecord{}, apierrors.NewNotFoundError(nil, AuditEventResourceType)
+	}
+
+	return auditEvents[0], nil
+}
+
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	authorizedSpaceNamespaces, err := authorizedSpaceNamespaces(ctx, authInfo, r.namespacePermissions)
+	if err != nil {
+		return nil, err
+	}
+	authorizedOrgNamespaces, err := authorizedOrgNamespaces(ctx, authInfo, r.namespacePermissions)
+	if err != nil {
+		return nil, err
+	}
+
+	nsList := append(authorizedSpaceNamespaces.Chain(authorizedOrgNamespaces).Collect(), r.rootNamespace)
+	cfAuditEvents := []korifiv1alpha1.CFAuditEvent{}
+	for _, ns := range nsList {
+		cfAuditEventList := &korifiv1alpha1.CFAuditEventList{}
+		err := userClient.List(ctx, cfAuditEventList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit events in namespace %s: %w", ns, apierrors.FromK8sError(err, AuditEventResourceType))
+		}
+		cfAuditEvents = append(cfAuditEvents, cfAuditEventList.Items...)
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfAuditEvents).Filter(message.matches), toAuditEventRecord))
+	slices.SortStableFunc(records, func(a, b AuditEventRecord) int {
+		if message.OrderBy == "-created_at" {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return records, nil
+}
+
+func toAuditEventRecord(cfAuditEvent korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	return AuditEventRecord{
+		GUID:             cfAuditEvent.Name,
+		Type:             cfAuditEvent.Spec.Type,
+		ActorGUID:        cfAuditEvent.Spec.Actor.GUID,
+		ActorType:        cfAuditEvent.Spec.Actor.Type,
+		ActorName:        cfAuditEvent.Spec.Actor.Name,
+		TargetGUID:       cfAuditEvent.Spec.Target.GUID,
+		TargetType:       cfAuditEvent.Spec.Target.Type,
+		TargetName:       cfAuditEvent.Spec.Target.Name,
+		SpaceGUID:        cfAuditEvent.Spec.SpaceGUID,
+		OrganizationGUID: cfAuditEvent.Spec.OrganizationGUID,
+		CreatedAt:        cfAuditEvent.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfAuditEvent),
+	}
+}
@@ -0,0 +1,269 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("AuditEventRepo", func() {
+	var (
+		repo  *repositories.AuditEventRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewAuditEventRepo(k8sClient, userClientFactory, namespaceRetriever, nsPerms, rootNamespace)
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+	})
+
+	listCFAuditEvents := func(namespace string) []korifiv1alpha1.CFAuditEvent {
+		cfAuditEvents := &korifiv1alpha1.CFAuditEventList{}
+		Expect(k8sClient.List(ctx, cfAuditEvents, client.InNamespace(namespace))).To(Succeed())
+		return cfAuditEvents.Items
+	}
+
+	recordEvent := func(message repositories.RecordAuditEventMessage) {
+		GinkgoHelper()
+
+		Expect(repo.RecordAuditEvent(ctx, message)).To(Succeed())
+	}
+
+	Describe("RecordAuditEvent", func() {
+		var (
+			message   repositories.RecordAuditEventMessage
+			recordErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.RecordAuditEventMessage{
+				Type:       "audit.app.start",
+				ActorGUID:  "alice",
+				ActorType:  "user",
+				ActorName:  "alice",
+				TargetGUID: "app-guid",
+				TargetType: "app",
+				TargetName: "my-app",
+				SpaceGUID:  space.Name,
+			}
+		})
+
+		JustBeforeEach(func() {
+			recordErr = repo.RecordAuditEvent(ctx, message)
+		})
+
+		It("stores the event in the space namespace", func() {
+			Expect(recordErr).NotTo(HaveOccurred())
+
+			cfAuditEvents := listCFAuditEvents(space.Name)
+			Expect(cfAuditEvents).To(HaveLen(1))
+			Expect(cfAuditEvents[0].Spec).To(Equal(korifiv1alpha1.CFAuditEventSpec{
+				Type:             "audit.app.start",
+				Actor:            korifiv1alpha1.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"},
+				Target:           korifiv1alpha1.AuditEventTarget{GUID: "app-guid", Type: "app", Name: "my-app"},
+				SpaceGUID:        space.Name,
+				OrganizationGUID: org.Name,
+			}))
+		})
+
+		When("the target is a space", func() {
+			BeforeEach(func() {
+				message.Type = "audit.space.update"
+				message.TargetGUID = space.Name
+				message.TargetType = repositories.AuditEventTargetTypeSpace
+			})
+
+			It("stores the event in the org namespace", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Expect(listCFAuditEvents(space.Name)).To(BeEmpty())
+				Expect(listCFAuditEvents(org.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Type":             Equal("audit.space.update"),
+						"SpaceGUID":        Equal(space.Name),
+						"OrganizationGUID": Equal(org.Name),
+					}),
+				})))
+			})
+		})
+
+		When("the target is an organization", func() {
+			BeforeEach(func() {
+				message.Type = "audit.organization.update"
+				message.TargetGUID = org.Name
+				message.TargetType = repositories.AuditEventTargetTypeOrganization
+				message.SpaceGUID = ""
+				message.OrganizationGUID = org.Name
+			})
+
+			It("stores the event in the root namespace", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Expect(listCFAuditEvents(org.Name)).To(BeEmpty())
+				Expect(listCFAuditEvents(rootNamespace)).To(HaveLen(1))
+			})
+		})
+
+		When("the event is not related to a space or organization", func() {
+			BeforeEach(func() {
+				message.Type = "audit.service_broker.create"
+				message.TargetType = "service_broker"
+				message.SpaceGUID = ""
+			})
+
+			It("stores the event in the root namespace", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Expect(listCFAuditEvents(rootNamespace)).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("ListAuditEvents", func() {
+		var (
+			otherSpace   *korifiv1alpha1.CFSpace
+			message      repositories.ListAuditEventsMessage
+			auditEvents  []repositories.AuditEventRecord
+			listErr      error
+			appEventGUID string
+		)
+
+		BeforeEach(func() {
+			otherSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+
+			recordEvent(repositories.RecordAuditEventMessage{Type: "audit.app.start", TargetGUID: "app-1", TargetType: "app", SpaceGUID: space.Name})
+			recordEvent(repositories.RecordAuditEventMessage{Type: "audit.app.stop", TargetGUID: "app-2", TargetType: "app", SpaceGUID: otherSpace.Name})
+			recordEvent(repositories.RecordAuditEventMessage{Type: "audit.service_broker.create", TargetGUID: "broker", TargetType: "service_broker"})
+
+			appEventGUID = listCFAuditEvents(space.Name)[0].Name
+			message = repositories.ListAuditEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			auditEvents, listErr = repo.ListAuditEvents(ctx, authInfo, message)
+		})
+
+		It("returns an empty list as the user has no roles", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(auditEvents).To(BeEmpty())
+		})
+
+		When("the user is a space developer in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the events of the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":             Equal(appEventGUID),
+					"Type":             Equal("audit.app.start"),
+					"TargetGUID":       Equal("app-1"),
+					"SpaceGUID":        Equal(space.Name),
+					"OrganizationGUID": Equal(org.Name),
+				})))
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, space.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, otherSpace.Name)
+			})
+
+			It("returns all the events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(auditEvents).To(HaveLen(3))
+			})
+
+			When("filtering by type", func() {
+				BeforeEach(func() {
+					message.Types = []string{"audit.app.stop"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"TargetGUID": Equal("app-2")})))
+				})
+			})
+
+			When("filtering by target guid", func() {
+				BeforeEach(func() {
+					message.TargetGUIDs = []string{"broker"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Type": Equal("audit.service_broker.create")})))
+				})
+			})
+
+			When("filtering by space guid", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{otherSpace.Name}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"TargetGUID": Equal("app-2")})))
+				})
+			})
+
+			When("filtering by creation time", func() {
+				BeforeEach(func() {
+					message.CreatedAts = repositories.TimestampFilter{GreaterThan: tools.PtrTo(time.Now().Add(time.Hour))}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(auditEvents).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("GetAuditEvent", func() {
+		var (
+			eventGUID  string
+			auditEvent repositories.AuditEventRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			recordEvent(repositories.RecordAuditEventMessage{Type: "audit.app.start", TargetGUID: "app-1", TargetType: "app", SpaceGUID: space.Name})
+			eventGUID = listCFAuditEvents(space.Name)[0].Name
+		})
+
+		JustBeforeEach(func() {
+			auditEvent, getErr = repo.GetAuditEvent(ctx, authInfo, eventGUID)
+		})
+
+		It("returns a not found error as the user has no roles", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space auditor in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("returns the event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(auditEvent.GUID).To(Equal(eventGUID))
+				Expect(auditEvent.Type).To(Equal("audit.app.start"))
+			})
+		})
+	})
+})
@@ -18,7 +18,9 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/go-logr/logr"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -31,11 +33,15 @@ type DeploymentRepo struct {
 }
 
 type DeploymentRecord struct {
-	GUID        string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DropletGUID string
-	Status      DeploymentStatus
+	GUID                string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+	DropletGUID         string
+	PreviousDropletGUID string
+	Status              DeploymentStatus
+	Strategy            DeploymentStrategy
+	CanarySteps         []CanaryStep
+	CanaryCurrentStep   int
 }
 
 func (r DeploymentRecord) Relationships() map[string]string {
@@ -87,6 +93,8 @@ type DeploymentStatusReason string
 const (
 	DeploymentStatusReasonDeploying DeploymentStatusReason = "DEPLOYING"
 	DeploymentStatusReasonDeployed  DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonPaused    DeploymentStatusReason = "PAUSED"
+	DeploymentStatusReasonCanceled  DeploymentStatusReason = "CANCELED"
 )
 
 type DeploymentStatus struct {
@@ -94,9 +102,24 @@ type DeploymentStatus struct {
 	Reason DeploymentStatusReason
 }
 
+type DeploymentStrategy string
+
+const (
+	DeploymentStrategyRolling DeploymentStrategy = "rolling"
+	DeploymentStrategyCanary  DeploymentStrategy = "canary"
+)
+
+type CanaryStep struct {
+	Instances int32
+	Weight    *int32
+}
+
 type CreateDeploymentMessage struct {
-	AppGUID     string
-	DropletGUID string
+	AppGUID      string
+	DropletGUID  string
+	RevisionGUID string
+	Strategy     DeploymentStrategy
+	CanarySteps  []CanaryStep
 }
 
 type ListDeploymentsMessage struct {
@@ -171,6 +194,17 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		dropletGUID = message.DropletGUID
 	}
 
+	if message.RevisionGUID != "" {
+		dropletGUID, err = rollBackToRevision(ctx, userClient, app, message.RevisionGUID)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+	}
+
+	if message.Strategy == DeploymentStrategyCanary {
+		return createCanaryDeployment(ctx, userClient, app, dropletGUID, message.CanarySteps)
+	}
+
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	newRev, err := bumpAppRev(appRev)
 	if err != nil {
@@ -178,12 +212,84 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 	}
 
 	err = k8s.PatchResource(ctx, userClient, app, func() {
+		setDeploymentStrategy(app, DeploymentStrategyRolling)
+		app.Annotations[korifiv1alpha1.CFAppPreviousDropletKey] = app.Spec.CurrentDropletRef.Name
+		app.Annotations[korifiv1alpha1.CFAppPreviousRevisionKey] = appRev
 		app.Spec.CurrentDropletRef.Name = dropletGUID
-		if app.Annotations == nil {
-			app.Annotations = map[string]string{}
-		}
 		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
 		app.Spec.DesiredState = korifiv1alpha1.StartedState
+		app.Spec.Canary = nil
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app), nil
+}
+
+// createCanaryDeployment runs the droplet alongside the current one, leaving
+// the current droplet in place until the last canary step is continued
+func createCanaryDeployment(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, dropletGUID string, steps []CanaryStep) (DeploymentRecord, error) {
+	if app.Spec.CurrentDropletRef.Name == "" || app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Canary deployments require the app to be started")
+	}
+
+	if len(steps) == 0 {
+		steps = []CanaryStep{{Instances: 1}}
+	}
+
+	err := k8s.PatchResource(ctx, userClient, app, func() {
+		setDeploymentStrategy(app, DeploymentStrategyCanary)
+		app.Spec.Canary = &korifiv1alpha1.CFAppCanary{
+			DropletRef: corev1.LocalObjectReference{Name: dropletGUID},
+			Steps: slices.Collect(it.Map(slices.Values(steps), func(step CanaryStep) korifiv1alpha1.CanaryStep {
+				return korifiv1alpha1.CanaryStep{
+					Instances: step.Instances,
+					Weight:    step.Weight,
+				}
+			})),
+		}
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app), nil
+}
+
+func (r *DeploymentRepo) ContinueDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	userClient, app, err := r.getDeploymentApp(ctx, authInfo, deploymentGUID)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	deployment := appToDeploymentRecord(*app)
+	if deployment.Status.Reason != DeploymentStatusReasonPaused {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Cannot continue a deployment with status: %s and reason: %s", deployment.Status.Value, deployment.Status.Reason,
+		))
+	}
+
+	if app.Spec.Canary.CurrentStep < len(app.Spec.Canary.Steps)-1 {
+		err = k8s.PatchResource(ctx, userClient, app, func() {
+			app.Spec.Canary.CurrentStep++
+		})
+		if err != nil {
+			return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+		}
+
+		return appToDeploymentRecord(*app), nil
+	}
+
+	newRev, err := bumpAppRev(app.Annotations[korifiv1alpha1.CFAppRevisionKey])
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("expected app-rev to be an integer: %w", err)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, app, func() {
+		app.Spec.CurrentDropletRef = app.Spec.Canary.DropletRef
+		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
+		app.Spec.Canary = nil
 	})
 	if err != nil {
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
@@ -192,6 +298,100 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 	return appToDeploymentRecord(*app), nil
 }
 
+func (r *DeploymentRepo) CancelDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	userClient, app, err := r.getDeploymentApp(ctx, authInfo, deploymentGUID)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	deployment := appToDeploymentRecord(*app)
+	if deployment.Status.Value != DeploymentStatusValueActive {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Cannot cancel a deployment with status: %s and reason: %s", deployment.Status.Value, deployment.Status.Reason,
+		))
+	}
+
+	if app.Spec.Canary != nil {
+		err = k8s.PatchResource(ctx, userClient, app, func() {
+			app.Annotations[korifiv1alpha1.CFAppDeploymentCanceledKey] = "true"
+			app.Spec.Canary = nil
+		})
+		if err != nil {
+			return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+		}
+
+		return appToDeploymentRecord(*app), nil
+	}
+
+	return cancelRollingDeployment(ctx, userClient, app)
+}
+
+// cancelRollingDeployment restores the droplet and app-rev the app had before
+// the deployment, so that its instances are rolled back to them
+func cancelRollingDeployment(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp) (DeploymentRecord, error) {
+	previousDroplet := app.Annotations[korifiv1alpha1.CFAppPreviousDropletKey]
+	previousRev, hasPreviousRev := app.Annotations[korifiv1alpha1.CFAppPreviousRevisionKey]
+	if previousDroplet == "" || !hasPreviousRev {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot cancel a deployment without a previous droplet to roll back to")
+	}
+
+	deployedRev, err := bumpAppRev(previousRev)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("expected previous app-rev to be an integer: %w", err)
+	}
+
+	if app.Annotations[korifiv1alpha1.CFAppRevisionKey] != deployedRev {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot cancel a deployment after the app has been changed")
+	}
+
+	err = k8s.PatchResource(ctx, userClient, app, func() {
+		app.Annotations[korifiv1alpha1.CFAppDeploymentCanceledKey] = "true"
+		app.Annotations[korifiv1alpha1.CFAppRevisionKey] = previousRev
+		app.Spec.CurrentDropletRef.Name = previousDroplet
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app), nil
+}
+
+func (r *DeploymentRepo) getDeploymentApp(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (client.Client, *korifiv1alpha1.CFApp, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, AppResourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	app := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, app)
+	if err != nil {
+		return nil, nil, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	if app.Annotations == nil {
+		app.Annotations = map[string]string{}
+	}
+
+	return userClient, app, nil
+}
+
+// setDeploymentStrategy records the strategy of the latest deployment of the
+// app, clearing the outcome of any previous one
+func setDeploymentStrategy(app *korifiv1alpha1.CFApp, strategy DeploymentStrategy) {
+	if app.Annotations == nil {
+		app.Annotations = map[string]string{}
+	}
+	app.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey] = string(strategy)
+	delete(app.Annotations, korifiv1alpha1.CFAppDeploymentCanceledKey)
+	delete(app.Annotations, korifiv1alpha1.CFAppPreviousDropletKey)
+	delete(app.Annotations, korifiv1alpha1.CFAppPreviousRevisionKey)
+}
+
 func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authorization.Info, message ListDeploymentsMessage) ([]DeploymentRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -210,6 +410,98 @@ func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authoriza
 	return r.sorter.Sort(slices.Collect(deploymentRecords), message.OrderBy), nil
 }
 
+// rollBackToRevision restores the environment variables and process commands
+// recorded in the revision and returns the droplet the app should run
+func rollBackToRevision(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, revisionGUID string) (string, error) {
+	revision := &korifiv1alpha1.CFRevision{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revisionGUID}, revision)
+	if err != nil {
+		return "", apierrors.AsUnprocessableEntity(
+			apierrors.FromK8sError(err, RevisionResourceType),
+			"The revision does not exist",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	if revision.Spec.AppRef.Name != app.Name {
+		return "", apierrors.NewUnprocessableEntityError(nil, "The revision does not belong to the app")
+	}
+
+	build := &korifiv1alpha1.CFBuild{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revision.Spec.DropletRef.Name}, build)
+	if err != nil {
+		return "", apierrors.AsUnprocessableEntity(
+			apierrors.FromK8sError(err, DropletResourceType),
+			"Unable to deploy this revision, the droplet for this revision no longer exists.",
+			apierrors.NotFoundError{},
+		)
+	}
+
+	if err = restoreRevisionEnv(ctx, userClient, app, revision); err != nil {
+		return "", err
+	}
+
+	if err = restoreRevisionProcessCommands(ctx, userClient, app, revision); err != nil {
+		return "", err
+	}
+
+	return revision.Spec.DropletRef.Name, nil
+}
+
+func restoreRevisionEnv(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFRevision) error {
+	if app.Spec.EnvSecretName == "" || revision.Spec.EnvSecretName == "" {
+		return nil
+	}
+
+	revisionEnvSecret := &corev1.Secret{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: revision.Spec.EnvSecretName}, revisionEnvSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get revision env secret: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	appEnvSecret := &corev1.Secret{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: app.Namespace, Name: app.Spec.EnvSecretName}, appEnvSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get app env secret: %w", apierrors.FromK8sError(err, AppEnvResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, appEnvSecret, func() {
+		appEnvSecret.Data = revisionEnvSecret.Data
+		appEnvSecret.StringData = nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore app env: %w", apierrors.FromK8sError(err, AppEnvResourceType))
+	}
+
+	return nil
+}
+
+func restoreRevisionProcessCommands(ctx context.Context, userClient client.Client, app *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFRevision) error {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := userClient.List(ctx, processList, client.InNamespace(app.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: app.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list app processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	for i := range processList.Items {
+		process := &processList.Items[i]
+		command, ok := revision.Spec.ProcessCommands[process.Spec.ProcessType]
+		if !ok || command == process.Spec.Command {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, userClient, process, func() {
+			process.Spec.Command = command
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore process command: %w", apierrors.FromK8sError(err, ProcessResourceType))
+		}
+	}
+
+	return nil
+}
+
 func bumpAppRev(appRev string) (string, error) {
 	r, err := strconv.Atoi(appRev)
 	if err != nil {
@@ -221,14 +513,47 @@ func bumpAppRev(appRev string) (string, error) {
 
 func appToDeploymentRecord(cfApp korifiv1alpha1.CFApp) DeploymentRecord {
 	deploymentRecord := DeploymentRecord{
-		GUID:        cfApp.Name,
-		CreatedAt:   cfApp.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfApp),
-		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
+		GUID:                cfApp.Name,
+		CreatedAt:           cfApp.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&cfApp),
+		DropletGUID:         cfApp.Spec.CurrentDropletRef.Name,
+		PreviousDropletGUID: cfApp.Annotations[korifiv1alpha1.CFAppPreviousDropletKey],
 		Status: DeploymentStatus{
 			Value:  DeploymentStatusValueActive,
 			Reason: DeploymentStatusReasonDeploying,
 		},
+		Strategy: DeploymentStrategyRolling,
+	}
+
+	if strategy, ok := cfApp.Annotations[korifiv1alpha1.CFAppDeploymentStrategyKey]; ok {
+		deploymentRecord.Strategy = DeploymentStrategy(strategy)
+	}
+
+	if cfApp.Annotations[korifiv1alpha1.CFAppDeploymentCanceledKey] == "true" {
+		deploymentRecord.Status = DeploymentStatus{
+			Value:  DeploymentStatusValueFinalized,
+			Reason: DeploymentStatusReasonCanceled,
+		}
+		return deploymentRecord
+	}
+
+	if canary := cfApp.Spec.Canary; canary != nil {
+		deploymentRecord.DropletGUID = canary.DropletRef.Name
+		deploymentRecord.PreviousDropletGUID = cfApp.Spec.CurrentDropletRef.Name
+		deploymentRecord.CanaryCurrentStep = canary.CurrentStep
+		deploymentRecord.CanarySteps = slices.Collect(it.Map(slices.Values(canary.Steps), func(step korifiv1alpha1.CanaryStep) CanaryStep {
+			return CanaryStep{
+				Instances: step.Instances,
+				Weight:    step.Weight,
+			}
+		}))
+
+		stepReady := meta.FindStatusCondition(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionCanaryStepReady)
+		if stepReady != nil && stepReady.Status == metav1.ConditionTrue && stepReady.ObservedGeneration == cfApp.Generation {
+			deploymentRecord.Status.Reason = DeploymentStatusReasonPaused
+		}
+
+		return deploymentRecord
 	}
 
 	if meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady) {
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -227,6 +228,74 @@ var _ = Describe("DeploymentRepository", func() {
 					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
 					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(newDropletGUID))
 				})
+
+				It("records the previous droplet and app-rev of the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.PreviousDropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
+
+					previousDropletGUID := cfApp.Spec.CurrentDropletRef.Name
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppPreviousDropletKey, previousDropletGUID))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppPreviousRevisionKey, "1"))
+				})
+			})
+
+			When("revision guid is set on the create message", func() {
+				var (
+					revision *korifiv1alpha1.CFRevision
+					process  *korifiv1alpha1.CFProcess
+				)
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfApp.Namespace,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						StringData: map[string]string{"FOO": "current"},
+					})).To(Succeed())
+
+					process = createProcessCR(ctx, k8sClient, uuid.NewString(), cfSpace.Name, cfApp.Name)
+
+					revisionDropletGUID := uuid.NewString()
+					createDropletCR(ctx, k8sClient, revisionDropletGUID, cfApp.Name, cfSpace.Name)
+					revision = createRevision(ctx, cfApp, 1, revisionDropletGUID, map[string]string{"FOO": "old"})
+					createDeploymentMessage.RevisionGUID = revision.Name
+				})
+
+				It("rolls the app back to the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(revision.Spec.DropletRef.Name))
+
+					envSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, envSecret)).To(Succeed())
+					Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("old")}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+					Expect(process.Spec.Command).To(Equal("custom command"))
+				})
+
+				When("the revision does not exist", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = "i-do-not-exist"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the revision droplet no longer exists", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = createRevision(ctx, cfApp, 2, "i-do-not-exist", nil).Name
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
 			})
 
 			When("the app does not exist", func() {
@@ -256,6 +325,275 @@ var _ = Describe("DeploymentRepository", func() {
 					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
 				})
 			})
+
+			When("the strategy is canary", func() {
+				var currentDropletGUID string
+
+				BeforeEach(func() {
+					currentDropletGUID = cfApp.Spec.CurrentDropletRef.Name
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+					})).To(Succeed())
+
+					createDeploymentMessage.DropletGUID = "canary-droplet"
+					createDeploymentMessage.Strategy = repositories.DeploymentStrategyCanary
+					createDeploymentMessage.CanarySteps = []repositories.CanaryStep{
+						{Instances: 1, Weight: tools.PtrTo[int32](10)},
+						{Instances: 2},
+					}
+				})
+
+				It("starts a canary deployment", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(deployment.Strategy).To(Equal(repositories.DeploymentStrategyCanary))
+					Expect(deployment.DropletGUID).To(Equal("canary-droplet"))
+					Expect(deployment.CanaryCurrentStep).To(BeZero())
+					Expect(deployment.CanarySteps).To(Equal(createDeploymentMessage.CanarySteps))
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Canary).To(PointTo(Equal(korifiv1alpha1.CFAppCanary{
+						DropletRef: corev1.LocalObjectReference{Name: "canary-droplet"},
+						Steps: []korifiv1alpha1.CanaryStep{
+							{Instances: 1, Weight: tools.PtrTo[int32](10)},
+							{Instances: 2},
+						},
+					})))
+				})
+
+				It("keeps the current droplet and app-rev", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(currentDropletGUID))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, CFAppRevisionValue))
+				})
+
+				When("no steps are specified", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.CanarySteps = nil
+					})
+
+					It("defaults to a single step with one instance", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						Expect(deployment.CanarySteps).To(Equal([]repositories.CanaryStep{{Instances: 1}}))
+					})
+				})
+
+				When("the app is stopped", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("ContinueDeployment", func() {
+		var (
+			deployment  repositories.DeploymentRecord
+			continueErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				cfApp.Spec.Canary = &korifiv1alpha1.CFAppCanary{
+					DropletRef: corev1.LocalObjectReference{Name: "canary-droplet"},
+					Steps:      []korifiv1alpha1.CanaryStep{{Instances: 1}, {Instances: 2}},
+				}
+			})).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+				meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+					Type:               korifiv1alpha1.StatusConditionCanaryStepReady,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: cfApp.Generation,
+					Reason:             "CanaryInstancesRunning",
+				})
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, continueErr = deploymentRepo.ContinueDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("moves on to the next canary step", func() {
+				Expect(continueErr).NotTo(HaveOccurred())
+				Expect(deployment.CanaryCurrentStep).To(Equal(1))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Canary.CurrentStep).To(Equal(1))
+			})
+
+			When("the deployment is on its last step", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary.CurrentStep = 1
+					})).To(Succeed())
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.StatusConditionCanaryStepReady,
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: cfApp.Generation,
+							Reason:             "CanaryInstancesRunning",
+						})
+					})).To(Succeed())
+				})
+
+				It("rolls the canary droplet out to all instances", func() {
+					Expect(continueErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Canary).To(BeNil())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal("canary-droplet"))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "2"))
+				})
+			})
+
+			When("the deployment is not paused", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						meta.RemoveStatusCondition(&cfApp.Status.Conditions, korifiv1alpha1.StatusConditionCanaryStepReady)
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(continueErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(continueErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("Cannot continue a deployment with status: ACTIVE and reason: DEPLOYING"))
+				})
+			})
+		})
+	})
+
+	Describe("CancelDeployment", func() {
+		var (
+			deployment repositories.DeploymentRecord
+			cancelErr  error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				cfApp.Spec.Canary = &korifiv1alpha1.CFAppCanary{
+					DropletRef: corev1.LocalObjectReference{Name: "canary-droplet"},
+					Steps:      []korifiv1alpha1.CanaryStep{{Instances: 1}},
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("cancels the canary deployment", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceled))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Canary).To(BeNil())
+				Expect(cfApp.Spec.CurrentDropletRef.Name).NotTo(Equal("canary-droplet"))
+			})
+
+			When("a rolling deployment is in progress", func() {
+				var previousDropletGUID string
+
+				BeforeEach(func() {
+					previousDropletGUID = cfApp.Spec.CurrentDropletRef.Name
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+						cfApp.Spec.CurrentDropletRef.Name = "new-droplet"
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "2"
+						cfApp.Annotations[korifiv1alpha1.CFAppPreviousRevisionKey] = "1"
+						cfApp.Annotations[korifiv1alpha1.CFAppPreviousDropletKey] = previousDropletGUID
+					})).To(Succeed())
+				})
+
+				It("cancels the deployment", func() {
+					Expect(cancelErr).NotTo(HaveOccurred())
+					Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+					Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceled))
+				})
+
+				It("restores the previous droplet and app-rev of the app", func() {
+					Expect(cancelErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(previousDropletGUID))
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "1"))
+				})
+
+				When("the app has been changed since the deployment", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "3"
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(cancelErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("Cannot cancel a deployment after the app has been changed"))
+					})
+				})
+
+				When("the deployment has already been deployed", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+								Type:   korifiv1alpha1.StatusConditionReady,
+								Status: metav1.ConditionTrue,
+								Reason: "ready",
+							})
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(cancelErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("Cannot cancel a deployment with status: FINALIZED and reason: DEPLOYED"))
+					})
+				})
+			})
+
+			When("there is no deployment to roll back from", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
 		})
 	})
 
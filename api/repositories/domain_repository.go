@@ -46,6 +46,8 @@ func NewDomainRepo(
 type DomainRecord struct {
 	Name        string
 	GUID        string
+	Internal    bool
+	RouterGroup string
 	Labels      map[string]string
 	Annotations map[string]string
 	Namespace   string
@@ -59,8 +61,10 @@ func (r DomainRecord) GetResourceType() string {
 }
 
 type CreateDomainMessage struct {
-	Name     string
-	Metadata Metadata
+	Name        string
+	Internal    bool
+	RouterGroup string
+	Metadata    Metadata
 }
 
 type UpdateDomainMessage struct {
@@ -110,7 +114,9 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFDomainSpec{
-			Name: message.Name,
+			Name:        message.Name,
+			Internal:    message.Internal,
+			RouterGroup: message.RouterGroup,
 		},
 	}
 
@@ -206,6 +212,8 @@ func cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
 	return DomainRecord{
 		Name:        cfDomain.Spec.Name,
 		GUID:        cfDomain.Name,
+		Internal:    cfDomain.Spec.Internal,
+		RouterGroup: cfDomain.Spec.RouterGroup,
 		Namespace:   cfDomain.Namespace,
 		CreatedAt:   cfDomain.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(&cfDomain),
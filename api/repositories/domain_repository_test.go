@@ -140,6 +140,38 @@ var _ = Describe("DomainRepository", func() {
 				Expect(createdCFDomain.Labels).To(HaveKeyWithValue("foo", "bar"))
 				Expect(createdCFDomain.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					domainCreate.Name = "apps.internal"
+					domainCreate.Internal = true
+				})
+
+				It("creates an internal domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.Internal).To(BeTrue())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.Internal).To(BeTrue())
+				})
+			})
+
+			When("the domain has a router group", func() {
+				BeforeEach(func() {
+					domainCreate.Name = "tcp.my.domain"
+					domainCreate.RouterGroup = "default-tcp"
+				})
+
+				It("creates a domain with the router group", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.RouterGroup).To(Equal("default-tcp"))
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.RouterGroup).To(Equal("default-tcp"))
+				})
+			})
 		})
 	})
 
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
@@ -13,7 +14,11 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -22,20 +27,30 @@ import (
 
 const (
 	DropletResourceType = "Droplet"
+
+	DropletStateAwaitingUpload   = "AWAITING_UPLOAD"
+	DropletStateProcessingUpload = "PROCESSING_UPLOAD"
+	DropletStateStaged           = "STAGED"
 )
 
 type DropletRepo struct {
 	userClientFactory  authorization.UserClientFactory
 	namespaceRetriever NamespaceRetriever
+	repositoryCreator  RepositoryCreator
+	repositoryPrefix   string
 }
 
 func NewDropletRepo(
 	userClientFactory authorization.UserClientFactory,
 	namespaceRetriever NamespaceRetriever,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *DropletRepo {
 	return &DropletRepo{
 		userClientFactory:  userClientFactory,
 		namespaceRetriever: namespaceRetriever,
+		repositoryCreator:  repositoryCreator,
+		repositoryPrefix:   repositoryPrefix,
 	}
 }
 
@@ -50,10 +65,15 @@ type DropletRecord struct {
 	ProcessTypes    map[string]string
 	AppGUID         string
 	PackageGUID     string
+	SpaceGUID       string
 	Labels          map[string]string
 	Annotations     map[string]string
 	Image           string
 	Ports           []int32
+	// ImageRef is the repository droplet bits are uploaded to
+	ImageRef string
+	// DropletImageRef is the image containing the droplet bits
+	DropletImageRef string
 }
 
 func (r DropletRecord) Relationships() map[string]string {
@@ -84,7 +104,20 @@ func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Inf
 		return DropletRecord{}, err
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
+}
+
+func (r *DropletRepo) GetState(ctx context.Context, authInfo authorization.Info, dropletGUID string) (model.CFResourceState, error) {
+	build, _, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
+	if err != nil {
+		return model.CFResourceStateUnknown, err
+	}
+
+	if meta.IsStatusConditionTrue(build.Status.Conditions, korifiv1alpha1.SucceededConditionType) {
+		return model.CFResourceStateReady, nil
+	}
+
+	return model.CFResourceStateUnknown, nil
 }
 
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, client.WithWatch, error) {
@@ -107,26 +140,25 @@ func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInf
 	return &build, userClient, nil
 }
 
-func cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+func (r *DropletRepo) cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
 	stagingStatus := getConditionValue(&cfBuild.Status.Conditions, StagingConditionType)
 	succeededStatus := getConditionValue(&cfBuild.Status.Conditions, SucceededConditionType)
 	if stagingStatus == metav1.ConditionFalse &&
 		succeededStatus == metav1.ConditionTrue {
-		return cfBuildToDropletRecord(*cfBuild), nil
+		return r.cfBuildToDropletRecord(*cfBuild), nil
 	}
-	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
-}
 
-func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
-	processTypesMap := make(map[string]string)
-	processTypesArrayObject := cfBuild.Status.Droplet.ProcessTypes
-	for index := range processTypesArrayObject {
-		processTypesMap[processTypesArrayObject[index].Type] = processTypesArrayObject[index].Command
+	if cfBuild.Spec.UploadedDroplet != nil && succeededStatus != metav1.ConditionFalse {
+		return r.cfBuildToDropletRecord(*cfBuild), nil
 	}
 
+	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
+}
+
+func (r *DropletRepo) cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 	result := DropletRecord{
 		GUID:      cfBuild.Name,
-		State:     "STAGED",
+		State:     dropletState(cfBuild),
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
 		Lifecycle: Lifecycle{
@@ -136,23 +168,59 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 				Stack:      cfBuild.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		Stack:        cfBuild.Status.Droplet.Stack,
-		ProcessTypes: processTypesMap,
+		ProcessTypes: map[string]string{},
 		AppGUID:      cfBuild.Spec.AppRef.Name,
 		PackageGUID:  cfBuild.Spec.PackageRef.Name,
+		SpaceGUID:    cfBuild.Namespace,
 		Labels:       cfBuild.Labels,
 		Annotations:  cfBuild.Annotations,
-		Ports:        cfBuild.Status.Droplet.Ports,
+		ImageRef:     r.repositoryRef(cfBuild),
+	}
+
+	if cfBuild.Spec.UploadedDroplet != nil {
+		result.ProcessTypes = toProcessTypesMap(cfBuild.Spec.UploadedDroplet.ProcessTypes)
+		result.DropletImageRef = cfBuild.Spec.UploadedDroplet.Registry.Image
+	}
+
+	if cfBuild.Status.Droplet != nil {
+		result.Stack = cfBuild.Status.Droplet.Stack
+		result.ProcessTypes = toProcessTypesMap(cfBuild.Status.Droplet.ProcessTypes)
+		result.Ports = cfBuild.Status.Droplet.Ports
+		result.DropletImageRef = cfBuild.Status.Droplet.Registry.Image
 	}
 
 	if cfBuild.Spec.Lifecycle.Type == "docker" {
 		result.Lifecycle.Data = LifecycleData{}
-		result.Image = cfBuild.Status.Droplet.Registry.Image
+		result.Image = result.DropletImageRef
 	}
 
 	return result
 }
 
+func dropletState(cfBuild korifiv1alpha1.CFBuild) string {
+	if cfBuild.Spec.UploadedDroplet == nil || meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType) {
+		return DropletStateStaged
+	}
+
+	if cfBuild.Spec.UploadedDroplet.Registry.Image == "" {
+		return DropletStateAwaitingUpload
+	}
+
+	return DropletStateProcessingUpload
+}
+
+func toProcessTypesMap(processTypes []korifiv1alpha1.ProcessType) map[string]string {
+	processTypesMap := make(map[string]string)
+	for _, processType := range processTypes {
+		processTypesMap[processType.Type] = processType.Command
+	}
+	return processTypesMap
+}
+
+func (r *DropletRepo) repositoryRef(cfBuild korifiv1alpha1.CFBuild) string {
+	return r.repositoryPrefix + cfBuild.Spec.AppRef.Name + "-droplets"
+}
+
 func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.Info, message ListDropletsMessage) ([]DropletRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -166,7 +234,7 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 	}
 
 	filteredBuilds := itx.FromSlice(buildList.Items)
-	return slices.Collect(it.Map(filteredBuilds, cfBuildToDropletRecord)), nil
+	return slices.Collect(it.Map(filteredBuilds, r.cfBuildToDropletRecord)), nil
 }
 
 type UpdateDropletMessage struct {
@@ -187,5 +255,107 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
+}
+
+type CreateDropletMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Stack        string
+	ProcessTypes map[string]string
+	Labels       map[string]string
+	Annotations  map[string]string
+}
+
+func (m CreateDropletMessage) toCFBuild() korifiv1alpha1.CFBuild {
+	processTypes := []korifiv1alpha1.ProcessType{}
+	for _, processType := range slices.Sorted(maps.Keys(m.ProcessTypes)) {
+		processTypes = append(processTypes, korifiv1alpha1.ProcessType{
+			Type:    processType,
+			Command: m.ProcessTypes[processType],
+		})
+	}
+
+	return korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      m.Labels,
+			Annotations: m.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: m.AppGUID,
+			},
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: "buildpack",
+				Data: korifiv1alpha1.LifecycleData{
+					Stack: m.Stack,
+				},
+			},
+			UploadedDroplet: &korifiv1alpha1.UploadedDroplet{
+				ProcessTypes: processTypes,
+			},
+		},
+	}
+}
+
+// CreateDroplet creates a droplet awaiting the upload of bits built outside
+// the cluster
+func (r *DropletRepo) CreateDroplet(ctx context.Context, authInfo authorization.Info, message CreateDropletMessage) (DropletRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfBuild := message.toCFBuild()
+	if err = userClient.Create(ctx, &cfBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	if err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(cfBuild)); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+	}
+
+	return r.cfBuildToDropletRecord(cfBuild), nil
+}
+
+type UpdateDropletSourceMessage struct {
+	GUID                string
+	SpaceGUID           string
+	ImageRef            string
+	RegistrySecretNames []string
+}
+
+// UpdateDropletSource sets the image containing the bits of an uploaded
+// droplet. The droplet is staged once the build controller picks it up.
+func (r *DropletRepo) UpdateDropletSource(ctx context.Context, authInfo authorization.Info, message UpdateDropletSourceMessage) (DropletRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfBuild := &korifiv1alpha1.CFBuild{}
+	if err = userClient.Get(ctx, client.ObjectKey{Name: message.GUID, Namespace: message.SpaceGUID}, cfBuild); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to get droplet: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	if cfBuild.Spec.UploadedDroplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Droplet bits can only be uploaded to droplets created for upload.")
+	}
+
+	if err = k8s.PatchResource(ctx, userClient, cfBuild, func() {
+		cfBuild.Spec.UploadedDroplet.Registry = korifiv1alpha1.Registry{
+			Image: message.ImageRef,
+			ImagePullSecrets: slices.Collect(
+				it.Map(slices.Values(message.RegistrySecretNames), func(secret string) corev1.LocalObjectReference {
+					return corev1.LocalObjectReference{Name: secret}
+				}),
+			),
+		}
+	}); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to update droplet source: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfBuildToDropletRecord(*cfBuild), nil
 }
@@ -1,12 +1,15 @@
 package repositories_test
 
 import (
+	"errors"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -33,6 +36,7 @@ var _ = Describe("DropletRepository", func() {
 
 	var (
 		dropletRepo *repositories.DropletRepo
+		repoCreator *fake.RepositoryCreator
 		org         *korifiv1alpha1.CFOrg
 		space       *korifiv1alpha1.CFSpace
 		build       *korifiv1alpha1.CFBuild
@@ -48,11 +52,14 @@ var _ = Describe("DropletRepository", func() {
 		org = createOrgWithCleanup(ctx, orgName)
 		space = createSpaceWithCleanup(ctx, org.Name, spaceName)
 
+		repoCreator = new(fake.RepositoryCreator)
 		dropletRepo = repositories.NewDropletRepo(
 			userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
 				return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
 			}),
 			namespaceRetriever,
+			repoCreator,
+			"container.registry/foo/my/prefix-",
 		)
 
 		build = &korifiv1alpha1.CFBuild{
@@ -162,6 +169,9 @@ var _ = Describe("DropletRepository", func() {
 					Expect(dropletRecord.Ports).To(ConsistOf(int32(1234), int32(2345)))
 					Expect(dropletRecord.AppGUID).To(Equal(build.Spec.AppRef.Name))
 					Expect(dropletRecord.PackageGUID).To(Equal(build.Spec.PackageRef.Name))
+					Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+					Expect(dropletRecord.ImageRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+					Expect(dropletRecord.DropletImageRef).To(Equal(registryImage))
 					Expect(dropletRecord.Labels).To(Equal(map[string]string{
 						"key1":                               "val1",
 						"key2":                               "val2",
@@ -268,6 +278,63 @@ var _ = Describe("DropletRepository", func() {
 				})
 			})
 
+			When("the build is for an uploaded droplet", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, build, func() {
+						build.Spec.UploadedDroplet = &korifiv1alpha1.UploadedDroplet{
+							ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+						}
+					})).To(Succeed())
+				})
+
+				It("returns a droplet awaiting upload", func() {
+					Expect(fetchErr).NotTo(HaveOccurred())
+					Expect(dropletRecord.State).To(Equal("AWAITING_UPLOAD"))
+					Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+					Expect(dropletRecord.DropletImageRef).To(BeEmpty())
+				})
+
+				When("the droplet image has been uploaded", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, build, func() {
+							build.Spec.UploadedDroplet.Registry.Image = "my-droplet@sha256:123"
+						})).To(Succeed())
+					})
+
+					It("returns a droplet processing the upload", func() {
+						Expect(fetchErr).NotTo(HaveOccurred())
+						Expect(dropletRecord.State).To(Equal("PROCESSING_UPLOAD"))
+						Expect(dropletRecord.DropletImageRef).To(Equal("my-droplet@sha256:123"))
+					})
+
+					When("the build has succeeded", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(ctx, k8sClient, build, func() {
+								meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+									Type:   "Staging",
+									Status: metav1.ConditionFalse,
+									Reason: "BuildNotRunning",
+								})
+								meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+									Type:   "Succeeded",
+									Status: metav1.ConditionTrue,
+									Reason: "DropletUploaded",
+								})
+								build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+									Registry:     korifiv1alpha1.Registry{Image: "my-droplet@sha256:123"},
+									ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+								}
+							})).To(Succeed())
+						})
+
+						It("returns a staged droplet", func() {
+							Expect(fetchErr).NotTo(HaveOccurred())
+							Expect(dropletRecord.State).To(Equal("STAGED"))
+						})
+					})
+				})
+			})
+
 			When("build does not exist", func() {
 				BeforeEach(func() {
 					meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
@@ -673,4 +740,190 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("GetState", func() {
+		var (
+			state    model.CFResourceState
+			stateErr error
+		)
+
+		JustBeforeEach(func() {
+			state, stateErr = dropletRepo.GetState(ctx, authInfo, buildGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(stateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns unknown state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(model.CFResourceStateUnknown))
+			})
+
+			When("the build has succeeded", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+							Type:   "Succeeded",
+							Status: metav1.ConditionTrue,
+							Reason: "DropletUploaded",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns ready state", func() {
+					Expect(stateErr).NotTo(HaveOccurred())
+					Expect(state).To(Equal(model.CFResourceStateReady))
+				})
+			})
+		})
+	})
+
+	Describe("CreateDroplet", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			createErr     error
+			createMessage repositories.CreateDropletMessage
+		)
+
+		BeforeEach(func() {
+			createMessage = repositories.CreateDropletMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+				Stack:     "cflinuxfs4",
+				ProcessTypes: map[string]string{
+					"web":    "bundle exec rackup",
+					"worker": "bundle exec sidekiq",
+				},
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, createErr = dropletRepo.CreateDroplet(ctx, authInfo, createMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a build awaiting the droplet upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				createdBuild := &korifiv1alpha1.CFBuild{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: dropletRecord.GUID}, createdBuild)).To(Succeed())
+				Expect(createdBuild.Spec.AppRef.Name).To(Equal(appGUID))
+				Expect(createdBuild.Spec.PackageRef.Name).To(BeEmpty())
+				Expect(createdBuild.Spec.Lifecycle).To(Equal(korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+					Data: korifiv1alpha1.LifecycleData{Stack: "cflinuxfs4"},
+				}))
+				Expect(createdBuild.Spec.UploadedDroplet).To(Equal(&korifiv1alpha1.UploadedDroplet{
+					ProcessTypes: []korifiv1alpha1.ProcessType{
+						{Type: "web", Command: "bundle exec rackup"},
+						{Type: "worker", Command: "bundle exec sidekiq"},
+					},
+				}))
+				Expect(createdBuild.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(createdBuild.Annotations).To(HaveKeyWithValue("bar", "baz"))
+			})
+
+			It("returns a droplet record awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal("AWAITING_UPLOAD"))
+				Expect(dropletRecord.AppGUID).To(Equal(appGUID))
+				Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(dropletRecord.ProcessTypes).To(Equal(createMessage.ProcessTypes))
+				Expect(dropletRecord.ImageRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+			})
+
+			It("creates the droplet repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+			})
+
+			When("creating the repository fails", func() {
+				BeforeEach(func() {
+					repoCreator.CreateRepositoryReturns(errors.New("repo-create-error"))
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("repo-create-error")))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateDropletSource", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			updateErr     error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, build, func() {
+				build.Spec.UploadedDroplet = &korifiv1alpha1.UploadedDroplet{}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, updateErr = dropletRepo.UpdateDropletSource(ctx, authInfo, repositories.UpdateDropletSourceMessage{
+				GUID:                buildGUID,
+				SpaceGUID:           space.Name,
+				ImageRef:            "my-droplet@sha256:123",
+				RegistrySecretNames: []string{"registry-secret"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the uploaded droplet image", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+
+				updatedBuild := &korifiv1alpha1.CFBuild{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(build), updatedBuild)).To(Succeed())
+				Expect(updatedBuild.Spec.UploadedDroplet.Registry).To(Equal(korifiv1alpha1.Registry{
+					Image:            "my-droplet@sha256:123",
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-secret"}},
+				}))
+			})
+
+			It("returns a droplet record processing the upload", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal("PROCESSING_UPLOAD"))
+				Expect(dropletRecord.DropletImageRef).To(Equal("my-droplet@sha256:123"))
+			})
+
+			When("the droplet was not created for upload", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, build, func() {
+						build.Spec.UploadedDroplet = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
 })
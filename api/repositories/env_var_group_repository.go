@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const EnvVarGroupResourceType = "Environment Variable Group"
+
+type EnvVarGroupRecord struct {
+	Name      string
+	Var       map[string]string
+	UpdatedAt *time.Time
+}
+
+type UpdateEnvVarGroupMessage struct {
+	Name string
+	Var  map[string]*string
+}
+
+// EnvVarGroupRepo stores the running and staging environment variable groups
+// as CFEnvVarGroups in the root namespace. The controllers add them to the
+// environment of app workloads and build workloads respectively.
+type EnvVarGroupRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewEnvVarGroupRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *EnvVarGroupRepo {
+	return &EnvVarGroupRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *EnvVarGroupRepo) GetEnvVarGroup(ctx context.Context, authInfo authorization.Info, name string) (EnvVarGroupRecord, error) {
+	if !isEnvVarGroupName(name) {
+		return EnvVarGroupRecord{}, apierrors.NewNotFoundError(nil, EnvVarGroupResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfEnvVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfEnvVarGroup)
+	if k8serrors.IsNotFound(err) {
+		return EnvVarGroupRecord{Name: name, Var: map[string]string{}}, nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get environment variable group: %w", apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return toEnvVarGroupRecord(*cfEnvVarGroup), nil
+}
+
+func (r *EnvVarGroupRepo) UpdateEnvVarGroup(ctx context.Context, authInfo authorization.Info, message UpdateEnvVarGroupMessage) (EnvVarGroupRecord, error) {
+	if !isEnvVarGroupName(message.Name) {
+		return EnvVarGroupRecord{}, apierrors.NewNotFoundError(nil, EnvVarGroupResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfEnvVarGroup := &korifiv1alpha1.CFEnvVarGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.Name,
+		},
+	}
+	applyVars := func() {
+		if cfEnvVarGroup.Spec.Env == nil {
+			cfEnvVarGroup.Spec.Env = map[string]string{}
+		}
+		for k, v := range message.Var {
+			if v == nil {
+				delete(cfEnvVarGroup.Spec.Env, k)
+			} else {
+				cfEnvVarGroup.Spec.Env[k] = *v
+			}
+		}
+	}
+
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(cfEnvVarGroup), cfEnvVarGroup)
+	if k8serrors.IsNotFound(err) {
+		applyVars()
+		if err = userClient.Create(ctx, cfEnvVarGroup); err != nil {
+			return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+		}
+		return toEnvVarGroupRecord(*cfEnvVarGroup), nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+	}
+
+	if err = k8s.PatchResource(ctx, userClient, cfEnvVarGroup, applyVars); err != nil {
+		return EnvVarGroupRecord{}, apierrors.FromK8sError(err, EnvVarGroupResourceType)
+	}
+
+	return toEnvVarGroupRecord(*cfEnvVarGroup), nil
+}
+
+func isEnvVarGroupName(name string) bool {
+	return name == korifiv1alpha1.RunningEnvVarGroupName || name == korifiv1alpha1.StagingEnvVarGroupName
+}
+
+func getEnvVarGroupEnv(ctx context.Context, userClient client.Client, rootNamespace, name string) (map[string]string, error) {
+	cfEnvVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: name}, cfEnvVarGroup)
+	if k8serrors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s environment variable group: %w", name, apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return toEnvVarGroupRecord(*cfEnvVarGroup).Var, nil
+}
+
+func toEnvVarGroupRecord(cfEnvVarGroup korifiv1alpha1.CFEnvVarGroup) EnvVarGroupRecord {
+	vars := map[string]string{}
+	for k, v := range cfEnvVarGroup.Spec.Env {
+		vars[k] = v
+	}
+
+	return EnvVarGroupRecord{
+		Name:      cfEnvVarGroup.Name,
+		Var:       vars,
+		UpdatedAt: getLastUpdatedTime(&cfEnvVarGroup),
+	}
+}
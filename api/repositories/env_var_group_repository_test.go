@@ -0,0 +1,141 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvVarGroupRepo", func() {
+	var repo *repositories.EnvVarGroupRepo
+
+	BeforeEach(func() {
+		repo = repositories.NewEnvVarGroupRepo(userClientFactory, rootNamespace)
+	})
+
+	Describe("GetEnvVarGroup", func() {
+		var (
+			name        string
+			envVarGroup repositories.EnvVarGroupRecord
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			name = "running"
+		})
+
+		JustBeforeEach(func() {
+			envVarGroup, getErr = repo.GetEnvVarGroup(ctx, authInfo, name)
+		})
+
+		It("returns an empty group", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(envVarGroup).To(Equal(repositories.EnvVarGroupRecord{
+				Name: "running",
+				Var:  map[string]string{},
+			}))
+		})
+
+		When("the group has been set", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "running"},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{"HTTP_PROXY": "http://proxy"},
+					},
+				})).To(Succeed())
+			})
+
+			It("returns the group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(envVarGroup.Var).To(Equal(map[string]string{"HTTP_PROXY": "http://proxy"}))
+				Expect(envVarGroup.UpdatedAt).NotTo(BeNil())
+			})
+		})
+
+		When("the group is not known", func() {
+			BeforeEach(func() {
+				name = "foo"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UpdateEnvVarGroup", func() {
+		var (
+			message     repositories.UpdateEnvVarGroupMessage
+			envVarGroup repositories.EnvVarGroupRecord
+			updateErr   error
+		)
+
+		BeforeEach(func() {
+			message = repositories.UpdateEnvVarGroupMessage{
+				Name: "staging",
+				Var:  map[string]*string{"NPM_REGISTRY": tools.PtrTo("http://registry")},
+			}
+		})
+
+		JustBeforeEach(func() {
+			envVarGroup, updateErr = repo.UpdateEnvVarGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error as the user is not an admin", func() {
+			Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the group", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(envVarGroup.Var).To(Equal(map[string]string{"NPM_REGISTRY": "http://registry"}))
+
+				cfEnvVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: "staging"}, cfEnvVarGroup)).To(Succeed())
+				Expect(cfEnvVarGroup.Spec.Env).To(Equal(map[string]string{"NPM_REGISTRY": "http://registry"}))
+			})
+
+			When("the group already exists", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "staging"},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Env: map[string]string{"KEEP": "me", "DELETE": "me"},
+						},
+					})).To(Succeed())
+
+					message.Var["DELETE"] = nil
+				})
+
+				It("patches the group, deleting null variables", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(envVarGroup.Var).To(Equal(map[string]string{
+						"KEEP":         "me",
+						"NPM_REGISTRY": "http://registry",
+					}))
+				})
+			})
+
+			When("the group is not known", func() {
+				BeforeEach(func() {
+					message.Name = "foo"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
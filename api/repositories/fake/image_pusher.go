@@ -11,6 +11,53 @@ import (
 )
 
 type ImagePusher struct {
+	CopyStub        func(context.Context, image.Creds, string, string, ...string) (string, error)
+	copyMutex       sync.RWMutex
+	copyArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 []string
+	}
+	copyReturns struct {
+		result1 string
+		result2 error
+	}
+	copyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PullStub        func(context.Context, image.Creds, string) (io.ReadCloser, error)
+	pullMutex       sync.RWMutex
+	pullArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	pullReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	pullReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	PullTarballStub        func(context.Context, image.Creds, string) (io.ReadCloser, error)
+	pullTarballMutex       sync.RWMutex
+	pullTarballArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	pullTarballReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	pullTarballReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	PushStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
 	pushMutex       sync.RWMutex
 	pushArgsForCall []struct {
@@ -28,10 +75,227 @@ type ImagePusher struct {
 		result1 string
 		result2 error
 	}
+	PushTarballStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushTarballMutex       sync.RWMutex
+	pushTarballArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushTarballReturns struct {
+		result1 string
+		result2 error
+	}
+	pushTarballReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImagePusher) Copy(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string, arg5 ...string) (string, error) {
+	fake.copyMutex.Lock()
+	ret, specificReturn := fake.copyReturnsOnCall[len(fake.copyArgsForCall)]
+	fake.copyArgsForCall = append(fake.copyArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.CopyStub
+	fakeReturns := fake.copyReturns
+	fake.recordInvocation("Copy", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.copyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) CopyCallCount() int {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	return len(fake.copyArgsForCall)
+}
+
+func (fake *ImagePusher) CopyCalls(stub func(context.Context, image.Creds, string, string, ...string) (string, error)) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = stub
+}
+
+func (fake *ImagePusher) CopyArgsForCall(i int) (context.Context, image.Creds, string, string, []string) {
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	argsForCall := fake.copyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) CopyReturns(result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	fake.copyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) CopyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyMutex.Lock()
+	defer fake.copyMutex.Unlock()
+	fake.CopyStub = nil
+	if fake.copyReturnsOnCall == nil {
+		fake.copyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) Pull(arg1 context.Context, arg2 image.Creds, arg3 string) (io.ReadCloser, error) {
+	fake.pullMutex.Lock()
+	ret, specificReturn := fake.pullReturnsOnCall[len(fake.pullArgsForCall)]
+	fake.pullArgsForCall = append(fake.pullArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PullStub
+	fakeReturns := fake.pullReturns
+	fake.recordInvocation("Pull", []interface{}{arg1, arg2, arg3})
+	fake.pullMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PullCallCount() int {
+	fake.pullMutex.RLock()
+	defer fake.pullMutex.RUnlock()
+	return len(fake.pullArgsForCall)
+}
+
+func (fake *ImagePusher) PullCalls(stub func(context.Context, image.Creds, string) (io.ReadCloser, error)) {
+	fake.pullMutex.Lock()
+	defer fake.pullMutex.Unlock()
+	fake.PullStub = stub
+}
+
+func (fake *ImagePusher) PullArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.pullMutex.RLock()
+	defer fake.pullMutex.RUnlock()
+	argsForCall := fake.pullArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImagePusher) PullReturns(result1 io.ReadCloser, result2 error) {
+	fake.pullMutex.Lock()
+	defer fake.pullMutex.Unlock()
+	fake.PullStub = nil
+	fake.pullReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PullReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.pullMutex.Lock()
+	defer fake.pullMutex.Unlock()
+	fake.PullStub = nil
+	if fake.pullReturnsOnCall == nil {
+		fake.pullReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.pullReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PullTarball(arg1 context.Context, arg2 image.Creds, arg3 string) (io.ReadCloser, error) {
+	fake.pullTarballMutex.Lock()
+	ret, specificReturn := fake.pullTarballReturnsOnCall[len(fake.pullTarballArgsForCall)]
+	fake.pullTarballArgsForCall = append(fake.pullTarballArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.PullTarballStub
+	fakeReturns := fake.pullTarballReturns
+	fake.recordInvocation("PullTarball", []interface{}{arg1, arg2, arg3})
+	fake.pullTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PullTarballCallCount() int {
+	fake.pullTarballMutex.RLock()
+	defer fake.pullTarballMutex.RUnlock()
+	return len(fake.pullTarballArgsForCall)
+}
+
+func (fake *ImagePusher) PullTarballCalls(stub func(context.Context, image.Creds, string) (io.ReadCloser, error)) {
+	fake.pullTarballMutex.Lock()
+	defer fake.pullTarballMutex.Unlock()
+	fake.PullTarballStub = stub
+}
+
+func (fake *ImagePusher) PullTarballArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.pullTarballMutex.RLock()
+	defer fake.pullTarballMutex.RUnlock()
+	argsForCall := fake.pullTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImagePusher) PullTarballReturns(result1 io.ReadCloser, result2 error) {
+	fake.pullTarballMutex.Lock()
+	defer fake.pullTarballMutex.Unlock()
+	fake.PullTarballStub = nil
+	fake.pullTarballReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PullTarballReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.pullTarballMutex.Lock()
+	defer fake.pullTarballMutex.Unlock()
+	fake.PullTarballStub = nil
+	if fake.pullTarballReturnsOnCall == nil {
+		fake.pullTarballReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.pullTarballReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Push(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
 	fake.pushMutex.Lock()
 	ret, specificReturn := fake.pushReturnsOnCall[len(fake.pushArgsForCall)]
@@ -100,11 +364,87 @@ func (fake *ImagePusher) PushReturnsOnCall(i int, result1 string, result2 error)
 	}{result1, result2}
 }
 
+func (fake *ImagePusher) PushTarball(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushTarballMutex.Lock()
+	ret, specificReturn := fake.pushTarballReturnsOnCall[len(fake.pushTarballArgsForCall)]
+	fake.pushTarballArgsForCall = append(fake.pushTarballArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushTarballStub
+	fakeReturns := fake.pushTarballReturns
+	fake.recordInvocation("PushTarball", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushTarballCallCount() int {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	return len(fake.pushTarballArgsForCall)
+}
+
+func (fake *ImagePusher) PushTarballCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = stub
+}
+
+func (fake *ImagePusher) PushTarballArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	argsForCall := fake.pushTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) PushTarballReturns(result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	fake.pushTarballReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushTarballReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	if fake.pushTarballReturnsOnCall == nil {
+		fake.pushTarballReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushTarballReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyMutex.RLock()
+	defer fake.copyMutex.RUnlock()
+	fake.pullMutex.RLock()
+	defer fake.pullMutex.RUnlock()
+	fake.pullTarballMutex.RLock()
+	defer fake.pullTarballMutex.RUnlock()
 	fake.pushMutex.RLock()
 	defer fake.pushMutex.RUnlock()
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
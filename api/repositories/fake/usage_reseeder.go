@@ -0,0 +1,186 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type UsageReseeder struct {
+	ReseedAppUsageStub        func(context.Context) error
+	reseedAppUsageMutex       sync.RWMutex
+	reseedAppUsageArgsForCall []struct {
+		arg1 context.Context
+	}
+	reseedAppUsageReturns struct {
+		result1 error
+	}
+	reseedAppUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReseedServiceUsageStub        func(context.Context) error
+	reseedServiceUsageMutex       sync.RWMutex
+	reseedServiceUsageArgsForCall []struct {
+		arg1 context.Context
+	}
+	reseedServiceUsageReturns struct {
+		result1 error
+	}
+	reseedServiceUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *UsageReseeder) ReseedAppUsage(arg1 context.Context) error {
+	fake.reseedAppUsageMutex.Lock()
+	ret, specificReturn := fake.reseedAppUsageReturnsOnCall[len(fake.reseedAppUsageArgsForCall)]
+	fake.reseedAppUsageArgsForCall = append(fake.reseedAppUsageArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ReseedAppUsageStub
+	fakeReturns := fake.reseedAppUsageReturns
+	fake.recordInvocation("ReseedAppUsage", []interface{}{arg1})
+	fake.reseedAppUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *UsageReseeder) ReseedAppUsageCallCount() int {
+	fake.reseedAppUsageMutex.RLock()
+	defer fake.reseedAppUsageMutex.RUnlock()
+	return len(fake.reseedAppUsageArgsForCall)
+}
+
+func (fake *UsageReseeder) ReseedAppUsageCalls(stub func(context.Context) error) {
+	fake.reseedAppUsageMutex.Lock()
+	defer fake.reseedAppUsageMutex.Unlock()
+	fake.ReseedAppUsageStub = stub
+}
+
+func (fake *UsageReseeder) ReseedAppUsageArgsForCall(i int) context.Context {
+	fake.reseedAppUsageMutex.RLock()
+	defer fake.reseedAppUsageMutex.RUnlock()
+	argsForCall := fake.reseedAppUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *UsageReseeder) ReseedAppUsageReturns(result1 error) {
+	fake.reseedAppUsageMutex.Lock()
+	defer fake.reseedAppUsageMutex.Unlock()
+	fake.ReseedAppUsageStub = nil
+	fake.reseedAppUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *UsageReseeder) ReseedAppUsageReturnsOnCall(i int, result1 error) {
+	fake.reseedAppUsageMutex.Lock()
+	defer fake.reseedAppUsageMutex.Unlock()
+	fake.ReseedAppUsageStub = nil
+	if fake.reseedAppUsageReturnsOnCall == nil {
+		fake.reseedAppUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reseedAppUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *UsageReseeder) ReseedServiceUsage(arg1 context.Context) error {
+	fake.reseedServiceUsageMutex.Lock()
+	ret, specificReturn := fake.reseedServiceUsageReturnsOnCall[len(fake.reseedServiceUsageArgsForCall)]
+	fake.reseedServiceUsageArgsForCall = append(fake.reseedServiceUsageArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ReseedServiceUsageStub
+	fakeReturns := fake.reseedServiceUsageReturns
+	fake.recordInvocation("ReseedServiceUsage", []interface{}{arg1})
+	fake.reseedServiceUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *UsageReseeder) ReseedServiceUsageCallCount() int {
+	fake.reseedServiceUsageMutex.RLock()
+	defer fake.reseedServiceUsageMutex.RUnlock()
+	return len(fake.reseedServiceUsageArgsForCall)
+}
+
+func (fake *UsageReseeder) ReseedServiceUsageCalls(stub func(context.Context) error) {
+	fake.reseedServiceUsageMutex.Lock()
+	defer fake.reseedServiceUsageMutex.Unlock()
+	fake.ReseedServiceUsageStub = stub
+}
+
+func (fake *UsageReseeder) ReseedServiceUsageArgsForCall(i int) context.Context {
+	fake.reseedServiceUsageMutex.RLock()
+	defer fake.reseedServiceUsageMutex.RUnlock()
+	argsForCall := fake.reseedServiceUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *UsageReseeder) ReseedServiceUsageReturns(result1 error) {
+	fake.reseedServiceUsageMutex.Lock()
+	defer fake.reseedServiceUsageMutex.Unlock()
+	fake.ReseedServiceUsageStub = nil
+	fake.reseedServiceUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *UsageReseeder) ReseedServiceUsageReturnsOnCall(i int, result1 error) {
+	fake.reseedServiceUsageMutex.Lock()
+	defer fake.reseedServiceUsageMutex.Unlock()
+	fake.ReseedServiceUsageStub = nil
+	if fake.reseedServiceUsageReturnsOnCall == nil {
+		fake.reseedServiceUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reseedServiceUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *UsageReseeder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.reseedAppUsageMutex.RLock()
+	defer fake.reseedAppUsageMutex.RUnlock()
+	fake.reseedServiceUsageMutex.RLock()
+	defer fake.reseedServiceUsageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *UsageReseeder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repositories.UsageReseeder = new(UsageReseeder)
@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	authv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cffeatureflags,verbs=get
+
+const (
+	FeatureFlagResourceType  = "Feature Flag"
+	SpaceFeatureResourceType = "Space Feature"
+
+	FeatureFlagDiegoDocker            = "diego_docker"
+	FeatureFlagUserOrgCreation        = "user_org_creation"
+	FeatureFlagTaskCreation           = "task_creation"
+	FeatureFlagServiceInstanceSharing = "service_instance_sharing"
+	FeatureFlagRouteSharing           = "route_sharing"
+	FeatureFlagSetRolesByUsername     = "set_roles_by_username"
+	FeatureFlagUnsetRolesByUsername   = "unset_roles_by_username"
+
+	SpaceFeatureSSH = "ssh"
+)
+
+// defaultFeatureFlags are the supported feature flags and the value they have
+// until an admin changes it. Unlike in CF, docker apps are allowed by default
+// as Korifi has always supported them.
+var defaultFeatureFlags = map[string]bool{
+	FeatureFlagDiegoDocker:            true,
+	FeatureFlagUserOrgCreation:        false,
+	FeatureFlagTaskCreation:           true,
+	FeatureFlagServiceInstanceSharing: false,
+	FeatureFlagRouteSharing:           false,
+	FeatureFlagSetRolesByUsername:     true,
+	FeatureFlagUnsetRolesByUsername:   true,
+}
+
+type spaceFeature struct {
+	description string
+	enabled     bool
+}
+
+var defaultSpaceFeatures = map[string]spaceFeature{
+	SpaceFeatureSSH: {description: "Enable SSHing into apps in the space.", enabled: true},
+}
+
+type FeatureFlagRecord struct {
+	Name               string
+	Enabled            bool
+	CustomErrorMessage string
+	UpdatedAt          *time.Time
+}
+
+type SpaceFeatureRecord struct {
+	Name        string
+	Description string
+	Enabled     bool
+}
+
+type UpdateFeatureFlagMessage struct {
+	Name               string
+	Enabled            *bool
+	CustomErrorMessage *string
+}
+
+type UpdateSpaceFeatureMessage struct {
+	SpaceGUID string
+	Name      string
+	Enabled   bool
+}
+
+// FeatureFlagRepo stores the global feature flags as CFFeatureFlags in the root
+// namespace and the space features as CFFeatureFlags in the space namespace.
+// Feature flags are read from the API server on every check, so that changes
+// take effect immediately.
+type FeatureFlagRepo struct {
+	privilegedClient  client.Client
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewFeatureFlagRepo(
+	privilegedClient client.Client,
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *FeatureFlagRepo {
+	return &FeatureFlagRepo{
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *FeatureFlagRepo) ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]FeatureFlagRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlags := &korifiv1alpha1.CFFeatureFlagList{}
+	if err := userClient.List(ctx, cfFeatureFlags, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	records := map[string]FeatureFlagRecord{}
+	for name, enabled := range defaultFeatureFlags {
+		records[name] = FeatureFlagRecord{Name: name, Enabled: enabled}
+	}
+	for _, cfFeatureFlag := range cfFeatureFlags.Items {
+		if _, ok := defaultFeatureFlags[cfFeatureFlag.Name]; ok {
+			records[cfFeatureFlag.Name] = toFeatureFlagRecord(cfFeatureFlag)
+		}
+	}
+
+	result := []FeatureFlagRecord{}
+	for _, name := range slices.Sorted(maps.Keys(records)) {
+		result = append(result, records[name])
+	}
+
+	return result, nil
+}
+
+func (r *FeatureFlagRepo) GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (FeatureFlagRecord, error) {
+	defaultEnabled, ok := defaultFeatureFlags[name]
+	if !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfFeatureFlag)
+	if k8serrors.IsNotFound(err) {
+		return FeatureFlagRecord{Name: name, Enabled: defaultEnabled}, nil
+	}
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to get feature flag: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return toFeatureFlagRecord(*cfFeatureFlag), nil
+}
+
+func (r *FeatureFlagRepo) UpdateFeatureFlag(ctx context.Context, authInfo authorization.Info, message UpdateFeatureFlagMessage) (FeatureFlagRecord, error) {
+	defaultEnabled, ok := defaultFeatureFlags[message.Name]
+	if !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, FeatureFlagResourceType)
+	}
+
+	cfFeatureFlag, err := r.createOrPatchFeatureFlag(ctx, authInfo, r.rootNamespace, message.Name, defaultEnabled, func(cfFeatureFlag *korifiv1alpha1.CFFeatureFlag) {
+		if message.Enabled != nil {
+			cfFeatureFlag.Spec.Enabled = *message.Enabled
+		}
+		if message.CustomErrorMessage != nil {
+			cfFeatureFlag.Spec.CustomErrorMessage = *message.CustomErrorMessage
+		}
+	})
+	if err != nil {
+		return FeatureFlagRecord{}, apierrors.FromK8sError(err, FeatureFlagResourceType)
+	}
+
+	return toFeatureFlagRecord(cfFeatureFlag), nil
+}
+
+// CheckFeatureFlag returns a FeatureDisabledError if the feature flag is
+// disabled. Admins, i.e. users allowed to change feature flags, are not
+// affected by disabled feature flags.
+func (r *FeatureFlagRepo) CheckFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) error {
+	enabled, ok := defaultFeatureFlags[name]
+	if !ok {
+		return fmt.Errorf("unknown feature flag %q", name)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{}
+	err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfFeatureFlag)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get feature flag %q: %w", name, err)
+	}
+
+	customErrorMessage := ""
+	if err == nil {
+		enabled = cfFeatureFlag.Spec.Enabled
+		customErrorMessage = cfFeatureFlag.Spec.CustomErrorMessage
+	}
+
+	if enabled {
+		return nil
+	}
+
+	isAdmin, err := r.canIPatchFeatureFlags(ctx, authInfo)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+
+	if customErrorMessage != "" {
+		return apierrors.NewFeatureDisabledError(customErrorMessage)
+	}
+	return apierrors.NewFeatureDisabledError(name)
+}
+
+func (r *FeatureFlagRepo) canIPatchFeatureFlags(ctx context.Context, authInfo authorization.Info) (bool, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return false, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: r.rootNamespace,
+				Verb:      "patch",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cffeatureflags",
+			},
+		},
+	}
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (r *FeatureFlagRepo) ListSpaceFeatures(ctx context.Context, authInfo authorization.Info, spaceGUID string) ([]SpaceFeatureRecord, error) {
+	records := []SpaceFeatureRecord{}
+	for _, name := range slices.Sorted(maps.Keys(defaultSpaceFeatures)) {
+		record, err := r.GetSpaceFeature(ctx, authInfo, spaceGUID, name)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (r *FeatureFlagRepo) GetSpaceFeature(ctx context.Context, authInfo authorization.Info, spaceGUID, name string) (SpaceFeatureRecord, error) {
+	feature, ok := defaultSpaceFeatures[name]
+	if !ok {
+		return SpaceFeatureRecord{}, apierrors.NewNotFoundError(nil, SpaceFeatureResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceFeatureRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: spaceGUID, Name: name}, cfFeatureFlag)
+	if k8serrors.IsNotFound(err) {
+		return SpaceFeatureRecord{Name: name, Description: feature.description, Enabled: feature.enabled}, nil
+	}
+	if err != nil {
+		return SpaceFeatureRecord{}, fmt.Errorf("failed to get space feature: %w", apierrors.FromK8sError(err, SpaceFeatureResourceType))
+	}
+
+	return SpaceFeatureRecord{Name: name, Description: feature.description, Enabled: cfFeatureFlag.Spec.Enabled}, nil
+}
+
+func (r *FeatureFlagRepo) UpdateSpaceFeature(ctx context.Context, authInfo authorization.Info, message UpdateSpaceFeatureMessage) (SpaceFeatureRecord, error) {
+	feature, ok := defaultSpaceFeatures[message.Name]
+	if !ok {
+		return SpaceFeatureRecord{}, apierrors.NewNotFoundError(nil, SpaceFeatureResourceType)
+	}
+
+	cfFeatureFlag, err := r.createOrPatchFeatureFlag(ctx, authInfo, message.SpaceGUID, message.Name, feature.enabled, func(cfFeatureFlag *korifiv1alpha1.CFFeatureFlag) {
+		cfFeatureFlag.Spec.Enabled = message.Enabled
+	})
+	if err != nil {
+		return SpaceFeatureRecord{}, apierrors.FromK8sError(err, SpaceFeatureResourceType)
+	}
+
+	return SpaceFeatureRecord{Name: message.Name, Description: feature.description, Enabled: cfFeatureFlag.Spec.Enabled}, nil
+}
+
+func (r *FeatureFlagRepo) createOrPatchFeatureFlag(
+	ctx context.Context,
+	authInfo authorization.Info,
+	namespace string,
+	name string,
+	defaultEnabled bool,
+	modify func(*korifiv1alpha1.CFFeatureFlag),
+) (korifiv1alpha1.CFFeatureFlag, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return korifiv1alpha1.CFFeatureFlag{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(cfFeatureFlag), cfFeatureFlag)
+	if k8serrors.IsNotFound(err) {
+		cfFeatureFlag.Spec.Enabled = defaultEnabled
+		modify(cfFeatureFlag)
+		if err = userClient.Create(ctx, cfFeatureFlag); err != nil {
+			return korifiv1alpha1.CFFeatureFlag{}, fmt.Errorf("failed to create feature flag: %w", err)
+		}
+		return *cfFeatureFlag, nil
+	}
+	if err != nil {
+		return korifiv1alpha1.CFFeatureFlag{}, fmt.Errorf("failed to get feature flag: %w", err)
+	}
+
+	if err = k8s.PatchResource(ctx, userClient, cfFeatureFlag, func() {
+		modify(cfFeatureFlag)
+	}); err != nil {
+		return korifiv1alpha1.CFFeatureFlag{}, fmt.Errorf("failed to patch feature flag: %w", err)
+	}
+
+	return *cfFeatureFlag, nil
+}
+
+func toFeatureFlagRecord(cfFeatureFlag korifiv1alpha1.CFFeatureFlag) FeatureFlagRecord {
+	return FeatureFlagRecord{
+		Name:               cfFeatureFlag.Name,
+		Enabled:            cfFeatureFlag.Spec.Enabled,
+		CustomErrorMessage: cfFeatureFlag.Spec.CustomErrorMessage,
+		UpdatedAt:          getLastUpdatedTime(&cfFeatureFlag),
+	}
+}
@@ -0,0 +1,270 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeatureFlagRepo", func() {
+	var repo *repositories.FeatureFlagRepo
+
+	BeforeEach(func() {
+		repo = repositories.NewFeatureFlagRepo(k8sClient, userClientFactory, rootNamespace)
+	})
+
+	setFeatureFlag := func(namespace, name string, enabled bool, customErrorMessage string) {
+		GinkgoHelper()
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: korifiv1alpha1.CFFeatureFlagSpec{
+				Enabled:            enabled,
+				CustomErrorMessage: customErrorMessage,
+			},
+		})).To(Succeed())
+	}
+
+	Describe("ListFeatureFlags", func() {
+		var (
+			featureFlags []repositories.FeatureFlagRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			setFeatureFlag(rootNamespace, "diego_docker", false, "no docker")
+			setFeatureFlag(rootNamespace, "unknown_flag", false, "")
+		})
+
+		JustBeforeEach(func() {
+			featureFlags, listErr = repo.ListFeatureFlags(ctx, authInfo)
+		})
+
+		It("returns all known feature flags sorted by name, with defaults for unset ones", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(featureFlags).To(HaveLen(7))
+			Expect(featureFlags[0]).To(MatchFields(IgnoreExtras, Fields{
+				"Name":               Equal("diego_docker"),
+				"Enabled":            BeFalse(),
+				"CustomErrorMessage": Equal("no docker"),
+				"UpdatedAt":          Not(BeNil()),
+			}))
+			Expect(featureFlags).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Name":      Equal("task_creation"),
+				"Enabled":   BeTrue(),
+				"UpdatedAt": BeNil(),
+			})))
+			Expect(featureFlags).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Name":    Equal("route_sharing"),
+				"Enabled": BeFalse(),
+			})))
+			Expect(featureFlags).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("unknown_flag"),
+			})))
+		})
+	})
+
+	Describe("GetFeatureFlag", func() {
+		var (
+			name        string
+			featureFlag repositories.FeatureFlagRecord
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			name = "task_creation"
+		})
+
+		JustBeforeEach(func() {
+			featureFlag, getErr = repo.GetFeatureFlag(ctx, authInfo, name)
+		})
+
+		It("returns the default value", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(featureFlag).To(Equal(repositories.FeatureFlagRecord{Name: "task_creation", Enabled: true}))
+		})
+
+		When("the feature flag has been set", func() {
+			BeforeEach(func() {
+				setFeatureFlag(rootNamespace, "task_creation", false, "")
+			})
+
+			It("returns the set value", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(featureFlag.Enabled).To(BeFalse())
+			})
+		})
+
+		When("the feature flag is not known", func() {
+			BeforeEach(func() {
+				name = "foo"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("UpdateFeatureFlag", func() {
+		var (
+			message     repositories.UpdateFeatureFlagMessage
+			featureFlag repositories.FeatureFlagRecord
+			updateErr   error
+		)
+
+		BeforeEach(func() {
+			message = repositories.UpdateFeatureFlagMessage{
+				Name:    "diego_docker",
+				Enabled: tools.PtrTo(false),
+			}
+		})
+
+		JustBeforeEach(func() {
+			featureFlag, updateErr = repo.UpdateFeatureFlag(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error as the user is not an admin", func() {
+			Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the feature flag", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(featureFlag.Enabled).To(BeFalse())
+
+				cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: "diego_docker"}, cfFeatureFlag)).To(Succeed())
+				Expect(cfFeatureFlag.Spec.Enabled).To(BeFalse())
+			})
+
+			When("the feature flag already exists", func() {
+				BeforeEach(func() {
+					setFeatureFlag(rootNamespace, "diego_docker", false, "no docker")
+					message = repositories.UpdateFeatureFlagMessage{
+						Name:    "diego_docker",
+						Enabled: tools.PtrTo(true),
+					}
+				})
+
+				It("only changes the fields in the message", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(featureFlag.Enabled).To(BeTrue())
+					Expect(featureFlag.CustomErrorMessage).To(Equal("no docker"))
+				})
+			})
+
+			When("the feature flag is not known", func() {
+				BeforeEach(func() {
+					message.Name = "foo"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("CheckFeatureFlag", func() {
+		var checkErr error
+
+		JustBeforeEach(func() {
+			checkErr = repo.CheckFeatureFlag(ctx, authInfo, "diego_docker")
+		})
+
+		It("succeeds as the feature flag is enabled by default", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+		})
+
+		When("the feature flag is disabled", func() {
+			BeforeEach(func() {
+				setFeatureFlag(rootNamespace, "diego_docker", false, "")
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(checkErr).To(BeAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+				Expect(checkErr.(apierrors.FeatureDisabledError).Detail()).To(Equal("Feature Disabled: diego_docker"))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("succeeds", func() {
+					Expect(checkErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the feature flag is disabled with a custom error message", func() {
+			BeforeEach(func() {
+				setFeatureFlag(rootNamespace, "diego_docker", false, "no docker")
+			})
+
+			It("uses the custom error message", func() {
+				Expect(checkErr.(apierrors.FeatureDisabledError).Detail()).To(Equal("Feature Disabled: no docker"))
+			})
+		})
+	})
+
+	Describe("space features", func() {
+		var spaceGUID string
+
+		BeforeEach(func() {
+			org := createOrgWithCleanup(ctx, uuid.NewString())
+			spaceGUID = createSpaceWithCleanup(ctx, org.Name, uuid.NewString()).Name
+			createRoleBinding(ctx, userName, spaceManagerRole.Name, spaceGUID)
+		})
+
+		Describe("ListSpaceFeatures", func() {
+			It("returns the ssh feature enabled by default", func() {
+				spaceFeatures, err := repo.ListSpaceFeatures(ctx, authInfo, spaceGUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaceFeatures).To(ConsistOf(repositories.SpaceFeatureRecord{
+					Name:        "ssh",
+					Description: "Enable SSHing into apps in the space.",
+					Enabled:     true,
+				}))
+			})
+		})
+
+		Describe("GetSpaceFeature", func() {
+			When("the feature is not known", func() {
+				It("returns a not found error", func() {
+					_, err := repo.GetSpaceFeature(ctx, authInfo, spaceGUID, "foo")
+					Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		Describe("UpdateSpaceFeature", func() {
+			It("updates the space feature", func() {
+				spaceFeature, err := repo.UpdateSpaceFeature(ctx, authInfo, repositories.UpdateSpaceFeatureMessage{
+					SpaceGUID: spaceGUID,
+					Name:      "ssh",
+					Enabled:   false,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaceFeature.Enabled).To(BeFalse())
+
+				spaceFeature, err = repo.GetSpaceFeature(ctx, authInfo, spaceGUID, "ssh")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaceFeature.Enabled).To(BeFalse())
+			})
+		})
+	})
+})
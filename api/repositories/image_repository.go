@@ -23,6 +23,10 @@ const SourceImageResourceType = "SourceImage"
 
 type ImagePusher interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
+	Pull(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
+	Copy(ctx context.Context, creds image.Creds, srcImageRef string, dstRepoRef string, tags ...string) (string, error)
+	PushTarball(ctx context.Context, creds image.Creds, repoRef string, tarReader io.Reader, tags ...string) (string, error)
+	PullTarball(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
 }
 
 type ImageRepository struct {
@@ -47,7 +51,7 @@ func NewImageRepository(
 }
 
 func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
-	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "cfpackages", "patch")
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
 	}
@@ -61,10 +65,72 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
 	}
 
-	pushedRef, err := r.pusher.Push(ctx, image.Creds{
-		Namespace:   r.pushSecretNamespace,
-		SecretNames: r.pushSecretNames,
-	}, imageRef, srcReader, tags...)
+	pushedRef, err := r.pusher.Push(ctx, r.pushCreds(), imageRef, srcReader, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return pushedRef, nil
+}
+
+// DownloadSourceImage returns the content of a source image uploaded by
+// UploadSourceImage as a zip archive
+func (r *ImageRepository) DownloadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error) {
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "cfpackages", "get")
+	if err != nil {
+		return nil, fmt.Errorf("checking auth to download source image failed: %w", err)
+	}
+
+	if !authorized {
+		return nil, apierrors.NewForbiddenError(errors.New("not authorized to get cfpackage"), PackageResourceType)
+	}
+
+	zipReader, err := r.pusher.Pull(ctx, r.pushCreds(), imageRef)
+	if err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pulling image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return zipReader, nil
+}
+
+// CopySourceImage copies a source image to the repository of another package in
+// the given space, returning the reference of the copy with its digest
+func (r *ImageRepository) CopySourceImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, dstRepoRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "cfpackages", "patch")
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy source image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	copiedRef, err := r.pusher.Copy(ctx, r.pushCreds(), srcImageRef, dstRepoRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", srcImageRef, dstRepoRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+// UploadDropletImage pushes a droplet tarball as a single layer image,
+// returning the reference of the pushed image with its digest
+func (r *ImageRepository) UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, tarReader io.Reader, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "cfbuilds", "patch")
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pushedRef, err := r.pusher.PushTarball(ctx, r.pushCreds(), imageRef, tarReader, tags...)
 	if err != nil {
 		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing image ref '%s' failed: %w", imageRef, err))
 	}
@@ -72,24 +138,71 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
-func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+// DownloadDropletImage returns the filesystem of a droplet image as a gzip
+// compressed tarball
+func (r *ImageRepository) DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, spaceGUID string) (io.ReadCloser, error) {
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "cfbuilds", "get")
+	if err != nil {
+		return nil, fmt.Errorf("checking auth to download droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return nil, apierrors.NewForbiddenError(errors.New("not authorized to get cfbuild"), DropletResourceType)
+	}
+
+	tgzReader, err := r.pusher.PullTarball(ctx, r.pushCreds(), imageRef)
+	if err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pulling image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return tgzReader, nil
+}
+
+// CopyDropletImage copies a droplet image to the droplet repository of an app
+// in the given space, returning the reference of the copy with its digest
+func (r *ImageRepository) CopyDropletImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, dstRepoRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canI(ctx, authInfo, spaceGUID, "cfbuilds", "patch")
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	copiedRef, err := r.pusher.Copy(ctx, r.pushCreds(), srcImageRef, dstRepoRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", srcImageRef, dstRepoRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+func (r *ImageRepository) pushCreds() image.Creds {
+	return image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}
+}
+
+func (r *ImageRepository) canI(ctx context.Context, authInfo authorization.Info, spaceGUID string, resource string, verb string) (bool, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create user k8s client: %w", err)
+		return false, fmt.Errorf("canI: failed to create user k8s client: %w", err)
 	}
 
 	review := authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authv1.ResourceAttributes{
 				Namespace: spaceGUID,
-				Verb:      "patch",
+				Verb:      verb,
 				Group:     "korifi.cloudfoundry.org",
-				Resource:  "cfpackages",
+				Resource:  resource,
 			},
 		},
 	}
 	if err := userClient.Create(ctx, &review); err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create self subject access review: %w", apierrors.FromK8sError(err, PackageResourceType))
+		return false, fmt.Errorf("canI: failed to create self subject access review: %w", apierrors.FromK8sError(err, resource))
 	}
 
 	return review.Status.Allowed, nil
@@ -51,56 +51,314 @@ var _ = Describe("ImageRepository", func() {
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+	Describe("UploadSourceImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imagePusher.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
+			})
+		})
+	})
+
+	Describe("DownloadSourceImage", func() {
+		var (
+			zipReader   io.ReadCloser
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			imagePusher.PullReturns(io.NopCloser(bytes.NewBufferString("zip-content")), nil)
+		})
+
+		JustBeforeEach(func() {
+			zipReader, downloadErr = imageRepo.DownloadSourceImage(context.Background(), authInfo, "my-image@sha256:123", space.Name)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("pulls the image from the registry", func() {
+				Expect(downloadErr).NotTo(HaveOccurred())
+				Expect(io.ReadAll(zipReader)).To(BeEquivalentTo("zip-content"))
+
+				Expect(imagePusher.PullCallCount()).To(Equal(1))
+				_, creds, actualRef := imagePusher.PullArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image@sha256:123"))
+			})
+
+			When("pulling the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PullReturns(nil, errors.New("pull-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(downloadErr).To(MatchError(ContainSubstring("pull-error")))
+					Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+
+		When("user has role SpaceAuditor", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("fails with unauthorized error", func() {
+				Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("CopySourceImage", func() {
+		var (
+			copiedRef string
+			copyErr   error
+		)
+
+		BeforeEach(func() {
+			imagePusher.CopyReturns("my-copied-image", nil)
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, copyErr = imageRepo.CopySourceImage(context.Background(), authInfo, "my-image@sha256:123", "my-other-image", space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image in the registry", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal("my-copied-image"))
+
+				Expect(imagePusher.CopyCallCount()).To(Equal(1))
+				_, creds, actualSrcRef, actualDstRef, actualTags := imagePusher.CopyArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualSrcRef).To(Equal("my-image@sha256:123"))
+				Expect(actualDstRef).To(Equal("my-other-image"))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
 	})
 
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	Describe("UploadDropletImage", func() {
+		BeforeEach(func() {
+			imagePusher.PushTarballReturns("my-pushed-droplet", nil)
+		})
+
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadDropletImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("uploads the tarball as an image to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-droplet"))
+
+				Expect(imagePusher.PushTarballCallCount()).To(Equal(1))
+				_, creds, actualRef, tarReader, actualTags := imagePusher.PushTarballArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(tarReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an unprocessable entity error", func() {
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushTarballReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
 	})
 
-	When("user has role SpaceDeveloper", func() {
+	Describe("DownloadDropletImage", func() {
+		var (
+			tgzReader   io.ReadCloser
+			downloadErr error
+		)
+
 		BeforeEach(func() {
-			createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			imagePusher.PullTarballReturns(io.NopCloser(bytes.NewBufferString("tgz-content")), nil)
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			tgzReader, downloadErr = imageRepo.DownloadDropletImage(context.Background(), authInfo, "my-droplet@sha256:123", space.Name)
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
-			Expect(creds.Namespace).To(Equal(rootNamespace))
-			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
-		When("the image name is invalid", func() {
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imageName = "invAlid-image"
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
 			})
 
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+			It("pulls the image from the registry", func() {
+				Expect(downloadErr).NotTo(HaveOccurred())
+				Expect(io.ReadAll(tgzReader)).To(BeEquivalentTo("tgz-content"))
+
+				Expect(imagePusher.PullTarballCallCount()).To(Equal(1))
+				_, creds, actualRef := imagePusher.PullTarballArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-droplet@sha256:123"))
+			})
+
+			When("pulling the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PullTarballReturns(nil, errors.New("pull-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(downloadErr).To(MatchError(ContainSubstring("pull-error")))
+					Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
 			})
 		})
+	})
 
-		When("pushing the image fails", func() {
+	Describe("CopyDropletImage", func() {
+		var (
+			copiedRef string
+			copyErr   error
+		)
+
+		BeforeEach(func() {
+			imagePusher.CopyReturns("my-copied-droplet", nil)
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, copyErr = imageRepo.CopyDropletImage(context.Background(), authInfo, "my-droplet@sha256:123", "my-other-droplet", space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image in the registry", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal("my-copied-droplet"))
+
+				Expect(imagePusher.CopyCallCount()).To(Equal(1))
+				_, creds, actualSrcRef, actualDstRef, actualTags := imagePusher.CopyArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualSrcRef).To(Equal("my-droplet@sha256:123"))
+				Expect(actualDstRef).To(Equal("my-other-droplet"))
+				Expect(actualTags).To(Equal(tags))
 			})
 
-			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
-				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
 			})
 		})
 	})
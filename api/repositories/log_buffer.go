@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"sync"
+)
+
+// LogBuffer keeps the most recent log records of each app in memory, so that
+// they survive the restart and deletion of the pods that emitted them. Once the
+// buffer of an app is full, the oldest records are overwritten.
+type LogBuffer struct {
+	size    int
+	mutex   sync.RWMutex
+	buffers map[string]*logRing
+}
+
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{
+		size:    size,
+		buffers: map[string]*logRing{},
+	}
+}
+
+func (b *LogBuffer) Add(appGUID string, record LogRecord) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	ring, ok := b.buffers[appGUID]
+	if !ok {
+		ring = &logRing{records: make([]LogRecord, 0, b.size)}
+		b.buffers[appGUID] = ring
+	}
+
+	ring.add(record)
+}
+
+// Get returns a copy of the records of the app, in the order they were added
+func (b *LogBuffer) Get(appGUID string) []LogRecord {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	ring, ok := b.buffers[appGUID]
+	if !ok {
+		return []LogRecord{}
+	}
+
+	return ring.list()
+}
+
+// Retain drops the records of all the apps that are not in appGUIDs
+func (b *LogBuffer) Retain(appGUIDs map[string]bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for appGUID := range b.buffers {
+		if !appGUIDs[appGUID] {
+			delete(b.buffers, appGUID)
+		}
+	}
+}
+
+type logRing struct {
+	records []LogRecord
+	next    int
+}
+
+func (r *logRing) add(record LogRecord) {
+	if cap(r.records) == 0 {
+		return
+	}
+
+	if len(r.records) < cap(r.records) {
+		r.records = append(r.records, record)
+		return
+	}
+
+	r.records[r.next] = record
+	r.next = (r.next + 1) % len(r.records)
+}
+
+func (r *logRing) list() []LogRecord {
+	result := make([]LogRecord, 0, len(r.records))
+	result = append(result, r.records[r.next:]...)
+	return append(result, r.records[:r.next]...)
+}
@@ -0,0 +1,56 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogBuffer", func() {
+	var logBuffer *repositories.LogBuffer
+
+	BeforeEach(func() {
+		logBuffer = repositories.NewLogBuffer(3)
+	})
+
+	It("returns the records of an app in the order they were added", func() {
+		logBuffer.Add("app-1", newLogRecord(2, "two", "APP"))
+		logBuffer.Add("app-1", newLogRecord(1, "one", "APP"))
+		logBuffer.Add("app-2", newLogRecord(3, "three", "APP"))
+
+		Expect(logBuffer.Get("app-1")).To(Equal([]repositories.LogRecord{
+			newLogRecord(2, "two", "APP"),
+			newLogRecord(1, "one", "APP"),
+		}))
+		Expect(logBuffer.Get("app-2")).To(Equal([]repositories.LogRecord{
+			newLogRecord(3, "three", "APP"),
+		}))
+	})
+
+	It("returns no records for unknown apps", func() {
+		Expect(logBuffer.Get("unknown")).To(BeEmpty())
+	})
+
+	It("overwrites the oldest records once full", func() {
+		for i := range 5 {
+			logBuffer.Add("app", newLogRecord(int64(i), "log", "APP"))
+		}
+
+		Expect(logBuffer.Get("app")).To(Equal([]repositories.LogRecord{
+			newLogRecord(2, "log", "APP"),
+			newLogRecord(3, "log", "APP"),
+			newLogRecord(4, "log", "APP"),
+		}))
+	})
+
+	It("drops the records of the apps that are not retained", func() {
+		logBuffer.Add("app-1", newLogRecord(1, "one", "APP"))
+		logBuffer.Add("app-2", newLogRecord(2, "two", "APP"))
+
+		logBuffer.Retain(map[string]bool{"app-2": true})
+
+		Expect(logBuffer.Get("app-1")).To(BeEmpty())
+		Expect(logBuffer.Get("app-2")).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,431 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds,verbs=get
+//+kubebuilder:rbac:groups="",resources=events,verbs=list
+
+const exitStateTimeout = 10 * time.Second
+
+// LogCollector follows the logs of all app and staging pods into the log
+// buffer. Pods are polled for, and each container (or container restart) is
+// followed until it terminates, at which point its exit status is recorded as
+// well. Crash looping containers may restart before a poll sees them running,
+// in which case the logs and exit status of their previous run are collected
+// instead. The app log events that controllers record about the lifecycle of
+// apps (e.g. app instance crashes or staging progress) are added to the buffer
+// as they occur.
+//
+// Container logs are read from their start, so the buffer of a collector that
+// has just started is filled with the logs the nodes still hold for the
+// current and previous run of each container.
+type LogCollector struct {
+	privilegedClient    client.Client
+	privilegedClientset k8sclient.Interface
+	logStreamer         LogStreamer
+	logBuffer           *LogBuffer
+	pollInterval        time.Duration
+}
+
+func NewLogCollector(
+	privilegedClient client.Client,
+	privilegedClientset k8sclient.Interface,
+	logStreamer LogStreamer,
+	logBuffer *LogBuffer,
+	pollInterval time.Duration,
+) *LogCollector {
+	return &LogCollector{
+		privilegedClient:    privilegedClient,
+		privilegedClientset: privilegedClientset,
+		logStreamer:         logStreamer,
+		logBuffer:           logBuffer,
+		pollInterval:        pollInterval,
+	}
+}
+
+type collectedContainer struct {
+	podUID       types.UID
+	name         string
+	restartCount int32
+}
+
+// collectedEvent identifies an occurrence of an event, as recurring events are
+// aggregated into a single event with an increasing count
+type collectedEvent struct {
+	uid   types.UID
+	count int32
+}
+
+// Start collects logs until the context is done
+func (c *LogCollector) Start(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("log-collector")
+
+	collectedContainers := map[collectedContainer]bool{}
+	buildApps := map[types.NamespacedName]string{}
+	collectedEvents := map[collectedEvent]bool{}
+
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.collect(ctx, collectedContainers, buildApps, collectedEvents); err != nil {
+			logger.Info("failed to collect logs", "reason", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *LogCollector) collect(
+	ctx context.Context,
+	collectedContainers map[collectedContainer]bool,
+	buildApps map[types.NamespacedName]string,
+	collectedEvents map[collectedEvent]bool,
+) error {
+	apps := korifiv1alpha1.CFAppList{}
+	if err := c.privilegedClient.List(ctx, &apps); err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	appGUIDs := map[string]bool{}
+	for _, app := range apps.Items {
+		appGUIDs[app.Name] = true
+	}
+	c.logBuffer.Retain(appGUIDs)
+
+	if err := c.collectEvents(ctx, appGUIDs, collectedEvents); err != nil {
+		return err
+	}
+
+	pods, err := c.listPods(ctx)
+	if err != nil {
+		return err
+	}
+
+	podUIDs := map[types.UID]bool{}
+	for _, pod := range pods {
+		podUIDs[pod.UID] = true
+
+		appGUID, err := c.appGUID(ctx, pod, buildApps)
+		if err != nil {
+			logr.FromContextOrDiscard(ctx).Info("failed to get the app of pod", "pod", pod.Name, "reason", err)
+			continue
+		}
+
+		for _, containerStatus := range append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...) {
+			previous := collectedContainer{
+				podUID:       pod.UID,
+				name:         containerStatus.Name,
+				restartCount: containerStatus.RestartCount - 1,
+			}
+			if containerStatus.LastTerminationState.Terminated != nil && !collectedContainers[previous] {
+				collectedContainers[previous] = true
+				go c.collectPreviousRun(ctx, appGUID, pod, previous, containerStatus.LastTerminationState.Terminated)
+			}
+
+			current := collectedContainer{
+				podUID:       pod.UID,
+				name:         containerStatus.Name,
+				restartCount: containerStatus.RestartCount,
+			}
+			if containerStatus.State.Waiting == nil && !collectedContainers[current] {
+				collectedContainers[current] = true
+				go c.followContainer(ctx, appGUID, pod, current)
+			}
+		}
+	}
+
+	for container := range collectedContainers {
+		if !podUIDs[container.podUID] {
+			delete(collectedContainers, container)
+		}
+	}
+
+	for build := range buildApps {
+		if !appGUIDs[buildApps[build]] {
+			delete(buildApps, build)
+		}
+	}
+
+	return nil
+}
+
+func (c *LogCollector) collectEvents(ctx context.Context, appGUIDs map[string]bool, collectedEvents map[collectedEvent]bool) error {
+	events := corev1.EventList{}
+	err := c.privilegedClient.List(ctx, &events, client.MatchingFields{
+		"source": korifiv1alpha1.AppLogEventComponent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	eventUIDs := map[types.UID]bool{}
+	for _, event := range events.Items {
+		eventUIDs[event.UID] = true
+
+		appGUID := event.Annotations[korifiv1alpha1.CFAppGUIDLabelKey]
+		sourceType := event.Annotations[korifiv1alpha1.AppLogSourceTypeAnnotationKey]
+		if !appGUIDs[appGUID] || sourceType == "" {
+			continue
+		}
+
+		key := collectedEvent{uid: event.UID, count: event.Count}
+		if collectedEvents[key] {
+			continue
+		}
+		collectedEvents[key] = true
+
+		c.logBuffer.Add(appGUID, LogRecord{
+			Message:    event.Message,
+			Timestamp:  eventTimestamp(event).UnixNano(),
+			InstanceID: event.Annotations[korifiv1alpha1.AppLogInstanceIDAnnotationKey],
+			Tags: map[string]string{
+				"source_type": sourceType,
+			},
+		})
+	}
+
+	for event := range collectedEvents {
+		if !eventUIDs[event.uid] {
+			delete(collectedEvents, event)
+		}
+	}
+
+	return nil
+}
+
+// eventTimestamp returns the time of the latest occurrence of the event
+func eventTimestamp(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+
+	return event.FirstTimestamp.Time
+}
+
+func (c *LogCollector) listPods(ctx context.Context) ([]corev1.Pod, error) {
+	pods := []corev1.Pod{}
+
+	for _, labelKey := range []string{korifiv1alpha1.CFAppGUIDLabelKey, BuildWorkloadLabelKey} {
+		requirement, err := labels.NewRequirement(labelKey, selection.Exists, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		podList := corev1.PodList{}
+		err = c.privilegedClient.List(ctx, &podList, &client.ListOptions{
+			LabelSelector: labels.NewSelector().Add(*requirement),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+
+		pods = append(pods, podList.Items...)
+	}
+
+	return pods, nil
+}
+
+// appGUID returns the guid of the app a pod belongs to. Staging pods are only
+// labelled with their build, so their app is looked up on the build.
+func (c *LogCollector) appGUID(ctx context.Context, pod corev1.Pod, buildApps map[types.NamespacedName]string) (string, error) {
+	if appGUID, ok := pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey]; ok {
+		return appGUID, nil
+	}
+
+	buildKey := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[BuildWorkloadLabelKey]}
+	if appGUID, ok := buildApps[buildKey]; ok {
+		return appGUID, nil
+	}
+
+	build := korifiv1alpha1.CFBuild{}
+	if err := c.privilegedClient.Get(ctx, buildKey, &build); err != nil {
+		return "", fmt.Errorf("failed to get build: %w", err)
+	}
+
+	appGUID := build.Spec.AppRef.Name
+	buildApps[buildKey] = appGUID
+
+	return appGUID, nil
+}
+
+func (c *LogCollector) followContainer(ctx context.Context, appGUID string, pod corev1.Pod, container collectedContainer) {
+	logOptions := corev1.PodLogOptions{
+		Container:  container.name,
+		Follow:     true,
+		Timestamps: true,
+	}
+	if !c.collectLogs(ctx, appGUID, pod, logOptions) {
+		return
+	}
+
+	c.recordExit(ctx, appGUID, pod, container)
+}
+
+// collectPreviousRun collects the logs and the exit status of a container run
+// that has terminated before it could be followed
+func (c *LogCollector) collectPreviousRun(
+	ctx context.Context,
+	appGUID string,
+	pod corev1.Pod,
+	container collectedContainer,
+	terminated *corev1.ContainerStateTerminated,
+) {
+	c.collectLogs(ctx, appGUID, pod, corev1.PodLogOptions{
+		Container:  container.name,
+		Previous:   true,
+		Timestamps: true,
+	})
+
+	c.addExitRecord(appGUID, pod, terminated)
+}
+
+// collectLogs adds the container logs to the buffer and returns whether they
+// have been read through
+func (c *LogCollector) collectLogs(ctx context.Context, appGUID string, pod corev1.Pod, logOptions corev1.PodLogOptions) bool {
+	logger := logr.FromContextOrDiscard(ctx).WithName("log-collector").WithValues("pod", pod.Name, "container", logOptions.Container)
+
+	logReadCloser, err := c.logStreamer(ctx, c.privilegedClientset, pod, logOptions)
+	if err != nil {
+		logger.Info("failed to read logs", "reason", err)
+		return false
+	}
+	defer logReadCloser.Close()
+
+	rateLimiter, err := newLogRateLimiter(ctx, c.privilegedClient, pod)
+	if err != nil {
+		logger.Info("failed to get the log rate limit, logs are not limited", "reason", err)
+		rateLimiter = &logRateLimiter{}
+	}
+
+	sourceType, instanceID := logSource(pod)
+
+	scanner := bufio.NewScanner(logReadCloser)
+	for scanner.Scan() {
+		if len(scanner.Text()) == 0 {
+			continue
+		}
+
+		record, ok := rateLimiter.limit(logLineToLogRecord(scanner.Text()))
+		if !ok {
+			continue
+		}
+
+		record.InstanceID = instanceID
+		record.Tags = map[string]string{
+			"source_type": sourceType,
+		}
+		c.logBuffer.Add(appGUID, record)
+	}
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err = scanner.Err(); err != nil {
+		logger.Info("failed to read logs", "reason", err)
+		return false
+	}
+
+	return true
+}
+
+// recordExit waits for the followed container to terminate and records its
+// exit status. The log stream may end before the termination is reflected in
+// the pod status, so it is waited for for a little while.
+func (c *LogCollector) recordExit(ctx context.Context, appGUID string, pod corev1.Pod, container collectedContainer) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("log-collector").WithValues("pod", pod.Name, "container", container.name)
+
+	var terminated *corev1.ContainerStateTerminated
+	err := wait.PollUntilContextTimeout(ctx, time.Second, exitStateTimeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.privilegedClient.Get(ctx, client.ObjectKeyFromObject(&pod), &pod); err != nil {
+			return false, err
+		}
+
+		terminated = terminatedState(pod, container)
+		return terminated != nil, nil
+	})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) && !wait.Interrupted(err) {
+			logger.Info("failed to get the container exit state", "reason", err)
+		}
+		return
+	}
+
+	c.addExitRecord(appGUID, pod, terminated)
+}
+
+// addExitRecord adds the exit status of a terminated container to the buffer,
+// including the reason and message of crashes (e.g. OOMKilled). Successful
+// staging steps are not recorded, as they are expected to exit.
+func (c *LogCollector) addExitRecord(appGUID string, pod corev1.Pod, terminated *corev1.ContainerStateTerminated) {
+	sourceType, instanceID := logSource(pod)
+	if sourceType == korifiv1alpha1.AppLogSourceTypeSTG && terminated.ExitCode == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("Exit status %d", terminated.ExitCode)
+	if terminated.Reason != "" && terminated.Reason != "Completed" {
+		message += fmt.Sprintf(" (%s)", terminated.Reason)
+	}
+	if terminated.Message != "" {
+		message += ": " + terminated.Message
+	}
+
+	c.logBuffer.Add(appGUID, LogRecord{
+		Message:    message,
+		Timestamp:  terminated.FinishedAt.UnixNano(),
+		InstanceID: instanceID,
+		Tags: map[string]string{
+			"source_type": korifiv1alpha1.AppLogSourceTypeCell,
+		},
+	})
+}
+
+// terminatedState returns the termination state of the followed container,
+// which is the last state if the container has been restarted since
+func terminatedState(pod corev1.Pod, container collectedContainer) *corev1.ContainerStateTerminated {
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if status.Name != container.name {
+			continue
+		}
+
+		if status.RestartCount == container.restartCount {
+			return status.State.Terminated
+		}
+
+		if status.RestartCount == container.restartCount+1 {
+			return status.LastTerminationState.Terminated
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var _ = Describe("LogCollector", func() {
+	var (
+		cfSpace     *korifiv1alpha1.CFSpace
+		appGUID     string
+		appPod      *corev1.Pod
+		logStreamer *fake.LogStreamer
+		logBuffer   *repositories.LogBuffer
+	)
+
+	BeforeEach(func() {
+		cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		appGUID = createApp(cfSpace.Name).Name
+
+		buildGUID := uuid.NewString()
+		createBuild(ctx, k8sClient, cfSpace.Name, buildGUID, uuid.NewString(), appGUID)
+
+		createReadyPod(cfSpace.Name, "build-pod", map[string]string{
+			repositories.BuildWorkloadLabelKey: buildGUID,
+		})
+		appPod = createReadyPod(cfSpace.Name, "web-0", map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+			korifiv1alpha1.CFProcessTypeLabelKey: "web",
+			korifiv1alpha1.PodIndexLabelKey:      "0",
+		})
+
+		logStreamer = new(fake.LogStreamer)
+		logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+			return readerFor(map[time.Time]string{
+				time.Unix(0, 100): pod.Name + "-log",
+			}), nil
+		}
+		logBuffer = repositories.NewLogBuffer(100)
+	})
+
+	JustBeforeEach(func() {
+		collectorCtx, cancelCollector := context.WithCancel(ctx)
+		DeferCleanup(cancelCollector)
+		go repositories.NewLogCollector(k8sClient, nil, logStreamer.Spy, logBuffer, 100*time.Millisecond).Start(collectorCtx)
+	})
+
+	It("follows the container logs", func() {
+		Eventually(logStreamer.CallCount).Should(BeNumerically(">=", 2))
+		for i := range logStreamer.CallCount() {
+			_, _, _, actualLogOptions := logStreamer.ArgsForCall(i)
+			Expect(actualLogOptions.Follow).To(BeTrue())
+			Expect(actualLogOptions.Timestamps).To(BeTrue())
+		}
+	})
+
+	It("collects the app and staging logs into the buffer", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(logBuffer.Get(appGUID)).To(ContainElements(
+				repositories.LogRecord{
+					Message:    "build-pod-log",
+					Timestamp:  100,
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "STG"},
+				},
+				repositories.LogRecord{
+					Message:    "web-0-log",
+					Timestamp:  100,
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "APP/PROC/WEB"},
+				},
+			))
+		}).Should(Succeed())
+	})
+
+	When("the app container crashes", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+				appPod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   137,
+						Reason:     "OOMKilled",
+						FinishedAt: metav1.NewTime(time.Unix(5, 0)),
+					},
+				}
+			})).To(Succeed())
+		})
+
+		It("records the exit status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(logBuffer.Get(appGUID)).To(ContainElement(repositories.LogRecord{
+					Message:    "Exit status 137 (OOMKilled)",
+					Timestamp:  time.Unix(5, 0).UnixNano(),
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "CELL"},
+				}))
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+		})
+	})
+
+	When("the app container is crash looping", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+				appPod.Status.ContainerStatuses[0].RestartCount = 3
+				appPod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				}
+				appPod.Status.ContainerStatuses[0].LastTerminationState = corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						Reason:     "Error",
+						FinishedAt: metav1.NewTime(time.Unix(6, 0)),
+					},
+				}
+			})).To(Succeed())
+		})
+
+		It("collects the logs of the previous run", func() {
+			Eventually(func(g Gomega) {
+				var previousLogOptions []corev1.PodLogOptions
+				for i := range logStreamer.CallCount() {
+					_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(i)
+					if actualPod.Name == appPod.Name && actualLogOptions.Previous {
+						previousLogOptions = append(previousLogOptions, actualLogOptions)
+					}
+				}
+				g.Expect(previousLogOptions).To(ConsistOf(corev1.PodLogOptions{
+					Container:  "the-container",
+					Previous:   true,
+					Timestamps: true,
+				}))
+			}).Should(Succeed())
+		})
+
+		It("records the exit status of the previous run", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(logBuffer.Get(appGUID)).To(ContainElement(repositories.LogRecord{
+					Message:    "Exit status 1 (Error)",
+					Timestamp:  time.Unix(6, 0).UnixNano(),
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "CELL"},
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the process limits the log rate", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+						korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					},
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:                     corev1.LocalObjectReference{Name: appGUID},
+					ProcessType:                "web",
+					LogRateLimitBytesPerSecond: tools.PtrTo[int64](10),
+				},
+			})).To(Succeed())
+
+			logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(strings.Join([]string{
+					time.Unix(1, 0).Format(time.RFC3339Nano) + " 0123456789",
+					time.Unix(1, 1).Format(time.RFC3339Nano) + " over",
+					time.Unix(1, 2).Format(time.RFC3339Nano) + " dropped",
+					time.Unix(2, 0).Format(time.RFC3339Nano) + " next",
+				}, "\n"))), nil
+			}
+		})
+
+		It("drops the logs that exceed the limit", func() {
+			Eventually(func(g Gomega) {
+				messages := []string{}
+				for _, record := range logBuffer.Get(appGUID) {
+					if record.Tags["source_type"] == "APP/PROC/WEB" {
+						messages = append(messages, record.Message)
+					}
+				}
+				g.Expect(messages).To(Equal([]string{
+					"0123456789",
+					"app instance exceeded log rate limit (10 bytes/sec)",
+					"next",
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("app log events are recorded", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      uuid.NewString(),
+					Annotations: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:             appGUID,
+						korifiv1alpha1.AppLogSourceTypeAnnotationKey: "API",
+						korifiv1alpha1.AppLogInstanceIDAnnotationKey: "0",
+					},
+				},
+				InvolvedObject: corev1.ObjectReference{Namespace: cfSpace.Name, Name: appGUID},
+				Source:         corev1.EventSource{Component: korifiv1alpha1.AppLogEventComponent},
+				Message:        "app-event",
+				LastTimestamp:  metav1.NewTime(time.Unix(7, 0)),
+				Count:          1,
+			})).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      uuid.NewString(),
+					Annotations: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:             appGUID,
+						korifiv1alpha1.AppLogSourceTypeAnnotationKey: "API",
+					},
+				},
+				InvolvedObject: corev1.ObjectReference{Namespace: cfSpace.Name, Name: appGUID},
+				Source:         corev1.EventSource{Component: "another-component"},
+				Message:        "another-event",
+				LastTimestamp:  metav1.NewTime(time.Unix(7, 0)),
+				Count:          1,
+			})).To(Succeed())
+		})
+
+		It("adds the events of the app log event component to the buffer once", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(logBuffer.Get(appGUID)).To(ContainElement(repositories.LogRecord{
+					Message:    "app-event",
+					Timestamp:  time.Unix(7, 0).UnixNano(),
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "API"},
+				}))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				records := logBuffer.Get(appGUID)
+				g.Expect(records).NotTo(ContainElement(HaveField("Message", "another-event")))
+				g.Expect(slices.DeleteFunc(records, func(record repositories.LogRecord) bool {
+					return record.Message != "app-event"
+				})).To(HaveLen(1))
+			}, "500ms").Should(Succeed())
+		})
+	})
+
+	When("the app is deleted", func() {
+		It("drops its logs", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(logBuffer.Get(appGUID)).NotTo(BeEmpty())
+			}).Should(Succeed())
+
+			Expect(k8sClient.Delete(ctx, &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{Namespace: cfSpace.Name, Name: appGUID},
+			})).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(logBuffer.Get(appGUID)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses;cftasks,verbs=get;list
+
+const LogRateLimitExceededMessage = "app instance exceeded log rate limit (%d bytes/sec)"
+
+// logRateLimiter drops the log records of an app instance or task that exceed
+// its log rate limit. The limit applies to the records emitted within each
+// second of their timestamps, so the same records are dropped whenever the
+// logs are read. The first record over the limit within a second is replaced
+// with a record reporting that the limit has been exceeded.
+type logRateLimiter struct {
+	bytesPerSecond *int64
+	second         int64
+	bytes          int64
+	exceeded       bool
+}
+
+// limit returns the record to emit for a log record, and false if it is
+// dropped
+func (l *logRateLimiter) limit(record LogRecord) (LogRecord, bool) {
+	if l.bytesPerSecond == nil {
+		return record, true
+	}
+
+	second := record.Timestamp / int64(time.Second)
+	if second != l.second {
+		l.second = second
+		l.bytes = 0
+		l.exceeded = false
+	}
+
+	l.bytes += int64(len(record.Message))
+	if l.bytes <= *l.bytesPerSecond {
+		return record, true
+	}
+
+	if l.exceeded {
+		return LogRecord{}, false
+	}
+	l.exceeded = true
+
+	record.Message = fmt.Sprintf(LogRateLimitExceededMessage, *l.bytesPerSecond)
+	return record, true
+}
+
+// newLogRateLimiter returns the log rate limiter for the logs of a pod. App
+// instances are limited by the log rate limit of their process and task pods
+// by the one of their task, as set when the pod logs start being followed.
+// Staging logs are not limited.
+func newLogRateLimiter(ctx context.Context, k8sClient client.Client, pod corev1.Pod) (*logRateLimiter, error) {
+	if taskGUID, ok := pod.Labels[korifiv1alpha1.CFTaskGUIDLabelKey]; ok {
+		task := korifiv1alpha1.CFTask{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: taskGUID}, &task); err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+
+		return &logRateLimiter{bytesPerSecond: task.Spec.LogRateLimitBytesPerSecond}, nil
+	}
+
+	processType, ok := pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]
+	if !ok {
+		return &logRateLimiter{}, nil
+	}
+
+	processes := korifiv1alpha1.CFProcessList{}
+	err := k8sClient.List(ctx, &processes, client.InNamespace(pod.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey:     pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+		korifiv1alpha1.CFProcessTypeLabelKey: processType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	if len(processes.Items) == 0 {
+		return &logRateLimiter{}, nil
+	}
+
+	return &logRateLimiter{bytesPerSecond: processes.Items[0].Spec.LogRateLimitBytesPerSecond}, nil
+}
@@ -1,17 +1,17 @@
 package repositories
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
-	"iter"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
-	"code.cloudfoundry.org/korifi/tools"
 	k8sclient "k8s.io/client-go/kubernetes"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -32,19 +33,23 @@ const (
 type LogStreamer func(context.Context, k8sclient.Interface, corev1.Pod, corev1.PodLogOptions) (io.ReadCloser, error)
 
 type GetLogsMessage struct {
-	App   AppRecord
-	Build BuildRecord
+	App AppRecord
 
 	StartTime  *int64
 	Limit      *int64
 	Descending bool
 }
 
+type StreamLogsMessage struct {
+	App AppRecord
+}
+
 type LogRecord struct {
-	Message   string
-	Timestamp int64
-	Header    string
-	Tags      map[string]string
+	Message    string
+	Timestamp  int64
+	Header     string
+	InstanceID string
+	Tags       map[string]string
 }
 
 var DefaultLogStreamer LogStreamer = func(
@@ -70,39 +75,42 @@ type LogRepo struct {
 	userClientFactory    authorization.UserClientFactory
 	userClientsetFactory authorization.UserClientsetFactory
 	logStreamer          LogStreamer
+	logBuffer            *LogBuffer
+	podsPollInterval     time.Duration
 }
 
 func NewLogRepo(
 	userClientFactory authorization.UserClientFactory,
 	userClientsetFactory authorization.UserClientsetFactory,
 	logStreamer LogStreamer,
+	logBuffer *LogBuffer,
+	podsPollInterval time.Duration,
 ) *LogRepo {
 	return &LogRepo{
 		userClientFactory:    userClientFactory,
 		userClientsetFactory: userClientsetFactory,
 		logStreamer:          logStreamer,
+		logBuffer:            logBuffer,
+		podsPollInterval:     podsPollInterval,
 	}
 }
 
+// GetAppLogs returns the logs of the app collected in the log buffer, which
+// include the logs of its staging pods and of its instances that have been
+// restarted or deleted since
 func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, message GetLogsMessage) ([]LogRecord, error) {
-	buildLogs, err := r.getBuildLogs(ctx, authInfo, message.Build, message.StartTime, message.Limit)
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get build logs: %w", err)
+		return nil, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	appLogs, err := r.getAppLogs(ctx, authInfo, message.App, message.StartTime, message.Limit)
+	// the logs are visible to the users who can get the app
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.App.SpaceGUID, Name: message.App.GUID}, &korifiv1alpha1.CFApp{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get app logs: %w", err)
+		return nil, apierrors.FromK8sError(err, AppResourceType)
 	}
 
-	logs := itx.From(buildLogs).Chain(appLogs).Filter(func(r LogRecord) bool {
-		// Even though we have listed logs with `SinceTime` option, ensure that
-		// there are no log entries several milliseconds before the StartTime
-		// `SinceTime` log option has a precision of a second, therefore listed
-		// logs could contain entries that are a millisecond prior the
-		// `StartTime` if the `StartTime` has seconds fraction.
-		// See	https://github.com/kubernetes/kubernetes/issues/77856 and
-		// https://github.com/kubernetes/kubernetes/pull/92595
+	logs := itx.FromSlice(r.logBuffer.Get(message.App.GUID)).Filter(func(r LogRecord) bool {
 		if message.StartTime == nil || *message.StartTime < 0 {
 			return true
 		}
@@ -113,175 +121,207 @@ func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, m
 	if message.Descending {
 		sortOrder = descendingOrder
 	}
-	slices.SortFunc(logs, sortOrder)
-
-	if message.Limit == nil {
-		return logs, nil
-	}
+	slices.SortStableFunc(logs, sortOrder)
 
-	if len(logs) <= int(*message.Limit) {
+	if message.Limit == nil || len(logs) <= int(*message.Limit) {
 		return logs, nil
 	}
 
-	return logs[:len(logs)-int(*message.Limit)], nil
+	return logs[:*message.Limit], nil
 }
 
-func (r *LogRepo) getBuildLogs(
-	ctx context.Context,
-	authInfo authorization.Info,
-	build BuildRecord,
-	startTime *int64,
-	limit *int64,
-) (iter.Seq[LogRecord], error) {
-	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
-		BuildWorkloadLabelKey: build.GUID,
-	})
+// StreamAppLogs follows the logs of all the app instances and of the app
+// staging pods. Pods are polled for, so that instances and builds started
+// after the stream has been opened are picked up as well. Only log entries
+// emitted after the stream has been opened are sent. The returned channel is
+// closed once the context is done.
+func (r *LogRepo) StreamAppLogs(ctx context.Context, authInfo authorization.Info, message StreamLogsMessage) (<-chan LogRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	logClient, err := r.userClientsetFactory.BuildClientset(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	logs, err := r.getLogs(
-		ctx,
-		authInfo,
-		&client.ListOptions{
-			Namespace:     build.SpaceGUID,
-			LabelSelector: labelSelector,
-		},
-		startTime,
-		limit,
-	)
+	// list the pods upfront so that authorisation errors are returned to the
+	// caller rather than ending the stream
+	pods, err := r.listAppAndStagingPods(ctx, userClient, message.App)
 	if err != nil {
 		return nil, err
 	}
 
-	return it.Map(logs, func(record LogRecord) LogRecord {
-		record.Tags = map[string]string{
-			"source_type": "STG",
+	records := make(chan LogRecord)
+	sinceTime := metav1.Now()
+
+	go func() {
+		logger := logr.FromContextOrDiscard(ctx).WithName("stream-app-logs").WithValues("app", message.App.GUID)
+		followedContainers := map[string]bool{}
+		followers := sync.WaitGroup{}
+		defer func() {
+			followers.Wait()
+			close(records)
+		}()
+
+		ticker := time.NewTicker(r.podsPollInterval)
+		defer ticker.Stop()
+
+		for {
+			for _, pod := range pods {
+				for _, containerStatus := range getReadyContainerStatuses(pod) {
+					// restarted containers are followed again, as their
+					// logs are the ones of a new container
+					containerKey := fmt.Sprintf("%s/%s/%d", pod.UID, containerStatus.Name, containerStatus.RestartCount)
+					if followedContainers[containerKey] {
+						continue
+					}
+					followedContainers[containerKey] = true
+
+					followers.Add(1)
+					go func() {
+						defer followers.Done()
+						r.followContainerLogs(ctx, userClient, logClient, pod, corev1.PodLogOptions{
+							Container:  containerStatus.Name,
+							Follow:     true,
+							Timestamps: true,
+							SinceTime:  &sinceTime,
+						}, records)
+					}()
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			pods, err = r.listAppAndStagingPods(ctx, userClient, message.App)
+			if err != nil {
+				logger.Info("failed to list pods", "reason", err)
+			}
 		}
-		return record
-	}), nil
+	}()
+
+	return records, nil
 }
 
-func (r *LogRepo) getAppLogs(
-	ctx context.Context,
-	authInfo authorization.Info,
-	app AppRecord,
-	startTime *int64,
-	limit *int64,
-) (iter.Seq[LogRecord], error) {
-	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
+func (r *LogRepo) listAppAndStagingPods(ctx context.Context, userClient client.Client, app AppRecord) ([]corev1.Pod, error) {
+	appPods := corev1.PodList{}
+	err := userClient.List(ctx, &appPods, client.InNamespace(app.SpaceGUID), client.MatchingLabels{
 		korifiv1alpha1.CFAppGUIDLabelKey: app.GUID,
-		korifiv1alpha1.VersionLabelKey:   app.Revision,
 	})
 	if err != nil {
-		return nil, err
+		return nil, apierrors.FromK8sError(err, PodResourceType)
 	}
 
-	logs, err := r.getLogs(
-		ctx,
-		authInfo,
-		&client.ListOptions{
-			Namespace:     app.SpaceGUID,
-			LabelSelector: labelSelector,
-		},
-		startTime,
-		limit,
-	)
+	builds := korifiv1alpha1.CFBuildList{}
+	err = userClient.List(ctx, &builds, client.InNamespace(app.SpaceGUID), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: app.GUID,
+	})
 	if err != nil {
-		return nil, err
+		return nil, apierrors.FromK8sError(err, BuildResourceType)
 	}
 
-	return it.Map(logs, func(record LogRecord) LogRecord {
-		record.Tags = map[string]string{
-			"source_type": "APP",
-		}
-		return record
-	}), nil
-}
-
-func (r *LogRepo) getLogs(
-	ctx context.Context,
-	authInfo authorization.Info,
-	podListOptions *client.ListOptions,
-	startTime *int64,
-	limit *int64,
-) (iter.Seq[LogRecord], error) {
-	userClient, err := r.userClientFactory.BuildClient(authInfo)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build user client: %w", err)
+	if len(builds.Items) == 0 {
+		return appPods.Items, nil
 	}
 
-	logClient, err := r.userClientsetFactory.BuildClientset(authInfo)
+	buildGUIDs := slices.Collect(it.Map(slices.Values(builds.Items), func(build korifiv1alpha1.CFBuild) string {
+		return build.Name
+	}))
+	buildRequirement, err := labels.NewRequirement(BuildWorkloadLabelKey, selection.In, buildGUIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build user client: %w", err)
+		return nil, err
 	}
 
-	podList := corev1.PodList{}
-	err = userClient.List(ctx, &podList, podListOptions)
+	stagingPods := corev1.PodList{}
+	err = userClient.List(ctx, &stagingPods, &client.ListOptions{
+		Namespace:     app.SpaceGUID,
+		LabelSelector: labels.NewSelector().Add(*buildRequirement),
+	})
 	if err != nil {
 		return nil, apierrors.FromK8sError(err, PodResourceType)
 	}
 
-	podLogRecords := slices.Collect(it.Map(slices.Values(podList.Items), func(pod corev1.Pod) func(func(LogRecord) bool) {
-		return r.getLogsForPod(ctx, logClient, pod, startTime, limit)
-	}))
-
-	return it.Chain(podLogRecords...), nil
+	return append(appPods.Items, stagingPods.Items...), nil
 }
 
-func (r *LogRepo) getLogsForPod(ctx context.Context, k8sClient k8sclient.Interface, pod corev1.Pod, startTime *int64, limit *int64) iter.Seq[LogRecord] {
-	readyContaines := getReadyContainers(pod)
-
-	readyContainerLogs := slices.Collect(it.Map(slices.Values(readyContaines), func(containerName string) func(func(LogRecord) bool) {
-		return r.getContainerLogs(ctx, k8sClient, pod, corev1.PodLogOptions{
-			Container:  containerName,
-			Timestamps: true,
-			SinceTime:  toMetav1Time(startTime),
-			TailLines:  limit,
-		})
-	}))
-
-	return it.Chain(readyContainerLogs...)
-}
+func (r *LogRepo) followContainerLogs(
+	ctx context.Context,
+	userClient client.Client,
+	logClient k8sclient.Interface,
+	pod corev1.Pod,
+	logOpts corev1.PodLogOptions,
+	records chan<- LogRecord,
+) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("follow-container-logs").WithValues("pod", pod.Name, "container", logOpts.Container)
 
-func (r *LogRepo) getContainerLogs(ctx context.Context, k8sClient k8sclient.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) iter.Seq[LogRecord] {
-	logger := logr.FromContextOrDiscard(ctx).WithName("get-container-logs").WithValues("pod", pod.Name)
+	logReadCloser, err := r.logStreamer(ctx, logClient, pod, logOpts)
+	if err != nil {
+		logger.Info("failed to follow logs", "reason", err)
+		return
+	}
+	defer logReadCloser.Close()
 
-	logReadCloser, err := r.logStreamer(ctx, k8sClient, pod, logOpts)
+	rateLimiter, err := newLogRateLimiter(ctx, userClient, pod)
 	if err != nil {
-		logger.Info("failed to fetch logs", "reason", err)
-		return it.Exhausted[LogRecord]()
+		logger.Info("failed to get the log rate limit, logs are not limited", "reason", err)
+		rateLimiter = &logRateLimiter{}
 	}
 
-	defer logReadCloser.Close()
+	sourceType, instanceID := logSource(pod)
 
-	logLines := itx.FromSlice(readLines(ctx, logReadCloser)).Filter(func(logLine string) bool {
-		return len(logLine) > 0
-	})
+	scanner := bufio.NewScanner(logReadCloser)
+	for scanner.Scan() {
+		if len(scanner.Text()) == 0 {
+			continue
+		}
 
-	return it.Map(logLines, logLineToLogRecord)
-}
+		record, ok := rateLimiter.limit(logLineToLogRecord(scanner.Text()))
+		if !ok {
+			continue
+		}
 
-func getReadyContainers(pod corev1.Pod) []string {
-	containerStatuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
-	readyContainers := it.Filter(slices.Values(containerStatuses), func(status corev1.ContainerStatus) bool {
-		return status.State.Waiting == nil
-	})
+		record.InstanceID = instanceID
+		record.Tags = map[string]string{
+			"source_type": sourceType,
+		}
 
-	return slices.Collect(it.Map(readyContainers, func(container corev1.ContainerStatus) string {
-		return container.Name
-	}))
+		select {
+		case records <- record:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	if err = scanner.Err(); err != nil && ctx.Err() == nil {
+		logger.Info("failed to read logs", "reason", err)
+	}
 }
 
-func readLines(ctx context.Context, r io.Reader) []string {
-	logger := logr.FromContextOrDiscard(ctx)
+// logSource returns the loggregator source type and instance id for the logs
+// of a pod, e.g. APP/PROC/WEB and 0 for the first instance of the web process
+func logSource(pod corev1.Pod) (string, string) {
+	if _, isStagingPod := pod.Labels[BuildWorkloadLabelKey]; isStagingPod {
+		return "STG", "0"
+	}
 
-	lines, err := it.TryCollect(it.LinesString(r))
-	if err != nil {
-		logger.Info("failed to parse pod logs", "err", err)
+	sourceType := "APP"
+	if processType, ok := pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]; ok {
+		sourceType = "APP/PROC/" + strings.ToUpper(processType)
 	}
 
-	return lines
+	return sourceType, pod.Labels[korifiv1alpha1.PodIndexLabelKey]
+}
+
+func getReadyContainerStatuses(pod corev1.Pod) []corev1.ContainerStatus {
+	containerStatuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+	return slices.Collect(it.Filter(slices.Values(containerStatuses), func(status corev1.ContainerStatus) bool {
+		return status.State.Waiting == nil
+	}))
 }
 
 func logLineToLogRecord(logLine string) LogRecord {
@@ -311,11 +351,3 @@ func parseRFC3339NanoTime(input string) (string, int64) {
 
 	return input[timestampSeparatorIndex+1:], t.UnixNano()
 }
-
-func toMetav1Time(timestamp *int64) *metav1.Time {
-	if timestamp == nil {
-		return nil
-	}
-
-	return tools.PtrTo(metav1.NewTime(time.Unix(0, *timestamp)))
-}
@@ -17,8 +17,6 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	. "github.com/onsi/gomega/gstruct"
-	"github.com/onsi/gomega/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -26,106 +24,38 @@ import (
 
 var _ = Describe("LogRepository", func() {
 	var (
-		appPod   *corev1.Pod
-		buildPod *corev1.Pod
-		message  repositories.GetLogsMessage
-		cfOrg    *korifiv1alpha1.CFOrg
-		cfSpace  *korifiv1alpha1.CFSpace
-
-		logStreamer *fake.LogStreamer
-		logRepo     *repositories.LogRepo
-		logRecords  []repositories.LogRecord
-		err         error
+		appGUID    string
+		message    repositories.GetLogsMessage
+		cfSpace    *korifiv1alpha1.CFSpace
+		logBuffer  *repositories.LogBuffer
+		logRepo    *repositories.LogRepo
+		logRecords []repositories.LogRecord
+		err        error
 	)
 
 	BeforeEach(func() {
-		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
 		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
-
-		buildGUID := uuid.NewString()
-		buildPod = &corev1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: cfSpace.Name,
-				Name:      buildGUID,
-				Labels: map[string]string{
-					repositories.BuildWorkloadLabelKey: buildGUID,
-				},
-			},
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{{
-					Image: "dont/care",
-					Name:  "build-completion-container",
-				}},
-				InitContainers: []corev1.Container{{
-					Image: "dont/care",
-					Name:  "build-container",
-				}},
-			},
+		appGUID = createApp(cfSpace.Name).Name
+
+		logBuffer = repositories.NewLogBuffer(100)
+		for _, record := range []repositories.LogRecord{
+			newLogRecord(100, "b0", "STG"),
+			newLogRecord(1000, "b1", "STG"),
+			newLogRecord(110, "a0", "APP"),
+			newLogRecord(1100, "a1", "APP"),
+			newLogRecord(2000, "b2", "STG"),
+			newLogRecord(2100, "a2", "APP"),
+		} {
+			logBuffer.Add(appGUID, record)
 		}
-		Expect(k8sClient.Create(ctx, buildPod)).To(Succeed())
-		Expect(k8s.Patch(ctx, k8sClient, buildPod, func() {
-			buildPod.Status = corev1.PodStatus{
-				InitContainerStatuses: []corev1.ContainerStatus{{
-					Name: "build-container",
-				}},
-			}
-		})).To(Succeed())
+		logBuffer.Add("another-app", newLogRecord(1500, "another-app-log", "APP"))
 
-		appGUID := uuid.NewString()
-		appPod = &corev1.Pod{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: cfSpace.Name,
-				Name:      appGUID,
-				Labels: map[string]string{
-					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
-					korifiv1alpha1.VersionLabelKey:   "7",
-				},
-			},
-			Spec: corev1.PodSpec{
-				Containers: []corev1.Container{{
-					Image: "dont/care",
-					Name:  "app-container",
-				}},
-			},
-		}
-		Expect(k8sClient.Create(ctx, appPod)).To(Succeed())
-		Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
-			appPod.Status = corev1.PodStatus{
-				ContainerStatuses: []corev1.ContainerStatus{{
-					Name: "app-container",
-				}},
-			}
-		})).To(Succeed())
-
-		logStreamer = new(fake.LogStreamer)
-		logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
-			switch pod.Name {
-			case buildPod.Name:
-				return readerFor(map[time.Time]string{
-					time.Unix(0, 100):  "b0",
-					time.Unix(0, 1000): "b1",
-					time.Unix(0, 2000): "b2",
-				}), nil
-			case appPod.Name:
-				return readerFor(map[time.Time]string{
-					time.Unix(0, 110):  "a0",
-					time.Unix(0, 1100): "a1",
-					time.Unix(0, 2100): "a2",
-				}), nil
-			}
-			return nil, nil
-		}
-
-		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy)
+		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, new(fake.LogStreamer).Spy, logBuffer, 100*time.Millisecond)
 
 		message = repositories.GetLogsMessage{
 			App: repositories.AppRecord{
 				GUID:      appGUID,
-				Revision:  "7",
-				SpaceGUID: cfSpace.Name,
-			},
-			Build: repositories.BuildRecord{
-				GUID:      buildGUID,
 				SpaceGUID: cfSpace.Name,
 			},
 			StartTime: tools.PtrTo[int64](1000),
@@ -142,63 +72,19 @@ var _ = Describe("LogRepository", func() {
 
 	When("the user is allowed to get logs", func() {
 		BeforeEach(func() {
-			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
 			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
 		})
 
-		It("asks streams for build and app pod containers", func() {
+		It("returns the buffered app log entries later than the start time in ascending order", func() {
 			Expect(err).NotTo(HaveOccurred())
-			Expect(logStreamer.CallCount()).To(Equal(2))
-
-			_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(0)
-			Expect(actualPod.Name).To(Equal(buildPod.Name))
-			Expect(actualLogOptions).To(Equal(corev1.PodLogOptions{
-				Container:  "build-container",
-				SinceTime:  tools.PtrTo(metav1.NewTime(time.Unix(0, 1000))),
-				Timestamps: true,
-			}))
-
-			_, _, actualPod, actualLogOptions = logStreamer.ArgsForCall(1)
-			Expect(actualPod.Name).To(Equal(appPod.Name))
-			Expect(actualLogOptions).To(Equal(corev1.PodLogOptions{
-				Container:  "app-container",
-				SinceTime:  tools.PtrTo(metav1.NewTime(time.Unix(0, 1000))),
-				Timestamps: true,
+			Expect(logRecords).To(Equal([]repositories.LogRecord{
+				newLogRecord(1000, "b1", "STG"),
+				newLogRecord(1100, "a1", "APP"),
+				newLogRecord(2000, "b2", "STG"),
+				newLogRecord(2100, "a2", "APP"),
 			}))
 		})
 
-		When("a pod container is in waiting state", func() {
-			BeforeEach(func() {
-				Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
-					appPod.Status = corev1.PodStatus{
-						ContainerStatuses: []corev1.ContainerStatus{{
-							Name: "app-container",
-							State: corev1.ContainerState{
-								Waiting: &corev1.ContainerStateWaiting{},
-							},
-						}},
-					}
-				})).To(Succeed())
-			})
-
-			It("does not fetch its logs", func() {
-				Expect(err).NotTo(HaveOccurred())
-				Expect(logStreamer.CallCount()).To(Equal(1))
-
-				_, _, actualPod, _ := logStreamer.ArgsForCall(0)
-				Expect(actualPod.Name).To(Equal(buildPod.Name))
-			})
-		})
-
-		It("merges build and app log entries later than the start time specified in ascending order", func() {
-			Expect(err).NotTo(HaveOccurred())
-			Expect(logRecords).To(HaveLen(4))
-			Expect(logRecords[0]).To(matchLogRecord(1000, "b1", "STG"))
-			Expect(logRecords[1]).To(matchLogRecord(1100, "a1", "APP"))
-			Expect(logRecords[2]).To(matchLogRecord(2000, "b2", "STG"))
-			Expect(logRecords[3]).To(matchLogRecord(2100, "a2", "APP"))
-		})
-
 		When("start time is not provided", func() {
 			BeforeEach(func() {
 				message.StartTime = nil
@@ -206,13 +92,14 @@ var _ = Describe("LogRepository", func() {
 
 			It("returns all logs", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(logRecords).To(HaveLen(6))
-				Expect(logRecords[0]).To(matchLogRecord(100, "b0", "STG"))
-				Expect(logRecords[1]).To(matchLogRecord(110, "a0", "APP"))
-				Expect(logRecords[2]).To(matchLogRecord(1000, "b1", "STG"))
-				Expect(logRecords[3]).To(matchLogRecord(1100, "a1", "APP"))
-				Expect(logRecords[4]).To(matchLogRecord(2000, "b2", "STG"))
-				Expect(logRecords[5]).To(matchLogRecord(2100, "a2", "APP"))
+				Expect(logRecords).To(Equal([]repositories.LogRecord{
+					newLogRecord(100, "b0", "STG"),
+					newLogRecord(110, "a0", "APP"),
+					newLogRecord(1000, "b1", "STG"),
+					newLogRecord(1100, "a1", "APP"),
+					newLogRecord(2000, "b2", "STG"),
+					newLogRecord(2100, "a2", "APP"),
+				}))
 			})
 		})
 
@@ -221,24 +108,12 @@ var _ = Describe("LogRepository", func() {
 				message.Limit = tools.PtrTo[int64](2)
 			})
 
-			It("uses the limit when getting app ad build log streams", func() {
-				Expect(err).NotTo(HaveOccurred())
-				Expect(logStreamer.CallCount()).To(Equal(2))
-
-				_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(0)
-				Expect(actualPod.Name).To(Equal(buildPod.Name))
-				Expect(actualLogOptions.TailLines).To(PointTo(BeEquivalentTo(2)))
-
-				_, _, actualPod, actualLogOptions = logStreamer.ArgsForCall(1)
-				Expect(actualPod.Name).To(Equal(appPod.Name))
-				Expect(actualLogOptions.TailLines).To(PointTo(BeEquivalentTo(2)))
-			})
-
 			It("limits the logs", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(logRecords).To(HaveLen(2))
-				Expect(logRecords[0]).To(matchLogRecord(1000, "b1", "STG"))
-				Expect(logRecords[1]).To(matchLogRecord(1100, "a1", "APP"))
+				Expect(logRecords).To(Equal([]repositories.LogRecord{
+					newLogRecord(1000, "b1", "STG"),
+					newLogRecord(1100, "a1", "APP"),
+				}))
 			})
 
 			When("the limit is greater than the number of log lines", func() {
@@ -249,10 +124,6 @@ var _ = Describe("LogRepository", func() {
 				It("returns all logs since the desired timestamp", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(logRecords).To(HaveLen(4))
-					Expect(logRecords[0]).To(matchLogRecord(1000, "b1", "STG"))
-					Expect(logRecords[1]).To(matchLogRecord(1100, "a1", "APP"))
-					Expect(logRecords[2]).To(matchLogRecord(2000, "b2", "STG"))
-					Expect(logRecords[3]).To(matchLogRecord(2100, "a2", "APP"))
 				})
 			})
 		})
@@ -260,15 +131,162 @@ var _ = Describe("LogRepository", func() {
 		When("descending is requested", func() {
 			BeforeEach(func() {
 				message.Descending = true
+				message.Limit = tools.PtrTo[int64](3)
 			})
 
-			It("sorts logs in descending order", func() {
+			It("returns the most recent logs in descending order", func() {
 				Expect(err).NotTo(HaveOccurred())
-				Expect(logRecords).To(HaveLen(4))
-				Expect(logRecords[0]).To(matchLogRecord(2100, "a2", "APP"))
-				Expect(logRecords[1]).To(matchLogRecord(2000, "b2", "STG"))
-				Expect(logRecords[2]).To(matchLogRecord(1100, "a1", "APP"))
-				Expect(logRecords[3]).To(matchLogRecord(1000, "b1", "STG"))
+				Expect(logRecords).To(Equal([]repositories.LogRecord{
+					newLogRecord(2100, "a2", "APP"),
+					newLogRecord(2000, "b2", "STG"),
+					newLogRecord(1100, "a1", "APP"),
+				}))
+			})
+		})
+	})
+})
+
+var _ = Describe("LogRepository StreamAppLogs", func() {
+	var (
+		cfSpace     *korifiv1alpha1.CFSpace
+		appGUID     string
+		buildGUID   string
+		logStreamer *fake.LogStreamer
+		logRepo     *repositories.LogRepo
+
+		streamCtx    context.Context
+		cancelStream context.CancelFunc
+		records      <-chan repositories.LogRecord
+		err          error
+	)
+
+	BeforeEach(func() {
+		cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		appGUID = uuid.NewString()
+		buildGUID = uuid.NewString()
+
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      buildGUID,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: korifiv1alpha1.CFBuildSpec{
+				PackageRef: corev1.LocalObjectReference{Name: uuid.NewString()},
+				AppRef:     corev1.LocalObjectReference{Name: appGUID},
+				Lifecycle:  korifiv1alpha1.Lifecycle{Type: "buildpack"},
+			},
+		})).To(Succeed())
+
+		createReadyPod(cfSpace.Name, "build-pod", map[string]string{
+			repositories.BuildWorkloadLabelKey: buildGUID,
+		})
+		createReadyPod(cfSpace.Name, "web-0", map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+			korifiv1alpha1.CFProcessTypeLabelKey: "web",
+			korifiv1alpha1.PodIndexLabelKey:      "0",
+		})
+		createReadyPod(cfSpace.Name, "another-app-pod", map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey: uuid.NewString(),
+		})
+
+		logStreamer = new(fake.LogStreamer)
+		logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+			return readerFor(map[time.Time]string{
+				time.Unix(0, 100): pod.Name + "-log",
+			}), nil
+		}
+
+		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, repositories.NewLogBuffer(10), 100*time.Millisecond)
+		streamCtx, cancelStream = context.WithCancel(ctx)
+		DeferCleanup(func() {
+			cancelStream()
+		})
+	})
+
+	JustBeforeEach(func() {
+		records, err = logRepo.StreamAppLogs(streamCtx, authInfo, repositories.StreamLogsMessage{
+			App: repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: cfSpace.Name,
+			},
+		})
+	})
+
+	It("returns a forbidden error", func() {
+		Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+	})
+
+	When("the user is allowed to get logs", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		It("follows the app and staging pod logs", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(logStreamer.CallCount).Should(Equal(2))
+
+			for i := range 2 {
+				_, _, _, actualLogOptions := logStreamer.ArgsForCall(i)
+				Expect(actualLogOptions.Container).To(Equal("the-container"))
+				Expect(actualLogOptions.Follow).To(BeTrue())
+				Expect(actualLogOptions.Timestamps).To(BeTrue())
+				Expect(actualLogOptions.SinceTime).NotTo(BeNil())
+			}
+		})
+
+		It("tags the log records with their source", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			received := []repositories.LogRecord{}
+			for range 2 {
+				var record repositories.LogRecord
+				Eventually(records).Should(Receive(&record))
+				received = append(received, record)
+			}
+			Expect(received).To(ConsistOf(
+				repositories.LogRecord{
+					Message:    "build-pod-log",
+					Timestamp:  100,
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "STG"},
+				},
+				repositories.LogRecord{
+					Message:    "web-0-log",
+					Timestamp:  100,
+					InstanceID: "0",
+					Tags:       map[string]string{"source_type": "APP/PROC/WEB"},
+				},
+			))
+		})
+
+		When("a new instance starts", func() {
+			JustBeforeEach(func() {
+				Eventually(logStreamer.CallCount).Should(Equal(2))
+				createReadyPod(cfSpace.Name, "web-1", map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.PodIndexLabelKey:      "1",
+				})
+			})
+
+			It("follows its logs too", func() {
+				Eventually(logStreamer.CallCount).Should(Equal(3))
+				_, _, actualPod, _ := logStreamer.ArgsForCall(2)
+				Expect(actualPod.Name).To(Equal("web-1"))
+			})
+		})
+
+		When("the context is done", func() {
+			JustBeforeEach(func() {
+				cancelStream()
+			})
+
+			It("closes the channel", func() {
+				Eventually(records).Should(BeClosed())
 			})
 		})
 	})
@@ -283,12 +301,38 @@ func readerFor(logs map[time.Time]string) io.ReadCloser {
 	return io.NopCloser(strings.NewReader(strings.Join(result, "\n")))
 }
 
-func matchLogRecord(timestamp int64, message string, tag string) types.GomegaMatcher {
-	return Equal(repositories.LogRecord{
+func newLogRecord(timestamp int64, message string, sourceType string) repositories.LogRecord {
+	return repositories.LogRecord{
 		Message:   message,
 		Timestamp: timestamp,
 		Tags: map[string]string{
-			"source_type": tag,
+			"source_type": sourceType,
 		},
-	})
+	}
+}
+
+func createReadyPod(namespace, name string, podLabels map[string]string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    podLabels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Image: "dont/care",
+				Name:  "the-container",
+			}},
+		},
+	}
+	Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+	Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+		pod.Status = corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "the-container",
+			}},
+		}
+	})).To(Succeed())
+
+	return pod
 }
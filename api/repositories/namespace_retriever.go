@@ -3,15 +3,17 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfrevisions;cfspaces;cftasks,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
 
@@ -52,6 +54,12 @@ var (
 		Resource: "cfprocesses",
 	}
 
+	CFRevisionsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfrevisions",
+	}
+
 	CFRoutesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -83,17 +91,19 @@ var (
 	}
 
 	ResourceMap = map[string]schema.GroupVersionResource{
-		AppResourceType:             CFAppsGVR,
-		BuildResourceType:           CFBuildsGVR,
-		DropletResourceType:         CFDropletsGVR,
-		DomainResourceType:          CFDomainsGVR,
-		PackageResourceType:         CFPackagesGVR,
-		ProcessResourceType:         CFProcessesGVR,
-		RouteResourceType:           CFRoutesGVR,
-		ServiceBindingResourceType:  CFServiceBindingsGVR,
-		ServiceInstanceResourceType: CFServiceInstancesGVR,
-		SpaceResourceType:           CFSpacesGVR,
-		TaskResourceType:            CFTasksGVR,
+		AppResourceType:                 CFAppsGVR,
+		BuildResourceType:               CFBuildsGVR,
+		DropletResourceType:             CFDropletsGVR,
+		DomainResourceType:              CFDomainsGVR,
+		PackageResourceType:             CFPackagesGVR,
+		ProcessResourceType:             CFProcessesGVR,
+		RevisionResourceType:            CFRevisionsGVR,
+		RouteResourceType:               CFRoutesGVR,
+		ServiceBindingResourceType:      CFServiceBindingsGVR,
+		ServiceInstanceResourceType:     CFServiceInstancesGVR,
+		ServiceRouteBindingResourceType: CFServiceBindingsGVR,
+		SpaceResourceType:               CFSpacesGVR,
+		TaskResourceType:                CFTasksGVR,
 	}
 )
 
@@ -126,6 +136,14 @@ func (nr NamespaceRetriever) NamespaceFor(ctx context.Context, resourceGUID, res
 		return "", apierrors.NewNotFoundError(fmt.Errorf("resource %q not found", resourceGUID), resourceType)
 	}
 
+	if len(list.Items) > 1 {
+		// resources moved to another namespace (e.g. transferred routes) exist
+		// in both namespaces until the original one is finalized
+		list.Items = slices.DeleteFunc(list.Items, func(item unstructured.Unstructured) bool {
+			return item.GetDeletionTimestamp() != nil
+		})
+	}
+
 	if len(list.Items) > 1 {
 		return "", fmt.Errorf("get-%s duplicate records exist", strings.ToLower(resourceType))
 	}
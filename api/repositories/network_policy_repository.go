@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	NetworkPolicyResourceType = "Network Policy"
+)
+
+type NetworkPolicyRecord struct {
+	SourceAppGUID      string
+	DestinationAppGUID string
+	Protocol           string
+	StartPort          int32
+	EndPort            int32
+}
+
+type CreateNetworkPoliciesMessage struct {
+	Policies []NetworkPolicyRecord
+}
+
+type DeleteNetworkPoliciesMessage struct {
+	Policies []NetworkPolicyRecord
+}
+
+type ListNetworkPoliciesMessage struct {
+	// AppGUIDs matches policies whose source or destination is one of the apps
+	AppGUIDs []string
+}
+
+func (m *ListNetworkPoliciesMessage) matches(p korifiv1alpha1.CFNetworkPolicy) bool {
+	return len(m.AppGUIDs) == 0 ||
+		slices.Contains(m.AppGUIDs, p.Spec.Source.AppGUID) ||
+		slices.Contains(m.AppGUIDs, p.Spec.Destination.AppGUID)
+}
+
+type NetworkPolicyRepo struct {
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+}
+
+func NewNetworkPolicyRepo(
+	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
+) *NetworkPolicyRepo {
+	return &NetworkPolicyRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+	}
+}
+
+// CreateNetworkPolicies allows the source apps to connect to the destination
+// apps. The policies are stored in the space of their destination app. All
+// the apps are looked up before any policy is created, and creating an
+// existing policy is a no-op.
+func (r *NetworkPolicyRepo) CreateNetworkPolicies(ctx context.Context, authInfo authorization.Info, message CreateNetworkPoliciesMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfNetworkPolicies := []*korifiv1alpha1.CFNetworkPolicy{}
+	for _, policy := range message.Policies {
+		sourceSpace, err := r.appSpace(ctx, userClient, policy.SourceAppGUID)
+		if err != nil {
+			return err
+		}
+
+		destinationSpace, err := r.appSpace(ctx, userClient, policy.DestinationAppGUID)
+		if err != nil {
+			return err
+		}
+
+		cfNetworkPolicies = append(cfNetworkPolicies, &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: destinationSpace,
+				Name:      networkPolicyName(policy),
+			},
+			Spec: korifiv1alpha1.CFNetworkPolicySpec{
+				Source: korifiv1alpha1.NetworkPolicySource{
+					AppGUID:   policy.SourceAppGUID,
+					SpaceGUID: sourceSpace,
+				},
+				Destination: korifiv1alpha1.NetworkPolicyDestination{
+					AppGUID:  policy.DestinationAppGUID,
+					Protocol: policy.Protocol,
+					Ports: korifiv1alpha1.NetworkPolicyPorts{
+						Start: policy.StartPort,
+						End:   policy.EndPort,
+					},
+				},
+			},
+		})
+	}
+
+	for _, cfNetworkPolicy := range cfNetworkPolicies {
+		err = userClient.Create(ctx, cfNetworkPolicy)
+		if k8serrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return apierrors.FromK8sError(err, NetworkPolicyResourceType)
+		}
+	}
+
+	return nil
+}
+
+func (r *NetworkPolicyRepo) ListNetworkPolicies(ctx context.Context, authInfo authorization.Info, message ListNetworkPoliciesMessage) ([]NetworkPolicyRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfNetworkPolicies := &korifiv1alpha1.CFNetworkPolicyList{}
+	if err = userClient.List(ctx, cfNetworkPolicies); err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", apierrors.FromK8sError(err, NetworkPolicyResourceType))
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfNetworkPolicies.Items).Filter(message.matches), toNetworkPolicyRecord))
+	slices.SortFunc(records, func(a, b NetworkPolicyRecord) int {
+		return cmp.Or(
+			cmp.Compare(a.SourceAppGUID, b.SourceAppGUID),
+			cmp.Compare(a.DestinationAppGUID, b.DestinationAppGUID),
+			cmp.Compare(a.Protocol, b.Protocol),
+			cmp.Compare(a.StartPort, b.StartPort),
+			cmp.Compare(a.EndPort, b.EndPort),
+		)
+	})
+
+	return records, nil
+}
+
+// DeleteNetworkPolicies removes the policies. Policies that do not exist
+// (including policies whose destination app is gone) are ignored.
+func (r *NetworkPolicyRepo) DeleteNetworkPolicies(ctx context.Context, authInfo authorization.Info, message DeleteNetworkPoliciesMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	for _, policy := range message.Policies {
+		destinationSpace, err := r.namespaceRetriever.NamespaceFor(ctx, policy.DestinationAppGUID, AppResourceType)
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = userClient.Delete(ctx, &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: destinationSpace,
+				Name:      networkPolicyName(policy),
+			},
+		})
+		if client.IgnoreNotFound(err) != nil {
+			return apierrors.FromK8sError(err, NetworkPolicyResourceType)
+		}
+	}
+
+	return nil
+}
+
+func (r *NetworkPolicyRepo) appSpace(ctx context.Context, userClient client.Client, appGUID string) (string, error) {
+	notFoundErr := apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("App with guid '%s' does not exist, or you do not have access to it.", appGUID))
+
+	spaceGUID, err := r.namespaceRetriever.NamespaceFor(ctx, appGUID, AppResourceType)
+	if errors.As(err, &apierrors.NotFoundError{}) {
+		return "", notFoundErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: spaceGUID, Name: appGUID}, &korifiv1alpha1.CFApp{})
+	if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+		return "", notFoundErr
+	}
+	if err != nil {
+		return "", apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	return spaceGUID, nil
+}
+
+// networkPolicyName is deterministic so that a policy can be deleted by its
+// content, as the policy-server API does not expose policy GUIDs
+func networkPolicyName(policy NetworkPolicyRecord) string {
+	return tools.NamespacedUUID(
+		policy.SourceAppGUID,
+		policy.DestinationAppGUID,
+		policy.Protocol,
+		strconv.Itoa(int(policy.StartPort)),
+		strconv.Itoa(int(policy.EndPort)),
+	)
+}
+
+func toNetworkPolicyRecord(cfNetworkPolicy korifiv1alpha1.CFNetworkPolicy) NetworkPolicyRecord {
+	return NetworkPolicyRecord{
+		SourceAppGUID:      cfNetworkPolicy.Spec.Source.AppGUID,
+		DestinationAppGUID: cfNetworkPolicy.Spec.Destination.AppGUID,
+		Protocol:           cfNetworkPolicy.Spec.Destination.Protocol,
+		StartPort:          cfNetworkPolicy.Spec.Destination.Ports.Start,
+		EndPort:            cfNetworkPolicy.Spec.Destination.Ports.End,
+	}
+}
@@ -0,0 +1,192 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicyRepo", func() {
+	var (
+		repo             *repositories.NetworkPolicyRepo
+		sourceSpace      *korifiv1alpha1.CFSpace
+		destinationSpace *korifiv1alpha1.CFSpace
+		sourceApp        *korifiv1alpha1.CFApp
+		destinationApp   *korifiv1alpha1.CFApp
+		policy           repositories.NetworkPolicyRecord
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewNetworkPolicyRepo(userClientFactory, namespaceRetriever)
+		org := createOrgWithCleanup(ctx, uuid.NewString())
+		sourceSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		destinationSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		sourceApp = createApp(sourceSpace.Name)
+		destinationApp = createApp(destinationSpace.Name)
+
+		policy = repositories.NetworkPolicyRecord{
+			SourceAppGUID:      sourceApp.Name,
+			DestinationAppGUID: destinationApp.Name,
+			Protocol:           korifiv1alpha1.NetworkPolicyProtocolTCP,
+			StartPort:          8080,
+			EndPort:            8090,
+		}
+	})
+
+	listCFNetworkPolicies := func() []korifiv1alpha1.CFNetworkPolicy {
+		cfNetworkPolicies := &korifiv1alpha1.CFNetworkPolicyList{}
+		Expect(k8sClient.List(ctx, cfNetworkPolicies, client.InNamespace(destinationSpace.Name))).To(Succeed())
+		return cfNetworkPolicies.Items
+	}
+
+	Describe("CreateNetworkPolicies", func() {
+		var createErr error
+
+		JustBeforeEach(func() {
+			createErr = repo.CreateNetworkPolicies(ctx, authInfo, repositories.CreateNetworkPoliciesMessage{
+				Policies: []repositories.NetworkPolicyRecord{policy},
+			})
+		})
+
+		It("returns an unprocessable entity error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			Expect(listCFNetworkPolicies()).To(BeEmpty())
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			})
+
+			It("creates the policy in the destination space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfNetworkPolicies := listCFNetworkPolicies()
+				Expect(cfNetworkPolicies).To(HaveLen(1))
+				Expect(cfNetworkPolicies[0].Spec).To(Equal(korifiv1alpha1.CFNetworkPolicySpec{
+					Source: korifiv1alpha1.NetworkPolicySource{
+						AppGUID:   sourceApp.Name,
+						SpaceGUID: sourceSpace.Name,
+					},
+					Destination: korifiv1alpha1.NetworkPolicyDestination{
+						AppGUID:  destinationApp.Name,
+						Protocol: korifiv1alpha1.NetworkPolicyProtocolTCP,
+						Ports:    korifiv1alpha1.NetworkPolicyPorts{Start: 8080, End: 8090},
+					},
+				}))
+			})
+
+			When("the policy already exists", func() {
+				BeforeEach(func() {
+					Expect(repo.CreateNetworkPolicies(ctx, authInfo, repositories.CreateNetworkPoliciesMessage{
+						Policies: []repositories.NetworkPolicyRecord{policy},
+					})).To(Succeed())
+				})
+
+				It("does not duplicate it", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(listCFNetworkPolicies()).To(HaveLen(1))
+				})
+			})
+
+			When("the destination app does not exist", func() {
+				BeforeEach(func() {
+					policy.DestinationAppGUID = uuid.NewString()
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListNetworkPolicies", func() {
+		var (
+			message  repositories.ListNetworkPoliciesMessage
+			policies []repositories.NetworkPolicyRecord
+			listErr  error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListNetworkPoliciesMessage{}
+
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			Expect(repo.CreateNetworkPolicies(ctx, authInfo, repositories.CreateNetworkPoliciesMessage{
+				Policies: []repositories.NetworkPolicyRecord{policy},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			policies, listErr = repo.ListNetworkPolicies(ctx, authInfo, message)
+		})
+
+		It("lists the policies", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(policies).To(ContainElement(policy))
+		})
+
+		When("filtering by an unrelated app", func() {
+			BeforeEach(func() {
+				message.AppGUIDs = []string{uuid.NewString()}
+			})
+
+			It("returns no policies", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(policies).To(BeEmpty())
+			})
+		})
+
+		When("filtering by the source app", func() {
+			BeforeEach(func() {
+				message.AppGUIDs = []string{sourceApp.Name}
+			})
+
+			It("returns the policy", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(policies).To(ConsistOf(policy))
+			})
+		})
+	})
+
+	Describe("DeleteNetworkPolicies", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, destinationSpace.Name)
+			Expect(repo.CreateNetworkPolicies(ctx, authInfo, repositories.CreateNetworkPoliciesMessage{
+				Policies: []repositories.NetworkPolicyRecord{policy},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteNetworkPolicies(ctx, authInfo, repositories.DeleteNetworkPoliciesMessage{
+				Policies: []repositories.NetworkPolicyRecord{policy},
+			})
+		})
+
+		It("deletes the policy", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(listCFNetworkPolicies()).To(BeEmpty())
+		})
+
+		When("the policy does not exist", func() {
+			BeforeEach(func() {
+				policy.EndPort = 9000
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(listCFNetworkPolicies()).To(HaveLen(1))
+			})
+		})
+	})
+})
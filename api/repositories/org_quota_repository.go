@@ -0,0 +1,266 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OrgQuotaResourceType = "Organization Quota"
+)
+
+type OrgQuotaRecord struct {
+	quotas.OrgQuota
+	GUID          string
+	Organizations []string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+}
+
+type CreateOrgQuotaMessage struct {
+	quotas.OrgQuota
+	Organizations []string
+}
+
+type UpdateOrgQuotaMessage struct {
+	quotas.OrgQuota
+	GUID string
+}
+
+type ApplyOrgQuotaMessage struct {
+	GUID          string
+	Organizations []string
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+}
+
+func (m *ListOrgQuotasMessage) matches(q korifiv1alpha1.CFOrgQuota) bool {
+	return tools.EmptyOrContains(m.GUIDs, q.Name) &&
+		tools.EmptyOrContains(m.Names, q.Spec.Name) &&
+		(len(m.OrganizationGUIDs) == 0 || slices.ContainsFunc(q.Spec.Organizations, func(org string) bool {
+			return slices.Contains(m.OrganizationGUIDs, org)
+		}))
+}
+
+type OrgQuotaRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewOrgQuotaRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.ensureOrgsExist(ctx, userClient, message.Organizations); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			OrgQuota:      message.OrgQuota,
+			Organizations: tools.Uniq(message.Organizations),
+		},
+	}
+
+	if err = r.detachOrgs(ctx, userClient, cfOrgQuota.Name, message.Organizations); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = userClient.Create(ctx, cfOrgQuota); err != nil {
+		return OrgQuotaRecord{}, apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err = userClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota); err != nil {
+		return OrgQuotaRecord{}, apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuotas := &korifiv1alpha1.CFOrgQuotaList{}
+	if err = userClient.List(ctx, cfOrgQuotas, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfOrgQuotas.Items).Filter(message.matches), toOrgQuotaRecord))
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func (r *OrgQuotaRepo) UpdateOrgQuota(ctx context.Context, authInfo authorization.Info, message UpdateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	return r.patchOrgQuota(ctx, authInfo, message.GUID, func(cfOrgQuota *korifiv1alpha1.CFOrgQuota) {
+		cfOrgQuota.Spec.OrgQuota = message.OrgQuota
+	})
+}
+
+// ApplyOrgQuota associates the organizations with the quota. As an
+// organization can only have a single quota, the organizations are removed
+// from any quota they were previously associated with.
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, message ApplyOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if _, err = r.GetOrgQuota(ctx, authInfo, message.GUID); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.ensureOrgsExist(ctx, userClient, message.Organizations); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.detachOrgs(ctx, userClient, message.GUID, message.Organizations); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return r.patchOrgQuota(ctx, authInfo, message.GUID, func(cfOrgQuota *korifiv1alpha1.CFOrgQuota) {
+		cfOrgQuota.Spec.Organizations = tools.Uniq(append(cfOrgQuota.Spec.Organizations, message.Organizations...))
+	})
+}
+
+func (r *OrgQuotaRepo) patchOrgQuota(
+	ctx context.Context,
+	authInfo authorization.Info,
+	guid string,
+	patchFunc func(*korifiv1alpha1.CFOrgQuota),
+) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err = PatchResource(ctx, userClient, cfOrgQuota, func() {
+		patchFunc(cfOrgQuota)
+	}); err != nil {
+		return OrgQuotaRecord{}, apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) ensureOrgsExist(ctx context.Context, userClient client.Client, orgGUIDs []string) error {
+	missing := []string{}
+	for _, orgGUID := range orgGUIDs {
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, &korifiv1alpha1.CFOrg{})
+		if k8serrors.IsNotFound(err) {
+			missing = append(missing, orgGUID)
+			continue
+		}
+		if err != nil {
+			return apierrors.FromK8sError(err, OrgResourceType)
+		}
+	}
+
+	if len(missing) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Organizations with guids %v do not exist", missing))
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) detachOrgs(ctx context.Context, userClient client.Client, quotaGUID string, orgGUIDs []string) error {
+	if len(orgGUIDs) == 0 {
+		return nil
+	}
+
+	cfOrgQuotas := &korifiv1alpha1.CFOrgQuotaList{}
+	if err := userClient.List(ctx, cfOrgQuotas, client.InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	for _, cfOrgQuota := range cfOrgQuotas.Items {
+		if cfOrgQuota.Name == quotaGUID || !slices.ContainsFunc(cfOrgQuota.Spec.Organizations, func(org string) bool {
+			return slices.Contains(orgGUIDs, org)
+		}) {
+			continue
+		}
+
+		if err := PatchResource(ctx, userClient, &cfOrgQuota, func() {
+			cfOrgQuota.Spec.Organizations = slices.DeleteFunc(cfOrgQuota.Spec.Organizations, func(org string) bool {
+				return slices.Contains(orgGUIDs, org)
+			})
+		}); err != nil {
+			return apierrors.FromK8sError(err, OrgQuotaResourceType)
+		}
+	}
+
+	return nil
+}
+
+func toOrgQuotaRecord(cfOrgQuota korifiv1alpha1.CFOrgQuota) OrgQuotaRecord {
+	return OrgQuotaRecord{
+		OrgQuota:      cfOrgQuota.Spec.OrgQuota,
+		GUID:          cfOrgQuota.Name,
+		Organizations: cfOrgQuota.Spec.Organizations,
+		CreatedAt:     cfOrgQuota.CreationTimestamp.Time,
+		UpdatedAt:     getLastUpdatedTime(&cfOrgQuota),
+	}
+}
@@ -0,0 +1,265 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("OrgQuotaRepo", func() {
+	var (
+		repo *repositories.OrgQuotaRepo
+		org  *korifiv1alpha1.CFOrg
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewOrgQuotaRepo(userClientFactory, rootNamespace)
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+	})
+
+	createOrgQuota := func(name string, orgs ...string) *korifiv1alpha1.CFOrgQuota {
+		cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				OrgQuota: quotas.OrgQuota{
+					Name: name,
+					Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				Organizations: orgs,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+		return cfOrgQuota
+	}
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			message     repositories.CreateOrgQuotaMessage
+			orgQuota    repositories.OrgQuotaRecord
+			createErr   error
+			existingOrg *korifiv1alpha1.CFOrgQuota
+		)
+
+		BeforeEach(func() {
+			existingOrg = createOrgQuota("existing", org.Name)
+			message = repositories.CreateOrgQuotaMessage{
+				OrgQuota: quotas.OrgQuota{
+					Name: "my-quota",
+					Routes: quotas.RouteQuotas{
+						TotalRoutes: tools.PtrTo[int64](10),
+					},
+				},
+				Organizations: []string{org.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgQuota, createErr = repo.CreateOrgQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the quota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(orgQuota.GUID).NotTo(BeEmpty())
+				Expect(orgQuota.Name).To(Equal("my-quota"))
+				Expect(orgQuota.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(10)))
+				Expect(orgQuota.Organizations).To(ConsistOf(org.Name))
+
+				cfOrgQuota := &korifiv1alpha1.CFOrgQuota{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgQuota.GUID}, cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.Organizations).To(ConsistOf(org.Name))
+			})
+
+			It("removes the organization from its previous quota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existingOrg), existingOrg)).To(Succeed())
+				Expect(existingOrg.Spec.Organizations).To(BeEmpty())
+			})
+
+			When("the organization does not exist", func() {
+				BeforeEach(func() {
+					message.Organizations = []string{"not-an-org"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetOrgQuota", func() {
+		var (
+			cfOrgQuota *korifiv1alpha1.CFOrgQuota
+			orgQuota   repositories.OrgQuotaRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			cfOrgQuota = createOrgQuota("my-quota", org.Name)
+		})
+
+		JustBeforeEach(func() {
+			orgQuota, getErr = repo.GetOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("returns the quota", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(orgQuota.GUID).To(Equal(cfOrgQuota.Name))
+			Expect(orgQuota.Name).To(Equal("my-quota"))
+			Expect(orgQuota.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(1024)))
+			Expect(orgQuota.Organizations).To(ConsistOf(org.Name))
+		})
+
+		When("the quota does not exist", func() {
+			BeforeEach(func() {
+				cfOrgQuota.Name = "not-a-quota"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			quota1, quota2 *korifiv1alpha1.CFOrgQuota
+			message        repositories.ListOrgQuotasMessage
+			orgQuotas      []repositories.OrgQuotaRecord
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			quota1 = createOrgQuota("quota-1", org.Name)
+			quota2 = createOrgQuota("quota-2")
+			message = repositories.ListOrgQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			orgQuotas, err = repo.ListOrgQuotas(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists all quotas", func() {
+			Expect(orgQuotas).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+			))
+		})
+
+		When("filtering by organization", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{org.Name}
+			})
+
+			It("returns the quota applied to the organization", func() {
+				Expect(orgQuotas).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+				))
+			})
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"quota-2"}
+			})
+
+			It("returns the matching quota", func() {
+				Expect(orgQuotas).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+				))
+			})
+		})
+	})
+
+	Describe("UpdateOrgQuota", func() {
+		var (
+			cfOrgQuota *korifiv1alpha1.CFOrgQuota
+			orgQuota   repositories.OrgQuotaRecord
+			updateErr  error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			cfOrgQuota = createOrgQuota("my-quota", org.Name)
+		})
+
+		JustBeforeEach(func() {
+			orgQuota, updateErr = repo.UpdateOrgQuota(ctx, authInfo, repositories.UpdateOrgQuotaMessage{
+				GUID: cfOrgQuota.Name,
+				OrgQuota: quotas.OrgQuota{
+					Name: "new-name",
+					Apps: quotas.AppQuotas{TotalInstances: tools.PtrTo[int64](3)},
+				},
+			})
+		})
+
+		It("updates the quota limits without changing the organizations", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(orgQuota.Name).To(Equal("new-name"))
+			Expect(orgQuota.Apps.TotalMemoryInMB).To(BeNil())
+			Expect(orgQuota.Apps.TotalInstances).To(PointTo(BeEquivalentTo(3)))
+			Expect(orgQuota.Organizations).To(ConsistOf(org.Name))
+		})
+	})
+
+	Describe("ApplyOrgQuota", func() {
+		var (
+			otherOrg   *korifiv1alpha1.CFOrg
+			cfOrgQuota *korifiv1alpha1.CFOrgQuota
+			orgQuota   repositories.OrgQuotaRecord
+			applyErr   error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			otherOrg = createOrgWithCleanup(ctx, uuid.NewString())
+			cfOrgQuota = createOrgQuota("my-quota", org.Name)
+		})
+
+		JustBeforeEach(func() {
+			orgQuota, applyErr = repo.ApplyOrgQuota(ctx, authInfo, repositories.ApplyOrgQuotaMessage{
+				GUID:          cfOrgQuota.Name,
+				Organizations: []string{otherOrg.Name},
+			})
+		})
+
+		It("adds the organization to the quota", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(orgQuota.Organizations).To(ConsistOf(org.Name, otherOrg.Name))
+		})
+
+		When("the quota does not exist", func() {
+			BeforeEach(func() {
+				cfOrgQuota.Name = "not-a-quota"
+			})
+
+			It("returns a not found error", func() {
+				Expect(applyErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -81,6 +81,9 @@ type PackageRecord struct {
 	Labels      map[string]string
 	Annotations map[string]string
 	ImageRef    string
+	// SourceImageRef is the image holding the package content, which is empty
+	// until bits packages have been uploaded
+	SourceImageRef string
 }
 
 func (r PackageRecord) Relationships() map[string]string {
@@ -407,17 +410,18 @@ func (r *PackageRepo) cfPackageToPackageRecord(cfPackage korifiv1alpha1.CFPackag
 		state = PackageStateReady
 	}
 	return PackageRecord{
-		GUID:        cfPackage.Name,
-		UID:         cfPackage.UID,
-		SpaceGUID:   cfPackage.Namespace,
-		Type:        string(cfPackage.Spec.Type),
-		AppGUID:     cfPackage.Spec.AppRef.Name,
-		State:       state,
-		CreatedAt:   cfPackage.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfPackage),
-		Labels:      cfPackage.Labels,
-		Annotations: cfPackage.Annotations,
-		ImageRef:    r.repositoryRef(cfPackage),
+		GUID:           cfPackage.Name,
+		UID:            cfPackage.UID,
+		SpaceGUID:      cfPackage.Namespace,
+		Type:           string(cfPackage.Spec.Type),
+		AppGUID:        cfPackage.Spec.AppRef.Name,
+		State:          state,
+		CreatedAt:      cfPackage.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfPackage),
+		Labels:         cfPackage.Labels,
+		Annotations:    cfPackage.Annotations,
+		ImageRef:       r.repositoryRef(cfPackage),
+		SourceImageRef: cfPackage.Spec.Source.Registry.Image,
 	}
 }
 
@@ -42,11 +42,13 @@ type ProcessRecord struct {
 	DesiredInstances int32
 	MemoryMB         int64
 	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
+	// LogRateLimitInBytesPerSecond is -1 when the log rate is unlimited
+	LogRateLimitInBytesPerSecond int64
+	HealthCheck                  HealthCheck
+	Labels                       map[string]string
+	Annotations                  map[string]string
+	CreatedAt                    time.Time
+	UpdatedAt                    *time.Time
 }
 
 func (r ProcessRecord) Relationships() map[string]string {
@@ -77,20 +79,22 @@ type ScaleProcessMessage struct {
 }
 
 type ProcessScaleValues struct {
-	Instances *int32
-	MemoryMB  *int64
-	DiskMB    *int64
+	Instances                    *int32
+	MemoryMB                     *int64
+	DiskMB                       *int64
+	LogRateLimitInBytesPerSecond *int64
 }
 
 type CreateProcessMessage struct {
-	AppGUID          string
-	SpaceGUID        string
-	Type             string
-	Command          string
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	DesiredInstances *int32
-	MemoryMB         int64
+	AppGUID                      string
+	SpaceGUID                    string
+	Type                         string
+	Command                      string
+	DiskQuotaMB                  int64
+	HealthCheck                  HealthCheck
+	DesiredInstances             *int32
+	MemoryMB                     int64
+	LogRateLimitInBytesPerSecond *int64
 }
 
 type PatchProcessMessage struct {
@@ -104,6 +108,7 @@ type PatchProcessMessage struct {
 	HealthCheckType                     *string
 	DesiredInstances                    *int32
 	MemoryMB                            *int64
+	LogRateLimitInBytesPerSecond        *int64
 	MetadataPatch                       *MetadataPatch
 }
 
@@ -184,6 +189,9 @@ func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.I
 		if scaleProcessMessage.DiskMB != nil {
 			cfProcess.Spec.DiskQuotaMB = *scaleProcessMessage.DiskMB
 		}
+		if scaleProcessMessage.LogRateLimitInBytesPerSecond != nil {
+			cfProcess.Spec.LogRateLimitBytesPerSecond = toLogRateLimitSpec(scaleProcessMessage.LogRateLimitInBytesPerSecond)
+		}
 	})
 	if err != nil {
 		return ProcessRecord{}, fmt.Errorf("failed to scale process %q: %w", scaleProcessMessage.GUID, apierrors.FromK8sError(err, ProcessResourceType))
@@ -211,9 +219,10 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 				Type: korifiv1alpha1.HealthCheckType(message.HealthCheck.Type),
 				Data: korifiv1alpha1.HealthCheckData(message.HealthCheck.Data),
 			},
-			DesiredInstances: message.DesiredInstances,
-			MemoryMB:         message.MemoryMB,
-			DiskQuotaMB:      message.DiskQuotaMB,
+			DesiredInstances:           message.DesiredInstances,
+			MemoryMB:                   message.MemoryMB,
+			DiskQuotaMB:                message.DiskQuotaMB,
+			LogRateLimitBytesPerSecond: toLogRateLimitSpec(message.LogRateLimitInBytesPerSecond),
 		},
 	}
 	err = userClient.Create(ctx, process)
@@ -270,6 +279,9 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.DiskQuotaMB != nil {
 			updatedProcess.Spec.DiskQuotaMB = *message.DiskQuotaMB
 		}
+		if message.LogRateLimitInBytesPerSecond != nil {
+			updatedProcess.Spec.LogRateLimitBytesPerSecond = toLogRateLimitSpec(message.LogRateLimitInBytesPerSecond)
+		}
 		if message.HealthCheckType != nil {
 			// TODO: how do we handle when the type changes? Clear the HTTPEndpoint when type != http? Should we require the endpoint when type == http?
 			updatedProcess.Spec.HealthCheck.Type = korifiv1alpha1.HealthCheckType(*message.HealthCheckType)
@@ -301,14 +313,15 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 	}
 
 	return ProcessRecord{
-		GUID:             cfProcess.Name,
-		SpaceGUID:        cfProcess.Namespace,
-		AppGUID:          cfProcess.Spec.AppRef.Name,
-		Type:             cfProcess.Spec.ProcessType,
-		Command:          cmd,
-		DesiredInstances: *cfProcess.Spec.DesiredInstances,
-		MemoryMB:         cfProcess.Spec.MemoryMB,
-		DiskQuotaMB:      cfProcess.Spec.DiskQuotaMB,
+		GUID:                         cfProcess.Name,
+		SpaceGUID:                    cfProcess.Namespace,
+		AppGUID:                      cfProcess.Spec.AppRef.Name,
+		Type:                         cfProcess.Spec.ProcessType,
+		Command:                      cmd,
+		DesiredInstances:             *cfProcess.Spec.DesiredInstances,
+		MemoryMB:                     cfProcess.Spec.MemoryMB,
+		DiskQuotaMB:                  cfProcess.Spec.DiskQuotaMB,
+		LogRateLimitInBytesPerSecond: toLogRateLimitRecord(cfProcess.Spec.LogRateLimitBytesPerSecond),
 		HealthCheck: HealthCheck{
 			Type: string(cfProcess.Spec.HealthCheck.Type),
 			Data: HealthCheckData{
@@ -323,3 +336,21 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 		UpdatedAt:   getLastUpdatedTime(&cfProcess),
 	}
 }
+
+// toLogRateLimitSpec converts a CF API log rate limit, where -1 means
+// unlimited, to the resource spec one, where unlimited is not set
+func toLogRateLimitSpec(logRateLimit *int64) *int64 {
+	if logRateLimit == nil || *logRateLimit < 0 {
+		return nil
+	}
+
+	return tools.PtrTo(*logRateLimit)
+}
+
+func toLogRateLimitRecord(logRateLimit *int64) int64 {
+	if logRateLimit == nil {
+		return -1
+	}
+
+	return *logRateLimit
+}
@@ -10,6 +10,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -81,6 +82,7 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.DesiredInstances).To(Equal(*cfProcess1.Spec.DesiredInstances))
 				Expect(processRecord.MemoryMB).To(Equal(cfProcess1.Spec.MemoryMB))
 				Expect(processRecord.DiskQuotaMB).To(Equal(cfProcess1.Spec.DiskQuotaMB))
+				Expect(processRecord.LogRateLimitInBytesPerSecond).To(BeEquivalentTo(-1))
 				Expect(processRecord.HealthCheck.Type).To(Equal(string(cfProcess1.Spec.HealthCheck.Type)))
 				Expect(processRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(Equal(cfProcess1.Spec.HealthCheck.Data.InvocationTimeoutSeconds))
 				Expect(processRecord.HealthCheck.Data.TimeoutSeconds).To(Equal(cfProcess1.Spec.HealthCheck.Data.TimeoutSeconds))
@@ -310,6 +312,32 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(updatedCFProcess.Spec.MemoryMB).To(Equal(memoryScaleMB))
 			})
 
+			When("scaling the log rate limit", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](1024)
+					})).To(Succeed())
+				})
+
+				It("updates the log rate limit", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{LogRateLimitInBytesPerSecond: tools.PtrTo[int64](2048)}
+					scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).ToNot(HaveOccurred())
+					Expect(scaleProcessRecord.LogRateLimitInBytesPerSecond).To(BeEquivalentTo(2048))
+				})
+
+				It("removes the limit when scaled to unlimited", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{LogRateLimitInBytesPerSecond: tools.PtrTo[int64](-1)}
+					scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).ToNot(HaveOccurred())
+					Expect(scaleProcessRecord.LogRateLimitInBytesPerSecond).To(BeEquivalentTo(-1))
+
+					var updatedCFProcess korifiv1alpha1.CFProcess
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Name: process1GUID, Namespace: space1.Name}, &updatedCFProcess)).To(Succeed())
+					Expect(updatedCFProcess.Spec.LogRateLimitBytesPerSecond).To(BeNil())
+				})
+			})
+
 			When("scaling down a process to 0 instances", func() {
 				It("works", func() {
 					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo[int32](0)}
@@ -352,8 +380,9 @@ var _ = Describe("ProcessRepo", func() {
 						TimeoutSeconds:           10,
 					},
 				},
-				DesiredInstances: tools.PtrTo[int32](42),
-				MemoryMB:         456,
+				DesiredInstances:             tools.PtrTo[int32](42),
+				MemoryMB:                     456,
+				LogRateLimitInBytesPerSecond: tools.PtrTo[int64](1024),
 			})
 		})
 
@@ -382,9 +411,10 @@ var _ = Describe("ProcessRepo", func() {
 							TimeoutSeconds:           10,
 						},
 					},
-					DesiredInstances: tools.PtrTo[int32](42),
-					MemoryMB:         456,
-					DiskQuotaMB:      123,
+					DesiredInstances:           tools.PtrTo[int32](42),
+					MemoryMB:                   456,
+					DiskQuotaMB:                123,
+					LogRateLimitBytesPerSecond: tools.PtrTo[int64](1024),
 				}))
 			})
 
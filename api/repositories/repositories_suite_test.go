@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization/testhelpers"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -449,3 +450,53 @@ func createAppWithGUID(space, guid string) *korifiv1alpha1.CFApp {
 
 	return cfApp
 }
+
+func createBrokerServicePlan(ctx context.Context, features services.BrokerCatalogFeatures) *korifiv1alpha1.CFServicePlan {
+	serviceBroker := &korifiv1alpha1.CFServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+	}
+	Expect(k8sClient.Create(ctx, serviceBroker)).To(Succeed())
+
+	serviceOffering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFServiceOfferingSpec{
+			ServiceOffering: services.ServiceOffering{
+				BrokerCatalog: services.ServiceBrokerCatalog{
+					ID:       "offering-catalog-id",
+					Features: features,
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, serviceOffering)).To(Succeed())
+
+	servicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.RelServiceBrokerGUIDLabel:   serviceBroker.Name,
+				korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFServicePlanSpec{
+			ServicePlan: services.ServicePlan{
+				BrokerCatalog: services.ServicePlanBrokerCatalog{
+					ID: "plan-catalog-id",
+				},
+			},
+			Visibility: korifiv1alpha1.ServicePlanVisibility{
+				Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, servicePlan)).To(Succeed())
+
+	return servicePlan
+}
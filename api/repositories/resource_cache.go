@@ -0,0 +1,341 @@
+package repositories
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+)
+
+const (
+	defaultResourceMode = 0o644
+
+	resourceTempFilePrefix   = "resource-"
+	resourceTempFilePattern  = resourceTempFilePrefix + "*"
+	staleResourceTempFileAge = time.Hour
+)
+
+var resourceSHA1Regexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+type ResourceRecord struct {
+	SHA1        string
+	SizeInBytes int64
+	Path        string
+	Mode        string
+}
+
+// ResourceCache is a content addressed store of the files of uploaded
+// packages, keyed by their SHA1. It lets clients skip uploading the files that
+// are already known to the API, which are then pulled from the cache when the
+// package is assembled. Once the cache outgrows its maximum size, the least
+// recently used files are evicted.
+//
+// The state of the cache is only kept in its directory, which can therefore be
+// shared by several API replicas: files are touched whenever they are used, and
+// the least recently used files of the whole directory are evicted whenever
+// files are added to it.
+type ResourceCache struct {
+	dir          string
+	maxSizeBytes int64
+
+	mutex sync.Mutex
+}
+
+func NewResourceCache(dir string, maxSizeBytes int64) (*ResourceCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create resource cache dir %q: %w", dir, err)
+	}
+
+	cache := &ResourceCache{
+		dir:          dir,
+		maxSizeBytes: maxSizeBytes,
+	}
+
+	if err := cache.evict(); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// MatchResources returns the resources whose content is in the cache
+func (c *ResourceCache) MatchResources(resources []ResourceRecord) []ResourceRecord {
+	matches := []ResourceRecord{}
+	for _, resource := range resources {
+		if c.lookup(resource.SHA1, resource.SizeInBytes) {
+			matches = append(matches, resource)
+		}
+	}
+
+	return matches
+}
+
+// AddResources stores the files in the package zip in the cache
+func (c *ResourceCache) AddResources(packageZip io.ReaderAt, size int64) error {
+	zipReader, err := zip.NewReader(packageZip, size)
+	if err != nil {
+		return fmt.Errorf("failed to read package zip: %w", err)
+	}
+
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() || file.UncompressedSize64 == 0 || int64(file.UncompressedSize64) > c.maxSizeBytes {
+			continue
+		}
+
+		if err = c.add(file); err != nil {
+			return err
+		}
+	}
+
+	return c.evict()
+}
+
+// AssemblePackage returns a zip made of the files in the uploaded bits, if
+// any, and the given resources, whose content is read from the cache. The
+// returned reader must be closed to release the storage of the zip.
+func (c *ResourceCache) AssemblePackage(bits io.ReaderAt, bitsSize int64, resources []ResourceRecord) (io.ReadCloser, error) {
+	packageFile, err := os.CreateTemp("", "package-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create package file: %w", err)
+	}
+
+	assembledPackage := &tempFile{File: packageFile}
+	if err = c.writePackage(packageFile, bits, bitsSize, resources); err != nil {
+		assembledPackage.Close()
+		return nil, err
+	}
+
+	if _, err = packageFile.Seek(0, io.SeekStart); err != nil {
+		assembledPackage.Close()
+		return nil, fmt.Errorf("failed to rewind package file: %w", err)
+	}
+
+	return assembledPackage, nil
+}
+
+func (c *ResourceCache) writePackage(w io.Writer, bits io.ReaderAt, bitsSize int64, resources []ResourceRecord) error {
+	zipWriter := zip.NewWriter(w)
+
+	if bits != nil {
+		bitsReader, err := zip.NewReader(bits, bitsSize)
+		if err != nil {
+			return apierrors.NewUnprocessableEntityError(err, "The uploaded bits are not a valid zip file")
+		}
+
+		for _, file := range bitsReader.File {
+			if err = zipWriter.Copy(file); err != nil {
+				return fmt.Errorf("failed to copy %q to package: %w", file.Name, err)
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		if err := c.writeResource(zipWriter, resource); err != nil {
+			return err
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to write package: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ResourceCache) writeResource(zipWriter *zip.Writer, resource ResourceRecord) error {
+	mode, err := resourceMode(resource.Mode)
+	if err != nil {
+		return apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Resource %q has an invalid mode %q", resource.Path, resource.Mode))
+	}
+
+	content, err := c.open(resource)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	header := &zip.FileHeader{
+		Name:   resource.Path,
+		Method: zip.Deflate,
+	}
+	header.SetMode(mode)
+
+	entryWriter, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add %q to package: %w", resource.Path, err)
+	}
+
+	if _, err = io.Copy(entryWriter, content); err != nil {
+		return fmt.Errorf("failed to write %q to package: %w", resource.Path, err)
+	}
+
+	return nil
+}
+
+func (c *ResourceCache) open(resource ResourceRecord) (io.ReadCloser, error) {
+	notFoundErr := apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Resource %q with SHA1 %q could not be found in the resource cache", resource.Path, resource.SHA1))
+
+	if !c.lookup(resource.SHA1, resource.SizeInBytes) {
+		return nil, notFoundErr
+	}
+
+	// Files evicted after being opened remain readable until they are closed
+	content, err := os.Open(c.path(resource.SHA1))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, notFoundErr
+		}
+		return nil, fmt.Errorf("failed to open cached resource %q: %w", resource.SHA1, err)
+	}
+
+	return content, nil
+}
+
+func (c *ResourceCache) add(file *zip.File) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %q in package: %w", file.Name, err)
+	}
+	defer content.Close()
+
+	tmpFile, err := os.CreateTemp(c.dir, resourceTempFilePattern)
+	if err != nil {
+		return fmt.Errorf("failed to create resource cache file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	hash := sha1.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hash), content)
+	if err != nil {
+		return fmt.Errorf("failed to cache %q: %w", file.Name, err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to cache %q: %w", file.Name, err)
+	}
+	sha := hex.EncodeToString(hash.Sum(nil))
+
+	if c.lookup(sha, size) {
+		return nil
+	}
+
+	if err = os.Rename(tmpFile.Name(), c.path(sha)); err != nil {
+		return fmt.Errorf("failed to cache %q: %w", file.Name, err)
+	}
+
+	return nil
+}
+
+// lookup returns whether the file with the given SHA1 and size is in the
+// cache, and marks it as used if so. Files are looked up in the directory
+// rather than remembered, as they may have been added or evicted by another
+// replica.
+func (c *ResourceCache) lookup(sha string, size int64) bool {
+	if !resourceSHA1Regexp.MatchString(sha) {
+		return false
+	}
+
+	info, err := os.Stat(c.path(sha))
+	if err != nil || !info.Mode().IsRegular() || info.Size() != size {
+		return false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(c.path(sha), now, now)
+
+	return true
+}
+
+// evict removes the least recently used files until the cache fits its
+// maximum size, along with the temporary files of writes that have been
+// interrupted. Temporary files are only removed once they are old enough not
+// to be in use by another replica.
+func (c *ResourceCache) evict() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read resource cache dir %q: %w", c.dir, err)
+	}
+
+	var size int64
+	cachedFiles := []fs.FileInfo{}
+	for _, dirEntry := range dirEntries {
+		info, err := dirEntry.Info()
+		if err != nil {
+			// removed in the meantime
+			continue
+		}
+
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		if !resourceSHA1Regexp.MatchString(info.Name()) {
+			if strings.HasPrefix(info.Name(), resourceTempFilePrefix) && time.Since(info.ModTime()) > staleResourceTempFileAge {
+				_ = os.Remove(c.path(info.Name()))
+			}
+			continue
+		}
+
+		cachedFiles = append(cachedFiles, info)
+		size += info.Size()
+	}
+
+	slices.SortFunc(cachedFiles, func(f1, f2 fs.FileInfo) int {
+		return f1.ModTime().Compare(f2.ModTime())
+	})
+
+	for _, cachedFile := range cachedFiles {
+		if size <= c.maxSizeBytes {
+			break
+		}
+
+		if err = os.Remove(c.path(cachedFile.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to evict cached resource %q: %w", cachedFile.Name(), err)
+		}
+		size -= cachedFile.Size()
+	}
+
+	return nil
+}
+
+func (c *ResourceCache) path(sha string) string {
+	return filepath.Join(c.dir, sha)
+}
+
+func resourceMode(mode string) (fs.FileMode, error) {
+	if mode == "" {
+		return defaultResourceMode, nil
+	}
+
+	parsedMode, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return fs.FileMode(parsedMode).Perm(), nil
+}
+
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	defer os.Remove(f.Name())
+	return f.File.Close()
+}
@@ -0,0 +1,328 @@
+package repositories_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCache", func() {
+	var (
+		cacheDir      string
+		resourceCache *repositories.ResourceCache
+	)
+
+	BeforeEach(func() {
+		cacheDir = GinkgoT().TempDir()
+
+		var err error
+		resourceCache, err = repositories.NewResourceCache(cacheDir, 10)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	addResources := func(files map[string]string) {
+		GinkgoHelper()
+
+		packageZip := zipOf(files)
+		Expect(resourceCache.AddResources(bytes.NewReader(packageZip), int64(len(packageZip)))).To(Succeed())
+	}
+
+	Describe("MatchResources", func() {
+		BeforeEach(func() {
+			addResources(map[string]string{"a.txt": "aaa", "b.txt": "bbbb"})
+		})
+
+		It("returns the resources in the cache", func() {
+			Expect(resourceCache.MatchResources([]repositories.ResourceRecord{
+				{SHA1: sha1Of("aaa"), SizeInBytes: 3, Path: "x/a.txt", Mode: "644"},
+				{SHA1: sha1Of("bbbb"), SizeInBytes: 5, Path: "b.txt"},
+				{SHA1: sha1Of("cc"), SizeInBytes: 2, Path: "c.txt"},
+			})).To(ConsistOf(
+				repositories.ResourceRecord{SHA1: sha1Of("aaa"), SizeInBytes: 3, Path: "x/a.txt", Mode: "644"},
+			))
+		})
+
+		It("returns an empty list when nothing matches", func() {
+			Expect(resourceCache.MatchResources(nil)).To(BeEmpty())
+		})
+
+		When("the cache outgrows its maximum size", func() {
+			BeforeEach(func() {
+				resourceCache.MatchResources([]repositories.ResourceRecord{{SHA1: sha1Of("aaa"), SizeInBytes: 3}})
+				addResources(map[string]string{"d.txt": "dddd"})
+			})
+
+			It("evicts the least recently used resources", func() {
+				Expect(resourceCache.MatchResources([]repositories.ResourceRecord{
+					{SHA1: sha1Of("aaa"), SizeInBytes: 3},
+					{SHA1: sha1Of("bbbb"), SizeInBytes: 4},
+					{SHA1: sha1Of("dddd"), SizeInBytes: 4},
+				})).To(ConsistOf(
+					repositories.ResourceRecord{SHA1: sha1Of("aaa"), SizeInBytes: 3},
+					repositories.ResourceRecord{SHA1: sha1Of("dddd"), SizeInBytes: 4},
+				))
+				Expect(filepath.Join(cacheDir, sha1Of("bbbb"))).NotTo(BeAnExistingFile())
+			})
+		})
+
+		When("the cache is recreated", func() {
+			BeforeEach(func() {
+				var err error
+				resourceCache, err = repositories.NewResourceCache(cacheDir, 10)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("still holds the resources", func() {
+				Expect(resourceCache.MatchResources([]repositories.ResourceRecord{
+					{SHA1: sha1Of("aaa"), SizeInBytes: 3},
+				})).To(HaveLen(1))
+			})
+		})
+
+		When("another replica shares the cache directory", func() {
+			var otherReplicaCache *repositories.ResourceCache
+
+			BeforeEach(func() {
+				var err error
+				otherReplicaCache, err = repositories.NewResourceCache(cacheDir, 10)
+				Expect(err).NotTo(HaveOccurred())
+
+				addResources(map[string]string{"e.txt": "ee"})
+			})
+
+			It("matches the resources cached by the other replica", func() {
+				Expect(otherReplicaCache.MatchResources([]repositories.ResourceRecord{
+					{SHA1: sha1Of("ee"), SizeInBytes: 2},
+					{SHA1: sha1Of("ee"), SizeInBytes: 3},
+				})).To(ConsistOf(
+					repositories.ResourceRecord{SHA1: sha1Of("ee"), SizeInBytes: 2},
+				))
+			})
+
+			It("assembles packages from the resources cached by the other replica", func() {
+				assembled, err := otherReplicaCache.AssemblePackage(nil, 0, []repositories.ResourceRecord{
+					{SHA1: sha1Of("ee"), SizeInBytes: 2, Path: "e.txt"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(assembled.Close()).To(Succeed())
+			})
+
+			When("the other replica outgrows the cache", func() {
+				BeforeEach(func() {
+					packageZip := zipOf(map[string]string{"f.txt": "ffffffff"})
+					Expect(otherReplicaCache.AddResources(bytes.NewReader(packageZip), int64(len(packageZip)))).To(Succeed())
+				})
+
+				It("evicts the least recently used resources of the whole cache", func() {
+					Expect(otherReplicaCache.MatchResources([]repositories.ResourceRecord{
+						{SHA1: sha1Of("aaa"), SizeInBytes: 3},
+						{SHA1: sha1Of("bbbb"), SizeInBytes: 4},
+						{SHA1: sha1Of("ee"), SizeInBytes: 2},
+						{SHA1: sha1Of("ffffffff"), SizeInBytes: 8},
+					})).To(ConsistOf(
+						repositories.ResourceRecord{SHA1: sha1Of("ee"), SizeInBytes: 2},
+						repositories.ResourceRecord{SHA1: sha1Of("ffffffff"), SizeInBytes: 8},
+					))
+				})
+
+				It("does not match the resources evicted by the other replica", func() {
+					Expect(resourceCache.MatchResources([]repositories.ResourceRecord{
+						{SHA1: sha1Of("aaa"), SizeInBytes: 3},
+					})).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("NewResourceCache", func() {
+		var staleTempFile, tempFile string
+
+		BeforeEach(func() {
+			staleTempFile = filepath.Join(cacheDir, "resource-stale")
+			Expect(os.WriteFile(staleTempFile, []byte("stale"), 0o600)).To(Succeed())
+			Expect(os.Chtimes(staleTempFile, time.Now().Add(-2*time.Hour), time.Now().Add(-2*time.Hour))).To(Succeed())
+
+			tempFile = filepath.Join(cacheDir, "resource-in-progress")
+			Expect(os.WriteFile(tempFile, []byte("in-progress"), 0o600)).To(Succeed())
+
+			var err error
+			resourceCache, err = repositories.NewResourceCache(cacheDir, 10)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the temporary files of interrupted writes", func() {
+			Expect(staleTempFile).NotTo(BeAnExistingFile())
+		})
+
+		It("keeps the temporary files that may still be written by another replica", func() {
+			Expect(tempFile).To(BeAnExistingFile())
+		})
+	})
+
+	Describe("AddResources", func() {
+		It("does not cache files bigger than the cache", func() {
+			addResources(map[string]string{"big.txt": "0123456789a"})
+			Expect(resourceCache.MatchResources([]repositories.ResourceRecord{
+				{SHA1: sha1Of("0123456789a"), SizeInBytes: 11},
+			})).To(BeEmpty())
+		})
+
+		It("fails when the package is not a zip", func() {
+			Expect(resourceCache.AddResources(bytes.NewReader([]byte("not-a-zip")), 9)).To(MatchError(ContainSubstring("failed to read package zip")))
+		})
+	})
+
+	Describe("AssemblePackage", func() {
+		var (
+			bits        []byte
+			resources   []repositories.ResourceRecord
+			assembled   io.ReadCloser
+			assembleErr error
+		)
+
+		BeforeEach(func() {
+			addResources(map[string]string{"a.txt": "aaa"})
+
+			bits = zipOf(map[string]string{"b.txt": "bbbb"})
+			resources = []repositories.ResourceRecord{
+				{SHA1: sha1Of("aaa"), SizeInBytes: 3, Path: "dir/a.txt", Mode: "0750"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var bitsReader io.ReaderAt
+			if bits != nil {
+				bitsReader = bytes.NewReader(bits)
+			}
+			assembled, assembleErr = resourceCache.AssemblePackage(bitsReader, int64(len(bits)), resources)
+		})
+
+		AfterEach(func() {
+			if assembled != nil {
+				Expect(assembled.Close()).To(Succeed())
+			}
+		})
+
+		It("assembles the package from the bits and the cached resources", func() {
+			Expect(assembleErr).NotTo(HaveOccurred())
+
+			files := unzip(assembled)
+			Expect(files).To(HaveLen(2))
+			Expect(files).To(HaveKeyWithValue("b.txt", "bbbb"))
+			Expect(files).To(HaveKeyWithValue("dir/a.txt", "aaa"))
+		})
+
+		It("sets the mode of the cached resources", func() {
+			Expect(assembleErr).NotTo(HaveOccurred())
+
+			content, err := io.ReadAll(assembled)
+			Expect(err).NotTo(HaveOccurred())
+			zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			Expect(err).NotTo(HaveOccurred())
+
+			var resourceMode fs.FileMode
+			for _, f := range zipReader.File {
+				if f.Name == "dir/a.txt" {
+					resourceMode = f.Mode()
+				}
+			}
+			Expect(resourceMode).To(Equal(fs.FileMode(0o750)))
+		})
+
+		When("no bits are uploaded", func() {
+			BeforeEach(func() {
+				bits = nil
+			})
+
+			It("assembles the package from the cached resources", func() {
+				Expect(assembleErr).NotTo(HaveOccurred())
+				Expect(unzip(assembled)).To(Equal(map[string]string{"dir/a.txt": "aaa"}))
+			})
+		})
+
+		When("the bits are not a zip", func() {
+			BeforeEach(func() {
+				bits = []byte("not-a-zip")
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(assembleErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
+		When("a resource is not in the cache", func() {
+			BeforeEach(func() {
+				resources = append(resources, repositories.ResourceRecord{SHA1: sha1Of("cc"), SizeInBytes: 2, Path: "c.txt"})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(assembleErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(assembleErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring("could not be found in the resource cache"))
+			})
+		})
+
+		When("a resource has an invalid mode", func() {
+			BeforeEach(func() {
+				resources[0].Mode = "rwx"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(assembleErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+})
+
+func sha1Of(content string) string {
+	sum := sha1.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func zipOf(files map[string]string) []byte {
+	GinkgoHelper()
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zipWriter.Create(name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = w.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(zipWriter.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
+
+func unzip(r io.Reader) map[string]string {
+	GinkgoHelper()
+
+	content, err := io.ReadAll(r)
+	Expect(err).NotTo(HaveOccurred())
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	Expect(err).NotTo(HaveOccurred())
+
+	files := map[string]string{}
+	for _, f := range zipReader.File {
+		rc, err := f.Open()
+		Expect(err).NotTo(HaveOccurred())
+		fileContent, err := io.ReadAll(rc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rc.Close()).To(Succeed())
+		files[f.Name] = string(fileContent)
+	}
+
+	return files
+}
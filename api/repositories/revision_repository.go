@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const RevisionResourceType = "Revision"
+
+type RevisionRecord struct {
+	GUID        string
+	AppGUID     string
+	Version     int
+	DropletGUID string
+	Processes   map[string]string
+	Description string
+	Deployable  bool
+	Deployed    bool
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+func (r RevisionRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type RevisionEnvVarsRecord struct {
+	RevisionGUID         string
+	EnvironmentVariables map[string]string
+}
+
+type ListRevisionsMessage struct {
+	AppGUID  string
+	Versions []string
+	Deployed bool
+}
+
+func (m ListRevisionsMessage) matches(revision RevisionRecord) bool {
+	if m.Deployed && !revision.Deployed {
+		return false
+	}
+
+	return tools.EmptyOrContains(m.Versions, strconv.Itoa(revision.Version))
+}
+
+// RevisionRepo reads the CFRevisions the CFApp controller records whenever
+// the droplet, environment variables or process commands of an app change
+type RevisionRepo struct {
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+}
+
+func NewRevisionRepo(
+	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
+) *RevisionRepo {
+	return &RevisionRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+	}
+}
+
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.AppGUID, AppResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	revisions, err := r.listAppRevisions(ctx, userClient, ns, message.AppGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(revisions, func(revision RevisionRecord) bool {
+		return !message.matches(revision)
+	}), nil
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, guid string) (RevisionRecord, error) {
+	userClient, cfRevision, err := r.getCFRevision(ctx, authInfo, guid)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	revisions, err := r.listAppRevisions(ctx, userClient, cfRevision.Namespace, cfRevision.Spec.AppRef.Name)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	for _, revision := range revisions {
+		if revision.GUID == guid {
+			return revision, nil
+		}
+	}
+
+	return RevisionRecord{}, apierrors.NewNotFoundError(nil, RevisionResourceType)
+}
+
+func (r *RevisionRepo) GetRevisionEnvVars(ctx context.Context, authInfo authorization.Info, guid string) (RevisionEnvVarsRecord, error) {
+	userClient, cfRevision, err := r.getCFRevision(ctx, authInfo, guid)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	envVars := map[string]string{}
+	if cfRevision.Spec.EnvSecretName != "" {
+		secret := &corev1.Secret{}
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: cfRevision.Namespace, Name: cfRevision.Spec.EnvSecretName}, secret)
+		if err != nil {
+			return RevisionEnvVarsRecord{}, fmt.Errorf("failed to get revision env secret: %w", apierrors.FromK8sError(err, RevisionResourceType))
+		}
+
+		for k, v := range secret.Data {
+			envVars[k] = string(v)
+		}
+	}
+
+	return RevisionEnvVarsRecord{
+		RevisionGUID:         guid,
+		EnvironmentVariables: envVars,
+	}, nil
+}
+
+func (r *RevisionRepo) getCFRevision(ctx context.Context, authInfo authorization.Info, guid string) (client.Client, *korifiv1alpha1.CFRevision, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, RevisionResourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRevision := &korifiv1alpha1.CFRevision{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfRevision)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get revision: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	return userClient, cfRevision, nil
+}
+
+func (r *RevisionRepo) listAppRevisions(ctx context.Context, userClient client.Client, ns, appGUID string) ([]RevisionRecord, error) {
+	cfApp := &korifiv1alpha1.CFApp{}
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: appGUID}, cfApp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	revisionList := &korifiv1alpha1.CFRevisionList{}
+	err = userClient.List(ctx, revisionList, client.InNamespace(ns), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: appGUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	buildList := &korifiv1alpha1.CFBuildList{}
+	err = userClient.List(ctx, buildList, client.InNamespace(ns), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: appGUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	droplets := map[string]bool{}
+	for _, build := range buildList.Items {
+		if build.Status.Droplet != nil {
+			droplets[build.Name] = true
+		}
+	}
+
+	latestVersion := 0
+	for _, revision := range revisionList.Items {
+		latestVersion = max(latestVersion, revision.Spec.Version)
+	}
+
+	records := []RevisionRecord{}
+	for _, revision := range revisionList.Items {
+		records = append(records, RevisionRecord{
+			GUID:        revision.Name,
+			AppGUID:     revision.Spec.AppRef.Name,
+			Version:     revision.Spec.Version,
+			DropletGUID: revision.Spec.DropletRef.Name,
+			Processes:   revision.Spec.ProcessCommands,
+			Description: revision.Spec.Description,
+			Deployable:  droplets[revision.Spec.DropletRef.Name],
+			Deployed:    revision.Spec.Version == latestVersion && cfApp.Spec.DesiredState == korifiv1alpha1.StartedState,
+			Labels:      revision.Labels,
+			Annotations: revision.Annotations,
+			CreatedAt:   revision.CreationTimestamp.Time,
+			UpdatedAt:   getLastUpdatedTime(&revision),
+		})
+	}
+
+	slices.SortFunc(records, func(r1, r2 RevisionRecord) int {
+		return r1.Version - r2.Version
+	})
+
+	return records, nil
+}
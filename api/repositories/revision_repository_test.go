@@ -0,0 +1,254 @@
+package repositories_test
+
+import (
+	"context"
+	"strconv"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("RevisionRepo", func() {
+	var (
+		revisionRepo *repositories.RevisionRepo
+		cfOrg        *korifiv1alpha1.CFOrg
+		cfSpace      *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+		dropletGUID  string
+		revision1    *korifiv1alpha1.CFRevision
+		revision2    *korifiv1alpha1.CFRevision
+	)
+
+	BeforeEach(func() {
+		revisionRepo = repositories.NewRevisionRepo(userClientFactory, namespaceRetriever)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+
+		dropletGUID = uuid.NewString()
+		build := createBuild(ctx, k8sClient, cfSpace.Name, dropletGUID, uuid.NewString(), cfApp.Name)
+		Expect(k8s.Patch(ctx, k8sClient, build, func() {
+			build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+				Registry: korifiv1alpha1.Registry{Image: "image"},
+			}
+		})).To(Succeed())
+
+		revision1 = createRevision(ctx, cfApp, 1, "no-longer-there", map[string]string{"FOO": "one"})
+		revision2 = createRevision(ctx, cfApp, 2, dropletGUID, map[string]string{"FOO": "two"})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			message   repositories.ListRevisionsMessage
+			revisions []repositories.RevisionRecord
+			listErr   error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListRevisionsMessage{AppGUID: cfApp.Name}
+		})
+
+		JustBeforeEach(func() {
+			revisions, listErr = revisionRepo.ListRevisions(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the app revisions sorted by version", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(revisions).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":        Equal(revision1.Name),
+						"AppGUID":     Equal(cfApp.Name),
+						"Version":     Equal(1),
+						"DropletGUID": Equal("no-longer-there"),
+						"Processes":   Equal(map[string]string{"web": "custom command"}),
+						"Description": Equal("revision 1"),
+						"Deployable":  BeFalse(),
+						"Deployed":    BeFalse(),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":       Equal(revision2.Name),
+						"Version":    Equal(2),
+						"Deployable": BeTrue(),
+						"Deployed":   BeFalse(),
+					}),
+				))
+			})
+
+			When("the app is started", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+					})).To(Succeed())
+				})
+
+				It("marks the latest revision as deployed", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisions[0].Deployed).To(BeFalse())
+					Expect(revisions[1].Deployed).To(BeTrue())
+				})
+
+				When("only deployed revisions are requested", func() {
+					BeforeEach(func() {
+						message.Deployed = true
+					})
+
+					It("returns the deployed revision", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(revisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"GUID": Equal(revision2.Name),
+						})))
+					})
+				})
+			})
+
+			When("filtering by version", func() {
+				BeforeEach(func() {
+					message.Versions = []string{"1"}
+				})
+
+				It("returns the matching revisions", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(revisions).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(revision1.Name),
+					})))
+				})
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				message.AppGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			revisionGUID string
+			revision     repositories.RevisionRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = revision2.Name
+		})
+
+		JustBeforeEach(func() {
+			revision, getErr = revisionRepo.GetRevision(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(revision.GUID).To(Equal(revision2.Name))
+				Expect(revision.Version).To(Equal(2))
+				Expect(revision.DropletGUID).To(Equal(dropletGUID))
+			})
+		})
+
+		When("the revision does not exist", func() {
+			BeforeEach(func() {
+				revisionGUID = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("GetRevisionEnvVars", func() {
+		var (
+			envVars repositories.RevisionEnvVarsRecord
+			getErr  error
+		)
+
+		JustBeforeEach(func() {
+			envVars, getErr = revisionRepo.GetRevisionEnvVars(ctx, authInfo, revision1.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revision environment variables", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(envVars).To(Equal(repositories.RevisionEnvVarsRecord{
+					RevisionGUID:         revision1.Name,
+					EnvironmentVariables: map[string]string{"FOO": "one"},
+				}))
+			})
+		})
+	})
+})
+
+func createRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, version int, dropletGUID string, env map[string]string) *korifiv1alpha1.CFRevision {
+	GinkgoHelper()
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      uuid.NewString(),
+		},
+		StringData: env,
+	}
+	Expect(k8sClient.Create(ctx, envSecret)).To(Succeed())
+
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:          corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:         version,
+			DropletRef:      corev1.LocalObjectReference{Name: dropletGUID},
+			EnvSecretName:   envSecret.Name,
+			ProcessCommands: map[string]string{"web": "custom command"},
+			Description:     "revision " + strconv.Itoa(version),
+		},
+	}
+	Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+	return revision
+}
@@ -3,12 +3,14 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"slices"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -39,6 +41,7 @@ func NewRouteRepo(namespaceRetriever NamespaceRetriever, userClientFactory autho
 type DestinationRecord struct {
 	GUID        string
 	AppGUID     string
+	SpaceGUID   string
 	ProcessType string
 	Port        *int32
 	Protocol    *string
@@ -52,7 +55,9 @@ type RouteRecord struct {
 	Host         string
 	Path         string
 	Protocol     string
+	Port         *int32
 	Destinations []DestinationRecord
+	SharedSpaces []string
 	Labels       map[string]string
 	Annotations  map[string]string
 	CreatedAt    time.Time
@@ -68,7 +73,10 @@ func (r RouteRecord) Relationships() map[string]string {
 }
 
 type DesiredDestination struct {
-	AppGUID     string
+	AppGUID string
+	// SpaceGUID is the space of the app, which must be either the space of
+	// the route or one of its shared spaces
+	SpaceGUID   string
 	ProcessType string
 	Port        *int32
 	Protocol    *string
@@ -92,6 +100,24 @@ func (m *RemoveDestinationMessage) matches(dest korifiv1alpha1.Destination) bool
 	return dest.GUID == m.GUID
 }
 
+type ShareRouteMessage struct {
+	RouteGUID        string
+	SpaceGUID        string
+	SharedSpaceGUIDs []string
+}
+
+type UnshareRouteMessage struct {
+	RouteGUID       string
+	SpaceGUID       string
+	SharedSpaceGUID string
+}
+
+type TransferRouteMessage struct {
+	RouteGUID       string
+	SpaceGUID       string
+	TargetSpaceGUID string
+}
+
 type PatchRouteMetadataMessage struct {
 	MetadataPatch
 	RouteGUID string
@@ -104,16 +130,26 @@ type ListRoutesMessage struct {
 	DomainGUIDs []string
 	Hosts       []string
 	Paths       []string
+	Ports       []int32
 }
 
 func (m *ListRoutesMessage) matches(r korifiv1alpha1.CFRoute) bool {
 	return tools.EmptyOrContains(m.DomainGUIDs, r.Spec.DomainRef.Name) &&
 		tools.EmptyOrContains(m.Hosts, r.Spec.Host) &&
 		tools.EmptyOrContains(m.Paths, r.Spec.Path) &&
+		m.matchesPort(r) &&
 		tools.EmptyOrContains(m.SpaceGUIDs, r.Namespace) &&
 		m.matchesApp(r)
 }
 
+func (m *ListRoutesMessage) matchesPort(r korifiv1alpha1.CFRoute) bool {
+	if len(m.Ports) == 0 {
+		return true
+	}
+
+	return r.Spec.Port != nil && slices.Contains(m.Ports, *r.Spec.Port)
+}
+
 func (m *ListRoutesMessage) matchesApp(r korifiv1alpha1.CFRoute) bool {
 	if len(m.AppGUIDs) == 0 {
 		return true
@@ -127,12 +163,18 @@ func (m *ListRoutesMessage) matchesApp(r korifiv1alpha1.CFRoute) bool {
 type CreateRouteMessage struct {
 	Host            string
 	Path            string
+	Protocol        string
+	Port            *int32
 	SpaceGUID       string
 	DomainGUID      string
 	DomainName      string
 	DomainNamespace string
 	Labels          map[string]string
 	Annotations     map[string]string
+
+	// AvailablePorts are the ports a TCP route without a port can be
+	// assigned. One of them is picked at random.
+	AvailablePorts []int32
 }
 
 type DeleteRouteMessage struct {
@@ -141,6 +183,11 @@ type DeleteRouteMessage struct {
 }
 
 func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
+	protocol := korifiv1alpha1.ProtocolHTTP
+	if m.Protocol != "" {
+		protocol = korifiv1alpha1.Protocol(m.Protocol)
+	}
+
 	return korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        uuid.NewString(),
@@ -151,7 +198,8 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 		Spec: korifiv1alpha1.CFRouteSpec{
 			Host:     m.Host,
 			Path:     m.Path,
-			Protocol: "http",
+			Protocol: protocol,
+			Port:     m.Port,
 			DomainRef: v1.ObjectReference{
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
@@ -205,8 +253,10 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 		},
 		Host:         cfRoute.Spec.Host,
 		Path:         cfRoute.Spec.Path,
-		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
+		Protocol:     routeProtocol(cfRoute),
+		Port:         cfRoute.Spec.Port,
 		Destinations: cfRouteDestinationsToDestinationRecords(cfRoute),
+		SharedSpaces: cfRoute.Spec.SharedSpaces,
 		CreatedAt:    cfRoute.CreationTimestamp.Time,
 		UpdatedAt:    getLastUpdatedTime(&cfRoute),
 		DeletedAt:    golangTime(cfRoute.DeletionTimestamp),
@@ -215,11 +265,20 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 	}
 }
 
+func routeProtocol(cfRoute korifiv1alpha1.CFRoute) string {
+	if cfRoute.Spec.Protocol == "" {
+		return string(korifiv1alpha1.ProtocolHTTP) // TODO: Create a mutating webhook to set this default on the CFRoute
+	}
+
+	return string(cfRoute.Spec.Protocol)
+}
+
 func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []DestinationRecord {
 	return slices.Collect(it.Map(slices.Values(cfRoute.Spec.Destinations), func(specDestination korifiv1alpha1.Destination) DestinationRecord {
 		record := DestinationRecord{
 			GUID:        specDestination.GUID,
 			AppGUID:     specDestination.AppRef.Name,
+			SpaceGUID:   specDestination.AppNamespace(cfRoute.Namespace),
 			ProcessType: specDestination.ProcessType,
 			Port:        specDestination.Port,
 			Protocol:    specDestination.Protocol,
@@ -237,10 +296,11 @@ func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []D
 	}))
 }
 
-func (r *RouteRepo) ListRoutesForApp(ctx context.Context, authInfo authorization.Info, appGUID string, spaceGUID string) ([]RouteRecord, error) {
+// ListRoutesForApp returns the routes the app is a destination of, including
+// routes in other spaces that are shared with the space of the app
+func (r *RouteRepo) ListRoutesForApp(ctx context.Context, authInfo authorization.Info, appGUID string) ([]RouteRecord, error) {
 	return r.ListRoutes(ctx, authInfo, ListRoutesMessage{
-		AppGUIDs:   []string{appGUID},
-		SpaceGUIDs: []string{spaceGUID},
+		AppGUIDs: []string{appGUID},
 	})
 }
 
@@ -255,12 +315,16 @@ func findEffectiveDestination(destGUID string, effectiveDestinations []korifiv1a
 }
 
 func (r *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info, message CreateRouteMessage) (RouteRecord, error) {
-	cfRoute := message.toCFRoute()
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	if message.Port == nil && len(message.AvailablePorts) > 0 {
+		return r.createRouteOnRandomPort(ctx, userClient, message)
+	}
+
+	cfRoute := message.toCFRoute()
 	err = userClient.Create(ctx, &cfRoute)
 	if err != nil {
 		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
@@ -269,6 +333,34 @@ func (r *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info
 	return cfRouteToRouteRecord(cfRoute), nil
 }
 
+// createRouteOnRandomPort reserves a port for the route by trying the
+// available ports in random order until the webhook accepts one that is not
+// taken by another route on the domain
+func (r *RouteRepo) createRouteOnRandomPort(ctx context.Context, userClient client.WithWatch, message CreateRouteMessage) (RouteRecord, error) {
+	ports := slices.Clone(message.AvailablePorts)
+	rand.Shuffle(len(ports), func(i, j int) {
+		ports[i], ports[j] = ports[j], ports[i]
+	})
+
+	for _, port := range ports {
+		message.Port = tools.PtrTo(port)
+		cfRoute := message.toCFRoute()
+
+		err := userClient.Create(ctx, &cfRoute)
+		if err == nil {
+			return cfRouteToRouteRecord(cfRoute), nil
+		}
+
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok && validationError.Type == validation.DuplicateNameErrorType {
+			continue
+		}
+
+		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
+	}
+
+	return RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "There are no more ports available for the domain. Try a different domain.")
+}
+
 func (r *RouteRepo) DeleteRoute(ctx context.Context, authInfo authorization.Info, message DeleteRouteMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -310,7 +402,7 @@ func (r *RouteRepo) AddDestinationsToRoute(ctx context.Context, authInfo authori
 		},
 	}
 	err = k8s.PatchResource(ctx, userClient, cfRoute, func() {
-		cfRoute.Spec.Destinations = mergeDestinations(message.ExistingDestinations, message.NewDestinations)
+		cfRoute.Spec.Destinations = mergeDestinations(message.SpaceGUID, message.ExistingDestinations, message.NewDestinations)
 	})
 	if err != nil {
 		return RouteRecord{}, fmt.Errorf("failed to add destination to route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
@@ -351,10 +443,14 @@ func (r *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo aut
 	return cfRouteToRouteRecord(*cfRoute), err
 }
 
-func mergeDestinations(existingDestinations []DestinationRecord, desiredDestinations []DesiredDestination) []korifiv1alpha1.Destination {
+func mergeDestinations(routeSpaceGUID string, existingDestinations []DestinationRecord, desiredDestinations []DesiredDestination) []korifiv1alpha1.Destination {
 	destinations := destinationRecordsToCFDestinations(existingDestinations)
 
 	for _, desired := range desiredDestinations {
+		if desired.SpaceGUID == "" {
+			desired.SpaceGUID = routeSpaceGUID
+		}
+
 		if contains(destinations, desired) {
 			continue
 		}
@@ -369,8 +465,9 @@ func destinationMessageToDestination(m DesiredDestination) korifiv1alpha1.Destin
 	return korifiv1alpha1.Destination{
 		GUID: uuid.NewString(),
 		Port: m.Port,
-		AppRef: v1.LocalObjectReference{
-			Name: m.AppGUID,
+		AppRef: v1.ObjectReference{
+			Name:      m.AppGUID,
+			Namespace: m.SpaceGUID,
 		},
 		ProcessType: m.ProcessType,
 		Protocol:    m.Protocol,
@@ -423,8 +520,9 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 		return korifiv1alpha1.Destination{
 			GUID: destinationRecord.GUID,
 			Port: destinationRecord.Port,
-			AppRef: v1.LocalObjectReference{
-				Name: destinationRecord.AppGUID,
+			AppRef: v1.ObjectReference{
+				Name:      destinationRecord.AppGUID,
+				Namespace: destinationRecord.SpaceGUID,
 			},
 			ProcessType: destinationRecord.ProcessType,
 			Protocol:    destinationRecord.Protocol,
@@ -432,6 +530,117 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 	}))
 }
 
+// ShareRoute shares the route with the spaces, so that apps in these spaces
+// can be destinations of the route
+func (r *RouteRepo) ShareRoute(ctx context.Context, authInfo authorization.Info, message ShareRouteMessage) (RouteRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.RouteGUID}, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfRoute, func() {
+		for _, sharedSpaceGUID := range message.SharedSpaceGUIDs {
+			if !slices.Contains(cfRoute.Spec.SharedSpaces, sharedSpaceGUID) {
+				cfRoute.Spec.SharedSpaces = append(cfRoute.Spec.SharedSpaces, sharedSpaceGUID)
+			}
+		}
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to share route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
+// UnshareRoute stops sharing the route with the space. The apps in the space
+// stop being destinations of the route.
+func (r *RouteRepo) UnshareRoute(ctx context.Context, authInfo authorization.Info, message UnshareRouteMessage) (RouteRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.RouteGUID}, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfRoute, func() {
+		cfRoute.Spec.SharedSpaces = slices.DeleteFunc(cfRoute.Spec.SharedSpaces, func(sharedSpaceGUID string) bool {
+			return sharedSpaceGUID == message.SharedSpaceGUID
+		})
+		cfRoute.Spec.Destinations = slices.DeleteFunc(cfRoute.Spec.Destinations, func(destination korifiv1alpha1.Destination) bool {
+			return destination.AppNamespace(cfRoute.Namespace) == message.SharedSpaceGUID
+		})
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to unshare route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
+// TransferRoute moves the route to the target space. As objects cannot be
+// moved between namespaces, the route is recreated with the same GUID in the
+// target namespace. The original space keeps access to the route as a shared
+// space, so the route destinations are preserved.
+func (r *RouteRepo) TransferRoute(ctx context.Context, authInfo authorization.Info, message TransferRouteMessage) (RouteRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.RouteGUID}, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	if message.TargetSpaceGUID == cfRoute.Namespace {
+		return cfRouteToRouteRecord(*cfRoute), nil
+	}
+
+	transferredRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfRoute.Name,
+			Namespace:   message.TargetSpaceGUID,
+			Labels:      cfRoute.Labels,
+			Annotations: cfRoute.Annotations,
+		},
+		Spec: *cfRoute.Spec.DeepCopy(),
+	}
+	for i, destination := range transferredRoute.Spec.Destinations {
+		transferredRoute.Spec.Destinations[i].AppRef.Namespace = destination.AppNamespace(cfRoute.Namespace)
+	}
+	transferredRoute.Spec.SharedSpaces = slices.DeleteFunc(
+		append(transferredRoute.Spec.SharedSpaces, cfRoute.Namespace),
+		func(sharedSpaceGUID string) bool {
+			return sharedSpaceGUID == message.TargetSpaceGUID
+		},
+	)
+
+	// the route must be deleted first, as it holds the unique name of the
+	// route until then
+	err = userClient.Delete(ctx, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to delete route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = userClient.Create(ctx, transferredRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to transfer route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*transferredRoute), nil
+}
+
 func (r *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorization.Info, message PatchRouteMetadataMessage) (RouteRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -90,7 +90,7 @@ var _ = Describe("RouteRepository", func() {
 						{
 							GUID: "destination-guid",
 							Port: tools.PtrTo[int32](8080),
-							AppRef: corev1.LocalObjectReference{
+							AppRef: corev1.ObjectReference{
 								Name: "some-app-guid",
 							},
 							ProcessType: "web",
@@ -153,7 +153,7 @@ var _ = Describe("RouteRepository", func() {
 					Expect(k8s.PatchResource(ctx, k8sClient, cfRoute, func() {
 						cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
 							GUID: "destination-guid",
-							AppRef: corev1.LocalObjectReference{
+							AppRef: corev1.ObjectReference{
 								Name: "some-app-guid",
 							},
 							ProcessType: "web",
@@ -175,7 +175,7 @@ var _ = Describe("RouteRepository", func() {
 							cfRoute.Status = korifiv1alpha1.CFRouteStatus{
 								Destinations: []korifiv1alpha1.Destination{{
 									GUID: "destination-guid",
-									AppRef: corev1.LocalObjectReference{
+									AppRef: corev1.ObjectReference{
 										Name: "some-app-guid",
 									},
 									ProcessType: "web",
@@ -237,7 +237,7 @@ var _ = Describe("RouteRepository", func() {
 					},
 					Destinations: []korifiv1alpha1.Destination{{
 						Protocol: tools.PtrTo("http1"),
-						AppRef: corev1.LocalObjectReference{
+						AppRef: corev1.ObjectReference{
 							Name: uuid.NewString(),
 						},
 					}},
@@ -464,7 +464,7 @@ var _ = Describe("RouteRepository", func() {
 						{
 							GUID: "destination-guid",
 							Port: tools.PtrTo[int32](8080),
-							AppRef: corev1.LocalObjectReference{
+							AppRef: corev1.ObjectReference{
 								Name: appGUID,
 							},
 							ProcessType: "web",
@@ -480,7 +480,7 @@ var _ = Describe("RouteRepository", func() {
 
 		JustBeforeEach(func() {
 			var err error
-			routeRecords, err = routeRepo.ListRoutesForApp(ctx, authInfo, queryAppGUID, space.Name)
+			routeRecords, err = routeRepo.ListRoutesForApp(ctx, authInfo, queryAppGUID)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -558,12 +558,18 @@ var _ = Describe("RouteRepository", func() {
 			routeHost          string
 			routePath          string
 			routeNamespace     string
+			routeProtocol      string
+			routePort          *int32
+			availablePorts     []int32
 		)
 
 		BeforeEach(func() {
 			routeNamespace = space.Name
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
+			routeProtocol = ""
+			routePort = nil
+			availablePorts = nil
 			createdRouteRecord = RouteRecord{}
 			createdRouteErr = nil
 		})
@@ -572,9 +578,12 @@ var _ = Describe("RouteRepository", func() {
 			createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(ctx, authInfo, CreateRouteMessage{
 				Host:            routeHost,
 				Path:            routePath,
+				Protocol:        routeProtocol,
+				Port:            routePort,
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
+				AvailablePorts:  availablePorts,
 			})
 		})
 
@@ -620,6 +629,38 @@ var _ = Describe("RouteRepository", func() {
 					Expect(createdRouteErr).To(MatchError("an empty namespace may not be set during creation"))
 				})
 			})
+
+			When("the route is a tcp route", func() {
+				BeforeEach(func() {
+					routeHost = ""
+					routePath = ""
+					routeProtocol = "tcp"
+					routePort = tools.PtrTo[int32](1024)
+				})
+
+				It("creates a tcp CFRoute on the port", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+
+					Expect(createdCFRoute.Spec.Protocol).To(Equal(korifiv1alpha1.ProtocolTCP))
+					Expect(createdCFRoute.Spec.Port).To(PointTo(BeEquivalentTo(1024)))
+					Expect(createdRouteRecord.Protocol).To(Equal("tcp"))
+					Expect(createdRouteRecord.Port).To(PointTo(BeEquivalentTo(1024)))
+				})
+
+				When("no port is requested", func() {
+					BeforeEach(func() {
+						routePort = nil
+						availablePorts = []int32{2000, 2001}
+					})
+
+					It("assigns one of the available ports", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+						Expect(createdRouteRecord.Port).To(PointTo(BeElementOf(int32(2000), int32(2001))))
+					})
+				})
+			})
 		})
 	})
 
@@ -653,7 +694,7 @@ var _ = Describe("RouteRepository", func() {
 						{
 							GUID: "destination-guid",
 							Port: tools.PtrTo[int32](8080),
-							AppRef: corev1.LocalObjectReference{
+							AppRef: corev1.ObjectReference{
 								Name: "some-app-guid",
 							},
 							ProcessType: "web",
@@ -948,7 +989,7 @@ var _ = Describe("RouteRepository", func() {
 					routeDestination = korifiv1alpha1.Destination{
 						GUID: prefixedGUID("existing-route-guid"),
 						Port: tools.PtrTo[int32](8000),
-						AppRef: corev1.LocalObjectReference{
+						AppRef: corev1.ObjectReference{
 							Name: prefixedGUID("existing-route-app"),
 						},
 						ProcessType: "web",
@@ -1059,7 +1100,7 @@ var _ = Describe("RouteRepository", func() {
 						Expect(cfRoute.Spec.Destinations).To(ConsistOf(
 							korifiv1alpha1.Destination{
 								GUID: routeDestination.GUID,
-								AppRef: corev1.LocalObjectReference{
+								AppRef: corev1.ObjectReference{
 									Name: routeDestination.AppRef.Name,
 								},
 								ProcessType: routeDestination.ProcessType,
@@ -1123,7 +1164,7 @@ var _ = Describe("RouteRepository", func() {
 					Destinations: []korifiv1alpha1.Destination{{
 						GUID: destinationGUID,
 						Port: tools.PtrTo[int32](8000),
-						AppRef: corev1.LocalObjectReference{
+						AppRef: corev1.ObjectReference{
 							Name: uuid.NewString(),
 						},
 						ProcessType: "web",
@@ -1180,6 +1221,223 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("route sharing", func() {
+		var (
+			sharedSpace *korifiv1alpha1.CFSpace
+			otherSpace  *korifiv1alpha1.CFSpace
+			appGUID     string
+		)
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("shared-space"))
+			otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+			appGUID = uuid.NewString()
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      route1GUID,
+					Namespace: space.Name,
+					Labels: map[string]string{
+						korifiv1alpha1.SpaceGUIDKey: space.Name,
+						"foo":                       "bar",
+					},
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host: "test-route-host",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+					SharedSpaces: []string{sharedSpace.Name},
+					Destinations: []korifiv1alpha1.Destination{
+						{
+							GUID: "own-destination-guid",
+							AppRef: corev1.ObjectReference{
+								Name: appGUID,
+							},
+							ProcessType: "web",
+						},
+						{
+							GUID: "shared-destination-guid",
+							AppRef: corev1.ObjectReference{
+								Name:      uuid.NewString(),
+								Namespace: sharedSpace.Name,
+							},
+							ProcessType: "web",
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		getCFRoute := func(namespace string) *korifiv1alpha1.CFRoute {
+			cfRoute := &korifiv1alpha1.CFRoute{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: route1GUID}, cfRoute)).To(Succeed())
+			return cfRoute
+		}
+
+		Describe("ShareRoute", func() {
+			var (
+				routeRecord RouteRecord
+				shareErr    error
+			)
+
+			JustBeforeEach(func() {
+				routeRecord, shareErr = routeRepo.ShareRoute(ctx, authInfo, ShareRouteMessage{
+					RouteGUID:        route1GUID,
+					SpaceGUID:        space.Name,
+					SharedSpaceGUIDs: []string{otherSpace.Name, sharedSpace.Name},
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in the route space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("adds the spaces that are not shared yet", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(routeRecord.SharedSpaces).To(Equal([]string{sharedSpace.Name, otherSpace.Name}))
+					Expect(getCFRoute(space.Name).Spec.SharedSpaces).To(Equal([]string{sharedSpace.Name, otherSpace.Name}))
+				})
+			})
+		})
+
+		Describe("UnshareRoute", func() {
+			var (
+				routeRecord RouteRecord
+				unshareErr  error
+			)
+
+			JustBeforeEach(func() {
+				routeRecord, unshareErr = routeRepo.UnshareRoute(ctx, authInfo, UnshareRouteMessage{
+					RouteGUID:       route1GUID,
+					SpaceGUID:       space.Name,
+					SharedSpaceGUID: sharedSpace.Name,
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in the route space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("removes the space and the destinations in it", func() {
+					Expect(unshareErr).NotTo(HaveOccurred())
+					Expect(routeRecord.SharedSpaces).To(BeEmpty())
+					Expect(routeRecord.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal("own-destination-guid"),
+						"SpaceGUID": Equal(space.Name),
+					})))
+
+					cfRoute := getCFRoute(space.Name)
+					Expect(cfRoute.Spec.SharedSpaces).To(BeEmpty())
+					Expect(cfRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal("own-destination-guid"),
+					})))
+				})
+			})
+		})
+
+		Describe("TransferRoute", func() {
+			var (
+				targetSpaceGUID string
+				routeRecord     RouteRecord
+				transferErr     error
+			)
+
+			BeforeEach(func() {
+				targetSpaceGUID = otherSpace.Name
+			})
+
+			JustBeforeEach(func() {
+				routeRecord, transferErr = routeRepo.TransferRoute(ctx, authInfo, TransferRouteMessage{
+					RouteGUID:       route1GUID,
+					SpaceGUID:       space.Name,
+					TargetSpaceGUID: targetSpaceGUID,
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in both spaces", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+				})
+
+				It("recreates the route in the target space", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					Expect(routeRecord.GUID).To(Equal(route1GUID))
+					Expect(routeRecord.SpaceGUID).To(Equal(otherSpace.Name))
+
+					cfRoute := getCFRoute(otherSpace.Name)
+					Expect(cfRoute.Labels).To(HaveKeyWithValue("foo", "bar"))
+					Expect(cfRoute.Spec.Host).To(Equal("test-route-host"))
+				})
+
+				It("shares the route with the original space", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					Expect(getCFRoute(otherSpace.Name).Spec.SharedSpaces).To(ConsistOf(sharedSpace.Name, space.Name))
+				})
+
+				It("keeps the destinations in their spaces", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					Expect(getCFRoute(otherSpace.Name).Spec.Destinations).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":   Equal("own-destination-guid"),
+							"AppRef": MatchFields(IgnoreExtras, Fields{"Namespace": Equal(space.Name)}),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":   Equal("shared-destination-guid"),
+							"AppRef": MatchFields(IgnoreExtras, Fields{"Namespace": Equal(sharedSpace.Name)}),
+						}),
+					))
+				})
+
+				It("deletes the route in the original space", func() {
+					Expect(transferErr).NotTo(HaveOccurred())
+					err := k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: route1GUID}, &korifiv1alpha1.CFRoute{})
+					Expect(err).To(MatchError(ContainSubstring("not found")))
+				})
+
+				When("the route is transferred to one of its shared spaces", func() {
+					BeforeEach(func() {
+						targetSpaceGUID = sharedSpace.Name
+					})
+
+					It("is no longer shared with the target space", func() {
+						Expect(transferErr).NotTo(HaveOccurred())
+						Expect(getCFRoute(sharedSpace.Name).Spec.SharedSpaces).To(ConsistOf(space.Name))
+					})
+				})
+
+				When("the target space is the route space", func() {
+					BeforeEach(func() {
+						targetSpaceGUID = space.Name
+					})
+
+					It("leaves the route alone", func() {
+						Expect(transferErr).NotTo(HaveOccurred())
+						Expect(routeRecord.SpaceGUID).To(Equal(space.Name))
+						Expect(getCFRoute(space.Name).Spec.SharedSpaces).To(ConsistOf(sharedSpace.Name))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("PatchRouteMetadata", func() {
 		var (
 			cfRoute                       *korifiv1alpha1.CFRoute
@@ -1415,7 +1673,7 @@ var _ = Describe("RouteRepository", func() {
 						{
 							GUID: "destination-guid",
 							Port: tools.PtrTo[int32](8080),
-							AppRef: corev1.LocalObjectReference{
+							AppRef: corev1.ObjectReference{
 								Name: "some-app-guid",
 							},
 							ProcessType: "web",
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+)
+
+const (
+	RouterGroupResourceType = "Router Group"
+	RouterGroupTypeTCP      = "tcp"
+)
+
+// RouterGroupRepo serves the TCP router groups configured by the operator.
+// Each reservable port of a router group is a TCP listener of the gateway, so
+// router groups cannot be managed through the API. Router groups are
+// identified by their name.
+type RouterGroupRepo struct {
+	routerGroups []config.RouterGroup
+}
+
+func NewRouterGroupRepo(routerGroups []config.RouterGroup) *RouterGroupRepo {
+	return &RouterGroupRepo{
+		routerGroups: routerGroups,
+	}
+}
+
+type RouterGroupRecord struct {
+	GUID            string
+	Name            string
+	Type            string
+	ReservablePorts string
+	PortRanges      []config.PortRange
+}
+
+// ReservesPort returns true if routes on the router group can use the port
+func (r RouterGroupRecord) ReservesPort(port int32) bool {
+	return slices.ContainsFunc(r.PortRanges, func(portRange config.PortRange) bool {
+		return portRange.Start <= port && port <= portRange.End
+	})
+}
+
+// Ports returns all the reservable ports of the router group
+func (r RouterGroupRecord) Ports() []int32 {
+	ports := []int32{}
+	for _, portRange := range r.PortRanges {
+		for port := portRange.Start; port <= portRange.End; port++ {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+type ListRouterGroupsMessage struct {
+	Names []string
+}
+
+func (m ListRouterGroupsMessage) matches(routerGroup config.RouterGroup) bool {
+	return tools.EmptyOrContains(m.Names, routerGroup.Name)
+}
+
+func (r *RouterGroupRepo) ListRouterGroups(ctx context.Context, message ListRouterGroupsMessage) ([]RouterGroupRecord, error) {
+	return slices.Collect(it.Map(
+		itx.FromSlice(r.routerGroups).Filter(message.matches),
+		toRouterGroupRecord,
+	)), nil
+}
+
+func (r *RouterGroupRepo) GetRouterGroup(ctx context.Context, guid string) (RouterGroupRecord, error) {
+	for _, routerGroup := range r.routerGroups {
+		if routerGroup.Name == guid {
+			return toRouterGroupRecord(routerGroup), nil
+		}
+	}
+
+	return RouterGroupRecord{}, apierrors.NewNotFoundError(errors.New("router group not found"), RouterGroupResourceType)
+}
+
+func toRouterGroupRecord(routerGroup config.RouterGroup) RouterGroupRecord {
+	return RouterGroupRecord{
+		GUID:            routerGroup.Name,
+		Name:            routerGroup.Name,
+		Type:            RouterGroupTypeTCP,
+		ReservablePorts: routerGroup.ReservablePorts,
+		PortRanges:      routerGroup.PortRanges,
+	}
+}
@@ -0,0 +1,68 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("RouterGroupRepo", func() {
+	var routerGroupRepo *repositories.RouterGroupRepo
+
+	BeforeEach(func() {
+		routerGroupRepo = repositories.NewRouterGroupRepo([]config.RouterGroup{
+			{
+				Name:            "default-tcp",
+				ReservablePorts: "1024-1026,2000",
+				PortRanges:      []config.PortRange{{Start: 1024, End: 1026}, {Start: 2000, End: 2000}},
+			},
+			{
+				Name:            "other-tcp",
+				ReservablePorts: "3000",
+				PortRanges:      []config.PortRange{{Start: 3000, End: 3000}},
+			},
+		})
+	})
+
+	Describe("ListRouterGroups", func() {
+		It("lists all router groups", func() {
+			routerGroups, err := routerGroupRepo.ListRouterGroups(ctx, repositories.ListRouterGroupsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerGroups).To(HaveLen(2))
+			Expect(routerGroups[0]).To(MatchFields(IgnoreExtras, Fields{
+				"GUID":            Equal("default-tcp"),
+				"Name":            Equal("default-tcp"),
+				"Type":            Equal("tcp"),
+				"ReservablePorts": Equal("1024-1026,2000"),
+			}))
+		})
+
+		It("filters router groups by name", func() {
+			routerGroups, err := routerGroupRepo.ListRouterGroups(ctx, repositories.ListRouterGroupsMessage{Names: []string{"other-tcp"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerGroups).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal("other-tcp"),
+			})))
+		})
+	})
+
+	Describe("GetRouterGroup", func() {
+		It("gets the router group by guid", func() {
+			routerGroup, err := routerGroupRepo.GetRouterGroup(ctx, "default-tcp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerGroup.Name).To(Equal("default-tcp"))
+			Expect(routerGroup.Ports()).To(Equal([]int32{1024, 1025, 1026, 2000}))
+			Expect(routerGroup.ReservesPort(1025)).To(BeTrue())
+			Expect(routerGroup.ReservesPort(1027)).To(BeFalse())
+		})
+
+		It("returns a not found error for unknown router groups", func() {
+			_, err := routerGroupRepo.GetRouterGroup(ctx, "unknown")
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+})
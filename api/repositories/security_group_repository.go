@@ -0,0 +1,361 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecurityGroupResourceType = "Security Group"
+)
+
+type SecurityGroupRecord struct {
+	GUID            string
+	Name            string
+	GloballyEnabled securitygroups.Workloads
+	Rules           []securitygroups.Rule
+	RunningSpaces   []string
+	StagingSpaces   []string
+	CreatedAt       time.Time
+	UpdatedAt       *time.Time
+	DeletedAt       *time.Time
+}
+
+type CreateSecurityGroupMessage struct {
+	Name            string
+	GloballyEnabled securitygroups.Workloads
+	Rules           []securitygroups.Rule
+	RunningSpaces   []string
+	StagingSpaces   []string
+}
+
+type UpdateSecurityGroupMessage struct {
+	GUID            string
+	Name            string
+	GloballyEnabled securitygroups.Workloads
+	// Rules replace the current rules of the security group, unless nil
+	Rules []securitygroups.Rule
+}
+
+type BindSecurityGroupMessage struct {
+	GUID string
+	// Either "running" or "staging"
+	Workload string
+	Spaces   []string
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID string
+	// Either "running" or "staging"
+	Workload string
+	Space    string
+}
+
+type ListSecurityGroupMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+func (m *ListSecurityGroupMessage) matches(g korifiv1alpha1.CFSecurityGroup) bool {
+	return tools.EmptyOrContains(m.GUIDs, g.Name) &&
+		tools.EmptyOrContains(m.Names, g.Spec.DisplayName) &&
+		tools.NilOrEquals(m.GloballyEnabledRunning, g.Spec.GloballyEnabled.Running) &&
+		tools.NilOrEquals(m.GloballyEnabledStaging, g.Spec.GloballyEnabled.Staging) &&
+		boundToAnyOf(g, securitygroups.WorkloadRunning, m.RunningSpaceGUIDs) &&
+		boundToAnyOf(g, securitygroups.WorkloadStaging, m.StagingSpaceGUIDs)
+}
+
+func boundToAnyOf(g korifiv1alpha1.CFSecurityGroup, workload string, spaceGUIDs []string) bool {
+	return len(spaceGUIDs) == 0 || slices.ContainsFunc(spaceGUIDs, func(space string) bool {
+		return g.Spec.Spaces[space].Enabled(workload)
+	})
+}
+
+type SecurityGroupRepo struct {
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+	rootNamespace      string
+}
+
+func NewSecurityGroupRepo(
+	userClientFactory authorization.UserClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	rootNamespace string,
+) *SecurityGroupRepo {
+	return &SecurityGroupRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+		rootNamespace:      rootNamespace,
+	}
+}
+
+func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo authorization.Info, message CreateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.ensureSpacesExist(ctx, userClient, append(slices.Clone(message.RunningSpaces), message.StagingSpaces...)); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	securityGroups := &korifiv1alpha1.CFSecurityGroupList{}
+	if err = userClient.List(ctx, securityGroups, client.InNamespace(r.rootNamespace)); err != nil {
+		return SecurityGroupRecord{}, apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+	if slices.ContainsFunc(securityGroups.Items, func(g korifiv1alpha1.CFSecurityGroup) bool {
+		return g.Spec.DisplayName == message.Name
+	}) {
+		return SecurityGroupRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Security group with name '%s' already exists.", message.Name))
+	}
+
+	spaces := map[string]securitygroups.Workloads{}
+	bindSpaces(spaces, securitygroups.WorkloadRunning, message.RunningSpaces)
+	bindSpaces(spaces, securitygroups.WorkloadStaging, message.StagingSpaces)
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFSecurityGroupSpec{
+			DisplayName:     message.Name,
+			Rules:           message.Rules,
+			GloballyEnabled: message.GloballyEnabled,
+			Spaces:          spaces,
+		},
+	}
+
+	if err = userClient.Create(ctx, cfSecurityGroup); err != nil {
+		return SecurityGroupRecord{}, apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, authInfo, guid)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) getSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFSecurityGroup, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err = userClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup); err != nil {
+		return nil, apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return cfSecurityGroup, nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupMessage) ([]SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroups := &korifiv1alpha1.CFSecurityGroupList{}
+	if err = userClient.List(ctx, cfSecurityGroups, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfSecurityGroups.Items).Filter(message.matches), toSecurityGroupRecord))
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func (r *SecurityGroupRepo) UpdateSecurityGroup(ctx context.Context, authInfo authorization.Info, message UpdateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	return r.patchSecurityGroup(ctx, authInfo, message.GUID, func(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+		cfSecurityGroup.Spec.DisplayName = message.Name
+		cfSecurityGroup.Spec.GloballyEnabled = message.GloballyEnabled
+		if message.Rules != nil {
+			cfSecurityGroup.Spec.Rules = message.Rules
+		}
+	})
+}
+
+// BindSecurityGroup applies the security group to the running or staging
+// workloads of the spaces
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.ensureSpacesExist(ctx, userClient, message.Spaces); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	return r.patchSecurityGroup(ctx, authInfo, message.GUID, func(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+		if cfSecurityGroup.Spec.Spaces == nil {
+			cfSecurityGroup.Spec.Spaces = map[string]securitygroups.Workloads{}
+		}
+		bindSpaces(cfSecurityGroup.Spec.Spaces, message.Workload, message.Spaces)
+	})
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) error {
+	_, err := r.patchSecurityGroup(ctx, authInfo, message.GUID, func(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+		workloads, ok := cfSecurityGroup.Spec.Spaces[message.Space]
+		if !ok {
+			return
+		}
+
+		workloads.Set(message.Workload, false)
+		if workloads == (securitygroups.Workloads{}) {
+			delete(cfSecurityGroup.Spec.Spaces, message.Space)
+			return
+		}
+		cfSecurityGroup.Spec.Spaces[message.Space] = workloads
+	})
+
+	return err
+}
+
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	return apierrors.FromK8sError(
+		userClient.Delete(ctx, &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.rootNamespace,
+				Name:      guid,
+			},
+		}),
+		SecurityGroupResourceType,
+	)
+}
+
+func (r *SecurityGroupRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	securityGroup, err := r.GetSecurityGroup(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	return securityGroup.DeletedAt, nil
+}
+
+func (r *SecurityGroupRepo) patchSecurityGroup(
+	ctx context.Context,
+	authInfo authorization.Info,
+	guid string,
+	patchFunc func(*korifiv1alpha1.CFSecurityGroup),
+) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err = PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		patchFunc(cfSecurityGroup)
+	}); err != nil {
+		return SecurityGroupRecord{}, apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) ensureSpacesExist(ctx context.Context, userClient client.Client, spaceGUIDs []string) error {
+	missing := []string{}
+	for _, spaceGUID := range tools.Uniq(spaceGUIDs) {
+		orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			missing = append(missing, spaceGUID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: orgGUID, Name: spaceGUID}, &korifiv1alpha1.CFSpace{})
+		if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+			missing = append(missing, spaceGUID)
+			continue
+		}
+		if err != nil {
+			return apierrors.FromK8sError(err, SpaceResourceType)
+		}
+	}
+
+	if len(missing) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Space guids %v do not exist, or you do not have access to them.", missing))
+	}
+
+	return nil
+}
+
+func bindSpaces(spaces map[string]securitygroups.Workloads, workload string, spaceGUIDs []string) {
+	for _, spaceGUID := range spaceGUIDs {
+		workloads := spaces[spaceGUID]
+		workloads.Set(workload, true)
+		spaces[spaceGUID] = workloads
+	}
+}
+
+func boundSpaces(spaces map[string]securitygroups.Workloads, workload string) []string {
+	return slices.Sorted(it.Filter(maps.Keys(spaces), func(space string) bool {
+		return spaces[space].Enabled(workload)
+	}))
+}
+
+func toSecurityGroupRecord(cfSecurityGroup korifiv1alpha1.CFSecurityGroup) SecurityGroupRecord {
+	return SecurityGroupRecord{
+		GUID:            cfSecurityGroup.Name,
+		Name:            cfSecurityGroup.Spec.DisplayName,
+		GloballyEnabled: cfSecurityGroup.Spec.GloballyEnabled,
+		Rules:           cfSecurityGroup.Spec.Rules,
+		RunningSpaces:   boundSpaces(cfSecurityGroup.Spec.Spaces, securitygroups.WorkloadRunning),
+		StagingSpaces:   boundSpaces(cfSecurityGroup.Spec.Spaces, securitygroups.WorkloadStaging),
+		CreatedAt:       cfSecurityGroup.CreationTimestamp.Time,
+		UpdatedAt:       getLastUpdatedTime(&cfSecurityGroup),
+		DeletedAt:       golangTime(cfSecurityGroup.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,337 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroupRepo", func() {
+	var (
+		repo  *repositories.SecurityGroupRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewSecurityGroupRepo(userClientFactory, namespaceRetriever, rootNamespace)
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+	})
+
+	createSecurityGroup := func(name string, spaces map[string]securitygroups.Workloads) *korifiv1alpha1.CFSecurityGroup {
+		cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: name,
+				Rules: []securitygroups.Rule{{
+					Protocol:    securitygroups.ProtocolTCP,
+					Destination: "10.0.0.0/8",
+					Ports:       "443",
+				}},
+				Spaces: spaces,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+		return cfSecurityGroup
+	}
+
+	Describe("CreateSecurityGroup", func() {
+		var (
+			message       repositories.CreateSecurityGroupMessage
+			securityGroup repositories.SecurityGroupRecord
+			createErr     error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSecurityGroupMessage{
+				Name: uuid.NewString(),
+				Rules: []securitygroups.Rule{{
+					Protocol:    securitygroups.ProtocolUDP,
+					Destination: "1.1.1.1",
+					Ports:       "53",
+				}},
+				GloballyEnabled: securitygroups.Workloads{Staging: true},
+				RunningSpaces:   []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			securityGroup, createErr = repo.CreateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("creates the security group", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(securityGroup.GUID).NotTo(BeEmpty())
+				Expect(securityGroup.Name).To(Equal(message.Name))
+				Expect(securityGroup.Rules).To(Equal(message.Rules))
+				Expect(securityGroup.GloballyEnabled).To(Equal(securitygroups.Workloads{Staging: true}))
+				Expect(securityGroup.RunningSpaces).To(ConsistOf(space.Name))
+				Expect(securityGroup.StagingSpaces).To(BeEmpty())
+
+				cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: securityGroup.GUID}, cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]securitygroups.Workloads{
+					space.Name: {Running: true},
+				}))
+			})
+
+			When("a security group with the same name exists", func() {
+				BeforeEach(func() {
+					createSecurityGroup(message.Name, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					message.StagingSpaces = []string{"not-a-space"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSecurityGroup", func() {
+		var (
+			cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+			securityGroup   repositories.SecurityGroupRecord
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), map[string]securitygroups.Workloads{
+				space.Name: {Staging: true},
+			})
+		})
+
+		JustBeforeEach(func() {
+			securityGroup, getErr = repo.GetSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("returns a not found error", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user has access to the root namespace", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns the security group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(securityGroup.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(securityGroup.Name).To(Equal(cfSecurityGroup.Spec.DisplayName))
+				Expect(securityGroup.RunningSpaces).To(BeEmpty())
+				Expect(securityGroup.StagingSpaces).To(ConsistOf(space.Name))
+			})
+		})
+	})
+
+	Describe("ListSecurityGroups", func() {
+		var (
+			group1, group2 *korifiv1alpha1.CFSecurityGroup
+			message        repositories.ListSecurityGroupMessage
+			securityGroups []repositories.SecurityGroupRecord
+			listErr        error
+		)
+
+		BeforeEach(func() {
+			group1 = createSecurityGroup(uuid.NewString(), map[string]securitygroups.Workloads{
+				space.Name: {Running: true},
+			})
+			group2 = createSecurityGroup(uuid.NewString(), nil)
+			message = repositories.ListSecurityGroupMessage{}
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			securityGroups, listErr = repo.ListSecurityGroups(ctx, authInfo, message)
+		})
+
+		It("lists the security groups", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(securityGroups).To(ContainElements(
+				HaveField("GUID", group1.Name),
+				HaveField("GUID", group2.Name),
+			))
+		})
+
+		When("filtering by running space guids", func() {
+			BeforeEach(func() {
+				message.RunningSpaceGUIDs = []string{space.Name}
+			})
+
+			It("returns the groups bound to the running workloads of the spaces", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(securityGroups).To(ConsistOf(HaveField("GUID", group1.Name)))
+			})
+		})
+
+		When("filtering by staging space guids", func() {
+			BeforeEach(func() {
+				message.StagingSpaceGUIDs = []string{space.Name}
+			})
+
+			It("returns nothing", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(securityGroups).To(BeEmpty())
+			})
+		})
+
+		When("filtering by globally enabled running", func() {
+			BeforeEach(func() {
+				message.GUIDs = []string{group1.Name, group2.Name}
+				message.GloballyEnabledRunning = tools.PtrTo(false)
+			})
+
+			It("returns the groups that are not globally enabled", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(securityGroups).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("UpdateSecurityGroup", func() {
+		var (
+			cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+			message         repositories.UpdateSecurityGroupMessage
+			securityGroup   repositories.SecurityGroupRecord
+			updateErr       error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), nil)
+			message = repositories.UpdateSecurityGroupMessage{
+				GUID:            cfSecurityGroup.Name,
+				Name:            "new-name",
+				GloballyEnabled: securitygroups.Workloads{Running: true},
+			}
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			securityGroup, updateErr = repo.UpdateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("updates the security group leaving the rules unchanged", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(securityGroup.Name).To(Equal("new-name"))
+			Expect(securityGroup.GloballyEnabled).To(Equal(securitygroups.Workloads{Running: true}))
+			Expect(securityGroup.Rules).To(Equal(cfSecurityGroup.Spec.Rules))
+		})
+
+		When("the rules are specified", func() {
+			BeforeEach(func() {
+				message.Rules = []securitygroups.Rule{{Protocol: securitygroups.ProtocolAll, Destination: "0.0.0.0/0"}}
+			})
+
+			It("replaces the rules", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(securityGroup.Rules).To(Equal(message.Rules))
+			})
+		})
+	})
+
+	Describe("BindSecurityGroup and UnbindSecurityGroup", func() {
+		var cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), nil)
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+		})
+
+		It("binds and unbinds spaces per workload", func() {
+			securityGroup, err := repo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+				GUID:     cfSecurityGroup.Name,
+				Workload: securitygroups.WorkloadRunning,
+				Spaces:   []string{space.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(securityGroup.RunningSpaces).To(ConsistOf(space.Name))
+
+			securityGroup, err = repo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+				GUID:     cfSecurityGroup.Name,
+				Workload: securitygroups.WorkloadStaging,
+				Spaces:   []string{space.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(securityGroup.StagingSpaces).To(ConsistOf(space.Name))
+
+			Expect(repo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+				GUID:     cfSecurityGroup.Name,
+				Workload: securitygroups.WorkloadRunning,
+				Space:    space.Name,
+			})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]securitygroups.Workloads{
+				space.Name: {Staging: true},
+			}))
+
+			Expect(repo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+				GUID:     cfSecurityGroup.Name,
+				Workload: securitygroups.WorkloadStaging,
+				Space:    space.Name,
+			})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			Expect(cfSecurityGroup.Spec.Spaces).To(BeEmpty())
+		})
+
+		When("the space does not exist", func() {
+			It("returns an unprocessable entity error", func() {
+				_, err := repo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+					GUID:     cfSecurityGroup.Name,
+					Workload: securitygroups.WorkloadRunning,
+					Spaces:   []string{"not-a-space"},
+				})
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("DeleteSecurityGroup", func() {
+		var cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), nil)
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		It("deletes the security group", func() {
+			Expect(repo.DeleteSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)).To(Succeed())
+
+			_, err := repo.GetSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+})
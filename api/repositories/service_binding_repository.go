@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/model"
@@ -31,23 +33,32 @@ const (
 	LabelServiceBindingProvisionedService = "servicebinding.io/provisioned-service"
 	ServiceBindingResourceType            = "Service Binding"
 	ServiceBindingTypeApp                 = "app"
+	ServiceBindingTypeKey                 = "key"
 )
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get
+
 type ServiceBindingRepo struct {
 	userClientFactory       authorization.UserClientFactory
 	namespaceRetriever      NamespaceRetriever
 	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding]
+	brokerClientFactory     osbapi.BrokerClientFactory
+	serviceAssets           *osbapi.Assets
 }
 
 func NewServiceBindingRepo(
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserClientFactory,
 	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceBinding],
+	brokerClientFactory osbapi.BrokerClientFactory,
+	serviceAssets *osbapi.Assets,
 ) *ServiceBindingRepo {
 	return &ServiceBindingRepo{
 		userClientFactory:       userClientFactory,
 		namespaceRetriever:      namespaceRetriever,
 		bindingConditionAwaiter: bindingConditionAwaiter,
+		brokerClientFactory:     brokerClientFactory,
+		serviceAssets:           serviceAssets,
 	}
 }
 
@@ -68,10 +79,19 @@ type ServiceBindingRecord struct {
 }
 
 func (r ServiceBindingRecord) Relationships() map[string]string {
-	return map[string]string{
-		"app":              r.AppGUID,
+	relationships := map[string]string{
 		"service_instance": r.ServiceInstanceGUID,
 	}
+
+	if r.Type != ServiceBindingTypeKey {
+		relationships["app"] = r.AppGUID
+	}
+
+	return relationships
+}
+
+type ServiceBindingDetailsRecord struct {
+	Credentials map[string]any
 }
 
 type ServiceBindingLastOperation struct {
@@ -84,9 +104,13 @@ type ServiceBindingLastOperation struct {
 
 type CreateServiceBindingMessage struct {
 	Name                *string
+	Type                string
 	ServiceInstanceGUID string
 	AppGUID             string
 	SpaceGUID           string
+	// ServiceInstanceSpaceGUID is the space of the service instance when it
+	// is shared with the space of the binding
+	ServiceInstanceSpaceGUID string
 }
 
 type DeleteServiceBindingMessage struct {
@@ -98,15 +122,35 @@ type ListServiceBindingsMessage struct {
 	ServiceInstanceGUIDs []string
 	LabelSelector        string
 	PlanGUIDs            []string
+	Type                 string
 }
 
 func (m *ListServiceBindingsMessage) matches(serviceBinding korifiv1alpha1.CFServiceBinding) bool {
-	return tools.EmptyOrContains(m.ServiceInstanceGUIDs, serviceBinding.Spec.Service.Name) &&
+	return !serviceBinding.IsRoute() &&
+		tools.EmptyOrContains(m.ServiceInstanceGUIDs, serviceBinding.Spec.Service.Name) &&
 		tools.EmptyOrContains(m.AppGUIDs, serviceBinding.Spec.AppRef.Name) &&
-		tools.EmptyOrContains(m.PlanGUIDs, serviceBinding.Labels[korifiv1alpha1.PlanGUIDLabelKey])
+		tools.EmptyOrContains(m.PlanGUIDs, serviceBinding.Labels[korifiv1alpha1.PlanGUIDLabelKey]) &&
+		(m.Type == "" || m.Type == bindingType(serviceBinding))
+}
+
+func (m CreateServiceBindingMessage) isKey() bool {
+	return m.Type == ServiceBindingTypeKey
+}
+
+func (m CreateServiceBindingMessage) serviceInstanceNamespace() string {
+	if m.ServiceInstanceSpaceGUID == m.SpaceGUID {
+		return ""
+	}
+
+	return m.ServiceInstanceSpaceGUID
 }
 
 func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServiceBinding {
+	bindingType := korifiv1alpha1.CFServiceBindingTypeApp
+	if m.isKey() {
+		bindingType = korifiv1alpha1.CFServiceBindingTypeKey
+	}
+
 	return &korifiv1alpha1.CFServiceBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      uuid.NewString(),
@@ -115,10 +159,12 @@ func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServ
 		},
 		Spec: korifiv1alpha1.CFServiceBindingSpec{
 			DisplayName: m.Name,
+			Type:        bindingType,
 			Service: corev1.ObjectReference{
 				Kind:       "CFServiceInstance",
 				APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
 				Name:       m.ServiceInstanceGUID,
+				Namespace:  m.serviceInstanceNamespace(),
 			},
 			AppRef: corev1.LocalObjectReference{Name: m.AppGUID},
 		},
@@ -138,16 +184,18 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 
 	cfServiceBinding := message.toCFServiceBinding()
 
-	cfApp := new(korifiv1alpha1.CFApp)
-	err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
-	if err != nil {
-		return ServiceBindingRecord{},
-			apierrors.AsUnprocessableEntity(
-				apierrors.FromK8sError(err, ServiceBindingResourceType),
-				"Unable to use app. Ensure that the app exists and you have access to it.",
-				apierrors.ForbiddenError{},
-				apierrors.NotFoundError{},
-			)
+	if !message.isKey() {
+		cfApp := new(korifiv1alpha1.CFApp)
+		err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
+		if err != nil {
+			return ServiceBindingRecord{},
+				apierrors.AsUnprocessableEntity(
+					apierrors.FromK8sError(err, ServiceBindingResourceType),
+					"Unable to use app. Ensure that the app exists and you have access to it.",
+					apierrors.ForbiddenError{},
+					apierrors.NotFoundError{},
+				)
+		}
 	}
 
 	err = userClient.Create(ctx, cfServiceBinding)
@@ -162,7 +210,7 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 	}
 
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
-	err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceInstanceNamespace()}, cfServiceInstance)
 	if err != nil {
 		return ServiceBindingRecord{}, fmt.Errorf("failed to get service instance: %w", err)
 	}
@@ -219,13 +267,125 @@ func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo aut
 		return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
 
+	if serviceBinding.IsRoute() {
+		// route bindings are served by the service route bindings endpoints
+		return ServiceBindingRecord{}, apierrors.NewNotFoundError(nil, ServiceBindingResourceType)
+	}
+
 	return serviceBindingToRecord(*serviceBinding), nil
 }
 
+func (r *ServiceBindingRepo) GetServiceBindingDetails(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBindingDetailsRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBindingResourceType)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
+	}
+
+	if serviceBinding.Status.Credentials.Name == "" {
+		return ServiceBindingDetailsRecord{}, apierrors.NewUnprocessableEntityError(nil, "The service binding is not yet ready")
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: serviceBinding.Status.Credentials.Name}, credentialsSecret)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to get credentials secret: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	credentials := map[string]any{}
+	err = json.Unmarshal(credentialsSecret.Data[tools.CredentialsSecretKey], &credentials)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+
+	return ServiceBindingDetailsRecord{Credentials: credentials}, nil
+}
+
+// GetServiceBindingParameters fetches the parameters of a managed service
+// binding from its broker. The broker must advertise the bindings_retrievable
+// feature in its catalog.
+func (r *ServiceBindingRepo) GetServiceBindingParameters(ctx context.Context, authInfo authorization.Info, guid string) (map[string]any, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBindingResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, ServiceBindingResourceType)
+	}
+
+	if serviceBinding.IsRoute() {
+		return nil, apierrors.NewNotFoundError(nil, ServiceBindingResourceType)
+	}
+
+	// the service instance may live in another space when it is shared with
+	// the space of the binding
+	serviceInstance, err := r.serviceAssets.GetServiceInstance(ctx, serviceBinding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	notSupportedErr := apierrors.NewInvalidRequestError(nil, "This service does not support fetching service binding parameters.")
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return nil, notSupportedErr
+	}
+
+	assets, err := r.serviceAssets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	if !assets.ServiceOffering.Spec.BrokerCatalog.Features.BindingsRetrievable {
+		return nil, notSupportedErr
+	}
+
+	osbapiClient, err := r.brokerClientFactory.CreateClient(ctx, assets.ServiceBroker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broker client: %w", err)
+	}
+
+	binding, err := osbapiClient.GetServiceBinding(ctx, osbapi.GetServiceBindingRequest{
+		InstanceID: serviceInstance.Name,
+		BindingID:  serviceBinding.Name,
+		ServiceId:  assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:     assets.ServicePlan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service binding from broker: %w", err)
+	}
+
+	return binding.Parameters, nil
+}
+
+func bindingType(binding korifiv1alpha1.CFServiceBinding) string {
+	if binding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeKey {
+		return ServiceBindingTypeKey
+	}
+
+	return ServiceBindingTypeApp
+}
+
 func serviceBindingToRecord(binding korifiv1alpha1.CFServiceBinding) ServiceBindingRecord {
 	return ServiceBindingRecord{
 		GUID:                binding.Name,
-		Type:                ServiceBindingTypeApp,
+		Type:                bindingType(binding),
 		Name:                binding.Spec.DisplayName,
 		AppGUID:             binding.Spec.AppRef.Name,
 		ServiceInstanceGUID: binding.Spec.Service.Name,
@@ -10,7 +10,10 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -28,7 +31,10 @@ import (
 
 var _ = Describe("ServiceBindingRepo", func() {
 	var (
-		repo  *repositories.ServiceBindingRepo
+		repo                *repositories.ServiceBindingRepo
+		brokerClientFactory *osbapifake.BrokerClientFactory
+		brokerClient        *osbapifake.BrokerClient
+
 		org   *korifiv1alpha1.CFOrg
 		space *korifiv1alpha1.CFSpace
 
@@ -49,12 +55,19 @@ var _ = Describe("ServiceBindingRepo", func() {
 			korifiv1alpha1.CFServiceBindingList,
 			*korifiv1alpha1.CFServiceBindingList,
 		]{}
+		brokerClient = new(osbapifake.BrokerClient)
+		brokerClientFactory = new(osbapifake.BrokerClientFactory)
+		brokerClientFactory.CreateClientReturns(brokerClient, nil)
+
 		repo = repositories.NewServiceBindingRepo(
 			namespaceRetriever,
 			userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
 				return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
 			}),
-			conditionAwaiter)
+			conditionAwaiter,
+			brokerClientFactory,
+			osbapi.NewAssets(k8sClient, rootNamespace),
+		)
 
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space1"))
@@ -225,9 +238,10 @@ var _ = Describe("ServiceBindingRepo", func() {
 
 	Describe("CreateUserProvidedServiceBinding", func() {
 		var (
-			cfServiceInstance    *korifiv1alpha1.CFServiceInstance
-			serviceBindingRecord repositories.ServiceBindingRecord
-			createErr            error
+			cfServiceInstance        *korifiv1alpha1.CFServiceInstance
+			serviceInstanceSpaceGUID string
+			serviceBindingRecord     repositories.ServiceBindingRecord
+			createErr                error
 		)
 
 		BeforeEach(func() {
@@ -262,14 +276,16 @@ var _ = Describe("ServiceBindingRepo", func() {
 			}
 
 			bindingName = nil
+			serviceInstanceSpaceGUID = space.Name
 		})
 
 		JustBeforeEach(func() {
 			serviceBindingRecord, createErr = repo.CreateServiceBinding(ctx, authInfo, repositories.CreateServiceBindingMessage{
-				Name:                bindingName,
-				ServiceInstanceGUID: cfServiceInstance.Name,
-				AppGUID:             appGUID,
-				SpaceGUID:           space.Name,
+				Name:                     bindingName,
+				ServiceInstanceGUID:      cfServiceInstance.Name,
+				AppGUID:                  appGUID,
+				SpaceGUID:                space.Name,
+				ServiceInstanceSpaceGUID: serviceInstanceSpaceGUID,
 			})
 		})
 
@@ -308,6 +324,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(serviceBinding.Spec).To(Equal(
 					korifiv1alpha1.CFServiceBindingSpec{
 						DisplayName: nil,
+						Type:        korifiv1alpha1.CFServiceBindingTypeApp,
 						Service: corev1.ObjectReference{
 							Kind:       "CFServiceInstance",
 							APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
@@ -363,6 +380,38 @@ var _ = Describe("ServiceBindingRepo", func() {
 					Expect(serviceBindingRecord.Name).To(Equal(bindingName))
 				})
 			})
+
+			When("the service instance is shared from another space", func() {
+				BeforeEach(func() {
+					instanceSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space2"))
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, instanceSpace.Name)
+
+					cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: instanceSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceInstanceSpec{
+							Type:         korifiv1alpha1.UserProvidedType,
+							SharedSpaces: []string{space.Name},
+						},
+					}
+					Expect(k8sClient.Create(ctx, cfServiceInstance)).To(Succeed())
+
+					serviceInstanceSpaceGUID = instanceSpace.Name
+				})
+
+				It("creates a binding referencing the service instance in its space", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					serviceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(
+						k8sClient.Get(ctx, types.NamespacedName{Name: serviceBindingRecord.GUID, Namespace: space.Name}, serviceBinding),
+					).To(Succeed())
+					Expect(serviceBinding.Spec.Service.Name).To(Equal(cfServiceInstance.Name))
+					Expect(serviceBinding.Spec.Service.Namespace).To(Equal(serviceInstanceSpaceGUID))
+				})
+			})
 		})
 	})
 
@@ -561,6 +610,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(serviceBinding.Spec).To(Equal(
 					korifiv1alpha1.CFServiceBindingSpec{
 						DisplayName: nil,
+						Type:        korifiv1alpha1.CFServiceBindingTypeApp,
 						Service: corev1.ObjectReference{
 							Kind:       "CFServiceInstance",
 							APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
@@ -596,6 +646,66 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("CreateServiceKey", func() {
+		var (
+			cfServiceInstance    *korifiv1alpha1.CFServiceInstance
+			serviceBindingRecord repositories.ServiceBindingRecord
+			createErr            error
+		)
+
+		BeforeEach(func() {
+			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type: korifiv1alpha1.ManagedType,
+				},
+			}
+			Expect(
+				k8sClient.Create(ctx, cfServiceInstance),
+			).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			serviceBindingRecord, createErr = repo.CreateServiceBinding(ctx, authInfo, repositories.CreateServiceBindingMessage{
+				Name:                tools.PtrTo("my-key"),
+				Type:                repositories.ServiceBindingTypeKey,
+				ServiceInstanceGUID: cfServiceInstance.Name,
+				SpaceGUID:           space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user can create CFServiceBindings in the Space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a key CFServiceBinding without an app and returns a record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(serviceBindingRecord.Type).To(Equal("key"))
+				Expect(serviceBindingRecord.Name).To(PointTo(Equal("my-key")))
+				Expect(serviceBindingRecord.AppGUID).To(BeEmpty())
+				Expect(serviceBindingRecord.Relationships()).To(Equal(map[string]string{
+					"service_instance": cfServiceInstance.Name,
+				}))
+
+				serviceBinding := new(korifiv1alpha1.CFServiceBinding)
+				Expect(
+					k8sClient.Get(ctx, types.NamespacedName{Name: serviceBindingRecord.GUID, Namespace: space.Name}, serviceBinding),
+				).To(Succeed())
+				Expect(serviceBinding.Spec.Type).To(Equal(korifiv1alpha1.CFServiceBindingTypeKey))
+				Expect(serviceBinding.Spec.AppRef.Name).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("DeleteServiceBinding", func() {
 		var (
 			deleteErr          error
@@ -809,6 +919,32 @@ var _ = Describe("ServiceBindingRepo", func() {
 				})
 			})
 
+			When("a route is bound to one of the service instances", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      prefixedGUID("route-binding"),
+							Namespace: space.Name,
+						},
+						Spec: korifiv1alpha1.CFServiceBindingSpec{
+							Type: korifiv1alpha1.CFServiceBindingTypeRoute,
+							Service: corev1.ObjectReference{
+								Kind:       "ServiceInstance",
+								Name:       serviceInstance1GUID,
+								APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+							},
+							RouteRef: corev1.LocalObjectReference{
+								Name: "some-route",
+							},
+						},
+					})).To(Succeed())
+				})
+
+				It("does not return the route binding", func() {
+					Expect(responseServiceBindings).To(HaveLen(3))
+				})
+			})
+
 			When("filtered by service instance GUID", func() {
 				BeforeEach(func() {
 					requestMessage = repositories.ListServiceBindingsMessage{
@@ -928,6 +1064,18 @@ var _ = Describe("ServiceBindingRepo", func() {
 					))
 				})
 			})
+
+			When("filtered by type", func() {
+				BeforeEach(func() {
+					requestMessage = repositories.ListServiceBindingsMessage{
+						Type: repositories.ServiceBindingTypeKey,
+					}
+				})
+
+				It("returns only the ServiceBindings of that type", func() {
+					Expect(responseServiceBindings).To(BeEmpty())
+				})
+			})
 		})
 
 		When("the user does not have access to any namespaces", func() {
@@ -1005,6 +1153,198 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("GetServiceBindingDetails", func() {
+		var (
+			serviceBinding *korifiv1alpha1.CFServiceBinding
+			details        repositories.ServiceBindingDetailsRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			credentialsSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Data: map[string][]byte{
+					tools.CredentialsSecretKey: []byte(`{"username":"user","password":"pass"}`),
+				},
+			}
+			Expect(k8sClient.Create(ctx, credentialsSecret)).To(Succeed())
+
+			serviceBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("binding"),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeKey,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       uuid.NewString(),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBinding)).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, serviceBinding, func() {
+				serviceBinding.Status.Credentials.Name = credentialsSecret.Name
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			details, getErr = repo.GetServiceBindingDetails(ctx, authInfo, serviceBinding.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the binding credentials", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(details.Credentials).To(Equal(map[string]any{
+					"username": "user",
+					"password": "pass",
+				}))
+			})
+
+			When("the binding has no credentials yet", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, serviceBinding, func() {
+						serviceBinding.Status.Credentials.Name = ""
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetServiceBindingParameters", func() {
+		var (
+			servicePlan     *korifiv1alpha1.CFServicePlan
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			serviceBinding  *korifiv1alpha1.CFServiceBinding
+			parameters      map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			servicePlan = createBrokerServicePlan(ctx, services.BrokerCatalogFeatures{BindingsRetrievable: true})
+
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "managed-instance",
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    servicePlan.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+
+			serviceBinding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("binding"),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeKey,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBinding)).To(Succeed())
+
+			brokerClient.GetServiceBindingReturns(osbapi.GetBindingResponse{
+				Parameters: map[string]any{"p1": "v1"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			parameters, getErr = repo.GetServiceBindingParameters(ctx, authInfo, serviceBinding.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user can read the service binding", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("fetches the parameters from the broker", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(parameters).To(Equal(map[string]any{"p1": "v1"}))
+
+				Expect(brokerClientFactory.CreateClientCallCount()).To(Equal(1))
+				_, actualBroker := brokerClientFactory.CreateClientArgsForCall(0)
+				Expect(actualBroker.Name).To(Equal(servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]))
+
+				Expect(brokerClient.GetServiceBindingCallCount()).To(Equal(1))
+				_, actualRequest := brokerClient.GetServiceBindingArgsForCall(0)
+				Expect(actualRequest).To(Equal(osbapi.GetServiceBindingRequest{
+					InstanceID: serviceInstance.Name,
+					BindingID:  serviceBinding.Name,
+					ServiceId:  "offering-catalog-id",
+					PlanID:     "plan-catalog-id",
+				}))
+			})
+
+			When("the broker does not support fetching service bindings", func() {
+				BeforeEach(func() {
+					servicePlan = createBrokerServicePlan(ctx, services.BrokerCatalogFeatures{})
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.PlanGUID = servicePlan.Name
+					})).To(Succeed())
+				})
+
+				It("returns an invalid request error", func() {
+					Expect(getErr).To(SatisfyAll(
+						BeAssignableToTypeOf(apierrors.InvalidRequestError{}),
+						MatchError(ContainSubstring("This service does not support fetching service binding parameters.")),
+					))
+					Expect(brokerClient.GetServiceBindingCallCount()).To(BeZero())
+				})
+			})
+
+			When("the service instance is user-provided", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.Type = korifiv1alpha1.UserProvidedType
+					})).To(Succeed())
+				})
+
+				It("returns an invalid request error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.InvalidRequestError{}))
+				})
+			})
+
+			When("fetching the service binding from the broker fails", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceBindingReturns(osbapi.GetBindingResponse{}, errors.New("get-binding-err"))
+				})
+
+				It("returns the error", func() {
+					Expect(getErr).To(MatchError(ContainSubstring("get-binding-err")))
+				})
+			})
+		})
+	})
+
 	Describe("UpdateServiceBinding", func() {
 		var (
 			serviceBinding        *korifiv1alpha1.CFServiceBinding
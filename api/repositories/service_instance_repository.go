@@ -13,6 +13,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories/compare"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools"
@@ -41,11 +42,13 @@ type NamespaceGetter interface {
 }
 
 type ServiceInstanceRepo struct {
-	namespaceRetriever NamespaceRetriever
-	userClientFactory  authorization.UserClientFactory
-	awaiter            Awaiter[*korifiv1alpha1.CFServiceInstance]
-	sorter             ServiceInstanceSorter
-	rootNamespace      string
+	namespaceRetriever  NamespaceRetriever
+	userClientFactory   authorization.UserClientFactory
+	awaiter             Awaiter[*korifiv1alpha1.CFServiceInstance]
+	sorter              ServiceInstanceSorter
+	rootNamespace       string
+	brokerClientFactory osbapi.BrokerClientFactory
+	serviceAssets       *osbapi.Assets
 }
 
 //counterfeiter:generate -o fake -fake-name ServiceInstanceSorter . ServiceInstanceSorter
@@ -93,13 +96,17 @@ func NewServiceInstanceRepo(
 	awaiter Awaiter[*korifiv1alpha1.CFServiceInstance],
 	sorter ServiceInstanceSorter,
 	rootNamespace string,
+	brokerClientFactory osbapi.BrokerClientFactory,
+	serviceAssets *osbapi.Assets,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
-		namespaceRetriever: namespaceRetriever,
-		userClientFactory:  userClientFactory,
-		awaiter:            awaiter,
-		sorter:             sorter,
-		rootNamespace:      rootNamespace,
+		namespaceRetriever:  namespaceRetriever,
+		userClientFactory:   userClientFactory,
+		awaiter:             awaiter,
+		sorter:              sorter,
+		rootNamespace:       rootNamespace,
+		brokerClientFactory: brokerClientFactory,
+		serviceAssets:       serviceAssets,
 	}
 }
 
@@ -128,6 +135,8 @@ type PatchServiceInstanceMessage struct {
 	Name        *string
 	Credentials *map[string]any
 	Tags        *[]string
+	PlanGUID    *string
+	Parameters  *map[string]any
 	MetadataPatch
 }
 
@@ -138,6 +147,9 @@ func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFS
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.PlanGUID != nil {
+		cfServiceInstance.Spec.PlanGUID = *p.PlanGUID
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
 }
 
@@ -162,6 +174,23 @@ type DeleteServiceInstanceMessage struct {
 	Purge bool
 }
 
+type ShareServiceInstanceMessage struct {
+	GUID             string
+	SpaceGUID        string
+	SharedSpaceGUIDs []string
+}
+
+type UnshareServiceInstanceMessage struct {
+	GUID            string
+	SpaceGUID       string
+	SharedSpaceGUID string
+}
+
+type SharedSpaceUsageRecord struct {
+	SpaceGUID     string
+	BoundAppCount int
+}
+
 type ServiceInstanceRecord struct {
 	Name          string
 	GUID          string
@@ -170,6 +199,7 @@ type ServiceInstanceRecord struct {
 	SecretName    string
 	Tags          []string
 	Type          string
+	SharedSpaces  []string
 	Labels        map[string]string
 	Annotations   map[string]string
 	CreatedAt     time.Time
@@ -296,6 +326,43 @@ func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, userClient
 	return slices.Contains(servicePlan.Spec.Visibility.Organizations, orgGUID), nil
 }
 
+// validatePlanChange checks that the service instance can be moved to the
+// new plan, i.e. that the new plan is visible in the space of the instance,
+// belongs to the same service offering as the current plan and that the
+// offering or the plan allow changing plans
+func (r *ServiceInstanceRepo) validatePlanChange(ctx context.Context, userClient client.Client, cfServiceInstance *korifiv1alpha1.CFServiceInstance, newPlanGUID string) error {
+	planVisible, err := r.servicePlanVisible(ctx, userClient, newPlanGUID, cfServiceInstance.Namespace)
+	if err != nil || !planVisible {
+		return apierrors.NewUnprocessableEntityError(err, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+	}
+
+	newPlan := &korifiv1alpha1.CFServicePlan{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: newPlanGUID}, newPlan); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	currentPlan := &korifiv1alpha1.CFServicePlan{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: cfServiceInstance.Spec.PlanGUID}, currentPlan); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	offeringGUID := currentPlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]
+	if newPlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel] != offeringGUID {
+		return apierrors.NewUnprocessableEntityError(nil, "service plan relates to a different service offering")
+	}
+
+	offering := &korifiv1alpha1.CFServiceOffering{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: offeringGUID}, offering); err != nil {
+		return apierrors.FromK8sError(err, ServiceOfferingResourceType)
+	}
+
+	if !offering.Spec.BrokerCatalog.Features.PlanUpdateable && !newPlan.Spec.BrokerCatalog.Features.PlanUpdateable {
+		return apierrors.NewUnprocessableEntityError(nil, "The service does not support changing plans.")
+	}
+
+	return nil
+}
+
 func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo authorization.Info, message PatchServiceInstanceMessage) (ServiceInstanceRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -309,8 +376,26 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if message.PlanGUID != nil && *message.PlanGUID != cfServiceInstance.Spec.PlanGUID {
+		if err = r.validatePlanChange(ctx, userClient, cfServiceInstance, *message.PlanGUID); err != nil {
+			return ServiceInstanceRecord{}, err
+		}
+	}
+
+	var parameters *runtime.RawExtension
+	if message.Parameters != nil {
+		parameterBytes, marshalErr := json.Marshal(*message.Parameters)
+		if marshalErr != nil {
+			return ServiceInstanceRecord{}, fmt.Errorf("failed to marshal parameters: %w", marshalErr)
+		}
+		parameters = &runtime.RawExtension{Raw: parameterBytes}
+	}
+
 	err = k8s.PatchResource(ctx, userClient, cfServiceInstance, func() {
 		message.Apply(cfServiceInstance)
+		if parameters != nil {
+			cfServiceInstance.Spec.Parameters = parameters
+		}
 	})
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
@@ -436,6 +521,94 @@ func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo a
 	return cfServiceInstanceToRecord(*serviceInstance), nil
 }
 
+// GetServiceInstanceCredentials returns the credentials of a user-provided
+// service instance. Reading the credentials secret requires the space
+// developer role.
+func (r *ServiceInstanceRepo) GetServiceInstanceCredentials(ctx context.Context, authInfo authorization.Info, guid string) (map[string]any, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, serviceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.UserProvidedType {
+		return nil, apierrors.NewNotFoundError(nil, ServiceInstanceResourceType)
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: serviceInstance.Spec.SecretName}, credentialsSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials secret: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	credentials := map[string]any{}
+	err = json.Unmarshal(credentialsSecret.Data[tools.CredentialsSecretKey], &credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+// GetServiceInstanceParameters fetches the parameters of a managed service
+// instance from its broker. The broker must advertise the
+// instances_retrievable feature in its catalog.
+func (r *ServiceInstanceRepo) GetServiceInstanceParameters(ctx context.Context, authInfo authorization.Info, guid string) (map[string]any, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, serviceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	notSupportedErr := apierrors.NewInvalidRequestError(nil, "This service does not support fetching service instance parameters.")
+	if serviceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return nil, notSupportedErr
+	}
+
+	assets, err := r.serviceAssets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	if !assets.ServiceOffering.Spec.BrokerCatalog.Features.InstancesRetrievable {
+		return nil, notSupportedErr
+	}
+
+	osbapiClient, err := r.brokerClientFactory.CreateClient(ctx, assets.ServiceBroker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broker client: %w", err)
+	}
+
+	instance, err := osbapiClient.GetServiceInstance(ctx, osbapi.GetServiceInstanceRequest{
+		InstanceID: serviceInstance.Name,
+		ServiceId:  assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:     assets.ServicePlan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch service instance from broker: %w", err)
+	}
+
+	return instance.Parameters, nil
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) (ServiceInstanceRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -477,6 +650,125 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return cfServiceInstanceToRecord(*serviceInstance), nil
 }
 
+// ShareServiceInstance shares the service instance with the spaces, so that
+// apps in these spaces can be bound to it
+func (r *ServiceInstanceRepo) ShareServiceInstance(ctx context.Context, authInfo authorization.Info, message ShareServiceInstanceMessage) (ServiceInstanceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, serviceInstance)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, serviceInstance, func() {
+		for _, sharedSpaceGUID := range message.SharedSpaceGUIDs {
+			if !slices.Contains(serviceInstance.Spec.SharedSpaces, sharedSpaceGUID) {
+				serviceInstance.Spec.SharedSpaces = append(serviceInstance.Spec.SharedSpaces, sharedSpaceGUID)
+			}
+		}
+	})
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to share service instance %q: %w", message.GUID, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return cfServiceInstanceToRecord(*serviceInstance), nil
+}
+
+// UnshareServiceInstance stops sharing the service instance with the space.
+// The bindings of apps in that space to the service instance are deleted.
+func (r *ServiceInstanceRepo) UnshareServiceInstance(ctx context.Context, authInfo authorization.Info, message UnshareServiceInstanceMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, serviceInstance)
+	if err != nil {
+		return fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	sharedBindings, err := r.listSharedBindings(ctx, userClient, serviceInstance, message.SharedSpaceGUID)
+	if err != nil {
+		return err
+	}
+
+	for _, binding := range sharedBindings {
+		if err = userClient.Delete(ctx, &binding); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service binding %q: %w", binding.Name, apierrors.FromK8sError(err, ServiceBindingResourceType))
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, serviceInstance, func() {
+		serviceInstance.Spec.SharedSpaces = slices.DeleteFunc(serviceInstance.Spec.SharedSpaces, func(sharedSpaceGUID string) bool {
+			return sharedSpaceGUID == message.SharedSpaceGUID
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare service instance %q: %w", message.GUID, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return nil
+}
+
+// GetSharedSpacesUsageSummary returns the number of apps bound to the
+// service instance in each of the spaces it is shared with
+func (r *ServiceInstanceRepo) GetSharedSpacesUsageSummary(ctx context.Context, authInfo authorization.Info, guid string) ([]SharedSpaceUsageRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, serviceInstance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	usageSummary := []SharedSpaceUsageRecord{}
+	for _, sharedSpaceGUID := range serviceInstance.Spec.SharedSpaces {
+		sharedBindings, err := r.listSharedBindings(ctx, userClient, serviceInstance, sharedSpaceGUID)
+		if err != nil {
+			return nil, err
+		}
+
+		usageSummary = append(usageSummary, SharedSpaceUsageRecord{
+			SpaceGUID: sharedSpaceGUID,
+			BoundAppCount: len(slices.DeleteFunc(sharedBindings, func(binding korifiv1alpha1.CFServiceBinding) bool {
+				return binding.IsKey()
+			})),
+		})
+	}
+
+	return usageSummary, nil
+}
+
+func (r *ServiceInstanceRepo) listSharedBindings(
+	ctx context.Context,
+	userClient client.WithWatch,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	sharedSpaceGUID string,
+) ([]korifiv1alpha1.CFServiceBinding, error) {
+	serviceBindings := new(korifiv1alpha1.CFServiceBindingList)
+	if err := userClient.List(ctx, serviceBindings, client.InNamespace(sharedSpaceGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list service bindings: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return itx.FromSlice(serviceBindings.Items).Filter(func(serviceBinding korifiv1alpha1.CFServiceBinding) bool {
+		return serviceBinding.Spec.Service.Name == serviceInstance.Name &&
+			serviceBinding.ServiceInstanceNamespace() == serviceInstance.Namespace
+	}).Collect(), nil
+}
+
 func (r ServiceInstanceRecord) GetResourceType() string {
 	return ServiceInstanceResourceType
 }
@@ -533,6 +825,7 @@ func cfServiceInstanceToRecord(cfServiceInstance korifiv1alpha1.CFServiceInstanc
 		SecretName:    cfServiceInstance.Spec.SecretName,
 		Tags:          cfServiceInstance.Spec.Tags,
 		Type:          string(cfServiceInstance.Spec.Type),
+		SharedSpaces:  cfServiceInstance.Spec.SharedSpaces,
 		Labels:        cfServiceInstance.Labels,
 		Annotations:   cfServiceInstance.Annotations,
 		CreatedAt:     cfServiceInstance.CreationTimestamp.Time,
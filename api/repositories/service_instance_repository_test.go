@@ -13,6 +13,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/model"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tests/matchers"
@@ -41,7 +43,9 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			korifiv1alpha1.CFServiceInstanceList,
 			*korifiv1alpha1.CFServiceInstanceList,
 		]
-		sorter *fake.ServiceInstanceSorter
+		sorter              *fake.ServiceInstanceSorter
+		brokerClientFactory *osbapifake.BrokerClientFactory
+		brokerClient        *osbapifake.BrokerClient
 
 		org                 *korifiv1alpha1.CFOrg
 		space               *korifiv1alpha1.CFSpace
@@ -59,6 +63,9 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		sorter.SortStub = func(records []repositories.ServiceInstanceRecord, _ string) []repositories.ServiceInstanceRecord {
 			return records
 		}
+		brokerClient = new(osbapifake.BrokerClient)
+		brokerClientFactory = new(osbapifake.BrokerClientFactory)
+		brokerClientFactory.CreateClientReturns(brokerClient, nil)
 
 		serviceInstanceRepo = repositories.NewServiceInstanceRepo(
 			namespaceRetriever,
@@ -68,6 +75,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			conditionAwaiter,
 			sorter,
 			rootNamespace,
+			brokerClientFactory,
+			osbapi.NewAssets(k8sClient, rootNamespace),
 		)
 
 		org = createOrgWithCleanup(ctx, uuid.NewString())
@@ -682,6 +691,119 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					})
 				})
 			})
+
+			When("the parameters are provided", func() {
+				BeforeEach(func() {
+					patchMessage.Parameters = &map[string]any{"p1": "v1"}
+				})
+
+				It("updates the parameters of the service instance", func() {
+					Expect(err).NotTo(HaveOccurred())
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.Parameters).NotTo(BeNil())
+					Expect(serviceInstance.Spec.Parameters.Raw).To(MatchJSON(`{"p1":"v1"}`))
+				})
+			})
+
+			When("the service plan is changed", func() {
+				var (
+					serviceOffering *korifiv1alpha1.CFServiceOffering
+					newPlan         *korifiv1alpha1.CFServicePlan
+				)
+
+				BeforeEach(func() {
+					serviceOffering = &korifiv1alpha1.CFServiceOffering{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceOfferingSpec{
+							ServiceOffering: services.ServiceOffering{
+								BrokerCatalog: services.ServiceBrokerCatalog{
+									Features: services.BrokerCatalogFeatures{
+										PlanUpdateable: true,
+									},
+								},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, serviceOffering)).To(Succeed())
+
+					currentPlan := createServicePlan(ctx, serviceOffering.Name)
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.PlanGUID = currentPlan.Name
+					})).To(Succeed())
+
+					newPlan = createServicePlan(ctx, serviceOffering.Name)
+					patchMessage.PlanGUID = tools.PtrTo(newPlan.Name)
+				})
+
+				It("updates the plan of the service instance", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.PlanGUID).To(Equal(newPlan.Name))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.PlanGUID).To(Equal(newPlan.Name))
+				})
+
+				When("the service offering does not allow plan changes", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, serviceOffering, func() {
+							serviceOffering.Spec.BrokerCatalog.Features.PlanUpdateable = false
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(SatisfyAll(
+							BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+							MatchError(ContainSubstring("The service does not support changing plans.")),
+						))
+					})
+
+					When("the new plan allows plan changes", func() {
+						BeforeEach(func() {
+							Expect(k8s.PatchResource(ctx, k8sClient, newPlan, func() {
+								newPlan.Spec.BrokerCatalog.Features.PlanUpdateable = true
+							})).To(Succeed())
+						})
+
+						It("succeeds", func() {
+							Expect(err).NotTo(HaveOccurred())
+						})
+					})
+				})
+
+				When("the new plan belongs to a different service offering", func() {
+					BeforeEach(func() {
+						newPlan = createServicePlan(ctx, uuid.NewString())
+						patchMessage.PlanGUID = tools.PtrTo(newPlan.Name)
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(SatisfyAll(
+							BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+							MatchError(ContainSubstring("service plan relates to a different service offering")),
+						))
+					})
+				})
+
+				When("the new plan is not visible", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, newPlan, func() {
+							newPlan.Spec.Visibility.Type = korifiv1alpha1.AdminServicePlanVisibilityType
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(SatisfyAll(
+							BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}),
+							MatchError(ContainSubstring("Invalid service plan")),
+						))
+					})
+				})
+			})
 		})
 	})
 
@@ -1037,6 +1159,167 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceCredentials", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			credentials     map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, uuid.NewString(), space.Name, "the-service-instance", uuid.NewString())
+
+			credentialsSecretData, err := tools.ToCredentialsSecretData(map[string]any{"foo": "bar"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      serviceInstance.Spec.SecretName,
+				},
+				Data: credentialsSecretData,
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			credentials, getErr = serviceInstanceRepo.GetServiceInstanceCredentials(ctx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the credentials", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(credentials).To(Equal(map[string]any{"foo": "bar"}))
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+					})).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is a space auditor", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetServiceInstanceParameters", func() {
+		var (
+			servicePlan     *korifiv1alpha1.CFServicePlan
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			parameters      map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			servicePlan = createBrokerServicePlan(ctx, services.BrokerCatalogFeatures{InstancesRetrievable: true})
+
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "managed-instance",
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    servicePlan.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+
+			brokerClient.GetServiceInstanceReturns(osbapi.GetInstanceResponse{
+				Parameters: map[string]any{"p1": "v1"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			parameters, getErr = serviceInstanceRepo.GetServiceInstanceParameters(ctx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user can read the service instance", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("fetches the parameters from the broker", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(parameters).To(Equal(map[string]any{"p1": "v1"}))
+
+				Expect(brokerClientFactory.CreateClientCallCount()).To(Equal(1))
+				_, actualBroker := brokerClientFactory.CreateClientArgsForCall(0)
+				Expect(actualBroker.Name).To(Equal(servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]))
+
+				Expect(brokerClient.GetServiceInstanceCallCount()).To(Equal(1))
+				_, actualRequest := brokerClient.GetServiceInstanceArgsForCall(0)
+				Expect(actualRequest).To(Equal(osbapi.GetServiceInstanceRequest{
+					InstanceID: serviceInstance.Name,
+					ServiceId:  "offering-catalog-id",
+					PlanID:     "plan-catalog-id",
+				}))
+			})
+
+			When("the broker does not support fetching service instances", func() {
+				BeforeEach(func() {
+					servicePlan = createBrokerServicePlan(ctx, services.BrokerCatalogFeatures{})
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.PlanGUID = servicePlan.Name
+					})).To(Succeed())
+				})
+
+				It("returns an invalid request error", func() {
+					Expect(getErr).To(SatisfyAll(
+						BeAssignableToTypeOf(apierrors.InvalidRequestError{}),
+						MatchError(ContainSubstring("This service does not support fetching service instance parameters.")),
+					))
+					Expect(brokerClient.GetServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			When("the service instance is user-provided", func() {
+				BeforeEach(func() {
+					serviceInstance = createServiceInstanceCR(ctx, k8sClient, uuid.NewString(), space.Name, "upsi", uuid.NewString())
+				})
+
+				It("returns an invalid request error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.InvalidRequestError{}))
+				})
+			})
+
+			When("fetching the service instance from the broker fails", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceReturns(osbapi.GetInstanceResponse{}, errors.New("get-instance-err"))
+				})
+
+				It("returns the error", func() {
+					Expect(getErr).To(MatchError(ContainSubstring("get-instance-err")))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
@@ -1150,6 +1433,168 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			Expect(binding.Finalizers).To(BeEmpty())
 		})
 	})
+
+	Describe("sharing", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+		)
+
+		createSharedBinding := func(bindingType string) *korifiv1alpha1.CFServiceBinding {
+			serviceBinding := &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: sharedSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: bindingType,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+						Namespace:  space.Name,
+					},
+					AppRef: corev1.LocalObjectReference{
+						Name: uuid.NewString(),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBinding)).To(Succeed())
+
+			return serviceBinding
+		}
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		})
+
+		Describe("ShareServiceInstance", func() {
+			var (
+				record   repositories.ServiceInstanceRecord
+				shareErr error
+			)
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name}
+				})).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				record, shareErr = serviceInstanceRepo.ShareServiceInstance(ctx, authInfo, repositories.ShareServiceInstanceMessage{
+					GUID:             serviceInstance.Name,
+					SpaceGUID:        space.Name,
+					SharedSpaceGUIDs: []string{"other-space-guid", sharedSpace.Name},
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in the service instance space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("adds the spaces that are not shared yet", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(record.SharedSpaces).To(Equal([]string{sharedSpace.Name, "other-space-guid"}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.SharedSpaces).To(Equal([]string{sharedSpace.Name, "other-space-guid"}))
+				})
+			})
+		})
+
+		Describe("UnshareServiceInstance", func() {
+			var (
+				sharedBinding *korifiv1alpha1.CFServiceBinding
+				unshareErr    error
+			)
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name, "other-space-guid"}
+				})).To(Succeed())
+
+				sharedBinding = createSharedBinding(korifiv1alpha1.CFServiceBindingTypeApp)
+			})
+
+			JustBeforeEach(func() {
+				unshareErr = serviceInstanceRepo.UnshareServiceInstance(ctx, authInfo, repositories.UnshareServiceInstanceMessage{
+					GUID:            serviceInstance.Name,
+					SpaceGUID:       space.Name,
+					SharedSpaceGUID: sharedSpace.Name,
+				})
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in both spaces", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+				})
+
+				It("removes the space from the shared spaces", func() {
+					Expect(unshareErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.SharedSpaces).To(Equal([]string{"other-space-guid"}))
+				})
+
+				It("deletes the bindings to the service instance in the space", func() {
+					Expect(unshareErr).NotTo(HaveOccurred())
+
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), &korifiv1alpha1.CFServiceBinding{})
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue(), fmt.Sprintf("error: %+v", err))
+				})
+			})
+		})
+
+		Describe("GetSharedSpacesUsageSummary", func() {
+			var (
+				usageSummary []repositories.SharedSpaceUsageRecord
+				summaryErr   error
+			)
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name}
+				})).To(Succeed())
+
+				createSharedBinding(korifiv1alpha1.CFServiceBindingTypeApp)
+				createSharedBinding(korifiv1alpha1.CFServiceBindingTypeApp)
+			})
+
+			JustBeforeEach(func() {
+				usageSummary, summaryErr = serviceInstanceRepo.GetSharedSpacesUsageSummary(ctx, authInfo, serviceInstance.Name)
+			})
+
+			It("returns a forbidden error for unauthorized users", func() {
+				Expect(summaryErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer in both spaces", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sharedSpace.Name)
+				})
+
+				It("returns the number of bound apps per shared space", func() {
+					Expect(summaryErr).NotTo(HaveOccurred())
+					Expect(usageSummary).To(ConsistOf(repositories.SharedSpaceUsageRecord{
+						SpaceGUID:     sharedSpace.Name,
+						BoundAppCount: 2,
+					}))
+				})
+			})
+		})
+	})
 })
 
 var _ = DescribeTable("ServiceInstanceSorter",
@@ -1193,3 +1638,23 @@ var _ = DescribeTable("ServiceInstanceSorter",
 		BeNumerically(">", 0),
 	),
 )
+
+func createServicePlan(ctx context.Context, serviceOfferingGUID string) *korifiv1alpha1.CFServicePlan {
+	servicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOfferingGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFServicePlanSpec{
+			Visibility: korifiv1alpha1.ServicePlanVisibility{
+				Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, servicePlan)).To(Succeed())
+
+	return servicePlan
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const ServiceRouteBindingResourceType = "Service Route Binding"
+
+// ServiceRouteBindingRepo reads and deletes the bindings of routes to route
+// service instances. Route bindings are service bindings of type route. They
+// cannot be created as the gateway does not support route services.
+type ServiceRouteBindingRepo struct {
+	userClientFactory  authorization.UserClientFactory
+	namespaceRetriever NamespaceRetriever
+}
+
+func NewServiceRouteBindingRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserClientFactory,
+) *ServiceRouteBindingRepo {
+	return &ServiceRouteBindingRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+	}
+}
+
+type ServiceRouteBindingRecord struct {
+	GUID                string
+	RouteGUID           string
+	ServiceInstanceGUID string
+	SpaceGUID           string
+	RouteServiceURL     string
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+	DeletedAt           *time.Time
+	LastOperation       ServiceBindingLastOperation
+	Ready               bool
+}
+
+func (r ServiceRouteBindingRecord) Relationships() map[string]string {
+	return map[string]string{
+		"route":            r.RouteGUID,
+		"service_instance": r.ServiceInstanceGUID,
+	}
+}
+
+type ListServiceRouteBindingsMessage struct {
+	RouteGUIDs           []string
+	ServiceInstanceGUIDs []string
+	LabelSelector        string
+}
+
+func (m *ListServiceRouteBindingsMessage) matches(serviceBinding korifiv1alpha1.CFServiceBinding) bool {
+	return serviceBinding.IsRoute() &&
+		tools.EmptyOrContains(m.RouteGUIDs, serviceBinding.Spec.RouteRef.Name) &&
+		tools.EmptyOrContains(m.ServiceInstanceGUIDs, serviceBinding.Spec.Service.Name)
+}
+
+func (r *ServiceRouteBindingRepo) GetServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceRouteBindingRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceRouteBindingResourceType)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	if !serviceBinding.IsRoute() {
+		return ServiceRouteBindingRecord{}, apierrors.NewNotFoundError(nil, ServiceRouteBindingResourceType)
+	}
+
+	return serviceRouteBindingToRecord(*serviceBinding), nil
+}
+
+// nolint:dupl
+func (r *ServiceRouteBindingRepo) ListServiceRouteBindings(ctx context.Context, authInfo authorization.Info, message ListServiceRouteBindingsMessage) ([]ServiceRouteBindingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	labelSelector, err := labels.Parse(message.LabelSelector)
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
+	}
+
+	serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
+	err = userClient.List(ctx, serviceBindingList, &client.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to list service route bindings: %w",
+			apierrors.FromK8sError(err, ServiceRouteBindingResourceType),
+		)
+	}
+
+	filteredServiceBindings := itx.FromSlice(serviceBindingList.Items).Filter(message.matches)
+	return slices.Collect(it.Map(filteredServiceBindings, serviceRouteBindingToRecord)), nil
+}
+
+func (r *ServiceRouteBindingRepo) DeleteServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceRouteBindingResourceType)
+	if err != nil {
+		return err
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	if !serviceBinding.IsRoute() {
+		return apierrors.NewNotFoundError(nil, ServiceRouteBindingResourceType)
+	}
+
+	err = userClient.Delete(ctx, serviceBinding)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return nil
+}
+
+func (r *ServiceRouteBindingRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (model.CFResourceState, error) {
+	bindingRecord, err := r.GetServiceRouteBinding(ctx, authInfo, guid)
+	if err != nil {
+		return model.CFResourceStateUnknown, err
+	}
+
+	if bindingRecord.Ready {
+		return model.CFResourceStateReady, nil
+	}
+
+	return model.CFResourceStateUnknown, nil
+}
+
+func (r *ServiceRouteBindingRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	bindingRecord, err := r.GetServiceRouteBinding(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	return bindingRecord.DeletedAt, nil
+}
+
+func serviceRouteBindingToRecord(binding korifiv1alpha1.CFServiceBinding) ServiceRouteBindingRecord {
+	return ServiceRouteBindingRecord{
+		GUID:                binding.Name,
+		RouteGUID:           binding.Spec.RouteRef.Name,
+		ServiceInstanceGUID: binding.Spec.Service.Name,
+		SpaceGUID:           binding.Namespace,
+		RouteServiceURL:     binding.Status.RouteServiceURL,
+		Labels:              binding.Labels,
+		Annotations:         binding.Annotations,
+		CreatedAt:           binding.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&binding),
+		DeletedAt:           golangTime(binding.DeletionTimestamp),
+		LastOperation:       serviceBindingRecordLastOperation(binding),
+		Ready:               isBindingReady(binding),
+	}
+}
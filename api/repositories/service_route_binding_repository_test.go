@@ -0,0 +1,274 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceRouteBindingRepo", func() {
+	var (
+		repo  *repositories.ServiceRouteBindingRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+
+		instanceGUID string
+		routeGUID    string
+	)
+
+	createRouteBinding := func(namespace, routeName string) *korifiv1alpha1.CFServiceBinding {
+		GinkgoHelper()
+
+		binding := &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Type: korifiv1alpha1.CFServiceBindingTypeRoute,
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+					Name:       instanceGUID,
+				},
+				RouteRef: corev1.LocalObjectReference{Name: routeName},
+			},
+		}
+		Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+		return binding
+	}
+
+	BeforeEach(func() {
+		repo = repositories.NewServiceRouteBindingRepo(
+			namespaceRetriever,
+			userClientFactory.WithWrappingFunc(func(client client.WithWatch) client.WithWatch {
+				return authorization.NewSpaceFilteringClient(client, k8sClient, nsPerms)
+			}),
+		)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		instanceGUID = uuid.NewString()
+		routeGUID = uuid.NewString()
+	})
+
+	Describe("GetServiceRouteBinding", func() {
+		var (
+			binding *korifiv1alpha1.CFServiceBinding
+			record  repositories.ServiceRouteBindingRecord
+			getErr  error
+		)
+
+		BeforeEach(func() {
+			binding = createRouteBinding(space.Name, routeGUID)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceRouteBinding(ctx, authInfo, binding.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+
+				Expect(k8s.Patch(ctx, k8sClient, binding, func() {
+					binding.Status.RouteServiceURL = "https://route-service.example.com"
+				})).To(Succeed())
+			})
+
+			It("returns the route binding", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(binding.Name),
+					"RouteGUID":           Equal(routeGUID),
+					"ServiceInstanceGUID": Equal(instanceGUID),
+					"SpaceGUID":           Equal(space.Name),
+					"RouteServiceURL":     Equal("https://route-service.example.com"),
+				}))
+			})
+
+			When("the binding is not a route binding", func() {
+				BeforeEach(func() {
+					binding = &korifiv1alpha1.CFServiceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: space.Name,
+						},
+						Spec: korifiv1alpha1.CFServiceBindingSpec{
+							Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+							DisplayName: tools.PtrTo("my-key"),
+							Service: corev1.ObjectReference{
+								Kind:       "CFServiceInstance",
+								APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+								Name:       instanceGUID,
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, binding)).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			binding  *korifiv1alpha1.CFServiceBinding
+			state    model.CFResourceState
+			stateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			binding = createRouteBinding(space.Name, routeGUID)
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = repo.GetState(ctx, authInfo, binding.Name)
+		})
+
+		It("returns unknown state", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(model.CFResourceStateUnknown))
+		})
+
+		When("the route binding is ready", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, binding, func() {
+					binding.Status.ObservedGeneration = binding.Generation
+					meta.SetStatusCondition(&binding.Status.Conditions, metav1.Condition{
+						Type:    korifiv1alpha1.StatusConditionReady,
+						Status:  metav1.ConditionTrue,
+						Reason:  "Ready",
+						Message: "Ready",
+					})
+				})).To(Succeed())
+			})
+
+			It("returns ready state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(model.CFResourceStateReady))
+			})
+		})
+	})
+
+	Describe("ListServiceRouteBindings", func() {
+		var (
+			binding1, binding2 *korifiv1alpha1.CFServiceBinding
+			message            repositories.ListServiceRouteBindingsMessage
+			records            []repositories.ServiceRouteBindingRecord
+			listErr            error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+
+			binding1 = createRouteBinding(space.Name, routeGUID)
+			binding2 = createRouteBinding(space.Name, uuid.NewString())
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+					DisplayName: tools.PtrTo("my-key"),
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       instanceGUID,
+					},
+				},
+			})).To(Succeed())
+
+			message = repositories.ListServiceRouteBindingsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServiceRouteBindings(ctx, authInfo, message)
+		})
+
+		It("returns the route bindings only", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(binding1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(binding2.Name)}),
+			))
+		})
+
+		When("filtering by route", func() {
+			BeforeEach(func() {
+				message.RouteGUIDs = []string{routeGUID}
+			})
+
+			It("returns the bindings of the route", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(binding1.Name)}),
+				))
+			})
+		})
+
+		When("filtering by service instance", func() {
+			BeforeEach(func() {
+				message.ServiceInstanceGUIDs = []string{"another-instance"}
+			})
+
+			It("returns no bindings", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteServiceRouteBinding", func() {
+		var (
+			binding   *korifiv1alpha1.CFServiceBinding
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			binding = createRouteBinding(space.Name, routeGUID)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteServiceRouteBinding(ctx, authInfo, binding.Name)
+		})
+
+		It("returns a not found error for users with no role in the space", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the route binding", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
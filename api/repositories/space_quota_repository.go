@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SpaceQuotaResourceType = "Space Quota"
+)
+
+type SpaceQuotaRecord struct {
+	quotas.SpaceQuota
+	GUID      string
+	OrgGUID   string
+	Spaces    []string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type CreateSpaceQuotaMessage struct {
+	quotas.SpaceQuota
+	OrgGUID string
+	Spaces  []string
+}
+
+type UpdateSpaceQuotaMessage struct {
+	quotas.SpaceQuota
+	GUID string
+}
+
+type ApplySpaceQuotaMessage struct {
+	GUID   string
+	Spaces []string
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+	SpaceGUIDs        []string
+}
+
+func (m *ListSpaceQuotasMessage) matches(q korifiv1alpha1.CFSpaceQuota) bool {
+	return tools.EmptyOrContains(m.GUIDs, q.Name) &&
+		tools.EmptyOrContains(m.Names, q.Spec.Name) &&
+		tools.EmptyOrContains(m.OrganizationGUIDs, q.Spec.OrgGUID) &&
+		(len(m.SpaceGUIDs) == 0 || slices.ContainsFunc(q.Spec.Spaces, func(space string) bool {
+			return slices.Contains(m.SpaceGUIDs, space)
+		}))
+}
+
+type SpaceQuotaRepo struct {
+	userClientFactory authorization.UserClientFactory
+	rootNamespace     string
+}
+
+func NewSpaceQuotaRepo(
+	userClientFactory authorization.UserClientFactory,
+	rootNamespace string,
+) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.OrgGUID}, &korifiv1alpha1.CFOrg{})
+	if k8serrors.IsNotFound(err) {
+		return SpaceQuotaRecord{}, apierrors.NewUnprocessableEntityError(err, "Organization with guid '"+message.OrgGUID+"' does not exist")
+	}
+	if err != nil {
+		return SpaceQuotaRecord{}, apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	if err = ensureSpacesExist(ctx, userClient, message.OrgGUID, message.Spaces); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			SpaceQuota: message.SpaceQuota,
+			OrgGUID:    message.OrgGUID,
+			Spaces:     tools.Uniq(message.Spaces),
+		},
+	}
+
+	if err = r.detachSpaces(ctx, userClient, cfSpaceQuota.Name, message.Spaces); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = userClient.Create(ctx, cfSpaceQuota); err != nil {
+		return SpaceQuotaRecord{}, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err = userClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota); err != nil {
+		return SpaceQuotaRecord{}, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuotas := &korifiv1alpha1.CFSpaceQuotaList{}
+	if err = userClient.List(ctx, cfSpaceQuotas, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfSpaceQuotas.Items).Filter(message.matches), toSpaceQuotaRecord))
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func (r *SpaceQuotaRepo) UpdateSpaceQuota(ctx context.Context, authInfo authorization.Info, message UpdateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	return r.patchSpaceQuota(ctx, authInfo, message.GUID, func(cfSpaceQuota *korifiv1alpha1.CFSpaceQuota) {
+		cfSpaceQuota.Spec.SpaceQuota = message.SpaceQuota
+	})
+}
+
+// ApplySpaceQuota associates the spaces with the quota. The spaces must
+// belong to the quota organization. As a space can only have a single quota,
+// the spaces are removed from any quota they were previously associated with.
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, message ApplySpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	spaceQuota, err := r.GetSpaceQuota(ctx, authInfo, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = ensureSpacesExist(ctx, userClient, spaceQuota.OrgGUID, message.Spaces); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = r.detachSpaces(ctx, userClient, message.GUID, message.Spaces); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return r.patchSpaceQuota(ctx, authInfo, message.GUID, func(cfSpaceQuota *korifiv1alpha1.CFSpaceQuota) {
+		cfSpaceQuota.Spec.Spaces = tools.Uniq(append(cfSpaceQuota.Spec.Spaces, message.Spaces...))
+	})
+}
+
+func (r *SpaceQuotaRepo) patchSpaceQuota(
+	ctx context.Context,
+	authInfo authorization.Info,
+	guid string,
+	patchFunc func(*korifiv1alpha1.CFSpaceQuota),
+) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err = PatchResource(ctx, userClient, cfSpaceQuota, func() {
+		patchFunc(cfSpaceQuota)
+	}); err != nil {
+		return SpaceQuotaRecord{}, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) detachSpaces(ctx context.Context, userClient client.Client, quotaGUID string, spaceGUIDs []string) error {
+	if len(spaceGUIDs) == 0 {
+		return nil
+	}
+
+	cfSpaceQuotas := &korifiv1alpha1.CFSpaceQuotaList{}
+	if err := userClient.List(ctx, cfSpaceQuotas, client.InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	for _, cfSpaceQuota := range cfSpaceQuotas.Items {
+		if cfSpaceQuota.Name == quotaGUID || !slices.ContainsFunc(cfSpaceQuota.Spec.Spaces, func(space string) bool {
+			return slices.Contains(spaceGUIDs, space)
+		}) {
+			continue
+		}
+
+		if err := PatchResource(ctx, userClient, &cfSpaceQuota, func() {
+			cfSpaceQuota.Spec.Spaces = slices.DeleteFunc(cfSpaceQuota.Spec.Spaces, func(space string) bool {
+				return slices.Contains(spaceGUIDs, space)
+			})
+		}); err != nil {
+			return apierrors.FromK8sError(err, SpaceQuotaResourceType)
+		}
+	}
+
+	return nil
+}
+
+func ensureSpacesExist(ctx context.Context, userClient client.Client, orgGUID string, spaceGUIDs []string) error {
+	missing := []string{}
+	for _, spaceGUID := range spaceGUIDs {
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: orgGUID, Name: spaceGUID}, &korifiv1alpha1.CFSpace{})
+		if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+			missing = append(missing, spaceGUID)
+			continue
+		}
+		if err != nil {
+			return apierrors.FromK8sError(err, SpaceResourceType)
+		}
+	}
+
+	if len(missing) > 0 {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Spaces with guids %v do not exist within the organization, or you do not have access to them", missing))
+	}
+
+	return nil
+}
+
+func toSpaceQuotaRecord(cfSpaceQuota korifiv1alpha1.CFSpaceQuota) SpaceQuotaRecord {
+	return SpaceQuotaRecord{
+		SpaceQuota: cfSpaceQuota.Spec.SpaceQuota,
+		GUID:       cfSpaceQuota.Name,
+		OrgGUID:    cfSpaceQuota.Spec.OrgGUID,
+		Spaces:     cfSpaceQuota.Spec.Spaces,
+		CreatedAt:  cfSpaceQuota.CreationTimestamp.Time,
+		UpdatedAt:  getLastUpdatedTime(&cfSpaceQuota),
+	}
+}
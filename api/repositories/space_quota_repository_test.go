@@ -0,0 +1,285 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SpaceQuotaRepo", func() {
+	var (
+		repo  *repositories.SpaceQuotaRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewSpaceQuotaRepo(userClientFactory, rootNamespace)
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+	})
+
+	createSpaceQuota := func(name string, spaces ...string) *korifiv1alpha1.CFSpaceQuota {
+		cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				SpaceQuota: quotas.SpaceQuota{
+					Name: name,
+					Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				OrgGUID: org.Name,
+				Spaces:  spaces,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+		return cfSpaceQuota
+	}
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			message       repositories.CreateSpaceQuotaMessage
+			spaceQuota    repositories.SpaceQuotaRecord
+			createErr     error
+			existingQuota *korifiv1alpha1.CFSpaceQuota
+		)
+
+		BeforeEach(func() {
+			existingQuota = createSpaceQuota("existing", space.Name)
+			message = repositories.CreateSpaceQuotaMessage{
+				SpaceQuota: quotas.SpaceQuota{
+					Name: "my-quota",
+					Routes: quotas.RouteQuotas{
+						TotalRoutes: tools.PtrTo[int64](10),
+					},
+				},
+				OrgGUID: org.Name,
+				Spaces:  []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, createErr = repo.CreateSpaceQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			})
+
+			It("creates the quota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(spaceQuota.GUID).NotTo(BeEmpty())
+				Expect(spaceQuota.Name).To(Equal("my-quota"))
+				Expect(spaceQuota.OrgGUID).To(Equal(org.Name))
+				Expect(spaceQuota.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(10)))
+				Expect(spaceQuota.Spaces).To(ConsistOf(space.Name))
+
+				cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: spaceQuota.GUID}, cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.OrgGUID).To(Equal(org.Name))
+				Expect(cfSpaceQuota.Spec.Spaces).To(ConsistOf(space.Name))
+			})
+
+			It("removes the space from its previous quota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existingQuota), existingQuota)).To(Succeed())
+				Expect(existingQuota.Spec.Spaces).To(BeEmpty())
+			})
+
+			When("the organization does not exist", func() {
+				BeforeEach(func() {
+					message.OrgGUID = "not-an-org"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the space does not belong to the organization", func() {
+				BeforeEach(func() {
+					otherOrg := createOrgWithCleanup(ctx, uuid.NewString())
+					otherSpace := createSpaceWithCleanup(ctx, otherOrg.Name, uuid.NewString())
+					message.Spaces = []string{otherSpace.Name}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			spaceQuota   repositories.SpaceQuotaRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			cfSpaceQuota = createSpaceQuota("my-quota", space.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, getErr = repo.GetSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("returns the quota", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(spaceQuota.GUID).To(Equal(cfSpaceQuota.Name))
+			Expect(spaceQuota.Name).To(Equal("my-quota"))
+			Expect(spaceQuota.OrgGUID).To(Equal(org.Name))
+			Expect(spaceQuota.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(1024)))
+			Expect(spaceQuota.Spaces).To(ConsistOf(space.Name))
+		})
+
+		When("the quota does not exist", func() {
+			BeforeEach(func() {
+				cfSpaceQuota.Name = "not-a-quota"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			quota1, quota2 *korifiv1alpha1.CFSpaceQuota
+			message        repositories.ListSpaceQuotasMessage
+			spaceQuotas    []repositories.SpaceQuotaRecord
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			quota1 = createSpaceQuota("quota-1", space.Name)
+			quota2 = createSpaceQuota("quota-2")
+			message = repositories.ListSpaceQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			spaceQuotas, err = repo.ListSpaceQuotas(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists all quotas", func() {
+			Expect(spaceQuotas).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+			))
+		})
+
+		When("filtering by space", func() {
+			BeforeEach(func() {
+				message.SpaceGUIDs = []string{space.Name}
+			})
+
+			It("returns the quota applied to the space", func() {
+				Expect(spaceQuotas).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+				))
+			})
+		})
+
+		When("filtering by organization", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{"another-org"}
+			})
+
+			It("returns no quotas", func() {
+				Expect(spaceQuotas).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("UpdateSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			spaceQuota   repositories.SpaceQuotaRecord
+			updateErr    error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			cfSpaceQuota = createSpaceQuota("my-quota", space.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, updateErr = repo.UpdateSpaceQuota(ctx, authInfo, repositories.UpdateSpaceQuotaMessage{
+				GUID: cfSpaceQuota.Name,
+				SpaceQuota: quotas.SpaceQuota{
+					Name: "new-name",
+					Apps: quotas.AppQuotas{TotalInstances: tools.PtrTo[int64](3)},
+				},
+			})
+		})
+
+		It("updates the quota limits without changing the spaces", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(spaceQuota.Name).To(Equal("new-name"))
+			Expect(spaceQuota.Apps.TotalMemoryInMB).To(BeNil())
+			Expect(spaceQuota.Apps.TotalInstances).To(PointTo(BeEquivalentTo(3)))
+			Expect(spaceQuota.OrgGUID).To(Equal(org.Name))
+			Expect(spaceQuota.Spaces).To(ConsistOf(space.Name))
+		})
+	})
+
+	Describe("ApplySpaceQuota", func() {
+		var (
+			otherSpace   *korifiv1alpha1.CFSpace
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			spaceQuota   repositories.SpaceQuotaRecord
+			applyErr     error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+			otherSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+			cfSpaceQuota = createSpaceQuota("my-quota", space.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuota, applyErr = repo.ApplySpaceQuota(ctx, authInfo, repositories.ApplySpaceQuotaMessage{
+				GUID:   cfSpaceQuota.Name,
+				Spaces: []string{otherSpace.Name},
+			})
+		})
+
+		It("adds the space to the quota", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(spaceQuota.Spaces).To(ConsistOf(space.Name, otherSpace.Name))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				otherSpace.Name = "not-a-space"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(applyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+})
@@ -33,21 +33,23 @@ const (
 )
 
 type TaskRecord struct {
-	Name          string
-	GUID          string
-	SpaceGUID     string
-	Command       string
-	AppGUID       string
-	DropletGUID   string
-	Labels        map[string]string
-	Annotations   map[string]string
-	SequenceID    int64
-	CreatedAt     time.Time
-	UpdatedAt     *time.Time
-	MemoryMB      int64
-	DiskMB        int64
-	State         string
-	FailureReason string
+	Name        string
+	GUID        string
+	SpaceGUID   string
+	Command     string
+	AppGUID     string
+	DropletGUID string
+	Labels      map[string]string
+	Annotations map[string]string
+	SequenceID  int64
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	MemoryMB    int64
+	DiskMB      int64
+	// LogRateLimitInBytesPerSecond is -1 when the log rate is unlimited
+	LogRateLimitInBytesPerSecond int64
+	State                        string
+	FailureReason                string
 }
 
 func (t TaskRecord) Relationships() map[string]string {
@@ -57,9 +59,10 @@ func (t TaskRecord) Relationships() map[string]string {
 }
 
 type CreateTaskMessage struct {
-	Command   string
-	SpaceGUID string
-	AppGUID   string
+	Command                      string
+	SpaceGUID                    string
+	AppGUID                      string
+	LogRateLimitInBytesPerSecond *int64
 	Metadata
 }
 
@@ -92,6 +95,7 @@ func (m *CreateTaskMessage) toCFTask() *korifiv1alpha1.CFTask {
 			AppRef: v1.LocalObjectReference{
 				Name: m.AppGUID,
 			},
+			LogRateLimitBytesPerSecond: toLogRateLimitSpec(m.LogRateLimitInBytesPerSecond),
 		},
 	}
 }
@@ -241,20 +245,21 @@ func (r *TaskRepo) PatchTaskMetadata(ctx context.Context, authInfo authorization
 
 func taskToRecord(task korifiv1alpha1.CFTask) TaskRecord {
 	taskRecord := TaskRecord{
-		Name:        task.Name,
-		GUID:        task.Name,
-		SpaceGUID:   task.Namespace,
-		Command:     task.Spec.Command,
-		AppGUID:     task.Spec.AppRef.Name,
-		SequenceID:  task.Status.SequenceID,
-		CreatedAt:   task.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&task),
-		MemoryMB:    task.Status.MemoryMB,
-		DiskMB:      task.Status.DiskQuotaMB,
-		DropletGUID: task.Status.DropletRef.Name,
-		State:       toRecordState(&task),
-		Labels:      task.Labels,
-		Annotations: task.Annotations,
+		Name:                         task.Name,
+		GUID:                         task.Name,
+		SpaceGUID:                    task.Namespace,
+		Command:                      task.Spec.Command,
+		AppGUID:                      task.Spec.AppRef.Name,
+		SequenceID:                   task.Status.SequenceID,
+		CreatedAt:                    task.CreationTimestamp.Time,
+		UpdatedAt:                    getLastUpdatedTime(&task),
+		MemoryMB:                     task.Status.MemoryMB,
+		DiskMB:                       task.Status.DiskQuotaMB,
+		LogRateLimitInBytesPerSecond: toLogRateLimitRecord(task.Spec.LogRateLimitBytesPerSecond),
+		DropletGUID:                  task.Status.DropletRef.Name,
+		State:                        toRecordState(&task),
+		Labels:                       task.Labels,
+		Annotations:                  task.Annotations,
 	}
 
 	failedCond := meta.FindStatusCondition(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType)
@@ -90,9 +90,10 @@ var _ = Describe("TaskRepository", func() {
 			}
 
 			createMessage = repositories.CreateTaskMessage{
-				Command:   "echo 'hello world'",
-				SpaceGUID: space.Name,
-				AppGUID:   cfApp.Name,
+				Command:                      "echo 'hello world'",
+				SpaceGUID:                    space.Name,
+				AppGUID:                      cfApp.Name,
+				LogRateLimitInBytesPerSecond: tools.PtrTo[int64](1024),
 				Metadata: repositories.Metadata{
 					Labels:      map[string]string{"color": "blue"},
 					Annotations: map[string]string{"extra-bugs": "true"},
@@ -137,6 +138,7 @@ var _ = Describe("TaskRepository", func() {
 
 				Expect(taskRecord.MemoryMB).To(BeEquivalentTo(256))
 				Expect(taskRecord.DiskMB).To(BeEquivalentTo(128))
+				Expect(taskRecord.LogRateLimitInBytesPerSecond).To(BeEquivalentTo(1024))
 				Expect(taskRecord.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
 				Expect(taskRecord.State).To(Equal(repositories.TaskStatePending))
 				Expect(taskRecord.Labels).To(Equal(map[string]string{"color": "blue"}))
@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/BooleanCat/go-functional/v2/it/itx"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents;cfserviceusageevents,verbs=list;create;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans;cfserviceofferings;cfservicebrokers,verbs=get
+
+const (
+	AppUsageEventResourceType     = "App Usage Event"
+	ServiceUsageEventResourceType = "Service Usage Event"
+)
+
+//counterfeiter:generate -o fake -fake-name UsageReseeder . UsageReseeder
+type UsageReseeder interface {
+	ReseedAppUsage(context.Context) error
+	ReseedServiceUsage(context.Context) error
+}
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	State                         string
+	PreviousState                 string
+	AppGUID                       string
+	AppName                       string
+	ProcessGUID                   string
+	ProcessType                   string
+	SpaceGUID                     string
+	SpaceName                     string
+	OrganizationGUID              string
+	InstanceCount                 int32
+	PreviousInstanceCount         int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance int64
+	CreatedAt                     time.Time
+	UpdatedAt                     *time.Time
+}
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	State               string
+	ServiceInstanceGUID string
+	ServiceInstanceName string
+	ServiceInstanceType string
+	ServicePlanGUID     string
+	ServicePlanName     string
+	ServiceOfferingGUID string
+	ServiceOfferingName string
+	ServiceBrokerGUID   string
+	ServiceBrokerName   string
+	SpaceGUID           string
+	SpaceName           string
+	OrganizationGUID    string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
+
+type ListAppUsageEventsMessage struct {
+	GUIDs     []string
+	AfterGUID string
+}
+
+func (m *ListAppUsageEventsMessage) matches(e korifiv1alpha1.CFAppUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, e.Name) &&
+		isAfter(e.Name, m.AfterGUID)
+}
+
+type ListServiceUsageEventsMessage struct {
+	GUIDs                []string
+	AfterGUID            string
+	ServiceInstanceTypes []string
+	ServiceOfferingGUIDs []string
+}
+
+func (m *ListServiceUsageEventsMessage) matches(e korifiv1alpha1.CFServiceUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, e.Name) &&
+		isAfter(e.Name, m.AfterGUID) &&
+		tools.EmptyOrContains(m.ServiceInstanceTypes, toServiceInstanceType(e.Spec.ServiceInstanceType)) &&
+		tools.EmptyOrContains(m.ServiceOfferingGUIDs, e.Spec.ServiceOfferingGUID)
+}
+
+// isAfter relies on usage events being named after time ordered UUIDs
+func isAfter(guid, afterGUID string) bool {
+	return afterGUID == "" || guid > afterGUID
+}
+
+// UsageEventRepo serves the usage events the controllers record in the root
+// namespace. Only users allowed to list (and purge) the events in the root
+// namespace, i.e. admins, have access to them.
+type UsageEventRepo struct {
+	userClientFactory authorization.UserClientFactory
+	usageReseeder     UsageReseeder
+	rootNamespace     string
+}
+
+func NewUsageEventRepo(
+	userClientFactory authorization.UserClientFactory,
+	usageReseeder UsageReseeder,
+	rootNamespace string,
+) *UsageEventRepo {
+	return &UsageEventRepo{
+		userClientFactory: userClientFactory,
+		usageReseeder:     usageReseeder,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *UsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppUsageEventRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfAppUsageEvent := &korifiv1alpha1.CFAppUsageEvent{}
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfAppUsageEvent); err != nil {
+		return AppUsageEventRecord{}, fmt.Errorf("failed to get app usage event: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	return toAppUsageEventRecord(*cfAppUsageEvent), nil
+}
+
+func (r *UsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListAppUsageEventsMessage) ([]AppUsageEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfAppUsageEvents := &korifiv1alpha1.CFAppUsageEventList{}
+	if err := userClient.List(ctx, cfAppUsageEvents, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list app usage events: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfAppUsageEvents.Items).Filter(message.matches), toAppUsageEventRecord))
+	slices.SortFunc(records, func(a, b AppUsageEventRecord) int {
+		return strings.Compare(a.GUID, b.GUID)
+	})
+
+	return records, nil
+}
+
+// PurgeAndReseedAppUsageEvents deletes all the app usage events and records a
+// started event for each running process
+func (r *UsageEventRepo) PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err := userClient.DeleteAllOf(ctx, &korifiv1alpha1.CFAppUsageEvent{}, client.InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to purge app usage events: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	if err := r.usageReseeder.ReseedAppUsage(ctx); err != nil {
+		return fmt.Errorf("failed to reseed app usage events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceUsageEventRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceUsageEvent := &korifiv1alpha1.CFServiceUsageEvent{}
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServiceUsageEvent); err != nil {
+		return ServiceUsageEventRecord{}, fmt.Errorf("failed to get service usage event: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	return toServiceUsageEventRecord(*cfServiceUsageEvent), nil
+}
+
+func (r *UsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListServiceUsageEventsMessage) ([]ServiceUsageEventRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceUsageEvents := &korifiv1alpha1.CFServiceUsageEventList{}
+	if err := userClient.List(ctx, cfServiceUsageEvents, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list service usage events: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	records := slices.Collect(it.Map(itx.FromSlice(cfServiceUsageEvents.Items).Filter(message.matches), toServiceUsageEventRecord))
+	slices.SortFunc(records, func(a, b ServiceUsageEventRecord) int {
+		return strings.Compare(a.GUID, b.GUID)
+	})
+
+	return records, nil
+}
+
+// PurgeAndReseedServiceUsageEvents deletes all the service usage events and
+// records a created event for each existing service instance
+func (r *UsageEventRepo) PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err := userClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceUsageEvent{}, client.InNamespace(r.rootNamespace)); err != nil {
+		return fmt.Errorf("failed to purge service usage events: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	if err := r.usageReseeder.ReseedServiceUsage(ctx); err != nil {
+		return fmt.Errorf("failed to reseed service usage events: %w", err)
+	}
+
+	return nil
+}
+
+func toAppUsageEventRecord(cfAppUsageEvent korifiv1alpha1.CFAppUsageEvent) AppUsageEventRecord {
+	return AppUsageEventRecord{
+		GUID:                          cfAppUsageEvent.Name,
+		State:                         cfAppUsageEvent.Spec.State,
+		PreviousState:                 cfAppUsageEvent.Spec.PreviousState,
+		AppGUID:                       cfAppUsageEvent.Spec.AppGUID,
+		AppName:                       cfAppUsageEvent.Spec.AppName,
+		ProcessGUID:                   cfAppUsageEvent.Spec.ProcessGUID,
+		ProcessType:                   cfAppUsageEvent.Spec.ProcessType,
+		SpaceGUID:                     cfAppUsageEvent.Spec.SpaceGUID,
+		SpaceName:                     cfAppUsageEvent.Spec.SpaceName,
+		OrganizationGUID:              cfAppUsageEvent.Spec.OrganizationGUID,
+		InstanceCount:                 cfAppUsageEvent.Spec.InstanceCount,
+		PreviousInstanceCount:         cfAppUsageEvent.Spec.PreviousInstanceCount,
+		MemoryInMBPerInstance:         cfAppUsageEvent.Spec.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: cfAppUsageEvent.Spec.PreviousMemoryInMBPerInstance,
+		CreatedAt:                     cfAppUsageEvent.CreationTimestamp.Time,
+		UpdatedAt:                     getLastUpdatedTime(&cfAppUsageEvent),
+	}
+}
+
+func toServiceUsageEventRecord(cfServiceUsageEvent korifiv1alpha1.CFServiceUsageEvent) ServiceUsageEventRecord {
+	return ServiceUsageEventRecord{
+		GUID:                cfServiceUsageEvent.Name,
+		State:               cfServiceUsageEvent.Spec.State,
+		ServiceInstanceGUID: cfServiceUsageEvent.Spec.ServiceInstanceGUID,
+		ServiceInstanceName: cfServiceUsageEvent.Spec.ServiceInstanceName,
+		ServiceInstanceType: toServiceInstanceType(cfServiceUsageEvent.Spec.ServiceInstanceType),
+		ServicePlanGUID:     cfServiceUsageEvent.Spec.ServicePlanGUID,
+		ServicePlanName:     cfServiceUsageEvent.Spec.ServicePlanName,
+		ServiceOfferingGUID: cfServiceUsageEvent.Spec.ServiceOfferingGUID,
+		ServiceOfferingName: cfServiceUsageEvent.Spec.ServiceOfferingName,
+		ServiceBrokerGUID:   cfServiceUsageEvent.Spec.ServiceBrokerGUID,
+		ServiceBrokerName:   cfServiceUsageEvent.Spec.ServiceBrokerName,
+		SpaceGUID:           cfServiceUsageEvent.Spec.SpaceGUID,
+		SpaceName:           cfServiceUsageEvent.Spec.SpaceName,
+		OrganizationGUID:    cfServiceUsageEvent.Spec.OrganizationGUID,
+		CreatedAt:           cfServiceUsageEvent.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&cfServiceUsageEvent),
+	}
+}
+
+// toServiceInstanceType returns the service instance type as named in the
+// usage events of the CF API
+func toServiceInstanceType(instanceType korifiv1alpha1.InstanceType) string {
+	if instanceType == korifiv1alpha1.UserProvidedType {
+		return "user_provided_service_instance"
+	}
+	return "managed_service_instance"
+}
@@ -0,0 +1,251 @@
+package repositories_test
+
+import (
+	"errors"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("UsageEventRepo", func() {
+	var (
+		repo          *repositories.UsageEventRepo
+		usageReseeder *fake.UsageReseeder
+		appEventGUIDs []string
+		svcEventGUIDs []string
+	)
+
+	createAppUsageEvent := func(spec korifiv1alpha1.CFAppUsageEventSpec) string {
+		GinkgoHelper()
+
+		guid, err := uuid.NewV7()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: guid.String()},
+			Spec:       spec,
+		})).To(Succeed())
+		return guid.String()
+	}
+
+	createServiceUsageEvent := func(spec korifiv1alpha1.CFServiceUsageEventSpec) string {
+		GinkgoHelper()
+
+		guid, err := uuid.NewV7()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: guid.String()},
+			Spec:       spec,
+		})).To(Succeed())
+		return guid.String()
+	}
+
+	BeforeEach(func() {
+		usageReseeder = new(fake.UsageReseeder)
+		repo = repositories.NewUsageEventRepo(userClientFactory, usageReseeder, rootNamespace)
+
+		appEventGUIDs = []string{
+			createAppUsageEvent(korifiv1alpha1.CFAppUsageEventSpec{State: korifiv1alpha1.AppUsageEventStateStarted, AppGUID: "app-1", InstanceCount: 1}),
+			createAppUsageEvent(korifiv1alpha1.CFAppUsageEventSpec{State: korifiv1alpha1.AppUsageEventStateStopped, AppGUID: "app-1", PreviousState: korifiv1alpha1.AppUsageEventStateStarted}),
+		}
+		svcEventGUIDs = []string{
+			createServiceUsageEvent(korifiv1alpha1.CFServiceUsageEventSpec{State: korifiv1alpha1.ServiceUsageEventStateCreated, ServiceInstanceType: korifiv1alpha1.ManagedType, ServiceOfferingGUID: "offering-1"}),
+			createServiceUsageEvent(korifiv1alpha1.CFServiceUsageEventSpec{State: korifiv1alpha1.ServiceUsageEventStateCreated, ServiceInstanceType: korifiv1alpha1.UserProvidedType}),
+		}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFAppUsageEvent{}, client.InNamespace(rootNamespace))).To(Succeed())
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceUsageEvent{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			message   repositories.ListAppUsageEventsMessage
+			appEvents []repositories.AppUsageEventRecord
+			listErr   error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListAppUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			appEvents, listErr = repo.ListAppUsageEvents(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error as the user is not an admin", func() {
+			Expect(listErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the events in chronological order", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(appEvents).To(HaveLen(2))
+				Expect(appEvents[0]).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":          Equal(appEventGUIDs[0]),
+					"State":         Equal(korifiv1alpha1.AppUsageEventStateStarted),
+					"AppGUID":       Equal("app-1"),
+					"InstanceCount": BeEquivalentTo(1),
+				}))
+				Expect(appEvents[1]).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":          Equal(appEventGUIDs[1]),
+					"PreviousState": Equal(korifiv1alpha1.AppUsageEventStateStarted),
+				}))
+			})
+
+			When("filtering by after_guid", func() {
+				BeforeEach(func() {
+					message.AfterGUID = appEventGUIDs[0]
+				})
+
+				It("returns the events recorded after the given one", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(appEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(appEventGUIDs[1])})))
+				})
+			})
+		})
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var getErr error
+
+		JustBeforeEach(func() {
+			_, getErr = repo.GetAppUsageEvent(ctx, authInfo, appEventGUIDs[0])
+		})
+
+		It("returns a forbidden error as the user is not an admin", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedAppUsageEvents", func() {
+		var purgeErr error
+
+		JustBeforeEach(func() {
+			purgeErr = repo.PurgeAndReseedAppUsageEvents(ctx, authInfo)
+		})
+
+		It("returns a forbidden error as the user is not an admin", func() {
+			Expect(purgeErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(usageReseeder.ReseedAppUsageCallCount()).To(BeZero())
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the app usage events and reseeds them", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					cfAppUsageEvents := &korifiv1alpha1.CFAppUsageEventList{}
+					g.Expect(k8sClient.List(ctx, cfAppUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+					g.Expect(cfAppUsageEvents.Items).To(BeEmpty())
+				}).Should(Succeed())
+				Expect(usageReseeder.ReseedAppUsageCallCount()).To(Equal(1))
+			})
+
+			When("reseeding fails", func() {
+				BeforeEach(func() {
+					usageReseeder.ReseedAppUsageReturns(errors.New("reseed-err"))
+				})
+
+				It("returns the error", func() {
+					Expect(purgeErr).To(MatchError(ContainSubstring("reseed-err")))
+				})
+			})
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		var (
+			message   repositories.ListServiceUsageEventsMessage
+			svcEvents []repositories.ServiceUsageEventRecord
+			listErr   error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			message = repositories.ListServiceUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			svcEvents, listErr = repo.ListServiceUsageEvents(ctx, authInfo, message)
+		})
+
+		It("returns the events", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(svcEvents).To(HaveLen(2))
+			Expect(svcEvents[0].GUID).To(Equal(svcEventGUIDs[0]))
+			Expect(svcEvents[0].ServiceInstanceType).To(Equal("managed_service_instance"))
+			Expect(svcEvents[1].ServiceInstanceType).To(Equal("user_provided_service_instance"))
+		})
+
+		When("filtering by service instance type", func() {
+			BeforeEach(func() {
+				message.ServiceInstanceTypes = []string{"user_provided_service_instance"}
+			})
+
+			It("returns the matching events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(svcEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(svcEventGUIDs[1])})))
+			})
+		})
+
+		When("filtering by service offering", func() {
+			BeforeEach(func() {
+				message.ServiceOfferingGUIDs = []string{"offering-1"}
+			})
+
+			It("returns the matching events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(svcEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(svcEventGUIDs[0])})))
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedServiceUsageEvents", func() {
+		var purgeErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = repo.PurgeAndReseedServiceUsageEvents(ctx, authInfo)
+		})
+
+		It("deletes the service usage events and reseeds them", func() {
+			Expect(purgeErr).NotTo(HaveOccurred())
+			Eventually(func(g Gomega) {
+				cfServiceUsageEvents := &korifiv1alpha1.CFServiceUsageEventList{}
+				g.Expect(k8sClient.List(ctx, cfServiceUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+				g.Expect(cfServiceUsageEvents.Items).To(BeEmpty())
+			}).Should(Succeed())
+			Expect(usageReseeder.ReseedServiceUsageCallCount()).To(Equal(1))
+		})
+	})
+})
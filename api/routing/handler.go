@@ -15,9 +15,14 @@ import (
 type Response struct {
 	httpStatus int
 	body       interface{}
+	stream     StreamFunc
 	headers    map[string][]string
 }
 
+// StreamFunc writes the response body incrementally, e.g. for server-sent
+// events. It is called once the status and headers have been written.
+type StreamFunc func(http.ResponseWriter) error
+
 func NewResponse(httpStatus int) *Response {
 	return &Response{
 		httpStatus: httpStatus,
@@ -35,6 +40,11 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+func (r *Response) WithStream(stream StreamFunc) *Response {
+	r.stream = stream
+	return r
+}
+
 //counterfeiter:generate -o fake -fake-name Handler . Handler
 
 type Handler func(r *http.Request) (*Response, error)
@@ -86,6 +96,11 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 		}
 	}
 
+	if response.stream != nil {
+		w.WriteHeader(response.httpStatus)
+		return response.stream(w)
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
@@ -59,6 +59,24 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response is streamed", func() {
+		BeforeEach(func() {
+			response = response.WithHeader("Content-Type", "text/event-stream").WithStream(func(w http.ResponseWriter) error {
+				_, err := w.Write([]byte("data: hello\n\n"))
+				return err
+			})
+		})
+
+		It("writes the status and headers", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+		})
+
+		It("writes the streamed body", func() {
+			Expect(rr).To(HaveHTTPBody("data: hello\n\n"))
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
@@ -44,6 +44,43 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef v1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// The canary deployment currently in progress, if any. While set, the droplet it references runs alongside the
+	// current droplet and receives a share of the traffic of the app routes
+	// +optional
+	Canary *CFAppCanary `json:"canary,omitempty"`
+
+	// Whether users are allowed to SSH into the app instances. Defaults to true.
+	// +optional
+	EnableSSH *bool `json:"enableSSH,omitempty"`
+}
+
+// CFAppCanary describes a canary deployment of a CFApp
+type CFAppCanary struct {
+	// A reference to the CFBuild being deployed. The CFBuild must be in the same namespace.
+	DropletRef v1.LocalObjectReference `json:"dropletRef"`
+
+	// The steps of the deployment. Each step is paused until it is continued.
+	// +kubebuilder:validation:MinItems=1
+	Steps []CanaryStep `json:"steps"`
+
+	// The index of the step currently running
+	// +kubebuilder:validation:Minimum=0
+	CurrentStep int `json:"currentStep"`
+}
+
+// CanaryStep describes a single step of a canary deployment
+type CanaryStep struct {
+	// The number of instances of every process running the new droplet
+	// +kubebuilder:validation:Minimum=1
+	Instances int32 `json:"instances"`
+
+	// The percentage of the route traffic sent to the new droplet. When not set the traffic is split in proportion
+	// to the number of instances
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 // AppState defines the desired state of CFApp.
@@ -113,3 +150,17 @@ func (a CFApp) UniqueName() string {
 func (a CFApp) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("App with the name '%s' already exists.", a.Spec.DisplayName)
 }
+
+// SSHEnabled returns whether users are allowed to SSH into the app instances
+func (a CFApp) SSHEnabled() bool {
+	return a.Spec.EnableSSH == nil || *a.Spec.EnableSSH
+}
+
+func (c CFAppCanary) Step() CanaryStep {
+	return c.Steps[min(c.CurrentStep, len(c.Steps)-1)]
+}
+
+// CanaryWorkloadGUID returns the GUID of the workload running the canary instances of the given process
+func CanaryWorkloadGUID(processGUID string) string {
+	return processGUID + "-canary"
+}
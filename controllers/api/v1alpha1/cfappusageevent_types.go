@@ -0,0 +1,111 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AppUsageEventStateStarted = "STARTED"
+	AppUsageEventStateStopped = "STOPPED"
+
+	// LatestUsageEventLabelKey marks the last usage event recorded for a
+	// process or a service instance
+	LatestUsageEventLabelKey = "korifi.cloudfoundry.org/latest-usage-event"
+)
+
+// CFAppUsageEventSpec defines the desired state of CFAppUsageEvent. App usage
+// events are immutable records of a process starting, stopping or being scaled
+// while running. They are stored in the root namespace and named after a time
+// ordered (version 7) UUID, so that sorting them by name sorts them by
+// occurrence.
+type CFAppUsageEventSpec struct {
+	// The state of the process after the event, i.e. STARTED or STOPPED
+	//+kubebuilder:validation:Enum=STARTED;STOPPED
+	State string `json:"state"`
+
+	// The state of the process before the event, if any
+	//+kubebuilder:validation:Optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	AppGUID string `json:"appGUID"`
+	//+kubebuilder:validation:Optional
+	AppName string `json:"appName,omitempty"`
+
+	ProcessGUID string `json:"processGUID"`
+	ProcessType string `json:"processType"`
+
+	SpaceGUID string `json:"spaceGUID"`
+	//+kubebuilder:validation:Optional
+	SpaceName string `json:"spaceName,omitempty"`
+	//+kubebuilder:validation:Optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	InstanceCount int32 `json:"instanceCount"`
+	//+kubebuilder:validation:Optional
+	PreviousInstanceCount int32 `json:"previousInstanceCount,omitempty"`
+
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+	//+kubebuilder:validation:Optional
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance,omitempty"`
+}
+
+// CFAppUsageEventStatus defines the observed state of CFAppUsageEvent
+type CFAppUsageEventStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFAppUsageEvent that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appName`
+//+kubebuilder:printcolumn:name="Process Type",type=string,JSONPath=`.spec.processType`
+//+kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.spec.instanceCount`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEvent is the Schema for the cfappusageevents API
+type CFAppUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFAppUsageEventSpec   `json:"spec,omitempty"`
+	Status CFAppUsageEventStatus `json:"status,omitempty"`
+}
+
+func (e *CFAppUsageEvent) StatusConditions() *[]metav1.Condition {
+	return &e.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEventList contains a list of CFAppUsageEvent
+type CFAppUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppUsageEvent{}, &CFAppUsageEventList{})
+}
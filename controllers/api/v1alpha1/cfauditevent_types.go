@@ -0,0 +1,105 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFAuditEventSpec defines the desired state of CFAuditEvent. Audit events
+// are immutable records of a mutation performed through the CF API. They are
+// stored in the namespace of the space they relate to, or in the organization
+// or root namespace for events that do not relate to a space.
+type CFAuditEventSpec struct {
+	// The CF event type, e.g. audit.app.start
+	Type string `json:"type"`
+
+	// The subject that performed the operation
+	Actor AuditEventActor `json:"actor"`
+
+	// The resource the operation was performed on
+	Target AuditEventTarget `json:"target"`
+
+	// The GUID of the space of the target, if any
+	//+kubebuilder:validation:Optional
+	SpaceGUID string `json:"spaceGUID,omitempty"`
+
+	// The GUID of the organization of the target, if any
+	//+kubebuilder:validation:Optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+}
+
+type AuditEventActor struct {
+	GUID string `json:"guid"`
+	// The kind of the actor, e.g. user
+	Type string `json:"type"`
+	//+kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+type AuditEventTarget struct {
+	GUID string `json:"guid"`
+	// The kind of the target, e.g. app
+	Type string `json:"type"`
+	//+kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// CFAuditEventStatus defines the observed state of CFAuditEvent
+type CFAuditEventStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFAuditEvent that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Actor",type=string,JSONPath=`.spec.actor.name`
+//+kubebuilder:printcolumn:name="Target Type",type=string,JSONPath=`.spec.target.type`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.guid`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEvent is the Schema for the cfauditevents API
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFAuditEventSpec   `json:"spec,omitempty"`
+	Status CFAuditEventStatus `json:"status,omitempty"`
+}
+
+func (e *CFAuditEvent) StatusConditions() *[]metav1.Condition {
+	return &e.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
@@ -35,6 +35,22 @@ type CFBuildSpec struct {
 
 	// Specifies the buildpacks and stack for the build
 	Lifecycle Lifecycle `json:"lifecycle"`
+
+	// UploadedDroplet is set for builds whose droplet has been built outside
+	// the cluster and is uploaded directly instead of being staged from a package
+	//+kubebuilder:validation:Optional
+	UploadedDroplet *UploadedDroplet `json:"uploadedDroplet,omitempty"`
+}
+
+// UploadedDroplet describes a pre-built droplet
+type UploadedDroplet struct {
+	// The Container registry image the droplet has been uploaded to. Empty until the droplet bits are uploaded
+	//+kubebuilder:validation:Optional
+	Registry Registry `json:"registry"`
+
+	// The process types and associated start commands for the Droplet
+	//+kubebuilder:validation:Optional
+	ProcessTypes []ProcessType `json:"processTypes"`
 }
 
 // CFBuildStatus defines the observed state of CFBuild
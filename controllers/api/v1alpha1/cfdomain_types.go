@@ -28,6 +28,18 @@ const (
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+
+	// Whether the domain is only reachable from within the cluster. Routes on
+	// internal domains are not exposed through the gateway; they resolve to a
+	// ClusterIP service in the namespace named after the domain GUID instead
+	// +kubebuilder:validation:Optional
+	Internal bool `json:"internal,omitempty"`
+
+	// The name of the router group of TCP domains. Routes on TCP domains are
+	// identified by a port reserved in the router group instead of a host and
+	// path, and are exposed through the matching TCP listener of the gateway
+	// +kubebuilder:validation:Optional
+	RouterGroup string `json:"routerGroup,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
@@ -43,6 +55,8 @@ type CFDomainStatus struct {
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Namespaced
 //+kubebuilder:printcolumn:name="Domain Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Internal",type=boolean,JSONPath=`.spec.internal`
+//+kubebuilder:printcolumn:name="Router Group",type=string,JSONPath=`.spec.routerGroup`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -0,0 +1,62 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RunningEnvVarGroupName = "running"
+	StagingEnvVarGroupName = "staging"
+)
+
+// CFEnvVarGroupSpec defines the desired state of CFEnvVarGroup
+type CFEnvVarGroupSpec struct {
+	// The environment variables injected into every app. Variables set on
+	// the app itself take precedence.
+	//+kubebuilder:validation:Optional
+	Env map[string]string `json:"env,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFEnvVarGroup is the Schema for the cfenvvargroups API. The running and
+// staging environment variable groups live in the root namespace and are
+// named after the group.
+type CFEnvVarGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFEnvVarGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFEnvVarGroupList contains a list of CFEnvVarGroup
+type CFEnvVarGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFEnvVarGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFEnvVarGroup{}, &CFEnvVarGroupList{})
+}
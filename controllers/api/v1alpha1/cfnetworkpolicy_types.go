@@ -0,0 +1,106 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NetworkPolicyProtocolTCP = "tcp"
+	NetworkPolicyProtocolUDP = "udp"
+)
+
+// CFNetworkPolicySpec defines the desired state of CFNetworkPolicy. The
+// policy lives in the namespace of the destination app.
+type CFNetworkPolicySpec struct {
+	// The app that is allowed to connect to the destination app
+	Source NetworkPolicySource `json:"source"`
+
+	// The app accepting the connections. It must be in the same namespace as the policy
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+type NetworkPolicySource struct {
+	// The GUID of the source app
+	AppGUID string `json:"appGUID"`
+	// The GUID of the space of the source app
+	SpaceGUID string `json:"spaceGUID"`
+}
+
+type NetworkPolicyDestination struct {
+	// The GUID of the destination app
+	AppGUID string `json:"appGUID"`
+	// +kubebuilder:validation:Enum=tcp;udp
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+type NetworkPolicyPorts struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Start int32 `json:"start"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	End int32 `json:"end"`
+}
+
+// CFNetworkPolicyStatus defines the observed state of CFNetworkPolicy
+type CFNetworkPolicyStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFNetworkPolicy that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.source.appGUID`
+//+kubebuilder:printcolumn:name="Destination",type=string,JSONPath=`.spec.destination.appGUID`
+//+kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.spec.destination.protocol`
+//+kubebuilder:printcolumn:name="Start Port",type=integer,JSONPath=`.spec.destination.ports.start`
+//+kubebuilder:printcolumn:name="End Port",type=integer,JSONPath=`.spec.destination.ports.end`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFNetworkPolicy is the Schema for the cfnetworkpolicies API
+type CFNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFNetworkPolicySpec   `json:"spec,omitempty"`
+	Status CFNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+func (p *CFNetworkPolicy) StatusConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFNetworkPolicyList contains a list of CFNetworkPolicy
+type CFNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFNetworkPolicy{}, &CFNetworkPolicyList{})
+}
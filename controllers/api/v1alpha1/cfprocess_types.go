@@ -51,6 +51,10 @@ type CFProcessSpec struct {
 	// The disk limit in MiB
 	DiskQuotaMB int64 `json:"diskQuotaMB"`
 
+	// The log rate limit of each instance in bytes per second. Unlimited when not set
+	// +optional
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
+
 	// The ports to expose
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
@@ -90,6 +94,10 @@ type CFProcessStatus struct {
 
 	//+kubebuilder:validation:Optional
 	ActualInstances int32 `json:"actualInstances"`
+
+	// CanaryInstances is the number of running instances of the canary deployment of the app, if any
+	//+kubebuilder:validation:Optional
+	CanaryInstances int32 `json:"canaryInstances,omitempty"`
 }
 
 //+kubebuilder:object:root=true
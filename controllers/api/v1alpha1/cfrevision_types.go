@@ -0,0 +1,77 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFRevisionSpec defines the desired state of CFRevision. A revision is a
+// snapshot of the droplet, environment variables and process commands an app
+// has been configured with.
+type CFRevisionSpec struct {
+	// The CFApp this revision belongs to. Must be in the same namespace
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The version of the revision. The first revision of an app has version 1
+	Version int `json:"version"`
+
+	// The droplet (CFBuild) of the app. Must be in the same namespace
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The name of the Secret holding a copy of the app environment variables
+	//+kubebuilder:validation:Optional
+	EnvSecretName string `json:"envSecretName,omitempty"`
+
+	// The custom start command of each process type. An empty command means
+	// that the command detected by the build is used
+	//+kubebuilder:validation:Optional
+	ProcessCommands map[string]string `json:"processCommands,omitempty"`
+
+	// A human readable description of what changed since the previous revision
+	//+kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="AppGUID",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFRevision is the Schema for the cfrevisions API
+type CFRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFRevisionList contains a list of CFRevision
+type CFRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFRevision{}, &CFRevisionList{})
+}
@@ -27,6 +27,9 @@ import (
 const (
 	// Deprecated. Used for removing leftover finalizers
 	CFRouteFinalizerName = "cfRoute.korifi.cloudfoundry.org"
+
+	ProtocolHTTP Protocol = "http"
+	ProtocolTCP  Protocol = "tcp"
 )
 
 // Destination defines a target for a CFRoute, does not carry meaning outside of a CF context
@@ -38,12 +41,13 @@ type Destination struct {
 	// droplet
 	//+kubebuilder:validation:Optional
 	Port *int32 `json:"port,omitempty"`
-	// A required reference to the CFApp that will receive traffic. The CFApp must be in the same namespace
-	AppRef v1.LocalObjectReference `json:"appRef"`
+	// A required reference to the CFApp that will receive traffic. The namespace of the CFApp is optional
+	// and defaults to the namespace of the CFRoute. Otherwise, it must be one of the shared spaces of the CFRoute
+	AppRef v1.ObjectReference `json:"appRef"`
 	// The process type on the CFApp app which will receive traffic
 	ProcessType string `json:"processType"`
-	// Protocol is optional, when set must be "http1"
-	// +kubebuilder:validation:Enum=http1
+	// Protocol is optional, when set must be "http1", or "tcp" for destinations of tcp routes
+	// +kubebuilder:validation:Enum=http1;tcp
 	//+kubebuilder:validation:Optional
 	Protocol *string `json:"protocol,omitempty"`
 }
@@ -59,12 +63,18 @@ type CFRouteSpec struct {
 	Host string `json:"host,omitempty"`
 	// Path is optional, defaults to empty
 	Path string `json:"path,omitempty"`
-	// Protocol is optional and defaults to http. Currently only http is supported
+	// Protocol is optional and defaults to http. Routes on domains with a router group must use tcp
 	Protocol Protocol `json:"protocol,omitempty"`
+	// The port reserved for the route in the router group of its domain. Port is only set on tcp routes
+	//+kubebuilder:validation:Optional
+	Port *int32 `json:"port,omitempty"`
 	// A reference to the CFDomain this CFRoute is assigned to, including name and namespace
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
 	Destinations []Destination `json:"destinations,omitempty"`
+	// The GUIDs of the spaces the route is shared with. Apps in these spaces can be destinations of the route
+	//+kubebuilder:validation:Optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // CFRouteStatus defines the observed state of CFRoute
@@ -115,10 +125,20 @@ func init() {
 }
 
 func (r CFRoute) UniqueName() string {
+	// all router groups share the listeners of the gateway, so ports must be
+	// unique across domains
+	if r.Spec.Protocol == ProtocolTCP && r.Spec.Port != nil {
+		return fmt.Sprintf("tcp::%d", *r.Spec.Port)
+	}
+
 	return strings.Join([]string{strings.ToLower(r.Spec.Host), r.Spec.DomainRef.Namespace, r.Spec.DomainRef.Name, r.Spec.Path}, "::")
 }
 
 func (r CFRoute) UniqueValidationErrorMessage() string {
+	if r.Spec.Protocol == ProtocolTCP && r.Spec.Port != nil {
+		return fmt.Sprintf("Port %d is not available. Try a different port or use a different domain.", *r.Spec.Port)
+	}
+
 	pathDetails := ""
 
 	if r.Spec.Path != "" {
@@ -128,6 +148,15 @@ func (r CFRoute) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("Route already exists with host '%s'%s for domain '%s'.", r.Spec.Host, pathDetails, r.Status.FQDN)
 }
 
+// AppNamespace returns the namespace of the destination app
+func (d Destination) AppNamespace(routeNamespace string) string {
+	if d.AppRef.Namespace == "" {
+		return routeNamespace
+	}
+
+	return d.AppRef.Namespace
+}
+
 func (r *CFRoute) StatusConditions() *[]metav1.Condition {
 	return &r.Status.Conditions
 }
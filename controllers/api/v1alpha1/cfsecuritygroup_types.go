@@ -0,0 +1,102 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecurityGroupRulesEnforcedConditionType is False when some of the rules
+	// of the security group cannot be enforced by network policies, e.g. icmp
+	// rules, in which case the rules are not applied to any space
+	SecurityGroupRulesEnforcedConditionType = "RulesEnforced"
+)
+
+// CFSecurityGroupSpec defines the desired state of CFSecurityGroup
+type CFSecurityGroupSpec struct {
+	// The mutable, user-friendly name of the security group. Unlike metadata.name, the user can change this field
+	DisplayName string `json:"displayName"`
+
+	// The egress rules of the security group
+	// +kubebuilder:validation:Optional
+	Rules []securitygroups.Rule `json:"rules,omitempty"`
+
+	// Whether the security group applies to the workloads of every space
+	// +kubebuilder:validation:Optional
+	GloballyEnabled securitygroups.Workloads `json:"globallyEnabled"`
+
+	// The workloads the security group applies to, keyed by space GUID
+	// +kubebuilder:validation:Optional
+	Spaces map[string]securitygroups.Workloads `json:"spaces,omitempty"`
+}
+
+// CFSecurityGroupStatus defines the observed state of CFSecurityGroup
+type CFSecurityGroupStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFSecurityGroup that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Running",type=boolean,JSONPath=`.spec.globallyEnabled.running`
+//+kubebuilder:printcolumn:name="Staging",type=boolean,JSONPath=`.spec.globallyEnabled.staging`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFSecurityGroup is the Schema for the cfsecuritygroups API
+type CFSecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFSecurityGroupSpec   `json:"spec,omitempty"`
+	Status CFSecurityGroupStatus `json:"status,omitempty"`
+}
+
+func (g *CFSecurityGroup) StatusConditions() *[]metav1.Condition {
+	return &g.Status.Conditions
+}
+
+// AppliesTo returns whether the security group applies to the running or
+// staging workloads of the space
+func (g *CFSecurityGroup) AppliesTo(spaceGUID string) securitygroups.Workloads {
+	bound := g.Spec.Spaces[spaceGUID]
+
+	return securitygroups.Workloads{
+		Running: g.Spec.GloballyEnabled.Running || bound.Running,
+		Staging: g.Spec.GloballyEnabled.Staging || bound.Staging,
+	}
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFSecurityGroupList contains a list of CFSecurityGroup
+type CFSecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSecurityGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSecurityGroup{}, &CFSecurityGroupList{})
+}
@@ -31,6 +31,10 @@ const (
 	ServiceInstanceTypeAnnotationKey = "korifi.cloudfoundry.org/service-instance-type"
 	PlanGUIDLabelKey                 = "korifi.cloudfoundry.org/plan-guid"
 
+	CFServiceBindingTypeApp   = "app"
+	CFServiceBindingTypeKey   = "key"
+	CFServiceBindingTypeRoute = "route"
+
 	ServiceBindingGUIDLabel           = "korifi.cloudfoundry.org/service-binding-guid"
 	ServiceCredentialBindingTypeLabel = "korifi.cloudfoundry.org/service-credential-binding-type"
 	CFServiceBindingFinalizerName     = "cfServiceBinding.korifi.cloudfoundry.org"
@@ -41,11 +45,29 @@ type CFServiceBindingSpec struct {
 	// The mutable, user-friendly name of the service binding. Unlike metadata.name, the user can change this field
 	DisplayName *string `json:"displayName,omitempty"`
 
-	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance
+	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance.
+	// The namespace of the service is optional and defaults to the namespace of the binding. Otherwise,
+	// the service instance must be shared with the namespace of the binding
 	Service v1.ObjectReference `json:"service"`
 
-	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace
+	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace.
+	// Only set for bindings of type app
+	// +optional
 	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// A reference to the CFRoute whose traffic is forwarded through the route service. The CFRoute must be in
+	// the same namespace. Only set for bindings of type route
+	// +optional
+	RouteRef v1.LocalObjectReference `json:"routeRef,omitempty"`
+
+	// The type of the binding. Bindings of type app bind the service to an
+	// app, bindings of type key (aka service keys) only provide credentials
+	// for the service and bindings of type route front a route with the
+	// route service provided by the service broker
+	// +kubebuilder:validation:Enum=app;key;route
+	// +kubebuilder:default=app
+	// +optional
+	Type string `json:"type,omitempty"`
 }
 
 // CFServiceBindingStatus defines the observed state of CFServiceBinding
@@ -79,6 +101,11 @@ type CFServiceBindingStatus struct {
 	// +optional
 	Credentials v1.LocalObjectReference `json:"credentials"`
 
+	// The URL of the route service returned by the broker when binding to a
+	// route. Only set for bindings of type route
+	// +optional
+	RouteServiceURL string `json:"routeServiceURL,omitempty"`
+
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -116,14 +143,64 @@ func (b *CFServiceBinding) StatusConditions() *[]metav1.Condition {
 	return &b.Status.Conditions
 }
 
+// IsKey returns true if the binding is a service key
+func (b CFServiceBinding) IsKey() bool {
+	return b.Spec.Type == CFServiceBindingTypeKey
+}
+
+// IsRoute returns true if the binding binds a route to a route service
+func (b CFServiceBinding) IsRoute() bool {
+	return b.Spec.Type == CFServiceBindingTypeRoute
+}
+
+// ServiceInstanceNamespace returns the namespace of the bound service instance
+func (b CFServiceBinding) ServiceInstanceNamespace() string {
+	if b.Spec.Service.Namespace == "" {
+		return b.Namespace
+	}
+
+	return b.Spec.Service.Namespace
+}
+
+// IsToSharedServiceInstance returns true when the bound service instance is
+// in another namespace and has been shared with the namespace of the binding
+func (b CFServiceBinding) IsToSharedServiceInstance() bool {
+	return b.ServiceInstanceNamespace() != b.Namespace
+}
+
 func (b CFServiceBinding) UniqueName() string {
+	// a route can only be bound to a single route service
+	if b.IsRoute() {
+		return fmt.Sprintf("rb::%s", b.Spec.RouteRef.Name)
+	}
+
+	if b.IsKey() {
+		return fmt.Sprintf("sk::%s::%s::%s", b.displayName(), b.Spec.Service.Namespace, b.Spec.Service.Name)
+	}
+
 	return fmt.Sprintf("sb::%s::%s::%s", b.Spec.AppRef.Name, b.Spec.Service.Namespace, b.Spec.Service.Name)
 }
 
 func (b CFServiceBinding) UniqueValidationErrorMessage() string {
+	if b.IsRoute() {
+		return "A route may only be bound to a single route service instance"
+	}
+
+	if b.IsKey() {
+		return fmt.Sprintf("The binding name is invalid. Key binding names must be unique. The service instance already has a key binding with name '%s'.", b.displayName())
+	}
+
 	return fmt.Sprintf("Service binding already exists: App: %s Service Instance: %s", b.Spec.AppRef.Name, b.Spec.Service.Name)
 }
 
+func (b CFServiceBinding) displayName() string {
+	if b.Spec.DisplayName == nil {
+		return ""
+	}
+
+	return *b.Spec.DisplayName
+}
+
 func init() {
 	SchemeBuilder.Register(&CFServiceBinding{}, &CFServiceBindingList{})
 }
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/model/services"
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +33,7 @@ const (
 	CFServiceInstanceFinalizerName = "cfServiceInstance.korifi.cloudfoundry.org"
 
 	ProvisioningFailedCondition   = "ProvisioningFailed"
+	UpdateFailedCondition         = "UpdateFailed"
 	DeprovisioningFailedCondition = "DeprovisioningFailed"
 )
 
@@ -58,6 +60,11 @@ type CFServiceInstanceSpec struct {
 	PlanGUID string `json:"plan_guid"`
 
 	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+
+	// The GUIDs of the spaces the service instance is shared with. Apps in
+	// these spaces can be bound to the service instance
+	//+kubebuilder:validation:Optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
@@ -84,6 +91,24 @@ type CFServiceInstanceStatus struct {
 
 	//+kubebuilder:validation:Optional
 	LastOperation services.LastOperation `json:"last_operation"`
+
+	// The GUID of the plan the broker has provisioned or last updated the
+	// managed service instance with. The instance is updated by the broker
+	// whenever it differs from spec.plan_guid
+	//+kubebuilder:validation:Optional
+	ProvisionedPlanGUID string `json:"provisionedPlanGUID,omitempty"`
+
+	// The checksum of the parameters the broker has provisioned or last
+	// updated the managed service instance with. The instance is updated by
+	// the broker whenever it differs from the checksum of spec.parameters
+	//+kubebuilder:validation:Optional
+	ProvisionedParametersChecksum string `json:"provisionedParametersChecksum,omitempty"`
+
+	// UpdateOperation is the operation ID returned by the OSBAPI broker for
+	// an asynchronous update of the managed service instance that is still
+	// in progress
+	//+kubebuilder:validation:Optional
+	UpdateOperation string `json:"updateOperation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -111,6 +136,11 @@ func (si CFServiceInstance) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("The service instance name is taken: %s", si.Spec.DisplayName)
 }
 
+// IsSharedWith returns true when the service instance is shared with the space
+func (si CFServiceInstance) IsSharedWith(spaceGUID string) bool {
+	return slices.Contains(si.Spec.SharedSpaces, spaceGUID)
+}
+
 func (si *CFServiceInstance) StatusConditions() *[]metav1.Condition {
 	return &si.Status.Conditions
 }
@@ -0,0 +1,108 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ServiceUsageEventStateCreated = "CREATED"
+	ServiceUsageEventStateDeleted = "DELETED"
+
+	CFServiceInstanceGUIDLabelKey = "korifi.cloudfoundry.org/service-instance-guid"
+)
+
+// CFServiceUsageEventSpec defines the desired state of CFServiceUsageEvent.
+// Service usage events are immutable records of a service instance being
+// created or deleted. Like app usage events, they are stored in the root
+// namespace and named after a time ordered (version 7) UUID.
+type CFServiceUsageEventSpec struct {
+	// The state of the service instance after the event, i.e. CREATED or DELETED
+	//+kubebuilder:validation:Enum=CREATED;DELETED
+	State string `json:"state"`
+
+	ServiceInstanceGUID string `json:"serviceInstanceGUID"`
+	//+kubebuilder:validation:Optional
+	ServiceInstanceName string `json:"serviceInstanceName,omitempty"`
+	// The type of the service instance, i.e. managed or user-provided
+	ServiceInstanceType InstanceType `json:"serviceInstanceType"`
+
+	SpaceGUID string `json:"spaceGUID"`
+	//+kubebuilder:validation:Optional
+	SpaceName string `json:"spaceName,omitempty"`
+	//+kubebuilder:validation:Optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// The plan, offering and broker of managed service instances
+	//+kubebuilder:validation:Optional
+	ServicePlanGUID string `json:"servicePlanGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServicePlanName string `json:"servicePlanName,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServiceOfferingGUID string `json:"serviceOfferingGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServiceOfferingName string `json:"serviceOfferingName,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServiceBrokerGUID string `json:"serviceBrokerGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServiceBrokerName string `json:"serviceBrokerName,omitempty"`
+}
+
+// CFServiceUsageEventStatus defines the observed state of CFServiceUsageEvent
+type CFServiceUsageEventStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFServiceUsageEvent that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Service Instance",type=string,JSONPath=`.spec.serviceInstanceName`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.serviceInstanceType`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEvent is the Schema for the cfserviceusageevents API
+type CFServiceUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFServiceUsageEventSpec   `json:"spec,omitempty"`
+	Status CFServiceUsageEventStatus `json:"status,omitempty"`
+}
+
+func (e *CFServiceUsageEvent) StatusConditions() *[]metav1.Condition {
+	return &e.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEventList contains a list of CFServiceUsageEvent
+type CFServiceUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceUsageEvent{}, &CFServiceUsageEventList{})
+}
@@ -38,6 +38,9 @@ type CFTaskSpec struct {
 	// A boolean describing whether the CFTask has been canceled
 	// +optional
 	Canceled bool `json:"canceled"`
+	// The log rate limit of the task in bytes per second. Unlimited when not set
+	// +optional
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
 }
 
 // CFTaskStatus defines the observed state of CFTask
@@ -11,5 +11,6 @@ const (
 	PortHealthCheckType    HealthCheckType = "port"
 	ProcessHealthCheckType HealthCheckType = "process"
 
-	StatusConditionReady = "Ready"
+	StatusConditionReady           = "Ready"
+	StatusConditionCanaryStepReady = "CanaryStepReady"
 )
@@ -6,23 +6,42 @@ import (
 
 const (
 	VersionLabelKey = "korifi.cloudfoundry.org/version"
+	GUIDLabelKey    = "korifi.cloudfoundry.org/guid"
 
-	CFAppGUIDLabelKey        = "korifi.cloudfoundry.org/app-guid"
-	CFAppRevisionKey         = "korifi.cloudfoundry.org/app-rev"
-	CFAppLastStopRevisionKey = "korifi.cloudfoundry.org/last-stop-app-rev"
-	CFAppRevisionKeyDefault  = "0"
-	CFPackageGUIDLabelKey    = "korifi.cloudfoundry.org/package-guid"
-	CFBuildGUIDLabelKey      = "korifi.cloudfoundry.org/build-guid"
-	CFProcessGUIDLabelKey    = "korifi.cloudfoundry.org/process-guid"
-	CFProcessTypeLabelKey    = "korifi.cloudfoundry.org/process-type"
-	CFDomainGUIDLabelKey     = "korifi.cloudfoundry.org/domain-guid"
-	CFRouteGUIDLabelKey      = "korifi.cloudfoundry.org/route-guid"
-	CFTaskGUIDLabelKey       = "korifi.cloudfoundry.org/task-guid"
+	CFAppGUIDLabelKey          = "korifi.cloudfoundry.org/app-guid"
+	CFAppRevisionKey           = "korifi.cloudfoundry.org/app-rev"
+	CFAppLastStopRevisionKey   = "korifi.cloudfoundry.org/last-stop-app-rev"
+	CFAppDeploymentStrategyKey = "korifi.cloudfoundry.org/deployment-strategy"
+	CFAppDeploymentCanceledKey = "korifi.cloudfoundry.org/deployment-canceled"
+	CFAppPreviousDropletKey    = "korifi.cloudfoundry.org/previous-droplet-guid"
+	CFAppPreviousRevisionKey   = "korifi.cloudfoundry.org/previous-app-rev"
+	CFAppLoggedStateKey        = "korifi.cloudfoundry.org/logged-state"
+	CFAppRevisionKeyDefault    = "0"
+	CFPackageGUIDLabelKey      = "korifi.cloudfoundry.org/package-guid"
+	CFBuildGUIDLabelKey        = "korifi.cloudfoundry.org/build-guid"
+	CFProcessGUIDLabelKey      = "korifi.cloudfoundry.org/process-guid"
+	CFProcessTypeLabelKey      = "korifi.cloudfoundry.org/process-type"
+	CFDomainGUIDLabelKey       = "korifi.cloudfoundry.org/domain-guid"
+	CFRouteGUIDLabelKey        = "korifi.cloudfoundry.org/route-guid"
+	CFTaskGUIDLabelKey         = "korifi.cloudfoundry.org/task-guid"
+	BuildWorkloadLabelKey      = "korifi.cloudfoundry.org/build-workload-name"
 
 	SpaceGUIDKey = "korifi.cloudfoundry.org/space-guid"
 
 	PodIndexLabelKey = "apps.kubernetes.io/pod-index"
 
+	// App log events are k8s events that are shown in the logs of the app
+	// they are annotated with. They are all reported by the same component, so
+	// that they can be listed efficiently.
+	AppLogEventComponent          = "korifi-app-logs"
+	AppLogSourceTypeAnnotationKey = "korifi.cloudfoundry.org/log-source-type"
+	AppLogInstanceIDAnnotationKey = "korifi.cloudfoundry.org/log-instance-id"
+
+	AppLogSourceTypeAPI  = "API"
+	AppLogSourceTypeCell = "CELL"
+	AppLogSourceTypeRTR  = "RTR"
+	AppLogSourceTypeSTG  = "STG"
+
 	StagingConditionType   = "Staging"
 	SucceededConditionType = "Succeeded"
 
@@ -98,14 +98,17 @@ var _ = BeforeSuite(func() {
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
+	quotaValidator := validation.NewQuotaValidator(uncachedClient, namespace, 1024)
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		quotaValidator,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(routes.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routes.RouteEntityType)),
+		quotaValidator,
 		namespace,
 		uncachedClient,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -20,6 +20,8 @@ type ControllerConfig struct {
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	AuditEventTTL                    string             `yaml:"auditEventTTL"`
+	UsageEventTTL                    string             `yaml:"usageEventTTL"`
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
@@ -62,10 +64,12 @@ type Networking struct {
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL             = 30 * 24 * time.Hour
+	defaultAuditEventTTL       = 31 * 24 * time.Hour
+	defaultUsageEventTTL       = 31 * 24 * time.Hour
+	defaultTimeout       int32 = 60
+	defaultJobTTL              = 24 * time.Hour
+	defaultBuildCacheMB        = 2048
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -107,6 +111,22 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 	return tools.ParseDuration(c.TaskTTL)
 }
 
+func (c ControllerConfig) ParseAuditEventTTL() (time.Duration, error) {
+	if c.AuditEventTTL == "" {
+		return defaultAuditEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.AuditEventTTL)
+}
+
+func (c ControllerConfig) ParseUsageEventTTL() (time.Duration, error) {
+	if c.UsageEventTTL == "" {
+		return defaultUsageEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.UsageEventTTL)
+}
+
 func (c ControllerConfig) ParseBuilderReadinessTimeout() (time.Duration, error) {
 	return tools.ParseDuration(c.BuilderReadinessTimeout)
 }
@@ -188,6 +188,98 @@ var _ = Describe("ParseTaskTTL", func() {
 	})
 })
 
+var _ = Describe("ParseAuditEventTTL", func() {
+	var (
+		auditEventTTLString string
+		auditEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		auditEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AuditEventTTL: auditEventTTLString,
+		}
+
+		auditEventTTL, parseErr = cfg.ParseAuditEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(auditEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(auditEventTTL).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseUsageEventTTL", func() {
+	var (
+		usageEventTTLString string
+		usageEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		usageEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			UsageEventTTL: usageEventTTLString,
+		}
+
+		usageEventTTL, parseErr = cfg.ParseUsageEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(usageEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			usageEventTTLString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(usageEventTTL).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			usageEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
 var _ = Describe("ParseJobTTL", func() {
 	var (
 		jobTTL    time.Duration
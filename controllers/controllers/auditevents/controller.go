@@ -0,0 +1,83 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auditevents
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Reconciler struct {
+	k8sClient       client.Client
+	log             logr.Logger
+	retentionPeriod time.Duration
+}
+
+func NewReconciler(
+	client client.Client,
+	log logr.Logger,
+	retentionPeriod time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAuditEvent, *korifiv1alpha1.CFAuditEvent] {
+	auditEventReconciler := Reconciler{
+		k8sClient:       client,
+		log:             log,
+		retentionPeriod: retentionPeriod,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAuditEvent, *korifiv1alpha1.CFAuditEvent](log, client, &auditEventReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAuditEvent{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents/status,verbs=get;patch
+
+// ReconcileResource deletes audit events once they are older than the
+// retention period and requeues the others for when they expire.
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfAuditEvent *korifiv1alpha1.CFAuditEvent) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfAuditEvent.Status.ObservedGeneration = cfAuditEvent.Generation
+	log.V(1).Info("set observed generation", "generation", cfAuditEvent.Status.ObservedGeneration)
+
+	if !cfAuditEvent.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	expiresIn := time.Until(cfAuditEvent.CreationTimestamp.Add(r.retentionPeriod))
+	if expiresIn > 0 {
+		return ctrl.Result{RequeueAfter: expiresIn}, nil
+	}
+
+	log.V(1).Info("deleting-expired-audit-event", "namespace", cfAuditEvent.Namespace, "name", cfAuditEvent.Name)
+	if err := r.k8sClient.Delete(ctx, cfAuditEvent); client.IgnoreNotFound(err) != nil {
+		log.Info("error-deleting-audit-event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,60 @@
+package auditevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAuditEventReconciler Integration Tests", func() {
+	var cfAuditEvent *korifiv1alpha1.CFAuditEvent
+
+	BeforeEach(func() {
+		namespace := uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		cfAuditEvent = &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type: "audit.app.start",
+				Actor: korifiv1alpha1.AuditEventActor{
+					GUID: "alice",
+					Type: "user",
+					Name: "alice",
+				},
+				Target: korifiv1alpha1.AuditEventTarget{
+					GUID: "app-guid",
+					Type: "app",
+				},
+				SpaceGUID: namespace,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfAuditEvent)).To(Succeed())
+	})
+
+	It("keeps the event for the retention period", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)).To(Succeed())
+		}, "1s").Should(Succeed())
+	})
+
+	It("deletes the event once the retention period has passed", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+})
@@ -25,6 +25,8 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -56,6 +58,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/status,verbs=patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;patch;delete
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -66,9 +70,44 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1al
 	cfDomain.Status.ObservedGeneration = cfDomain.Generation
 	log.V(1).Info("set observed generation", "generation", cfDomain.Status.ObservedGeneration)
 
+	if cfDomain.Spec.Internal {
+		if err := r.reconcileInternalDomainNamespace(ctx, cfDomain); err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("InternalDomainNamespace")
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileInternalDomainNamespace ensures the namespace holding the cluster
+// services of the internal domain routes exists. The namespace is named after
+// the domain GUID so that the cluster DNS can be configured to resolve
+// <host>.<domain> to <host>.<domain-guid>.svc
+func (r *Reconciler) reconcileInternalDomainNamespace(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileInternalDomainNamespace")
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cfDomain.Name,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, namespace, func() error {
+		if namespace.Labels == nil {
+			namespace.Labels = map[string]string{}
+		}
+		namespace.Labels[korifiv1alpha1.CFDomainGUIDLabelKey] = cfDomain.Name
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch namespace", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("namespace reconciled", "operation", result)
+	return nil
+}
+
 func (r *Reconciler) finalizeCFDomain(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFDomain")
 
@@ -85,6 +124,14 @@ func (r *Reconciler) finalizeCFDomain(ctx context.Context, cfDomain *korifiv1alp
 	log.Info("routes", "len", len(domainRoutes))
 
 	if len(domainRoutes) == 0 {
+		if cfDomain.Spec.Internal {
+			err = r.client.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cfDomain.Name}})
+			if client.IgnoreNotFound(err) != nil {
+				log.Info("failed to delete internal domain namespace", "reason", err)
+				return ctrl.Result{}, err
+			}
+		}
+
 		if controllerutil.RemoveFinalizer(cfDomain, korifiv1alpha1.CFDomainFinalizerName) {
 			log.V(1).Info("finalizer removed")
 		}
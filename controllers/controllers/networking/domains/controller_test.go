@@ -2,12 +2,14 @@ package domains_test
 
 import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -45,6 +47,45 @@ var _ = Describe("CFDomainReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	When("the domain is internal", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+				cfDomain.Spec.Internal = true
+			})).To(Succeed())
+		})
+
+		It("creates the namespace of the internal route services", func() {
+			Eventually(func(g Gomega) {
+				namespace := &corev1.Namespace{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Name: cfDomain.Name}, namespace)).To(Succeed())
+				g.Expect(namespace.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFDomainGUIDLabelKey, cfDomain.Name))
+			}).Should(Succeed())
+		})
+
+		When("the domain is deleted", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Name: cfDomain.Name}, &corev1.Namespace{})).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, cfDomain)).To(Succeed())
+			})
+
+			It("deletes the namespace", func() {
+				Eventually(func(g Gomega) {
+					namespace := &corev1.Namespace{}
+					err := adminClient.Get(ctx, client.ObjectKey{Name: cfDomain.Name}, namespace)
+					if err == nil {
+						// envtest has no namespace controller to complete the deletion
+						g.Expect(namespace.DeletionTimestamp).NotTo(BeNil())
+						return
+					}
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			route1Namespace string
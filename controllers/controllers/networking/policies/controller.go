@@ -0,0 +1,151 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policies
+
+import (
+	"context"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type Reconciler struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	log              logr.Logger
+	controllerConfig *config.ControllerConfig
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	controllerConfig *config.ControllerConfig,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFNetworkPolicy, *korifiv1alpha1.CFNetworkPolicy] {
+	policyReconciler := Reconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFNetworkPolicy, *korifiv1alpha1.CFNetworkPolicy](log, client, &policyReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFNetworkPolicy{}).
+		Owns(&networkingv1.NetworkPolicy{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies/status,verbs=get;patch
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;patch;delete
+
+// ReconcileResource renders the CFNetworkPolicy into a NetworkPolicy allowing
+// ingress traffic from the source app pods to the destination app pods. As
+// soon as a NetworkPolicy selects the destination pods, any ingress traffic
+// not explicitly allowed is denied, so the policy also allows traffic from
+// the gateway in order to keep the app routes working.
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfNetworkPolicy.Status.ObservedGeneration = cfNetworkPolicy.Generation
+	log.V(1).Info("set observed generation", "generation", cfNetworkPolicy.Status.ObservedGeneration)
+
+	if !cfNetworkPolicy.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfNetworkPolicy.Name,
+			Namespace: cfNetworkPolicy.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Labels = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.Destination.AppGUID,
+		}
+
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.Destination.AppGUID,
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: namespaceSelector(cfNetworkPolicy.Spec.Source.SpaceGUID),
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.Source.AppGUID,
+							},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{toNetworkPolicyPort(cfNetworkPolicy.Spec.Destination)},
+				},
+				{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: namespaceSelector(r.controllerConfig.Networking.GatewayNamespace),
+					}},
+				},
+			},
+		}
+
+		return controllerutil.SetControllerReference(cfNetworkPolicy, networkPolicy, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch network policy", "reason", err)
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("NetworkPolicy")
+	}
+
+	log.V(1).Info("network policy reconciled", "operation", result)
+	return ctrl.Result{}, nil
+}
+
+func namespaceSelector(namespace string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			corev1.LabelMetadataName: namespace,
+		},
+	}
+}
+
+func toNetworkPolicyPort(destination korifiv1alpha1.NetworkPolicyDestination) networkingv1.NetworkPolicyPort {
+	port := networkingv1.NetworkPolicyPort{
+		Protocol: tools.PtrTo(corev1.Protocol(strings.ToUpper(destination.Protocol))),
+		Port:     tools.PtrTo(intstr.FromInt32(destination.Ports.Start)),
+	}
+	if destination.Ports.End > destination.Ports.Start {
+		port.EndPort = tools.PtrTo(destination.Ports.End)
+	}
+
+	return port
+}
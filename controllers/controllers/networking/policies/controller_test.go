@@ -0,0 +1,98 @@
+package policies_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFNetworkPolicyReconciler Integration Tests", func() {
+	var (
+		namespace       string
+		cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy
+	)
+
+	BeforeEach(func() {
+		namespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		cfNetworkPolicy = &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFNetworkPolicySpec{
+				Source: korifiv1alpha1.NetworkPolicySource{
+					AppGUID:   "source-app",
+					SpaceGUID: "source-space",
+				},
+				Destination: korifiv1alpha1.NetworkPolicyDestination{
+					AppGUID:  "destination-app",
+					Protocol: korifiv1alpha1.NetworkPolicyProtocolTCP,
+					Ports: korifiv1alpha1.NetworkPolicyPorts{
+						Start: 8080,
+						End:   8090,
+					},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfNetworkPolicy)).To(Succeed())
+	})
+
+	It("sets the ready condition", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfNetworkPolicy.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("creates a network policy allowing ingress from the source app and the gateway", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), networkPolicy)).To(Succeed())
+
+			g.Expect(networkPolicy.OwnerReferences).To(ConsistOf(HaveField("Name", cfNetworkPolicy.Name)))
+			g.Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: "destination-app",
+			}))
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+			g.Expect(networkPolicy.Spec.Ingress).To(ConsistOf(
+				networkingv1.NetworkPolicyIngressRule{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{corev1.LabelMetadataName: "source-space"},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: "source-app"},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{{
+						Protocol: tools.PtrTo(corev1.ProtocolTCP),
+						Port:     tools.PtrTo(intstr.FromInt32(8080)),
+						EndPort:  tools.PtrTo[int32](8090),
+					}},
+				},
+				networkingv1.NetworkPolicyIngressRule{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{corev1.LabelMetadataName: "korifi-gateway"},
+						},
+					}},
+				},
+			))
+		}).Should(Succeed())
+	})
+})
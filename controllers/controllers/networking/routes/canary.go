@@ -0,0 +1,115 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// destinationCanary describes the canary deployment in progress for the app
+// of a route destination. The canary instances are told apart from the
+// current ones by the GUID label of their pods, which is the process GUID for
+// the current instances and the canary workload GUID for the canary ones.
+type destinationCanary struct {
+	processGUID string
+	weight      int32
+}
+
+// getDestinationCanaries returns the canary deployments in progress for the
+// route destinations, keyed by destination GUID
+func (r *Reconciler) getDestinationCanaries(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (map[string]destinationCanary, error) {
+	canaries := map[string]destinationCanary{}
+
+	for _, destination := range cfRoute.Status.Destinations {
+		cfApp := &korifiv1alpha1.CFApp{}
+		err := r.client.Get(ctx, client.ObjectKey{Namespace: destination.AppNamespace(cfRoute.Namespace), Name: destination.AppRef.Name}, cfApp)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get app %q: %w", destination.AppRef.Name, err)
+		}
+
+		if cfApp.Spec.Canary == nil {
+			continue
+		}
+
+		processList := &korifiv1alpha1.CFProcessList{}
+		err = r.client.List(ctx, processList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+			korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list processes of app %q: %w", cfApp.Name, err)
+		}
+
+		if len(processList.Items) == 0 {
+			continue
+		}
+
+		process := processList.Items[0]
+		canaries[destination.GUID] = destinationCanary{
+			processGUID: process.Name,
+			weight:      canaryWeight(cfApp.Spec.Canary.Step(), tools.ZeroIfNil(process.Spec.DesiredInstances)),
+		}
+	}
+
+	return canaries, nil
+}
+
+// canaryWeight returns the percentage of the traffic sent to the canary
+// instances. Unless the step sets it explicitly, traffic is split in
+// proportion to the number of instances.
+func canaryWeight(step korifiv1alpha1.CanaryStep, desiredInstances int32) int32 {
+	if step.Weight != nil {
+		return *step.Weight
+	}
+
+	return 100 * step.Instances / (step.Instances + desiredInstances)
+}
+
+func (r *Reconciler) createOrPatchCanaryService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination, canary destinationCanary) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchCanaryService")
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateCanaryServiceName(destination),
+			Namespace: destination.AppNamespace(cfRoute.Namespace),
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Labels = destinationServiceLabels(cfRoute, destination)
+
+		service.Spec.Ports = []corev1.ServicePort{{
+			Port: *destination.Port,
+		}}
+
+		service.Spec.Selector = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
+			korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
+			korifiv1alpha1.GUIDLabelKey:          korifiv1alpha1.CanaryWorkloadGUID(canary.processGUID),
+		}
+
+		return setDestinationServiceOwner(cfRoute, service, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to patch canary Service", "reason", err)
+		return fmt.Errorf("canary service reconciliation failed for CFRoute/%s destinations", cfRoute.Name)
+	}
+
+	log.V(1).Info("canary Service reconciled", "serviceName", service.Name, "operation", result)
+	return nil
+}
+
+func generateCanaryServiceName(destination korifiv1alpha1.Destination) string {
+	return generateServiceName(destination) + "-canary"
+}
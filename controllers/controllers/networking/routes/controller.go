@@ -19,6 +19,7 @@ package routes
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -29,8 +30,8 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -66,9 +67,40 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFApp{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFAppRequests),
+		).
+		Watches(
+			&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueEndpointSliceRequests),
 		)
 }
 
+// enqueueEndpointSliceRequests enqueues the route owning the destination
+// service of the endpoint slice, so that the endpoints of internal routes are
+// kept in sync with the app instances. The endpoint slices controller copies
+// the service labels (including the route GUID and space) onto the slices.
+func (r *Reconciler) enqueueEndpointSliceRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetLabels()[discoveryv1.LabelManagedBy] == InternalRouteEndpointsManager {
+		return []reconcile.Request{}
+	}
+
+	routeGUID, ok := o.GetLabels()[korifiv1alpha1.CFRouteGUIDLabelKey]
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	routeNamespace, ok := o.GetLabels()[korifiv1alpha1.SpaceGUIDKey]
+	if !ok {
+		routeNamespace = o.GetNamespace()
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      routeGUID,
+			Namespace: routeNamespace,
+		},
+	}}
+}
+
 func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object) []reconcile.Request {
 	var requests []reconcile.Request
 
@@ -77,11 +109,11 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 		return []reconcile.Request{}
 	}
 
+	// routes shared with the app space live in other namespaces
 	var appRoutes korifiv1alpha1.CFRouteList
 	err := r.client.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -106,8 +138,11 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch;create;patch;delete
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;patch;delete
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -130,19 +165,52 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("InvalidDomainRef")
 	}
 
-	err = r.createOrPatchServices(ctx, cfRoute)
+	if cfDomain.Spec.Internal || len(cfRoute.Spec.SharedSpaces) > 0 {
+		// the internal route service lives in the domain namespace and the
+		// services of destinations in shared spaces live in the app
+		// namespaces, so they cannot be garbage collected through an owner
+		// reference
+		controllerutil.AddFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName)
+	}
+
+	canaries, err := r.getDestinationCanaries(ctx, cfRoute)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("GetDestinationCanaries")
+	}
+
+	err = r.createOrPatchServices(ctx, cfRoute, canaries)
 	if err != nil {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchServices")
 	}
 
-	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
+	err = r.reconcileReferenceGrants(ctx, cfRoute, canaries)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileReferenceGrants")
+	}
+
+	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain, canaries)
 	if err != nil {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
 	}
 
-	fqdn := buildFQDN(cfRoute, cfDomain)
-	cfRoute.Status.FQDN = fqdn
-	cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
+	err = r.reconcileInternalRoute(ctx, cfRoute, cfDomain)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileInternalRoute")
+	}
+
+	err = r.reconcileTCPRoute(ctx, cfRoute, cfDomain, canaries)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileTCPRoute")
+	}
+
+	if isTCPRoute(cfRoute, cfDomain) {
+		cfRoute.Status.FQDN = cfDomain.Spec.Name
+		cfRoute.Status.URI = fmt.Sprintf("%s:%d", cfDomain.Spec.Name, *cfRoute.Spec.Port)
+	} else {
+		fqdn := buildFQDN(cfRoute, cfDomain)
+		cfRoute.Status.FQDN = fqdn
+		cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
+	}
 
 	effectiveDestinations, err := r.buildEffectiveDestinations(ctx, cfRoute)
 	if err != nil {
@@ -150,7 +218,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 	}
 	cfRoute.Status.Destinations = effectiveDestinations
 
-	if cleanupErr := r.deleteOrphanedServices(ctx, cfRoute); cleanupErr != nil {
+	if cleanupErr := r.deleteOrphanedServices(ctx, cfRoute, canaries); cleanupErr != nil {
 		// technically, failing to delete the orphaned services does not make
 		// the CFRoute invalid or not ready so we don't mess with the cfRoute
 		// ready status condition here
@@ -167,6 +235,14 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 		return nil
 	}
 
+	if err := r.deleteInternalRouteService(ctx, cfRoute); err != nil {
+		return err
+	}
+
+	if err := r.deleteSharedSpaceResources(ctx, cfRoute); err != nil {
+		return err
+	}
+
 	if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -174,7 +250,7 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 	return nil
 }
 
-func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
 
 	for _, destination := range cfRoute.Status.Destinations {
@@ -185,20 +261,19 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 			continue
 		}
 
+		canary, hasCanary := canaries[destination.GUID]
+
 		service := &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      serviceName,
-				Namespace: cfRoute.Namespace,
+				Namespace: destination.AppNamespace(cfRoute.Namespace),
 			},
 		}
 
 		result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
-			service.Labels = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
-				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
-			}
+			service.Labels = destinationServiceLabels(cfRoute, destination)
 
-			err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+			err := setDestinationServiceOwner(cfRoute, service, r.scheme)
 			if err != nil {
 				loopLog.Info("failed to set OwnerRef on Service", "reason", err)
 				return err
@@ -212,6 +287,9 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 				korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
 				korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
 			}
+			if hasCanary {
+				service.Spec.Selector[korifiv1alpha1.GUIDLabelKey] = canary.processGUID
+			}
 
 			return nil
 		})
@@ -221,6 +299,12 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 		}
 
 		log.V(1).Info("Service reconciled", "operation", result)
+
+		if hasCanary {
+			if err = r.createOrPatchCanaryService(ctx, cfRoute, destination, canary); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -234,10 +318,13 @@ func (r *Reconciler) buildEffectiveDestinations(ctx context.Context, cfRoute *ko
 
 		if effectiveDest.Protocol == nil {
 			effectiveDest.Protocol = tools.PtrTo("http1")
+			if cfRoute.Spec.Protocol == korifiv1alpha1.ProtocolTCP {
+				effectiveDest.Protocol = tools.PtrTo("tcp")
+			}
 		}
 
 		if effectiveDest.Port == nil {
-			droplet, err := r.getAppCurrentDroplet(ctx, cfRoute.Namespace, dest.AppRef.Name)
+			droplet, err := r.getAppCurrentDroplet(ctx, dest.AppNamespace(cfRoute.Namespace), dest.AppRef.Name)
 			if err != nil {
 				return []korifiv1alpha1.Destination{}, err
 			}
@@ -288,7 +375,7 @@ func (r *Reconciler) getAppCurrentDroplet(ctx context.Context, appNamespace, app
 	return cfBuild.Status.Droplet, nil
 }
 
-func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, canaries map[string]destinationCanary) error {
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchHTTPRoute").WithValues("fqdn", fqdn, "path", cfRoute.Spec.Path)
 
@@ -299,7 +386,7 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		},
 	}
 
-	if len(cfRoute.Status.Destinations) == 0 {
+	if len(cfRoute.Status.Destinations) == 0 || cfDomain.Spec.Internal || cfDomain.Spec.RouterGroup != "" {
 		err := r.client.Delete(ctx, httpRoute)
 		if client.IgnoreNotFound(err) != nil {
 			log.Info("failed to delete existing HTTPRoutes", "reason", err)
@@ -321,7 +408,7 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		}
 
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
-			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
+			BackendRefs: toBackendRefs(cfRoute, canaries),
 		}}
 		if cfRoute.Spec.Path != "" {
 			httpRoute.Spec.Rules[0].Matches = []gatewayv1beta1.HTTPRouteMatch{{
@@ -343,28 +430,33 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 	return nil
 }
 
-func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedServices")
 
-	matchingLabelSet := map[string]string{
-		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
-	}
-
-	serviceList, err := r.fetchServicesByMatchingLabels(ctx, matchingLabelSet, cfRoute.Namespace)
+	serviceList, err := r.fetchDestinationServices(ctx, cfRoute)
 	if err != nil {
 		log.Info("failed to fetch services using label", "label", korifiv1alpha1.CFRouteGUIDLabelKey, "value", cfRoute.Name, "reason", err)
 		return err
 	}
 
 	for i, service := range serviceList.Items {
-		loopLog := log.WithValues("serviceName", service.Name)
+		loopLog := log.WithValues("serviceName", service.Name, "serviceNamespace", service.Namespace)
 
 		isOrphan := true
 		for _, destination := range cfRoute.Status.Destinations {
+			if service.Namespace != destination.AppNamespace(cfRoute.Namespace) {
+				continue
+			}
+
 			if service.Name == generateServiceName(destination) {
 				isOrphan = false
 				break
 			}
+
+			if _, hasCanary := canaries[destination.GUID]; hasCanary && service.Name == generateCanaryServiceName(destination) {
+				isOrphan = false
+				break
+			}
 		}
 
 		if isOrphan {
@@ -379,25 +471,53 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 	return nil
 }
 
-func (r *Reconciler) fetchServicesByMatchingLabels(ctx context.Context, labelSet map[string]string, namespace string) (*corev1.ServiceList, error) {
-	log := logr.FromContextOrDiscard(ctx).WithName("fetchServicesByMatchingLabels")
-
-	selector, err := labels.ValidatedSelectorFromSet(labelSet)
-	if err != nil {
-		log.Info("error initializing label selector", "reason", err)
-		return nil, err
-	}
+// fetchDestinationServices lists the services of the route destinations in
+// all namespaces. Unlike the internal route service, destination services
+// select the pods of their app. Services in other namespaces are also matched
+// by the route space, so that the services of a route transferred to another
+// space are not mistaken for the ones of the original route.
+func (r *Reconciler) fetchDestinationServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (*corev1.ServiceList, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("fetchDestinationServices")
 
 	serviceList := corev1.ServiceList{}
-	err = r.client.List(ctx, &serviceList, &client.ListOptions{LabelSelector: selector, Namespace: namespace})
+	err := r.client.List(ctx, &serviceList,
+		client.MatchingLabels{korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name},
+		client.HasLabels{korifiv1alpha1.CFAppGUIDLabelKey},
+	)
 	if err != nil {
 		log.Info("failed to list services", "reason", err)
 		return nil, err
 	}
 
+	serviceList.Items = slices.DeleteFunc(serviceList.Items, func(service corev1.Service) bool {
+		return service.Namespace != cfRoute.Namespace && service.Labels[korifiv1alpha1.SpaceGUIDKey] != cfRoute.Namespace
+	})
+
 	return &serviceList, nil
 }
 
+func destinationServiceLabels(cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination) map[string]string {
+	return map[string]string{
+		korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
+		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		korifiv1alpha1.SpaceGUIDKey:        cfRoute.Namespace,
+	}
+}
+
+// setDestinationServiceOwner makes the route the owner of the destination
+// services in its namespace. Services in shared spaces are deleted when the
+// route is finalized instead, as owner references cannot cross namespaces.
+func setDestinationServiceOwner(cfRoute *korifiv1alpha1.CFRoute, service *corev1.Service, scheme *runtime.Scheme) error {
+	if service.Namespace != cfRoute.Namespace {
+		// the service may have been owned by the route before it was
+		// transferred to another space
+		service.OwnerReferences = nil
+		return nil
+	}
+
+	return controllerutil.SetControllerReference(cfRoute, service, scheme)
+}
+
 func generateServiceName(destination korifiv1alpha1.Destination) string {
 	return fmt.Sprintf("s-%s", destination.GUID)
 }
@@ -406,20 +526,49 @@ func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomai
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
-func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
+// toBackendRefs sends the route traffic evenly to all destinations. When
+// canary deployments are in progress every destination is explicitly
+// weighted, so that the traffic of a destination can be split between its
+// current and canary instances without affecting the others.
+func toBackendRefs(cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) []gatewayv1beta1.HTTPBackendRef {
 	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
 
-	for _, destination := range destinations {
-		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
-			BackendRef: gatewayv1beta1.BackendRef{
-				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
-					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
-					Name: gatewayv1beta1.ObjectName(generateServiceName(destination)),
-					Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
-				},
-			},
-		})
+	for _, destination := range cfRoute.Status.Destinations {
+		canary, hasCanary := canaries[destination.GUID]
+
+		var weight *int32
+		if len(canaries) > 0 {
+			weight = tools.PtrTo[int32](100)
+		}
+		if hasCanary {
+			weight = tools.PtrTo(100 - canary.weight)
+		}
+
+		backendRefs = append(backendRefs, toBackendRef(cfRoute, generateServiceName(destination), destination, weight))
+
+		if hasCanary {
+			backendRefs = append(backendRefs, toBackendRef(cfRoute, generateCanaryServiceName(destination), destination, tools.PtrTo(canary.weight)))
+		}
 	}
 
 	return backendRefs
 }
+
+func toBackendRef(cfRoute *korifiv1alpha1.CFRoute, serviceName string, destination korifiv1alpha1.Destination, weight *int32) gatewayv1beta1.HTTPBackendRef {
+	backendRef := gatewayv1beta1.HTTPBackendRef{
+		BackendRef: gatewayv1beta1.BackendRef{
+			BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+				Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
+				Name: gatewayv1beta1.ObjectName(serviceName),
+				Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
+			},
+			Weight: weight,
+		},
+	}
+
+	if appNamespace := destination.AppNamespace(cfRoute.Namespace); appNamespace != cfRoute.Namespace {
+		backendRef.Namespace = tools.PtrTo(gatewayv1beta1.Namespace(appNamespace))
+	}
+
+	return backendRef
+}
@@ -13,12 +13,14 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -129,7 +131,7 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
 				{
 					GUID: uuid.NewString(),
-					AppRef: corev1.LocalObjectReference{
+					AppRef: corev1.ObjectReference{
 						Name: cfApp.Name,
 					},
 					ProcessType: "web",
@@ -246,6 +248,134 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		When("the destination app is in a shared space", func() {
+			var sharedNamespace *corev1.Namespace
+
+			BeforeEach(func() {
+				sharedNamespace = &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedNamespace)).To(Succeed())
+
+				cfApp = &korifiv1alpha1.CFApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: sharedNamespace.Name,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFAppSpec{
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+						DesiredState: "STARTED",
+						DisplayName:  uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+				cfRoute.Spec.SharedSpaces = []string{sharedNamespace.Name}
+				cfRoute.Spec.Destinations[0].AppRef = corev1.ObjectReference{
+					Name:      cfApp.Name,
+					Namespace: sharedNamespace.Name,
+				}
+			})
+
+			It("creates the destination service in the shared space", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNamespace.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Labels).To(SatisfyAll(
+						HaveKeyWithValue(korifiv1alpha1.CFRouteGUIDLabelKey, cfRoute.Name),
+						HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, ns.Name),
+					))
+					g.Expect(svc.OwnerReferences).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("sends traffic to the service in the shared space", func() {
+				httpRoute := getHTTPRoute()
+				Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+				Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+				Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Namespace).To(PointTo(Equal(gatewayv1beta1.Namespace(sharedNamespace.Name))))
+			})
+
+			It("grants the route access to the service in the shared space", func() {
+				Eventually(func(g Gomega) {
+					referenceGrant := &gatewayv1beta1.ReferenceGrant{}
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfRoute.Name, Namespace: sharedNamespace.Name}, referenceGrant)).To(Succeed())
+					g.Expect(referenceGrant.Spec.From).To(ConsistOf(
+						gatewayv1beta1.ReferenceGrantFrom{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: gatewayv1beta1.Namespace(ns.Name)},
+						gatewayv1beta1.ReferenceGrantFrom{Group: "gateway.networking.k8s.io", Kind: "TCPRoute", Namespace: gatewayv1beta1.Namespace(ns.Name)},
+					))
+					g.Expect(referenceGrant.Spec.To).To(ConsistOf(gatewayv1beta1.ReferenceGrantTo{
+						Group: "",
+						Kind:  "Service",
+						Name:  tools.PtrTo(gatewayv1beta1.ObjectName(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID))),
+					}))
+				}).Should(Succeed())
+			})
+
+			It("adds a finalizer to the route", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(cfRoute.Finalizers).To(ContainElement(korifiv1alpha1.CFRouteFinalizerName))
+				}).Should(Succeed())
+			})
+
+			When("the destination is removed", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						referenceGrants := &gatewayv1beta1.ReferenceGrantList{}
+						g.Expect(adminClient.List(ctx, referenceGrants, client.InNamespace(sharedNamespace.Name))).To(Succeed())
+						g.Expect(referenceGrants.Items).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfRoute, func() {
+						cfRoute.Spec.Destinations = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the service and the reference grant in the shared space", func() {
+					Eventually(func(g Gomega) {
+						services := &corev1.ServiceList{}
+						g.Expect(adminClient.List(ctx, services, client.InNamespace(sharedNamespace.Name))).To(Succeed())
+						g.Expect(services.Items).To(BeEmpty())
+
+						referenceGrants := &gatewayv1beta1.ReferenceGrantList{}
+						g.Expect(adminClient.List(ctx, referenceGrants, client.InNamespace(sharedNamespace.Name))).To(Succeed())
+						g.Expect(referenceGrants.Items).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						referenceGrants := &gatewayv1beta1.ReferenceGrantList{}
+						g.Expect(adminClient.List(ctx, referenceGrants, client.InNamespace(sharedNamespace.Name))).To(Succeed())
+						g.Expect(referenceGrants.Items).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("deletes the service and the reference grant in the shared space", func() {
+					Eventually(func(g Gomega) {
+						services := &corev1.ServiceList{}
+						g.Expect(adminClient.List(ctx, services, client.InNamespace(sharedNamespace.Name))).To(Succeed())
+						g.Expect(services.Items).To(BeEmpty())
+
+						referenceGrants := &gatewayv1beta1.ReferenceGrantList{}
+						g.Expect(adminClient.List(ctx, referenceGrants, client.InNamespace(sharedNamespace.Name))).To(Succeed())
+						g.Expect(referenceGrants.Items).To(BeEmpty())
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the route's path is empty", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Path = ""
@@ -414,6 +544,354 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the destination app has a canary deployment in progress", func() {
+			var (
+				cfProcess *korifiv1alpha1.CFProcess
+				weight    *int32
+			)
+
+			BeforeEach(func() {
+				weight = tools.PtrTo[int32](20)
+
+				cfProcess = &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+							korifiv1alpha1.CFProcessTypeLabelKey: "web",
+						},
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+						ProcessType:      "web",
+						DesiredInstances: tools.PtrTo[int32](3),
+					},
+				}
+				Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+			})
+
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Canary = &korifiv1alpha1.CFAppCanary{
+						DropletRef: corev1.LocalObjectReference{Name: uuid.NewString()},
+						Steps:      []korifiv1alpha1.CanaryStep{{Instances: 1, Weight: weight}},
+					}
+				})).To(Succeed())
+			})
+
+			backendRefsOf := func(g Gomega) []gatewayv1beta1.HTTPBackendRef {
+				httpRoute := &gatewayv1beta1.HTTPRoute{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), httpRoute)).To(Succeed())
+				g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+				return httpRoute.Spec.Rules[0].BackendRefs
+			}
+
+			It("splits the traffic between the current and the canary instances", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(backendRefsOf(g)).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name": BeEquivalentTo(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+							}),
+							"Weight": PointTo(BeEquivalentTo(80)),
+						})}),
+						MatchFields(IgnoreExtras, Fields{"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name": BeEquivalentTo(fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID)),
+							}),
+							"Weight": PointTo(BeEquivalentTo(20)),
+						})}),
+					))
+				}).Should(Succeed())
+			})
+
+			It("selects the current and the canary instances with separate services", func() {
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Selector).To(HaveKeyWithValue(korifiv1alpha1.GUIDLabelKey, cfProcess.Name))
+
+					var canarySvc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, &canarySvc)).To(Succeed())
+					g.Expect(canarySvc.Spec.Selector).To(SatisfyAll(
+						HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name),
+						HaveKeyWithValue(korifiv1alpha1.CFProcessTypeLabelKey, "web"),
+						HaveKeyWithValue(korifiv1alpha1.GUIDLabelKey, korifiv1alpha1.CanaryWorkloadGUID(cfProcess.Name)),
+					))
+				}).Should(Succeed())
+			})
+
+			When("the canary step has no weight", func() {
+				BeforeEach(func() {
+					weight = nil
+				})
+
+				It("splits the traffic in proportion to the number of instances", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(backendRefsOf(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name": BeEquivalentTo(fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID)),
+							}),
+							"Weight": PointTo(BeEquivalentTo(25)),
+						})})))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the canary deployment is over", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(backendRefsOf(g)).To(HaveLen(2))
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the canary service", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("s-%s-canary", cfRoute.Spec.Destinations[0].GUID), Namespace: ns.Name}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+						g.Expect(backendRefsOf(g)).To(HaveLen(1))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the domain is internal", func() {
+		var cfApp *korifiv1alpha1.CFApp
+
+		BeforeEach(func() {
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: cfDomain.Name,
+				},
+			})).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+				cfDomain.Spec.Internal = true
+			})).To(Succeed())
+
+			cfApp = &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					Lifecycle: korifiv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+					DesiredState: "STARTED",
+					DisplayName:  uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID: uuid.NewString(),
+				AppRef: corev1.ObjectReference{
+					Name: cfApp.Name,
+				},
+				ProcessType: "web",
+				Port:        tools.PtrTo[int32](8080),
+			}}
+		})
+
+		It("does not create a HTTPRoute", func() {
+			Consistently(func(g Gomega) {
+				httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+				g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+				g.Expect(httpRoutes.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("creates a selectorless cluster service in the domain namespace", func() {
+			Eventually(func(g Gomega) {
+				service := &corev1.Service{}
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfDomain.Name, Name: "test-route-host"}, service)).To(Succeed())
+				g.Expect(service.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRouteGUIDLabelKey, cfRoute.Name))
+				g.Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+				g.Expect(service.Spec.Selector).To(BeEmpty())
+				g.Expect(service.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Name": Equal("port-8080"),
+					"Port": BeEquivalentTo(8080),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("adds the finalizer to the route", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(cfRoute.Finalizers).To(ContainElement(korifiv1alpha1.CFRouteFinalizerName))
+			}).Should(Succeed())
+		})
+
+		When("the destination service has endpoints", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Create(ctx, &discoveryv1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							discoveryv1.LabelServiceName:       "s-" + cfRoute.Spec.Destinations[0].GUID,
+							korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+						},
+					},
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Endpoints: []discoveryv1.Endpoint{{
+						Addresses: []string{"10.1.2.3"},
+					}},
+				})).To(Succeed())
+			})
+
+			It("mirrors the endpoints into the domain namespace", func() {
+				Eventually(func(g Gomega) {
+					endpointSlices := &discoveryv1.EndpointSliceList{}
+					g.Expect(adminClient.List(ctx, endpointSlices,
+						client.InNamespace(cfDomain.Name),
+						client.MatchingLabels{discoveryv1.LabelServiceName: "test-route-host"},
+					)).To(Succeed())
+					g.Expect(endpointSlices.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Endpoints": ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Addresses": ConsistOf("10.1.2.3"),
+						})),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfDomain.Name, Name: "test-route-host"}, &corev1.Service{})).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+			})
+
+			It("deletes the internal route service", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, types.NamespacedName{Namespace: cfDomain.Name, Name: "test-route-host"}, &corev1.Service{})
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the domain has a router group", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+			})).To(Succeed())
+
+			cfApp := &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					Lifecycle: korifiv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+					DesiredState: "STARTED",
+					DisplayName:  uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+			cfRoute.Spec.Host = ""
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolTCP
+			cfRoute.Spec.Port = tools.PtrTo[int32](1024)
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID: uuid.NewString(),
+				AppRef: corev1.ObjectReference{
+					Name: cfApp.Name,
+				},
+				ProcessType: "web",
+				Port:        tools.PtrTo[int32](5432),
+			}}
+		})
+
+		getTCPRoute := func() *gatewayv1alpha2.TCPRoute {
+			GinkgoHelper()
+
+			tcpRoute := &gatewayv1alpha2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfRoute.Name,
+					Namespace: cfRoute.Namespace,
+				},
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(tcpRoute), tcpRoute)).To(Succeed())
+			}).Should(Succeed())
+			return tcpRoute
+		}
+
+		It("creates a TCPRoute attached to the gateway listener of the port", func() {
+			tcpRoute := getTCPRoute()
+
+			Expect(tcpRoute.Spec.ParentRefs).To(ConsistOf(gatewayv1alpha2.ParentReference{
+				Group:       tools.PtrTo(gatewayv1alpha2.Group("gateway.networking.k8s.io")),
+				Kind:        tools.PtrTo(gatewayv1alpha2.Kind("Gateway")),
+				Namespace:   tools.PtrTo(gatewayv1alpha2.Namespace("korifi-gateway")),
+				Name:        gatewayv1alpha2.ObjectName("korifi"),
+				SectionName: tools.PtrTo(gatewayv1alpha2.SectionName("tcp-1024")),
+			}))
+			Expect(tcpRoute.Spec.Rules).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"BackendRefs": ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+						"Name": BeEquivalentTo("s-" + cfRoute.Spec.Destinations[0].GUID),
+						"Port": PointTo(BeEquivalentTo(5432)),
+					}),
+				})),
+			})))
+			Expect(tcpRoute.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name": Equal(cfRoute.Name),
+			})))
+		})
+
+		It("does not create a HTTPRoute", func() {
+			Consistently(func(g Gomega) {
+				httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+				g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+				g.Expect(httpRoutes.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("sets the domain and port as the route uri", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				g.Expect(cfRoute.Status.FQDN).To(Equal(cfDomain.Spec.Name))
+				g.Expect(cfRoute.Status.URI).To(Equal(cfDomain.Spec.Name + ":1024"))
+				g.Expect(cfRoute.Status.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Protocol": PointTo(Equal("tcp")),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the destinations are deleted from the route", func() {
+			JustBeforeEach(func() {
+				getTCPRoute()
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = nil
+				})).To(Succeed())
+			})
+
+			It("deletes the TCPRoute", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &gatewayv1alpha2.TCPRoute{})
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	When("a route has a legacy finalizer", func() {
@@ -0,0 +1,230 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const InternalRouteEndpointsManager = "korifi.cloudfoundry.org"
+
+// reconcileInternalRoute exposes routes on internal domains as a ClusterIP
+// service named after the route host in the domain namespace. As the route
+// destinations may live in a different namespace than the service (and may
+// belong to different apps), the service has no selector; its endpoints are
+// mirrored from the endpoint slices of the destination services instead.
+func (r *Reconciler) reconcileInternalRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	if !cfDomain.Spec.Internal {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileInternalRoute").WithValues("fqdn", buildFQDN(cfRoute, cfDomain))
+
+	destinations := slices.DeleteFunc(slices.Clone(cfRoute.Status.Destinations), func(destination korifiv1alpha1.Destination) bool {
+		return destination.Port == nil
+	})
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.ToLower(cfRoute.Spec.Host),
+			Namespace: cfDomain.Name,
+		},
+	}
+
+	if len(destinations) == 0 {
+		if err := r.client.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete internal route service", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		if owner, ok := service.Labels[korifiv1alpha1.CFRouteGUIDLabelKey]; ok && owner != cfRoute.Name {
+			return fmt.Errorf("service %s/%s belongs to route %s", service.Namespace, service.Name, owner)
+		}
+
+		service.Labels = map[string]string{
+			korifiv1alpha1.CFRouteGUIDLabelKey:  cfRoute.Name,
+			korifiv1alpha1.CFDomainGUIDLabelKey: cfDomain.Name,
+		}
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.Selector = nil
+		service.Spec.Ports = toInternalServicePorts(destinations)
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch internal route service", "reason", err)
+		return err
+	}
+	log.V(1).Info("internal route service reconciled", "operation", result)
+
+	desiredSlices := []string{}
+	for _, destination := range destinations {
+		sliceNames, err := r.mirrorEndpointSlices(ctx, cfRoute, service, destination)
+		if err != nil {
+			log.Info("failed to mirror endpoint slices", "destination", destination.GUID, "reason", err)
+			return err
+		}
+		desiredSlices = append(desiredSlices, sliceNames...)
+	}
+
+	return r.deleteOrphanedEndpointSlices(ctx, cfRoute, service, desiredSlices)
+}
+
+// mirrorEndpointSlices copies the endpoints of the destination service into
+// slices of the internal route service, one per address type
+func (r *Reconciler) mirrorEndpointSlices(
+	ctx context.Context,
+	cfRoute *korifiv1alpha1.CFRoute,
+	service *corev1.Service,
+	destination korifiv1alpha1.Destination,
+) ([]string, error) {
+	sourceSlices := &discoveryv1.EndpointSliceList{}
+	if err := r.client.List(ctx, sourceSlices,
+		client.InNamespace(destination.AppNamespace(cfRoute.Namespace)),
+		client.MatchingLabels{discoveryv1.LabelServiceName: generateServiceName(destination)},
+	); err != nil {
+		return nil, err
+	}
+
+	endpointsByAddressType := map[discoveryv1.AddressType][]discoveryv1.Endpoint{
+		discoveryv1.AddressTypeIPv4: {},
+	}
+	for _, sourceSlice := range sourceSlices.Items {
+		for _, endpoint := range sourceSlice.Endpoints {
+			endpointsByAddressType[sourceSlice.AddressType] = append(endpointsByAddressType[sourceSlice.AddressType], discoveryv1.Endpoint{
+				Addresses:  endpoint.Addresses,
+				Conditions: endpoint.Conditions,
+				NodeName:   endpoint.NodeName,
+				Zone:       endpoint.Zone,
+				TargetRef:  endpoint.TargetRef,
+			})
+		}
+	}
+
+	sliceNames := []string{}
+	for addressType, endpoints := range endpointsByAddressType {
+		endpointSlice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", generateServiceName(destination), strings.ToLower(string(addressType))),
+				Namespace: service.Namespace,
+			},
+		}
+
+		_, err := controllerutil.CreateOrPatch(ctx, r.client, endpointSlice, func() error {
+			endpointSlice.Labels = map[string]string{
+				discoveryv1.LabelServiceName:       service.Name,
+				discoveryv1.LabelManagedBy:         InternalRouteEndpointsManager,
+				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+			}
+			endpointSlice.AddressType = addressType
+			endpointSlice.Endpoints = endpoints
+			endpointSlice.Ports = []discoveryv1.EndpointPort{{
+				Name:     tools.PtrTo(toInternalServicePortName(*destination.Port)),
+				Port:     destination.Port,
+				Protocol: tools.PtrTo(corev1.ProtocolTCP),
+			}}
+
+			return controllerutil.SetControllerReference(service, endpointSlice, r.scheme)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sliceNames = append(sliceNames, endpointSlice.Name)
+	}
+
+	return sliceNames, nil
+}
+
+func (r *Reconciler) deleteOrphanedEndpointSlices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, service *corev1.Service, desiredSlices []string) error {
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+	if err := r.client.List(ctx, endpointSlices,
+		client.InNamespace(service.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name},
+	); err != nil {
+		return err
+	}
+
+	for i, endpointSlice := range endpointSlices.Items {
+		if slices.Contains(desiredSlices, endpointSlice.Name) {
+			continue
+		}
+
+		if err := r.client.Delete(ctx, &endpointSlices.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deleteInternalRouteService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	cfDomain := &korifiv1alpha1.CFDomain{}
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: cfRoute.Spec.DomainRef.Namespace, Name: cfRoute.Spec.DomainRef.Name}, cfDomain)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !cfDomain.Spec.Internal {
+		return nil
+	}
+
+	service := &corev1.Service{}
+	err = r.client.Get(ctx, client.ObjectKey{Namespace: cfDomain.Name, Name: strings.ToLower(cfRoute.Spec.Host)}, service)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if service.Labels[korifiv1alpha1.CFRouteGUIDLabelKey] != cfRoute.Name {
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.client.Delete(ctx, service))
+}
+
+func toInternalServicePorts(destinations []korifiv1alpha1.Destination) []corev1.ServicePort {
+	ports := []corev1.ServicePort{}
+	for _, destination := range destinations {
+		if slices.ContainsFunc(ports, func(port corev1.ServicePort) bool {
+			return port.Port == *destination.Port
+		}) {
+			continue
+		}
+
+		ports = append(ports, corev1.ServicePort{
+			Name:     toInternalServicePortName(*destination.Port),
+			Port:     *destination.Port,
+			Protocol: corev1.ProtocolTCP,
+		})
+	}
+
+	slices.SortFunc(ports, func(a, b corev1.ServicePort) int {
+		return int(a.Port - b.Port)
+	})
+
+	return ports
+}
+
+func toInternalServicePortName(port int32) string {
+	return fmt.Sprintf("port-%d", port)
+}
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// reconcileReferenceGrants allows the gateway routes of the route to send
+// traffic to the destination services in shared spaces. Each shared space with
+// destinations gets a ReferenceGrant named after the route, which only grants
+// access to the services of the route destinations in that space.
+func (r *Reconciler) reconcileReferenceGrants(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, canaries map[string]destinationCanary) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileReferenceGrants")
+
+	serviceNamesByNamespace := map[string][]string{}
+	for _, destination := range cfRoute.Status.Destinations {
+		appNamespace := destination.AppNamespace(cfRoute.Namespace)
+		if appNamespace == cfRoute.Namespace || destination.Port == nil {
+			continue
+		}
+
+		serviceNamesByNamespace[appNamespace] = append(serviceNamesByNamespace[appNamespace], generateServiceName(destination))
+		if _, hasCanary := canaries[destination.GUID]; hasCanary {
+			serviceNamesByNamespace[appNamespace] = append(serviceNamesByNamespace[appNamespace], generateCanaryServiceName(destination))
+		}
+	}
+
+	for namespace, serviceNames := range serviceNamesByNamespace {
+		referenceGrant := &gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfRoute.Name,
+				Namespace: namespace,
+			},
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.client, referenceGrant, func() error {
+			referenceGrant.Labels = map[string]string{
+				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+				korifiv1alpha1.SpaceGUIDKey:        cfRoute.Namespace,
+			}
+
+			referenceGrant.Spec.From = []gatewayv1beta1.ReferenceGrantFrom{
+				{
+					Group:     gatewayv1beta1.GroupName,
+					Kind:      "HTTPRoute",
+					Namespace: gatewayv1beta1.Namespace(cfRoute.Namespace),
+				},
+				{
+					Group:     gatewayv1beta1.GroupName,
+					Kind:      "TCPRoute",
+					Namespace: gatewayv1beta1.Namespace(cfRoute.Namespace),
+				},
+			}
+
+			referenceGrant.Spec.To = []gatewayv1beta1.ReferenceGrantTo{}
+			for _, serviceName := range serviceNames {
+				referenceGrant.Spec.To = append(referenceGrant.Spec.To, gatewayv1beta1.ReferenceGrantTo{
+					Group: "",
+					Kind:  "Service",
+					Name:  tools.PtrTo(gatewayv1beta1.ObjectName(serviceName)),
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			log.Info("failed to create/patch ReferenceGrant", "namespace", namespace, "reason", err)
+			return err
+		}
+
+		log.V(1).Info("ReferenceGrant reconciled", "namespace", namespace, "operation", result)
+	}
+
+	return r.deleteReferenceGrants(ctx, cfRoute, func(referenceGrant gatewayv1beta1.ReferenceGrant) bool {
+		_, desired := serviceNamesByNamespace[referenceGrant.Namespace]
+		return !desired
+	})
+}
+
+// deleteSharedSpaceResources deletes the destination services and reference
+// grants of the route in shared spaces
+func (r *Reconciler) deleteSharedSpaceResources(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	serviceList, err := r.fetchDestinationServices(ctx, cfRoute)
+	if err != nil {
+		return err
+	}
+
+	for i := range serviceList.Items {
+		if serviceList.Items[i].Namespace == cfRoute.Namespace {
+			continue
+		}
+
+		if err = r.client.Delete(ctx, &serviceList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return r.deleteReferenceGrants(ctx, cfRoute, func(gatewayv1beta1.ReferenceGrant) bool {
+		return true
+	})
+}
+
+func (r *Reconciler) deleteReferenceGrants(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, shouldDelete func(gatewayv1beta1.ReferenceGrant) bool) error {
+	referenceGrants := &gatewayv1beta1.ReferenceGrantList{}
+	if err := r.client.List(ctx, referenceGrants, client.MatchingLabels{
+		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		korifiv1alpha1.SpaceGUIDKey:        cfRoute.Namespace,
+	}); err != nil {
+		return err
+	}
+
+	for i, referenceGrant := range referenceGrants.Items {
+		if !shouldDelete(referenceGrant) {
+			continue
+		}
+
+		if err := r.client.Delete(ctx, &referenceGrants.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+
+	return nil
+}
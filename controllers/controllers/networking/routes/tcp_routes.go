@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// reconcileTCPRoute exposes routes on domains with a router group through a
+// TCPRoute attached to the gateway listener of the route port. Routes on
+// other domains are left alone, so that the (experimental) TCPRoute CRD is
+// only required when router groups are configured.
+func (r *Reconciler) reconcileTCPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, canaries map[string]destinationCanary) error {
+	if !isTCPRoute(cfRoute, cfDomain) {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileTCPRoute").WithValues("domain", cfDomain.Spec.Name, "port", *cfRoute.Spec.Port)
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	if len(cfRoute.Status.Destinations) == 0 {
+		if err := r.client.Delete(ctx, tcpRoute); client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete existing TCPRoute", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, tcpRoute, func() error {
+		tcpRoute.Spec.ParentRefs = []gatewayv1alpha2.ParentReference{{
+			Group:       tools.PtrTo(gatewayv1alpha2.Group("gateway.networking.k8s.io")),
+			Kind:        tools.PtrTo(gatewayv1alpha2.Kind("Gateway")),
+			Namespace:   tools.PtrTo(gatewayv1alpha2.Namespace(r.controllerConfig.Networking.GatewayNamespace)),
+			Name:        gatewayv1alpha2.ObjectName(r.controllerConfig.Networking.GatewayName),
+			SectionName: tools.PtrTo(gatewayv1alpha2.SectionName(TCPListenerName(*cfRoute.Spec.Port))),
+		}}
+
+		backendRefs := []gatewayv1alpha2.BackendRef{}
+		for _, httpBackendRef := range toBackendRefs(cfRoute, canaries) {
+			backendRefs = append(backendRefs, httpBackendRef.BackendRef)
+		}
+		tcpRoute.Spec.Rules = []gatewayv1alpha2.TCPRouteRule{{
+			BackendRefs: backendRefs,
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, tcpRoute, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch TCPRoute", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("TCPRoute reconciled", "operation", result)
+	return nil
+}
+
+// TCPListenerName is the name of the gateway listener serving the given
+// router group port
+func TCPListenerName(port int32) string {
+	return fmt.Sprintf("tcp-%d", port)
+}
+
+func isTCPRoute(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) bool {
+	return cfDomain.Spec.RouterGroup != "" && cfRoute.Spec.Port != nil
+}
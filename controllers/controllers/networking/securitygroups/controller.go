@@ -0,0 +1,96 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Reconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFSecurityGroup, *korifiv1alpha1.CFSecurityGroup] {
+	securityGroupReconciler := Reconciler{client: client, scheme: scheme, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFSecurityGroup, *korifiv1alpha1.CFSecurityGroup](log, client, &securityGroupReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFSecurityGroup{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/status,verbs=get;patch
+
+// ReconcileResource reports the rules of the CFSecurityGroup that cannot be
+// rendered into network policies in the RulesEnforced condition. The network
+// policies themselves are reconciled by the CFSpace controller, which skips
+// these rules.
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfSecurityGroup.Status.ObservedGeneration = cfSecurityGroup.Generation
+	log.V(1).Info("set observed generation", "generation", cfSecurityGroup.Status.ObservedGeneration)
+
+	var skippedRules []string
+	for i, rule := range cfSecurityGroup.Spec.Rules {
+		if _, err := shared.ToEgressRule(rule); err != nil {
+			skippedRules = append(skippedRules, fmt.Sprintf("rule %d (%s %s): %s", i, rule.Protocol, rule.Destination, err))
+		}
+	}
+
+	if len(skippedRules) > 0 {
+		log.Info("security group rules are not enforced", "rules", skippedRules)
+		meta.SetStatusCondition(&cfSecurityGroup.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.SecurityGroupRulesEnforcedConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "UnsupportedRules",
+			Message:            "The following rules are skipped: " + strings.Join(skippedRules, "; "),
+			ObservedGeneration: cfSecurityGroup.Generation,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&cfSecurityGroup.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SecurityGroupRulesEnforcedConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AllRulesEnforced",
+		ObservedGeneration: cfSecurityGroup.Generation,
+	})
+	return ctrl.Result{}, nil
+}
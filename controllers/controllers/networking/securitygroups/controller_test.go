@@ -0,0 +1,109 @@
+package securitygroups_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFSecurityGroupReconciler Integration Tests", func() {
+	var (
+		namespace       string
+		cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+	)
+
+	BeforeEach(func() {
+		namespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		cfSecurityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: uuid.NewString(),
+				Rules: []securitygroups.Rule{
+					{Protocol: securitygroups.ProtocolTCP, Destination: "10.0.0.0/24", Ports: "443"},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+	})
+
+	It("sets the ready condition and the observed generation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			g.Expect(cfSecurityGroup.Status.ObservedGeneration).To(Equal(cfSecurityGroup.Generation))
+		}).Should(Succeed())
+	})
+
+	It("sets the rules enforced condition to true", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.SecurityGroupRulesEnforcedConditionType)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	When("the security group has rules that network policies cannot enforce", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Rules = append(cfSecurityGroup.Spec.Rules,
+				securitygroups.Rule{Protocol: securitygroups.ProtocolICMP, Destination: "10.0.0.0/24", Type: tools.PtrTo[int32](8), Code: tools.PtrTo[int32](0)},
+				securitygroups.Rule{Protocol: securitygroups.ProtocolTCP, Destination: "not-an-ip", Ports: "443"},
+			)
+		})
+
+		It("reports the skipped rules in the rules enforced condition", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+
+				condition := meta.FindStatusCondition(cfSecurityGroup.Status.Conditions, korifiv1alpha1.SecurityGroupRulesEnforcedConditionType)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal("UnsupportedRules"))
+				g.Expect(condition.Message).To(ContainSubstring("rule 1 (icmp 10.0.0.0/24)"))
+				g.Expect(condition.Message).To(ContainSubstring("rule 2 (tcp not-an-ip)"))
+				g.Expect(condition.Message).NotTo(ContainSubstring("rule 0"))
+			}).Should(Succeed())
+		})
+
+		It("keeps the security group ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the unsupported rules are removed", func() {
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfSecurityGroup, func() {
+					cfSecurityGroup.Spec.Rules = cfSecurityGroup.Spec.Rules[:1]
+				})).To(Succeed())
+			})
+
+			It("sets the rules enforced condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.SecurityGroupRulesEnforcedConditionType)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+})
@@ -26,6 +26,7 @@ import (
 
 	"github.com/go-logr/logr"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -84,22 +85,28 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 func (r *Reconciler) serviceInstanceToServiceBindings(ctx context.Context, o client.Object) []reconcile.Request {
 	serviceInstance := o.(*korifiv1alpha1.CFServiceInstance)
 
-	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
-	if err := r.k8sClient.List(ctx, &serviceBindings,
-		client.InNamespace(serviceInstance.Namespace),
-		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: serviceInstance.Name},
-	); err != nil {
-		return []reconcile.Request{}
-	}
-
 	requests := []reconcile.Request{}
-	for _, sb := range serviceBindings.Items {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Name:      sb.Name,
-				Namespace: sb.Namespace,
-			},
-		})
+	for _, namespace := range append([]string{serviceInstance.Namespace}, serviceInstance.Spec.SharedSpaces...) {
+		serviceBindings := korifiv1alpha1.CFServiceBindingList{}
+		if err := r.k8sClient.List(ctx, &serviceBindings,
+			client.InNamespace(namespace),
+			client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: serviceInstance.Name},
+		); err != nil {
+			return []reconcile.Request{}
+		}
+
+		for _, sb := range serviceBindings.Items {
+			if sb.ServiceInstanceNamespace() != serviceInstance.Namespace {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      sb.Name,
+					Namespace: sb.Namespace,
+				},
+			})
+		}
 	}
 
 	return requests
@@ -117,18 +124,33 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 	log.V(1).Info("set observed generation", "generation", cfServiceBinding.Status.ObservedGeneration)
 
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceInstanceNamespace()}, cfServiceInstance)
 	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return r.reconcileOrphanedBinding(ctx, cfServiceBinding)
+		}
+
 		log.Info("service instance not found", "service-instance", cfServiceBinding.Spec.Service.Name, "error", err)
 		return ctrl.Result{}, err
 	}
 
 	cfServiceBinding.Annotations = tools.SetMapValue(cfServiceBinding.Annotations, korifiv1alpha1.ServiceInstanceTypeAnnotationKey, string(cfServiceInstance.Spec.Type))
 
-	err = controllerutil.SetOwnerReference(cfServiceInstance, cfServiceBinding, r.scheme)
-	if err != nil {
-		log.Info("error when making the service instance owner of the service binding", "reason", err)
-		return ctrl.Result{}, err
+	if cfServiceBinding.IsToSharedServiceInstance() {
+		// owner references cannot cross namespaces, shared service instances
+		// do not own the bindings in the spaces they are shared with
+		if cfServiceBinding.GetDeletionTimestamp().IsZero() && !cfServiceInstance.IsSharedWith(cfServiceBinding.Namespace) {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("ServiceInstanceNotShared").
+				WithMessage("The service instance is not shared with the space of the binding").
+				WithNoRequeue()
+		}
+	} else {
+		err = controllerutil.SetOwnerReference(cfServiceInstance, cfServiceBinding, r.scheme)
+		if err != nil {
+			log.Info("error when making the service instance owner of the service binding", "reason", err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	res, err := r.reconcileByType(ctx, cfServiceInstance, cfServiceBinding)
@@ -142,6 +164,30 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 	return ctrl.Result{}, nil
 }
 
+// reconcileOrphanedBinding cleans up bindings whose service instance has been
+// deleted. Bindings to shared service instances are not garbage collected
+// together with the instance, so they are deleted here.
+func (r *Reconciler) reconcileOrphanedBinding(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		if controllerutil.RemoveFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+			log.V(1).Info("finalizer removed as the service instance no longer exists")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if cfServiceBinding.IsToSharedServiceInstance() {
+		log.Info("deleting binding to deleted shared service instance", "service-instance", cfServiceBinding.Spec.Service.Name)
+		return ctrl.Result{}, client.IgnoreNotFound(r.k8sClient.Delete(ctx, cfServiceBinding))
+	}
+
+	return ctrl.Result{}, k8s.NewNotReadyError().
+		WithReason("ServiceInstanceNotFound").
+		WithMessage("The service instance does not exist").
+		WithRequeue()
+}
+
 func (r *Reconciler) reconcileByType(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
 	if cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
 		return r.upsiReconciler.ReconcileResource(ctx, cfServiceBinding, cfServiceInstance)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/model/services"
@@ -461,6 +462,156 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the binding is to an instance shared from another namespace", func() {
+			var (
+				sharedNamespace string
+				sharedBinding   *korifiv1alpha1.CFServiceBinding
+			)
+
+			BeforeEach(func() {
+				sharedNamespace = uuid.NewString()
+				Expect(adminClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: sharedNamespace,
+					},
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.SharedSpaces = []string{sharedNamespace}
+				})).To(Succeed())
+
+				sharedBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: sharedNamespace,
+						Finalizers: []string{
+							korifiv1alpha1.CFServiceBindingFinalizerName,
+						},
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Service: corev1.ObjectReference{
+							Kind:       "ServiceInstance",
+							Name:       instanceGUID,
+							Namespace:  testNamespace,
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+						},
+						AppRef: corev1.LocalObjectReference{
+							Name: uuid.NewString(),
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedBinding)).To(Succeed())
+			})
+
+			It("propagates the instance credentials to the namespace of the binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)).To(Succeed())
+					g.Expect(sharedBinding.Status.Credentials.Name).To(Equal(sharedBinding.Name + "-credentials"))
+
+					credentialsSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: sharedNamespace, Name: sharedBinding.Status.Credentials.Name}, credentialsSecret)).To(Succeed())
+					g.Expect(credentialsSecret.Data).To(Equal(instanceCredentialsSecret.Data))
+				}).Should(Succeed())
+			})
+
+			It("creates the binding secret in the namespace of the binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)).To(Succeed())
+					g.Expect(sharedBinding.Status.Binding.Name).To(Equal(sharedBinding.Name))
+
+					bindingSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: sharedNamespace, Name: sharedBinding.Name}, bindingSecret)).To(Succeed())
+				}).Should(Succeed())
+			})
+
+			It("does not set an owner reference from the instance to the binding", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)).To(Succeed())
+					g.Expect(sharedBinding.OwnerReferences).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			When("the instance is no longer shared with the namespace of the binding", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Spec.SharedSpaces = nil
+					})).To(Succeed())
+				})
+
+				It("sets the binding Ready status condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)).To(Succeed())
+						g.Expect(sharedBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("ServiceInstanceNotShared")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the instance is deleted", func() {
+				BeforeEach(func() {
+					Expect(adminClient.Delete(ctx, instance)).To(Succeed())
+				})
+
+				It("deletes the binding", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), sharedBinding)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the binding is a service key", func() {
+			var keyBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				keyBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						DisplayName: tools.PtrTo("my-key"),
+						Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+						Service: corev1.ObjectReference{
+							Kind:       "ServiceInstance",
+							Name:       instanceGUID,
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, keyBinding)).To(Succeed())
+			})
+
+			It("sets the binding status credentials name to the instance credentials secret", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(keyBinding), keyBinding)).To(Succeed())
+					g.Expect(keyBinding.Status.Credentials.Name).To(Equal(instanceCredentialsSecret.Name))
+				}).Should(Succeed())
+			})
+
+			It("sets the binding Ready status condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(keyBinding), keyBinding)).To(Succeed())
+					g.Expect(keyBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+
+			It("does not create a servicebinding.io ServiceBinding", func() {
+				Consistently(func(g Gomega) {
+					sbServiceBinding := &servicebindingv1beta1.ServiceBinding{}
+					err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: fmt.Sprintf("cf-binding-%s", keyBinding.Name)}, sbServiceBinding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
 		When("the binding is deleted", func() {
 			JustBeforeEach(func() {
 				Expect(adminClient.Delete(ctx, binding)).To(Succeed())
@@ -988,6 +1139,123 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the binding is a service key", func() {
+			var keyBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				keyBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						DisplayName: tools.PtrTo("my-key"),
+						Type:        korifiv1alpha1.CFServiceBindingTypeKey,
+						Service: corev1.ObjectReference{
+							Kind:       "ServiceInstance",
+							Name:       instanceGUID,
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, keyBinding)).To(Succeed())
+			})
+
+			It("binds the key with the credential client id", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 0))
+					var keyPayloads []osbapi.BindPayload
+					for i := range brokerClient.BindCallCount() {
+						_, payload := brokerClient.BindArgsForCall(i)
+						if payload.BindingID == keyBinding.Name {
+							keyPayloads = append(keyPayloads, payload)
+						}
+					}
+					g.Expect(keyPayloads).NotTo(BeEmpty())
+					g.Expect(keyPayloads[0]).To(Equal(osbapi.BindPayload{
+						InstanceID: instance.Name,
+						BindingID:  keyBinding.Name,
+						BindRequest: osbapi.BindRequest{
+							ServiceId: "service-offering-id",
+							PlanID:    "service-plan-id",
+							BindResource: osbapi.BindResource{
+								CredentialClientID: managed.ServiceKeyCredentialClientID,
+							},
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			It("stores the credentials in a secret and becomes ready", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(keyBinding), keyBinding)).To(Succeed())
+					g.Expect(keyBinding.Status.Credentials.Name).To(Equal(keyBinding.Name))
+					g.Expect(keyBinding.Status.Binding.Name).To(BeEmpty())
+					g.Expect(keyBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+
+					credentialsSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: keyBinding.Name}, credentialsSecret)).To(Succeed())
+					g.Expect(credentialsSecret.Data).To(MatchAllKeys(Keys{
+						tools.CredentialsSecretKey: MatchJSON(`{"foo":"bar"}`),
+					}))
+				}).Should(Succeed())
+			})
+
+			It("does not create a servicebinding.io ServiceBinding", func() {
+				Consistently(func(g Gomega) {
+					sbServiceBinding := &servicebindingv1beta1.ServiceBinding{}
+					err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: fmt.Sprintf("cf-binding-%s", keyBinding.Name)}, sbServiceBinding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the binding is a route binding", func() {
+			var routeBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				routeBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Type: korifiv1alpha1.CFServiceBindingTypeRoute,
+						Service: corev1.ObjectReference{
+							Kind:       "ServiceInstance",
+							Name:       instanceGUID,
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+						},
+						RouteRef: corev1.LocalObjectReference{
+							Name: uuid.NewString(),
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, routeBinding)).To(Succeed())
+			})
+
+			It("does not bind the route as route services are not supported", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("RouteBindingNotSupported")),
+					)))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					for i := range brokerClient.BindCallCount() {
+						_, payload := brokerClient.BindArgsForCall(i)
+						g.Expect(payload.BindingID).NotTo(Equal(routeBinding.Name))
+					}
+				}).Should(Succeed())
+			})
+		})
+
 		When("the binding is deleted", func() {
 			BeforeEach(func() {
 				brokerClient.UnbindReturns(osbapi.UnbindResponse{}, nil)
@@ -999,12 +1267,86 @@ var _ = Describe("CFServiceBinding", func() {
 				Expect(k8sManager.GetClient().Delete(ctx, binding)).To(Succeed())
 			})
 
+			It("unbinds the binding with the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UnbindCallCount()).To(BeNumerically(">", 0))
+					_, payload := brokerClient.UnbindArgsForCall(0)
+					g.Expect(payload).To(Equal(osbapi.UnbindPayload{
+						InstanceID: instance.Name,
+						BindingID:  binding.Name,
+						UnbindRequestParameters: osbapi.UnbindRequestParameters{
+							ServiceId: "service-offering-id",
+							PlanID:    "service-plan-id",
+						},
+					}))
+				}).Should(Succeed())
+			})
+
 			It("deletes the binding", func() {
 				Eventually(func(g Gomega) {
 					err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
 					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
 				}).Should(Succeed())
 			})
+
+			When("the binding is gone at the broker", func() {
+				BeforeEach(func() {
+					brokerClient.UnbindReturns(osbapi.UnbindResponse{}, osbapi.GoneError{})
+				})
+
+				It("deletes the binding", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+
+			When("unbinding fails", func() {
+				BeforeEach(func() {
+					brokerClient.UnbindReturns(osbapi.UnbindResponse{}, errors.New("unbind-failed"))
+				})
+
+				It("does not delete the binding", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					}).Should(Succeed())
+				})
+			})
+
+			When("unbinding is asynchronous", func() {
+				BeforeEach(func() {
+					brokerClient.UnbindReturns(osbapi.UnbindResponse{Operation: "unbind-operation"}, nil)
+					brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+						State: "in progress",
+					}, nil)
+				})
+
+				It("keeps checking the unbind last operation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.GetServiceBindingLastOperationCallCount()).To(BeNumerically(">", 1))
+						_, lastOp := brokerClient.GetServiceBindingLastOperationArgsForCall(1)
+						g.Expect(lastOp.Operation).To(Equal("unbind-operation"))
+					}).Should(Succeed())
+
+					Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				})
+
+				When("the unbind operation succeeds", func() {
+					BeforeEach(func() {
+						brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+							State: "succeeded",
+						}, nil)
+					})
+
+					It("deletes the binding", func() {
+						Eventually(func(g Gomega) {
+							err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+							g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+						}).Should(Succeed())
+					})
+				})
+			})
 		})
 	})
 })
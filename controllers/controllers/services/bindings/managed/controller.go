@@ -2,6 +2,7 @@ package managed
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/sbio"
@@ -23,6 +24,10 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+// ServiceKeyCredentialClientID is sent to brokers as the
+// bind_resource.credential_client_id when binding service keys
+const ServiceKeyCredentialClientID = "korifi"
+
 type ManagedBindingsReconciler struct {
 	k8sClient           client.Client
 	osbapiClientFactory osbapi.BrokerClientFactory
@@ -42,10 +47,6 @@ func NewReconciler(k8sClient client.Client, brokerClientFactory osbapi.BrokerCli
 func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("reconcile-managed-service-binding")
 
-	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
-		return r.finalizeCFServiceBinding(ctx, cfServiceBinding)
-	}
-
 	cfServiceBinding.Labels = tools.SetMapValue(cfServiceBinding.Labels, korifiv1alpha1.PlanGUIDLabelKey, cfServiceInstance.Spec.PlanGUID)
 
 	assets, err := r.assets.GetServiceBindingAssets(ctx, cfServiceBinding)
@@ -60,6 +61,19 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return ctrl.Result{}, err
 	}
 
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFServiceBinding(ctx, cfServiceBinding, assets, osbapiClient)
+	}
+
+	// Route services expect the router to sign the requests it forwards to
+	// them, which the gateway cannot do, so route bindings are never bound
+	if cfServiceBinding.IsRoute() {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("RouteBindingNotSupported").
+			WithMessage("Route services are not supported").
+			WithNoRequeue()
+	}
+
 	if isReconciled(cfServiceBinding) {
 		return ctrl.Result{}, nil
 	}
@@ -78,6 +92,10 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return ctrl.Result{}, err
 	}
 
+	if cfServiceBinding.IsKey() {
+		return ctrl.Result{}, nil
+	}
+
 	sbServiceBinding, err := r.reconcileSBServiceBinding(ctx, cfServiceBinding)
 	if err != nil {
 		log.Info("error creating/updating servicebinding.io servicebinding", "reason", err)
@@ -112,17 +130,25 @@ func (r *ManagedBindingsReconciler) requestBind(
 ) (map[string]any, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
-	bindResponse, err := osbapiClient.Bind(ctx, osbapi.BindPayload{
-		BindingID:  cfServiceBinding.Name,
-		InstanceID: assets.ServiceInstance.Name,
-		BindRequest: osbapi.BindRequest{
-			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
-			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
-			AppGUID:   cfServiceBinding.Spec.AppRef.Name,
-			BindResource: osbapi.BindResource{
-				AppGUID: cfServiceBinding.Spec.AppRef.Name,
-			},
+	bindRequest := osbapi.BindRequest{
+		ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		AppGUID:   cfServiceBinding.Spec.AppRef.Name,
+		BindResource: osbapi.BindResource{
+			AppGUID: cfServiceBinding.Spec.AppRef.Name,
 		},
+	}
+	if cfServiceBinding.IsKey() {
+		bindRequest.AppGUID = ""
+		bindRequest.BindResource = osbapi.BindResource{
+			CredentialClientID: ServiceKeyCredentialClientID,
+		}
+	}
+
+	bindResponse, err := osbapiClient.Bind(ctx, osbapi.BindPayload{
+		BindingID:   cfServiceBinding.Name,
+		InstanceID:  assets.ServiceInstance.Name,
+		BindRequest: bindRequest,
 	})
 	if err != nil {
 		log.Error(err, "failed to bind service")
@@ -231,6 +257,10 @@ func (r *ManagedBindingsReconciler) reconcileCredentials(ctx context.Context, cf
 	}
 	cfServiceBinding.Status.Credentials.Name = credentialsSecret.Name
 
+	if cfServiceBinding.IsKey() {
+		return nil
+	}
+
 	bindingSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfServiceBinding.Name + "-sbio",
@@ -259,15 +289,115 @@ func (r *ManagedBindingsReconciler) reconcileCredentials(ctx context.Context, cf
 func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 	ctx context.Context,
 	serviceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
 ) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalize-managed-service-binding")
 
+	if !controllerutil.ContainsFinalizer(serviceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.unbind(ctx, serviceBinding, assets, osbapiClient); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if controllerutil.RemoveFinalizer(serviceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
 	return ctrl.Result{}, nil
 }
 
+func (r *ManagedBindingsReconciler) unbind(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) error {
+	if !isUnbindRequested(cfServiceBinding) {
+		return r.requestUnbind(ctx, cfServiceBinding, assets, osbapiClient)
+	}
+
+	return r.pollUnbindOperation(ctx, cfServiceBinding, assets, osbapiClient)
+}
+
+func (r *ManagedBindingsReconciler) requestUnbind(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
+		BindingID:  cfServiceBinding.Name,
+		InstanceID: assets.ServiceInstance.Name,
+		UnbindRequestParameters: osbapi.UnbindRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "failed to unbind service")
+		return err
+	}
+
+	if err != nil || unbindResponse.IsComplete() {
+		return nil
+	}
+
+	cfServiceBinding.Status.UnbindingOperation = unbindResponse.Operation
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.UnbindingRequestedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "UnbindingRequested",
+	})
+
+	return k8s.NewNotReadyError().WithReason("UnbindingInProgress").WithRequeue()
+}
+
+func (r *ManagedBindingsReconciler) pollUnbindOperation(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	lastOperation, err := osbapiClient.GetServiceBindingLastOperation(ctx, osbapi.GetServiceBindingLastOperationRequest{
+		InstanceID: cfServiceBinding.Spec.Service.Name,
+		BindingID:  cfServiceBinding.Name,
+		GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			Operation: cfServiceBinding.Status.UnbindingOperation,
+		},
+	})
+	if err != nil {
+		if errors.As(err, &osbapi.GoneError{}) {
+			return nil
+		}
+
+		log.Error(err, "failed to get last operation", "operation", cfServiceBinding.Status.UnbindingOperation)
+		return k8s.NewNotReadyError().WithCause(err).WithReason("GetLastOperationFailed")
+	}
+
+	if lastOperation.State == "in progress" {
+		log.Info("unbinding operation in progress", "operation", cfServiceBinding.Status.UnbindingOperation)
+		return k8s.NewNotReadyError().WithReason("UnbindingInProgress").WithRequeue()
+	}
+
+	if lastOperation.State == "failed" {
+		log.Error(nil, "unbinding operation has failed", "operation", cfServiceBinding.Status.UnbindingOperation, "description", lastOperation.Description)
+		meta.RemoveStatusCondition(&cfServiceBinding.Status.Conditions, korifiv1alpha1.UnbindingRequestedCondition)
+		return k8s.NewNotReadyError().WithReason("UnbindingFailed").WithMessage(lastOperation.Description).WithRequeue()
+	}
+
+	return nil
+}
+
 func (r *ManagedBindingsReconciler) reconcileSBServiceBinding(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (*servicebindingv1beta1.ServiceBinding, error) {
 	sbServiceBinding := sbio.ToSBServiceBinding(cfServiceBinding, korifiv1alpha1.ManagedType)
 
@@ -285,10 +415,18 @@ func isBindRequested(binding *korifiv1alpha1.CFServiceBinding) bool {
 	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingRequestedCondition)
 }
 
+func isUnbindRequested(binding *korifiv1alpha1.CFServiceBinding) bool {
+	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.UnbindingRequestedCondition)
+}
+
 func isFailed(binding *korifiv1alpha1.CFServiceBinding) bool {
 	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingFailedCondition)
 }
 
 func isReconciled(binding *korifiv1alpha1.CFServiceBinding) bool {
+	if binding.IsKey() {
+		return binding.Status.Credentials.Name != ""
+	}
+
 	return binding.Status.Credentials.Name != "" && binding.Status.Binding.Name != ""
 }
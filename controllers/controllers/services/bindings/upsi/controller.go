@@ -45,6 +45,13 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 		return ctrl.Result{}, nil
 	}
 
+	if cfServiceBinding.IsRoute() {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("RouteBindingNotSupported").
+			WithMessage("Route services are not supported").
+			WithNoRequeue()
+	}
+
 	if cfServiceInstance.Status.Credentials.Name == "" {
 		return ctrl.Result{}, k8s.NewNotReadyError().
 			WithReason("CredentialsSecretNotAvailable").
@@ -52,6 +59,11 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 			WithRequeueAfter(time.Second)
 	}
 
+	if cfServiceBinding.IsKey() {
+		cfServiceBinding.Status.Credentials.Name = cfServiceInstance.Status.Credentials.Name
+		return ctrl.Result{}, nil
+	}
+
 	bindingSecret, err := r.reconcileCredentials(ctx, cfServiceInstance, cfServiceBinding)
 	if err != nil {
 		if k8serrors.IsInvalid(err) {
@@ -95,6 +107,13 @@ func (r *UPSIBindingReconciler) reconcileCredentials(ctx context.Context, cfServ
 		return nil, fmt.Errorf("failed to get service instance credentials secret %q: %w", cfServiceInstance.Status.Credentials.Name, err)
 	}
 
+	if cfServiceBinding.IsToSharedServiceInstance() {
+		err = r.propagateCredentials(ctx, cfServiceBinding, credentialsSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	bindingSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfServiceBinding.Name,
@@ -123,6 +142,31 @@ func (r *UPSIBindingReconciler) reconcileCredentials(ctx context.Context, cfServ
 	return bindingSecret, nil
 }
 
+// propagateCredentials copies the credentials of a shared service instance to
+// the namespace of the binding, where the apps can read them
+func (r *UPSIBindingReconciler) propagateCredentials(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, credentialsSecret *corev1.Secret) error {
+	propagatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name + "-credentials",
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.k8sClient, propagatedSecret, func() error {
+		propagatedSecret.Type = credentialsSecret.Type
+		propagatedSecret.Data = credentialsSecret.Data
+
+		return controllerutil.SetControllerReference(cfServiceBinding, propagatedSecret, r.scheme)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to propagate service instance credentials")
+	}
+
+	cfServiceBinding.Status.Credentials.Name = propagatedSecret.Name
+
+	return nil
+}
+
 func (r *UPSIBindingReconciler) reconcileSBServiceBinding(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, bindingSecret *corev1.Secret) (*servicebindingv1beta1.ServiceBinding, error) {
 	sbServiceBinding := sbio.ToSBServiceBinding(cfServiceBinding, korifiv1alpha1.UserProvidedType)
 
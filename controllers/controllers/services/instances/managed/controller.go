@@ -18,6 +18,7 @@ package managed
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"slices"
@@ -26,6 +27,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/model/services"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -46,6 +48,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+type UsageRecorder interface {
+	RecordServiceInstanceUsage(context.Context, *korifiv1alpha1.CFServiceInstance, usage.ServicePlanDetails) error
+}
+
 type Reconciler struct {
 	k8sClient           client.Client
 	osbapiClientFactory osbapi.BrokerClientFactory
@@ -53,6 +59,7 @@ type Reconciler struct {
 	rootNamespace       string
 	log                 logr.Logger
 	assets              *osbapi.Assets
+	usageRecorder       UsageRecorder
 }
 
 func NewReconciler(
@@ -61,6 +68,7 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	rootNamespace string,
 	log logr.Logger,
+	usageRecorder UsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
 	return k8s.NewPatchingReconciler(log, client, &Reconciler{
 		k8sClient:           client,
@@ -69,6 +77,7 @@ func NewReconciler(
 		rootNamespace:       rootNamespace,
 		log:                 log,
 		assets:              osbapi.NewAssets(client, rootNamespace),
+		usageRecorder:       usageRecorder,
 	})
 }
 
@@ -142,8 +151,18 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 		return r.finalizeCFServiceInstance(ctx, serviceInstance, serviceInstanceAssets, osbapiClient)
 	}
 
-	if isReady(serviceInstance) {
-		return ctrl.Result{}, nil
+	parametersChecksum, err := getParametersChecksum(serviceInstance)
+	if err != nil {
+		log.Error(err, "failed to compute service instance parameters checksum")
+		return ctrl.Result{}, err
+	}
+
+	if isLegacyProvisioned(serviceInstance) {
+		setProvisionedValues(serviceInstance, parametersChecksum)
+	}
+
+	if isProvisioned(serviceInstance) {
+		return r.reconcileProvisionedServiceInstance(ctx, serviceInstance, serviceInstanceAssets, osbapiClient, parametersChecksum)
 	}
 
 	if isFailed(serviceInstance) {
@@ -176,13 +195,167 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		return r.processProvisionOperation(serviceInstance, lastOpResponse)
+		return r.processProvisionOperation(serviceInstance, lastOpResponse, parametersChecksum)
 	}
 
 	serviceInstance.Status.LastOperation.State = "succeeded"
+	setProvisionedValues(serviceInstance, parametersChecksum)
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) reconcileProvisionedServiceInstance(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+	parametersChecksum string,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !needsUpdate(serviceInstance, parametersChecksum) {
+		if err := r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance, usage.ServicePlanDetailsFor(assets)); err != nil {
+			log.Error(err, "failed to record service instance usage")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if isUpdateFailed(serviceInstance) {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("UpdateFailed").WithNoRequeue()
+	}
+
+	if serviceInstance.Status.UpdateOperation == "" {
+		updateResponse, err := r.updateServiceInstance(ctx, serviceInstance, assets, osbapiClient, parametersChecksum)
+		if err != nil {
+			log.Error(err, "failed to update service instance")
+			return ctrl.Result{}, err
+		}
+
+		if !updateResponse.IsAsync {
+			serviceInstance.Status.LastOperation.State = "succeeded"
+			setProvisionedValues(serviceInstance, parametersChecksum)
+			return ctrl.Result{}, nil
+		}
+
+		serviceInstance.Status.UpdateOperation = updateResponse.Operation
+	}
+
+	lastOpResponse, err := r.pollLastOperation(ctx, serviceInstance, assets, osbapiClient, serviceInstance.Status.UpdateOperation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return r.processUpdateOperation(serviceInstance, lastOpResponse, parametersChecksum)
+}
+
+// updateServiceInstance asks the broker to update the service instance to
+// the plan and parameters in its spec. The plan and the parameters are only
+// sent if they have changed since the instance was last provisioned or
+// updated.
+func (r *Reconciler) updateServiceInstance(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+	parametersChecksum string,
+) (osbapi.ServiceInstanceOperationResponse, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("update-service-instance")
+
+	serviceInstance.Status.LastOperation = services.LastOperation{
+		Type:  "update",
+		State: "initial",
+	}
+
+	previousPlan, err := r.assets.GetServicePlan(ctx, serviceInstance.Status.ProvisionedPlanGUID)
+	if err != nil {
+		log.Error(err, "failed to get previous service plan")
+		return osbapi.ServiceInstanceOperationResponse{}, err
+	}
+
+	namespace, err := r.getNamespace(ctx, serviceInstance.Namespace)
+	if err != nil {
+		log.Error(err, "failed to get namespace")
+		return osbapi.ServiceInstanceOperationResponse{}, err
+	}
+
+	updateRequest := osbapi.InstanceUpdateRequest{
+		ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PreviousValues: osbapi.PreviousValues{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    previousPlan.Spec.BrokerCatalog.ID,
+			SpaceGUID: namespace.Labels[korifiv1alpha1.SpaceGUIDKey],
+			OrgGUID:   namespace.Labels[korifiv1alpha1.OrgGUIDKey],
+		},
+	}
+
+	if serviceInstance.Spec.PlanGUID != serviceInstance.Status.ProvisionedPlanGUID {
+		if !isPlanUpdateable(assets) {
+			return osbapi.ServiceInstanceOperationResponse{}, setUpdateFailed(serviceInstance, "The service does not support changing plans.")
+		}
+		updateRequest.PlanID = assets.ServicePlan.Spec.BrokerCatalog.ID
+	}
+
+	if parametersChecksum != serviceInstance.Status.ProvisionedParametersChecksum {
+		updateRequest.Parameters, err = getServiceInstanceParameters(serviceInstance)
+		if err != nil {
+			log.Error(err, "failed to get service instance parameters")
+			return osbapi.ServiceInstanceOperationResponse{},
+				fmt.Errorf("failed to get service instance parameters: %w", err)
+		}
+	}
+
+	updateResponse, err := osbapiClient.Update(ctx, osbapi.InstanceUpdatePayload{
+		InstanceID:            serviceInstance.Name,
+		InstanceUpdateRequest: updateRequest,
+	})
+	if err != nil {
+		log.Error(err, "failed to update service")
+
+		if osbapi.IsUnrecoveralbeError(err) {
+			return osbapi.ServiceInstanceOperationResponse{}, setUpdateFailed(serviceInstance, err.Error())
+		}
+
+		return osbapi.ServiceInstanceOperationResponse{}, err
+	}
+
+	return updateResponse, nil
+}
+
+func (r *Reconciler) processUpdateOperation(
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	lastOpResponse osbapi.LastOperationResponse,
+	parametersChecksum string,
+) (ctrl.Result, error) {
+	if lastOpResponse.State == "in progress" {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("UpdateInProgress").WithRequeue()
+	}
+
+	serviceInstance.Status.UpdateOperation = ""
+
+	if lastOpResponse.State == "failed" {
+		return ctrl.Result{}, setUpdateFailed(serviceInstance, lastOpResponse.Description)
+	}
+
+	setProvisionedValues(serviceInstance, parametersChecksum)
+	return ctrl.Result{}, nil
+}
+
+// setUpdateFailed records that the broker failed to update the service
+// instance. The update is not retried until the spec of the service instance
+// changes again.
+func setUpdateFailed(serviceInstance *korifiv1alpha1.CFServiceInstance, message string) error {
+	serviceInstance.Status.LastOperation.State = "failed"
+	serviceInstance.Status.LastOperation.Description = message
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.UpdateFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "UpdateFailed",
+		Message:            message,
+	})
+	return k8s.NewNotReadyError().WithReason("UpdateFailed").WithNoRequeue()
+}
+
 func (r *Reconciler) provisionServiceInstance(
 	ctx context.Context,
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
@@ -246,6 +419,7 @@ func (r *Reconciler) provisionServiceInstance(
 func (r *Reconciler) processProvisionOperation(
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
 	lastOpResponse osbapi.LastOperationResponse,
+	parametersChecksum string,
 ) (ctrl.Result, error) {
 	if lastOpResponse.State == "in progress" {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionInProgress").WithRequeue()
@@ -263,6 +437,7 @@ func (r *Reconciler) processProvisionOperation(
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionFailed")
 	}
 
+	setProvisionedValues(serviceInstance, parametersChecksum)
 	return ctrl.Result{}, nil
 }
 
@@ -293,6 +468,11 @@ func (r *Reconciler) finalizeCFServiceInstance(
 		return r.processDeprovisionOperation(serviceInstance, lastOpResponse)
 	}
 
+	if err = r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance, usage.ServicePlanDetailsFor(assets)); err != nil {
+		log.Error(err, "failed to record service instance usage")
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	log.V(1).Info("finalizer removed")
 
@@ -400,6 +580,41 @@ func getServiceInstanceParameters(serviceInstance *korifiv1alpha1.CFServiceInsta
 	return parametersMap, nil
 }
 
+// getParametersChecksum returns a checksum of the service instance
+// parameters, which is used to detect parameter changes that have to be sent
+// to the broker
+func getParametersChecksum(serviceInstance *korifiv1alpha1.CFServiceInstance) (string, error) {
+	parametersMap, err := getServiceInstanceParameters(serviceInstance)
+	if err != nil {
+		return "", err
+	}
+
+	// marshalling the map sorts its keys, so that equal parameters always
+	// have the same checksum
+	parametersBytes, err := json.Marshal(parametersMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(parametersBytes)), nil
+}
+
+func setProvisionedValues(serviceInstance *korifiv1alpha1.CFServiceInstance, parametersChecksum string) {
+	serviceInstance.Status.ProvisionedPlanGUID = serviceInstance.Spec.PlanGUID
+	serviceInstance.Status.ProvisionedParametersChecksum = parametersChecksum
+	meta.RemoveStatusCondition(&serviceInstance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)
+}
+
+func needsUpdate(serviceInstance *korifiv1alpha1.CFServiceInstance, parametersChecksum string) bool {
+	return serviceInstance.Spec.PlanGUID != serviceInstance.Status.ProvisionedPlanGUID ||
+		parametersChecksum != serviceInstance.Status.ProvisionedParametersChecksum
+}
+
+func isPlanUpdateable(assets osbapi.ServiceInstanceAssets) bool {
+	return assets.ServiceOffering.Spec.BrokerCatalog.Features.PlanUpdateable ||
+		assets.ServicePlan.Spec.BrokerCatalog.Features.PlanUpdateable
+}
+
 func (r *Reconciler) isServicePlanVisible(
 	ctx context.Context,
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
@@ -444,6 +659,20 @@ func isFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
 	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.ProvisioningFailedCondition)
 }
 
-func isReady(instance *korifiv1alpha1.CFServiceInstance) bool {
-	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+func isProvisioned(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Status.ProvisionedPlanGUID != ""
+}
+
+// isLegacyProvisioned checks whether the instance has been provisioned before
+// its provisioned plan and parameters were recorded in its status
+func isLegacyProvisioned(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return !isProvisioned(instance) &&
+		meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+}
+
+func isUpdateFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
+	updateFailedCondition := meta.FindStatusCondition(instance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)
+	return updateFailedCondition != nil &&
+		updateFailedCondition.Status == metav1.ConditionTrue &&
+		updateFailedCondition.ObservedGeneration == instance.Generation
 }
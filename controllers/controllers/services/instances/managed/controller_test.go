@@ -28,10 +28,11 @@ import (
 
 var _ = Describe("CFServiceInstance", func() {
 	var (
-		brokerClient  *fake.BrokerClient
-		instance      *korifiv1alpha1.CFServiceInstance
-		serviceBroker *korifiv1alpha1.CFServiceBroker
-		servicePlan   *korifiv1alpha1.CFServicePlan
+		brokerClient    *fake.BrokerClient
+		instance        *korifiv1alpha1.CFServiceInstance
+		serviceBroker   *korifiv1alpha1.CFServiceBroker
+		serviceOffering *korifiv1alpha1.CFServiceOffering
+		servicePlan     *korifiv1alpha1.CFServicePlan
 	)
 
 	BeforeEach(func() {
@@ -74,7 +75,7 @@ var _ = Describe("CFServiceInstance", func() {
 		}
 		Expect(adminClient.Create(ctx, namespace)).To(Succeed())
 
-		serviceOffering := &korifiv1alpha1.CFServiceOffering{
+		serviceOffering = &korifiv1alpha1.CFServiceOffering{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      uuid.NewString(),
 				Namespace: rootNamespace,
@@ -728,6 +729,273 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	Describe("instance update", func() {
+		var newPlan *korifiv1alpha1.CFServicePlan
+
+		BeforeEach(func() {
+			brokerClient.UpdateReturns(osbapi.ServiceInstanceOperationResponse{
+				IsAsync:   true,
+				Operation: "update-operation",
+			}, nil)
+
+			newPlan = &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceBrokerGUIDLabel:   serviceBroker.Name,
+						korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					Visibility: korifiv1alpha1.ServicePlanVisibility{
+						Type: "public",
+					},
+					ServicePlan: services.ServicePlan{
+						BrokerCatalog: services.ServicePlanBrokerCatalog{
+							ID: "new-service-plan-id",
+							Features: services.ServicePlanFeatures{
+								PlanUpdateable: true,
+							},
+						},
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, newPlan)).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.ProvisionedPlanGUID).To(Equal(servicePlan.Name))
+				g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("does not update an instance that has not changed", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(brokerClient.UpdateCallCount()).To(BeZero())
+			}).Should(Succeed())
+		})
+
+		When("the plan and the parameters change", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.PlanGUID = newPlan.Name
+					instance.Spec.Parameters = &runtime.RawExtension{
+						Raw: []byte(`{"param-key":"new-param-value"}`),
+					}
+				})).To(Succeed())
+			})
+
+			It("updates the instance with the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload).To(Equal(osbapi.InstanceUpdatePayload{
+						InstanceID: instance.Name,
+						InstanceUpdateRequest: osbapi.InstanceUpdateRequest{
+							ServiceId: "service-offering-id",
+							PlanID:    "new-service-plan-id",
+							Parameters: map[string]any{
+								"param-key": "new-param-value",
+							},
+							PreviousValues: osbapi.PreviousValues{
+								ServiceId: "service-offering-id",
+								PlanID:    "service-plan-id",
+								SpaceGUID: "space-guid",
+								OrgGUID:   "org-guid",
+							},
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			It("checks the last operation of the update", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.GetServiceInstanceLastOperationCallCount()).To(BeNumerically(">", 0))
+					_, lastOp := brokerClient.GetServiceInstanceLastOperationArgsForCall(brokerClient.GetServiceInstanceLastOperationCallCount() - 1)
+					g.Expect(lastOp.Operation).To(Equal("update-operation"))
+				}).Should(Succeed())
+			})
+
+			It("records the updated plan and sets succeeded state in instance last operation", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.ProvisionedPlanGUID).To(Equal(newPlan.Name))
+					g.Expect(instance.Status.UpdateOperation).To(BeEmpty())
+					g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+						Type:  "update",
+						State: "succeeded",
+					}))
+					g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("updates the instance only once", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.ProvisionedPlanGUID).To(Equal(newPlan.Name))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+				}).Should(Succeed())
+			})
+
+			When("the last operation is in progress", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+						State: "in progress",
+					}, nil)
+				})
+
+				It("sets in progress state in instance last operation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.UpdateOperation).To(Equal("update-operation"))
+						g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+							Type:  "update",
+							State: "in progress",
+						}))
+						g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeFalse())
+					}).Should(Succeed())
+				})
+
+				It("keeps checking last operation without requesting the update again", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.GetServiceInstanceLastOperationCallCount()).To(BeNumerically(">", 2))
+					}).Should(Succeed())
+					Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+				})
+			})
+
+			When("the last operation is failed", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+						State:       "failed",
+						Description: "update-failed",
+					}, nil)
+				})
+
+				It("sets the update failed condition", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.ProvisionedPlanGUID).To(Equal(servicePlan.Name))
+						g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+							Type:        "update",
+							State:       "failed",
+							Description: "update-failed",
+						}))
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+						)))
+						g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeFalse())
+					}).Should(Succeed())
+				})
+
+				It("does not retry the update", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)).To(BeTrue())
+					}).Should(Succeed())
+
+					Consistently(func(g Gomega) {
+						g.Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the update fails with an unrecoverable error", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.ServiceInstanceOperationResponse{}, osbapi.UnrecoverableError{Status: http.StatusBadRequest})
+				})
+
+				It("sets the update failed condition", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.LastOperation.Type).To(Equal("update"))
+						g.Expect(instance.Status.LastOperation.State).To(Equal("failed"))
+						g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the update fails with a recoverable error", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.ServiceInstanceOperationResponse{}, errors.New("update-failed"))
+				})
+
+				It("keeps trying to update the instance", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.UpdateCallCount()).To(BeNumerically(">", 1))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the update is synchronous", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.ServiceInstanceOperationResponse{}, nil)
+				})
+
+				It("records the updated plan", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.ProvisionedPlanGUID).To(Equal(newPlan.Name))
+						g.Expect(instance.Status.LastOperation).To(Equal(services.LastOperation{
+							Type:  "update",
+							State: "succeeded",
+						}))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the plan changes to a plan that is not updateable", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, newPlan, func() {
+					newPlan.Spec.BrokerCatalog.Features.PlanUpdateable = false
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.PlanGUID = newPlan.Name
+				})).To(Succeed())
+			})
+
+			It("fails the update without contacting the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasMessage(ContainSubstring("does not support changing plans")),
+					)))
+				}).Should(Succeed())
+				Expect(brokerClient.UpdateCallCount()).To(BeZero())
+			})
+		})
+
+		When("only the parameters change", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.Parameters = &runtime.RawExtension{
+						Raw: []byte(`{"param-key":"new-param-value"}`),
+					}
+				})).To(Succeed())
+			})
+
+			It("updates the instance parameters without changing the plan", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload.PlanID).To(BeEmpty())
+					g.Expect(payload.Parameters).To(Equal(map[string]any{
+						"param-key": "new-param-value",
+					}))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("instance deletion", func() {
 		BeforeEach(func() {
 			brokerClient.DeprovisionReturns(osbapi.ServiceInstanceOperationResponse{
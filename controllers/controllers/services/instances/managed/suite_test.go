@@ -26,6 +26,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"github.com/google/uuid"
@@ -106,6 +107,7 @@ var _ = BeforeEach(func() {
 		k8sManager.GetScheme(),
 		rootNamespace,
 		ctrl.Log.WithName("controllers").WithName("ManagedCFServiceInstance"),
+		usage.NewRecorder(k8sManager.GetClient(), rootNamespace),
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +58,23 @@ var _ = Describe("CFServiceInstance", func() {
 			}).Should(Succeed())
 		})
 
+		It("records a service usage event", func() {
+			Eventually(func(g Gomega) {
+				usageEvents := &korifiv1alpha1.CFServiceUsageEventList{}
+				g.Expect(adminClient.List(ctx, usageEvents,
+					client.InNamespace(rootNamespace),
+					client.MatchingLabels{korifiv1alpha1.CFServiceInstanceGUIDLabelKey: instance.Name},
+				)).To(Succeed())
+				g.Expect(usageEvents.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+						"ServiceInstanceName": Equal("service-instance-name"),
+						"ServiceInstanceType": Equal(korifiv1alpha1.UserProvidedType),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
 		It("sets the CredentialsSecretAvailable condition to false", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
@@ -217,6 +235,21 @@ var _ = Describe("CFServiceInstance", func() {
 						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
 					}).Should(Succeed())
 				})
+
+				It("records a deleted service usage event", func() {
+					Eventually(func(g Gomega) {
+						usageEvents := &korifiv1alpha1.CFServiceUsageEventList{}
+						g.Expect(adminClient.List(ctx, usageEvents,
+							client.InNamespace(rootNamespace),
+							client.MatchingLabels{korifiv1alpha1.CFServiceInstanceGUIDLabelKey: instance.Name},
+						)).To(Succeed())
+						g.Expect(usageEvents.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted),
+							}),
+						})))
+					}).Should(Succeed())
+				})
 			})
 		})
 
@@ -28,7 +28,7 @@ type ServiceInstanceAssets struct {
 }
 
 func (r *Assets) GetServiceInstanceAssets(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (ServiceInstanceAssets, error) {
-	servicePlan, err := r.getServicePlan(ctx, serviceInstance.Spec.PlanGUID)
+	servicePlan, err := r.GetServicePlan(ctx, serviceInstance.Spec.PlanGUID)
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
@@ -56,13 +56,7 @@ type ServiceBindingAssets struct {
 }
 
 func (r *Assets) GetServiceBindingAssets(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (ServiceBindingAssets, error) {
-	serviceInstance := &korifiv1alpha1.CFServiceInstance{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: serviceBinding.Namespace,
-			Name:      serviceBinding.Spec.Service.Name,
-		},
-	}
-	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)
+	serviceInstance, err := r.GetServiceInstance(ctx, serviceBinding)
 	if err != nil {
 		return ServiceBindingAssets{}, err
 	}
@@ -78,6 +72,23 @@ func (r *Assets) GetServiceBindingAssets(ctx context.Context, serviceBinding *ko
 	}, nil
 }
 
+// GetServiceInstance returns the service instance of the binding, which may
+// live in another namespace when it is shared with the namespace of the binding
+func (r *Assets) GetServiceInstance(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (*korifiv1alpha1.CFServiceInstance, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceBinding.ServiceInstanceNamespace(),
+			Name:      serviceBinding.Spec.Service.Name,
+		},
+	}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)
+	if err != nil {
+		return nil, err
+	}
+
+	return serviceInstance, nil
+}
+
 func (r *Assets) getServiceOffering(ctx context.Context, offeringGUID string) (*korifiv1alpha1.CFServiceOffering, error) {
 	serviceOffering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
@@ -93,7 +104,7 @@ func (r *Assets) getServiceOffering(ctx context.Context, offeringGUID string) (*
 	return serviceOffering, nil
 }
 
-func (r *Assets) getServicePlan(ctx context.Context, planGUID string) (*korifiv1alpha1.CFServicePlan, error) {
+func (r *Assets) GetServicePlan(ctx context.Context, planGUID string) (*korifiv1alpha1.CFServicePlan, error) {
 	servicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      planGUID,
@@ -116,6 +116,58 @@ func (c *Client) Provision(ctx context.Context, payload InstanceProvisionPayload
 	return response, nil
 }
 
+func (c *Client) Update(ctx context.Context, payload InstanceUpdatePayload) (ServiceInstanceOperationResponse, error) {
+	statusCode, respBytes, err := c.newBrokerRequester().
+		forBroker(c.broker).
+		async().
+		sendRequest(
+			ctx,
+			"/v2/service_instances/"+payload.InstanceID,
+			http.MethodPatch,
+			nil,
+			payload.InstanceUpdateRequest,
+		)
+	if err != nil {
+		return ServiceInstanceOperationResponse{}, fmt.Errorf("update request failed: %w", err)
+	}
+
+	if statusCode == http.StatusUnprocessableEntity && isConcurrencyError(respBytes) {
+		return ServiceInstanceOperationResponse{}, errors.New("update request failed: another operation for this service instance is in progress")
+	}
+
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity {
+		return ServiceInstanceOperationResponse{}, UnrecoverableError{Status: statusCode}
+	}
+
+	if statusCode >= 300 {
+		return ServiceInstanceOperationResponse{}, fmt.Errorf("update request failed with status code: %d", statusCode)
+	}
+
+	response := ServiceInstanceOperationResponse{
+		IsAsync: statusCode == http.StatusAccepted,
+	}
+
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return ServiceInstanceOperationResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
+// isConcurrencyError checks whether the broker rejected the request because
+// it does not support concurrent operations on the same service instance
+func isConcurrencyError(respBytes []byte) bool {
+	var brokerError struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(respBytes, &brokerError); err != nil {
+		return false
+	}
+
+	return brokerError.Error == "ConcurrencyError"
+}
+
 func (c *Client) Deprovision(ctx context.Context, payload InstanceDeprovisionPayload) (ServiceInstanceOperationResponse, error) {
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
@@ -186,6 +238,36 @@ func (c *Client) GetServiceInstanceLastOperation(ctx context.Context, request Ge
 	return response, nil
 }
 
+func (c *Client) GetServiceInstance(ctx context.Context, request GetServiceInstanceRequest) (GetInstanceResponse, error) {
+	statusCode, respBytes, err := c.newBrokerRequester().
+		forBroker(c.broker).
+		sendRequest(
+			ctx,
+			"/v2/service_instances/"+request.InstanceID,
+			http.MethodGet,
+			map[string]string{
+				"service_id": request.ServiceId,
+				"plan_id":    request.PlanID,
+			},
+			nil,
+		)
+	if err != nil {
+		return GetInstanceResponse{}, fmt.Errorf("get service instance request failed: %w", err)
+	}
+
+	if statusCode != http.StatusOK {
+		return GetInstanceResponse{}, fmt.Errorf("get service instance request failed with code: %d", statusCode)
+	}
+
+	var response GetInstanceResponse
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return GetInstanceResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
 func (c *Client) GetServiceBinding(ctx context.Context, request GetServiceBindingRequest) (GetBindingResponse, error) {
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
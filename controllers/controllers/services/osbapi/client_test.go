@@ -246,6 +246,150 @@ var _ = Describe("OSBAPI Client", func() {
 			})
 		})
 
+		Describe("Update", func() {
+			var (
+				updateResp osbapi.ServiceInstanceOperationResponse
+				updateErr  error
+			)
+
+			BeforeEach(func() {
+				brokerServer = brokerServer.WithResponse(
+					"/v2/service_instances/{id}",
+					nil,
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				updateResp, updateErr = brokerClient.Update(ctx, osbapi.InstanceUpdatePayload{
+					InstanceID: "my-service-instance",
+					InstanceUpdateRequest: osbapi.InstanceUpdateRequest{
+						ServiceId: "service-guid",
+						PlanID:    "new-plan-guid",
+						Parameters: map[string]any{
+							"foo": "bar",
+						},
+						PreviousValues: osbapi.PreviousValues{
+							ServiceId: "service-guid",
+							PlanID:    "plan-guid",
+							SpaceGUID: "space-guid",
+							OrgGUID:   "org-guid",
+						},
+					},
+				})
+			})
+
+			It("sends async update request to broker", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				Expect(requests[0].Method).To(Equal(http.MethodPatch))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+
+				Expect(requests[0].URL.Query().Get("accepts_incomplete")).To(Equal("true"))
+			})
+
+			It("sends correct request body", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				requestBytes, err := io.ReadAll(requests[0].Body)
+				Expect(err).NotTo(HaveOccurred())
+				requestBody := map[string]any{}
+				Expect(json.Unmarshal(requestBytes, &requestBody)).To(Succeed())
+
+				Expect(requestBody).To(MatchAllKeys(Keys{
+					"service_id": Equal("service-guid"),
+					"plan_id":    Equal("new-plan-guid"),
+					"parameters": MatchAllKeys(Keys{
+						"foo": Equal("bar"),
+					}),
+					"previous_values": MatchAllKeys(Keys{
+						"service_id":      Equal("service-guid"),
+						"plan_id":         Equal("plan-guid"),
+						"space_id":        Equal("space-guid"),
+						"organization_id": Equal("org-guid"),
+					}),
+				}))
+			})
+
+			It("updates the service synchronously", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(updateResp).To(Equal(osbapi.ServiceInstanceOperationResponse{}))
+			})
+
+			When("the broker accepts the update request", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						map[string]any{
+							"operation": "update_op1",
+						},
+						http.StatusAccepted,
+					)
+				})
+
+				It("updates the service asynchronously", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(updateResp).To(Equal(osbapi.ServiceInstanceOperationResponse{
+						IsAsync:   true,
+						Operation: "update_op1",
+					}))
+				})
+			})
+
+			When("the update request fails with 400 BadRequest error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusBadRequest)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(updateErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusBadRequest}))
+				})
+			})
+
+			When("the update request fails with 422 Unprocessable entity error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusUnprocessableEntity)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(updateErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity}))
+				})
+			})
+
+			When("the broker is busy with another operation on the instance", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						map[string]any{
+							"error": "ConcurrencyError",
+						},
+						http.StatusUnprocessableEntity,
+					)
+				})
+
+				It("returns a recoverable error", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("another operation for this service instance is in progress")))
+					Expect(osbapi.IsUnrecoveralbeError(updateErr)).To(BeFalse())
+				})
+			})
+
+			When("the update request fails", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusInternalServerError)
+				})
+
+				It("returns an error", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("update request failed")))
+				})
+			})
+		})
+
 		Describe("Deprovision", func() {
 			var (
 				deprovisionResp osbapi.ServiceInstanceOperationResponse
@@ -476,13 +620,85 @@ var _ = Describe("OSBAPI Client", func() {
 				})
 			})
 		})
+
+		Describe("GetServiceInstance", func() {
+			var (
+				getInstanceResponse osbapi.GetInstanceResponse
+				getInstanceErr      error
+			)
+
+			BeforeEach(func() {
+				brokerServer.WithResponse(
+					"/v2/service_instances/{id}",
+					map[string]any{
+						"service_id":    "service-guid",
+						"plan_id":       "plan-guid",
+						"dashboard_url": "https://dashboard.example.com",
+						"parameters": map[string]any{
+							"param-key": "param-value",
+						},
+					},
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				getInstanceResponse, getInstanceErr = brokerClient.GetServiceInstance(ctx, osbapi.GetServiceInstanceRequest{
+					InstanceID: "my-service-instance",
+					ServiceId:  "service-guid",
+					PlanID:     "plan-guid",
+				})
+			})
+
+			It("gets the service instance", func() {
+				Expect(getInstanceErr).NotTo(HaveOccurred())
+				Expect(getInstanceResponse).To(Equal(osbapi.GetInstanceResponse{
+					ServiceId:    "service-guid",
+					PlanID:       "plan-guid",
+					DashboardURL: "https://dashboard.example.com",
+					Parameters: map[string]any{
+						"param-key": "param-value",
+					},
+				}))
+			})
+
+			It("sends correct request to broker", func() {
+				Expect(getInstanceErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				Expect(requests[0].Method).To(Equal(http.MethodGet))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+
+				Expect(requests[0].URL.Query()).To(BeEquivalentTo(map[string][]string{
+					"service_id": {"service-guid"},
+					"plan_id":    {"plan-guid"},
+				}))
+			})
+
+			When("getting the service instance fails", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						nil,
+						http.StatusTeapot,
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(getInstanceErr).To(MatchError(ContainSubstring("get service instance request failed")))
+				})
+			})
+		})
 	})
 
 	Describe("Bindings", func() {
 		Describe("Bind", func() {
 			var (
-				bindResp osbapi.BindResponse
-				bindErr  error
+				bindRequest osbapi.BindRequest
+				bindResp    osbapi.BindResponse
+				bindErr     error
 			)
 
 			BeforeEach(func() {
@@ -495,23 +711,25 @@ var _ = Describe("OSBAPI Client", func() {
 					},
 					http.StatusCreated,
 				)
+
+				bindRequest = osbapi.BindRequest{
+					ServiceId: "service-guid",
+					PlanID:    "plan-guid",
+					AppGUID:   "app-guid",
+					BindResource: osbapi.BindResource{
+						AppGUID: "app-guid",
+					},
+					Parameters: map[string]any{
+						"foo": "bar",
+					},
+				}
 			})
 
 			JustBeforeEach(func() {
 				bindResp, bindErr = brokerClient.Bind(ctx, osbapi.BindPayload{
-					InstanceID: "instance-id",
-					BindingID:  "binding-id",
-					BindRequest: osbapi.BindRequest{
-						ServiceId: "service-guid",
-						PlanID:    "plan-guid",
-						AppGUID:   "app-guid",
-						BindResource: osbapi.BindResource{
-							AppGUID: "app-guid",
-						},
-						Parameters: map[string]any{
-							"foo": "bar",
-						},
-					},
+					InstanceID:  "instance-id",
+					BindingID:   "binding-id",
+					BindRequest: bindRequest,
 				})
 			})
 
@@ -554,6 +772,72 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("binding a service key", func() {
+				BeforeEach(func() {
+					bindRequest.AppGUID = ""
+					bindRequest.BindResource = osbapi.BindResource{
+						CredentialClientID: "client-id",
+					}
+				})
+
+				It("sends the credential client id instead of the app guid", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					requests := brokerServer.ServedRequests()
+					Expect(requests).To(HaveLen(1))
+
+					requestBytes, err := io.ReadAll(requests[0].Body)
+					Expect(err).NotTo(HaveOccurred())
+					requestBody := map[string]any{}
+					Expect(json.Unmarshal(requestBytes, &requestBody)).To(Succeed())
+
+					Expect(requestBody).NotTo(HaveKey("app_guid"))
+					Expect(requestBody).To(HaveKeyWithValue("bind_resource", MatchAllKeys(Keys{
+						"credential_client_id": Equal("client-id"),
+					})))
+				})
+			})
+
+			When("binding a route", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"route_service_url": "https://route-service.example.com",
+						},
+						http.StatusCreated,
+					)
+
+					bindRequest.AppGUID = ""
+					bindRequest.BindResource = osbapi.BindResource{
+						Route: "my-app.example.com/path",
+					}
+				})
+
+				It("sends the route instead of the app guid", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					requests := brokerServer.ServedRequests()
+					Expect(requests).To(HaveLen(1))
+
+					requestBytes, err := io.ReadAll(requests[0].Body)
+					Expect(err).NotTo(HaveOccurred())
+					requestBody := map[string]any{}
+					Expect(json.Unmarshal(requestBytes, &requestBody)).To(Succeed())
+
+					Expect(requestBody).NotTo(HaveKey("app_guid"))
+					Expect(requestBody).To(HaveKeyWithValue("bind_resource", MatchAllKeys(Keys{
+						"route": Equal("my-app.example.com/path"),
+					})))
+				})
+
+				It("returns the route service url", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp).To(Equal(osbapi.BindResponse{
+						RouteServiceURL: "https://route-service.example.com",
+						Complete:        true,
+					}))
+				})
+			})
+
 			It("binds the service", func() {
 				Expect(bindErr).NotTo(HaveOccurred())
 				Expect(bindResp).To(Equal(osbapi.BindResponse{
@@ -706,6 +990,10 @@ var _ = Describe("OSBAPI Client", func() {
 						"credentials": map[string]any{
 							"credentialKey": "credentialValue",
 						},
+						"route_service_url": "https://route-service.example.com",
+						"parameters": map[string]any{
+							"param-key": "param-value",
+						},
 					},
 					http.StatusOK,
 				)
@@ -726,6 +1014,10 @@ var _ = Describe("OSBAPI Client", func() {
 					Credentials: map[string]any{
 						"credentialKey": "credentialValue",
 					},
+					RouteServiceURL: "https://route-service.example.com",
+					Parameters: map[string]any{
+						"param-key": "param-value",
+					},
 				}))
 			})
 
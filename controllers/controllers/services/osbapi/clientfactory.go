@@ -18,8 +18,10 @@ import (
 //counterfeiter:generate -o fake -fake-name BrokerClient code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi.BrokerClient
 type BrokerClient interface {
 	Provision(context.Context, InstanceProvisionPayload) (ServiceInstanceOperationResponse, error)
+	Update(context.Context, InstanceUpdatePayload) (ServiceInstanceOperationResponse, error)
 	Deprovision(context.Context, InstanceDeprovisionPayload) (ServiceInstanceOperationResponse, error)
 	GetServiceInstanceLastOperation(context.Context, GetServiceInstanceLastOperationRequest) (LastOperationResponse, error)
+	GetServiceInstance(context.Context, GetServiceInstanceRequest) (GetInstanceResponse, error)
 	GetCatalog(context.Context) (Catalog, error)
 	Bind(context.Context, BindPayload) (BindResponse, error)
 	Unbind(context.Context, UnbindPayload) (UnbindResponse, error)
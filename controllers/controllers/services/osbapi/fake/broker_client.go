@@ -78,6 +78,20 @@ type BrokerClient struct {
 		result1 osbapi.LastOperationResponse
 		result2 error
 	}
+	GetServiceInstanceStub        func(context.Context, osbapi.GetServiceInstanceRequest) (osbapi.GetInstanceResponse, error)
+	getServiceInstanceMutex       sync.RWMutex
+	getServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 osbapi.GetServiceInstanceRequest
+	}
+	getServiceInstanceReturns struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}
+	getServiceInstanceReturnsOnCall map[int]struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}
 	GetServiceInstanceLastOperationStub        func(context.Context, osbapi.GetServiceInstanceLastOperationRequest) (osbapi.LastOperationResponse, error)
 	getServiceInstanceLastOperationMutex       sync.RWMutex
 	getServiceInstanceLastOperationArgsForCall []struct {
@@ -120,6 +134,20 @@ type BrokerClient struct {
 		result1 osbapi.UnbindResponse
 		result2 error
 	}
+	UpdateStub        func(context.Context, osbapi.InstanceUpdatePayload) (osbapi.ServiceInstanceOperationResponse, error)
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 context.Context
+		arg2 osbapi.InstanceUpdatePayload
+	}
+	updateReturns struct {
+		result1 osbapi.ServiceInstanceOperationResponse
+		result2 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 osbapi.ServiceInstanceOperationResponse
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -448,6 +476,71 @@ func (fake *BrokerClient) GetServiceBindingLastOperationReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *BrokerClient) GetServiceInstance(arg1 context.Context, arg2 osbapi.GetServiceInstanceRequest) (osbapi.GetInstanceResponse, error) {
+	fake.getServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.getServiceInstanceReturnsOnCall[len(fake.getServiceInstanceArgsForCall)]
+	fake.getServiceInstanceArgsForCall = append(fake.getServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 osbapi.GetServiceInstanceRequest
+	}{arg1, arg2})
+	stub := fake.GetServiceInstanceStub
+	fakeReturns := fake.getServiceInstanceReturns
+	fake.recordInvocation("GetServiceInstance", []interface{}{arg1, arg2})
+	fake.getServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BrokerClient) GetServiceInstanceCallCount() int {
+	fake.getServiceInstanceMutex.RLock()
+	defer fake.getServiceInstanceMutex.RUnlock()
+	return len(fake.getServiceInstanceArgsForCall)
+}
+
+func (fake *BrokerClient) GetServiceInstanceCalls(stub func(context.Context, osbapi.GetServiceInstanceRequest) (osbapi.GetInstanceResponse, error)) {
+	fake.getServiceInstanceMutex.Lock()
+	defer fake.getServiceInstanceMutex.Unlock()
+	fake.GetServiceInstanceStub = stub
+}
+
+func (fake *BrokerClient) GetServiceInstanceArgsForCall(i int) (context.Context, osbapi.GetServiceInstanceRequest) {
+	fake.getServiceInstanceMutex.RLock()
+	defer fake.getServiceInstanceMutex.RUnlock()
+	argsForCall := fake.getServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BrokerClient) GetServiceInstanceReturns(result1 osbapi.GetInstanceResponse, result2 error) {
+	fake.getServiceInstanceMutex.Lock()
+	defer fake.getServiceInstanceMutex.Unlock()
+	fake.GetServiceInstanceStub = nil
+	fake.getServiceInstanceReturns = struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BrokerClient) GetServiceInstanceReturnsOnCall(i int, result1 osbapi.GetInstanceResponse, result2 error) {
+	fake.getServiceInstanceMutex.Lock()
+	defer fake.getServiceInstanceMutex.Unlock()
+	fake.GetServiceInstanceStub = nil
+	if fake.getServiceInstanceReturnsOnCall == nil {
+		fake.getServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 osbapi.GetInstanceResponse
+			result2 error
+		})
+	}
+	fake.getServiceInstanceReturnsOnCall[i] = struct {
+		result1 osbapi.GetInstanceResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BrokerClient) GetServiceInstanceLastOperation(arg1 context.Context, arg2 osbapi.GetServiceInstanceLastOperationRequest) (osbapi.LastOperationResponse, error) {
 	fake.getServiceInstanceLastOperationMutex.Lock()
 	ret, specificReturn := fake.getServiceInstanceLastOperationReturnsOnCall[len(fake.getServiceInstanceLastOperationArgsForCall)]
@@ -643,6 +736,71 @@ func (fake *BrokerClient) UnbindReturnsOnCall(i int, result1 osbapi.UnbindRespon
 	}{result1, result2}
 }
 
+func (fake *BrokerClient) Update(arg1 context.Context, arg2 osbapi.InstanceUpdatePayload) (osbapi.ServiceInstanceOperationResponse, error) {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 context.Context
+		arg2 osbapi.InstanceUpdatePayload
+	}{arg1, arg2})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
+	fake.recordInvocation("Update", []interface{}{arg1, arg2})
+	fake.updateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BrokerClient) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *BrokerClient) UpdateCalls(stub func(context.Context, osbapi.InstanceUpdatePayload) (osbapi.ServiceInstanceOperationResponse, error)) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *BrokerClient) UpdateArgsForCall(i int) (context.Context, osbapi.InstanceUpdatePayload) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BrokerClient) UpdateReturns(result1 osbapi.ServiceInstanceOperationResponse, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 osbapi.ServiceInstanceOperationResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BrokerClient) UpdateReturnsOnCall(i int, result1 osbapi.ServiceInstanceOperationResponse, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 osbapi.ServiceInstanceOperationResponse
+			result2 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 osbapi.ServiceInstanceOperationResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BrokerClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -656,12 +814,16 @@ func (fake *BrokerClient) Invocations() map[string][][]interface{} {
 	defer fake.getServiceBindingMutex.RUnlock()
 	fake.getServiceBindingLastOperationMutex.RLock()
 	defer fake.getServiceBindingLastOperationMutex.RUnlock()
+	fake.getServiceInstanceMutex.RLock()
+	defer fake.getServiceInstanceMutex.RUnlock()
 	fake.getServiceInstanceLastOperationMutex.RLock()
 	defer fake.getServiceInstanceLastOperationMutex.RUnlock()
 	fake.provisionMutex.RLock()
 	defer fake.provisionMutex.RUnlock()
 	fake.unbindMutex.RLock()
 	defer fake.unbindMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -43,6 +43,25 @@ type InstanceProvisionRequest struct {
 	Parameters map[string]any `json:"parameters"`
 }
 
+type InstanceUpdatePayload struct {
+	InstanceID string
+	InstanceUpdateRequest
+}
+
+type InstanceUpdateRequest struct {
+	ServiceId      string         `json:"service_id"`
+	PlanID         string         `json:"plan_id,omitempty"`
+	Parameters     map[string]any `json:"parameters,omitempty"`
+	PreviousValues PreviousValues `json:"previous_values"`
+}
+
+type PreviousValues struct {
+	ServiceId string `json:"service_id"`
+	PlanID    string `json:"plan_id"`
+	SpaceGUID string `json:"space_id"`
+	OrgGUID   string `json:"organization_id"`
+}
+
 type GetServiceInstanceLastOperationRequest struct {
 	InstanceID string
 	GetLastOperationRequestParameters
@@ -62,7 +81,22 @@ type GetServiceBindingRequest struct {
 }
 
 type GetBindingResponse struct {
-	Credentials map[string]any `json:"credentials"`
+	Credentials     map[string]any `json:"credentials"`
+	RouteServiceURL string         `json:"route_service_url"`
+	Parameters      map[string]any `json:"parameters"`
+}
+
+type GetServiceInstanceRequest struct {
+	InstanceID string
+	ServiceId  string
+	PlanID     string
+}
+
+type GetInstanceResponse struct {
+	ServiceId    string         `json:"service_id"`
+	PlanID       string         `json:"plan_id"`
+	DashboardURL string         `json:"dashboard_url"`
+	Parameters   map[string]any `json:"parameters"`
 }
 
 type GetLastOperationRequestParameters struct {
@@ -84,7 +118,7 @@ type InstanceDeprovisionRequest struct {
 type BindRequest struct {
 	ServiceId    string         `json:"service_id"`
 	PlanID       string         `json:"plan_id"`
-	AppGUID      string         `json:"app_guid"`
+	AppGUID      string         `json:"app_guid,omitempty"`
 	BindResource BindResource   `json:"bind_resource"`
 	Parameters   map[string]any `json:"parameters"`
 }
@@ -96,13 +130,16 @@ type BindPayload struct {
 }
 
 type BindResponse struct {
-	Credentials map[string]any `json:"credentials"`
-	Operation   string         `json:"operation"`
-	Complete    bool
+	Credentials     map[string]any `json:"credentials"`
+	RouteServiceURL string         `json:"route_service_url"`
+	Operation       string         `json:"operation"`
+	Complete        bool
 }
 
 type BindResource struct {
-	AppGUID string `json:"app_guid"`
+	AppGUID            string `json:"app_guid,omitempty"`
+	CredentialClientID string `json:"credential_client_id,omitempty"`
+	Route              string `json:"route,omitempty"`
 }
 
 type UnbindPayload struct {
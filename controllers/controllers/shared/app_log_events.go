@@ -0,0 +1,40 @@
+package shared
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// AppLogEventRecorder records k8s events about the lifecycle of apps, which the
+// API surfaces in the logs of the app with the given source type (e.g. API,
+// CELL or STG)
+type AppLogEventRecorder struct {
+	recorder record.EventRecorder
+}
+
+func NewAppLogEventRecorder(recorder record.EventRecorder) *AppLogEventRecorder {
+	return &AppLogEventRecorder{
+		recorder: recorder,
+	}
+}
+
+func (r *AppLogEventRecorder) Eventf(
+	object runtime.Object,
+	appGUID string,
+	sourceType string,
+	instanceID string,
+	eventType string,
+	reason string,
+	messageFmt string,
+	args ...any,
+) {
+	annotations := map[string]string{
+		korifiv1alpha1.CFAppGUIDLabelKey:             appGUID,
+		korifiv1alpha1.AppLogSourceTypeAnnotationKey: sourceType,
+		korifiv1alpha1.AppLogInstanceIDAnnotationKey: instanceID,
+	}
+
+	r.recorder.AnnotatedEventf(object, annotations, eventType, reason, messageFmt, args...)
+}
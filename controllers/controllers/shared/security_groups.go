@@ -0,0 +1,58 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ToEgressRule converts a security group rule into a NetworkPolicy egress
+// rule. NetworkPolicies cannot filter ICMP traffic, so ICMP rules are not
+// supported. The API rejects them, but security groups created directly in
+// the cluster may still contain them, in which case they are skipped and
+// reported in the status of the security group.
+func ToEgressRule(rule securitygroups.Rule) (networkingv1.NetworkPolicyEgressRule, error) {
+	destinations, err := securitygroups.ParseDestination(rule.Destination)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, err
+	}
+
+	egressRule := networkingv1.NetworkPolicyEgressRule{}
+	for _, destination := range destinations {
+		egressRule.To = append(egressRule.To, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: destination.String()},
+		})
+	}
+
+	switch rule.Protocol {
+	case securitygroups.ProtocolAll:
+		return egressRule, nil
+	case securitygroups.ProtocolTCP, securitygroups.ProtocolUDP:
+		ports, err := securitygroups.ParsePorts(rule.Ports)
+		if err != nil {
+			return networkingv1.NetworkPolicyEgressRule{}, err
+		}
+
+		protocol := corev1.Protocol(strings.ToUpper(rule.Protocol))
+		for _, port := range ports {
+			policyPort := networkingv1.NetworkPolicyPort{
+				Protocol: tools.PtrTo(protocol),
+				Port:     tools.PtrTo(intstr.FromInt32(port.Start)),
+			}
+			if port.End > port.Start {
+				policyPort.EndPort = tools.PtrTo(port.End)
+			}
+			egressRule.Ports = append(egressRule.Ports, policyPort)
+		}
+
+		return egressRule, nil
+	default:
+		return networkingv1.NetworkPolicyEgressRule{}, fmt.Errorf("protocol %q is not supported by network policies", rule.Protocol)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package appevents
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Reconciler struct {
+	k8sClient       client.Client
+	log             logr.Logger
+	retentionPeriod time.Duration
+}
+
+func NewReconciler(
+	client client.Client,
+	log logr.Logger,
+	retentionPeriod time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAppUsageEvent, *korifiv1alpha1.CFAppUsageEvent] {
+	appUsageEventReconciler := Reconciler{
+		k8sClient:       client,
+		log:             log,
+		retentionPeriod: retentionPeriod,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAppUsageEvent, *korifiv1alpha1.CFAppUsageEvent](log, client, &appUsageEventReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAppUsageEvent{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch
+
+// ReconcileResource deletes app usage events once they are older than the
+// retention period and requeues the others for when they expire. The last
+// event of a process that still exists is kept regardless of its age, as
+// the usage recorder needs it to record the next change in the usage of the
+// process.
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfAppUsageEvent *korifiv1alpha1.CFAppUsageEvent) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfAppUsageEvent.Status.ObservedGeneration = cfAppUsageEvent.Generation
+	log.V(1).Info("set observed generation", "generation", cfAppUsageEvent.Status.ObservedGeneration)
+
+	if !cfAppUsageEvent.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	expiresIn := time.Until(cfAppUsageEvent.CreationTimestamp.Add(r.retentionPeriod))
+	if expiresIn > 0 {
+		return ctrl.Result{RequeueAfter: expiresIn}, nil
+	}
+
+	if _, isLatest := cfAppUsageEvent.Labels[korifiv1alpha1.LatestUsageEventLabelKey]; isLatest {
+		err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: cfAppUsageEvent.Spec.SpaceGUID, Name: cfAppUsageEvent.Spec.ProcessGUID}, &korifiv1alpha1.CFProcess{})
+		if err == nil {
+			return ctrl.Result{RequeueAfter: r.retentionPeriod}, nil
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			log.Info("error-getting-process", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	log.V(1).Info("deleting-expired-app-usage-event", "namespace", cfAppUsageEvent.Namespace, "name", cfAppUsageEvent.Name)
+	if err := r.k8sClient.Delete(ctx, cfAppUsageEvent); client.IgnoreNotFound(err) != nil {
+		log.Info("error-deleting-app-usage-event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,102 @@
+package appevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAppUsageEventReconciler Integration Tests", func() {
+	var (
+		namespace       string
+		cfProcess       *korifiv1alpha1.CFProcess
+		cfAppUsageEvent *korifiv1alpha1.CFAppUsageEvent
+	)
+
+	BeforeEach(func() {
+		namespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: "app-guid"},
+				ProcessType: korifiv1alpha1.ProcessTypeWeb,
+				HealthCheck: korifiv1alpha1.HealthCheck{Type: korifiv1alpha1.ProcessHealthCheckType},
+				MemoryMB:    256,
+				DiskQuotaMB: 256,
+			},
+		}
+
+		cfAppUsageEvent = &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				State:                 korifiv1alpha1.AppUsageEventStateStarted,
+				AppGUID:               "app-guid",
+				ProcessGUID:           cfProcess.Name,
+				ProcessType:           korifiv1alpha1.ProcessTypeWeb,
+				SpaceGUID:             namespace,
+				InstanceCount:         1,
+				MemoryInMBPerInstance: 256,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfAppUsageEvent)).To(Succeed())
+	})
+
+	It("keeps the event for the retention period", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAppUsageEvent), cfAppUsageEvent)).To(Succeed())
+		}, "1s").Should(Succeed())
+	})
+
+	It("deletes the event once the retention period has passed", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAppUsageEvent), cfAppUsageEvent)
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	When("the event is the latest one of the process", func() {
+		BeforeEach(func() {
+			cfAppUsageEvent.Labels = map[string]string{korifiv1alpha1.LatestUsageEventLabelKey: "true"}
+		})
+
+		It("deletes the event once the retention period has passed", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAppUsageEvent), cfAppUsageEvent)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the process still exists", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+			})
+
+			It("keeps the event after the retention period", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAppUsageEvent), cfAppUsageEvent)).To(Succeed())
+				}, "3s").Should(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package usage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// cacheSyncTimeout is how long a recorded event is expected to take at most
+// to be observed by the cache
+const cacheSyncTimeout = time.Minute
+
+// recordedEvents keeps track of the last usage event recorded for each app and
+// service instance until it is observed by the cache. Usage is recorded on
+// every reconcile, which may read the last events of a resource from a cache
+// that has not caught up with the event recorded by the previous reconcile yet
+// and would record the same usage again.
+type recordedEvents struct {
+	mu     sync.Mutex
+	events map[string]recordedEvent
+}
+
+type recordedEvent struct {
+	name       string
+	recordedAt time.Time
+}
+
+func (e *recordedEvents) record(guid, eventName string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	for g, event := range e.events {
+		if now.Sub(event.recordedAt) > cacheSyncTimeout {
+			delete(e.events, g)
+		}
+	}
+
+	e.events[guid] = recordedEvent{name: eventName, recordedAt: now}
+}
+
+// observe returns an error if the last event found for the resource is older
+// than the last one recorded for it, i.e. the cache is stale
+func (e *recordedEvents) observe(guid, lastEventName string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	event, ok := e.events[guid]
+	if !ok {
+		return nil
+	}
+
+	// the event may have been deleted since, e.g. when usage events are purged
+	if time.Since(event.recordedAt) <= cacheSyncTimeout && lastEventName < event.name {
+		return fmt.Errorf("usage event %q has not been observed yet", event.name)
+	}
+
+	delete(e.events, guid)
+	return nil
+}
@@ -0,0 +1,439 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServicePlanDetails describes the plan of a managed service instance
+type ServicePlanDetails struct {
+	PlanGUID     string
+	PlanName     string
+	OfferingGUID string
+	OfferingName string
+	BrokerGUID   string
+	BrokerName   string
+}
+
+func ServicePlanDetailsFor(assets osbapi.ServiceInstanceAssets) ServicePlanDetails {
+	return ServicePlanDetails{
+		PlanGUID:     assets.ServicePlan.Name,
+		PlanName:     assets.ServicePlan.Spec.Name,
+		OfferingGUID: assets.ServiceOffering.Name,
+		OfferingName: assets.ServiceOffering.Spec.Name,
+		BrokerGUID:   assets.ServiceBroker.Name,
+		BrokerName:   assets.ServiceBroker.Spec.Name,
+	}
+}
+
+// Recorder records app and service usage events. Recording is idempotent: an
+// event is only recorded when the usage of a resource differs from the one in
+// its last recorded event, so it is safe to record usage on every reconcile.
+// Only the last recorded event of a process or a service instance carries the
+// latest usage event label, so that it can be looked up through the cache
+// without listing all the events of the resource.
+type Recorder struct {
+	k8sClient     client.Client
+	rootNamespace string
+	recorded      *recordedEvents
+}
+
+func NewRecorder(k8sClient client.Client, rootNamespace string) *Recorder {
+	return &Recorder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+		recorded:      &recordedEvents{events: map[string]recordedEvent{}},
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceusageevents,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+
+// ReseedAppUsage records the usage of all the apps as if it had never been
+// recorded before, i.e. a started event for each running process. It is meant
+// to be called after all the app usage events have been purged.
+func (r *Recorder) ReseedAppUsage(ctx context.Context) error {
+	cfApps := &korifiv1alpha1.CFAppList{}
+	if err := r.k8sClient.List(ctx, cfApps); err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	for i := range cfApps.Items {
+		cfApp := &cfApps.Items[i]
+
+		cfProcesses := &korifiv1alpha1.CFProcessList{}
+		if err := r.k8sClient.List(ctx, cfProcesses,
+			client.InNamespace(cfApp.Namespace),
+			client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+		); err != nil {
+			return fmt.Errorf("failed to list processes of app %q: %w", cfApp.Name, err)
+		}
+
+		processes := []*korifiv1alpha1.CFProcess{}
+		for j := range cfProcesses.Items {
+			processes = append(processes, &cfProcesses.Items[j])
+		}
+
+		if err := r.RecordAppUsage(ctx, cfApp, processes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReseedServiceUsage records a created event for each existing service
+// instance. It is meant to be called after all the service usage events have
+// been purged.
+func (r *Recorder) ReseedServiceUsage(ctx context.Context) error {
+	cfServiceInstances := &korifiv1alpha1.CFServiceInstanceList{}
+	if err := r.k8sClient.List(ctx, cfServiceInstances); err != nil {
+		return fmt.Errorf("failed to list service instances: %w", err)
+	}
+
+	assets := osbapi.NewAssets(r.k8sClient, r.rootNamespace)
+	for i := range cfServiceInstances.Items {
+		cfServiceInstance := &cfServiceInstances.Items[i]
+		if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		planDetails := ServicePlanDetails{}
+		if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+			serviceInstanceAssets, err := assets.GetServiceInstanceAssets(ctx, cfServiceInstance)
+			if err != nil {
+				return fmt.Errorf("failed to get plan of service instance %q: %w", cfServiceInstance.Name, err)
+			}
+			planDetails = ServicePlanDetailsFor(serviceInstanceAssets)
+		}
+
+		if err := r.RecordServiceInstanceUsage(ctx, cfServiceInstance, planDetails); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordAppUsage records the usage of the given processes of the app. A
+// process is in use while its app is started and it has desired instances.
+// Processes with recorded usage that are not in the list anymore, e.g. because
+// the app is being deleted, are recorded as stopped.
+func (r *Recorder) RecordAppUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcesses []*korifiv1alpha1.CFProcess) error {
+	lastEvents, err := r.lastAppUsageEvents(ctx, cfApp.Name)
+	if err != nil {
+		return err
+	}
+
+	space, err := r.getSpace(ctx, cfApp.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, cfProcess := range cfProcesses {
+		usage := korifiv1alpha1.CFAppUsageEventSpec{
+			State:            korifiv1alpha1.AppUsageEventStateStopped,
+			AppGUID:          cfApp.Name,
+			AppName:          cfApp.Spec.DisplayName,
+			ProcessGUID:      cfProcess.Name,
+			ProcessType:      cfProcess.Spec.ProcessType,
+			SpaceGUID:        cfApp.Namespace,
+			SpaceName:        space.Spec.DisplayName,
+			OrganizationGUID: space.Namespace,
+		}
+		if isProcessRunning(cfApp, cfProcess) {
+			usage.State = korifiv1alpha1.AppUsageEventStateStarted
+			usage.InstanceCount = *cfProcess.Spec.DesiredInstances
+			usage.MemoryInMBPerInstance = cfProcess.Spec.MemoryMB
+		}
+
+		lastEvent, hasLastEvent := lastEvents[cfProcess.Name]
+		delete(lastEvents, cfProcess.Name)
+
+		if err := r.recordAppUsageChange(ctx, lastEvent, hasLastEvent, usage); err != nil {
+			return err
+		}
+	}
+
+	for _, lastEvent := range lastEvents {
+		usage := lastEvent.Spec
+		usage.State = korifiv1alpha1.AppUsageEventStateStopped
+		usage.InstanceCount = 0
+		usage.MemoryInMBPerInstance = 0
+
+		if err := r.recordAppUsageChange(ctx, lastEvent, true, usage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isProcessRunning(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) bool {
+	return cfApp.GetDeletionTimestamp().IsZero() &&
+		cfApp.Status.ActualState == korifiv1alpha1.StartedState &&
+		cfProcess.Spec.DesiredInstances != nil &&
+		*cfProcess.Spec.DesiredInstances > 0
+}
+
+func (r *Recorder) recordAppUsageChange(ctx context.Context, lastEvent korifiv1alpha1.CFAppUsageEvent, hasLastEvent bool, usage korifiv1alpha1.CFAppUsageEventSpec) error {
+	if !hasLastEvent {
+		if usage.State != korifiv1alpha1.AppUsageEventStateStarted {
+			return nil
+		}
+	} else {
+		if lastEvent.Spec.State == usage.State &&
+			(usage.State == korifiv1alpha1.AppUsageEventStateStopped ||
+				lastEvent.Spec.InstanceCount == usage.InstanceCount && lastEvent.Spec.MemoryInMBPerInstance == usage.MemoryInMBPerInstance) {
+			return nil
+		}
+
+		usage.PreviousState = lastEvent.Spec.State
+		usage.PreviousInstanceCount = lastEvent.Spec.InstanceCount
+		usage.PreviousMemoryInMBPerInstance = lastEvent.Spec.MemoryInMBPerInstance
+	}
+
+	name, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate app usage event name: %w", err)
+	}
+
+	cfAppUsageEvent := &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      name.String(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:        usage.AppGUID,
+				korifiv1alpha1.CFProcessGUIDLabelKey:    usage.ProcessGUID,
+				korifiv1alpha1.LatestUsageEventLabelKey: "true",
+			},
+		},
+		Spec: usage,
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("recording app usage event", "process", usage.ProcessGUID, "state", usage.State, "instances", usage.InstanceCount)
+	if err := r.k8sClient.Create(ctx, cfAppUsageEvent); err != nil {
+		return fmt.Errorf("failed to create app usage event for process %q: %w", usage.ProcessGUID, err)
+	}
+	r.recorded.record(usage.AppGUID, cfAppUsageEvent.Name)
+
+	if hasLastEvent {
+		return r.supersedeAppUsageEvent(ctx, &lastEvent)
+	}
+
+	return nil
+}
+
+// lastAppUsageEvents returns the last recorded event of each process of the
+// app, keyed by process GUID. A process may briefly have several events
+// labelled as latest when superseding one of them failed, in which case the
+// older ones are superseded again.
+func (r *Recorder) lastAppUsageEvents(ctx context.Context, appGUID string) (map[string]korifiv1alpha1.CFAppUsageEvent, error) {
+	cfAppUsageEvents := &korifiv1alpha1.CFAppUsageEventList{}
+	if err := r.k8sClient.List(ctx, cfAppUsageEvents,
+		client.InNamespace(r.rootNamespace),
+		client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey:        appGUID,
+			korifiv1alpha1.LatestUsageEventLabelKey: "true",
+		},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list app usage events: %w", err)
+	}
+
+	slices.SortFunc(cfAppUsageEvents.Items, func(a, b korifiv1alpha1.CFAppUsageEvent) int {
+		return strings.Compare(b.Name, a.Name)
+	})
+
+	lastEventName := ""
+	if len(cfAppUsageEvents.Items) > 0 {
+		lastEventName = cfAppUsageEvents.Items[0].Name
+	}
+	if err := r.recorded.observe(appGUID, lastEventName); err != nil {
+		return nil, err
+	}
+
+	lastEvents := map[string]korifiv1alpha1.CFAppUsageEvent{}
+	for _, event := range cfAppUsageEvents.Items {
+		if _, ok := lastEvents[event.Spec.ProcessGUID]; ok {
+			if err := r.supersedeAppUsageEvent(ctx, &event); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		lastEvents[event.Spec.ProcessGUID] = event
+	}
+
+	return lastEvents, nil
+}
+
+func (r *Recorder) supersedeAppUsageEvent(ctx context.Context, cfAppUsageEvent *korifiv1alpha1.CFAppUsageEvent) error {
+	err := k8s.PatchResource(ctx, r.k8sClient, cfAppUsageEvent, func() {
+		delete(cfAppUsageEvent.Labels, korifiv1alpha1.LatestUsageEventLabelKey)
+	})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to supersede app usage event %q: %w", cfAppUsageEvent.Name, err)
+	}
+
+	return nil
+}
+
+// RecordServiceInstanceUsage records the creation of the service instance, or
+// its deletion when it is being deleted. The plan details are only relevant
+// for managed service instances.
+func (r *Recorder) RecordServiceInstanceUsage(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance, planDetails ServicePlanDetails) error {
+	lastEvent, hasLastEvent, err := r.lastServiceUsageEvent(ctx, cfServiceInstance.Name)
+	if err != nil {
+		return err
+	}
+
+	state := korifiv1alpha1.ServiceUsageEventStateCreated
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		state = korifiv1alpha1.ServiceUsageEventStateDeleted
+	}
+
+	if hasLastEvent && lastEvent.Spec.State == state {
+		return nil
+	}
+
+	if !hasLastEvent && state == korifiv1alpha1.ServiceUsageEventStateDeleted {
+		return nil
+	}
+
+	space, err := r.getSpace(ctx, cfServiceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	name, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate service usage event name: %w", err)
+	}
+
+	cfServiceUsageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      name.String(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFServiceInstanceGUIDLabelKey: cfServiceInstance.Name,
+				korifiv1alpha1.LatestUsageEventLabelKey:      "true",
+			},
+		},
+		Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+			State:               state,
+			ServiceInstanceGUID: cfServiceInstance.Name,
+			ServiceInstanceName: cfServiceInstance.Spec.DisplayName,
+			ServiceInstanceType: cfServiceInstance.Spec.Type,
+			SpaceGUID:           cfServiceInstance.Namespace,
+			SpaceName:           space.Spec.DisplayName,
+			OrganizationGUID:    space.Namespace,
+			ServicePlanGUID:     planDetails.PlanGUID,
+			ServicePlanName:     planDetails.PlanName,
+			ServiceOfferingGUID: planDetails.OfferingGUID,
+			ServiceOfferingName: planDetails.OfferingName,
+			ServiceBrokerGUID:   planDetails.BrokerGUID,
+			ServiceBrokerName:   planDetails.BrokerName,
+		},
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("recording service usage event", "serviceInstance", cfServiceInstance.Name, "state", state)
+	if err := r.k8sClient.Create(ctx, cfServiceUsageEvent); err != nil {
+		return fmt.Errorf("failed to create service usage event for service instance %q: %w", cfServiceInstance.Name, err)
+	}
+	r.recorded.record(cfServiceInstance.Name, cfServiceUsageEvent.Name)
+
+	if hasLastEvent {
+		return r.supersedeServiceUsageEvent(ctx, &lastEvent)
+	}
+
+	return nil
+}
+
+func (r *Recorder) lastServiceUsageEvent(ctx context.Context, serviceInstanceGUID string) (korifiv1alpha1.CFServiceUsageEvent, bool, error) {
+	cfServiceUsageEvents := &korifiv1alpha1.CFServiceUsageEventList{}
+	if err := r.k8sClient.List(ctx, cfServiceUsageEvents,
+		client.InNamespace(r.rootNamespace),
+		client.MatchingLabels{
+			korifiv1alpha1.CFServiceInstanceGUIDLabelKey: serviceInstanceGUID,
+			korifiv1alpha1.LatestUsageEventLabelKey:      "true",
+		},
+	); err != nil {
+		return korifiv1alpha1.CFServiceUsageEvent{}, false, fmt.Errorf("failed to list service usage events: %w", err)
+	}
+
+	slices.SortFunc(cfServiceUsageEvents.Items, func(a, b korifiv1alpha1.CFServiceUsageEvent) int {
+		return strings.Compare(b.Name, a.Name)
+	})
+
+	lastEventName := ""
+	if len(cfServiceUsageEvents.Items) > 0 {
+		lastEventName = cfServiceUsageEvents.Items[0].Name
+	}
+	if err := r.recorded.observe(serviceInstanceGUID, lastEventName); err != nil {
+		return korifiv1alpha1.CFServiceUsageEvent{}, false, err
+	}
+
+	if len(cfServiceUsageEvents.Items) == 0 {
+		return korifiv1alpha1.CFServiceUsageEvent{}, false, nil
+	}
+
+	for _, event := range cfServiceUsageEvents.Items[1:] {
+		if err := r.supersedeServiceUsageEvent(ctx, &event); err != nil {
+			return korifiv1alpha1.CFServiceUsageEvent{}, false, err
+		}
+	}
+
+	return cfServiceUsageEvents.Items[0], true, nil
+}
+
+func (r *Recorder) supersedeServiceUsageEvent(ctx context.Context, cfServiceUsageEvent *korifiv1alpha1.CFServiceUsageEvent) error {
+	err := k8s.PatchResource(ctx, r.k8sClient, cfServiceUsageEvent, func() {
+		delete(cfServiceUsageEvent.Labels, korifiv1alpha1.LatestUsageEventLabelKey)
+	})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to supersede service usage event %q: %w", cfServiceUsageEvent.Name, err)
+	}
+
+	return nil
+}
+
+// getSpace returns the CFSpace whose namespace is given. The returned space is
+// empty if it cannot be found, e.g. because it has already been deleted.
+func (r *Recorder) getSpace(ctx context.Context, spaceGUID string) (korifiv1alpha1.CFSpace, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Name: spaceGUID}, namespace); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return korifiv1alpha1.CFSpace{}, nil
+		}
+		return korifiv1alpha1.CFSpace{}, fmt.Errorf("failed to get namespace %q: %w", spaceGUID, err)
+	}
+
+	orgGUID, ok := namespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if !ok {
+		return korifiv1alpha1.CFSpace{}, nil
+	}
+
+	cfSpace := korifiv1alpha1.CFSpace{}
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: orgGUID, Name: spaceGUID}, &cfSpace); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return korifiv1alpha1.CFSpace{}, nil
+		}
+		return korifiv1alpha1.CFSpace{}, fmt.Errorf("failed to get space %q: %w", spaceGUID, err)
+	}
+
+	return cfSpace, nil
+}
@@ -0,0 +1,326 @@
+package usage_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		recorder    *usage.Recorder
+		usageClient *staleClient
+		orgGUID     string
+		spaceGUID   string
+	)
+
+	BeforeEach(func() {
+		usageClient = &staleClient{Client: adminClient}
+		recorder = usage.NewRecorder(usageClient, rootNamespace)
+
+		orgGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: orgGUID},
+		})).To(Succeed())
+
+		spaceGUID = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   spaceGUID,
+				Labels: map[string]string{korifiv1alpha1.OrgGUIDKey: orgGUID},
+			},
+		})).To(Succeed())
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgGUID,
+				Name:      spaceGUID,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: "my-space",
+			},
+		})).To(Succeed())
+	})
+
+	Describe("RecordAppUsage", func() {
+		var (
+			cfApp     *korifiv1alpha1.CFApp
+			cfProcess *korifiv1alpha1.CFProcess
+		)
+
+		listAppUsageEvents := func() []korifiv1alpha1.CFAppUsageEventSpec {
+			GinkgoHelper()
+
+			events := &korifiv1alpha1.CFAppUsageEventList{}
+			Expect(adminClient.List(ctx, events, client.InNamespace(rootNamespace))).To(Succeed())
+
+			specs := []korifiv1alpha1.CFAppUsageEventSpec{}
+			for _, event := range events.Items {
+				specs = append(specs, event.Spec)
+			}
+			return specs
+		}
+
+		recordAppUsage := func() {
+			GinkgoHelper()
+
+			Expect(recorder.RecordAppUsage(ctx, cfApp, []*korifiv1alpha1.CFProcess{cfProcess})).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			cfApp = &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					DisplayName: "my-app",
+				},
+				Status: korifiv1alpha1.CFAppStatus{
+					ActualState: korifiv1alpha1.StartedState,
+				},
+			}
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					ProcessType:      "web",
+					DesiredInstances: tools.PtrTo[int32](2),
+					MemoryMB:         256,
+				},
+			}
+
+			recordAppUsage()
+		})
+
+		It("records the app as started", func() {
+			Expect(listAppUsageEvents()).To(ConsistOf(korifiv1alpha1.CFAppUsageEventSpec{
+				State:                 korifiv1alpha1.AppUsageEventStateStarted,
+				AppGUID:               cfApp.Name,
+				AppName:               "my-app",
+				ProcessGUID:           cfProcess.Name,
+				ProcessType:           "web",
+				SpaceGUID:             spaceGUID,
+				SpaceName:             "my-space",
+				OrganizationGUID:      orgGUID,
+				InstanceCount:         2,
+				MemoryInMBPerInstance: 256,
+			}))
+		})
+
+		When("the usage has not changed", func() {
+			BeforeEach(func() {
+				recordAppUsage()
+			})
+
+			It("does not record another event", func() {
+				Expect(listAppUsageEvents()).To(HaveLen(1))
+			})
+		})
+
+		When("the process is scaled", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+				recordAppUsage()
+			})
+
+			It("records the new instance count", func() {
+				Expect(listAppUsageEvents()).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"State":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+					"PreviousState":         Equal(korifiv1alpha1.AppUsageEventStateStarted),
+					"InstanceCount":         BeEquivalentTo(3),
+					"PreviousInstanceCount": BeEquivalentTo(2),
+				})))
+			})
+
+			It("labels only the new event as the latest one", func() {
+				events := &korifiv1alpha1.CFAppUsageEventList{}
+				Expect(adminClient.List(ctx, events,
+					client.InNamespace(rootNamespace),
+					client.MatchingLabels{korifiv1alpha1.LatestUsageEventLabelKey: "true"},
+				)).To(Succeed())
+				Expect(events.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{"InstanceCount": BeEquivalentTo(3)}),
+				})))
+			})
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				cfApp.Status.ActualState = korifiv1alpha1.StoppedState
+				recordAppUsage()
+				recordAppUsage()
+			})
+
+			It("records the app as stopped once", func() {
+				Expect(listAppUsageEvents()).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"State": Equal(korifiv1alpha1.AppUsageEventStateStarted)}),
+					MatchFields(IgnoreExtras, Fields{
+						"State":                         Equal(korifiv1alpha1.AppUsageEventStateStopped),
+						"PreviousState":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+						"InstanceCount":                 BeZero(),
+						"PreviousInstanceCount":         BeEquivalentTo(2),
+						"PreviousMemoryInMBPerInstance": BeEquivalentTo(256),
+					}),
+				))
+			})
+		})
+
+		When("the last recorded event has not been observed yet", func() {
+			BeforeEach(func() {
+				usageClient.stale = true
+			})
+
+			It("fails instead of recording the usage again", func() {
+				Expect(recorder.RecordAppUsage(ctx, cfApp, []*korifiv1alpha1.CFProcess{cfProcess})).To(MatchError(ContainSubstring("has not been observed yet")))
+				Expect(listAppUsageEvents()).To(HaveLen(1))
+			})
+		})
+
+		When("the space cannot be found", func() {
+			BeforeEach(func() {
+				cfApp.Namespace = uuid.NewString()
+				cfProcess.Name = uuid.NewString()
+				recordAppUsage()
+			})
+
+			It("records the event without the space details", func() {
+				Expect(listAppUsageEvents()).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"ProcessGUID":      Equal(cfProcess.Name),
+					"SpaceGUID":        Equal(cfApp.Namespace),
+					"SpaceName":        BeEmpty(),
+					"OrganizationGUID": BeEmpty(),
+				})))
+			})
+		})
+
+		When("the process is gone", func() {
+			BeforeEach(func() {
+				Expect(recorder.RecordAppUsage(ctx, cfApp, nil)).To(Succeed())
+			})
+
+			It("records the process as stopped", func() {
+				Expect(listAppUsageEvents()).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"State":       Equal(korifiv1alpha1.AppUsageEventStateStopped),
+					"ProcessGUID": Equal(cfProcess.Name),
+					"SpaceName":   Equal("my-space"),
+				})))
+			})
+		})
+	})
+
+	Describe("RecordServiceInstanceUsage", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			planDetails       usage.ServicePlanDetails
+		)
+
+		listServiceUsageEvents := func() []korifiv1alpha1.CFServiceUsageEventSpec {
+			GinkgoHelper()
+
+			events := &korifiv1alpha1.CFServiceUsageEventList{}
+			Expect(adminClient.List(ctx, events, client.InNamespace(rootNamespace))).To(Succeed())
+
+			specs := []korifiv1alpha1.CFServiceUsageEventSpec{}
+			for _, event := range events.Items {
+				specs = append(specs, event.Spec)
+			}
+			return specs
+		}
+
+		BeforeEach(func() {
+			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceGUID,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "my-instance",
+					Type:        korifiv1alpha1.ManagedType,
+				},
+			}
+			planDetails = usage.ServicePlanDetails{
+				PlanGUID:     "plan-guid",
+				PlanName:     "small",
+				OfferingGUID: "offering-guid",
+				OfferingName: "db",
+				BrokerGUID:   "broker-guid",
+				BrokerName:   "my-broker",
+			}
+
+			Expect(recorder.RecordServiceInstanceUsage(ctx, cfServiceInstance, planDetails)).To(Succeed())
+			Expect(recorder.RecordServiceInstanceUsage(ctx, cfServiceInstance, planDetails)).To(Succeed())
+		})
+
+		It("records the service instance as created once", func() {
+			Expect(listServiceUsageEvents()).To(ConsistOf(korifiv1alpha1.CFServiceUsageEventSpec{
+				State:               korifiv1alpha1.ServiceUsageEventStateCreated,
+				ServiceInstanceGUID: cfServiceInstance.Name,
+				ServiceInstanceName: "my-instance",
+				ServiceInstanceType: korifiv1alpha1.ManagedType,
+				SpaceGUID:           spaceGUID,
+				SpaceName:           "my-space",
+				OrganizationGUID:    orgGUID,
+				ServicePlanGUID:     "plan-guid",
+				ServicePlanName:     "small",
+				ServiceOfferingGUID: "offering-guid",
+				ServiceOfferingName: "db",
+				ServiceBrokerGUID:   "broker-guid",
+				ServiceBrokerName:   "my-broker",
+			}))
+		})
+
+		When("the service instance is being deleted", func() {
+			BeforeEach(func() {
+				cfServiceInstance.DeletionTimestamp = tools.PtrTo(metav1.Now())
+				Expect(recorder.RecordServiceInstanceUsage(ctx, cfServiceInstance, planDetails)).To(Succeed())
+			})
+
+			It("records the service instance as deleted", func() {
+				Expect(listServiceUsageEvents()).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"State": Equal(korifiv1alpha1.ServiceUsageEventStateCreated)}),
+					MatchFields(IgnoreExtras, Fields{"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted)}),
+				))
+			})
+
+			It("labels only the deleted event as the latest one", func() {
+				events := &korifiv1alpha1.CFServiceUsageEventList{}
+				Expect(adminClient.List(ctx, events,
+					client.InNamespace(rootNamespace),
+					client.MatchingLabels{korifiv1alpha1.LatestUsageEventLabelKey: "true"},
+				)).To(Succeed())
+				Expect(events.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted)}),
+				})))
+			})
+		})
+	})
+})
+
+// staleClient simulates a cache that has not observed any usage event yet
+type staleClient struct {
+	client.Client
+	stale bool
+}
+
+func (c *staleClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	switch list.(type) {
+	case *korifiv1alpha1.CFAppUsageEventList, *korifiv1alpha1.CFServiceUsageEventList:
+		if c.stale {
+			return nil
+		}
+	}
+
+	return c.Client.List(ctx, list, opts...)
+}
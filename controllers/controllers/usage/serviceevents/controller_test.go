@@ -0,0 +1,97 @@
+package serviceevents_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceUsageEventReconciler Integration Tests", func() {
+	var (
+		namespace           string
+		cfServiceInstance   *korifiv1alpha1.CFServiceInstance
+		cfServiceUsageEvent *korifiv1alpha1.CFServiceUsageEvent
+	)
+
+	BeforeEach(func() {
+		namespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "my-instance",
+				Type:        korifiv1alpha1.UserProvidedType,
+			},
+		}
+
+		cfServiceUsageEvent = &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+				State:               korifiv1alpha1.ServiceUsageEventStateCreated,
+				ServiceInstanceGUID: cfServiceInstance.Name,
+				ServiceInstanceName: "my-instance",
+				ServiceInstanceType: korifiv1alpha1.UserProvidedType,
+				SpaceGUID:           namespace,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfServiceUsageEvent)).To(Succeed())
+	})
+
+	It("keeps the event for the retention period", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceUsageEvent), cfServiceUsageEvent)).To(Succeed())
+		}, "1s").Should(Succeed())
+	})
+
+	It("deletes the event once the retention period has passed", func() {
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceUsageEvent), cfServiceUsageEvent)
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	When("the event is the latest one of the service instance", func() {
+		BeforeEach(func() {
+			cfServiceUsageEvent.Labels = map[string]string{korifiv1alpha1.LatestUsageEventLabelKey: "true"}
+		})
+
+		It("deletes the event once the retention period has passed", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceUsageEvent), cfServiceUsageEvent)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the service instance still exists", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("keeps the event after the retention period", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceUsageEvent), cfServiceUsageEvent)).To(Succeed())
+				}, "3s").Should(Succeed())
+			})
+		})
+	})
+})
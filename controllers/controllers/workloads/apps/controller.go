@@ -1,9 +1,14 @@
 package apps
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -13,11 +18,13 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,21 +37,29 @@ type EnvValueBuilder interface {
 	BuildEnvValue(context.Context, *korifiv1alpha1.CFApp) (map[string][]byte, error)
 }
 
+type UsageRecorder interface {
+	RecordAppUsage(context.Context, *korifiv1alpha1.CFApp, []*korifiv1alpha1.CFProcess) error
+}
+
 type Reconciler struct {
 	log                       logr.Logger
 	k8sClient                 client.Client
 	scheme                    *runtime.Scheme
 	vcapServicesEnvBuilder    EnvValueBuilder
 	vcapApplicationEnvBuilder EnvValueBuilder
+	usageRecorder             UsageRecorder
+	logEventRecorder          *shared.AppLogEventRecorder
 }
 
-func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
+func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder, usageRecorder UsageRecorder, eventRecorder record.EventRecorder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
 	appReconciler := Reconciler{
 		log:                       log,
 		k8sClient:                 k8sClient,
 		scheme:                    scheme,
 		vcapServicesEnvBuilder:    vcapServicesBuilder,
 		vcapApplicationEnvBuilder: vcapApplicationBuilder,
+		usageRecorder:             usageRecorder,
+		logEventRecorder:          shared.NewAppLogEventRecorder(eventRecorder),
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, &appReconciler)
 }
@@ -99,7 +114,11 @@ func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Reque
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/finalizers,verbs=update
 
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfrevisions,verbs=get;list;watch;create
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -120,6 +139,8 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		cfApp.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	}
 
+	r.recordDesiredStateChange(cfApp)
+
 	bindingsReady, err := r.serviceBindingsReady(ctx, cfApp)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -158,7 +179,20 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, err
 	}
 
+	setCanaryStepCondition(cfApp, reconciledProcesses)
+
+	if err = r.reconcileRevision(ctx, cfApp, reconciledProcesses); err != nil {
+		log.Info("failed to record app revision", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	cfApp.Status.ActualState = getActualState(reconciledProcesses)
+
+	if err = r.usageRecorder.RecordAppUsage(ctx, cfApp, reconciledProcesses); err != nil {
+		log.Info("failed to record app usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	if cfApp.Status.ActualState != cfApp.Spec.DesiredState {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DesiredStateNotReached")
 	}
@@ -166,6 +200,34 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 	return ctrl.Result{}, nil
 }
 
+// recordDesiredStateChange records an app log event whenever the desired state
+// of the app changes. Apps that are created stopped are not logged until they
+// are started.
+func (r *Reconciler) recordDesiredStateChange(cfApp *korifiv1alpha1.CFApp) {
+	loggedState := cfApp.Annotations[korifiv1alpha1.CFAppLoggedStateKey]
+	desiredState := string(cfApp.Spec.DesiredState)
+	if loggedState == desiredState {
+		return
+	}
+	cfApp.Annotations[korifiv1alpha1.CFAppLoggedStateKey] = desiredState
+
+	if loggedState == "" && cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return
+	}
+
+	r.logEventRecorder.Eventf(
+		cfApp,
+		cfApp.Name,
+		korifiv1alpha1.AppLogSourceTypeAPI,
+		"0",
+		corev1.EventTypeNormal,
+		"DesiredStateChanged",
+		`Updated app with guid %s ({"state"=>"%s"})`,
+		cfApp.Name,
+		desiredState,
+	)
+}
+
 func (r *Reconciler) serviceBindingsReady(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (bool, error) {
 	bindings := &korifiv1alpha1.CFServiceBindingList{}
 	if err := r.k8sClient.List(ctx, bindings,
@@ -196,6 +258,38 @@ func getActualState(processes []*korifiv1alpha1.CFProcess) korifiv1alpha1.AppSta
 	return korifiv1alpha1.StartedState
 }
 
+// setCanaryStepCondition reports whether all the canary instances of the
+// current step of an in-progress canary deployment are running
+func setCanaryStepCondition(cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) {
+	if cfApp.Spec.Canary == nil {
+		meta.RemoveStatusCondition(&cfApp.Status.Conditions, korifiv1alpha1.StatusConditionCanaryStepReady)
+		return
+	}
+
+	step := cfApp.Spec.Canary.Step()
+	condition := metav1.Condition{
+		Type:               korifiv1alpha1.StatusConditionCanaryStepReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfApp.Generation,
+		Reason:             "CanaryInstancesRunning",
+	}
+
+	for _, p := range processes {
+		if p.Spec.DesiredInstances == nil || *p.Spec.DesiredInstances == 0 {
+			continue
+		}
+
+		if p.Status.CanaryInstances < step.Instances {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "CanaryInstancesStarting"
+			condition.Message = fmt.Sprintf("%d of %d canary instances of process %q are running", p.Status.CanaryInstances, step.Instances, p.Spec.ProcessType)
+			break
+		}
+	}
+
+	meta.SetStatusCondition(&cfApp.Status.Conditions, condition)
+}
+
 func (r *Reconciler) getDroplet(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.BuildDropletStatus, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getDroplet").WithValues("dropletName", cfApp.Spec.CurrentDropletRef.Name)
 
@@ -296,6 +390,205 @@ func (r *Reconciler) createCFProcess(ctx context.Context, process korifiv1alpha1
 	return desiredCFProcess, nil
 }
 
+// reconcileRevision records a CFRevision whenever the droplet, the environment
+// variables or the process commands of the app differ from its latest revision
+func (r *Reconciler) reconcileRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, reconciledProcesses []*korifiv1alpha1.CFProcess) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileRevision")
+
+	appEnv, err := r.getSecretData(ctx, cfApp.Namespace, cfApp.Spec.EnvSecretName)
+	if err != nil {
+		return err
+	}
+
+	processCommands, err := r.getProcessCommands(ctx, cfApp, reconciledProcesses)
+	if err != nil {
+		return err
+	}
+
+	latestRevision, err := r.getLatestRevision(ctx, cfApp)
+	if err != nil {
+		return err
+	}
+
+	version := 1
+	description := "Initial revision."
+	if latestRevision != nil {
+		var revisionEnv map[string][]byte
+		revisionEnv, err = r.getSecretData(ctx, cfApp.Namespace, latestRevision.Spec.EnvSecretName)
+		if err != nil {
+			return err
+		}
+
+		changes := describeRevisionChanges(latestRevision, revisionEnv, cfApp.Spec.CurrentDropletRef.Name, appEnv, processCommands)
+		if len(changes) == 0 {
+			return nil
+		}
+
+		version = latestRevision.Spec.Version + 1
+		description = strings.Join(changes, " ")
+	}
+
+	revisionName := tools.NamespacedUUID(cfApp.Name, "revision", strconv.Itoa(version))
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionName + "-env",
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, envSecret, func() error {
+		if envSecret.Labels == nil {
+			envSecret.Labels = map[string]string{}
+		}
+		envSecret.Labels[korifiv1alpha1.CFAppGUIDLabelKey] = cfApp.Name
+		envSecret.Data = appEnv
+
+		return controllerutil.SetOwnerReference(cfApp, envSecret, r.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create revision env secret: %w", err)
+	}
+
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionName,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:          corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:         version,
+			DropletRef:      cfApp.Spec.CurrentDropletRef,
+			EnvSecretName:   envSecret.Name,
+			ProcessCommands: processCommands,
+			Description:     description,
+		},
+	}
+	if err = controllerutil.SetControllerReference(cfApp, revision, r.scheme); err != nil {
+		return fmt.Errorf("failed to set OwnerRef on CFRevision: %w", err)
+	}
+
+	err = r.k8sClient.Create(ctx, revision)
+	if k8serrors.IsAlreadyExists(err) {
+		// the cache has not caught up with a revision created by a previous reconcile
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create revision: %w", err)
+	}
+
+	log.V(1).Info("recorded revision", "version", version)
+	return nil
+}
+
+func (r *Reconciler) getSecretData(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+	if name == "" {
+		return map[string][]byte{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if k8serrors.IsNotFound(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+
+	if secret.Data == nil {
+		return map[string][]byte{}, nil
+	}
+
+	return secret.Data, nil
+}
+
+func (r *Reconciler) getProcessCommands(ctx context.Context, cfApp *korifiv1alpha1.CFApp, reconciledProcesses []*korifiv1alpha1.CFProcess) (map[string]string, error) {
+	cfProcessList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, cfProcessList,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing app CFProcesses: %w", err)
+	}
+
+	processCommands := map[string]string{}
+	for _, process := range cfProcessList.Items {
+		processCommands[process.Spec.ProcessType] = process.Spec.Command
+	}
+	for _, process := range reconciledProcesses {
+		processCommands[process.Spec.ProcessType] = process.Spec.Command
+	}
+
+	return processCommands, nil
+}
+
+func (r *Reconciler) getLatestRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFRevision, error) {
+	revisionList := &korifiv1alpha1.CFRevisionList{}
+	err := r.k8sClient.List(ctx, revisionList,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error listing app CFRevisions: %w", err)
+	}
+
+	var latestRevision *korifiv1alpha1.CFRevision
+	for i := range revisionList.Items {
+		if latestRevision == nil || revisionList.Items[i].Spec.Version > latestRevision.Spec.Version {
+			latestRevision = &revisionList.Items[i]
+		}
+	}
+
+	return latestRevision, nil
+}
+
+func describeRevisionChanges(
+	revision *korifiv1alpha1.CFRevision,
+	revisionEnv map[string][]byte,
+	dropletGUID string,
+	appEnv map[string][]byte,
+	processCommands map[string]string,
+) []string {
+	changes := []string{}
+
+	if revision.Spec.DropletRef.Name != dropletGUID {
+		changes = append(changes, "New droplet deployed.")
+	}
+
+	if !maps.EqualFunc(revisionEnv, appEnv, bytes.Equal) {
+		changes = append(changes, "New environment variables deployed.")
+	}
+
+	for _, processType := range slices.Sorted(maps.Keys(processCommands)) {
+		oldCommand, existed := revision.Spec.ProcessCommands[processType]
+		newCommand := processCommands[processType]
+		if existed && oldCommand == newCommand {
+			continue
+		}
+
+		switch {
+		case !existed:
+			changes = append(changes, fmt.Sprintf("New process type '%s' added.", processType))
+		case oldCommand == "":
+			changes = append(changes, fmt.Sprintf("Custom start command added for '%s' process.", processType))
+		case newCommand == "":
+			changes = append(changes, fmt.Sprintf("Custom start command removed for '%s' process.", processType))
+		default:
+			changes = append(changes, fmt.Sprintf("Custom start command updated for '%s' process.", processType))
+		}
+	}
+
+	for _, processType := range slices.Sorted(maps.Keys(revision.Spec.ProcessCommands)) {
+		if _, ok := processCommands[processType]; !ok {
+			changes = append(changes, fmt.Sprintf("Process type '%s' removed.", processType))
+		}
+	}
+
+	return changes
+}
+
 func (r *Reconciler) fetchProcessByType(ctx context.Context, appGUID, appNamespace, processType string) (*korifiv1alpha1.CFProcess, error) {
 	selector, err := labels.ValidatedSelectorFromSet(map[string]string{
 		korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
@@ -341,6 +634,11 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		return sbFinalizationResult, nil
 	}
 
+	if err = r.usageRecorder.RecordAppUsage(ctx, cfApp, nil); err != nil {
+		log.Info("failed to record app usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	if controllerutil.RemoveFinalizer(cfApp, korifiv1alpha1.CFAppFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -349,7 +647,7 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 }
 
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
-	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
+	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name)
 	if err != nil {
 		return err
 	}
@@ -415,12 +713,14 @@ func (r *Reconciler) updateRouteDestinations(ctx context.Context, cfAppGUID stri
 	return nil
 }
 
-func (r *Reconciler) getCFRoutes(ctx context.Context, cfAppGUID string, cfAppNamespace string) ([]korifiv1alpha1.CFRoute, error) {
+// getCFRoutes returns the routes with destinations of the app, including the
+// routes shared with the app space, which live in other namespaces
+func (r *Reconciler) getCFRoutes(ctx context.Context, cfAppGUID string) ([]korifiv1alpha1.CFRoute, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getCFRoutes")
 
 	var foundRoutes korifiv1alpha1.CFRouteList
 	matchingFields := client.MatchingFields{shared.IndexRouteDestinationAppName: cfAppGUID}
-	err := r.k8sClient.List(context.Background(), &foundRoutes, client.InNamespace(cfAppNamespace), matchingFields)
+	err := r.k8sClient.List(context.Background(), &foundRoutes, matchingFields)
 	if err != nil {
 		log.Info("failed to List CFRoutes", "reason", err)
 		return []korifiv1alpha1.CFRoute{}, err
@@ -1,6 +1,9 @@
 package apps_test
 
 import (
+	"fmt"
+	"slices"
+
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -194,6 +197,59 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	Describe("app log events", func() {
+		appLogEvents := func(g Gomega) []corev1.Event {
+			events := &corev1.EventList{}
+			g.Expect(adminClient.List(ctx, events, client.InNamespace(testNamespace))).To(Succeed())
+			return slices.DeleteFunc(events.Items, func(event corev1.Event) bool {
+				return event.InvolvedObject.Name != cfApp.Name
+			})
+		}
+
+		It("remembers the logged desired state", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppLoggedStateKey, "STOPPED"))
+			}).Should(Succeed())
+		})
+
+		It("does not record an event for an app that is created stopped", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(appLogEvents(g)).To(BeEmpty())
+			}, "1s").Should(Succeed())
+		})
+
+		When("the app is started", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Annotations).To(HaveKey(korifiv1alpha1.CFAppLoggedStateKey))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+			})
+
+			It("records an API app log event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(appLogEvents(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Annotations": Equal(map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey:             cfApp.Name,
+								korifiv1alpha1.AppLogSourceTypeAnnotationKey: "API",
+								korifiv1alpha1.AppLogInstanceIDAnnotationKey: "0",
+							}),
+						}),
+						"Source":  MatchFields(IgnoreExtras, Fields{"Component": Equal(korifiv1alpha1.AppLogEventComponent)}),
+						"Reason":  Equal("DesiredStateChanged"),
+						"Message": Equal(fmt.Sprintf(`Updated app with guid %s ({"state"=>"STARTED"})`, cfApp.Name)),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	It("creates a default web CFProcess", func() {
 		Eventually(func(g Gomega) {
 			cfProcessList := &korifiv1alpha1.CFProcessList{}
@@ -275,6 +331,118 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("revisions", func() {
+		listRevisions := func(g Gomega) []korifiv1alpha1.CFRevision {
+			revisionList := &korifiv1alpha1.CFRevisionList{}
+			g.Expect(adminClient.List(ctx, revisionList, client.InNamespace(cfApp.Namespace))).To(Succeed())
+			return revisionList.Items
+		}
+
+		It("records an initial revision", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listRevisions(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Name":   Equal(tools.NamespacedUUID(cfApp.Name, "revision", "1")),
+						"Labels": HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name),
+					}),
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"AppRef":          Equal(corev1.LocalObjectReference{Name: cfApp.Name}),
+						"Version":         Equal(1),
+						"DropletRef":      Equal(corev1.LocalObjectReference{Name: cfBuild.Name}),
+						"ProcessCommands": Equal(map[string]string{"web": ""}),
+						"Description":     Equal("Initial revision."),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the process command changes", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				process := &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfApp.Namespace,
+						Name:      tools.NamespacedUUID(cfApp.Name, "web"),
+					},
+				}
+				Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, process, func() {
+					process.Spec.Command = "custom command"
+				})).To(Succeed())
+			})
+
+			It("records a new revision", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listRevisions(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"Version":         Equal(2),
+							"ProcessCommands": Equal(map[string]string{"web": "custom command"}),
+							"Description":     Equal("Custom start command added for 'web' process."),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the app has a canary deployment in progress", func() {
+		var process *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.Canary = &korifiv1alpha1.CFAppCanary{
+					DropletRef: corev1.LocalObjectReference{Name: cfBuild.Name},
+					Steps:      []korifiv1alpha1.CanaryStep{{Instances: 2}},
+				}
+			})).To(Succeed())
+
+			process = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfApp.Namespace,
+					Name:      tools.NamespacedUUID(cfApp.Name, "web"),
+				},
+			}
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+			}).Should(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, process, func() {
+				process.Spec.DesiredInstances = tools.PtrTo[int32](1)
+			})).To(Succeed())
+		})
+
+		It("sets the canary step ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionCanaryStepReady)),
+					HasStatus(Equal(metav1.ConditionFalse)),
+					HasReason(Equal("CanaryInstancesStarting")),
+				)))
+			}).Should(Succeed())
+		})
+
+		When("the canary instances are running", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, process, func() {
+					process.Status.CanaryInstances = 2
+				})).To(Succeed())
+			})
+
+			It("sets the canary step ready condition to true", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionCanaryStepReady)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the app desired state does not match the actual state", func() {
 		BeforeEach(func() {
 			Eventually(func(g Gomega) {
@@ -461,7 +629,7 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 					Destinations: []korifiv1alpha1.Destination{
 						{
 							GUID: "destination-1-guid",
-							AppRef: corev1.LocalObjectReference{
+							AppRef: corev1.ObjectReference{
 								Name: cfApp.Name,
 							},
 							ProcessType: "web",
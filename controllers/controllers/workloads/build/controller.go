@@ -5,12 +5,15 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,11 +34,12 @@ type DelegateReconciler interface {
 }
 
 type Reconciler struct {
-	log          logr.Logger
-	k8sClient    client.Client
-	scheme       *runtime.Scheme
-	buildCleaner BuildCleaner
-	delegate     DelegateReconciler
+	log              logr.Logger
+	k8sClient        client.Client
+	scheme           *runtime.Scheme
+	buildCleaner     BuildCleaner
+	delegate         DelegateReconciler
+	logEventRecorder *shared.AppLogEventRecorder
 }
 
 var packageTypeToLifecycleType = map[korifiv1alpha1.PackageType]korifiv1alpha1.LifecycleType{
@@ -49,13 +53,15 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	buildCleaner BuildCleaner,
 	delegate DelegateReconciler,
+	eventRecorder record.EventRecorder,
 ) *Reconciler {
 	return &Reconciler{
-		log:          log,
-		k8sClient:    k8sClient,
-		scheme:       scheme,
-		buildCleaner: buildCleaner,
-		delegate:     delegate,
+		log:              log,
+		k8sClient:        k8sClient,
+		scheme:           scheme,
+		buildCleaner:     buildCleaner,
+		delegate:         delegate,
+		logEventRecorder: shared.NewAppLogEventRecorder(eventRecorder),
 	}
 }
 
@@ -63,6 +69,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return r.delegate.SetupWithManager(mgr)
 }
 
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -93,6 +101,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
+	if cfBuild.Spec.UploadedDroplet != nil {
+		reconcileUploadedDroplet(cfBuild)
+		return ctrl.Result{}, nil
+	}
+
 	cfPackage := new(korifiv1alpha1.CFPackage)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
 	if err != nil {
@@ -116,11 +129,66 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 			Reason:             "BuildNotRunning",
 			ObservedGeneration: cfBuild.Generation,
 		})
+		r.recordStagingProgress(cfBuild, false)
 
 		return ctrl.Result{}, nil
 	}
 
-	return r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
+	wasStaging := meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)
+	result, err := r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
+	r.recordStagingProgress(cfBuild, wasStaging)
+
+	return result, err
+}
+
+// reconcileUploadedDroplet completes builds whose droplet has been built
+// outside the cluster as soon as the droplet image has been uploaded
+func reconcileUploadedDroplet(cfBuild *korifiv1alpha1.CFBuild) {
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	if cfBuild.Spec.UploadedDroplet.Registry.Image == "" {
+		return
+	}
+
+	cfBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+		Registry:     cfBuild.Spec.UploadedDroplet.Registry,
+		Stack:        cfBuild.Spec.Lifecycle.Data.Stack,
+		ProcessTypes: cfBuild.Spec.UploadedDroplet.ProcessTypes,
+	}
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DropletUploaded",
+		ObservedGeneration: cfBuild.Generation,
+	})
+}
+
+// recordStagingProgress records app log events when the build starts staging
+// and when it completes or fails
+func (r *Reconciler) recordStagingProgress(cfBuild *korifiv1alpha1.CFBuild, wasStaging bool) {
+	recordEvent := func(eventType, reason, messageFmt string, args ...any) {
+		r.logEventRecorder.Eventf(cfBuild, cfBuild.Spec.AppRef.Name, korifiv1alpha1.AppLogSourceTypeSTG, "0", eventType, reason, messageFmt, args...)
+	}
+
+	succeededStatus := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+	if succeededStatus != nil {
+		if succeededStatus.Status == metav1.ConditionTrue {
+			recordEvent(corev1.EventTypeNormal, "StagingSucceeded", "Staging complete")
+		} else {
+			recordEvent(corev1.EventTypeWarning, "StagingFailed", "Staging failed: %s", succeededStatus.Message)
+		}
+		return
+	}
+
+	if !wasStaging && meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType) {
+		recordEvent(corev1.EventTypeNormal, "StagingStarted", "Staging build with guid %s", cfBuild.Name)
+	}
 }
 
 func validateLifecycleTypes(
@@ -141,6 +141,25 @@ var _ = Describe("CFBuildReconciler", func() {
 					g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
 				}).Should(Succeed())
 			})
+
+			It("records a staging app log event", func() {
+				Eventually(func(g Gomega) {
+					events := &v1.EventList{}
+					g.Expect(adminClient.List(ctx, events, client.InNamespace(testNamespace))).To(Succeed())
+					g.Expect(events.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+							"Annotations": Equal(map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey:             cfApp.Name,
+								korifiv1alpha1.AppLogSourceTypeAnnotationKey: "STG",
+								korifiv1alpha1.AppLogInstanceIDAnnotationKey: "0",
+							}),
+						}),
+						"InvolvedObject": MatchFields(IgnoreExtras, Fields{"Name": Equal(cfBuild.Name)}),
+						"Reason":         Equal("StagingFailed"),
+						"Message":        Equal("Staging failed: cannot build bits package with docker build"),
+					})))
+				}).Should(Succeed())
+			})
 		})
 
 		When("the package type is docker and build type is buildpack", func() {
@@ -194,6 +213,64 @@ var _ = Describe("CFBuildReconciler", func() {
 		})
 	})
 
+	When("the build is for an uploaded droplet", func() {
+		BeforeEach(func() {
+			cfBuild.Spec.PackageRef.Name = ""
+			cfBuild.Spec.Lifecycle.Data.Stack = "cflinuxfs4"
+			cfBuild.Spec.UploadedDroplet = &korifiv1alpha1.UploadedDroplet{
+				ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+			}
+		})
+
+		It("does not delegate the build", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(reconciledBuilds()).NotTo(HaveKey(cfBuild.Name))
+			}).Should(Succeed())
+		})
+
+		It("awaits the droplet upload", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+				g.Expect(meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeNil())
+				g.Expect(cfBuild.Status.Droplet).To(BeNil())
+			}).Should(Succeed())
+		})
+
+		When("the droplet image is uploaded", func() {
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfBuild, func() {
+					cfBuild.Spec.UploadedDroplet.Registry = korifiv1alpha1.Registry{
+						Image:            "my-registry/my-droplet@sha256:123",
+						ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry-secret"}},
+					}
+				})).To(Succeed())
+			})
+
+			It("succeeds the build", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+					g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("sets the droplet status from the uploaded droplet", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					g.Expect(cfBuild.Status.Droplet).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Registry": Equal(korifiv1alpha1.Registry{
+							Image:            "my-registry/my-droplet@sha256:123",
+							ImagePullSecrets: []v1.LocalObjectReference{{Name: "registry-secret"}},
+						}),
+						"Stack":        Equal("cflinuxfs4"),
+						"ProcessTypes": ConsistOf(korifiv1alpha1.ProcessType{Type: "web", Command: "bundle exec rackup"}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the build succeeds", func() {
 		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +46,7 @@ func NewReconciler(
 	imageConfigGetter ImageConfigGetter,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	eventRecorder record.EventRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFBuild, *korifiv1alpha1.CFBuild] {
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuild, *korifiv1alpha1.CFBuild](
 		log,
@@ -58,6 +60,7 @@ func NewReconciler(
 				k8sClient:         k8sClient,
 				imageConfigGetter: imageConfigGetter,
 			},
+			eventRecorder,
 		))
 }
 
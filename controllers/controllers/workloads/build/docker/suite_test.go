@@ -81,6 +81,7 @@ var _ = BeforeSuite(func() {
 		image.NewClient(k8sClient),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFDockerBuild"),
+		k8sManager.GetEventRecorderFor(korifiv1alpha1.AppLogEventComponent),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -126,6 +126,7 @@ var _ = BeforeSuite(func() {
 			scheme.Scheme,
 			buildCleaner,
 			delegateReconciler,
+			k8sManager.GetEventRecorderFor(korifiv1alpha1.AppLogEventComponent),
 		),
 	).SetupWithManager(k8sManager)).To(Succeed())
 
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -31,12 +32,23 @@ type ServiceDetails struct {
 	VolumeMounts   []string       `json:"volume_mounts"`
 }
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfenvvargroups,verbs=get;list;watch
+
+// AppEnvBuilder builds the environment of an app. The variables of the
+// envVarGroup environment variable group in the root namespace are added
+// unless the app sets a variable with the same name.
 type AppEnvBuilder struct {
-	k8sClient client.Client
+	k8sClient     client.Client
+	rootNamespace string
+	envVarGroup   string
 }
 
-func NewAppEnvBuilder(k8sClient client.Client) *AppEnvBuilder {
-	return &AppEnvBuilder{k8sClient: k8sClient}
+func NewAppEnvBuilder(k8sClient client.Client, rootNamespace string, envVarGroup string) *AppEnvBuilder {
+	return &AppEnvBuilder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+		envVarGroup:   envVarGroup,
+	}
 }
 
 func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
@@ -64,7 +76,35 @@ func (b *AppEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFApp)
 	}
 
 	// We explicitly order the vcapServicesSecret last so that its "VCAP_*" contents win
-	return sortEnvVars(envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)), nil
+	envVars := envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)
+
+	groupEnvVars, err := b.envVarGroupEnvVars(ctx, envVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortEnvVars(append(envVars, groupEnvVars...)), nil
+}
+
+func (b *AppEnvBuilder) envVarGroupEnvVars(ctx context.Context, appEnvVars []corev1.EnvVar) ([]corev1.EnvVar, error) {
+	envVarGroup := &korifiv1alpha1.CFEnvVarGroup{}
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: b.envVarGroup}, envVarGroup)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error when trying to fetch %s environment variable group: %w", b.envVarGroup, err)
+	}
+
+	var envVars []corev1.EnvVar
+	for name, value := range envVarGroup.Spec.Env {
+		if slices.ContainsFunc(appEnvVars, func(envVar corev1.EnvVar) bool { return envVar.Name == name }) {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return envVars, nil
 }
 
 func sortEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
@@ -98,9 +138,9 @@ type ProcessEnvBuilder struct {
 	k8sClient     client.Client
 }
 
-func NewProcessEnvBuilder(k8sClient client.Client) *ProcessEnvBuilder {
+func NewProcessEnvBuilder(k8sClient client.Client, rootNamespace string) *ProcessEnvBuilder {
 	return &ProcessEnvBuilder{
-		appEnvBuilder: NewAppEnvBuilder(k8sClient),
+		appEnvBuilder: NewAppEnvBuilder(k8sClient, rootNamespace, korifiv1alpha1.RunningEnvVarGroupName),
 		k8sClient:     k8sClient,
 	}
 }
@@ -126,9 +166,9 @@ func (b *ProcessEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFA
 }
 
 func (b *ProcessEnvBuilder) buildPortEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error) {
+	// routes shared with the app space live in other namespaces
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err := b.k8sClient.List(ctx, &cfRoutesForProcess,
-		client.InNamespace(cfProcess.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -101,7 +101,7 @@ var _ = Describe("EnvBuilder", func() {
 		var builder *env.AppEnvBuilder
 
 		BeforeEach(func() {
-			builder = env.NewAppEnvBuilder(controllersClient)
+			builder = env.NewAppEnvBuilder(controllersClient, rootNamespace, korifiv1alpha1.RunningEnvVarGroupName)
 		})
 
 		JustBeforeEach(func() {
@@ -127,6 +127,45 @@ var _ = Describe("EnvBuilder", func() {
 			Expect(slices.IsSorted(envVarNames)).To(BeTrue())
 		})
 
+		When("the environment variable group is set", func() {
+			BeforeEach(func() {
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{
+							"HTTP_PROXY": "http://proxy",
+							"app-secret": "group-value",
+						},
+					},
+				})
+				helpers.EnsureCreate(controllersClient, &korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.StagingEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{"STAGING_ONLY": "true"},
+					},
+				})
+			})
+
+			It("adds the group env vars that are not set on the app", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ConsistOf(
+					appSecretEnv,
+					vcapServicesEnv,
+					vcapApplicationEnv,
+					MatchFields(IgnoreExtras, Fields{
+						"Name":  Equal("HTTP_PROXY"),
+						"Value": Equal("http://proxy"),
+					}),
+				))
+			})
+		})
+
 		When("the app env secret does not exist", func() {
 			BeforeEach(func() {
 				helpers.EnsureDelete(controllersClient, appSecret)
@@ -277,7 +316,7 @@ var _ = Describe("EnvBuilder", func() {
 				},
 			}
 			helpers.EnsureCreate(controllersClient, cfProcess)
-			builder = env.NewProcessEnvBuilder(controllersClient)
+			builder = env.NewProcessEnvBuilder(controllersClient, rootNamespace)
 		})
 
 		JustBeforeEach(func() {
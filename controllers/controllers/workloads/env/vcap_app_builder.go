@@ -60,11 +60,11 @@ func (b *VCAPApplicationEnvValueBuilder) BuildEnvValue(ctx context.Context, cfAp
 }
 
 func (b *VCAPApplicationEnvValueBuilder) getAppURIs(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]string, error) {
+	// routes shared with the app space live in other namespaces
 	var appRoutes korifiv1alpha1.CFRouteList
 	err := b.k8sClient.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -72,7 +72,7 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 	serviceLabel := serviceBinding.Annotations[korifiv1alpha1.ServiceInstanceTypeAnnotationKey]
 
 	serviceInstance := korifiv1alpha1.CFServiceInstance{}
-	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.ServiceInstanceNamespace(), Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
 	if err != nil {
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceInstance: %w", err)
 	}
@@ -27,7 +27,7 @@ var _ = Describe("FromRoutes", func() {
 				Destinations: []korifiv1alpha1.Destination{{
 					GUID: "dest-guid",
 					Port: tools.PtrTo[int32](9876),
-					AppRef: corev1.LocalObjectReference{
+					AppRef: corev1.ObjectReference{
 						Name: "my-app",
 					},
 					ProcessType: "web",
@@ -54,7 +54,7 @@ var _ = Describe("FromRoutes", func() {
 					Destinations: []korifiv1alpha1.Destination{{
 						GUID: "dest-guid",
 						Port: tools.PtrTo[int32](1234),
-						AppRef: corev1.LocalObjectReference{
+						AppRef: corev1.ObjectReference{
 							Name: "my-app",
 						},
 						ProcessType: "web",
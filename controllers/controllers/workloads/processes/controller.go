@@ -109,7 +109,7 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 
 	result := []reconcile.Request{}
 	for _, destination := range cfRoute.Status.Destinations {
-		result = append(result, r.cfProcessRequestsForAppGUID(ctx, cfRoute.Namespace, destination.AppRef.Name)...)
+		result = append(result, r.cfProcessRequestsForAppGUID(ctx, destination.AppNamespace(cfRoute.Namespace), destination.AppRef.Name)...)
 	}
 
 	return result
@@ -156,9 +156,16 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		if err != nil {
 			return ctrl.Result{}, err
 		}
+
+		if cfApp.Spec.Canary != nil {
+			err = r.createOrPatchCanaryAppWorkload(ctx, cfApp, cfProcess, cfAppRev, cfLastStopAppRev)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
-	err = r.cleanUpAppWorkloads(ctx, cfProcess, cfApp.Spec.DesiredState, cfLastStopAppRev)
+	err = r.cleanUpAppWorkloads(ctx, cfProcess, cfApp, cfLastStopAppRev)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -168,15 +175,18 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
-	cfProcess.Status.ActualInstances = getActualInstances(appWorkloads)
+	cfProcess.Status.ActualInstances = getActualInstances(appWorkloads, cfProcess.Name)
+	cfProcess.Status.CanaryInstances = getActualInstances(appWorkloads, korifiv1alpha1.CanaryWorkloadGUID(cfProcess.Name))
 
 	return ctrl.Result{}, nil
 }
 
-func getActualInstances(appWorkloads []korifiv1alpha1.AppWorkload) int32 {
+func getActualInstances(appWorkloads []korifiv1alpha1.AppWorkload, workloadGUID string) int32 {
 	actualInstances := int32(0)
 	for _, w := range appWorkloads {
-		actualInstances += w.Status.ActualInstances
+		if w.Spec.GUID == workloadGUID {
+			actualInstances += w.Status.ActualInstances
+		}
 	}
 	return actualInstances
 }
@@ -191,23 +201,66 @@ func needsAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFP
 }
 
 func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfAppRev, cfLastStopAppRev string) error {
-	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchAppWorkload")
+	return r.createOrPatchAppWorkloadForDroplet(
+		ctx,
+		cfApp,
+		cfProcess,
+		cfApp.Spec.CurrentDropletRef.Name,
+		generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name),
+		cfAppRev,
+		cfLastStopAppRev,
+		func(*korifiv1alpha1.AppWorkload) {},
+	)
+}
+
+// createOrPatchCanaryAppWorkload runs the instances of the canary droplet
+// alongside the ones of the current droplet. The canary workload has its own
+// GUID so that its pods can be told apart by the route services.
+func (r *Reconciler) createOrPatchCanaryAppWorkload(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfAppRev, cfLastStopAppRev string) error {
+	canary := cfApp.Spec.Canary
+
+	return r.createOrPatchAppWorkloadForDroplet(
+		ctx,
+		cfApp,
+		cfProcess,
+		canary.DropletRef.Name,
+		generateCanaryAppWorkloadName(cfLastStopAppRev, cfProcess.Name),
+		cfAppRev,
+		cfLastStopAppRev,
+		func(appWorkload *korifiv1alpha1.AppWorkload) {
+			appWorkload.Spec.GUID = korifiv1alpha1.CanaryWorkloadGUID(cfProcess.Name)
+			appWorkload.Spec.Version = cfAppRev + "-canary"
+			appWorkload.Spec.Instances = canary.Step().Instances
+		},
+	)
+}
+
+func (r *Reconciler) createOrPatchAppWorkloadForDroplet(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	cfProcess *korifiv1alpha1.CFProcess,
+	dropletName string,
+	appWorkloadName string,
+	cfAppRev, cfLastStopAppRev string,
+	customize func(*korifiv1alpha1.AppWorkload),
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchAppWorkload").WithValues("appWorkloadName", appWorkloadName)
 
 	cfBuild := new(korifiv1alpha1.CFBuild)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfApp.Spec.CurrentDropletRef.Name, Namespace: cfProcess.Namespace}, cfBuild)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: dropletName, Namespace: cfProcess.Namespace}, cfBuild)
 	if err != nil {
-		log.Info("error when trying to fetch CFBuild", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
+		log.Info("error when trying to fetch CFBuild", "namespace", cfProcess.Namespace, "name", dropletName, "reason", err)
 		return err
 	}
 
 	if cfBuild.Status.Droplet == nil {
-		log.Info("no build droplet status on CFBuild", "namespace", cfProcess.Namespace, "name", cfApp.Spec.CurrentDropletRef.Name, "reason", err)
+		log.Info("no build droplet status on CFBuild", "namespace", cfProcess.Namespace, "name", dropletName, "reason", err)
 		return errors.New("no build droplet status on CFBuild")
 	}
 
+	// routes shared with the app space live in other namespaces
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err = r.k8sClient.List(ctx, &cfRoutesForProcess,
-		client.InNamespace(cfProcess.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -225,7 +278,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	actualAppWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfProcess.Namespace,
-			Name:      generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name),
+			Name:      appWorkloadName,
 		},
 	}
 
@@ -235,6 +288,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		log.Info("error when initializing AppWorkload", "reason", err)
 		return err
 	}
+	customize(desiredAppWorkload)
 
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, actualAppWorkload, appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload))
 	if err != nil {
@@ -244,7 +298,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	return nil
 }
 
-func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, desiredState korifiv1alpha1.AppState, cfLastStopAppRev string) error {
+func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, cfApp *korifiv1alpha1.CFApp, cfLastStopAppRev string) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("cleanUpAppWorkloads")
 
 	appWorkloadsForProcess, err := r.fetchAppWorkloadsForProcess(ctx, cfProcess)
@@ -254,7 +308,7 @@ func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv
 	}
 
 	for i, currentAppWorkload := range appWorkloadsForProcess {
-		if needsToDeleteAppWorkload(desiredState, cfProcess, currentAppWorkload, cfLastStopAppRev) {
+		if needsToDeleteAppWorkload(cfApp, cfProcess, currentAppWorkload, cfLastStopAppRev) {
 			err := r.k8sClient.Delete(ctx, &appWorkloadsForProcess[i])
 			if err != nil {
 				log.Info("error occurred deleting AppWorkload", "name", currentAppWorkload.Name, "reason", err)
@@ -266,14 +320,21 @@ func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv
 }
 
 func needsToDeleteAppWorkload(
-	desiredState korifiv1alpha1.AppState,
+	cfApp *korifiv1alpha1.CFApp,
 	cfProcess *korifiv1alpha1.CFProcess,
 	appWorkload korifiv1alpha1.AppWorkload,
 	cfLastStopAppRev string,
 ) bool {
-	return desiredState == korifiv1alpha1.StoppedState ||
-		(cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances == 0) ||
-		appWorkload.Name != generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name)
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState ||
+		(cfProcess.Spec.DesiredInstances != nil && *cfProcess.Spec.DesiredInstances == 0) {
+		return true
+	}
+
+	if cfApp.Spec.Canary != nil && appWorkload.Name == generateCanaryAppWorkloadName(cfLastStopAppRev, cfProcess.Name) {
+		return false
+	}
+
+	return appWorkload.Name != generateAppWorkloadName(cfLastStopAppRev, cfProcess.Name)
 }
 
 func appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload *korifiv1alpha1.AppWorkload) controllerutil.MutateFn {
@@ -355,6 +416,10 @@ func generateAppWorkloadName(cfAppRev string, processGUID string) string {
 	return appWorkloadName
 }
 
+func generateCanaryAppWorkloadName(cfAppRev string, processGUID string) string {
+	return generateAppWorkloadName("canary-"+cfAppRev, processGUID)
+}
+
 func (r *Reconciler) fetchAppWorkloadsForProcess(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) ([]korifiv1alpha1.AppWorkload, error) {
 	allAppWorkloads := &korifiv1alpha1.AppWorkloadList{}
 	err := r.k8sClient.List(ctx, allAppWorkloads, client.InNamespace(cfProcess.Namespace))
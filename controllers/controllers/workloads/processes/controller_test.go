@@ -95,7 +95,7 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			Spec: korifiv1alpha1.CFRouteSpec{
 				Destinations: []korifiv1alpha1.Destination{{
 					GUID:        uuid.NewString(),
-					AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+					AppRef:      corev1.ObjectReference{Name: cfApp.Name},
 					ProcessType: korifiv1alpha1.ProcessTypeWeb,
 					Port:        tools.PtrTo[int32](8080),
 					Protocol:    tools.PtrTo("http1"),
@@ -460,6 +460,101 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				}, "1s").Should(Succeed())
 			})
 		})
+
+		When("the app has a canary deployment in progress", func() {
+			var canaryBuild *korifiv1alpha1.CFBuild
+
+			BeforeEach(func() {
+				canaryBuild = &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFBuildSpec{
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, canaryBuild)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, canaryBuild, func() {
+					canaryBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+						Registry: korifiv1alpha1.Registry{
+							Image: "canary/image/url",
+						},
+					}
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Canary = &korifiv1alpha1.CFAppCanary{
+						DropletRef: corev1.LocalObjectReference{Name: canaryBuild.Name},
+						Steps:      []korifiv1alpha1.CanaryStep{{Instances: 2}},
+					}
+				})).To(Succeed())
+			})
+
+			getCanaryAppWorkload := func(g Gomega) korifiv1alpha1.AppWorkload {
+				var appWorkloads korifiv1alpha1.AppWorkloadList
+				g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(testNamespace))).To(Succeed())
+				g.Expect(appWorkloads.Items).To(HaveLen(2))
+
+				for _, appWorkload := range appWorkloads.Items {
+					if appWorkload.Spec.GUID == korifiv1alpha1.CanaryWorkloadGUID(cfProcess.Name) {
+						return appWorkload
+					}
+				}
+
+				g.Expect(false).To(BeTrue(), "canary app workload not found")
+				return korifiv1alpha1.AppWorkload{}
+			}
+
+			It("runs the canary droplet alongside the current one", func() {
+				Eventually(func(g Gomega) {
+					canaryAppWorkload := getCanaryAppWorkload(g)
+					g.Expect(canaryAppWorkload.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFProcessGUIDLabelKey, cfProcess.Name))
+					g.Expect(canaryAppWorkload.Spec.Image).To(Equal("canary/image/url"))
+					g.Expect(canaryAppWorkload.Spec.Instances).To(BeEquivalentTo(2))
+					g.Expect(canaryAppWorkload.Spec.Version).To(Equal("5-canary"))
+				}).Should(Succeed())
+			})
+
+			When("the canary app workload instances are running", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						canaryAppWorkload := getCanaryAppWorkload(g)
+						g.Expect(k8s.Patch(ctx, adminClient, &canaryAppWorkload, func() {
+							canaryAppWorkload.Status.ActualInstances = 2
+						})).To(Succeed())
+					}).Should(Succeed())
+				})
+
+				It("reports them separately from the actual instances", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.CanaryInstances).To(BeEquivalentTo(2))
+						g.Expect(cfProcess.Status.ActualInstances).To(BeZero())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the canary deployment is over", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						getCanaryAppWorkload(g)
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Canary = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the canary app workload", func() {
+					eventuallyCreatedAppWorkloadShould(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.GUID).To(Equal(cfProcess.Name))
+					})
+				})
+			})
+		})
 	})
 })
 
@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -90,7 +91,12 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		).
 		Watches(
 			&corev1.ServiceAccount{},
-			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForServiceAccount),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForRootNamespaceObject),
+		).
+		Watches(
+			&korifiv1alpha1.CFSecurityGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForRootNamespaceObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
 		)
 }
 
@@ -109,7 +115,7 @@ func (r *Reconciler) enqueueCFSpaceRequests(ctx context.Context, object client.O
 	return requests
 }
 
-func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context, object client.Object) []reconcile.Request {
+func (r *Reconciler) enqueueCFSpaceRequestsForRootNamespaceObject(ctx context.Context, object client.Object) []reconcile.Request {
 	if object.GetNamespace() != r.rootNamespace {
 		return nil
 	}
@@ -136,6 +142,8 @@ func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;patch;delete;get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;create;patch;delete
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) (ctrl.Result, error) {
 	nsReconcileResult, err := r.namespaceReconciler.ReconcileResource(ctx, cfSpace)
@@ -151,6 +159,12 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ServiceAccountPropagation")
 	}
 
+	err = r.reconcileSecurityGroups(ctx, cfSpace)
+	if err != nil {
+		log.Info("not ready yet", "reason", "error reconciling security groups", "error", err)
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("SecurityGroups")
+	}
+
 	return ctrl.Result{}, nil
 }
 
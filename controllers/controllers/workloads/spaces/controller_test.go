@@ -6,6 +6,9 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/spaces"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
@@ -13,10 +16,12 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/pod-security-admission/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -321,4 +326,115 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 	})
+
+	Describe("security groups", func() {
+		var securityGroup *korifiv1alpha1.CFSecurityGroup
+
+		BeforeEach(func() {
+			securityGroup = &korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfRootNamespace,
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: uuid.NewString(),
+					Rules: []securitygroups.Rule{
+						{Protocol: securitygroups.ProtocolTCP, Destination: "10.0.0.0/24", Ports: "443,8000-9000"},
+						{Protocol: securitygroups.ProtocolAll, Destination: "192.168.0.1-192.168.0.2"},
+						{Protocol: securitygroups.ProtocolICMP, Destination: "10.0.0.0/24", Type: tools.PtrTo[int32](8), Code: tools.PtrTo[int32](0)},
+					},
+					Spaces: map[string]securitygroups.Workloads{
+						cfSpace.Name: {Running: true},
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, securityGroup)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(adminClient.Delete(ctx, securityGroup))).To(Succeed())
+			})
+		})
+
+		It("renders the running security groups into a network policy", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: spaces.RunningSecurityGroupsPolicyName}, networkPolicy)).To(Succeed())
+
+				g.Expect(networkPolicy.Annotations).To(HaveKeyWithValue(spaces.SecurityGroupsAnnotation, securityGroup.Name))
+				g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+				g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+					Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}))
+				g.Expect(networkPolicy.Spec.Egress).To(ConsistOf(
+					networkingv1.NetworkPolicyEgressRule{
+						To: []networkingv1.NetworkPolicyPeer{{
+							NamespaceSelector: &metav1.LabelSelector{},
+							PodSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{{
+									Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+									Operator: metav1.LabelSelectorOpExists,
+								}},
+							},
+						}},
+					},
+					networkingv1.NetworkPolicyEgressRule{
+						To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/24"}}},
+						Ports: []networkingv1.NetworkPolicyPort{
+							{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(443))},
+							{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(8000)), EndPort: tools.PtrTo[int32](9000)},
+						},
+					},
+					networkingv1.NetworkPolicyEgressRule{
+						To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}}, {IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.2/32"}}},
+					},
+				))
+			}).Should(Succeed())
+		})
+
+		It("does not create a staging network policy", func() {
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: spaces.StagingSecurityGroupsPolicyName}, &networkingv1.NetworkPolicy{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}, time.Second).Should(Succeed())
+		})
+
+		When("the security group is unbound from the space", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: spaces.RunningSecurityGroupsPolicyName}, &networkingv1.NetworkPolicy{})).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, securityGroup, func() {
+					securityGroup.Spec.Spaces = nil
+				})).To(Succeed())
+			})
+
+			It("deletes the network policy", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: spaces.RunningSecurityGroupsPolicyName}, &networkingv1.NetworkPolicy{})
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the security group is globally enabled for staging", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, securityGroup, func() {
+					securityGroup.Spec.GloballyEnabled.Staging = true
+				})).To(Succeed())
+			})
+
+			It("renders the staging security groups into a network policy", func() {
+				Eventually(func(g Gomega) {
+					networkPolicy := &networkingv1.NetworkPolicy{}
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: spaces.StagingSecurityGroupsPolicyName}, networkPolicy)).To(Succeed())
+					g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+						Key:      korifiv1alpha1.BuildWorkloadLabelKey,
+						Operator: metav1.LabelSelectorOpExists,
+					}))
+					g.Expect(networkPolicy.Spec.Egress).To(HaveLen(2))
+				}).Should(Succeed())
+			})
+		})
+	})
 })
@@ -0,0 +1,143 @@
+package spaces
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	RunningSecurityGroupsPolicyName = "korifi-running-security-groups"
+	StagingSecurityGroupsPolicyName = "korifi-staging-security-groups"
+
+	SecurityGroupsAnnotation = "korifi.cloudfoundry.org/security-groups"
+)
+
+type securityGroupsPolicy struct {
+	name        string
+	podSelector metav1.LabelSelector
+	appliesTo   func(securitygroups.Workloads) bool
+	baseRules   []networkingv1.NetworkPolicyEgressRule
+}
+
+var appPodsSelector = metav1.LabelSelector{
+	MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+		Operator: metav1.LabelSelectorOpExists,
+	}},
+}
+
+// Running workloads are the app and task pods, both of which carry the app
+// GUID label. Staging workloads are the build pods, which carry the build
+// workload label propagated by the image builder. As in CF, container to
+// container traffic is not subject to security groups (it is governed by the
+// network policies on the destination app), so running workloads can always
+// reach other app pods.
+var securityGroupsPolicies = []securityGroupsPolicy{
+	{
+		name:        RunningSecurityGroupsPolicyName,
+		podSelector: appPodsSelector,
+		appliesTo:   func(w securitygroups.Workloads) bool { return w.Running },
+		baseRules: []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{},
+				PodSelector:       &appPodsSelector,
+			}},
+		}},
+	},
+	{
+		name: StagingSecurityGroupsPolicyName,
+		podSelector: metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      korifiv1alpha1.BuildWorkloadLabelKey,
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		},
+		appliesTo: func(w securitygroups.Workloads) bool { return w.Staging },
+	},
+}
+
+// reconcileSecurityGroups renders the security groups that apply to the space
+// into egress NetworkPolicies in the space namespace. As long as no security
+// group applies to a workload type, no policy is created for it and its
+// egress is left unrestricted.
+func (r *Reconciler) reconcileSecurityGroups(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileSecurityGroups")
+
+	securityGroups := &korifiv1alpha1.CFSecurityGroupList{}
+	if err := r.client.List(ctx, securityGroups, client.InNamespace(r.rootNamespace)); err != nil {
+		log.Info("error listing security groups", "reason", err)
+		return err
+	}
+
+	slices.SortFunc(securityGroups.Items, func(a, b korifiv1alpha1.CFSecurityGroup) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, policy := range securityGroupsPolicies {
+		var groupGUIDs []string
+		rules := slices.Clone(policy.baseRules)
+		for _, securityGroup := range securityGroups.Items {
+			if !securityGroup.DeletionTimestamp.IsZero() || !policy.appliesTo(securityGroup.AppliesTo(cfSpace.Name)) {
+				continue
+			}
+
+			groupGUIDs = append(groupGUIDs, securityGroup.Name)
+			for _, rule := range securityGroup.Spec.Rules {
+				egressRule, err := shared.ToEgressRule(rule)
+				if err != nil {
+					log.Info("skipping security group rule", "securityGroup", securityGroup.Name, "reason", err)
+					continue
+				}
+				rules = append(rules, egressRule)
+			}
+		}
+
+		networkPolicy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      policy.name,
+				Namespace: cfSpace.Name,
+			},
+		}
+
+		if len(groupGUIDs) == 0 {
+			if err := r.client.Delete(ctx, networkPolicy); client.IgnoreNotFound(err) != nil {
+				log.Info("error deleting network policy", "name", policy.name, "reason", err)
+				return err
+			}
+			continue
+		}
+
+		result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+			if networkPolicy.Annotations == nil {
+				networkPolicy.Annotations = map[string]string{}
+			}
+			networkPolicy.Annotations[SecurityGroupsAnnotation] = strings.Join(groupGUIDs, ",")
+
+			networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+				PodSelector: policy.podSelector,
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress:      rules,
+			}
+			return nil
+		})
+		if err != nil {
+			log.Info("error creating/patching network policy", "name", policy.name, "reason", err)
+			return err
+		}
+
+		log.V(1).Info("security groups network policy reconciled", "name", policy.name, "operation", result)
+	}
+
+	return nil
+}
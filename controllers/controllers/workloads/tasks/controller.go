@@ -260,6 +260,7 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		}
 
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
+		taskWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey] = cfTask.Spec.AppRef.Name
 
 		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
 		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
@@ -212,6 +212,7 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 
 				taskWorkload = taskWorkloads.Items[0]
 				g.Expect(taskWorkload.Name).To(Equal(cfTask.Name))
+				g.Expect(taskWorkload.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name))
 				g.Expect(taskWorkload.Spec.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "echo hello"}))
 				g.Expect(taskWorkload.Spec.Image).To(Equal("registry.io/my/image"))
 				g.Expect(taskWorkload.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "registry-secret"}}))
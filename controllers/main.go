@@ -27,8 +27,11 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/cleanup"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/auditevents"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/policies"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/securitygroups"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
@@ -37,6 +40,9 @@ import (
 	upsi_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage/appevents"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage/serviceevents"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
@@ -60,6 +66,7 @@ import (
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
+	processeswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	jobtaskrunnercontrollers "code.cloudfoundry.org/korifi/job-task-runner/controllers"
@@ -84,6 +91,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -101,6 +109,7 @@ func init() {
 	utilruntime.Must(buildv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(servicebindingv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -172,6 +181,8 @@ func main() {
 	if os.Getenv("ENABLE_CONTROLLERS") != "false" {
 		controllersLog := ctrl.Log.WithName("controllers")
 		imageClient := image.NewClient(k8sClient)
+		usageRecorder := usage.NewRecorder(mgr.GetClient(), controllerConfig.CFRootNamespace)
+		appLogEventRecorder := mgr.GetEventRecorderFor(korifiv1alpha1.AppLogEventComponent)
 
 		if err = apps.NewReconciler(
 			mgr.GetClient(),
@@ -179,6 +190,8 @@ func main() {
 			controllersLog,
 			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient()),
 			env.NewVCAPApplicationEnvValueBuilder(mgr.GetClient(), controllerConfig.ExtraVCAPApplicationValues),
+			usageRecorder,
+			appLogEventRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
 			os.Exit(1)
@@ -191,7 +204,8 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewAppEnvBuilder(mgr.GetClient()),
+			env.NewAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace, korifiv1alpha1.StagingEnvVarGroupName),
+			appLogEventRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuildpackBuild")
 			os.Exit(1)
@@ -203,6 +217,7 @@ func main() {
 			imageClient,
 			mgr.GetScheme(),
 			controllersLog,
+			appLogEventRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFDockerBuild")
 			os.Exit(1)
@@ -225,7 +240,7 @@ func main() {
 			mgr.GetScheme(),
 			controllersLog,
 			controllerConfig,
-			env.NewProcessEnvBuilder(mgr.GetClient()),
+			env.NewProcessEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
@@ -235,6 +250,7 @@ func main() {
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			controllersLog,
+			usageRecorder,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "UPSICFServiceInstance")
 			os.Exit(1)
@@ -297,13 +313,51 @@ func main() {
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("cftask-controller"),
 			controllersLog,
-			env.NewAppEnvBuilder(mgr.GetClient()),
+			env.NewAppEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace, korifiv1alpha1.RunningEnvVarGroupName),
 			taskTTL,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
 		}
 
+		var auditEventTTL time.Duration
+		auditEventTTL, err = controllerConfig.ParseAuditEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse audit event TTL", "controller", "CFAuditEvent", "auditEventTTL", controllerConfig.AuditEventTTL)
+			os.Exit(1)
+		}
+		if err = auditevents.NewReconciler(
+			mgr.GetClient(),
+			controllersLog,
+			auditEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAuditEvent")
+			os.Exit(1)
+		}
+
+		var usageEventTTL time.Duration
+		usageEventTTL, err = controllerConfig.ParseUsageEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse usage event TTL", "usageEventTTL", controllerConfig.UsageEventTTL)
+			os.Exit(1)
+		}
+		if err = appevents.NewReconciler(
+			mgr.GetClient(),
+			controllersLog,
+			usageEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAppUsageEvent")
+			os.Exit(1)
+		}
+		if err = serviceevents.NewReconciler(
+			mgr.GetClient(),
+			controllersLog,
+			usageEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceUsageEvent")
+			os.Exit(1)
+		}
+
 		if err = domains.NewReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -330,6 +384,7 @@ func main() {
 				mgr.GetScheme(),
 				controllerConfig.CFRootNamespace,
 				controllersLog,
+				usageRecorder,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "ManagedCFServiceInstance")
 				os.Exit(1)
@@ -420,6 +475,15 @@ func main() {
 				os.Exit(1)
 			}
 
+			if err = statefulsetcontrollers.NewPodReconciler(
+				mgr.GetClient(),
+				appLogEventRecorder,
+				controllersLog,
+			).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "AppPod")
+				os.Exit(1)
+			}
+
 			if err = statefulsetcontrollers.NewRunnerInfoReconciler(
 				mgr.GetClient(),
 				mgr.GetScheme(),
@@ -440,6 +504,25 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = policies.NewReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			controllersLog,
+			controllerConfig,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFNetworkPolicy")
+			os.Exit(1)
+		}
+
+		if err = securitygroups.NewReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			controllersLog,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFSecurityGroup")
+			os.Exit(1)
+		}
+
 	}
 
 	// Setup webhooks with manager
@@ -478,8 +561,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := validation.NewQuotaValidator(uncachedClient, controllerConfig.CFRootNamespace, controllerConfig.CFProcessDefaults.MemoryMB)
+
 		if err = appswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, appswebhook.AppEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
@@ -487,6 +573,7 @@ func main() {
 
 		if err = routeswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routeswebhook.RouteEntityType)),
+			quotaValidator,
 			controllerConfig.CFRootNamespace,
 			uncachedClient,
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -496,6 +583,7 @@ func main() {
 
 		if err = instanceswebhook.NewValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, instanceswebhook.ServiceInstanceEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
@@ -503,6 +591,7 @@ func main() {
 
 		if err = bindingswebhook.NewCFServiceBindingValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, bindingswebhook.ServiceBindingEntityType)),
+			uncachedClient,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBinding")
 			os.Exit(1)
@@ -548,11 +637,16 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = taskswebhook.NewValidator().SetupWebhookWithManager(mgr); err != nil {
+		if err = taskswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
 		}
 
+		if err = processeswebhook.NewValidator(quotaValidator).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
 		versionwebhook.NewVersionWebhook(version.Version).SetupWebhookWithManager(mgr)
 		controllersfinalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(mgr)
 
@@ -79,6 +79,13 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		}.ExportJSONError()
 	}
 
+	if domain.Spec.Internal && domain.Spec.RouterGroup != "" {
+		return nil, validationwebhook.ValidationError{
+			Type:    InvalidDomainErrorType,
+			Message: "Internal domains cannot have a router group",
+		}.ExportJSONError()
+	}
+
 	isOverlapping, err := v.domainIsOverlapping(ctx, domain.Spec.Name)
 	if err != nil {
 		log.Info("error checking for overlapping domain", "reason", err)
@@ -124,6 +131,20 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, o
 		}.ExportJSONError()
 	}
 
+	if oldDomain.Spec.Internal != domain.Spec.Internal {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.Internal"),
+		}.ExportJSONError()
+	}
+
+	if oldDomain.Spec.RouterGroup != domain.Spec.RouterGroup {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.RouterGroup"),
+		}.ExportJSONError()
+	}
+
 	return nil, nil
 }
 
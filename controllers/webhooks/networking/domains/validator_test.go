@@ -125,6 +125,20 @@ var _ = Describe("CFDomainValidator", func() {
 			})
 		})
 
+		When("the domain is internal and has a router group", func() {
+			BeforeEach(func() {
+				requestDomainCR.Spec.Internal = true
+				requestDomainCR.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					domains.InvalidDomainErrorType,
+					Equal("Internal domains cannot have a router group"),
+				))
+			})
+		})
+
 		When("there is an issue listing shared CFDomains", func() {
 			BeforeEach(func() {
 				listDomainsErr = errors.New("boom")
@@ -174,6 +188,34 @@ var _ = Describe("CFDomainValidator", func() {
 			))
 		})
 
+		When("the internal flag is changed", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.Internal = true
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.Internal' field is immutable"),
+				))
+			})
+		})
+
+		When("the router group is changed", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.RouterGroup' field is immutable"),
+				))
+			})
+		})
+
 		When("the domain is being deleted", func() {
 			BeforeEach(func() {
 				updatedCFDomain.DeletionTimestamp = &metav1.Time{Time: time.Now()}
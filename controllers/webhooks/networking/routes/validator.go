@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -28,10 +29,13 @@ const (
 
 	RouteDestinationNotInSpaceErrorType    = "RouteDestinationNotInSpaceError"
 	RouteDestinationNotInSpaceErrorMessage = "Route destination app not found in space"
+	RouteDestinationNotSharedErrorMessage  = "Route destination apps must be in the space of the route or in a space the route is shared with"
 	RouteHostNameValidationErrorType       = "RouteHostNameValidationError"
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	RouteInternalDomainErrorType           = "RouteInternalDomainError"
+	RouteProtocolErrorType                 = "RouteProtocolError"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
@@ -41,6 +45,15 @@ const (
 	PathIsSlashError         = "Path cannot be a single slash"
 	PathHasQuestionMarkError = "Path cannot contain a question mark"
 	PathLengthExceededError  = "Path cannot exceed 128 characters"
+
+	InternalDomainWildcardHostError = "Wildcard hosts are not supported for internal domains."
+	InternalDomainPathError         = "Paths are not supported for internal domains."
+
+	TCPRouteHostError     = "Hosts are not supported for TCP routes."
+	TCPRoutePathError     = "Paths are not supported for TCP routes."
+	TCPRoutePortError     = "TCP routes must have a port."
+	TCPRouteProtocolError = "Routes on domains with a router group must use the tcp protocol."
+	HTTPRoutePortError    = "Ports are only supported for TCP routes."
 )
 
 var logger = logf.Log.WithName("route-validation")
@@ -49,6 +62,7 @@ var logger = logf.Log.WithName("route-validation")
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 	rootNamespace      string
 	client             client.Client
 }
@@ -57,11 +71,13 @@ var _ webhook.CustomValidator = &Validator{}
 
 func NewValidator(
 	nameValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
 	rootNamespace string,
 	client client.Client,
 ) *Validator {
 	return &Validator{
 		duplicateValidator: nameValidator,
+		quotaValidator:     quotaValidator,
 		rootNamespace:      rootNamespace,
 		client:             client,
 	}
@@ -87,6 +103,10 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 
 	route.Status.FQDN = cfDomain.Spec.Name
 
+	if err = v.quotaValidator.ValidateRouteCreate(ctx, route); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
 }
 
@@ -124,6 +144,11 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, immutableError.ExportJSONError()
 	}
 
+	if !equalPorts(route.Spec.Port, oldRoute.Spec.Port) {
+		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.Port")
+		return nil, immutableError.ExportJSONError()
+	}
+
 	if route.Spec.DomainRef.Name != oldRoute.Spec.DomainRef.Name {
 		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.DomainRef.Name")
 		return nil, immutableError.ExportJSONError()
@@ -157,6 +182,18 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
+	if domain.Spec.RouterGroup != "" {
+		if err = validateTCPRoute(route); err != nil {
+			return nil, err
+		}
+
+		return domain, nil
+	}
+
+	if err = validateHTTPRoute(route); err != nil {
+		return nil, err
+	}
+
 	if err = validateFQDN(route.Spec.Host, domain.Spec.Name); err != nil {
 		return nil, err
 	}
@@ -165,6 +202,12 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return nil, err
 	}
 
+	if domain.Spec.Internal {
+		if err = validateInternalRoute(route); err != nil {
+			return nil, err
+		}
+	}
+
 	return domain, nil
 }
 
@@ -183,6 +226,17 @@ func (v *Validator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRou
 }
 
 func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	for _, destination := range route.Spec.Destinations {
+		appNamespace := destination.AppNamespace(route.Namespace)
+		if appNamespace != route.Namespace && !slices.Contains(route.Spec.SharedSpaces, appNamespace) {
+			logger.Info(RouteDestinationNotSharedErrorMessage, "appNamespace", appNamespace)
+			return validationwebhook.ValidationError{
+				Type:    RouteDestinationNotInSpaceErrorType,
+				Message: RouteDestinationNotSharedErrorMessage,
+			}.ExportJSONError()
+		}
+	}
+
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
 		validationErr := validationwebhook.ValidationError{}
@@ -280,9 +334,71 @@ func validatePath(path string) error {
 	return nil
 }
 
+// validateInternalRoute ensures the route can be served by a cluster DNS
+// name, i.e. it has a concrete host and no path
+func validateInternalRoute(route *korifiv1alpha1.CFRoute) error {
+	if route.Spec.Host == "*" {
+		return validationwebhook.ValidationError{
+			Type:    RouteInternalDomainErrorType,
+			Message: InternalDomainWildcardHostError,
+		}.ExportJSONError()
+	}
+
+	if route.Spec.Path != "" {
+		return validationwebhook.ValidationError{
+			Type:    RouteInternalDomainErrorType,
+			Message: InternalDomainPathError,
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
+// validateTCPRoute ensures the route is only identified by its port, as
+// the TCP listeners of the gateway cannot tell hosts and paths apart
+func validateTCPRoute(route *korifiv1alpha1.CFRoute) error {
+	var message string
+	switch {
+	case route.Spec.Protocol != korifiv1alpha1.ProtocolTCP:
+		message = TCPRouteProtocolError
+	case route.Spec.Host != "":
+		message = TCPRouteHostError
+	case route.Spec.Path != "":
+		message = TCPRoutePathError
+	case route.Spec.Port == nil:
+		message = TCPRoutePortError
+	default:
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolErrorType,
+		Message: message,
+	}.ExportJSONError()
+}
+
+func validateHTTPRoute(route *korifiv1alpha1.CFRoute) error {
+	if route.Spec.Protocol == korifiv1alpha1.ProtocolTCP || route.Spec.Port != nil {
+		return validationwebhook.ValidationError{
+			Type:    RouteProtocolErrorType,
+			Message: HTTPRoutePortError,
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
+func equalPorts(port, otherPort *int32) bool {
+	if port == nil || otherPort == nil {
+		return port == otherPort
+	}
+
+	return *port == *otherPort
+}
+
 func (v *Validator) checkDestinationsExistInNamespace(ctx context.Context, route korifiv1alpha1.CFRoute) error {
 	for _, destination := range route.Spec.Destinations {
-		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.Namespace, Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
+		err := v.client.Get(ctx, client.ObjectKey{Namespace: destination.AppNamespace(route.Namespace), Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
 		if err != nil {
 			return err
 		}
@@ -13,6 +13,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking/routes"
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,6 +30,7 @@ var _ = Describe("CFRouteValidator", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		fakeClient         *controllerfake.Client
 		cfRoute            *korifiv1alpha1.CFRoute
 		cfDomain           *korifiv1alpha1.CFDomain
@@ -86,6 +88,7 @@ var _ = Describe("CFRouteValidator", func() {
 		cfApp = &korifiv1alpha1.CFApp{}
 
 		duplicateValidator = new(fake.NameValidator)
+		quotaValidator = new(fake.QuotaValidator)
 		fakeClient = new(controllerfake.Client)
 
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
@@ -102,7 +105,7 @@ var _ = Describe("CFRouteValidator", func() {
 			}
 		}
 
-		validatingWebhook = routes.NewValidator(duplicateValidator, rootNamespace, fakeClient)
+		validatingWebhook = routes.NewValidator(duplicateValidator, quotaValidator, rootNamespace, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -127,6 +130,23 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(getDomainCallCount).To(Equal(1), "Expected get domain call count mismatch")
 		})
 
+		It("invokes the quota validator", func() {
+			Expect(quotaValidator.ValidateRouteCreateCallCount()).To(Equal(1))
+			_, actualRoute := quotaValidator.ValidateRouteCreateArgsForCall(0)
+			Expect(actualRoute).To(Equal(cfRoute))
+		})
+
+		When("the route quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateRouteCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request without reserving the route name", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the host is '*'", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "*"
@@ -137,6 +157,122 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the domain is internal", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.Internal = true
+				cfRoute.Spec.Path = ""
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the host is '*'", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "*"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteInternalDomainErrorType,
+						Equal(routes.InternalDomainWildcardHostError),
+					))
+				})
+			})
+
+			When("the route has a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteInternalDomainErrorType,
+						Equal(routes.InternalDomainPathError),
+					))
+				})
+			})
+		})
+
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteProtocolErrorType,
+					Equal(routes.HTTPRoutePortError),
+				))
+			})
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+				cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolTCP
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the route protocol is http", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Protocol = korifiv1alpha1.ProtocolHTTP
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolErrorType,
+						Equal(routes.TCPRouteProtocolError),
+					))
+				})
+			})
+
+			When("the route has a host", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "my-host"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolErrorType,
+						Equal(routes.TCPRouteHostError),
+					))
+				})
+			})
+
+			When("the route has a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolErrorType,
+						Equal(routes.TCPRoutePathError),
+					))
+				})
+			})
+
+			When("the route has no port", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Port = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolErrorType,
+						Equal(routes.TCPRoutePortError),
+					))
+				})
+			})
+		})
+
 		When("retrieving the domain record fails", func() {
 			BeforeEach(func() {
 				getDomainError = errors.New("nope")
@@ -258,7 +394,7 @@ var _ = Describe("CFRouteValidator", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
 					{
-						AppRef: v1.LocalObjectReference{
+						AppRef: v1.ObjectReference{
 							Name: "some-name",
 						},
 					},
@@ -269,6 +405,38 @@ var _ = Describe("CFRouteValidator", func() {
 				Expect(retErr).NotTo(HaveOccurred())
 			})
 
+			It("looks the destination app up in the route's namespace", func() {
+				Expect(fakeClient.GetCallCount()).To(Equal(2))
+				_, appKey, _, _ := fakeClient.GetArgsForCall(1)
+				Expect(appKey).To(Equal(types.NamespacedName{Namespace: testRouteNamespace, Name: "some-name"}))
+			})
+
+			When("the destination app is in another namespace", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].AppRef.Namespace = "other-ns"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteDestinationNotInSpaceErrorType,
+						Equal(routes.RouteDestinationNotSharedErrorMessage),
+					))
+				})
+
+				When("the route is shared with the namespace", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.SharedSpaces = []string{"other-ns"}
+					})
+
+					It("looks the destination app up in the shared namespace", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+						Expect(fakeClient.GetCallCount()).To(Equal(2))
+						_, appKey, _, _ := fakeClient.GetArgsForCall(1)
+						Expect(appKey).To(Equal(types.NamespacedName{Namespace: "other-ns", Name: "some-name"}))
+					})
+				})
+			})
+
 			When("the destination contains an app not found in the route's namespace", func() {
 				BeforeEach(func() {
 					getAppError = k8serrors.NewNotFound(schema.GroupResource{}, "foo")
@@ -304,7 +472,7 @@ var _ = Describe("CFRouteValidator", func() {
 			updatedCFRoute = cfRoute.DeepCopy()
 			updatedCFRoute.Spec.Destinations = []korifiv1alpha1.Destination{
 				{
-					AppRef: v1.LocalObjectReference{
+					AppRef: v1.ObjectReference{
 						Name: "some-name",
 					},
 				},
@@ -355,6 +523,19 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the port is updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Port = tools.PtrTo[int32](1025)
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validationwebhook.ImmutableFieldErrorType,
+					Equal("'CFRoute.Spec.Port' field is immutable"),
+				))
+			})
+		})
+
 		When("the new route name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateUpdateReturns(errors.New("foo"))
@@ -8,9 +8,11 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	validation "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -35,13 +37,15 @@ func (v *CFServiceBindingValidator) SetupWebhookWithManager(mgr ctrl.Manager) er
 
 type CFServiceBindingValidator struct {
 	duplicateValidator webhooks.NameValidator
+	client             client.Client
 }
 
 var _ webhook.CustomValidator = &CFServiceBindingValidator{}
 
-func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator) *CFServiceBindingValidator {
+func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator, client client.Client) *CFServiceBindingValidator {
 	return &CFServiceBindingValidator{
 		duplicateValidator: duplicateValidator,
+		client:             client,
 	}
 }
 
@@ -51,9 +55,55 @@ func (v *CFServiceBindingValidator) ValidateCreate(ctx context.Context, obj runt
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", obj))
 	}
 
+	// Route services rely on the router signing the requests it forwards to
+	// them, which the gateway korifi routes traffic through cannot do
+	if serviceBinding.IsRoute() {
+		return nil, validation.ValidationError{
+			Type:    ServiceBindingErrorType,
+			Message: "Route services are not supported",
+		}.ExportJSONError()
+	}
+
+	if serviceBinding.IsToSharedServiceInstance() {
+		if err := v.validateSharedServiceInstance(ctx, serviceBinding); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
 
+func (v *CFServiceBindingValidator) validateSharedServiceInstance(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) error {
+	if serviceBinding.IsKey() {
+		return validation.ValidationError{
+			Type:    ServiceBindingErrorType,
+			Message: "Service keys must be created in the space of the service instance",
+		}.ExportJSONError()
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: serviceBinding.ServiceInstanceNamespace(), Name: serviceBinding.Spec.Service.Name}, serviceInstance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return validation.ValidationError{
+				Type:    ServiceBindingErrorType,
+				Message: fmt.Sprintf("Service instance %q does not exist", serviceBinding.Spec.Service.Name),
+			}.ExportJSONError()
+		}
+
+		return err
+	}
+
+	if !serviceInstance.IsSharedWith(serviceBinding.Namespace) {
+		return validation.ValidationError{
+			Type:    ServiceBindingErrorType,
+			Message: fmt.Sprintf("Service instance %q is not shared with space %q", serviceBinding.Spec.Service.Name, serviceBinding.Namespace),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
 func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
 	serviceBinding, ok := obj.(*korifiv1alpha1.CFServiceBinding)
 	if !ok {
@@ -69,10 +119,22 @@ func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", oldObj))
 	}
 
+	if oldServiceBinding.Spec.Type != serviceBinding.Spec.Type {
+		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "Type is immutable"}
+	}
+
+	if serviceBinding.IsKey() && !equality.Semantic.DeepEqual(oldServiceBinding.Spec.DisplayName, serviceBinding.Spec.DisplayName) {
+		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "DisplayName of service keys is immutable"}
+	}
+
 	if oldServiceBinding.Spec.AppRef.Name != serviceBinding.Spec.AppRef.Name {
 		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "AppRef.Name is immutable"}
 	}
 
+	if oldServiceBinding.Spec.RouteRef.Name != serviceBinding.Spec.RouteRef.Name {
+		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "RouteRef.Name is immutable"}
+	}
+
 	if oldServiceBinding.Spec.Service.Name != serviceBinding.Spec.Service.Name {
 		return nil, validation.ValidationError{Type: ServiceBindingErrorType, Message: "Service.Name is immutable"}
 	}
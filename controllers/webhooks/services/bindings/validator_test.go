@@ -3,18 +3,26 @@ package bindings_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFServiceBindingValidatingWebhook", func() {
@@ -27,6 +35,8 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 		serviceInstanceGUID string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		fakeClient          *controllerfake.Client
+		serviceInstance     *korifiv1alpha1.CFServiceInstance
 		serviceBinding      *korifiv1alpha1.CFServiceBinding
 		validatingWebhook   *bindings.CFServiceBindingValidator
 		retErr              error
@@ -57,8 +67,25 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			},
 		}
 
+		serviceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      serviceInstanceGUID,
+				Namespace: "instance-namespace",
+			},
+		}
+
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = bindings.NewCFServiceBindingValidator(duplicateValidator)
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.CFServiceInstance:
+				serviceInstance.DeepCopyInto(obj)
+				return nil
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+		validatingWebhook = bindings.NewCFServiceBindingValidator(duplicateValidator, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -87,6 +114,114 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		When("the service binding is a key", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+				serviceBinding.Spec.AppRef.Name = ""
+				serviceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+			})
+
+			It("locks the name of the key for the service instance", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("sk::my-key::" + defaultNamespace + "::" + serviceInstanceGUID))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(Equal(
+					"The binding name is invalid. Key binding names must be unique. The service instance already has a key binding with name 'my-key'.",
+				))
+			})
+		})
+
+		When("the service binding is a route binding", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeRoute
+				serviceBinding.Spec.AppRef.Name = ""
+				serviceBinding.Spec.RouteRef.Name = "my-route"
+			})
+
+			It("denies the request as route services are not supported", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					bindings.ServiceBindingErrorType,
+					Equal("Route services are not supported"),
+				))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
+		It("does not look up the service instance", func() {
+			Expect(fakeClient.GetCallCount()).To(BeZero())
+		})
+
+		When("the service instance is in another namespace", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Service.Namespace = "instance-namespace"
+				serviceInstance.Spec.SharedSpaces = []string{defaultNamespace}
+			})
+
+			It("allows the creation of the binding", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			It("gets the service instance from its namespace", func() {
+				Expect(fakeClient.GetCallCount()).To(Equal(1))
+				_, instanceKey, _, _ := fakeClient.GetArgsForCall(0)
+				Expect(instanceKey.Namespace).To(Equal("instance-namespace"))
+				Expect(instanceKey.Name).To(Equal(serviceInstanceGUID))
+			})
+
+			When("the service instance is not shared with the binding namespace", func() {
+				BeforeEach(func() {
+					serviceInstance.Spec.SharedSpaces = []string{"another-namespace"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceBindingErrorType,
+						Equal(fmt.Sprintf("Service instance %q is not shared with space %q", serviceInstanceGUID, defaultNamespace)),
+					))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, serviceInstanceGUID))
+					fakeClient.GetStub = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceBindingErrorType,
+						Equal(fmt.Sprintf("Service instance %q does not exist", serviceInstanceGUID)),
+					))
+				})
+			})
+
+			When("getting the service instance fails", func() {
+				BeforeEach(func() {
+					fakeClient.GetReturns(errors.New("get-err"))
+					fakeClient.GetStub = nil
+				})
+
+				It("returns the error", func() {
+					Expect(retErr).To(MatchError("get-err"))
+				})
+			})
+
+			When("the binding is a key", func() {
+				BeforeEach(func() {
+					serviceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+					serviceBinding.Spec.AppRef.Name = ""
+					serviceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceBindingErrorType,
+						Equal("Service keys must be created in the space of the service instance"),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -126,6 +261,16 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			})
 		})
 
+		When("the RouteRef name changes", func() {
+			BeforeEach(func() {
+				updatedServiceBinding.Spec.RouteRef.Name = "another-route"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("RouteRef.Name is immutable")))
+			})
+		})
+
 		When("the Service Instance name changes", func() {
 			BeforeEach(func() {
 				updatedServiceBinding.Spec.Service.Name = "updated-service-instance"
@@ -136,6 +281,27 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			})
 		})
 
+		When("the type changes", func() {
+			BeforeEach(func() {
+				updatedServiceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("Type is immutable")))
+			})
+		})
+
+		When("the DisplayName of a service key changes", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+				updatedServiceBinding.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("DisplayName of service keys is immutable")))
+			})
+		})
+
 		When("the Service Instance namespace changes", func() {
 			BeforeEach(func() {
 				updatedServiceBinding.Spec.Service.Namespace = "other-ns"
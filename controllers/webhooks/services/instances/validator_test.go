@@ -26,6 +26,7 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		serviceInstance    *korifiv1alpha1.CFServiceInstance
 		validatingWebhook  *instances.Validator
 		retErr             error
@@ -50,7 +51,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = instances.NewValidator(duplicateValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = instances.NewValidator(duplicateValidator, quotaValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -71,6 +73,23 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("The service instance name is taken: " + serviceInstance.Spec.DisplayName))
 		})
 
+		It("invokes the quota validator", func() {
+			Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(1))
+			_, actualInstance := quotaValidator.ValidateServiceInstanceCreateArgsForCall(0)
+			Expect(actualInstance).To(Equal(serviceInstance))
+		})
+
+		When("the service instance quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateServiceInstanceCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the serviceInstance name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
@@ -0,0 +1,538 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/model/quotas"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+
+const (
+	QuotaExceededErrorType = "QuotaExceededError"
+
+	OrgMemoryQuotaExceededMessage           = "memory quota_exceeded"
+	SpaceMemoryQuotaExceededMessage         = "memory space_quota_exceeded"
+	OrgInstanceMemoryLimitExceededMessage   = "memory instance_memory_limit_exceeded"
+	SpaceInstanceMemoryLimitExceededMessage = "memory space_instance_memory_limit_exceeded"
+	OrgInstanceLimitExceededMessage         = "instances app_instance_limit_exceeded"
+	SpaceInstanceLimitExceededMessage       = "instances space_app_instance_limit_exceeded"
+	OrgAppTasksLimitExceededMessage         = "app_tasks quota_exceeded"
+	SpaceAppTasksLimitExceededMessage       = "app_tasks space_quota_exceeded"
+	OrgLogRateQuotaExceededMessage          = "log_rate_limit exceeds organization log rate quota"
+	SpaceLogRateQuotaExceededMessage        = "log_rate_limit exceeds space log rate quota"
+	OrgUnlimitedLogRateMessage              = "log_rate_limit cannot be unlimited in organization '%s'."
+	SpaceUnlimitedLogRateMessage            = "log_rate_limit cannot be unlimited in space '%s'."
+	OrgRoutesQuotaExceededMessage           = "Routes quota exceeded for organization '%s'."
+	SpaceRoutesQuotaExceededMessage         = "Routes quota exceeded for space '%s'."
+	OrgServicesQuotaExceededMessage         = "You have exceeded your organization's services limit."
+	SpaceServicesQuotaExceededMessage       = "You have exceeded your space's services limit."
+	PaidServicesNotAllowedMessage           = "The service instance cannot be created because paid service plans are not allowed."
+)
+
+type quotaScope struct {
+	isSpace     bool
+	displayName string
+	namespaces  []string
+	apps        quotas.AppQuotas
+	services    quotas.ServiceQuotas
+	routes      quotas.RouteQuotas
+}
+
+func (s quotaScope) message(orgMessage, spaceMessage string) string {
+	if s.isSpace {
+		return spaceMessage
+	}
+	return orgMessage
+}
+
+func quotaExceededError(message string) error {
+	return ValidationError{
+		Type:    QuotaExceededErrorType,
+		Message: message,
+	}.ExportJSONError()
+}
+
+type QuotaValidator struct {
+	client              client.Client
+	rootNamespace       string
+	defaultTaskMemoryMB int64
+}
+
+// NewQuotaValidator creates a quota validator. The default task memory is the
+// memory tasks get once they are defaulted, which only happens after they have
+// been created.
+func NewQuotaValidator(client client.Client, rootNamespace string, defaultTaskMemoryMB int64) *QuotaValidator {
+	return &QuotaValidator{client: client, rootNamespace: rootNamespace, defaultTaskMemoryMB: defaultTaskMemoryMB}
+}
+
+// ValidateProcess checks the process memory, instances and log rate against
+// the quotas applied to its space and organization. Usage is only accounted
+// for when the process app is started.
+func (v *QuotaValidator) ValidateProcess(ctx context.Context, process *korifiv1alpha1.CFProcess) error {
+	scopes, err := v.getScopes(ctx, process.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if quotas.Exceeds(scope.apps.PerProcessMemoryInMB, process.Spec.MemoryMB) {
+			return quotaExceededError(scope.message(OrgInstanceMemoryLimitExceededMessage, SpaceInstanceMemoryLimitExceededMessage))
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	app := &korifiv1alpha1.CFApp{}
+	err = v.client.Get(ctx, types.NamespacedName{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, app)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	return v.validateAppUsage(ctx, scopes, appUsageOverrides{process: process})
+}
+
+// ValidateAppStart checks that starting the app would not exceed the memory,
+// instances and log rate quotas applied to its space and organization
+func (v *QuotaValidator) ValidateAppStart(ctx context.Context, app *korifiv1alpha1.CFApp) error {
+	scopes, err := v.getScopes(ctx, app.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return v.validateAppUsage(ctx, scopes, appUsageOverrides{startingApp: app})
+}
+
+// ValidateTaskCreate checks the task memory and log rate and the number of
+// running tasks of the task app against the quotas applied to its space and
+// organization
+func (v *QuotaValidator) ValidateTaskCreate(ctx context.Context, task *korifiv1alpha1.CFTask) error {
+	scopes, err := v.getScopes(ctx, task.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	for _, scope := range scopes {
+		if quotas.Exceeds(scope.apps.PerProcessMemoryInMB, v.taskMemoryMB(*task)) {
+			return quotaExceededError(scope.message(OrgInstanceMemoryLimitExceededMessage, SpaceInstanceMemoryLimitExceededMessage))
+		}
+	}
+
+	tasks := &korifiv1alpha1.CFTaskList{}
+	if err = v.client.List(ctx, tasks, client.InNamespace(task.Namespace)); err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	runningAppTasks := int64(1)
+	for _, t := range tasks.Items {
+		if t.Spec.AppRef.Name == task.Spec.AppRef.Name && t.Name != task.Name && isTaskRunning(t) {
+			runningAppTasks++
+		}
+	}
+
+	for _, scope := range scopes {
+		if quotas.Exceeds(scope.apps.PerAppTasks, runningAppTasks) {
+			return quotaExceededError(scope.message(OrgAppTasksLimitExceededMessage, SpaceAppTasksLimitExceededMessage))
+		}
+	}
+
+	for _, scope := range scopes {
+		if scope.apps.TotalMemoryInMB == nil && scope.apps.LogRateLimitInBytesPerSecond == nil {
+			continue
+		}
+
+		usage, err := v.getAppUsage(ctx, scope.namespaces, appUsageOverrides{task: task})
+		if err != nil {
+			return err
+		}
+
+		if quotas.Exceeds(scope.apps.TotalMemoryInMB, usage.memoryMB) {
+			return quotaExceededError(scope.message(OrgMemoryQuotaExceededMessage, SpaceMemoryQuotaExceededMessage))
+		}
+
+		if err = validateLogRateUsage(scope, usage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateRouteCreate checks the number of routes against the quotas applied
+// to the route space and organization
+func (v *QuotaValidator) ValidateRouteCreate(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	scopes, err := v.getScopes(ctx, route.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if scope.routes.TotalRoutes == nil {
+			continue
+		}
+
+		routeCount, err := countInNamespaces(ctx, v.client, scope.namespaces, &korifiv1alpha1.CFRouteList{})
+		if err != nil {
+			return err
+		}
+
+		if quotas.Exceeds(scope.routes.TotalRoutes, routeCount+1) {
+			return quotaExceededError(fmt.Sprintf(
+				scope.message(OrgRoutesQuotaExceededMessage, SpaceRoutesQuotaExceededMessage),
+				scope.displayName,
+			))
+		}
+	}
+
+	return nil
+}
+
+// ValidateServiceInstanceCreate checks the number of service instances
+// against the quotas applied to the instance space and organization. Managed
+// instances of paid plans are rejected unless the quotas allow paid services.
+func (v *QuotaValidator) ValidateServiceInstanceCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	scopes, err := v.getScopes(ctx, serviceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType && !scope.services.PaidServicesAllowed {
+			paid, err := v.isPaidPlan(ctx, serviceInstance.Spec.PlanGUID)
+			if err != nil {
+				return err
+			}
+
+			if paid {
+				return quotaExceededError(PaidServicesNotAllowedMessage)
+			}
+		}
+
+		if scope.services.TotalServiceInstances == nil {
+			continue
+		}
+
+		instanceCount, err := countInNamespaces(ctx, v.client, scope.namespaces, &korifiv1alpha1.CFServiceInstanceList{})
+		if err != nil {
+			return err
+		}
+
+		if quotas.Exceeds(scope.services.TotalServiceInstances, instanceCount+1) {
+			return quotaExceededError(scope.message(OrgServicesQuotaExceededMessage, SpaceServicesQuotaExceededMessage))
+		}
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) isPaidPlan(ctx context.Context, planGUID string) (bool, error) {
+	plan := &korifiv1alpha1.CFServicePlan{}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: planGUID}, plan)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return !plan.Spec.Free, nil
+}
+
+type appUsageOverrides struct {
+	process     *korifiv1alpha1.CFProcess
+	startingApp *korifiv1alpha1.CFApp
+	task        *korifiv1alpha1.CFTask
+}
+
+// includesProcess returns whether the process is the one being validated or
+// one of the processes of the app being started
+func (o appUsageOverrides) includesProcess(process korifiv1alpha1.CFProcess) bool {
+	if o.process != nil && o.process.Namespace == process.Namespace && o.process.Name == process.Name {
+		return true
+	}
+
+	return o.startingApp != nil && o.startingApp.Namespace == process.Namespace && o.startingApp.Name == process.Spec.AppRef.Name
+}
+
+func (o appUsageOverrides) includesTask(task korifiv1alpha1.CFTask) bool {
+	return o.task != nil && o.task.Namespace == task.Namespace && o.task.Name == task.Name
+}
+
+type appUsage struct {
+	memoryMB              int64
+	instances             int64
+	logRateBytesPerSecond int64
+	// unlimitedLogRate is set when a validated process or task has no log
+	// rate limit. Unlimited processes and tasks that are not being validated
+	// are not accounted for, as they predate the quota.
+	unlimitedLogRate bool
+}
+
+func (v *QuotaValidator) validateAppUsage(ctx context.Context, scopes []quotaScope, overrides appUsageOverrides) error {
+	for _, scope := range scopes {
+		if scope.apps.TotalMemoryInMB == nil && scope.apps.TotalInstances == nil && scope.apps.LogRateLimitInBytesPerSecond == nil {
+			continue
+		}
+
+		usage, err := v.getAppUsage(ctx, scope.namespaces, overrides)
+		if err != nil {
+			return err
+		}
+
+		if quotas.Exceeds(scope.apps.TotalMemoryInMB, usage.memoryMB) {
+			return quotaExceededError(scope.message(OrgMemoryQuotaExceededMessage, SpaceMemoryQuotaExceededMessage))
+		}
+
+		if quotas.Exceeds(scope.apps.TotalInstances, usage.instances) {
+			return quotaExceededError(scope.message(OrgInstanceLimitExceededMessage, SpaceInstanceLimitExceededMessage))
+		}
+
+		if err = validateLogRateUsage(scope, usage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateLogRateUsage(scope quotaScope, usage appUsage) error {
+	if scope.apps.LogRateLimitInBytesPerSecond == nil {
+		return nil
+	}
+
+	if usage.unlimitedLogRate {
+		return quotaExceededError(fmt.Sprintf(
+			scope.message(OrgUnlimitedLogRateMessage, SpaceUnlimitedLogRateMessage),
+			scope.displayName,
+		))
+	}
+
+	if quotas.Exceeds(scope.apps.LogRateLimitInBytesPerSecond, usage.logRateBytesPerSecond) {
+		return quotaExceededError(scope.message(OrgLogRateQuotaExceededMessage, SpaceLogRateQuotaExceededMessage))
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) getAppUsage(ctx context.Context, namespaces []string, overrides appUsageOverrides) (appUsage, error) {
+	usage := appUsage{}
+
+	for _, ns := range namespaces {
+		apps := &korifiv1alpha1.CFAppList{}
+		if err := v.client.List(ctx, apps, client.InNamespace(ns)); err != nil {
+			return appUsage{}, fmt.Errorf("failed to list apps: %w", err)
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			startedApps[app.Name] = app.Spec.DesiredState == korifiv1alpha1.StartedState
+		}
+		if overrides.startingApp != nil && overrides.startingApp.Namespace == ns {
+			startedApps[overrides.startingApp.Name] = true
+		}
+
+		processes := &korifiv1alpha1.CFProcessList{}
+		if err := v.client.List(ctx, processes, client.InNamespace(ns)); err != nil {
+			return appUsage{}, fmt.Errorf("failed to list processes: %w", err)
+		}
+
+		processItems := processes.Items
+		if overrides.process != nil && overrides.process.Namespace == ns {
+			processItems = slices.DeleteFunc(processItems, func(p korifiv1alpha1.CFProcess) bool {
+				return p.Name == overrides.process.Name
+			})
+			processItems = append(processItems, *overrides.process)
+		}
+
+		for _, process := range processItems {
+			if !startedApps[process.Spec.AppRef.Name] || process.Spec.DesiredInstances == nil {
+				continue
+			}
+
+			instances := int64(*process.Spec.DesiredInstances)
+			usage.instances += instances
+			usage.memoryMB += instances * process.Spec.MemoryMB
+
+			if process.Spec.LogRateLimitBytesPerSecond != nil {
+				usage.logRateBytesPerSecond += instances * *process.Spec.LogRateLimitBytesPerSecond
+			} else if instances > 0 && overrides.includesProcess(process) {
+				usage.unlimitedLogRate = true
+			}
+		}
+
+		tasks := &korifiv1alpha1.CFTaskList{}
+		if err := v.client.List(ctx, tasks, client.InNamespace(ns)); err != nil {
+			return appUsage{}, fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		taskItems := tasks.Items
+		if overrides.task != nil && overrides.task.Namespace == ns {
+			taskItems = slices.DeleteFunc(taskItems, func(t korifiv1alpha1.CFTask) bool {
+				return t.Name == overrides.task.Name
+			})
+			taskItems = append(taskItems, *overrides.task)
+		}
+
+		for _, task := range taskItems {
+			if !isTaskRunning(task) {
+				continue
+			}
+
+			usage.memoryMB += v.taskMemoryMB(task)
+
+			if task.Spec.LogRateLimitBytesPerSecond != nil {
+				usage.logRateBytesPerSecond += *task.Spec.LogRateLimitBytesPerSecond
+			} else if overrides.includesTask(task) {
+				usage.unlimitedLogRate = true
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+func (v *QuotaValidator) getScopes(ctx context.Context, spaceGUID string) ([]quotaScope, error) {
+	namespace := &corev1.Namespace{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: spaceGUID}, namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %w", spaceGUID, err)
+	}
+
+	orgGUID, ok := namespace.Labels[korifiv1alpha1.OrgGUIDKey]
+	if !ok {
+		return nil, nil
+	}
+
+	scopes := []quotaScope{}
+
+	spaceScope, err := v.getSpaceScope(ctx, orgGUID, spaceGUID)
+	if err != nil {
+		return nil, err
+	}
+	if spaceScope != nil {
+		scopes = append(scopes, *spaceScope)
+	}
+
+	orgScope, err := v.getOrgScope(ctx, orgGUID)
+	if err != nil {
+		return nil, err
+	}
+	if orgScope != nil {
+		scopes = append(scopes, *orgScope)
+	}
+
+	return scopes, nil
+}
+
+func (v *QuotaValidator) getSpaceScope(ctx context.Context, orgGUID, spaceGUID string) (*quotaScope, error) {
+	spaceQuotas := &korifiv1alpha1.CFSpaceQuotaList{}
+	if err := v.client.List(ctx, spaceQuotas, client.InNamespace(v.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list space quotas: %w", err)
+	}
+
+	quotaIdx := slices.IndexFunc(spaceQuotas.Items, func(q korifiv1alpha1.CFSpaceQuota) bool {
+		return slices.Contains(q.Spec.Spaces, spaceGUID)
+	})
+	if quotaIdx < 0 {
+		return nil, nil
+	}
+	spaceQuota := spaceQuotas.Items[quotaIdx]
+
+	space := &korifiv1alpha1.CFSpace{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: orgGUID, Name: spaceGUID}, space); err != nil {
+		return nil, fmt.Errorf("failed to get space %q: %w", spaceGUID, err)
+	}
+
+	return &quotaScope{
+		isSpace:     true,
+		displayName: space.Spec.DisplayName,
+		namespaces:  []string{spaceGUID},
+		apps:        spaceQuota.Spec.Apps,
+		services:    spaceQuota.Spec.Services,
+		routes:      spaceQuota.Spec.Routes,
+	}, nil
+}
+
+func (v *QuotaValidator) getOrgScope(ctx context.Context, orgGUID string) (*quotaScope, error) {
+	orgQuotas := &korifiv1alpha1.CFOrgQuotaList{}
+	if err := v.client.List(ctx, orgQuotas, client.InNamespace(v.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list org quotas: %w", err)
+	}
+
+	quotaIdx := slices.IndexFunc(orgQuotas.Items, func(q korifiv1alpha1.CFOrgQuota) bool {
+		return slices.Contains(q.Spec.Organizations, orgGUID)
+	})
+	if quotaIdx < 0 {
+		return nil, nil
+	}
+	orgQuota := orgQuotas.Items[quotaIdx]
+
+	org := &korifiv1alpha1.CFOrg{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: orgGUID}, org); err != nil {
+		return nil, fmt.Errorf("failed to get org %q: %w", orgGUID, err)
+	}
+
+	spaces := &korifiv1alpha1.CFSpaceList{}
+	if err := v.client.List(ctx, spaces, client.InNamespace(orgGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list spaces of org %q: %w", orgGUID, err)
+	}
+
+	namespaces := []string{}
+	for _, space := range spaces.Items {
+		namespaces = append(namespaces, space.Name)
+	}
+
+	return &quotaScope{
+		displayName: org.Spec.DisplayName,
+		namespaces:  namespaces,
+		apps:        orgQuota.Spec.Apps,
+		services:    orgQuota.Spec.Services,
+		routes:      orgQuota.Spec.Routes,
+	}, nil
+}
+
+func countInNamespaces(ctx context.Context, k8sClient client.Client, namespaces []string, list client.ObjectList) (int64, error) {
+	count := int64(0)
+	for _, ns := range namespaces {
+		if err := k8sClient.List(ctx, list, client.InNamespace(ns)); err != nil {
+			return 0, fmt.Errorf("failed to list objects in namespace %q: %w", ns, err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(items))
+	}
+
+	return count, nil
+}
+
+// taskMemoryMB returns the memory of the task, which is only set on its status
+// once it has been defaulted
+func (v *QuotaValidator) taskMemoryMB(task korifiv1alpha1.CFTask) int64 {
+	if task.Status.MemoryMB != 0 {
+		return task.Status.MemoryMB
+	}
+
+	return v.defaultTaskMemoryMB
+}
+
+func isTaskRunning(task korifiv1alpha1.CFTask) bool {
+	return !meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskSucceededConditionType) &&
+		!meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType)
+}
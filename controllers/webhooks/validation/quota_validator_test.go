@@ -0,0 +1,583 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/model/quotas"
+	"code.cloudfoundry.org/korifi/model/services"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("QuotaValidator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+	)
+
+	var (
+		ctx            context.Context
+		fakeClient     *fake.Client
+		quotaValidator *validation.QuotaValidator
+		validationErr  error
+
+		namespaceLabels map[string]string
+		orgQuotas       []korifiv1alpha1.CFOrgQuota
+		spaceQuotas     []korifiv1alpha1.CFSpaceQuota
+		apps            []korifiv1alpha1.CFApp
+		processes       []korifiv1alpha1.CFProcess
+		tasks           []korifiv1alpha1.CFTask
+		routes          []korifiv1alpha1.CFRoute
+		instances       []korifiv1alpha1.CFServiceInstance
+		plan            *korifiv1alpha1.CFServicePlan
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeClient = new(fake.Client)
+
+		namespaceLabels = map[string]string{korifiv1alpha1.OrgGUIDKey: orgGUID}
+		orgQuotas = nil
+		spaceQuotas = nil
+		apps = []korifiv1alpha1.CFApp{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "app-guid"},
+			Spec:       korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StartedState},
+		}}
+		processes = []korifiv1alpha1.CFProcess{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "process-guid"},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: "app-guid"},
+				MemoryMB:         256,
+				DesiredInstances: tools.PtrTo[int32](2),
+			},
+		}}
+		tasks = nil
+		routes = nil
+		instances = nil
+		plan = nil
+
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *corev1.Namespace:
+				obj.Name = key.Name
+				obj.Labels = namespaceLabels
+			case *korifiv1alpha1.CFOrg:
+				obj.Name = key.Name
+				obj.Spec.DisplayName = "my-org"
+			case *korifiv1alpha1.CFSpace:
+				obj.Name = key.Name
+				obj.Spec.DisplayName = "my-space"
+			case *korifiv1alpha1.CFApp:
+				for _, app := range apps {
+					if app.Namespace == key.Namespace && app.Name == key.Name {
+						app.DeepCopyInto(obj)
+						return nil
+					}
+				}
+				return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+			case *korifiv1alpha1.CFServicePlan:
+				if plan == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				plan.DeepCopyInto(obj)
+			default:
+				panic(fmt.Sprintf("unexpected Get of %T", obj))
+			}
+			return nil
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFOrgQuotaList:
+				list.Items = orgQuotas
+			case *korifiv1alpha1.CFSpaceQuotaList:
+				list.Items = spaceQuotas
+			case *korifiv1alpha1.CFSpaceList:
+				list.Items = []korifiv1alpha1.CFSpace{{ObjectMeta: metav1.ObjectMeta{Namespace: orgGUID, Name: spaceGUID}}}
+			case *korifiv1alpha1.CFAppList:
+				list.Items = apps
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = processes
+			case *korifiv1alpha1.CFTaskList:
+				list.Items = tasks
+			case *korifiv1alpha1.CFRouteList:
+				list.Items = routes
+			case *korifiv1alpha1.CFServiceInstanceList:
+				list.Items = instances
+			default:
+				panic(fmt.Sprintf("unexpected List of %T", list))
+			}
+
+			return nil
+		}
+
+		quotaValidator = validation.NewQuotaValidator(fakeClient, rootNamespace, 128)
+	})
+
+	Describe("ValidateProcess", func() {
+		var process *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			process = processes[0].DeepCopy()
+			process.Spec.DesiredInstances = tools.PtrTo[int32](4)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateProcess(ctx, process)
+		})
+
+		It("succeeds when no quotas apply", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space has a per process memory limit", func() {
+			BeforeEach(func() {
+				spaceQuotas = []korifiv1alpha1.CFSpaceQuota{{
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						SpaceQuota: quotas.SpaceQuota{Apps: quotas.AppQuotas{PerProcessMemoryInMB: tools.PtrTo[int64](128)}},
+						Spaces:     []string{spaceGUID},
+					},
+				}}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.SpaceInstanceMemoryLimitExceededMessage),
+				))
+			})
+		})
+
+		When("the org total memory would be exceeded", func() {
+			BeforeEach(func() {
+				orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+					Spec: korifiv1alpha1.CFOrgQuotaSpec{
+						OrgQuota:      quotas.OrgQuota{Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](1000)}},
+						Organizations: []string{orgGUID},
+					},
+				}}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgMemoryQuotaExceededMessage),
+				))
+			})
+
+			When("the app is stopped", func() {
+				BeforeEach(func() {
+					apps[0].Spec.DesiredState = korifiv1alpha1.StoppedState
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the org total instances would be exceeded", func() {
+			BeforeEach(func() {
+				orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+					Spec: korifiv1alpha1.CFOrgQuotaSpec{
+						OrgQuota:      quotas.OrgQuota{Apps: quotas.AppQuotas{TotalInstances: tools.PtrTo[int64](3)}},
+						Organizations: []string{orgGUID},
+					},
+				}}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgInstanceLimitExceededMessage),
+				))
+			})
+		})
+
+		When("the space limits the log rate", func() {
+			BeforeEach(func() {
+				process.Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](100)
+				spaceQuotas = []korifiv1alpha1.CFSpaceQuota{{
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						SpaceQuota: quotas.SpaceQuota{Apps: quotas.AppQuotas{LogRateLimitInBytesPerSecond: tools.PtrTo[int64](400)}},
+						Spaces:     []string{spaceGUID},
+					},
+				}}
+			})
+
+			It("succeeds when the process instances fit in the quota", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+
+			When("the process instances exceed the quota", func() {
+				BeforeEach(func() {
+					process.Spec.DesiredInstances = tools.PtrTo[int32](5)
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal(validation.SpaceLogRateQuotaExceededMessage),
+					))
+				})
+			})
+
+			When("the process log rate is unlimited", func() {
+				BeforeEach(func() {
+					process.Spec.LogRateLimitBytesPerSecond = nil
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal("log_rate_limit cannot be unlimited in space 'my-space'."),
+					))
+				})
+			})
+
+			When("another process has an unlimited log rate", func() {
+				BeforeEach(func() {
+					processes = append(processes, korifiv1alpha1.CFProcess{
+						ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "other-process-guid"},
+						Spec: korifiv1alpha1.CFProcessSpec{
+							AppRef:           corev1.LocalObjectReference{Name: "app-guid"},
+							DesiredInstances: tools.PtrTo[int32](1),
+						},
+					})
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the namespace is not a space", func() {
+			BeforeEach(func() {
+				namespaceLabels = nil
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("getting the namespace fails", func() {
+			BeforeEach(func() {
+				fakeClient.GetReturns(errors.New("get-namespace-err"))
+				fakeClient.GetStub = nil
+			})
+
+			It("returns the error", func() {
+				Expect(validationErr).To(MatchError(ContainSubstring("get-namespace-err")))
+			})
+		})
+	})
+
+	Describe("ValidateAppStart", func() {
+		var app *korifiv1alpha1.CFApp
+
+		BeforeEach(func() {
+			apps[0].Spec.DesiredState = korifiv1alpha1.StoppedState
+			app = apps[0].DeepCopy()
+			app.Spec.DesiredState = korifiv1alpha1.StartedState
+
+			spaceQuotas = []korifiv1alpha1.CFSpaceQuota{{
+				Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+					SpaceQuota: quotas.SpaceQuota{Apps: quotas.AppQuotas{TotalMemoryInMB: tools.PtrTo[int64](512)}},
+					Spaces:     []string{spaceGUID},
+				},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateAppStart(ctx, app)
+		})
+
+		It("succeeds when the started app fits in the quota", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("running tasks use part of the quota", func() {
+			BeforeEach(func() {
+				tasks = []korifiv1alpha1.CFTask{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "task-guid"},
+					Status:     korifiv1alpha1.CFTaskStatus{MemoryMB: 1},
+				}}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.SpaceMemoryQuotaExceededMessage),
+				))
+			})
+		})
+
+		When("the org limits the log rate and the app processes are unlimited", func() {
+			BeforeEach(func() {
+				orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+					Spec: korifiv1alpha1.CFOrgQuotaSpec{
+						OrgQuota:      quotas.OrgQuota{Apps: quotas.AppQuotas{LogRateLimitInBytesPerSecond: tools.PtrTo[int64](1024)}},
+						Organizations: []string{orgGUID},
+					},
+				}}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("log_rate_limit cannot be unlimited in organization 'my-org'."),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateTaskCreate", func() {
+		var task *korifiv1alpha1.CFTask
+
+		BeforeEach(func() {
+			task = &korifiv1alpha1.CFTask{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-task"},
+				Spec:       korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "app-guid"}},
+			}
+			tasks = []korifiv1alpha1.CFTask{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "running-task"},
+				Spec:       korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "app-guid"}},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "succeeded-task"},
+				Spec:       korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "app-guid"}},
+				Status: korifiv1alpha1.CFTaskStatus{Conditions: []metav1.Condition{{
+					Type:   korifiv1alpha1.TaskSucceededConditionType,
+					Status: metav1.ConditionTrue,
+				}}},
+			}}
+			orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+				Spec: korifiv1alpha1.CFOrgQuotaSpec{
+					OrgQuota:      quotas.OrgQuota{Apps: quotas.AppQuotas{PerAppTasks: tools.PtrTo[int64](2)}},
+					Organizations: []string{orgGUID},
+				},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateTaskCreate(ctx, task)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the app already runs the maximum number of tasks", func() {
+			BeforeEach(func() {
+				orgQuotas[0].Spec.Apps.PerAppTasks = tools.PtrTo[int64](1)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgAppTasksLimitExceededMessage),
+				))
+			})
+		})
+
+		When("the task memory exceeds the per process memory limit", func() {
+			BeforeEach(func() {
+				orgQuotas[0].Spec.Apps.PerProcessMemoryInMB = tools.PtrTo[int64](127)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgInstanceMemoryLimitExceededMessage),
+				))
+			})
+		})
+
+		When("the org total memory fits the new task", func() {
+			BeforeEach(func() {
+				// 2 web instances of 256MB, a running task and the new one of 128MB each
+				orgQuotas[0].Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](768)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the new task would exceed the org total memory", func() {
+			BeforeEach(func() {
+				orgQuotas[0].Spec.Apps.TotalMemoryInMB = tools.PtrTo[int64](767)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgMemoryQuotaExceededMessage),
+				))
+			})
+		})
+
+		When("the org limits the log rate", func() {
+			BeforeEach(func() {
+				task.Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](100)
+				processes[0].Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](50)
+				// 2 web instances of 50 bytes per second and the new task of 100
+				orgQuotas[0].Spec.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](200)
+			})
+
+			It("succeeds when the new task fits in the quota", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+
+			When("the new task would exceed the quota", func() {
+				BeforeEach(func() {
+					orgQuotas[0].Spec.Apps.LogRateLimitInBytesPerSecond = tools.PtrTo[int64](199)
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal(validation.OrgLogRateQuotaExceededMessage),
+					))
+				})
+			})
+
+			When("the new task log rate is unlimited", func() {
+				BeforeEach(func() {
+					task.Spec.LogRateLimitBytesPerSecond = nil
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal("log_rate_limit cannot be unlimited in organization 'my-org'."),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("ValidateRouteCreate", func() {
+		var route *korifiv1alpha1.CFRoute
+
+		BeforeEach(func() {
+			route = &korifiv1alpha1.CFRoute{ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-route"}}
+			routes = []korifiv1alpha1.CFRoute{{ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "route-guid"}}}
+			spaceQuotas = []korifiv1alpha1.CFSpaceQuota{{
+				Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+					SpaceQuota: quotas.SpaceQuota{Routes: quotas.RouteQuotas{TotalRoutes: tools.PtrTo[int64](2)}},
+					Spaces:     []string{spaceGUID},
+				},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateRouteCreate(ctx, route)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space route quota is reached", func() {
+			BeforeEach(func() {
+				spaceQuotas[0].Spec.Routes.TotalRoutes = tools.PtrTo[int64](1)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("Routes quota exceeded for space 'my-space'."),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateServiceInstanceCreate", func() {
+		var serviceInstance *korifiv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-instance"},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:     korifiv1alpha1.ManagedType,
+					PlanGUID: "plan-guid",
+				},
+			}
+			plan = &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "plan-guid"},
+				Spec:       korifiv1alpha1.CFServicePlanSpec{ServicePlan: services.ServicePlan{Free: true}},
+			}
+			orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+				Spec: korifiv1alpha1.CFOrgQuotaSpec{
+					OrgQuota: quotas.OrgQuota{Services: quotas.ServiceQuotas{
+						TotalServiceInstances: tools.PtrTo[int64](1),
+					}},
+					Organizations: []string{orgGUID},
+				},
+			}}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateServiceInstanceCreate(ctx, serviceInstance)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the plan is paid and paid services are not allowed", func() {
+			BeforeEach(func() {
+				plan.Spec.Free = false
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.PaidServicesNotAllowedMessage),
+				))
+			})
+
+			When("paid services are allowed", func() {
+				BeforeEach(func() {
+					orgQuotas[0].Spec.Services.PaidServicesAllowed = true
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the org service instances quota is reached", func() {
+			BeforeEach(func() {
+				instances = []korifiv1alpha1.CFServiceInstance{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "instance-guid"},
+				}}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal(validation.OrgServicesQuotaExceededMessage),
+				))
+			})
+		})
+	})
+})
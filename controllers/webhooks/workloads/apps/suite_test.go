@@ -83,7 +83,8 @@ var _ = BeforeSuite(func() {
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	appNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType))
-	Expect(apps.NewValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	quotaValidator := validation.NewQuotaValidator(uncachedClient, "cf", 1024)
+	Expect(apps.NewValidator(appNameDuplicateValidator, quotaValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
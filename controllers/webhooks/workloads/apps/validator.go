@@ -27,13 +27,15 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 
 type Validator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *Validator {
 	return &Validator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -50,6 +52,12 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFApp but got a %T", obj))
 	}
 
+	if app.Spec.DesiredState == korifiv1alpha1.StartedState {
+		if err := v.quotaValidator.ValidateAppStart(ctx, app); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app)
 }
 
@@ -75,6 +83,12 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		}.ExportJSONError()
 	}
 
+	if oldApp.Spec.DesiredState != korifiv1alpha1.StartedState && app.Spec.DesiredState == korifiv1alpha1.StartedState {
+		if err := v.quotaValidator.ValidateAppStart(ctx, app); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app)
 }
 
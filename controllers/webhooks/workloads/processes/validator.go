@@ -0,0 +1,94 @@
+package processes
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var cfprocesslog = logf.Log.WithName("cfprocess-validator")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfprocess,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=create;update,versions=v1alpha1,name=vcfprocess.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	quotaValidator webhooks.QuotaValidator
+}
+
+var _ webhook.CustomValidator = &Validator{}
+
+func NewValidator(quotaValidator webhooks.QuotaValidator) *Validator {
+	return &Validator{
+		quotaValidator: quotaValidator,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFProcess{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	process, ok := obj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", obj))
+	}
+
+	cfprocesslog.V(1).Info("validate process creation", "namespace", process.Namespace, "name", process.Name)
+
+	return nil, v.quotaValidator.ValidateProcess(ctx, process)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	process, ok := obj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", obj))
+	}
+
+	if !process.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldProcess, ok := oldObj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", oldObj))
+	}
+
+	if !scaledUp(oldProcess, process) {
+		return nil, nil
+	}
+
+	cfprocesslog.V(1).Info("validate process scale", "namespace", process.Namespace, "name", process.Name)
+
+	return nil, v.quotaValidator.ValidateProcess(ctx, process)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func scaledUp(oldProcess, process *korifiv1alpha1.CFProcess) bool {
+	if process.Spec.MemoryMB > oldProcess.Spec.MemoryMB {
+		return true
+	}
+
+	return desiredInstances(process) > desiredInstances(oldProcess)
+}
+
+func desiredInstances(process *korifiv1alpha1.CFProcess) int32 {
+	if process.Spec.DesiredInstances == nil {
+		return 0
+	}
+
+	return *process.Spec.DesiredInstances
+}
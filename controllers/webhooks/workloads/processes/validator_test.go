@@ -0,0 +1,145 @@
+package processes_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx               context.Context
+		quotaValidator    *fake.QuotaValidator
+		process           *korifiv1alpha1.CFProcess
+		validatingWebhook *processes.Validator
+		retErr            error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		process = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				ProcessType:      "web",
+				MemoryMB:         256,
+				DesiredInstances: tools.PtrTo[int32](1),
+			},
+		}
+
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = processes.NewValidator(quotaValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, process)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the quota validator", func() {
+			Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+			_, actualProcess := quotaValidator.ValidateProcessArgsForCall(0)
+			Expect(actualProcess).To(Equal(process))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateProcessReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedProcess *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			updatedProcess = process.DeepCopy()
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, process, updatedProcess)
+		})
+
+		It("does not check quotas when the process is not scaled up", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(quotaValidator.ValidateProcessCallCount()).To(BeZero())
+		})
+
+		When("the process memory is increased", func() {
+			BeforeEach(func() {
+				updatedProcess.Spec.MemoryMB = 512
+			})
+
+			It("invokes the quota validator", func() {
+				Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+				_, actualProcess := quotaValidator.ValidateProcessArgsForCall(0)
+				Expect(actualProcess).To(Equal(updatedProcess))
+			})
+
+			When("the quota is exceeded", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateProcessReturns(errors.New("quota-exceeded"))
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(MatchError("quota-exceeded"))
+				})
+			})
+		})
+
+		When("the process instances are increased", func() {
+			BeforeEach(func() {
+				updatedProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+			})
+
+			It("invokes the quota validator", func() {
+				Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the process is scaled down", func() {
+			BeforeEach(func() {
+				updatedProcess.Spec.MemoryMB = 128
+				updatedProcess.Spec.DesiredInstances = tools.PtrTo[int32](0)
+			})
+
+			It("does not check quotas", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(quotaValidator.ValidateProcessCallCount()).To(BeZero())
+			})
+		})
+
+		When("the process is being deleted", func() {
+			BeforeEach(func() {
+				updatedProcess.Spec.MemoryMB = 1024
+				updatedProcess.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not check quotas", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(quotaValidator.ValidateProcessCallCount()).To(BeZero())
+			})
+		})
+	})
+})
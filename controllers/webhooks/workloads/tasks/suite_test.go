@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -82,7 +83,8 @@ var _ = BeforeSuite(func() {
 		MemoryMB:    500,
 		DiskQuotaMB: 512,
 	}).SetupWebhookWithManager(k8sManager)).To(Succeed())
-	Expect(tasks.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
+	Expect(tasks.NewValidator(validation.NewQuotaValidator(uncachedClient, "cf", 1024)).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
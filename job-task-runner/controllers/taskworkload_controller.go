@@ -163,6 +163,12 @@ func (r *TaskWorkloadReconciler) workloadToJob(taskWorkload *korifiv1alpha1.Task
 			Completions:             tools.PtrTo(int32(1)),
 			TTLSecondsAfterFinished: tools.PtrTo(int32(r.jobTTL.Seconds())),
 			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:  taskWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+						korifiv1alpha1.CFTaskGUIDLabelKey: taskWorkload.Name,
+					},
+				},
 				Spec: corev1.PodSpec{
 					RestartPolicy: corev1.RestartPolicyNever,
 					SecurityContext: &corev1.PodSecurityContext{
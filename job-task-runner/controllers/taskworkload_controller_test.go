@@ -34,6 +34,7 @@ var _ = Describe("TaskworkloadController", func() {
 		taskWorkload         *korifiv1alpha1.TaskWorkload
 		getTaskWorkloadError error
 		createdJob           *batchv1.Job
+		requestedJob         *batchv1.Job
 		existingJob          *batchv1.Job
 		getExistingJobError  error
 		createJobError       error
@@ -47,6 +48,9 @@ var _ = Describe("TaskworkloadController", func() {
 				Name:       "my-task-workload",
 				Namespace:  "my-namespace",
 				Generation: 1,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: "my-app",
+				},
 			},
 			Spec: korifiv1alpha1.TaskWorkloadSpec{
 				Image:   "my-image",
@@ -83,6 +87,7 @@ var _ = Describe("TaskworkloadController", func() {
 		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
 			switch obj := obj.(type) {
 			case *batchv1.Job:
+				requestedJob = obj.DeepCopy()
 				createdJob.DeepCopyInto(obj)
 				return createJobError
 			default:
@@ -154,6 +159,10 @@ var _ = Describe("TaskworkloadController", func() {
 			Expect(ok).To(BeTrue())
 			Expect(job.Namespace).To(Equal(taskWorkload.Namespace))
 			Expect(job.Name).To(Equal(taskWorkload.Name))
+			Expect(requestedJob.Spec.Template.Labels).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:  "my-app",
+				korifiv1alpha1.CFTaskGUIDLabelKey: "my-task-workload",
+			}))
 		})
 
 		When("the taskworkload has the initialized true condition", func() {
@@ -56,7 +56,7 @@ import (
 const (
 	clusterBuilderKind          = "ClusterBuilder"
 	clusterBuilderAPIVersion    = "kpack.io/v1alpha2"
-	BuildWorkloadLabelKey       = "korifi.cloudfoundry.org/build-workload-name"
+	BuildWorkloadLabelKey       = korifiv1alpha1.BuildWorkloadLabelKey
 	ImageGenerationKey          = "korifi.cloudfoundry.org/kpack-image-generation"
 	KpackReconcilerName         = "kpack-image-builder"
 	buildpackBuildMetadataLabel = "io.buildpacks.build.metadata"
@@ -0,0 +1,4 @@
+// +k8s:openapi-gen=true
+
+// Package quotas contains models for the CF Organization and Space Quotas API
+package quotas
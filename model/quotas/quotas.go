@@ -0,0 +1,90 @@
+package quotas
+
+// +kubebuilder:object:generate=true
+type AppQuotas struct {
+	// Total memory allowed for all the started processes and running tasks. A
+	// nil value means unlimited
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalMemoryInMB *int64 `json:"total_memory_in_mb"`
+	// Maximum memory for a single process or task instance
+	// +kubebuilder:validation:Optional
+	// +nullable
+	PerProcessMemoryInMB *int64 `json:"per_process_memory_in_mb"`
+	// Total log rate allowed for all the started processes and running tasks,
+	// in bytes per second. A nil value means unlimited
+	// +kubebuilder:validation:Optional
+	// +nullable
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+	// Total instances of all the started processes
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalInstances *int64 `json:"total_instances"`
+	// Maximum number of running tasks per app
+	// +kubebuilder:validation:Optional
+	// +nullable
+	PerAppTasks *int64 `json:"per_app_tasks"`
+}
+
+// +kubebuilder:object:generate=true
+type ServiceQuotas struct {
+	// Whether instances of paid service plans can be provisioned
+	PaidServicesAllowed bool `json:"paid_services_allowed"`
+	// Total number of service instances
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+	// Total number of service keys
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalServiceKeys *int64 `json:"total_service_keys"`
+}
+
+// +kubebuilder:object:generate=true
+type RouteQuotas struct {
+	// Total number of routes
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalRoutes *int64 `json:"total_routes"`
+	// Total number of ports reserved by TCP routes
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalReservedPorts *int64 `json:"total_reserved_ports"`
+}
+
+// +kubebuilder:object:generate=true
+type DomainQuotas struct {
+	// Total number of private domains
+	// +kubebuilder:validation:Optional
+	// +nullable
+	TotalDomains *int64 `json:"total_domains"`
+}
+
+// +kubebuilder:object:generate=true
+type OrgQuota struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Apps AppQuotas `json:"apps"`
+	// +kubebuilder:validation:Optional
+	Services ServiceQuotas `json:"services"`
+	// +kubebuilder:validation:Optional
+	Routes RouteQuotas `json:"routes"`
+	// +kubebuilder:validation:Optional
+	Domains DomainQuotas `json:"domains"`
+}
+
+// +kubebuilder:object:generate=true
+type SpaceQuota struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Apps AppQuotas `json:"apps"`
+	// +kubebuilder:validation:Optional
+	Services ServiceQuotas `json:"services"`
+	// +kubebuilder:validation:Optional
+	Routes RouteQuotas `json:"routes"`
+}
+
+// Exceeds returns true if the limit is set and the value is greater than it
+func Exceeds(limit *int64, value int64) bool {
+	return limit != nil && value > *limit
+}
@@ -0,0 +1,170 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package quotas
+
+import ()
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppQuotas) DeepCopyInto(out *AppQuotas) {
+	*out = *in
+	if in.TotalMemoryInMB != nil {
+		in, out := &in.TotalMemoryInMB, &out.TotalMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerProcessMemoryInMB != nil {
+		in, out := &in.PerProcessMemoryInMB, &out.PerProcessMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogRateLimitInBytesPerSecond != nil {
+		in, out := &in.LogRateLimitInBytesPerSecond, &out.LogRateLimitInBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalInstances != nil {
+		in, out := &in.TotalInstances, &out.TotalInstances
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerAppTasks != nil {
+		in, out := &in.PerAppTasks, &out.PerAppTasks
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppQuotas.
+func (in *AppQuotas) DeepCopy() *AppQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(AppQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainQuotas) DeepCopyInto(out *DomainQuotas) {
+	*out = *in
+	if in.TotalDomains != nil {
+		in, out := &in.TotalDomains, &out.TotalDomains
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainQuotas.
+func (in *DomainQuotas) DeepCopy() *DomainQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgQuota) DeepCopyInto(out *OrgQuota) {
+	*out = *in
+	in.Apps.DeepCopyInto(&out.Apps)
+	in.Services.DeepCopyInto(&out.Services)
+	in.Routes.DeepCopyInto(&out.Routes)
+	in.Domains.DeepCopyInto(&out.Domains)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgQuota.
+func (in *OrgQuota) DeepCopy() *OrgQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteQuotas) DeepCopyInto(out *RouteQuotas) {
+	*out = *in
+	if in.TotalRoutes != nil {
+		in, out := &in.TotalRoutes, &out.TotalRoutes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalReservedPorts != nil {
+		in, out := &in.TotalReservedPorts, &out.TotalReservedPorts
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteQuotas.
+func (in *RouteQuotas) DeepCopy() *RouteQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceQuotas) DeepCopyInto(out *ServiceQuotas) {
+	*out = *in
+	if in.TotalServiceInstances != nil {
+		in, out := &in.TotalServiceInstances, &out.TotalServiceInstances
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalServiceKeys != nil {
+		in, out := &in.TotalServiceKeys, &out.TotalServiceKeys
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceQuotas.
+func (in *ServiceQuotas) DeepCopy() *ServiceQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceQuota) DeepCopyInto(out *SpaceQuota) {
+	*out = *in
+	in.Apps.DeepCopyInto(&out.Apps)
+	in.Services.DeepCopyInto(&out.Services)
+	in.Routes.DeepCopyInto(&out.Routes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceQuota.
+func (in *SpaceQuota) DeepCopy() *SpaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -8,3 +8,8 @@ type ToOneRelationship struct {
 	//+kubebuilder:validation:Optional
 	Data Relationship `json:"data"`
 }
+
+type ToManyRelationship struct {
+	//+kubebuilder:validation:Optional
+	Data []Relationship `json:"data"`
+}
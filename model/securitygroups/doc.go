@@ -0,0 +1,4 @@
+// +k8s:openapi-gen=true
+
+// Package securitygroups contains models for the CF Security Groups API
+package securitygroups
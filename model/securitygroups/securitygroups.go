@@ -0,0 +1,204 @@
+package securitygroups
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+const (
+	ProtocolTCP  = "tcp"
+	ProtocolUDP  = "udp"
+	ProtocolICMP = "icmp"
+	ProtocolAll  = "all"
+
+	MinPort = 1
+	MaxPort = 65535
+
+	WorkloadRunning = "running"
+	WorkloadStaging = "staging"
+)
+
+// +kubebuilder:object:generate=true
+type Rule struct {
+	// The protocol of the traffic the rule allows
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+	Protocol string `json:"protocol"`
+	// A single IP address, an IP address range (e.g. 10.0.0.1-10.0.0.255), a
+	// CIDR block or a comma separated list of those
+	Destination string `json:"destination"`
+	// A single port, a port range (e.g. 8000-9000) or a comma separated list
+	// of ports. Only valid for the tcp and udp protocols
+	// +kubebuilder:validation:Optional
+	Ports string `json:"ports,omitempty"`
+	// The ICMP type. Only valid for the icmp protocol
+	// +kubebuilder:validation:Optional
+	Type *int32 `json:"type,omitempty"`
+	// The ICMP code. Only valid for the icmp protocol
+	// +kubebuilder:validation:Optional
+	Code *int32 `json:"code,omitempty"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// Whether to log the traffic matching the rule. Only valid for the tcp
+	// protocol
+	// +kubebuilder:validation:Optional
+	Log bool `json:"log,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+type Workloads struct {
+	// Whether the security group applies to running app and task instances
+	Running bool `json:"running"`
+	// Whether the security group applies to app staging
+	Staging bool `json:"staging"`
+}
+
+// Enabled returns whether the workload type ("running" or "staging") is
+// enabled
+func (w Workloads) Enabled(workload string) bool {
+	switch workload {
+	case WorkloadRunning:
+		return w.Running
+	case WorkloadStaging:
+		return w.Staging
+	default:
+		return false
+	}
+}
+
+// Set enables or disables the workload type ("running" or "staging")
+func (w *Workloads) Set(workload string, enabled bool) {
+	switch workload {
+	case WorkloadRunning:
+		w.Running = enabled
+	case WorkloadStaging:
+		w.Staging = enabled
+	}
+}
+
+type PortRange struct {
+	Start int32
+	End   int32
+}
+
+// ParseDestination returns the CIDR blocks covering the rule destination
+func ParseDestination(destination string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+
+	for _, item := range strings.Split(destination, ",") {
+		item = strings.TrimSpace(item)
+
+		if strings.Contains(item, "/") {
+			prefix, err := netip.ParsePrefix(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid destination %q: %w", item, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		start, end, isRange := strings.Cut(item, "-")
+		if !isRange {
+			end = start
+		}
+
+		startAddr, err := netip.ParseAddr(strings.TrimSpace(start))
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %w", item, err)
+		}
+
+		endAddr, err := netip.ParseAddr(strings.TrimSpace(end))
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %w", item, err)
+		}
+
+		if startAddr.Is4() != endAddr.Is4() || endAddr.Less(startAddr) {
+			return nil, fmt.Errorf("invalid destination range %q", item)
+		}
+
+		prefixes = append(prefixes, rangeToPrefixes(startAddr, endAddr)...)
+	}
+
+	return prefixes, nil
+}
+
+// rangeToPrefixes returns the smallest set of CIDR blocks covering the
+// inclusive [start, end] address range
+func rangeToPrefixes(start, end netip.Addr) []netip.Prefix {
+	bits := start.BitLen()
+	current := new(big.Int).SetBytes(start.AsSlice())
+	last := new(big.Int).SetBytes(end.AsSlice())
+	one := big.NewInt(1)
+
+	var prefixes []netip.Prefix
+	for current.Cmp(last) <= 0 {
+		prefixLen := bits
+		for prefixLen > 0 {
+			blockSize := new(big.Int).Lsh(one, uint(bits-prefixLen+1))
+			if new(big.Int).Mod(current, blockSize).Sign() != 0 {
+				break
+			}
+			blockEnd := new(big.Int).Add(current, blockSize)
+			if blockEnd.Sub(blockEnd, one).Cmp(last) > 0 {
+				break
+			}
+			prefixLen--
+		}
+
+		addr := toAddr(current, bits)
+		prefixes = append(prefixes, netip.PrefixFrom(addr, prefixLen))
+
+		current.Add(current, new(big.Int).Lsh(one, uint(bits-prefixLen)))
+	}
+
+	return prefixes
+}
+
+func toAddr(value *big.Int, bits int) netip.Addr {
+	bytes := value.FillBytes(make([]byte, bits/8))
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// ParsePorts returns the port ranges described by the rule ports
+func ParsePorts(ports string) ([]PortRange, error) {
+	var ranges []PortRange
+
+	for _, item := range strings.Split(ports, ",") {
+		item = strings.TrimSpace(item)
+
+		start, end, isRange := strings.Cut(item, "-")
+		if !isRange {
+			end = start
+		}
+
+		startPort, err := parsePort(start)
+		if err != nil {
+			return nil, err
+		}
+
+		endPort, err := parsePort(end)
+		if err != nil {
+			return nil, err
+		}
+
+		if endPort < startPort {
+			return nil, fmt.Errorf("invalid port range %q", item)
+		}
+
+		ranges = append(ranges, PortRange{Start: startPort, End: endPort})
+	}
+
+	return ranges, nil
+}
+
+func parsePort(port string) (int32, error) {
+	value, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+	if err != nil || value < MinPort || value > MaxPort {
+		return 0, fmt.Errorf("invalid port %q", port)
+	}
+
+	return int32(value), nil
+}
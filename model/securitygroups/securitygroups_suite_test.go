@@ -0,0 +1,13 @@
+package securitygroups_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSecurityGroups(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Security Groups Suite")
+}
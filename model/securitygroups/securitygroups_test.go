@@ -0,0 +1,74 @@
+package securitygroups_test
+
+import (
+	"net/netip"
+
+	"code.cloudfoundry.org/korifi/model/securitygroups"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseDestination", func() {
+	DescribeTable("valid destinations",
+		func(destination string, expected ...string) {
+			prefixes, err := securitygroups.ParseDestination(destination)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedPrefixes := []netip.Prefix{}
+			for _, e := range expected {
+				expectedPrefixes = append(expectedPrefixes, netip.MustParsePrefix(e))
+			}
+			Expect(prefixes).To(Equal(expectedPrefixes))
+		},
+		Entry("single address", "10.0.0.1", "10.0.0.1/32"),
+		Entry("cidr", "10.0.0.0/24", "10.0.0.0/24"),
+		Entry("unmasked cidr", "10.0.0.5/24", "10.0.0.0/24"),
+		Entry("aligned range", "10.0.0.0-10.0.0.255", "10.0.0.0/24"),
+		Entry("unaligned range", "10.0.0.1-10.0.0.6", "10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"),
+		Entry("whole ipv4 space", "0.0.0.0-255.255.255.255", "0.0.0.0/0"),
+		Entry("list", "10.0.0.1, 192.168.0.0/16", "10.0.0.1/32", "192.168.0.0/16"),
+		Entry("ipv6", "2001:db8::/32", "2001:db8::/32"),
+	)
+
+	DescribeTable("invalid destinations",
+		func(destination string) {
+			_, err := securitygroups.ParseDestination(destination)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("garbage", "not-an-ip"),
+		Entry("bad cidr", "10.0.0.0/33"),
+		Entry("reversed range", "10.0.0.9-10.0.0.1"),
+		Entry("mixed families", "10.0.0.1-2001:db8::1"),
+	)
+})
+
+var _ = Describe("ParsePorts", func() {
+	DescribeTable("valid ports",
+		func(ports string, expected ...securitygroups.PortRange) {
+			ranges, err := securitygroups.ParsePorts(ports)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal(expected))
+		},
+		Entry("single port", "443", securitygroups.PortRange{Start: 443, End: 443}),
+		Entry("range", "8000-9000", securitygroups.PortRange{Start: 8000, End: 9000}),
+		Entry("list", "80, 443,1000-2000",
+			securitygroups.PortRange{Start: 80, End: 80},
+			securitygroups.PortRange{Start: 443, End: 443},
+			securitygroups.PortRange{Start: 1000, End: 2000},
+		),
+	)
+
+	DescribeTable("invalid ports",
+		func(ports string) {
+			_, err := securitygroups.ParsePorts(ports)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("zero", "0"),
+		Entry("too big", "65536"),
+		Entry("garbage", "http"),
+		Entry("reversed range", "9000-8000"),
+	)
+})
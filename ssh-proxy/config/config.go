@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/korifi/tools"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type SSHProxyConfig struct {
+	ListenPort    int           `yaml:"listenPort"`
+	HostKeyPath   string        `yaml:"hostKeyPath"`
+	RootNamespace string        `yaml:"rootNamespace"`
+	LogLevel      zapcore.Level `yaml:"logLevel"`
+}
+
+func LoadFromPath(path string) (*SSHProxyConfig, error) {
+	var config SSHProxyConfig
+	err := tools.LoadConfigInto(&config, path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func (c *SSHProxyConfig) validate() error {
+	if c.ListenPort == 0 {
+		return errors.New("ListenPort must have a value")
+	}
+
+	if c.HostKeyPath == "" {
+		return errors.New("HostKeyPath must have a value")
+	}
+
+	if c.RootNamespace == "" {
+		return errors.New("RootNamespace must have a value")
+	}
+
+	return nil
+}
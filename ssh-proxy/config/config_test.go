@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		configMap map[string]any
+		cfg       *config.SSHProxyConfig
+		loadErr   error
+		cfgDir    string
+	)
+
+	BeforeEach(func() {
+		var err error
+		cfgDir, err = os.MkdirTemp("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		configMap = map[string]any{
+			"listenPort":    2222,
+			"hostKeyPath":   "/etc/ssh-proxy/host-key",
+			"rootNamespace": "root-ns",
+			"logLevel":      "debug",
+		}
+	})
+
+	JustBeforeEach(func() {
+		configBytes, err := yaml.Marshal(configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(cfgDir+"/ssh_proxy_config.yaml", configBytes, 0o644)).To(Succeed())
+
+		cfg, loadErr = config.LoadFromPath(cfgDir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(cfgDir)).To(Succeed())
+	})
+
+	It("populates the config", func() {
+		Expect(loadErr).NotTo(HaveOccurred())
+		Expect(cfg.ListenPort).To(Equal(2222))
+		Expect(cfg.HostKeyPath).To(Equal("/etc/ssh-proxy/host-key"))
+		Expect(cfg.RootNamespace).To(Equal("root-ns"))
+		Expect(cfg.LogLevel).To(Equal(zapcore.DebugLevel))
+	})
+
+	When("the listen port is not specified", func() {
+		BeforeEach(func() {
+			delete(configMap, "listenPort")
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError("ListenPort must have a value"))
+		})
+	})
+
+	When("the host key path is not specified", func() {
+		BeforeEach(func() {
+			delete(configMap, "hostKeyPath")
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError("HostKeyPath must have a value"))
+		})
+	})
+
+	When("the root namespace is not specified", func() {
+		BeforeEach(func() {
+			delete(configMap, "rootNamespace")
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError("RootNamespace must have a value"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/ssh-proxy/config"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/version"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func init() {
+	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme.Scheme))
+}
+
+func main() {
+	configPath, found := os.LookupEnv("SSHPROXYCONFIG")
+	if !found {
+		panic("SSHPROXYCONFIG must be set")
+	}
+	cfg, err := config.LoadFromPath(configPath)
+	if err != nil {
+		panic(fmt.Sprintf("Config could not be read: %v", err))
+	}
+
+	logger, _, err := tools.NewZapLogger(cfg.LogLevel)
+	if err != nil {
+		panic(fmt.Sprintf("error creating new zap logger: %v", err))
+	}
+	ctrl.SetLogger(logger)
+	klog.SetLogger(ctrl.Log)
+
+	ctrl.Log.Info("starting Korifi SSH proxy", "version", version.Version)
+
+	hostKeyBytes, err := os.ReadFile(cfg.HostKeyPath)
+	if err != nil {
+		panic(fmt.Sprintf("could not read host key: %v", err))
+	}
+	hostKey, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse host key: %v", err))
+	}
+
+	k8sClientConfig := ctrl.GetConfigOrDie()
+	privilegedClient, err := client.New(k8sClientConfig, client.Options{})
+	if err != nil {
+		panic(fmt.Sprintf("could not create privileged k8s client: %v", err))
+	}
+	privilegedClientset, err := k8sclient.NewForConfig(k8sClientConfig)
+	if err != nil {
+		panic(fmt.Sprintf("could not create privileged k8s clientset: %v", err))
+	}
+
+	server := proxy.NewServer(
+		hostKey,
+		proxy.NewOneTimeCodeAuthenticator(
+			authorization.NewOneTimeCodes(privilegedClient, cfg.RootNamespace, authorization.OneTimeCodeTTL),
+			authorization.NewSubjectAccessReviewer(privilegedClient),
+			proxy.NewPodLocator(privilegedClient),
+		),
+		proxy.NewPodExecutor(k8sClientConfig, privilegedClientset),
+	)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.ListenPort))
+	if err != nil {
+		panic(fmt.Sprintf("could not listen on port %d: %v", cfg.ListenPort, err))
+	}
+
+	ctx := logr.NewContext(ctrl.SetupSignalHandler(), ctrl.Log)
+	ctrl.Log.Info("listening for ssh connections", "port", cfg.ListenPort)
+	if err = server.Serve(ctx, listener); err != nil {
+		ctrl.Log.Error(err, "ssh proxy failed")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	authzv1 "k8s.io/api/authorization/v1"
+)
+
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;delete,namespace=ROOT_NAMESPACE
+
+var ErrNotAuthorized = errors.New("not authorized to ssh into the app instance")
+
+// SSHSubresource is the CFApp subresource users must be allowed to create in
+// order to ssh into the app instances. It is not served by the Kubernetes API
+// and only exists to be granted by the CF roles, so that users can ssh into
+// apps without being allowed to exec into their pods directly.
+const SSHSubresource = "ssh"
+
+//counterfeiter:generate -o fake -fake-name CodeRedeemer . CodeRedeemer
+
+type CodeRedeemer interface {
+	Redeem(context.Context, string) (authorization.Identity, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AccessReviewer . AccessReviewer
+
+type AccessReviewer interface {
+	IsAllowed(context.Context, authorization.Identity, authzv1.ResourceAttributes) (bool, error)
+}
+
+//counterfeiter:generate -o fake -fake-name InstanceLocator . InstanceLocator
+
+type InstanceLocator interface {
+	Locate(context.Context, Target) (Instance, error)
+}
+
+// OneTimeCodeAuthenticator authenticates users with the one-time codes issued
+// by the API and only lets them into instances of apps they are allowed to
+// ssh into, i.e. apps in spaces they are space developers in. The proxy then
+// execs into the instance pod with its own credentials.
+type OneTimeCodeAuthenticator struct {
+	codeRedeemer    CodeRedeemer
+	accessReviewer  AccessReviewer
+	instanceLocator InstanceLocator
+}
+
+func NewOneTimeCodeAuthenticator(
+	codeRedeemer CodeRedeemer,
+	accessReviewer AccessReviewer,
+	instanceLocator InstanceLocator,
+) *OneTimeCodeAuthenticator {
+	return &OneTimeCodeAuthenticator{
+		codeRedeemer:    codeRedeemer,
+		accessReviewer:  accessReviewer,
+		instanceLocator: instanceLocator,
+	}
+}
+
+func (a *OneTimeCodeAuthenticator) Authenticate(ctx context.Context, user string, password []byte) (Instance, error) {
+	target, err := ParseTarget(user)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	identity, err := a.codeRedeemer.Redeem(ctx, string(password))
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to redeem one-time code: %w", err)
+	}
+
+	instance, err := a.instanceLocator.Locate(ctx, target)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to locate app instance: %w", err)
+	}
+
+	allowed, err := a.accessReviewer.IsAllowed(ctx, identity, authzv1.ResourceAttributes{
+		Namespace:   instance.Namespace,
+		Verb:        "create",
+		Group:       korifiv1alpha1.SchemeGroupVersion.Group,
+		Resource:    "cfapps",
+		Subresource: SSHSubresource,
+		Name:        instance.AppGUID,
+	})
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to review access: %w", err)
+	}
+	if !allowed {
+		return Instance{}, ErrNotAuthorized
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,139 @@
+package proxy_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authzv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OneTimeCodeAuthenticator", func() {
+	var (
+		codeRedeemer    *fake.CodeRedeemer
+		accessReviewer  *fake.AccessReviewer
+		instanceLocator *fake.InstanceLocator
+		authenticator   *proxy.OneTimeCodeAuthenticator
+		user            string
+		instance        proxy.Instance
+		authErr         error
+	)
+
+	BeforeEach(func() {
+		codeRedeemer = new(fake.CodeRedeemer)
+		codeRedeemer.RedeemReturns(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}, nil)
+
+		instanceLocator = new(fake.InstanceLocator)
+		instanceLocator.LocateReturns(proxy.Instance{
+			Namespace: "space-ns",
+			AppGUID:   "my-app",
+			PodName:   "my-pod",
+			Container: "application",
+		}, nil)
+
+		accessReviewer = new(fake.AccessReviewer)
+		accessReviewer.IsAllowedReturns(true, nil)
+
+		authenticator = proxy.NewOneTimeCodeAuthenticator(codeRedeemer, accessReviewer, instanceLocator)
+		user = "cf:my-process/1"
+	})
+
+	JustBeforeEach(func() {
+		instance, authErr = authenticator.Authenticate(context.Background(), user, []byte("the-code"))
+	})
+
+	It("returns the app instance", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+		Expect(instance).To(Equal(proxy.Instance{
+			Namespace: "space-ns",
+			AppGUID:   "my-app",
+			PodName:   "my-pod",
+			Container: "application",
+		}))
+	})
+
+	It("redeems the code", func() {
+		Expect(codeRedeemer.RedeemCallCount()).To(Equal(1))
+		_, actualCode := codeRedeemer.RedeemArgsForCall(0)
+		Expect(actualCode).To(Equal("the-code"))
+	})
+
+	It("locates the target instance", func() {
+		Expect(instanceLocator.LocateCallCount()).To(Equal(1))
+		_, actualTarget := instanceLocator.LocateArgsForCall(0)
+		Expect(actualTarget).To(Equal(proxy.Target{ProcessGUID: "my-process", Index: 1}))
+	})
+
+	It("checks the user can ssh into the app", func() {
+		Expect(accessReviewer.IsAllowedCallCount()).To(Equal(1))
+		_, actualIdentity, actualAttributes := accessReviewer.IsAllowedArgsForCall(0)
+		Expect(actualIdentity).To(Equal(authorization.Identity{Name: "alice", Kind: rbacv1.UserKind}))
+		Expect(actualAttributes).To(Equal(authzv1.ResourceAttributes{
+			Namespace:   "space-ns",
+			Verb:        "create",
+			Group:       "korifi.cloudfoundry.org",
+			Resource:    "cfapps",
+			Subresource: "ssh",
+			Name:        "my-app",
+		}))
+	})
+
+	When("the user is not a valid target", func() {
+		BeforeEach(func() {
+			user = "alice"
+		})
+
+		It("returns an error without redeeming the code", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("invalid target")))
+			Expect(codeRedeemer.RedeemCallCount()).To(BeZero())
+		})
+	})
+
+	When("the code is invalid", func() {
+		BeforeEach(func() {
+			codeRedeemer.RedeemReturns(authorization.Identity{}, authorization.ErrInvalidOneTimeCode)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(authorization.ErrInvalidOneTimeCode))
+			Expect(instanceLocator.LocateCallCount()).To(BeZero())
+		})
+	})
+
+	When("the instance cannot be located", func() {
+		BeforeEach(func() {
+			instanceLocator.LocateReturns(proxy.Instance{}, proxy.ErrSSHDisabled)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(proxy.ErrSSHDisabled))
+			Expect(accessReviewer.IsAllowedCallCount()).To(BeZero())
+		})
+	})
+
+	When("the user is not allowed to ssh into the app", func() {
+		BeforeEach(func() {
+			accessReviewer.IsAllowedReturns(false, nil)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(proxy.ErrNotAuthorized))
+		})
+	})
+
+	When("reviewing the access fails", func() {
+		BeforeEach(func() {
+			accessReviewer.IsAllowedReturns(false, errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+})
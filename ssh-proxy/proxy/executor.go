@@ -0,0 +1,73 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+
+type ExecOptions struct {
+	Command           []string
+	Stdin             io.Reader
+	Stdout            io.Writer
+	Stderr            io.Writer
+	TTY               bool
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// PodExecutor runs commands in app instance containers the way `kubectl exec`
+// does. It uses the proxy credentials, as users are not allowed to exec into
+// pods themselves.
+type PodExecutor struct {
+	restConfig *rest.Config
+	clientset  kubernetes.Interface
+}
+
+func NewPodExecutor(restConfig *rest.Config, clientset kubernetes.Interface) *PodExecutor {
+	return &PodExecutor{
+		restConfig: restConfig,
+		clientset:  clientset,
+	}
+}
+
+func (e *PodExecutor) Exec(ctx context.Context, instance Instance, opts ExecOptions) error {
+	req := e.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(instance.Namespace).
+		Name(instance.PodName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: instance.Container,
+			Command:   opts.Command,
+			Stdin:     opts.Stdin != nil,
+			Stdout:    opts.Stdout != nil,
+			// stderr is merged into stdout when a TTY is allocated
+			Stderr: opts.Stderr != nil && !opts.TTY,
+			TTY:    opts.TTY,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.restConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:             opts.Stdin,
+		Stdout:            opts.Stdout,
+		Tty:               opts.TTY,
+		TerminalSizeQueue: opts.TerminalSizeQueue,
+	}
+	if !opts.TTY {
+		streamOptions.Stderr = opts.Stderr
+	}
+
+	return executor.StreamWithContext(ctx, streamOptions)
+}
@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	v1 "k8s.io/api/authorization/v1"
+)
+
+type AccessReviewer struct {
+	IsAllowedStub        func(context.Context, authorization.Identity, v1.ResourceAttributes) (bool, error)
+	isAllowedMutex       sync.RWMutex
+	isAllowedArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Identity
+		arg3 v1.ResourceAttributes
+	}
+	isAllowedReturns struct {
+		result1 bool
+		result2 error
+	}
+	isAllowedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *AccessReviewer) IsAllowed(arg1 context.Context, arg2 authorization.Identity, arg3 v1.ResourceAttributes) (bool, error) {
+	fake.isAllowedMutex.Lock()
+	ret, specificReturn := fake.isAllowedReturnsOnCall[len(fake.isAllowedArgsForCall)]
+	fake.isAllowedArgsForCall = append(fake.isAllowedArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Identity
+		arg3 v1.ResourceAttributes
+	}{arg1, arg2, arg3})
+	stub := fake.IsAllowedStub
+	fakeReturns := fake.isAllowedReturns
+	fake.recordInvocation("IsAllowed", []interface{}{arg1, arg2, arg3})
+	fake.isAllowedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AccessReviewer) IsAllowedCallCount() int {
+	fake.isAllowedMutex.RLock()
+	defer fake.isAllowedMutex.RUnlock()
+	return len(fake.isAllowedArgsForCall)
+}
+
+func (fake *AccessReviewer) IsAllowedCalls(stub func(context.Context, authorization.Identity, v1.ResourceAttributes) (bool, error)) {
+	fake.isAllowedMutex.Lock()
+	defer fake.isAllowedMutex.Unlock()
+	fake.IsAllowedStub = stub
+}
+
+func (fake *AccessReviewer) IsAllowedArgsForCall(i int) (context.Context, authorization.Identity, v1.ResourceAttributes) {
+	fake.isAllowedMutex.RLock()
+	defer fake.isAllowedMutex.RUnlock()
+	argsForCall := fake.isAllowedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *AccessReviewer) IsAllowedReturns(result1 bool, result2 error) {
+	fake.isAllowedMutex.Lock()
+	defer fake.isAllowedMutex.Unlock()
+	fake.IsAllowedStub = nil
+	fake.isAllowedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AccessReviewer) IsAllowedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isAllowedMutex.Lock()
+	defer fake.isAllowedMutex.Unlock()
+	fake.IsAllowedStub = nil
+	if fake.isAllowedReturnsOnCall == nil {
+		fake.isAllowedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isAllowedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AccessReviewer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.isAllowedMutex.RLock()
+	defer fake.isAllowedMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *AccessReviewer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ proxy.AccessReviewer = new(AccessReviewer)
@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+)
+
+type InstanceLocator struct {
+	LocateStub        func(context.Context, proxy.Target) (proxy.Instance, error)
+	locateMutex       sync.RWMutex
+	locateArgsForCall []struct {
+		arg1 context.Context
+		arg2 proxy.Target
+	}
+	locateReturns struct {
+		result1 proxy.Instance
+		result2 error
+	}
+	locateReturnsOnCall map[int]struct {
+		result1 proxy.Instance
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *InstanceLocator) Locate(arg1 context.Context, arg2 proxy.Target) (proxy.Instance, error) {
+	fake.locateMutex.Lock()
+	ret, specificReturn := fake.locateReturnsOnCall[len(fake.locateArgsForCall)]
+	fake.locateArgsForCall = append(fake.locateArgsForCall, struct {
+		arg1 context.Context
+		arg2 proxy.Target
+	}{arg1, arg2})
+	stub := fake.LocateStub
+	fakeReturns := fake.locateReturns
+	fake.recordInvocation("Locate", []interface{}{arg1, arg2})
+	fake.locateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *InstanceLocator) LocateCallCount() int {
+	fake.locateMutex.RLock()
+	defer fake.locateMutex.RUnlock()
+	return len(fake.locateArgsForCall)
+}
+
+func (fake *InstanceLocator) LocateCalls(stub func(context.Context, proxy.Target) (proxy.Instance, error)) {
+	fake.locateMutex.Lock()
+	defer fake.locateMutex.Unlock()
+	fake.LocateStub = stub
+}
+
+func (fake *InstanceLocator) LocateArgsForCall(i int) (context.Context, proxy.Target) {
+	fake.locateMutex.RLock()
+	defer fake.locateMutex.RUnlock()
+	argsForCall := fake.locateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *InstanceLocator) LocateReturns(result1 proxy.Instance, result2 error) {
+	fake.locateMutex.Lock()
+	defer fake.locateMutex.Unlock()
+	fake.LocateStub = nil
+	fake.locateReturns = struct {
+		result1 proxy.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *InstanceLocator) LocateReturnsOnCall(i int, result1 proxy.Instance, result2 error) {
+	fake.locateMutex.Lock()
+	defer fake.locateMutex.Unlock()
+	fake.LocateStub = nil
+	if fake.locateReturnsOnCall == nil {
+		fake.locateReturnsOnCall = make(map[int]struct {
+			result1 proxy.Instance
+			result2 error
+		})
+	}
+	fake.locateReturnsOnCall[i] = struct {
+		result1 proxy.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *InstanceLocator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.locateMutex.RLock()
+	defer fake.locateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *InstanceLocator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ proxy.InstanceLocator = new(InstanceLocator)
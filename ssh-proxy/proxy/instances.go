@@ -0,0 +1,126 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	stsetcontrollers "code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses;cfapps;cffeatureflags,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+
+var (
+	ErrInstanceNotFound = errors.New("app instance not found")
+	ErrSSHDisabled      = errors.New("ssh is disabled")
+)
+
+// Instance is the pod and container running an app instance
+type Instance struct {
+	Namespace string
+	AppGUID   string
+	PodName   string
+	Container string
+}
+
+// PodLocator finds the StatefulSet pod running an app instance, making sure
+// that SSH is enabled for both the app and its space
+type PodLocator struct {
+	privilegedClient client.Client
+}
+
+func NewPodLocator(privilegedClient client.Client) *PodLocator {
+	return &PodLocator{
+		privilegedClient: privilegedClient,
+	}
+}
+
+func (l *PodLocator) Locate(ctx context.Context, target Target) (Instance, error) {
+	processes := &korifiv1alpha1.CFProcessList{}
+	err := l.privilegedClient.List(ctx, processes, client.MatchingLabels{
+		korifiv1alpha1.CFProcessGUIDLabelKey: target.ProcessGUID,
+	})
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to list processes: %w", err)
+	}
+	if len(processes.Items) != 1 {
+		return Instance{}, ErrInstanceNotFound
+	}
+	process := processes.Items[0]
+
+	if err = l.checkSSHEnabled(ctx, process); err != nil {
+		return Instance{}, err
+	}
+
+	pods := &corev1.PodList{}
+	err = l.privilegedClient.List(ctx, pods, client.InNamespace(process.Namespace), client.MatchingLabels{
+		stsetcontrollers.LabelGUID:      process.Name,
+		korifiv1alpha1.PodIndexLabelKey: strconv.Itoa(target.Index),
+	})
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// while the app is being restarted there could be pods for both the old
+	// and the new version with the same index, in which case we pick the
+	// most recent running one
+	var instancePod *corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if instancePod == nil || instancePod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			instancePod = pod
+		}
+	}
+	if instancePod == nil {
+		return Instance{}, ErrInstanceNotFound
+	}
+
+	return Instance{
+		Namespace: instancePod.Namespace,
+		AppGUID:   process.Spec.AppRef.Name,
+		PodName:   instancePod.Name,
+		Container: stsetcontrollers.ApplicationContainerName,
+	}, nil
+}
+
+func (l *PodLocator) checkSSHEnabled(ctx context.Context, process korifiv1alpha1.CFProcess) error {
+	app := &korifiv1alpha1.CFApp{}
+	err := l.privilegedClient.Get(ctx, client.ObjectKey{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, app)
+	if k8serrors.IsNotFound(err) {
+		return ErrInstanceNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get app: %w", err)
+	}
+
+	if !app.SSHEnabled() {
+		return ErrSSHDisabled
+	}
+
+	// the space feature is enabled unless there is a feature flag disabling it
+	spaceFeature := &korifiv1alpha1.CFFeatureFlag{}
+	err = l.privilegedClient.Get(ctx, client.ObjectKey{Namespace: process.Namespace, Name: repositories.SpaceFeatureSSH}, spaceFeature)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get space ssh feature: %w", err)
+	}
+
+	if !spaceFeature.Spec.Enabled {
+		return ErrSSHDisabled
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	stsetcontrollers "code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("PodLocator", func() {
+	var (
+		ctx         context.Context
+		namespace   string
+		cfApp       *korifiv1alpha1.CFApp
+		processGUID string
+		locator     *proxy.PodLocator
+		target      proxy.Target
+		instance    proxy.Instance
+		locateErr   error
+	)
+
+	createPod := func(name string, index string, phase corev1.PodPhase) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels: map[string]string{
+					stsetcontrollers.LabelGUID:      processGUID,
+					korifiv1alpha1.PodIndexLabelKey: index,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  stsetcontrollers.ApplicationContainerName,
+					Image: "my-image",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		Expect(k8s.Patch(ctx, k8sClient, pod, func() {
+			pod.Status.Phase = phase
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+		})).To(Succeed())
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfApp)).To(Succeed())
+
+		processGUID = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      processGUID,
+				Labels: map[string]string{
+					korifiv1alpha1.CFProcessGUIDLabelKey: processGUID,
+				},
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType: "web",
+				HealthCheck: korifiv1alpha1.HealthCheck{
+					Type: "process",
+				},
+			},
+		})).To(Succeed())
+
+		createPod("my-pod-0", "0", corev1.PodRunning)
+		createPod("my-pod-1", "1", corev1.PodRunning)
+
+		locator = proxy.NewPodLocator(k8sClient)
+		target = proxy.Target{ProcessGUID: processGUID, Index: 1}
+	})
+
+	JustBeforeEach(func() {
+		instance, locateErr = locator.Locate(ctx, target)
+	})
+
+	It("returns the pod running the app instance", func() {
+		Expect(locateErr).NotTo(HaveOccurred())
+		Expect(instance).To(Equal(proxy.Instance{
+			Namespace: namespace,
+			AppGUID:   cfApp.Name,
+			PodName:   "my-pod-1",
+			Container: stsetcontrollers.ApplicationContainerName,
+		}))
+	})
+
+	When("there are several running pods with the same index", func() {
+		BeforeEach(func() {
+			time.Sleep(time.Second)
+			createPod("my-newer-pod-1", "1", corev1.PodRunning)
+		})
+
+		It("returns the most recent one", func() {
+			Expect(locateErr).NotTo(HaveOccurred())
+			Expect(instance.PodName).To(Equal("my-newer-pod-1"))
+		})
+	})
+
+	When("the pod is not running", func() {
+		BeforeEach(func() {
+			createPod("my-pod-2", "2", corev1.PodPending)
+			target.Index = 2
+		})
+
+		It("returns a not found error", func() {
+			Expect(locateErr).To(MatchError(proxy.ErrInstanceNotFound))
+		})
+	})
+
+	When("there is no pod for the index", func() {
+		BeforeEach(func() {
+			target.Index = 5
+		})
+
+		It("returns a not found error", func() {
+			Expect(locateErr).To(MatchError(proxy.ErrInstanceNotFound))
+		})
+	})
+
+	When("the process does not exist", func() {
+		BeforeEach(func() {
+			target.ProcessGUID = "not-a-process"
+		})
+
+		It("returns a not found error", func() {
+			Expect(locateErr).To(MatchError(proxy.ErrInstanceNotFound))
+		})
+	})
+
+	When("ssh is disabled for the app", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.EnableSSH = tools.PtrTo(false)
+			})).To(Succeed())
+		})
+
+		It("returns an ssh disabled error", func() {
+			Expect(locateErr).To(MatchError(proxy.ErrSSHDisabled))
+		})
+	})
+
+	When("ssh is disabled for the space", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFFeatureFlag{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "ssh",
+				},
+				Spec: korifiv1alpha1.CFFeatureFlagSpec{
+					Enabled: false,
+				},
+			})).To(Succeed())
+		})
+
+		It("returns an ssh disabled error", func() {
+			Expect(locateErr).To(MatchError(proxy.ErrSSHDisabled))
+		})
+	})
+
+	When("ssh is explicitly enabled for the space", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFFeatureFlag{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "ssh",
+				},
+				Spec: korifiv1alpha1.CFFeatureFlagSpec{
+					Enabled: true,
+				},
+			})).To(Succeed())
+		})
+
+		It("returns the instance", func() {
+			Expect(locateErr).NotTo(HaveOccurred())
+			Expect(instance.PodName).To(Equal("my-pod-1"))
+		})
+	})
+})
@@ -0,0 +1,288 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	instanceNamespaceKey = "namespace"
+	instancePodNameKey   = "pod-name"
+	instanceContainerKey = "container"
+)
+
+// defaultShell starts a login shell, preferring bash when the app image has it
+var defaultShell = []string{"/bin/sh", "-c", "if command -v bash >/dev/null 2>&1; then exec bash -l; else exec sh -l; fi"}
+
+//counterfeiter:generate -o fake -fake-name Authenticator . Authenticator
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, user string, password []byte) (Instance, error)
+}
+
+//counterfeiter:generate -o fake -fake-name Executor . Executor
+
+type Executor interface {
+	Exec(context.Context, Instance, ExecOptions) error
+}
+
+// Server is an SSH server that proxies sessions to app instances. Only
+// session channels are supported, port forwarding is not.
+type Server struct {
+	hostKey       ssh.Signer
+	authenticator Authenticator
+	executor      Executor
+}
+
+func NewServer(hostKey ssh.Signer, authenticator Authenticator, executor Executor) *Server {
+	return &Server{
+		hostKey:       hostKey,
+		authenticator: authenticator,
+		executor:      executor,
+	}
+}
+
+// Serve handles the connections accepted by the listener until the context is
+// done
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		go s.handleConnection(ctx, conn)
+	}
+}
+
+func (s *Server) handleConnection(ctx context.Context, conn net.Conn) {
+	log := logr.FromContextOrDiscard(ctx).WithName("ssh-proxy").WithValues("remoteAddr", conn.RemoteAddr().String())
+	defer conn.Close()
+
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, s.serverConfig(ctx))
+	if err != nil {
+		log.Info("ssh handshake failed", "reason", err)
+		return
+	}
+	defer serverConn.Close()
+
+	instance := Instance{
+		Namespace: serverConn.Permissions.Extensions[instanceNamespaceKey],
+		PodName:   serverConn.Permissions.Extensions[instancePodNameKey],
+		Container: serverConn.Permissions.Extensions[instanceContainerKey],
+	}
+	log = log.WithValues("namespace", instance.Namespace, "pod", instance.PodName)
+	log.V(1).Info("ssh connection established")
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, fmt.Sprintf("unsupported channel type %q", newChannel.ChannelType()))
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			log.Info("failed to accept session", "reason", err)
+			continue
+		}
+
+		go s.handleSession(logr.NewContext(ctx, log), instance, channel, channelRequests)
+	}
+}
+
+func (s *Server) serverConfig(ctx context.Context) *ssh.ServerConfig {
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(connMeta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			instance, err := s.authenticator.Authenticate(ctx, connMeta.User(), password)
+			if err != nil {
+				logr.FromContextOrDiscard(ctx).WithName("ssh-proxy").Info("authentication failed", "user", connMeta.User(), "reason", err)
+				return nil, errors.New("permission denied")
+			}
+
+			return &ssh.Permissions{
+				Extensions: map[string]string{
+					instanceNamespaceKey: instance.Namespace,
+					instancePodNameKey:   instance.PodName,
+					instanceContainerKey: instance.Container,
+				},
+			}, nil
+		},
+	}
+	config.AddHostKey(s.hostKey)
+
+	return config
+}
+
+type ptyRequest struct {
+	Term     string
+	Columns  uint32
+	Rows     uint32
+	Width    uint32
+	Height   uint32
+	Modelist string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+func (s *Server) handleSession(ctx context.Context, instance Instance, channel ssh.Channel, requests <-chan *ssh.Request) {
+	log := logr.FromContextOrDiscard(ctx)
+	defer channel.Close()
+
+	sizeQueue := newTerminalSizeQueue()
+	defer sizeQueue.close()
+
+	tty := false
+	started := false
+
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			pty := ptyRequest{}
+			if err := ssh.Unmarshal(req.Payload, &pty); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			tty = true
+			sizeQueue.push(pty.Columns, pty.Rows)
+			_ = req.Reply(true, nil)
+
+		case "window-change":
+			windowChange := windowChangeRequest{}
+			if err := ssh.Unmarshal(req.Payload, &windowChange); err == nil {
+				sizeQueue.push(windowChange.Columns, windowChange.Rows)
+			}
+			_ = req.Reply(true, nil)
+
+		case "shell", "exec":
+			if started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+
+			command := defaultShell
+			if req.Type == "exec" {
+				execReq := execRequest{}
+				if err := ssh.Unmarshal(req.Payload, &execReq); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				command = []string{"/bin/sh", "-c", execReq.Command}
+			}
+
+			started = true
+			_ = req.Reply(true, nil)
+
+			go func() {
+				status := s.exec(ctx, instance, channel, ExecOptions{
+					Command:           command,
+					Stdin:             channel,
+					Stdout:            channel,
+					Stderr:            channel.Stderr(),
+					TTY:               tty,
+					TerminalSizeQueue: sizeQueue,
+				})
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: status}))
+				channel.Close()
+			}()
+
+		default:
+			log.V(1).Info("ignoring unsupported request", "type", req.Type)
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *Server) exec(ctx context.Context, instance Instance, channel ssh.Channel, opts ExecOptions) uint32 {
+	err := s.executor.Exec(ctx, instance, opts)
+	if err == nil {
+		return 0
+	}
+
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) {
+		return uint32(exitErr.ExitStatus())
+	}
+
+	logr.FromContextOrDiscard(ctx).Info("exec failed", "reason", err)
+	fmt.Fprintf(channel.Stderr(), "failed to exec into app instance: %v\r\n", err)
+	return 1
+}
+
+// terminalSizeQueue feeds the terminal size changes requested by the SSH
+// client to the exec. Only the most recent size is kept, so that the client is
+// never blocked by an exec that has not started reading them yet.
+type terminalSizeQueue struct {
+	sizes     chan remotecommand.TerminalSize
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func newTerminalSizeQueue() *terminalSizeQueue {
+	return &terminalSizeQueue{
+		sizes: make(chan remotecommand.TerminalSize, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+func (q *terminalSizeQueue) push(columns, rows uint32) {
+	size := remotecommand.TerminalSize{Width: uint16(columns), Height: uint16(rows)}
+	for {
+		select {
+		case q.sizes <- size:
+			return
+		case <-q.done:
+			return
+		default:
+			select {
+			case <-q.sizes:
+			default:
+			}
+		}
+	}
+}
+
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-q.sizes:
+		return &size
+	case <-q.done:
+		return nil
+	}
+}
+
+func (q *terminalSizeQueue) close() {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+}
@@ -0,0 +1,184 @@
+package proxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		authenticator *fake.Authenticator
+		executor      *fake.Executor
+		hostKey       ssh.Signer
+		listener      net.Listener
+		cancel        context.CancelFunc
+		clientConfig  *ssh.ClientConfig
+	)
+
+	BeforeEach(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		authenticator = new(fake.Authenticator)
+		authenticator.AuthenticateReturns(proxy.Instance{
+			Namespace: "space-ns",
+			PodName:   "my-pod",
+			Container: "application",
+		}, nil)
+
+		executor = new(fake.Executor)
+		executor.ExecStub = func(_ context.Context, _ proxy.Instance, opts proxy.ExecOptions) error {
+			_, err := fmt.Fprintf(opts.Stdout, "hello from %s", opts.Command[len(opts.Command)-1])
+			return err
+		}
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		server := proxy.NewServer(hostKey, authenticator, executor)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(ctx, listener)).To(Succeed())
+		}()
+
+		clientConfig = &ssh.ClientConfig{
+			User:            "cf:my-process/0",
+			Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	dial := func() *ssh.Client {
+		client, err := ssh.Dial("tcp", listener.Addr().String(), clientConfig)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Close)
+		return client
+	}
+
+	It("authenticates the user with the one-time code", func() {
+		dial()
+
+		Expect(authenticator.AuthenticateCallCount()).To(Equal(1))
+		_, actualUser, actualPassword := authenticator.AuthenticateArgsForCall(0)
+		Expect(actualUser).To(Equal("cf:my-process/0"))
+		Expect(actualPassword).To(Equal([]byte("the-code")))
+	})
+
+	It("runs commands in the app instance", func() {
+		session, err := dial().NewSession()
+		Expect(err).NotTo(HaveOccurred())
+
+		output, err := session.Output("echo hi")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(output)).To(Equal("hello from echo hi"))
+
+		Expect(executor.ExecCallCount()).To(Equal(1))
+		_, actualInstance, actualOpts := executor.ExecArgsForCall(0)
+		Expect(actualInstance).To(Equal(proxy.Instance{
+			Namespace: "space-ns",
+			PodName:   "my-pod",
+			Container: "application",
+		}))
+		Expect(actualOpts.Command).To(Equal([]string{"/bin/sh", "-c", "echo hi"}))
+		Expect(actualOpts.TTY).To(BeFalse())
+	})
+
+	It("starts a shell with a tty when requested", func() {
+		var terminalSize *remotecommand.TerminalSize
+		executor.ExecStub = func(_ context.Context, _ proxy.Instance, opts proxy.ExecOptions) error {
+			terminalSize = opts.TerminalSizeQueue.Next()
+			return nil
+		}
+
+		session, err := dial().NewSession()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(session.RequestPty("xterm", 40, 80, ssh.TerminalModes{})).To(Succeed())
+		session.Stdin = eofReader{}
+
+		Expect(session.Shell()).To(Succeed())
+		Expect(session.Wait()).To(Succeed())
+
+		Expect(executor.ExecCallCount()).To(Equal(1))
+		_, _, actualOpts := executor.ExecArgsForCall(0)
+		Expect(actualOpts.TTY).To(BeTrue())
+		Expect(actualOpts.Command[0]).To(Equal("/bin/sh"))
+		Expect(terminalSize).To(Equal(&remotecommand.TerminalSize{Width: 80, Height: 40}))
+	})
+
+	When("the command fails", func() {
+		BeforeEach(func() {
+			executor.ExecReturns(utilexec.CodeExitError{Err: errors.New("failed"), Code: 42})
+		})
+
+		It("returns its exit status", func() {
+			session, err := dial().NewSession()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = session.Run("false")
+			var exitErr *ssh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(42))
+		})
+	})
+
+	When("the exec fails", func() {
+		BeforeEach(func() {
+			executor.ExecReturns(errors.New("boom"))
+		})
+
+		It("reports the error and exits with status 1", func() {
+			session, err := dial().NewSession()
+			Expect(err).NotTo(HaveOccurred())
+
+			output, err := session.CombinedOutput("true")
+			var exitErr *ssh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(1))
+			Expect(string(output)).To(ContainSubstring("boom"))
+		})
+	})
+
+	When("authentication fails", func() {
+		BeforeEach(func() {
+			authenticator.AuthenticateReturns(proxy.Instance{}, errors.New("invalid code"))
+		})
+
+		It("rejects the connection", func() {
+			_, err := ssh.Dial("tcp", listener.Addr().String(), clientConfig)
+			Expect(err).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	It("does not support port forwarding", func() {
+		_, err := dial().Dial("tcp", "127.0.0.1:8080")
+		Expect(err).To(MatchError(ContainSubstring("unknown channel type")))
+	})
+})
+
+type eofReader struct{}
+
+func (eofReader) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const targetPrefix = "cf:"
+
+// Target identifies the app instance a user wants to SSH into. The cf CLI
+// passes it as the SSH user in the `cf:<process-guid>/<index>` format.
+type Target struct {
+	ProcessGUID string
+	Index       int
+}
+
+func ParseTarget(user string) (Target, error) {
+	processGUID, index, found := strings.Cut(strings.TrimPrefix(user, targetPrefix), "/")
+	if !strings.HasPrefix(user, targetPrefix) || !found || processGUID == "" {
+		return Target{}, fmt.Errorf("invalid target %q: expected cf:<process-guid>/<index>", user)
+	}
+
+	instanceIndex, err := strconv.Atoi(index)
+	if err != nil || instanceIndex < 0 {
+		return Target{}, fmt.Errorf("invalid instance index %q", index)
+	}
+
+	return Target{
+		ProcessGUID: processGUID,
+		Index:       instanceIndex,
+	}, nil
+}
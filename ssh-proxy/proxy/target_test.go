@@ -0,0 +1,28 @@
+package proxy_test
+
+import (
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseTarget", func() {
+	It("parses the process guid and the instance index", func() {
+		target, err := proxy.ParseTarget("cf:my-process/2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(target).To(Equal(proxy.Target{ProcessGUID: "my-process", Index: 2}))
+	})
+
+	DescribeTable("invalid targets",
+		func(user string) {
+			_, err := proxy.ParseTarget(user)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("missing prefix", "my-process/2"),
+		Entry("missing index", "cf:my-process"),
+		Entry("empty process guid", "cf:/2"),
+		Entry("non numeric index", "cf:my-process/two"),
+		Entry("negative index", "cf:my-process/-1"),
+	)
+})
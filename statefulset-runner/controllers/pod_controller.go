@@ -0,0 +1,231 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	AnnotationLoggedNodeName     = "korifi.cloudfoundry.org/logged-node-name"
+	AnnotationLoggedRestartCount = "korifi.cloudfoundry.org/logged-restart-count"
+	AnnotationLoggedStopping     = "korifi.cloudfoundry.org/logged-stopping"
+)
+
+// PodReconciler records app log events about the lifecycle of the app
+// instances run by the statefulsets, i.e. when they are placed on a node,
+// crash, or are stopped or rescheduled. The events that have been recorded are
+// tracked via annotations on the pods.
+type PodReconciler struct {
+	k8sClient        client.Client
+	logEventRecorder *shared.AppLogEventRecorder
+	log              logr.Logger
+}
+
+func NewPodReconciler(
+	c client.Client,
+	eventRecorder record.EventRecorder,
+	log logr.Logger,
+) *PodReconciler {
+	return &PodReconciler{
+		k8sClient:        c,
+		logEventRecorder: shared.NewAppLogEventRecorder(eventRecorder),
+		log:              log,
+	}
+}
+
+func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// ignoring error as this construction is not dynamic
+	labelSelector, _ := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      LabelAppWorkloadGUID,
+				Operator: metav1.LabelSelectorOpExists,
+				Values:   []string{},
+			},
+		},
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}).
+		Named("app_pod").
+		WithEventFilter(labelSelector).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *PodReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := r.log.WithName("AppPod").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name).
+		WithValues("logID", uuid.NewString())
+
+	pod := &corev1.Pod{}
+	err := r.k8sClient.Get(ctx, req.NamespacedName, pod)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch pod", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	stopReason, err := r.stopReason(ctx, pod)
+	if err != nil {
+		log.Info("unable to determine why the pod is stopping", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	err = k8s.PatchResource(ctx, r.k8sClient, pod, func() {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+
+		r.recordPlacement(pod)
+		r.recordCrash(pod)
+		r.recordStopping(pod, stopReason)
+	})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to patch pod", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *PodReconciler) recordPlacement(pod *corev1.Pod) {
+	if pod.Spec.NodeName == "" || pod.Annotations[AnnotationLoggedNodeName] == pod.Spec.NodeName {
+		return
+	}
+	pod.Annotations[AnnotationLoggedNodeName] = pod.Spec.NodeName
+
+	r.recordEvent(pod, korifiv1alpha1.AppLogSourceTypeCell, corev1.EventTypeNormal, "InstanceCreating",
+		"Cell %s creating container for instance %s", pod.Spec.NodeName, pod.UID)
+}
+
+// recordCrash records an event when the application container has been
+// restarted since the last reconcile. The restarts that happened before the pod
+// was first reconciled are not recorded.
+func (r *PodReconciler) recordCrash(pod *corev1.Pod) {
+	status, ok := applicationContainerStatus(pod)
+	if !ok {
+		return
+	}
+
+	loggedRestartCount, err := strconv.ParseInt(pod.Annotations[AnnotationLoggedRestartCount], 10, 32)
+	pod.Annotations[AnnotationLoggedRestartCount] = strconv.Itoa(int(status.RestartCount))
+	if err != nil || status.RestartCount <= int32(loggedRestartCount) {
+		return
+	}
+
+	exitDescription := "Process has crashed"
+	crashTimestamp := metav1.Now()
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		exitDescription = fmt.Sprintf("Process has crashed with exit status %d", terminated.ExitCode)
+		if terminated.Reason == "OOMKilled" {
+			exitDescription += " (out of memory)"
+		}
+		crashTimestamp = terminated.FinishedAt
+	}
+
+	r.recordEvent(pod, korifiv1alpha1.AppLogSourceTypeAPI, corev1.EventTypeWarning, "InstanceCrashed",
+		`App instance exited with guid %s payload: {"instance"=>"%s", "index"=>%s, "cell_id"=>"%s", "reason"=>"CRASHED", "exit_description"=>"%s", "crash_count"=>%d, "crash_timestamp"=>%d, "version"=>"%s"}`,
+		pod.Labels[LabelAppGUID],
+		pod.UID,
+		pod.Labels[korifiv1alpha1.PodIndexLabelKey],
+		pod.Spec.NodeName,
+		exitDescription,
+		status.RestartCount,
+		crashTimestamp.UnixNano(),
+		pod.Labels[LabelVersion],
+	)
+}
+
+func (r *PodReconciler) recordStopping(pod *corev1.Pod, stopReason string) {
+	if stopReason == "" || pod.Annotations[AnnotationLoggedStopping] != "" {
+		return
+	}
+	pod.Annotations[AnnotationLoggedStopping] = stopReason
+
+	if stopReason == "rescheduling" {
+		r.recordEvent(pod, korifiv1alpha1.AppLogSourceTypeCell, corev1.EventTypeNormal, "InstanceRescheduling",
+			"Cell %s requesting replacement for instance %s", pod.Spec.NodeName, pod.UID)
+		return
+	}
+
+	r.recordEvent(pod, korifiv1alpha1.AppLogSourceTypeCell, corev1.EventTypeNormal, "InstanceStopping",
+		"Cell %s stopping instance %s", pod.Spec.NodeName, pod.UID)
+}
+
+// stopReason returns why a terminating pod is stopping: pods that are still
+// desired by their workload are being rescheduled (e.g. because their node is
+// drained), while all the others have been scaled down or stopped
+func (r *PodReconciler) stopReason(ctx context.Context, pod *corev1.Pod) (string, error) {
+	if pod.DeletionTimestamp.IsZero() {
+		return "", nil
+	}
+
+	index, err := strconv.Atoi(pod.Labels[korifiv1alpha1.PodIndexLabelKey])
+	if err != nil {
+		return "stopping", nil
+	}
+
+	appWorkload := &korifiv1alpha1.AppWorkload{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[LabelAppWorkloadGUID]}, appWorkload)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "stopping", nil
+		}
+		return "", err
+	}
+
+	if !appWorkload.DeletionTimestamp.IsZero() || int32(index) >= appWorkload.Spec.Instances {
+		return "stopping", nil
+	}
+
+	return "rescheduling", nil
+}
+
+func (r *PodReconciler) recordEvent(pod *corev1.Pod, sourceType, eventType, reason, messageFmt string, args ...any) {
+	r.logEventRecorder.Eventf(
+		pod,
+		pod.Labels[LabelAppGUID],
+		sourceType,
+		pod.Labels[korifiv1alpha1.PodIndexLabelKey],
+		eventType,
+		reason,
+		messageFmt,
+		args...,
+	)
+}
+
+func applicationContainerStatus(pod *corev1.Pod) (corev1.ContainerStatus, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == ApplicationContainerName {
+			return status, true
+		}
+	}
+
+	return corev1.ContainerStatus{}, false
+}
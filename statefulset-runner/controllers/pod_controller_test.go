@@ -0,0 +1,280 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+	"code.cloudfoundry.org/korifi/statefulset-runner/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Pod Reconcile", func() {
+	var (
+		reconciler        *controllers.PodReconciler
+		eventRecorder     *fake.EventRecorder
+		reconcileErr      error
+		pod               *corev1.Pod
+		appWorkload       *korifiv1alpha1.AppWorkload
+		getAppWorkloadErr error
+	)
+
+	BeforeEach(func() {
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-pod",
+				Namespace: "my-ns",
+				UID:       "my-pod-uid",
+				Labels: map[string]string{
+					controllers.LabelAppGUID:         "my-app",
+					controllers.LabelAppWorkloadGUID: "my-workload",
+					controllers.LabelVersion:         "2",
+					korifiv1alpha1.PodIndexLabelKey:  "1",
+				},
+			},
+		}
+
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				Instances: 2,
+			},
+		}
+		getAppWorkloadErr = nil
+
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *corev1.Pod:
+				pod.DeepCopyInto(obj)
+				return nil
+			case *korifiv1alpha1.AppWorkload:
+				appWorkload.DeepCopyInto(obj)
+				return getAppWorkloadErr
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		eventRecorder = new(fake.EventRecorder)
+		reconciler = controllers.NewPodReconciler(
+			fakeClient,
+			eventRecorder,
+			ctrl.Log.WithName("controllers").WithName("TestPod"),
+		)
+	})
+
+	JustBeforeEach(func() {
+		_, reconcileErr = reconciler.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: types.NamespacedName{Namespace: "my-ns", Name: "my-pod"},
+		})
+	})
+
+	patchedPod := func() *corev1.Pod {
+		GinkgoHelper()
+
+		Expect(fakeClient.PatchCallCount()).To(Equal(1))
+		_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+		patched, ok := obj.(*corev1.Pod)
+		Expect(ok).To(BeTrue())
+		return patched
+	}
+
+	It("does not record events for a pod that has not been scheduled", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+		Expect(eventRecorder.AnnotatedEventfCallCount()).To(BeZero())
+	})
+
+	When("the pod is scheduled", func() {
+		BeforeEach(func() {
+			pod.Spec.NodeName = "my-node"
+		})
+
+		It("records a cell event", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(eventRecorder.AnnotatedEventfCallCount()).To(Equal(1))
+
+			obj, annotations, eventType, reason, messageFmt, args := eventRecorder.AnnotatedEventfArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&corev1.Pod{}))
+			Expect(annotations).To(Equal(map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:             "my-app",
+				korifiv1alpha1.AppLogSourceTypeAnnotationKey: "CELL",
+				korifiv1alpha1.AppLogInstanceIDAnnotationKey: "1",
+			}))
+			Expect(eventType).To(Equal(corev1.EventTypeNormal))
+			Expect(reason).To(Equal("InstanceCreating"))
+			Expect(messageFmt).To(Equal("Cell %s creating container for instance %s"))
+			Expect(args).To(ConsistOf("my-node", types.UID("my-pod-uid")))
+		})
+
+		It("remembers the node the placement has been logged for", func() {
+			Expect(patchedPod().Annotations).To(HaveKeyWithValue(controllers.AnnotationLoggedNodeName, "my-node"))
+		})
+
+		When("the placement has already been logged", func() {
+			BeforeEach(func() {
+				pod.Annotations = map[string]string{
+					controllers.AnnotationLoggedNodeName: "my-node",
+				}
+			})
+
+			It("does not record it again", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	When("the application container has a restart count", func() {
+		BeforeEach(func() {
+			pod.Spec.NodeName = "my-node"
+			pod.Annotations = map[string]string{
+				controllers.AnnotationLoggedNodeName: "my-node",
+			}
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:         controllers.ApplicationContainerName,
+				RestartCount: 3,
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 137,
+						Reason:   "OOMKilled",
+					},
+				},
+			}}
+		})
+
+		It("remembers the restart count without recording a crash", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(eventRecorder.AnnotatedEventfCallCount()).To(BeZero())
+			Expect(patchedPod().Annotations).To(HaveKeyWithValue(controllers.AnnotationLoggedRestartCount, "3"))
+		})
+
+		When("the container has restarted since the last reconcile", func() {
+			BeforeEach(func() {
+				pod.Annotations[controllers.AnnotationLoggedRestartCount] = "2"
+			})
+
+			It("records an API crash event", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(Equal(1))
+
+				_, annotations, eventType, reason, messageFmt, args := eventRecorder.AnnotatedEventfArgsForCall(0)
+				Expect(annotations).To(HaveKeyWithValue(korifiv1alpha1.AppLogSourceTypeAnnotationKey, "API"))
+				Expect(eventType).To(Equal(corev1.EventTypeWarning))
+				Expect(reason).To(Equal("InstanceCrashed"))
+				Expect(messageFmt).To(HavePrefix("App instance exited with guid %s"))
+				Expect(args).To(ContainElements("my-app", "Process has crashed with exit status 137 (out of memory)", int32(3), "2"))
+			})
+
+			It("remembers the new restart count", func() {
+				Expect(patchedPod().Annotations).To(HaveKeyWithValue(controllers.AnnotationLoggedRestartCount, "3"))
+			})
+		})
+
+		When("the crash has already been logged", func() {
+			BeforeEach(func() {
+				pod.Annotations[controllers.AnnotationLoggedRestartCount] = "3"
+			})
+
+			It("does not record it again", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	When("the pod is terminating", func() {
+		BeforeEach(func() {
+			pod.Spec.NodeName = "my-node"
+			pod.Annotations = map[string]string{
+				controllers.AnnotationLoggedNodeName: "my-node",
+			}
+			pod.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		})
+
+		It("records a rescheduling cell event, as the instance is still desired", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(eventRecorder.AnnotatedEventfCallCount()).To(Equal(1))
+
+			_, annotations, _, reason, messageFmt, args := eventRecorder.AnnotatedEventfArgsForCall(0)
+			Expect(annotations).To(HaveKeyWithValue(korifiv1alpha1.AppLogSourceTypeAnnotationKey, "CELL"))
+			Expect(reason).To(Equal("InstanceRescheduling"))
+			Expect(messageFmt).To(Equal("Cell %s requesting replacement for instance %s"))
+			Expect(args).To(ConsistOf("my-node", types.UID("my-pod-uid")))
+
+			Expect(patchedPod().Annotations).To(HaveKeyWithValue(controllers.AnnotationLoggedStopping, "rescheduling"))
+		})
+
+		When("the app has been scaled down", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Instances = 1
+			})
+
+			It("records a stopping cell event", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(Equal(1))
+
+				_, _, _, reason, messageFmt, _ := eventRecorder.AnnotatedEventfArgsForCall(0)
+				Expect(reason).To(Equal("InstanceStopping"))
+				Expect(messageFmt).To(Equal("Cell %s stopping instance %s"))
+			})
+		})
+
+		When("the app workload has been deleted", func() {
+			BeforeEach(func() {
+				getAppWorkloadErr = k8serrors.NewNotFound(schema.GroupResource{}, "my-workload")
+			})
+
+			It("records a stopping cell event", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(Equal(1))
+
+				_, _, _, reason, _, _ := eventRecorder.AnnotatedEventfArgsForCall(0)
+				Expect(reason).To(Equal("InstanceStopping"))
+			})
+		})
+
+		When("getting the app workload fails", func() {
+			BeforeEach(func() {
+				getAppWorkloadErr = errors.New("get-workload-error")
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("get-workload-error"))
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(BeZero())
+			})
+		})
+
+		When("the stopping has already been logged", func() {
+			BeforeEach(func() {
+				pod.Annotations[controllers.AnnotationLoggedStopping] = "rescheduling"
+			})
+
+			It("does not record it again", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(eventRecorder.AnnotatedEventfCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	When("the pod does not exist", func() {
+		BeforeEach(func() {
+			fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "my-pod"))
+			fakeClient.GetStub = nil
+		})
+
+		It("does nothing", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.PatchCallCount()).To(BeZero())
+		})
+	})
+})
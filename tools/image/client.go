@@ -1,7 +1,11 @@
 package image
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +17,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -67,6 +72,32 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 		return "", fmt.Errorf("failed to create a layer out of '%s': %w", tmpFile.Name(), err)
 	}
 
+	return c.pushLayer(ctx, creds, repoRef, layer, tags...)
+}
+
+// PushTarball pushes a single layer image built from a tar archive, which may
+// be gzip compressed, and returns the reference of the image with its digest
+func (c Client) PushTarball(ctx context.Context, creds Creds, repoRef string, tarReader io.Reader, tags ...string) (string, error) {
+	tmpFile, err := os.CreateTemp(os.TempDir(), "tarballimg-%s")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for image: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err = io.Copy(tmpFile, tarReader); err != nil {
+		return "", fmt.Errorf("failed to copy image tarball into temp file '%s' %w", tmpFile.Name(), err)
+	}
+
+	layer, err := tarball.LayerFromFile(tmpFile.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to create a layer out of '%s': %w", tmpFile.Name(), err)
+	}
+
+	return c.pushLayer(ctx, creds, repoRef, layer, tags...)
+}
+
+func (c Client) pushLayer(ctx context.Context, creds Creds, repoRef string, layer v1.Layer, tags ...string) (string, error) {
 	image, err := mutate.AppendLayers(empty.Image, layer)
 	if err != nil {
 		return "", fmt.Errorf("failed to append layer: %w", err)
@@ -106,6 +137,165 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 	return refWithDigest.Name(), nil
 }
 
+// Pull fetches an image pushed by Push and returns its content as a zip
+// archive. The archive is built while it is being read.
+func (c Client) Pull(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	zipReader, zipWriter := io.Pipe()
+	go func() {
+		tarReader := mutate.Extract(img)
+		defer tarReader.Close()
+
+		zipWriter.CloseWithError(tarToZip(tarReader, zipWriter))
+	}()
+
+	return zipReader, nil
+}
+
+// PullTarball fetches an image and returns its flattened filesystem as a
+// gzip compressed tar archive. The archive is built while it is being read.
+func (c Client) PullTarball(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	tgzReader, tgzWriter := io.Pipe()
+	go func() {
+		tarReader := mutate.Extract(img)
+		defer tarReader.Close()
+
+		tgzWriter.CloseWithError(compress(tarReader, tgzWriter))
+	}()
+
+	return tgzReader, nil
+}
+
+// Copy pushes the image to another repository and tags it there, returning the
+// reference of the copy with its digest
+func (c Client) Copy(ctx context.Context, creds Creds, srcImageRef string, dstRepoRef string, tags ...string) (string, error) {
+	srcRef, err := name.ParseReference(srcImageRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", srcImageRef, err)
+	}
+
+	dstRef, err := name.ParseReference(dstRepoRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", dstRepoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(srcRef, authOpt, remote.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to get image: %w", err)
+	}
+
+	if err = remote.Write(dstRef, img, authOpt); err != nil {
+		return "", fmt.Errorf("failed to copy image: %w", err)
+	}
+
+	for _, tag := range tags {
+		err = remote.Tag(dstRef.Context().Tag(tag), img, authOpt)
+		if err != nil {
+			return "", fmt.Errorf("failed to tag image: %w", err)
+		}
+	}
+
+	imgDigest, err := img.Digest()
+	if err != nil {
+		return "", fmt.Errorf("failed to get image digest: %w", err)
+	}
+
+	return dstRef.Context().Digest(imgDigest.String()).Name(), nil
+}
+
+func compress(r io.Reader, w io.Writer) error {
+	gzipWriter := gzip.NewWriter(w)
+	if _, err := io.Copy(gzipWriter, r); err != nil {
+		return fmt.Errorf("failed to compress image content: %w", err)
+	}
+
+	return gzipWriter.Close()
+}
+
+func tarToZip(tarReader io.Reader, w io.Writer) error {
+	archiveReader := tar.NewReader(tarReader)
+	archiveWriter := zip.NewWriter(w)
+
+	for {
+		header, err := archiveReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read image content: %w", err)
+		}
+
+		// Push stores the zip entries under the root directory
+		entryName := strings.TrimPrefix(header.Name, "/")
+		if entryName == "" || entryName == "." {
+			continue
+		}
+
+		entryHeader, err := zip.FileInfoHeader(header.FileInfo())
+		if err != nil {
+			return fmt.Errorf("failed to create zip entry for %s: %w", entryName, err)
+		}
+		entryHeader.Name = entryName
+		entryHeader.Method = zip.Deflate
+		if header.Typeflag == tar.TypeDir {
+			entryHeader.Name = strings.TrimSuffix(entryName, "/") + "/"
+			entryHeader.Method = zip.Store
+		}
+
+		entryWriter, err := archiveWriter.CreateHeader(entryHeader)
+		if err != nil {
+			return fmt.Errorf("failed to create zip entry for %s: %w", entryName, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeReg:
+			if _, err = io.Copy(entryWriter, archiveReader); err != nil { // #nosec G110
+				return fmt.Errorf("failed to write zip entry for %s: %w", entryName, err)
+			}
+		case tar.TypeSymlink:
+			if _, err = io.WriteString(entryWriter, header.Linkname); err != nil {
+				return fmt.Errorf("failed to write zip entry for %s: %w", entryName, err)
+			}
+		}
+	}
+
+	return archiveWriter.Close()
+}
+
 func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Config, error) {
 	ref, err := name.ParseReference(imageRef)
 	if err != nil {
@@ -1,7 +1,13 @@
 package image_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
@@ -129,6 +135,229 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("PushTarball", func() {
+		var tarballContent []byte
+
+		BeforeEach(func() {
+			tarballContent = gzipped(tarball("app/run.sh", "echo hello"))
+		})
+
+		JustBeforeEach(func() {
+			imgRef, testErr = imgClient.PushTarball(ctx, creds, pushRef, bytes.NewReader(tarballContent), "jim")
+		})
+
+		It("pushes the tarball as an image to the registry", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(imgRef).To(HavePrefix(pushRef + "@sha256:"))
+
+			_, err := imgClient.Config(ctx, creds, imgRef)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = imgClient.Config(ctx, creds, pushRef+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the tarball is not compressed", func() {
+			BeforeEach(func() {
+				tarballContent = tarball("app/run.sh", "echo hello")
+			})
+
+			It("pushes the tarball as an image to the registry", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(imgRef).To(HavePrefix(pushRef + "@sha256:"))
+			})
+		})
+
+		When("pushRef is invalid", func() {
+			BeforeEach(func() {
+				pushRef += ":bar:baz"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("Unauthorized")))
+			})
+		})
+	})
+
+	Describe("PullTarball", func() {
+		var (
+			tgzContent []byte
+			pullRef    string
+		)
+
+		BeforeEach(func() {
+			var err error
+			pullRef, err = imgClient.PushTarball(ctx, creds, pushRef, bytes.NewReader(gzipped(tarball("app/run.sh", "echo hello"))))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var tgzReader io.ReadCloser
+			tgzReader, testErr = imgClient.PullTarball(ctx, creds, pullRef)
+			if testErr != nil {
+				return
+			}
+			defer tgzReader.Close()
+
+			var err error
+			tgzContent, err = io.ReadAll(tgzReader)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the image filesystem as a compressed tarball", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			gzipReader, err := gzip.NewReader(bytes.NewReader(tgzContent))
+			Expect(err).NotTo(HaveOccurred())
+			tarReader := tar.NewReader(gzipReader)
+
+			header, err := tarReader.Next()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header.Name).To(Equal("app/run.sh"))
+			Expect(io.ReadAll(tarReader)).To(Equal([]byte("echo hello")))
+
+			_, err = tarReader.Next()
+			Expect(err).To(MatchError(io.EOF))
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				pullRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
+	Describe("Pull", func() {
+		var (
+			zipContent []byte
+			pullRef    string
+		)
+
+		BeforeEach(func() {
+			var err error
+			pullRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var zipReader io.ReadCloser
+			zipReader, testErr = imgClient.Pull(ctx, creds, pullRef)
+			if testErr != nil {
+				return
+			}
+			defer zipReader.Close()
+
+			var err error
+			zipContent, err = io.ReadAll(zipReader)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the pushed content as a zip archive", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+
+			archive, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(archive.File).To(HaveLen(1))
+			Expect(archive.File[0].Name).To(Equal("foo"))
+
+			file, err := archive.File[0].Open()
+			Expect(err).NotTo(HaveOccurred())
+			defer file.Close()
+
+			expectedArchive, err := zip.OpenReader("fixtures/layer.zip")
+			Expect(err).NotTo(HaveOccurred())
+			defer expectedArchive.Close()
+			expectedFile, err := expectedArchive.File[0].Open()
+			Expect(err).NotTo(HaveOccurred())
+			defer expectedFile.Close()
+
+			Expect(io.ReadAll(file)).To(Equal(must(io.ReadAll(expectedFile))))
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				pullRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var (
+			srcRef  string
+			copyRef string
+		)
+
+		BeforeEach(func() {
+			var err error
+			srcRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			copyRef, testErr = imgClient.Copy(ctx, creds, srcRef, containerRegistry.ImageRef("foo/copy"), "jim")
+		})
+
+		It("copies the image to the other repository", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(copyRef).To(HavePrefix(containerRegistry.ImageRef("foo/copy") + "@sha256:"))
+			Expect(strings.Split(copyRef, "@")[1]).To(Equal(strings.Split(srcRef, "@")[1]))
+
+			_, err := imgClient.Config(ctx, creds, copyRef)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = imgClient.Config(ctx, creds, containerRegistry.ImageRef("foo/copy")+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the source image does not exist", func() {
+			BeforeEach(func() {
+				srcRef = containerRegistry.ImageRef("foo/does-not-exist")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+	})
+
 	Describe("Config", func() {
 		var config image.Config
 
@@ -375,3 +604,38 @@ var _ = Describe("Client", func() {
 		})
 	}
 })
+
+func must[T any](value T, err error) T {
+	GinkgoHelper()
+
+	Expect(err).NotTo(HaveOccurred())
+	return value
+}
+
+func tarball(fileName, content string) []byte {
+	GinkgoHelper()
+
+	buf := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(buf)
+	Expect(tarWriter.WriteHeader(&tar.Header{
+		Name:     fileName,
+		Mode:     0o755,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	})).To(Succeed())
+	Expect(tarWriter.Write([]byte(content))).To(Equal(len(content)))
+	Expect(tarWriter.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
+
+func gzipped(content []byte) []byte {
+	GinkgoHelper()
+
+	buf := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buf)
+	Expect(gzipWriter.Write(content)).To(Equal(len(content)))
+	Expect(gzipWriter.Close()).To(Succeed())
+
+	return buf.Bytes()
+}